@@ -37,15 +37,38 @@ func (adapt *Adapter) FromJobProto(spec *pb.JobSpecification) (models.JobSpec, e
 		}
 		endDate = &end
 	}
+	if _, err := (models.JobSpecSchedule{Timezone: spec.Timezone}).Location(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "invalid schedule of job %s", spec.Name)
+	}
 
 	// prep dirty dependencies
 	dependencies := map[string]models.JobSpecDependency{}
+	externalDependencies := models.ExternalDependency{}
 	for _, dep := range spec.Dependencies {
+		if dep.GetHttpDependency() != nil || dep.GetType() == models.JobSpecDependencyTypeHTTP.String() {
+			httpDep := dep.GetHttpDependency()
+			if httpDep == nil {
+				return models.JobSpec{}, errors.Errorf("http dependency %s is not defined", dep.GetName())
+			}
+			externalDependencies.HTTPDependencies = append(externalDependencies.HTTPDependencies, models.HTTPDependency{
+				Name:           dep.GetName(),
+				URL:            httpDep.GetUrl(),
+				Method:         httpDep.GetMethod(),
+				Headers:        httpDep.GetHeaders(),
+				ExpectedStatus: int(httpDep.GetExpectedStatus()),
+				ExpectedBody:   httpDep.GetExpectedBody(),
+			}.WithDefaults())
+			continue
+		}
 		dependencies[dep.GetName()] = models.JobSpecDependency{
 			Type: models.JobSpecDependencyType(dep.GetType()),
 		}
 	}
 
+	if err := externalDependencies.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "invalid dependencies of job %s", spec.Name)
+	}
+
 	window, err := prepareWindow(spec.WindowSize, spec.WindowOffset, spec.WindowTruncateTo)
 	if err != nil {
 		return models.JobSpec{}, err
@@ -74,6 +97,7 @@ func (adapt *Adapter) FromJobProto(spec *pb.JobSpecification) (models.JobSpec, e
 	retryCount := 0
 	retryExponentialBackoff := false
 	var notifiers []models.JobSpecNotifier
+	sla := models.JobSpecSLA{}
 	if spec.Behavior != nil {
 		if spec.Behavior.Retry != nil {
 			retryCount = int(spec.Behavior.Retry.Count)
@@ -90,6 +114,26 @@ func (adapt *Adapter) FromJobProto(spec *pb.JobSpecification) (models.JobSpec, e
 				Channels: notify.Channels,
 			})
 		}
+
+		if spec.Behavior.Sla != nil && spec.Behavior.Sla.Duration != nil && spec.Behavior.Sla.Duration.IsValid() {
+			sla.Duration = spec.Behavior.Sla.Duration.AsDuration()
+		}
+	}
+	retry := models.JobSpecBehaviorRetry{
+		Count:              retryCount,
+		Delay:              retryDelay,
+		ExponentialBackoff: retryExponentialBackoff,
+	}
+	if err := retry.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "invalid behavior of job %s", spec.Name)
+	}
+	if err := sla.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "invalid behavior of job %s", spec.Name)
+	}
+	for _, notifier := range notifiers {
+		if err := notifier.Validate(); err != nil {
+			return models.JobSpec{}, errors.Wrapf(err, "invalid behavior of job %s", spec.Name)
+		}
 	}
 	return models.JobSpec{
 		Version:     int(spec.Version),
@@ -101,25 +145,24 @@ func (adapt *Adapter) FromJobProto(spec *pb.JobSpecification) (models.JobSpec, e
 			Interval:  spec.Interval,
 			StartDate: startDate,
 			EndDate:   endDate,
+			Timezone:  spec.Timezone,
 		},
 		Assets: models.JobAssets{}.FromMap(spec.Assets),
 		Behavior: models.JobSpecBehavior{
 			DependsOnPast: spec.DependsOnPast,
 			CatchUp:       spec.CatchUp,
-			Retry: models.JobSpecBehaviorRetry{
-				Count:              retryCount,
-				Delay:              retryDelay,
-				ExponentialBackoff: retryExponentialBackoff,
-			},
-			Notify: notifiers,
+			Retry:         retry,
+			Notify:        notifiers,
+			SLA:           sla,
 		},
 		Task: models.JobSpecTask{
 			Unit:   execUnit,
 			Config: taskConfigs,
 			Window: window,
 		},
-		Dependencies: dependencies,
-		Hooks:        hooks,
+		Dependencies:         dependencies,
+		Hooks:                hooks,
+		ExternalDependencies: externalDependencies,
 	}, nil
 }
 
@@ -177,6 +220,7 @@ func (adapt *Adapter) ToJobProto(spec models.JobSpec) (*pb.JobSpecification, err
 		Owner:            spec.Owner,
 		Interval:         spec.Schedule.Interval,
 		StartDate:        spec.Schedule.StartDate.Format(models.JobDatetimeLayout),
+		Timezone:         spec.Schedule.Timezone,
 		DependsOnPast:    spec.Behavior.DependsOnPast,
 		CatchUp:          spec.Behavior.CatchUp,
 		TaskName:         taskSchema.Name,
@@ -195,6 +239,9 @@ func (adapt *Adapter) ToJobProto(spec models.JobSpec) (*pb.JobSpecification, err
 				ExponentialBackoff: spec.Behavior.Retry.ExponentialBackoff,
 			},
 			Notify: notifyProto,
+			Sla: &pb.JobSpecification_Behavior_SLA{
+				Duration: ptypes.DurationProto(spec.Behavior.SLA.Duration),
+			},
 		},
 	}
 	if spec.Schedule.EndDate != nil {
@@ -206,6 +253,19 @@ func (adapt *Adapter) ToJobProto(spec models.JobSpec) (*pb.JobSpecification, err
 			Type: dep.Type.String(),
 		})
 	}
+	for _, dep := range spec.ExternalDependencies.HTTPDependencies {
+		conf.Dependencies = append(conf.Dependencies, &pb.JobDependency{
+			Name: dep.Name,
+			Type: models.JobSpecDependencyTypeHTTP.String(),
+			HttpDependency: &pb.HttpDependency{
+				Url:            dep.URL,
+				Method:         dep.Method,
+				Headers:        dep.Headers,
+				ExpectedStatus: int32(dep.ExpectedStatus),
+				ExpectedBody:   dep.ExpectedBody,
+			},
+		})
+	}
 
 	var taskConfigs []*pb.JobConfigItem
 	for _, c := range spec.Task.Config {
@@ -221,8 +281,28 @@ func (adapt *Adapter) ToJobProto(spec models.JobSpec) (*pb.JobSpecification, err
 
 func (adapt *Adapter) ToProjectProto(spec models.ProjectSpec) *pb.ProjectSpecification {
 	return &pb.ProjectSpecification{
-		Name:   spec.Name,
-		Config: spec.Config,
+		Name:      spec.Name,
+		Config:    spec.Config,
+		Scheduler: adapt.toProjectSchedulerProto(spec.Scheduler),
+	}
+}
+
+func (adapt *Adapter) toProjectSchedulerProto(conf models.ProjectSchedulerConfig) *pb.ProjectSpecification_ProjectSchedulerConfig {
+	if conf == (models.ProjectSchedulerConfig{}) {
+		return nil
+	}
+	return &pb.ProjectSpecification_ProjectSchedulerConfig{
+		Type:       conf.Type,
+		Host:       conf.Host,
+		AuthSecret: conf.AuthSecret,
+	}
+}
+
+func (adapt *Adapter) fromProjectSchedulerProto(conf *pb.ProjectSpecification_ProjectSchedulerConfig) models.ProjectSchedulerConfig {
+	return models.ProjectSchedulerConfig{
+		Type:       conf.GetType(),
+		Host:       conf.GetHost(),
+		AuthSecret: conf.GetAuthSecret(),
 	}
 }
 
@@ -232,8 +312,9 @@ func (adapt *Adapter) FromProjectProto(conf *pb.ProjectSpecification) models.Pro
 		pConf[strings.ToUpper(key)] = val
 	}
 	return models.ProjectSpec{
-		Name:   conf.GetName(),
-		Config: pConf,
+		Name:      conf.GetName(),
+		Config:    pConf,
+		Scheduler: adapt.fromProjectSchedulerProto(conf.GetScheduler()),
 	}
 }
 
@@ -246,9 +327,10 @@ func (adapt *Adapter) ToProjectProtoWithSecrets(spec models.ProjectSpec) *pb.Pro
 		})
 	}
 	return &pb.ProjectSpecification{
-		Name:    spec.Name,
-		Config:  spec.Config,
-		Secrets: secrets,
+		Name:      spec.Name,
+		Config:    spec.Config,
+		Scheduler: adapt.toProjectSchedulerProto(spec.Scheduler),
+		Secrets:   secrets,
 	}
 }
 
@@ -272,9 +354,10 @@ func (adapt *Adapter) FromProjectProtoWithSecrets(conf *pb.ProjectSpecification)
 		}
 	}
 	return models.ProjectSpec{
-		Name:   conf.GetName(),
-		Config: pConf,
-		Secret: pSec,
+		Name:      conf.GetName(),
+		Config:    pConf,
+		Scheduler: adapt.fromProjectSchedulerProto(conf.GetScheduler()),
+		Secret:    pSec,
 	}
 }
 
@@ -291,9 +374,10 @@ func (adapt *Adapter) ToProjectProtoWithSecret(spec models.ProjectSpec, pluginTy
 		})
 	}
 	return &pb.ProjectSpecification{
-		Name:    spec.Name,
-		Config:  spec.Config,
-		Secrets: secrets,
+		Name:      spec.Name,
+		Config:    spec.Config,
+		Scheduler: adapt.toProjectSchedulerProto(spec.Scheduler),
+		Secrets:   secrets,
 	}
 }
 
@@ -367,7 +451,13 @@ func (adapt *Adapter) FromInstanceProto(conf *pb.InstanceSpec) (models.InstanceS
 
 func (adapt *Adapter) FromHookProto(hooksProto []*pb.JobSpecHook) ([]models.JobSpecHook, error) {
 	var hooks []models.JobSpecHook
+	hookNames := map[string]bool{}
 	for _, hook := range hooksProto {
+		if hookNames[hook.Name] {
+			return nil, errors.Errorf("hook %s is attached more than once", hook.Name)
+		}
+		hookNames[hook.Name] = true
+
 		hookUnit, err := adapt.supportedHookRepo.GetByName(hook.Name)
 		if err != nil {
 			return nil, err
@@ -445,6 +535,25 @@ func (adapt *Adapter) FromResourceProto(spec *pb.ResourceSpecification, storeNam
 	return typeController.Adapter().FromProtobuf(buf)
 }
 
+func (adapt *Adapter) ToBackupProto(spec models.BackupSpec) (*pb.BackupSpecification, error) {
+	createdAt, err := ptypes.TimestampProto(spec.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := ptypes.TimestampProto(spec.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BackupSpecification{
+		Id:           spec.ID.String(),
+		ResourceName: spec.Resource.Name,
+		Urn:          spec.URN,
+		Description:  spec.Description,
+		CreatedAt:    createdAt,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
 func (adapt *Adapter) ToReplayExecutionTreeNode(res *tree.TreeNode) (*pb.ReplayExecutionTreeNode, error) {
 	response := &pb.ReplayExecutionTreeNode{
 		JobName: res.GetName(),
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
 
 	"github.com/odpf/optimus/mock"
@@ -74,6 +75,9 @@ func TestAdapter(t *testing.T) {
 						Channels: []string{"slack://@devs"},
 					},
 				},
+				SLA: models.JobSpecSLA{
+					Duration: time.Hour * 2,
+				},
 			},
 			Task: models.JobSpecTask{
 				Unit: execUnit1,
@@ -118,6 +122,183 @@ func TestAdapter(t *testing.T) {
 		assert.Equal(t, jobSpec, original)
 		assert.Nil(t, err)
 	})
+	t.Run("should successfully parse job spec end date to and from proto", func(t *testing.T) {
+		execUnit1 := new(mock.TaskPlugin)
+		execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+			Name: "sample-task",
+		}, nil)
+		defer execUnit1.AssertExpectations(t)
+
+		allTasksRepo := new(mock.SupportedTaskRepo)
+		allTasksRepo.On("GetByName", "sample-task").Return(execUnit1, nil)
+		defer allTasksRepo.AssertExpectations(t)
+
+		endDate := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+		jobSpec := models.JobSpec{
+			Name: "test-job",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2021, 10, 6, 0, 0, 0, 0, time.UTC),
+				EndDate:   &endDate,
+				Interval:  "@daily",
+				Timezone:  "Asia/Jakarta",
+			},
+			Task: models.JobSpecTask{
+				Unit: execUnit1,
+			},
+		}
+
+		adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+		inProto, err := adapter.ToJobProto(jobSpec)
+		assert.Nil(t, err)
+		assert.Equal(t, "2021-12-31", inProto.EndDate)
+		assert.Equal(t, "Asia/Jakarta", inProto.Timezone)
+
+		original, err := adapter.FromJobProto(inProto)
+		assert.Nil(t, err)
+		assert.Equal(t, jobSpec.Schedule, original.Schedule)
+	})
+	t.Run("should fail to parse job spec with unknown schedule timezone", func(t *testing.T) {
+		adapter := v1.NewAdapter(new(mock.SupportedTaskRepo), nil, nil)
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			Timezone:  "Mars/Olympus",
+		})
+		assert.Equal(t, "invalid schedule of job test-job: invalid schedule timezone Mars/Olympus: unknown time zone Mars/Olympus", err.Error())
+	})
+	t.Run("should fail to parse job spec with negative retry count", func(t *testing.T) {
+		execUnit1 := new(mock.TaskPlugin)
+		allTasksRepo := new(mock.SupportedTaskRepo)
+		allTasksRepo.On("GetByName", "sample-task").Return(execUnit1, nil)
+		defer allTasksRepo.AssertExpectations(t)
+
+		adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			Behavior: &pb.JobSpecification_Behavior{
+				Retry: &pb.JobSpecification_Behavior_Retry{
+					Count: -1,
+				},
+			},
+		})
+		assert.Equal(t, "invalid behavior of job test-job: retry count cannot be negative: -1", err.Error())
+	})
+	t.Run("should fail to parse job spec with negative sla duration", func(t *testing.T) {
+		execUnit1 := new(mock.TaskPlugin)
+		allTasksRepo := new(mock.SupportedTaskRepo)
+		allTasksRepo.On("GetByName", "sample-task").Return(execUnit1, nil)
+		defer allTasksRepo.AssertExpectations(t)
+
+		adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			Behavior: &pb.JobSpecification_Behavior{
+				Sla: &pb.JobSpecification_Behavior_SLA{
+					Duration: ptypes.DurationProto(-time.Hour),
+				},
+			},
+		})
+		assert.Equal(t, "invalid behavior of job test-job: sla duration cannot be negative: -1h0m0s", err.Error())
+	})
+	t.Run("should fail to parse job spec with malformed notify channel", func(t *testing.T) {
+		execUnit1 := new(mock.TaskPlugin)
+		allTasksRepo := new(mock.SupportedTaskRepo)
+		allTasksRepo.On("GetByName", "sample-task").Return(execUnit1, nil)
+		defer allTasksRepo.AssertExpectations(t)
+
+		adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			Behavior: &pb.JobSpecification_Behavior{
+				Notify: []*pb.JobSpecification_Behavior_Notifiers{
+					{
+						On:       pb.JobEvent_FAILURE,
+						Channels: []string{"#data-alerts"},
+					},
+				},
+			},
+		})
+		assert.Equal(t, "invalid behavior of job test-job: invalid notify channel #data-alerts on failure, expected <type>://<route>", err.Error())
+	})
+	t.Run("should successfully parse job spec http dependencies to and from proto", func(t *testing.T) {
+		execUnit1 := new(mock.TaskPlugin)
+		execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+			Name: "sample-task",
+		}, nil)
+		defer execUnit1.AssertExpectations(t)
+
+		allTasksRepo := new(mock.SupportedTaskRepo)
+		allTasksRepo.On("GetByName", "sample-task").Return(execUnit1, nil)
+		defer allTasksRepo.AssertExpectations(t)
+
+		adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+		original, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			Dependencies: []*pb.JobDependency{
+				{
+					Name: "inventory-api",
+					Type: "http",
+					HttpDependency: &pb.HttpDependency{
+						Url:     "https://inventory.example.io/ready",
+						Headers: map[string]string{"Authorization": "Bearer token"},
+					},
+				},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Empty(t, original.Dependencies)
+		assert.Equal(t, []models.HTTPDependency{
+			{
+				Name:           "inventory-api",
+				URL:            "https://inventory.example.io/ready",
+				Method:         "GET",
+				Headers:        map[string]string{"Authorization": "Bearer token"},
+				ExpectedStatus: 200,
+			},
+		}, original.ExternalDependencies.HTTPDependencies)
+
+		inProto, err := adapter.ToJobProto(original)
+		assert.Nil(t, err)
+		assert.Equal(t, []*pb.JobDependency{
+			{
+				Name: "inventory-api",
+				Type: "http",
+				HttpDependency: &pb.HttpDependency{
+					Url:            "https://inventory.example.io/ready",
+					Method:         "GET",
+					Headers:        map[string]string{"Authorization": "Bearer token"},
+					ExpectedStatus: 200,
+				},
+			},
+		}, inProto.Dependencies)
+	})
+	t.Run("should fail to parse job spec with http dependency missing its definition", func(t *testing.T) {
+		adapter := v1.NewAdapter(nil, nil, nil)
+		_, err := adapter.FromJobProto(&pb.JobSpecification{
+			Name:      "test-job",
+			StartDate: "2021-10-06",
+			Interval:  "@daily",
+			TaskName:  "sample-task",
+			Dependencies: []*pb.JobDependency{
+				{Name: "inventory-api", Type: "http"},
+			},
+		})
+		assert.Equal(t, "http dependency inventory-api is not defined", err.Error())
+	})
 }
 
 func TestAdapter_FromProjectProtoWithSecrets(t *testing.T) {
@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+// AuditedMethods are the rpc methods changing state of optimus, every
+// request to them is recorded as an audit event. Requests made by
+// executors and schedulers while running jobs are not audited
+var AuditedMethods = map[string]bool{
+	"DeployJobSpecification":      true,
+	"CancelDeploy":                true,
+	"QueueJobDeployment":          true,
+	"CreateJobSpecification":      true,
+	"PatchJobSpecification":       true,
+	"DeleteJobSpecification":      true,
+	"RollbackJobSpecification":    true,
+	"RegisterProject":             true,
+	"UpdateProjectConfig":         true,
+	"RegisterProjectNamespace":    true,
+	"RegisterSecret":              true,
+	"DeleteSecret":                true,
+	"RotateSecretKey":             true,
+	"CloneProject":                true,
+	"ImportProject":               true,
+	"RunJob":                      true,
+	"DeployResourceSpecification": true,
+	"CreateResource":              true,
+	"UpdateResource":              true,
+	"RenameResource":              true,
+	"DeleteResource":              true,
+	"BackupResource":              true,
+	"RestoreResource":             true,
+	"Replay":                      true,
+}
+
+// auditSaveTimeout is the time allowed to record an audit event, events
+// are recorded even if the request was cancelled or timed out
+const auditSaveTimeout = 10 * time.Second
+
+// UnaryAuditInterceptor records an audit event of every request to
+// AuditedMethods once it is served, failing to record one is logged and
+// doesn't fail the request. identityHeader is the metadata key the proxy
+// authenticating callers of optimus sets to the caller, it is not read if
+// empty as clients could set it themselves without such a proxy
+func UnaryAuditInterceptor(repo store.AuditEventRepository, identityHeader string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if !AuditedMethods[path.Base(info.FullMethod)] {
+			return handler(ctx, req)
+		}
+		resp, err := handler(ctx, req)
+		saveAuditEvent(ctx, repo, identityHeader, info.FullMethod, req, err)
+		return resp, err
+	}
+}
+
+// StreamAuditInterceptor is UnaryAuditInterceptor for streaming calls, the
+// first message received is recorded as the request
+func StreamAuditInterceptor(repo store.AuditEventRepository, identityHeader string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if !AuditedMethods[path.Base(info.FullMethod)] {
+			return handler(srv, stream)
+		}
+		auditStream := &auditServerStream{ServerStream: stream}
+		err := handler(srv, auditStream)
+		saveAuditEvent(stream.Context(), repo, identityHeader, info.FullMethod, auditStream.req, err)
+		return err
+	}
+}
+
+type auditServerStream struct {
+	grpc.ServerStream
+	req interface{}
+}
+
+func (s *auditServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.req == nil {
+		s.req = m
+	}
+	return nil
+}
+
+func saveAuditEvent(ctx context.Context, repo store.AuditEventRepository, identityHeader, method string, req interface{}, err error) {
+	event := models.AuditEvent{
+		Method:    method,
+		Code:      status.Code(err).String(),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err != nil {
+		event.Message = status.Convert(err).Message()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if identityHeader != "" {
+			if values := md.Get(identityHeader); len(values) > 0 {
+				event.Identity = values[0]
+			}
+		}
+		if values := md.Get("user-agent"); len(values) > 0 {
+			event.UserAgent = values[0]
+		}
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			event.RequestID = values[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		event.Peer = p.Addr.String()
+	}
+	event.ProjectName, event.Namespace = auditScope(req)
+	if msg, ok := req.(proto.Message); ok {
+		if raw, err := (proto.MarshalOptions{Deterministic: true}).Marshal(msg); err == nil {
+			digest := sha256.Sum256(raw)
+			event.RequestDigest = hex.EncodeToString(digest[:])
+		}
+	}
+
+	saveCtx, cancel := context.WithTimeout(context.Background(), auditSaveTimeout)
+	defer cancel()
+	if err := repo.Save(saveCtx, event); err != nil {
+		logger.FromContext(ctx).WithError(err).Errorf("failed to save audit event of %s", method)
+	}
+}
+
+// auditScope picks the project and namespace a request changes
+func auditScope(req interface{}) (projectName, namespace string) {
+	if r, ok := req.(interface{ GetProjectName() string }); ok {
+		projectName = r.GetProjectName()
+	}
+	if r, ok := req.(interface {
+		GetProject() *pb.ProjectSpecification
+	}); ok && projectName == "" {
+		projectName = r.GetProject().GetName()
+	}
+	if r, ok := req.(interface{ GetNamespace() string }); ok {
+		namespace = r.GetNamespace()
+	}
+	if r, ok := req.(interface {
+		GetNamespace() *pb.NamespaceSpecification
+	}); ok {
+		namespace = r.GetNamespace().GetName()
+	}
+	return projectName, namespace
+}
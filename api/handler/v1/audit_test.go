@@ -0,0 +1,143 @@
+package v1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+)
+
+func TestAuditInterceptors(t *testing.T) {
+	t.Run("should record requests to audited methods with their result", func(t *testing.T) {
+		var saved models.AuditEvent
+		repo := new(mock.AuditEventRepository)
+		repo.On("Save", mock2.Anything, mock2.AnythingOfType("models.AuditEvent")).Run(func(args mock2.Arguments) {
+			saved = args.Get(1).(models.AuditEvent)
+		}).Return(nil).Once()
+		defer repo.AssertExpectations(t)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			v1.RequestIDHeader, "req-1",
+			"user-agent", "optimus-cli/0.1",
+		))
+		req := &pb.RegisterSecretRequest{
+			ProjectName: "a-data-project",
+			SecretName:  "STORAGE",
+			Value:       "c2VjcmV0",
+		}
+		_, err := v1.UnaryAuditInterceptor(repo, "")(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/RegisterSecret"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, status.Error(codes.Internal, "failed to save secret")
+			})
+		assert.Equal(t, codes.Internal, status.Code(err))
+
+		assert.Equal(t, "/odpf.optimus.RuntimeService/RegisterSecret", saved.Method)
+		assert.Equal(t, "optimus-cli/0.1", saved.UserAgent)
+		assert.Equal(t, "req-1", saved.RequestID)
+		assert.Equal(t, "a-data-project", saved.ProjectName)
+		assert.Equal(t, "Internal", saved.Code)
+		assert.Equal(t, "failed to save secret", saved.Message)
+		assert.Len(t, saved.RequestDigest, 64)
+		assert.NotContains(t, saved.RequestDigest, "c2VjcmV0")
+		assert.False(t, saved.CreatedAt.IsZero())
+	})
+	t.Run("should read project and namespace of registrations", func(t *testing.T) {
+		var saved models.AuditEvent
+		repo := new(mock.AuditEventRepository)
+		repo.On("Save", mock2.Anything, mock2.AnythingOfType("models.AuditEvent")).Run(func(args mock2.Arguments) {
+			saved = args.Get(1).(models.AuditEvent)
+		}).Return(nil).Once()
+		defer repo.AssertExpectations(t)
+
+		req := &pb.RegisterProjectRequest{
+			Project:   &pb.ProjectSpecification{Name: "a-data-project"},
+			Namespace: &pb.NamespaceSpecification{Name: "game_jam"},
+		}
+		_, err := v1.UnaryAuditInterceptor(repo, "")(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/RegisterProject"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &pb.RegisterProjectResponse{Success: true}, nil
+			})
+		assert.Nil(t, err)
+		assert.Equal(t, "a-data-project", saved.ProjectName)
+		assert.Equal(t, "game_jam", saved.Namespace)
+		assert.Equal(t, "OK", saved.Code)
+	})
+	t.Run("should record the identity only from the configured header", func(t *testing.T) {
+		var saved []models.AuditEvent
+		repo := new(mock.AuditEventRepository)
+		repo.On("Save", mock2.Anything, mock2.AnythingOfType("models.AuditEvent")).Run(func(args mock2.Arguments) {
+			saved = append(saved, args.Get(1).(models.AuditEvent))
+		}).Return(nil).Twice()
+		defer repo.AssertExpectations(t)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"x-auth-user", "jane@example.com",
+			"user-agent", "optimus-cli/0.1",
+		))
+		req := &pb.DeleteSecretRequest{ProjectName: "a-data-project"}
+		info := &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/DeleteSecret"}
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return &pb.DeleteSecretResponse{}, nil
+		}
+		_, err := v1.UnaryAuditInterceptor(repo, "x-auth-user")(ctx, req, info, handler)
+		assert.Nil(t, err)
+		_, err = v1.UnaryAuditInterceptor(repo, "")(ctx, req, info, handler)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "jane@example.com", saved[0].Identity)
+		assert.Equal(t, "", saved[1].Identity)
+		assert.Equal(t, "optimus-cli/0.1", saved[1].UserAgent)
+	})
+	t.Run("should record requests cancelled by the client", func(t *testing.T) {
+		var saveErr error
+		repo := new(mock.AuditEventRepository)
+		repo.On("Save", mock2.Anything, mock2.AnythingOfType("models.AuditEvent")).Run(func(args mock2.Arguments) {
+			saveErr = args.Get(0).(context.Context).Err()
+		}).Return(nil).Once()
+		defer repo.AssertExpectations(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := v1.UnaryAuditInterceptor(repo, "")(ctx, &pb.DeleteSecretRequest{ProjectName: "a-data-project"},
+			&grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/DeleteSecret"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				cancel()
+				return nil, status.FromContextError(ctx.Err()).Err()
+			})
+		assert.Equal(t, codes.Canceled, status.Code(err))
+		assert.Nil(t, saveErr)
+	})
+	t.Run("should not record requests to other methods", func(t *testing.T) {
+		repo := new(mock.AuditEventRepository)
+		defer repo.AssertExpectations(t)
+
+		_, err := v1.UnaryAuditInterceptor(repo, "")(context.Background(), &pb.ReadJobSpecificationRequest{},
+			&grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/ReadJobSpecification"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+		assert.Nil(t, err)
+	})
+	t.Run("should not fail the request if the event can't be saved", func(t *testing.T) {
+		repo := new(mock.AuditEventRepository)
+		repo.On("Save", mock2.Anything, mock2.AnythingOfType("models.AuditEvent")).Return(assert.AnError)
+		defer repo.AssertExpectations(t)
+
+		resp, err := v1.UnaryAuditInterceptor(repo, "")(context.Background(), &pb.DeleteSecretRequest{ProjectName: "a-data-project"},
+			&grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/DeleteSecret"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &pb.DeleteSecretResponse{}, nil
+			})
+		assert.Nil(t, err)
+		assert.NotNil(t, resp)
+	})
+}
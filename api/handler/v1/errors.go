@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"github.com/odpf/optimus/datastore"
+	"github.com/odpf/optimus/instance"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+)
+
+// errorCode maps typed errors of store, job, instance and datastore layers
+// to a grpc code so clients can react to a failure without parsing its
+// message, errors of unknown cause are internal
+func errorCode(err error) codes.Code {
+	var tmplErr *models.TemplateError
+	switch {
+	case errors.Is(err, store.ErrResourceNotFound),
+		errors.Is(err, job.ErrDeploymentNotFound),
+		errors.Is(err, models.ErrJobRunNotFound):
+		return codes.NotFound
+	case errors.Is(err, store.ErrResourceAlreadyExists),
+		errors.Is(err, models.ErrResourceExists):
+		return codes.AlreadyExists
+	case errors.Is(err, job.ErrInvalidJobSpec),
+		errors.Is(err, job.ErrDuplicateDestination),
+		errors.Is(err, datastore.ErrResourceDependencyCycle),
+		errors.Is(err, models.ErrUnsupportedDatastore),
+		errors.As(err, &tmplErr):
+		return codes.InvalidArgument
+	case errors.Is(err, job.ErrMissingProjectConfig),
+		errors.Is(err, job.ErrMissingGlobalConfig),
+		errors.Is(err, job.ErrConflictedJobRun),
+		errors.Is(err, instance.ErrMissingSecret),
+		errors.Is(err, instance.ErrUnsupportedAssetScheme),
+		errors.Is(err, instance.ErrAssetHostNotAllowed),
+		errors.Is(err, instance.ErrAssetTooLarge),
+		errors.Is(err, models.ErrDestructiveChange),
+		errors.Is(err, models.ErrRenameRequiresCopy),
+		errors.Is(err, datastore.ErrBackupExpired):
+		return codes.FailedPrecondition
+	case errors.Is(err, job.ErrRequestQueueFull):
+		return codes.ResourceExhausted
+	case errors.Is(err, datastore.ErrResourceApplyTimeout):
+		return codes.DeadlineExceeded
+	case errors.Is(err, models.ErrJobRunLogsNotSupported):
+		return codes.Unimplemented
+	}
+	return codes.Internal
+}
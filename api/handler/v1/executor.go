@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/odpf/optimus/models"
+)
+
+// ExecutorTokenHeader is the metadata key executors send the project secret
+// models.ProjectSecretExecutorTokenKey in while registering instances
+const ExecutorTokenHeader = "x-optimus-executor-token"
+
+// executorAuthenticated checks if the request carries the executor token
+// of the project, it is never the case for projects without one
+func executorAuthenticated(ctx context.Context, projSpec models.ProjectSpec) bool {
+	token, ok := projSpec.Secret.GetByName(models.ProjectSecretExecutorTokenKey)
+	if !ok || token == "" {
+		return false
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, value := range md.Get(ExecutorTokenHeader) {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// withExecutorSecrets returns the namespace with project secrets only if
+// the request is from an authenticated executor, jobs referring secrets
+// can't be compiled for anyone else
+func withExecutorSecrets(ctx context.Context, namespaceSpec models.NamespaceSpec, jobSpec models.JobSpec) (models.NamespaceSpec, error) {
+	if executorAuthenticated(ctx, namespaceSpec.ProjectSpec) {
+		return namespaceSpec, nil
+	}
+	if refs := jobSpec.SecretReferences(); len(refs) > 0 {
+		return namespaceSpec, status.Errorf(codes.Unauthenticated, "job %s refers secrets %s which are only resolved for executors of the project",
+			jobSpec.Name, strings.Join(refs, ", "))
+	}
+	namespaceSpec.ProjectSpec.Secret = nil
+	return namespaceSpec, nil
+}
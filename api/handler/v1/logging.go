@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/odpf/optimus/core/logger"
+)
+
+// RequestIDHeader is the metadata key a request id is read from, a new id
+// is generated for requests not carrying one. The id is sent back to
+// client in response header
+const RequestIDHeader = "x-request-id"
+
+// UnaryLogInterceptor scopes a logger to every request, the logger is
+// available to handlers through logger.FromContext and includes request
+// id, caller and the project, namespace and job the request is about
+func UnaryLogInterceptor(base logrus.FieldLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = newRequestLogContext(ctx, base, info.FullMethod)
+		ctx = logger.WithFields(ctx, requestLogFields(req))
+		return handler(ctx, req)
+	}
+}
+
+// StreamLogInterceptor is UnaryLogInterceptor for streaming calls, fields
+// of request are added once the request is received
+func StreamLogInterceptor(base logrus.FieldLogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		return handler(srv, &logServerStream{
+			ServerStream: stream,
+			ctx:          newRequestLogContext(stream.Context(), base, info.FullMethod),
+		})
+	}
+}
+
+type logServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *logServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *logServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	s.ctx = logger.WithFields(s.ctx, requestLogFields(m))
+	return nil
+}
+
+func newRequestLogContext(ctx context.Context, base logrus.FieldLogger, method string) context.Context {
+	requestID := ""
+	caller := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		}
+		if values := md.Get("user-agent"); len(values) > 0 {
+			caller = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+	fields := logrus.Fields{
+		"method":              method,
+		logger.FieldRequestID: requestID,
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields["peer"] = p.Addr.String()
+	}
+	if caller != "" {
+		fields[logger.FieldCaller] = caller
+	}
+	return logger.NewContext(ctx, base.WithFields(fields))
+}
+
+// requestLogFields picks the project, namespace and job a request is about
+func requestLogFields(req interface{}) logrus.Fields {
+	fields := logrus.Fields{}
+	if r, ok := req.(interface{ GetProjectName() string }); ok && r.GetProjectName() != "" {
+		fields[logger.FieldProject] = r.GetProjectName()
+	}
+	if r, ok := req.(interface{ GetNamespace() string }); ok && r.GetNamespace() != "" {
+		fields[logger.FieldNamespace] = r.GetNamespace()
+	}
+	if r, ok := req.(interface{ GetJobName() string }); ok && r.GetJobName() != "" {
+		fields[logger.FieldJob] = r.GetJobName()
+	}
+	return fields
+}
@@ -0,0 +1,62 @@
+package v1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/core/logger"
+)
+
+func TestLogInterceptors(t *testing.T) {
+	t.Run("should scope logger of unary request with request fields", func(t *testing.T) {
+		log, hook := logtest.NewNullLogger()
+		interceptor := v1.UnaryLogInterceptor(log)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			v1.RequestIDHeader, "req-1",
+			"user-agent", "optimus-cli/0.1",
+		))
+		req := &pb.ReadJobSpecificationRequest{
+			ProjectName: "a-data-project",
+			Namespace:   "game_jam",
+			JobName:     "transform-tables",
+		}
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/ReadJobSpecification"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				logger.FromContext(ctx).Info("reading job")
+				return nil, nil
+			})
+		assert.Nil(t, err)
+
+		assert.Equal(t, 1, len(hook.AllEntries()))
+		assert.Equal(t, logrus.Fields{
+			"method":              "/odpf.optimus.RuntimeService/ReadJobSpecification",
+			logger.FieldRequestID: "req-1",
+			logger.FieldCaller:    "optimus-cli/0.1",
+			logger.FieldProject:   "a-data-project",
+			logger.FieldNamespace: "game_jam",
+			logger.FieldJob:       "transform-tables",
+		}, hook.LastEntry().Data)
+	})
+	t.Run("should generate request id if not sent by client", func(t *testing.T) {
+		log, hook := logtest.NewNullLogger()
+		interceptor := v1.UnaryLogInterceptor(log)
+
+		_, err := interceptor(context.Background(), &pb.VersionRequest{}, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/Version"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				logger.FromContext(ctx).Info("ping")
+				return nil, nil
+			})
+		assert.Nil(t, err)
+		assert.NotEmpty(t, hook.LastEntry().Data[logger.FieldRequestID])
+		assert.NotContains(t, hook.LastEntry().Data, logger.FieldProject)
+	})
+}
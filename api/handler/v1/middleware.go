@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"context"
+	"path"
+	"runtime/debug"
+	"time"
+
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/odpf/optimus/core/logger"
+)
+
+// optionalProjectNameMethods are the methods where project name of the
+// request is a filter and can be left empty
+var optionalProjectNameMethods = map[string]bool{
+	"ListAuditEvents": true,
+}
+
+// UnaryValidationInterceptor rejects requests with an empty project name or
+// an invalid timestamp with InvalidArgument before they reach the handler
+func UnaryValidationInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validateRequest(info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidationInterceptor is UnaryValidationInterceptor for streaming
+// calls, every message received is validated
+func StreamValidationInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		return handler(srv, &validationServerStream{ServerStream: stream, method: info.FullMethod})
+	}
+}
+
+type validationServerStream struct {
+	grpc.ServerStream
+	method string
+}
+
+func (s *validationServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validateRequest(s.method, m)
+}
+
+func validateRequest(method string, req interface{}) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	reflected := msg.ProtoReflect()
+	if field := reflected.Descriptor().Fields().ByName("project_name"); field != nil &&
+		field.Kind() == protoreflect.StringKind && !optionalProjectNameMethods[path.Base(method)] &&
+		reflected.Get(field).String() == "" {
+		return status.Error(codes.InvalidArgument, "project name is required")
+	}
+	return validateTimestamps(reflected)
+}
+
+// validateTimestamps checks timestamps set in the message and messages
+// nested in it are within the range of valid timestamps
+func validateTimestamps(msg protoreflect.Message) error {
+	var err error
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+			return true
+		}
+		if ts, ok := value.Message().Interface().(*timestamppb.Timestamp); ok {
+			if checkErr := ts.CheckValid(); checkErr != nil {
+				err = status.Errorf(codes.InvalidArgument, "invalid %s: %s", field.Name(), checkErr.Error())
+			}
+		} else {
+			err = validateTimestamps(value.Message())
+		}
+		return err == nil
+	})
+	return err
+}
+
+// RecoveryOption converts a panic while serving a request to an Internal
+// error, the panic is logged with its stack so the server keeps running
+func RecoveryOption() grpc_recovery.Option {
+	return grpc_recovery.WithRecoveryHandlerContext(func(ctx context.Context, p interface{}) error {
+		logger.FromContext(ctx).WithField("stack", string(debug.Stack())).Errorf("panic while serving request: %v", p)
+		return status.Error(codes.Internal, "internal error while serving request")
+	})
+}
+
+// UnaryDeadlineInterceptor bounds the time a request is served for, the
+// deadline sent by client is kept if it is sooner
+func UnaryDeadlineInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
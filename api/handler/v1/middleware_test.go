@@ -0,0 +1,96 @@
+package v1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+)
+
+func TestMiddlewareInterceptors(t *testing.T) {
+	served := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "served", nil
+	}
+	t.Run("ValidationInterceptor", func(t *testing.T) {
+		interceptor := v1.UnaryValidationInterceptor()
+		t.Run("should reject requests without project name", func(t *testing.T) {
+			_, err := interceptor(context.Background(), &pb.ReadJobSpecificationRequest{JobName: "job-1"},
+				&grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/ReadJobSpecification"}, served)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should allow requests without project name where it is a filter", func(t *testing.T) {
+			resp, err := interceptor(context.Background(), &pb.ListAuditEventsRequest{},
+				&grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/ListAuditEvents"}, served)
+			assert.Nil(t, err)
+			assert.Equal(t, "served", resp)
+		})
+		t.Run("should reject requests with invalid timestamps", func(t *testing.T) {
+			_, err := interceptor(context.Background(), &pb.GetWindowRequest{
+				ScheduledAt: &timestamppb.Timestamp{Seconds: 1, Nanos: -1},
+			}, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/GetWindow"}, served)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+			_, err = interceptor(context.Background(), &pb.RegisterInstanceRequest{
+				ProjectName: "a-data-project",
+				ScheduledAt: &timestamppb.Timestamp{Seconds: 1 << 40},
+			}, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/RegisterInstance"}, served)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), "scheduled_at")
+		})
+		t.Run("should pass valid requests to handler", func(t *testing.T) {
+			resp, err := interceptor(context.Background(), &pb.RegisterInstanceRequest{
+				ProjectName: "a-data-project",
+				ScheduledAt: timestamppb.New(time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)),
+			}, &grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/RegisterInstance"}, served)
+			assert.Nil(t, err)
+			assert.Equal(t, "served", resp)
+		})
+	})
+	t.Run("RecoveryOption", func(t *testing.T) {
+		t.Run("should convert a panic to an internal error", func(t *testing.T) {
+			interceptor := grpc_recovery.UnaryServerInterceptor(v1.RecoveryOption())
+			_, err := interceptor(context.Background(), &pb.VersionRequest{},
+				&grpc.UnaryServerInfo{FullMethod: "/odpf.optimus.RuntimeService/Version"},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					var spec *pb.JobSpecification
+					return spec.Name, nil
+				})
+			assert.Equal(t, codes.Internal, status.Code(err))
+		})
+	})
+	t.Run("DeadlineInterceptor", func(t *testing.T) {
+		deadlineOf := func(ctx context.Context, req interface{}) (interface{}, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return nil, nil
+			}
+			return time.Until(deadline), nil
+		}
+		t.Run("should bound requests without a deadline", func(t *testing.T) {
+			resp, err := v1.UnaryDeadlineInterceptor(time.Minute)(context.Background(), nil, &grpc.UnaryServerInfo{}, deadlineOf)
+			assert.Nil(t, err)
+			assert.InDelta(t, time.Minute, resp, float64(time.Second))
+		})
+		t.Run("should keep a sooner deadline of client", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+			defer cancel()
+			resp, err := v1.UnaryDeadlineInterceptor(time.Minute)(ctx, nil, &grpc.UnaryServerInfo{}, deadlineOf)
+			assert.Nil(t, err)
+			assert.InDelta(t, time.Second*10, resp, float64(time.Second))
+		})
+		t.Run("should not bound requests if timeout is not set", func(t *testing.T) {
+			resp, err := v1.UnaryDeadlineInterceptor(0)(context.Background(), nil, &grpc.UnaryServerInfo{}, deadlineOf)
+			assert.Nil(t, err)
+			assert.Nil(t, resp)
+		})
+	})
+}
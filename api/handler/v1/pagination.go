@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"encoding/base64"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// page is the position a list request continues from, specifications are
+// listed by name so the name of the last one sent is enough to continue
+type page struct {
+	size  int
+	after string
+}
+
+// newPage reads the page requested, a zero size lists everything
+func newPage(size int32, token string) (page, error) {
+	if size < 0 {
+		return page{}, status.Errorf(codes.InvalidArgument, "page size cannot be negative: %d", size)
+	}
+	after, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return page{}, status.Errorf(codes.InvalidArgument, "invalid page token %s", token)
+	}
+	return page{size: int(size), after: string(after)}, nil
+}
+
+func (p page) isAll() bool {
+	return p.size == 0
+}
+
+// nextPageToken is the token to list specifications after the named one
+func nextPageToken(lastName string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastName))
+}
@@ -4,20 +4,26 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/odpf/optimus/core/cron"
 	"github.com/odpf/optimus/core/tree"
 
 	"github.com/odpf/optimus/datastore"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/google/uuid"
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
 	"github.com/odpf/optimus/core/logger"
-	log "github.com/odpf/optimus/core/logger"
 	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/instance"
 	"github.com/odpf/optimus/job"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/store"
@@ -59,10 +65,53 @@ type ProtoAdapter interface {
 
 	FromResourceProto(res *pb.ResourceSpecification, storeName string) (models.ResourceSpec, error)
 	ToResourceProto(res models.ResourceSpec) (*pb.ResourceSpecification, error)
+	ToBackupProto(backup models.BackupSpec) (*pb.BackupSpecification, error)
 
 	ToReplayExecutionTreeNode(res *tree.TreeNode) (*pb.ReplayExecutionTreeNode, error)
 }
 
+const (
+	// StreamSendTimeout is the default time a deploy waits for the client
+	// to accept a progress message before aborting
+	StreamSendTimeout = time.Second * 30
+
+	// streamBufferSize is the number of progress messages buffered
+	// while the client is reading slower than server is producing
+	streamBufferSize = 50
+
+	// replayPlanBatchSize is the number of runs fetched per request
+	// from scheduler while preparing a replay plan
+	replayPlanBatchSize = 100
+
+	// jobRunHistoryBatchSize is the number of runs fetched per request
+	// from scheduler while preparing run history of a job
+	jobRunHistoryBatchSize = 100
+
+	// jobRunHistoryDefaultPageSize is the number of runs returned in a page
+	// of run history when request doesn't specify it
+	jobRunHistoryDefaultPageSize = 50
+	jobRunHistoryMaxPageSize     = 500
+
+	// auditEventsDefaultPageSize is the number of audit events returned in
+	// a page when request doesn't specify it
+	auditEventsDefaultPageSize = 50
+	auditEventsMaxPageSize     = 500
+
+	// schedulePreviewDefaultCount is the number of runs returned by schedule
+	// preview when neither count nor end date is provided
+	schedulePreviewDefaultCount = 10
+	schedulePreviewMaxCount     = 1000
+
+	// windowSeriesDefaultCount is the number of windows returned by window
+	// series when request doesn't specify it
+	windowSeriesDefaultCount = 10
+	windowSeriesMaxCount     = 1000
+
+	// windowSeriesMaxLookback bounds how far back runs are searched for
+	// schedules that rarely run
+	windowSeriesMaxLookback = time.Hour * 24 * 365 * 100
+)
+
 type RuntimeServiceServer struct {
 	version              string
 	jobSvc               models.JobService
@@ -74,15 +123,48 @@ type RuntimeServiceServer struct {
 	secretRepoFactory    SecretRepoFactory
 	instSvc              models.InstanceService
 	scheduler            models.SchedulerUnit
+	assetLoaderFactory   models.AssetLoaderFactory
 
 	progressObserver progress.Observer
 	Now              func() time.Time
 
+	// StreamSendTimeout is the time deploy waits for a client to read
+	// progress messages before it gets aborted
+	StreamSendTimeout time.Duration
+
+	// MaxDeployJobs is the number of jobs a single deploy request can carry
+	// and MaxSpecBytes the serialized size of any job or resource spec in it,
+	// zero or less disables the limit
+	MaxDeployJobs int
+	MaxSpecBytes  int
+
+	// Datastores are the datastores listed as supported by server
+	Datastores models.DatastoreRepo
+
+	// DeployManager runs deployments queued with QueueJobDeployment, queued
+	// deployments are not supported if it is not set
+	DeployManager *job.DeployManager
+
+	// DeployAlerter raises an alert on alert channels of the namespace when
+	// a job deploy fails, deploy failures are not alerted if it is not set
+	DeployAlerter job.Alerter
+
+	// AuditEventRepo lists audit events recorded by UnaryAuditInterceptor
+	// and StreamAuditInterceptor, audit events can't be listed if not set
+	AuditEventRepo store.AuditEventRepository
+
+	// deploys tracks running job deployments which can be cancelled
+	deploys *deployRegistry
+	// deployLimiter caps concurrent deploys across projects, nil if unlimited
+	deployLimiter *deployLimiter
+	// projectGuard keeps deploys of a project from running along with its import
+	projectGuard *projectGuard
+
 	pb.UnimplementedRuntimeServiceServer
 }
 
 func (sv *RuntimeServiceServer) Version(ctx context.Context, version *pb.VersionRequest) (*pb.VersionResponse, error) {
-	log.I(fmt.Printf("client with version %s requested for ping ", version.Client))
+	logger.FromContext(ctx).Infof("client with version %s requested for ping", version.Client)
 	response := &pb.VersionResponse{
 		Server: sv.version,
 	}
@@ -92,104 +174,489 @@ func (sv *RuntimeServiceServer) Version(ctx context.Context, version *pb.Version
 func (sv *RuntimeServiceServer) DeployJobSpecification(req *pb.DeployJobSpecificationRequest, respStream pb.RuntimeService_DeployJobSpecificationServer) error {
 	startTime := time.Now()
 
-	projectRepo := sv.projectRepoFactory.New()
-	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	projSpec, namespaceSpec, err := sv.checkJobDeploy(req)
+	if err != nil {
+		return err
+	}
+	deployDone, err := sv.projectGuard.StartDeploy(projSpec.Name)
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return err
 	}
+	defer deployDone()
 
-	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
-	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	release, err := sv.deployLimiter.Acquire(respStream.Context())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return err
 	}
+	defer release()
 
-	var jobsToKeep []models.JobSpec
-	for _, reqJob := range req.GetJobs() {
-		adaptJob, err := sv.adapter.FromJobProto(reqJob)
-		if err != nil {
-			return status.Errorf(codes.Internal, "%s: cannot adapt job %s", err.Error(), reqJob.GetName())
-		}
+	ctx, cancel := context.WithCancel(respStream.Context())
+	defer cancel()
+	sender := newStreamSender(cancel, sv.StreamSendTimeout)
 
-		err = sv.jobSvc.Create(namespaceSpec, adaptJob)
-		if err != nil {
-			return status.Errorf(codes.Internal, "%s: failed to save %s", err.Error(), adaptJob.Name)
+	// deploy id goes first so the deploy can be cancelled while jobs are saved
+	deployID := sv.deploys.Add(projSpec.Name, cancel)
+	defer sv.deploys.Remove(deployID)
+	sender.Send(func() error {
+		return errors.Wrap(respStream.Send(&pb.DeployJobSpecificationResponse{
+			DeployId: deployID,
+			Message:  fmt.Sprintf("deploy %s started", deployID),
+		}), "failed to send deploy id")
+	})
+	send := func(resp *pb.DeployJobSpecificationResponse) error {
+		sender.Send(func() error { return respStream.Send(resp) })
+		return nil
+	}
+
+	jobsToKeep, err := sv.adaptDeployJobs(req)
+	if err == nil && req.GetStrict() {
+		err = sv.checkUnknownDependencies(projSpec, jobsToKeep, send)
+	}
+	if err == nil {
+		err = sv.saveDeployJobs(ctx, namespaceSpec, jobsToKeep, send)
+	}
+	if err != nil {
+		sender.Close()
+		if sv.deploys.IsCancelled(deployID) {
+			return status.Errorf(codes.Canceled, "deploy %s cancelled", deployID)
 		}
-		jobsToKeep = append(jobsToKeep, adaptJob)
+		return err
 	}
 
+	summary := new(job.DeploySummaryObserver)
 	observers := new(progress.ObserverChain)
-	observers.Join(sv.progressObserver)
+	if sv.progressObserver != nil {
+		observers.Join(sv.progressObserver)
+	}
+	observers.Join(summary)
 	observers.Join(&jobSyncObserver{
 		stream: respStream,
-		log:    logrus.New(),
+		sender: sender,
 	})
+	if sv.DeployAlerter != nil {
+		observers.Join(&job.DeployAlertObserver{
+			Namespace: namespaceSpec,
+			Alerter:   sv.DeployAlerter,
+		})
+	}
 
 	// delete specs not sent for deployment from internal repository
-	if err := sv.jobSvc.KeepOnly(namespaceSpec, jobsToKeep, observers); err != nil {
-		return status.Errorf(codes.Internal, "%s: failed to delete jobs", err.Error())
+	if err := sv.jobSvc.KeepOnly(namespaceSpec, jobsToKeep, req.GetForceDelete(), observers); err != nil {
+		sender.Close()
+		return status.Errorf(errorCode(err), "%s: failed to delete jobs", err.Error())
+	}
+
+	syncErr := sv.jobSvc.Sync(ctx, namespaceSpec, observers)
+	observers.Notify(summary.Summary())
+	if err := sender.Close(); err != nil {
+		return status.Errorf(codes.DeadlineExceeded, "%s: aborted job deployment", err.Error())
+	}
+	if syncErr != nil {
+		if sv.deploys.IsCancelled(deployID) {
+			return status.Errorf(codes.Canceled, "deploy %s cancelled", deployID)
+		}
+		return status.Errorf(errorCode(syncErr), "%s\nfailed to sync jobs", syncErr.Error())
 	}
 
-	if err := sv.jobSvc.Sync(respStream.Context(), namespaceSpec, observers); err != nil {
-		return status.Errorf(codes.Internal, "%s\nfailed to sync jobs", err.Error())
+	// rest of the jobs kept the paused state they had in scheduler
+	if len(req.GetPausedJobs()) > 0 {
+		scheduler, err := sv.schedulerForProject(projSpec)
+		if err != nil {
+			return status.Errorf(codes.FailedPrecondition, "%s: failed to find scheduler for project %s", err.Error(), projSpec.Name)
+		}
+		for jobName, paused := range req.GetPausedJobs() {
+			if err := scheduler.SetJobPaused(ctx, projSpec, jobName, paused); err != nil {
+				return status.Errorf(codes.Internal, "%s: failed to change paused state of job %s", err.Error(), jobName)
+			}
+		}
 	}
 
-	logger.I("finished job deployment in", time.Since(startTime))
+	logger.FromContext(ctx).WithField("duration", time.Since(startTime).String()).Info("finished job deployment")
 	return nil
 }
 
-func (sv *RuntimeServiceServer) ListJobSpecification(ctx context.Context, req *pb.ListJobSpecificationRequest) (*pb.ListJobSpecificationResponse, error) {
+func (sv *RuntimeServiceServer) CancelDeploy(ctx context.Context, req *pb.CancelDeployRequest) (*pb.CancelDeployResponse, error) {
+	cancelled := sv.deploys.Cancel(req.GetProjectName(), req.GetDeployId())
+	if !cancelled && sv.DeployManager != nil {
+		cancelled = sv.DeployManager.Cancel(req.GetProjectName(), req.GetDeployId())
+	}
+	if !cancelled {
+		return nil, status.Errorf(codes.NotFound, "deploy %s not running for project %s", req.GetDeployId(), req.GetProjectName())
+	}
+	return &pb.CancelDeployResponse{
+		Success: true,
+		Message: fmt.Sprintf("deploy %s cancelled", req.GetDeployId()),
+	}, nil
+}
+
+// QueueJobDeployment saves jobs of the request and leaves the rest of the
+// deploy to background workers, workers count against the deploy limit
+// same as streamed deploys
+func (sv *RuntimeServiceServer) QueueJobDeployment(ctx context.Context, req *pb.DeployJobSpecificationRequest) (*pb.QueueJobDeploymentResponse, error) {
+	if sv.DeployManager == nil {
+		return nil, status.Error(codes.Unimplemented, "queued deployments are not enabled")
+	}
+
+	projSpec, namespaceSpec, err := sv.checkJobDeploy(req)
+	if err != nil {
+		return nil, err
+	}
+	saveDone, err := sv.projectGuard.StartDeploy(projSpec.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// nobody is listening for rejected jobs, the error explains them
+	discard := func(*pb.DeployJobSpecificationResponse) error { return nil }
+	jobsToKeep, err := sv.adaptDeployJobs(req)
+	if err == nil && req.GetStrict() {
+		err = sv.checkUnknownDependencies(projSpec, jobsToKeep, discard)
+	}
+	if err == nil {
+		err = sv.saveDeployJobs(ctx, namespaceSpec, jobsToKeep, discard)
+	}
+	saveDone()
+	if err != nil {
+		return nil, err
+	}
+
+	// queued deploy outlives the request, keep logging with fields of request
+	requestLog := logger.FromContext(ctx)
+	deployID, err := sv.DeployManager.Deploy(projSpec.Name, namespaceSpec.Name, func(ctx context.Context, obs progress.Observer) error {
+		ctx = logger.NewContext(ctx, requestLog)
+		deployDone, err := sv.projectGuard.StartDeploy(projSpec.Name)
+		if err != nil {
+			return err
+		}
+		defer deployDone()
+		release, err := sv.deployLimiter.Wait(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+		if sv.DeployAlerter != nil {
+			// summary of queued deploys is notified after they return
+			alertObs := &job.DeployAlertObserver{
+				Namespace: namespaceSpec,
+				Alerter:   sv.DeployAlerter,
+			}
+			defer alertObs.Flush()
+			chain := new(progress.ObserverChain)
+			chain.Join(obs)
+			chain.Join(alertObs)
+			obs = chain
+		}
+
+		// delete specs not sent for deployment from internal repository
+		if err := sv.jobSvc.KeepOnly(namespaceSpec, jobsToKeep, req.GetForceDelete(), obs); err != nil {
+			return errors.Wrap(err, "failed to delete jobs")
+		}
+		if err := sv.jobSvc.Sync(ctx, namespaceSpec, obs); err != nil {
+			return errors.Wrap(err, "failed to sync jobs")
+		}
+
+		// rest of the jobs kept the paused state they had in scheduler
+		if len(req.GetPausedJobs()) == 0 {
+			return nil
+		}
+		scheduler, err := sv.schedulerForProject(projSpec)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find scheduler for project %s", projSpec.Name)
+		}
+		for jobName, paused := range req.GetPausedJobs() {
+			if err := scheduler.SetJobPaused(ctx, projSpec, jobName, paused); err != nil {
+				return errors.Wrapf(err, "failed to change paused state of job %s", jobName)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, job.ErrRequestQueueFull) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%s: too many deployments queued, try again later", err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "%s: failed to queue deployment", err.Error())
+	}
+
+	return &pb.QueueJobDeploymentResponse{
+		DeployId: deployID,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) GetDeploymentStatus(ctx context.Context, req *pb.GetDeploymentStatusRequest) (*pb.GetDeploymentStatusResponse, error) {
+	if sv.DeployManager == nil {
+		return nil, status.Error(codes.Unimplemented, "queued deployments are not enabled")
+	}
+
+	deployment, err := sv.DeployManager.Get(req.GetProjectName(), req.GetDeployId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s: deploy %s not found for project %s", err.Error(), req.GetDeployId(), req.GetProjectName())
+	}
+
+	return &pb.GetDeploymentStatusResponse{
+		DeployId:  deployment.ID,
+		Namespace: deployment.Namespace,
+		Status:    deployment.Status,
+		Message:   deployment.Message,
+		Summary: &pb.DeployJobSpecificationResponse_Summary{
+			Succeeded: int32(deployment.Summary.Succeeded),
+			Failed:    int32(deployment.Summary.Failed),
+			Deleted:   int32(deployment.Summary.Deleted),
+			Unchanged: int32(deployment.Summary.Unchanged),
+		},
+		CreatedAt: timestamppb.New(deployment.CreatedAt),
+		UpdatedAt: timestamppb.New(deployment.UpdatedAt),
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) StreamDeploymentEvents(req *pb.StreamDeploymentEventsRequest, respStream pb.RuntimeService_StreamDeploymentEventsServer) error {
+	if sv.DeployManager == nil {
+		return status.Error(codes.Unimplemented, "queued deployments are not enabled")
+	}
+
+	next := 0
+	for {
+		events, finished, changed, err := sv.DeployManager.Events(req.GetProjectName(), req.GetDeployId(), next)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "%s: deploy %s not found for project %s", err.Error(), req.GetDeployId(), req.GetProjectName())
+		}
+		next += len(events)
+		for _, evt := range events {
+			resp := jobDeployResponse(evt)
+			if resp == nil {
+				continue
+			}
+			if err := respStream.Send(resp); err != nil {
+				return errors.Wrap(err, "failed to send deploy progress")
+			}
+		}
+		if finished {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-respStream.Context().Done():
+			return status.FromContextError(respStream.Context().Err()).Err()
+		}
+	}
+}
+
+// checkJobDeploy validates a deploy request before anything is saved and
+// returns the project and namespace jobs are deployed to
+func (sv *RuntimeServiceServer) checkJobDeploy(req *pb.DeployJobSpecificationRequest) (models.ProjectSpec, models.NamespaceSpec, error) {
+	if sv.MaxDeployJobs > 0 && len(req.GetJobs()) > sv.MaxDeployJobs {
+		return models.ProjectSpec{}, models.NamespaceSpec{}, status.Errorf(codes.ResourceExhausted,
+			"deploy request has %d jobs, limit is %d jobs per deploy", len(req.GetJobs()), sv.MaxDeployJobs)
+	}
+	for _, reqJob := range req.GetJobs() {
+		if err := sv.checkSpecSize("job", reqJob.GetName(), reqJob); err != nil {
+			return models.ProjectSpec{}, models.NamespaceSpec{}, err
+		}
+	}
+
+	if duplicates := duplicateJobNames(req.GetJobs()); len(duplicates) > 0 {
+		return models.ProjectSpec{}, models.NamespaceSpec{}, status.Errorf(codes.InvalidArgument,
+			"duplicate job names in deploy request: %s", strings.Join(duplicates, ", "))
+	}
+
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return models.ProjectSpec{}, models.NamespaceSpec{}, status.Errorf(errorCode(err),
+			"%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+	if missing := projSpec.MissingRequiredConfigs(); len(missing) > 0 {
+		return models.ProjectSpec{}, models.NamespaceSpec{}, status.Errorf(codes.FailedPrecondition,
+			"project %s is missing required config: %s", req.GetProjectName(), strings.Join(missing, ", "))
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return models.ProjectSpec{}, models.NamespaceSpec{}, status.Errorf(errorCode(err),
+			"%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
+	return projSpec, namespaceSpec, nil
+}
 
-	jobSpecs, err := sv.jobSvc.GetAll(namespaceSpec)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to retrieve jobs for project %s", err.Error(), req.GetProjectName())
+// adaptDeployJobs reads jobs of a deploy request, nothing is saved
+func (sv *RuntimeServiceServer) adaptDeployJobs(req *pb.DeployJobSpecificationRequest) ([]models.JobSpec, error) {
+	var jobSpecs []models.JobSpec
+	for _, reqJob := range req.GetJobs() {
+		adaptJob, err := sv.adapter.FromJobProto(reqJob)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: cannot adapt job %s", err.Error(), reqJob.GetName())
+		}
+		jobSpecs = append(jobSpecs, adaptJob)
 	}
+	return jobSpecs, nil
+}
 
-	jobProtos := []*pb.JobSpecification{}
+// saveDeployJobs saves jobs of a deploy request, the first job rejected
+// is reported with send before the deploy is aborted
+func (sv *RuntimeServiceServer) saveDeployJobs(ctx context.Context, namespaceSpec models.NamespaceSpec, jobSpecs []models.JobSpec,
+	send func(*pb.DeployJobSpecificationResponse) error) error {
 	for _, jobSpec := range jobSpecs {
-		jobProto, err := sv.adapter.ToJobProto(jobSpec)
+		if ctx.Err() != nil {
+			return status.Errorf(codes.Canceled, "%s: stopped saving jobs", ctx.Err().Error())
+		}
+		if err := sv.jobSvc.Create(namespaceSpec, jobSpec); err != nil {
+			// let the client know which job was rejected, the deploy is aborted anyway
+			_ = send(&pb.DeployJobSpecificationResponse{
+				Ack:           true,
+				Success:       false,
+				JobName:       jobSpec.Name,
+				Message:       err.Error(),
+				ErrorCategory: pb.DeployJobSpecificationResponse_VALIDATION,
+			})
+			return status.Errorf(errorCode(err), "%s: failed to save %s", err.Error(), jobSpec.Name)
+		}
+	}
+	return nil
+}
+
+// checkUnknownDependencies fails a strict deploy before any job is saved if
+// jobs use destinations produced neither by saved jobs nor by jobs of the
+// deploy, each of them is reported to client
+func (sv *RuntimeServiceServer) checkUnknownDependencies(projSpec models.ProjectSpec, jobSpecs []models.JobSpec,
+	send func(*pb.DeployJobSpecificationResponse) error) error {
+	unknown, err := sv.jobSvc.UnknownDependencies(projSpec, jobSpecs)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s: failed to resolve dependencies of jobs", err.Error())
+	}
+	var unknownDeps []string
+	for _, jobSpec := range jobSpecs {
+		for _, destination := range unknown[jobSpec.Name] {
+			evt := &job.EventJobSpecUnknownDependencyUsed{Job: jobSpec.Name, Dependency: destination}
+			_ = send(&pb.DeployJobSpecificationResponse{
+				Success:       false,
+				JobName:       jobSpec.Name,
+				Message:       evt.String(),
+				ErrorCategory: pb.DeployJobSpecificationResponse_DEPENDENCY,
+			})
+			unknownDeps = append(unknownDeps, fmt.Sprintf("%s uses %s", jobSpec.Name, destination))
+		}
+	}
+	if len(unknownDeps) > 0 {
+		return status.Errorf(codes.FailedPrecondition, "strict deploy failed, unknown dependencies: %s",
+			strings.Join(unknownDeps, ", "))
+	}
+	return nil
+}
+
+// duplicateJobNames returns names that appear more than once in the
+// requested jobs, in the order they were first repeated
+func duplicateJobNames(jobs []*pb.JobSpecification) []string {
+	seen := map[string]bool{}
+	var duplicates []string
+	for _, reqJob := range jobs {
+		repeated, ok := seen[reqJob.GetName()]
+		if ok && !repeated {
+			duplicates = append(duplicates, reqJob.GetName())
+		}
+		seen[reqJob.GetName()] = ok
+	}
+	return duplicates
+}
+
+func (sv *RuntimeServiceServer) ListJobSpecification(ctx context.Context, req *pb.ListJobSpecificationRequest) (*pb.ListJobSpecificationResponse, error) {
+	labelSelector, err := models.ParseLabelSelector(req.GetLabelSelector())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	reqPage, err := newPage(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	if reqPage.isAll() {
+		jobSpecs, err := sv.jobSvc.GetAll(namespaceSpec)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "%s: failed to parse job spec %s", err.Error(), jobSpec.Name)
+			return nil, status.Errorf(codes.Internal, "%s: failed to retrieve jobs for project %s", err.Error(), req.GetProjectName())
+		}
+
+		jobProtos := []*pb.JobSpecification{}
+		for _, jobSpec := range jobSpecs {
+			if !labelSelector.Matches(jobSpec.Labels) {
+				continue
+			}
+			jobProto, err := sv.adapter.ToJobProto(jobSpec)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "%s: failed to parse job spec %s", err.Error(), jobSpec.Name)
+			}
+			jobProtos = append(jobProtos, jobProto)
+		}
+		return &pb.ListJobSpecificationResponse{
+			Jobs: jobProtos,
+		}, nil
+	}
+
+	// jobs not matching the selector are skipped, keep reading pages
+	// till the requested page is filled
+	resp := &pb.ListJobSpecificationResponse{Jobs: []*pb.JobSpecification{}}
+	for after := reqPage.after; ; {
+		jobSpecs, err := sv.jobSvc.GetPage(namespaceSpec, after, reqPage.size)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: failed to retrieve jobs for project %s", err.Error(), req.GetProjectName())
+		}
+		for _, jobSpec := range jobSpecs {
+			after = jobSpec.Name
+			if !labelSelector.Matches(jobSpec.Labels) {
+				continue
+			}
+			jobProto, err := sv.adapter.ToJobProto(jobSpec)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "%s: failed to parse job spec %s", err.Error(), jobSpec.Name)
+			}
+			resp.Jobs = append(resp.Jobs, jobProto)
+			if len(resp.Jobs) == reqPage.size {
+				resp.NextPageToken = nextPageToken(jobSpec.Name)
+				return resp, nil
+			}
+		}
+		if len(jobSpecs) < reqPage.size {
+			return resp, nil
 		}
-		jobProtos = append(jobProtos, jobProto)
 	}
-	return &pb.ListJobSpecificationResponse{
-		Jobs: jobProtos,
-	}, nil
 }
 
 func (sv *RuntimeServiceServer) DumpJobSpecification(ctx context.Context, req *pb.DumpJobSpecificationRequest) (*pb.DumpJobSpecificationResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	reqJobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: job %s not found", err.Error(), req.GetJobName())
+		return nil, status.Errorf(errorCode(err), "%s: job %s not found", err.Error(), req.GetJobName())
 	}
 
 	compiledJob, err := sv.jobSvc.Dump(namespaceSpec, reqJobSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to compile %s", err.Error(), reqJobSpec.Name)
+		var tmplErr *models.TemplateError
+		if errors.As(err, &tmplErr) {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: failed to compile %s at %s", err.Error(), reqJobSpec.Name, tmplErr.Location())
+		}
+		return nil, status.Errorf(errorCode(err), "%s: failed to compile %s", err.Error(), reqJobSpec.Name)
 	}
 
 	return &pb.DumpJobSpecificationResponse{Success: true, Content: string(compiledJob.Contents)}, nil
@@ -199,23 +666,23 @@ func (sv *RuntimeServiceServer) CheckJobSpecification(ctx context.Context, req *
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	j, err := sv.adapter.FromJobProto(req.GetJob())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to adapt job %s\n%s", req.GetJob().Name, err.Error())
+		return nil, status.Errorf(codes.InvalidArgument, "failed to adapt job %s\n%s", req.GetJob().Name, err.Error())
 	}
 	reqJobs := []models.JobSpec{j}
 
 	if err = sv.jobSvc.Check(namespaceSpec, reqJobs, nil); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to compile jobs\n%s", err.Error())
+		return nil, status.Errorf(errorCode(err), "failed to compile jobs\n%s", err.Error())
 	}
 	return &pb.CheckJobSpecificationResponse{Success: true}, nil
 }
@@ -224,33 +691,33 @@ func (sv *RuntimeServiceServer) CheckJobSpecifications(req *pb.CheckJobSpecifica
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	observers := new(progress.ObserverChain)
 	observers.Join(sv.progressObserver)
 	observers.Join(&jobCheckObserver{
 		stream: respStream,
-		log:    logrus.New(),
+		log:    logger.FromContext(respStream.Context()),
 	})
 
 	reqJobs := []models.JobSpec{}
 	for _, jobProto := range req.GetJobs() {
 		j, err := sv.adapter.FromJobProto(jobProto)
 		if err != nil {
-			return status.Errorf(codes.Internal, "failed to adapt job %s\n%s", jobProto.Name, err.Error())
+			return status.Errorf(codes.InvalidArgument, "failed to adapt job %s\n%s", jobProto.Name, err.Error())
 		}
 		reqJobs = append(reqJobs, j)
 	}
 
 	if err = sv.jobSvc.Check(namespaceSpec, reqJobs, observers); err != nil {
-		return status.Errorf(codes.Internal, "failed to compile jobs\n%s", err.Error())
+		return status.Errorf(errorCode(err), "failed to compile jobs\n%s", err.Error())
 	}
 	return nil
 }
@@ -259,6 +726,17 @@ func (sv *RuntimeServiceServer) RegisterProject(ctx context.Context, req *pb.Reg
 	projectRepo := sv.projectRepoFactory.New()
 	projectSpec := sv.adapter.FromProjectProto(req.GetProject())
 
+	if projectSpec.Scheduler.Type != "" {
+		if _, err := models.SchedulerRegistry.GetByName(projectSpec.Scheduler.Type); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: invalid scheduler for project %s", err.Error(), req.GetProject().GetName())
+		}
+	}
+
+	if missing := projectSpec.MissingRequiredConfigs(); len(missing) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "project %s is missing required config: %s",
+			req.GetProject().GetName(), strings.Join(missing, ", "))
+	}
+
 	if err := projectRepo.Save(projectSpec); err != nil {
 		return nil, status.Errorf(codes.Internal, "%s: failed to save project %s", err.Error(), req.GetProject().GetName())
 	}
@@ -266,7 +744,7 @@ func (sv *RuntimeServiceServer) RegisterProject(ctx context.Context, req *pb.Reg
 	if req.GetNamespace() != nil {
 		savedProjectSpec, err := projectRepo.GetByName(projectSpec.Name)
 		if err != nil {
-			return nil, status.Errorf(codes.NotFound, "%s: failed to find project %s",
+			return nil, status.Errorf(errorCode(err), "%s: failed to find project %s",
 				err.Error(), req.GetProject().GetName())
 		}
 
@@ -284,11 +762,99 @@ func (sv *RuntimeServiceServer) RegisterProject(ctx context.Context, req *pb.Reg
 	}, nil
 }
 
+// ListAuditEvents returns audit events matching the request, latest first
+func (sv *RuntimeServiceServer) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	if sv.AuditEventRepo == nil {
+		return nil, status.Error(codes.Unimplemented, "audit log is not enabled")
+	}
+	filter := models.AuditEventFilter{
+		ProjectName: req.GetProjectName(),
+		Method:      req.GetMethod(),
+		Identity:    req.GetIdentity(),
+		UserAgent:   req.GetUserAgent(),
+		Offset:      int(req.GetPageOffset()),
+		Limit:       int(req.GetPageSize()),
+	}
+	if req.GetStartTime() != nil {
+		filter.StartTime = req.GetStartTime().AsTime()
+	}
+	if req.GetEndTime() != nil {
+		filter.EndTime = req.GetEndTime().AsTime()
+	}
+	if !filter.StartTime.IsZero() && !filter.EndTime.IsZero() && filter.EndTime.Before(filter.StartTime) {
+		return nil, status.Error(codes.InvalidArgument, "audit events end time cannot be before start time")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = auditEventsDefaultPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > auditEventsMaxPageSize || filter.Offset < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page size should be between 1 and %d with a non negative offset",
+			auditEventsMaxPageSize)
+	}
+
+	events, err := sv.AuditEventRepo.List(ctx, filter)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to list audit events", err.Error())
+	}
+	eventProtos := []*pb.AuditEvent{}
+	for _, event := range events {
+		eventProtos = append(eventProtos, &pb.AuditEvent{
+			Method:        event.Method,
+			Identity:      event.Identity,
+			UserAgent:     event.UserAgent,
+			Peer:          event.Peer,
+			RequestId:     event.RequestID,
+			ProjectName:   event.ProjectName,
+			Namespace:     event.Namespace,
+			RequestDigest: event.RequestDigest,
+			Code:          event.Code,
+			Message:       event.Message,
+			CreatedAt:     timestamppb.New(event.CreatedAt),
+		})
+	}
+	return &pb.ListAuditEventsResponse{
+		Events: eventProtos,
+	}, nil
+}
+
+// UpdateProjectConfig merges requested configs into configs of the project,
+// scheduler, secrets and namespaces of the project are left as they are
+func (sv *RuntimeServiceServer) UpdateProjectConfig(ctx context.Context, req *pb.UpdateProjectConfigRequest) (*pb.UpdateProjectConfigResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	config := map[string]string{}
+	for key, val := range projSpec.Config {
+		config[key] = val
+	}
+	for _, key := range req.GetRemoveKeys() {
+		delete(config, strings.ToUpper(key))
+	}
+	for key, val := range req.GetConfig() {
+		config[strings.ToUpper(key)] = val
+	}
+	projSpec.Config = config
+
+	if missing := projSpec.MissingRequiredConfigs(); len(missing) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "project %s is missing required config: %s",
+			req.GetProjectName(), strings.Join(missing, ", "))
+	}
+	if err := projectRepo.Save(projSpec); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to save project %s", err.Error(), req.GetProjectName())
+	}
+	return &pb.UpdateProjectConfigResponse{
+		Project: sv.adapter.ToProjectProto(projSpec),
+	}, nil
+}
+
 func (sv *RuntimeServiceServer) RegisterProjectNamespace(ctx context.Context, req *pb.RegisterProjectNamespaceRequest) (*pb.RegisterProjectNamespaceResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceSpec := sv.adapter.FromNamespaceProto(req.GetNamespace())
@@ -307,32 +873,33 @@ func (sv *RuntimeServiceServer) CreateJobSpecification(ctx context.Context, req
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
 	}
 
 	jobSpec, err := sv.adapter.FromJobProto(req.GetSpec())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: cannot deserialize job", err.Error())
+		return nil, status.Errorf(codes.InvalidArgument, "%s: cannot deserialize job", err.Error())
 	}
 
 	// validate job spec
 	if err = sv.jobSvc.Check(namespaceSpec, []models.JobSpec{jobSpec}, sv.progressObserver); err != nil {
-		return nil, status.Errorf(codes.Internal, "spec validation failed\n%s", err.Error())
+		return nil, status.Errorf(errorCode(err), "spec validation failed\n%s", err.Error())
 	}
 
 	err = sv.jobSvc.Create(namespaceSpec, jobSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to save job %s", err.Error(), jobSpec.Name)
+		return nil, status.Errorf(errorCode(err), "%s: failed to save job %s", err.Error(), jobSpec.Name)
 	}
 
-	if err := sv.jobSvc.Sync(ctx, namespaceSpec, sv.progressObserver); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s\nfailed to sync jobs", err.Error())
+	// only the created job is deployed, other jobs of the namespace are left as they are
+	if err := sv.jobSvc.SyncJob(ctx, namespaceSpec, jobSpec.Name, sv.progressObserver); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s\nfailed to sync job %s", err.Error(), jobSpec.Name)
 	}
 
 	return &pb.CreateJobSpecificationResponse{
@@ -345,18 +912,18 @@ func (sv *RuntimeServiceServer) ReadJobSpecification(ctx context.Context, req *p
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
 	}
 
 	jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: error while finding the job %s", err.Error(), req.GetJobName())
+		return nil, status.Errorf(errorCode(err), "%s: error while finding the job %s", err.Error(), req.GetJobName())
 	}
 
 	jobSpecAdapt, err := sv.adapter.ToJobProto(jobSpec)
@@ -369,156 +936,1482 @@ func (sv *RuntimeServiceServer) ReadJobSpecification(ctx context.Context, req *p
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) DeleteJobSpecification(ctx context.Context, req *pb.DeleteJobSpecificationRequest) (*pb.DeleteJobSpecificationResponse, error) {
+// PatchJobSpecification changes only the fields of a job listed in update
+// mask, rest of the stored spec is kept as it is
+func (sv *RuntimeServiceServer) PatchJobSpecification(ctx context.Context, req *pb.PatchJobSpecificationRequest) (*pb.PatchJobSpecificationResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
 	}
 
-	jobSpecToDelete, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
+	if len(req.GetUpdateMask()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_mask should list the fields to change")
+	}
+
+	existingSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: job %s does not exist", err.Error(), req.GetJobName())
+		return nil, status.Errorf(errorCode(err), "%s: job %s does not exist", err.Error(), req.GetJobName())
+	}
+	jobProto, err := sv.adapter.ToJobProto(existingSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: cannot serialize job", err.Error())
 	}
 
-	if err := sv.jobSvc.Delete(ctx, namespaceSpec, jobSpecToDelete); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to delete job %s", err.Error(), req.GetJobName())
+	patch := req.GetSpec()
+	if patch == nil {
+		patch = &pb.JobSpecification{}
+	}
+	if err := applyJobPatch(jobProto, patch, req.GetUpdateMask()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to patch job %s", err.Error(), req.GetJobName())
+	}
+	jobSpec, err := sv.adapter.FromJobProto(jobProto)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: cannot deserialize patched job", err.Error())
 	}
 
-	return &pb.DeleteJobSpecificationResponse{
+	// validate job spec
+	if err = sv.jobSvc.Check(namespaceSpec, []models.JobSpec{jobSpec}, sv.progressObserver); err != nil {
+		return nil, status.Errorf(errorCode(err), "spec validation failed\n%s", err.Error())
+	}
+
+	if err = sv.jobSvc.Create(namespaceSpec, jobSpec); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to save job %s", err.Error(), jobSpec.Name)
+	}
+
+	if err := sv.jobSvc.SyncJob(ctx, namespaceSpec, jobSpec.Name, sv.progressObserver); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s\nfailed to sync job %s", err.Error(), jobSpec.Name)
+	}
+
+	patchedProto, err := sv.adapter.ToJobProto(jobSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: cannot serialize job", err.Error())
+	}
+	return &pb.PatchJobSpecificationResponse{
 		Success: true,
-		Message: fmt.Sprintf("job %s has been deleted", jobSpecToDelete.Name),
+		Message: fmt.Sprintf("job %s is patched and deployed successfully on project %s", jobSpec.Name, req.GetProjectName()),
+		Spec:    patchedProto,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
-	projectRepo := sv.projectRepoFactory.New()
-	projects, err := projectRepo.GetAll()
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to retrieve saved projects", err.Error())
+// jobPatchFields are the fields of a job spec that can be patched as a whole,
+// name identifies the job so it can't be patched
+var jobPatchFields = map[string]func(spec, patch *pb.JobSpecification){
+	"version":            func(spec, patch *pb.JobSpecification) { spec.Version = patch.Version },
+	"owner":              func(spec, patch *pb.JobSpecification) { spec.Owner = patch.Owner },
+	"start_date":         func(spec, patch *pb.JobSpecification) { spec.StartDate = patch.StartDate },
+	"end_date":           func(spec, patch *pb.JobSpecification) { spec.EndDate = patch.EndDate },
+	"interval":           func(spec, patch *pb.JobSpecification) { spec.Interval = patch.Interval },
+	"timezone":           func(spec, patch *pb.JobSpecification) { spec.Timezone = patch.Timezone },
+	"depends_on_past":    func(spec, patch *pb.JobSpecification) { spec.DependsOnPast = patch.DependsOnPast },
+	"catch_up":           func(spec, patch *pb.JobSpecification) { spec.CatchUp = patch.CatchUp },
+	"task_name":          func(spec, patch *pb.JobSpecification) { spec.TaskName = patch.TaskName },
+	"config":             func(spec, patch *pb.JobSpecification) { spec.Config = patch.Config },
+	"window_size":        func(spec, patch *pb.JobSpecification) { spec.WindowSize = patch.WindowSize },
+	"window_offset":      func(spec, patch *pb.JobSpecification) { spec.WindowOffset = patch.WindowOffset },
+	"window_truncate_to": func(spec, patch *pb.JobSpecification) { spec.WindowTruncateTo = patch.WindowTruncateTo },
+	"dependencies":       func(spec, patch *pb.JobSpecification) { spec.Dependencies = patch.Dependencies },
+	"assets":             func(spec, patch *pb.JobSpecification) { spec.Assets = patch.Assets },
+	"hooks":              func(spec, patch *pb.JobSpecification) { spec.Hooks = patch.Hooks },
+	"description":        func(spec, patch *pb.JobSpecification) { spec.Description = patch.Description },
+	"labels":             func(spec, patch *pb.JobSpecification) { spec.Labels = patch.Labels },
+	"behavior":           func(spec, patch *pb.JobSpecification) { spec.Behavior = patch.Behavior },
+}
+
+// applyJobPatch changes fields of spec listed in paths to their value in
+// patch, entries of config, labels and assets can be changed one at a time
+// as config.KEY, an entry missing from patch is removed
+func applyJobPatch(spec, patch *pb.JobSpecification, paths []string) error {
+	for _, path := range paths {
+		if setField, ok := jobPatchFields[path]; ok {
+			setField(spec, patch)
+			continue
+		}
+
+		field, key := path, ""
+		if parts := strings.SplitN(path, ".", 2); len(parts) == 2 {
+			field, key = parts[0], parts[1]
+		}
+		if key == "" {
+			return errors.Errorf("unknown field path %s", path)
+		}
+		switch field {
+		case "config":
+			spec.Config = patchJobConfig(spec.Config, patch.Config, key)
+		case "labels":
+			spec.Labels = patchMapEntry(spec.Labels, patch.Labels, key)
+		case "assets":
+			spec.Assets = patchMapEntry(spec.Assets, patch.Assets, key)
+		default:
+			return errors.Errorf("unknown field path %s", path)
+		}
 	}
+	return nil
+}
 
-	projSpecsProto := []*pb.ProjectSpecification{}
-	for _, project := range projects {
-		projSpecsProto = append(projSpecsProto, sv.adapter.ToProjectProto(project))
+// patchJobConfig sets config key to its value in patch keeping the order of
+// existing config, the key is removed if patch doesn't have it
+func patchJobConfig(config, patch []*pb.JobConfigItem, key string) []*pb.JobConfigItem {
+	var patched *pb.JobConfigItem
+	for _, item := range patch {
+		if item.GetName() == key {
+			patched = item
+		}
 	}
 
-	return &pb.ListProjectsResponse{
-		Projects: projSpecsProto,
-	}, nil
+	var result []*pb.JobConfigItem
+	replaced := false
+	for _, item := range config {
+		if item.GetName() != key {
+			result = append(result, item)
+			continue
+		}
+		if patched != nil && !replaced {
+			result = append(result, patched)
+			replaced = true
+		}
+	}
+	if patched != nil && !replaced {
+		result = append(result, patched)
+	}
+	return result
 }
 
-func (sv *RuntimeServiceServer) ListProjectNamespaces(ctx context.Context, req *pb.ListProjectNamespacesRequest) (*pb.ListProjectNamespacesResponse, error) {
+func patchMapEntry(entries, patch map[string]string, key string) map[string]string {
+	value, ok := patch[key]
+	if !ok {
+		delete(entries, key)
+		return entries
+	}
+	if entries == nil {
+		entries = map[string]string{}
+	}
+	entries[key] = value
+	return entries
+}
+
+func (sv *RuntimeServiceServer) DeleteJobSpecification(ctx context.Context, req *pb.DeleteJobSpecificationRequest) (*pb.DeleteJobSpecificationResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
-	namespaceSpecs, err := namespaceRepo.GetAll()
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: error while fetching namespaces", err.Error())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
 	}
 
-	namespaceSpecsProto := []*pb.NamespaceSpecification{}
-	for _, namespace := range namespaceSpecs {
-		namespaceSpecsProto = append(namespaceSpecsProto, sv.adapter.ToNamespaceProto(namespace))
+	jobSpecToDelete, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: job %s does not exist", err.Error(), req.GetJobName())
 	}
 
-	return &pb.ListProjectNamespacesResponse{
-		Namespaces: namespaceSpecsProto,
+	if err := sv.jobSvc.Delete(ctx, namespaceSpec, jobSpecToDelete); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to delete job %s", err.Error(), req.GetJobName())
+	}
+
+	return &pb.DeleteJobSpecificationResponse{
+		Success: true,
+		Message: fmt.Sprintf("job %s has been deleted", jobSpecToDelete.Name),
+	}, nil
+}
+
+// GetJobSpecHistory lists saved revisions of a job, latest first
+func (sv *RuntimeServiceServer) GetJobSpecHistory(ctx context.Context, req *pb.GetJobSpecHistoryRequest) (*pb.GetJobSpecHistoryResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+	}
+
+	revisions, err := sv.jobSvc.GetHistory(namespaceSpec, req.GetJobName())
+	if err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%s: no revisions found for job %s", err.Error(), req.GetJobName())
+		}
+		return nil, status.Errorf(codes.Internal, "%s: failed to fetch revisions of job %s", err.Error(), req.GetJobName())
+	}
+
+	revisionsProto := []*pb.JobSpecRevision{}
+	for _, revision := range revisions {
+		jobSpecAdapt, err := sv.adapter.ToJobProto(revision.Spec)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: cannot serialize revision %d of job %s", err.Error(),
+				revision.Revision, req.GetJobName())
+		}
+		revisionsProto = append(revisionsProto, &pb.JobSpecRevision{
+			Revision:  int32(revision.Revision),
+			CreatedAt: timestamppb.New(revision.CreatedAt),
+			Spec:      jobSpecAdapt,
+		})
+	}
+
+	return &pb.GetJobSpecHistoryResponse{
+		Revisions: revisionsProto,
+	}, nil
+}
+
+// RollbackJobSpecification restores a job to an earlier revision, the
+// restored spec is saved as a new revision and only this job is deployed
+func (sv *RuntimeServiceServer) RollbackJobSpecification(ctx context.Context, req *pb.RollbackJobSpecificationRequest) (*pb.RollbackJobSpecificationResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found. Is it registered?", err.Error(), req.GetNamespace())
+	}
+
+	if req.GetRevision() < 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "revision should be greater than 0, got %d", req.GetRevision())
+	}
+
+	jobSpec, err := sv.jobSvc.Rollback(ctx, namespaceSpec, req.GetJobName(), int(req.GetRevision()), sv.progressObserver)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrResourceNotFound):
+			return nil, status.Errorf(codes.NotFound, "%s: revision %d of job %s not found", err.Error(),
+				req.GetRevision(), req.GetJobName())
+		}
+		return nil, status.Errorf(errorCode(err), "%s: failed to rollback job %s", err.Error(), req.GetJobName())
+	}
+
+	jobSpecAdapt, err := sv.adapter.ToJobProto(jobSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: cannot serialize job", err.Error())
+	}
+
+	return &pb.RollbackJobSpecificationResponse{
+		Success: true,
+		Message: fmt.Sprintf("job %s is rolled back to revision %d", req.GetJobName(), req.GetRevision()),
+		Spec:    jobSpecAdapt,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projects, err := projectRepo.GetAll()
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to retrieve saved projects", err.Error())
+	}
+
+	projSpecsProto := []*pb.ProjectSpecification{}
+	for _, project := range projects {
+		projSpecsProto = append(projSpecsProto, sv.adapter.ToProjectProto(project))
+	}
+
+	return &pb.ListProjectsResponse{
+		Projects: projSpecsProto,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.GetProjectResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	return &pb.GetProjectResponse{
+		Project: sv.adapter.ToProjectProto(projSpec),
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) CloneProject(ctx context.Context, req *pb.CloneProjectRequest) (*pb.CloneProjectResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	sourceProjSpec, err := projectRepo.GetByName(req.GetSourceProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetSourceProjectName())
+	}
+	if _, err := projectRepo.GetByName(req.GetTargetProjectName()); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "project %s already exists", req.GetTargetProjectName())
+	} else if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, status.Errorf(codes.Internal, "%s: failed to find project %s", err.Error(), req.GetTargetProjectName())
+	}
+
+	targetConfig := map[string]string{}
+	for key, value := range sourceProjSpec.Config {
+		targetConfig[key] = value
+	}
+	for key, value := range req.GetConfig() {
+		targetConfig[strings.ToUpper(key)] = value
+	}
+	targetProjSpec := models.ProjectSpec{
+		Name:      req.GetTargetProjectName(),
+		Config:    targetConfig,
+		Scheduler: sourceProjSpec.Scheduler,
+	}
+	if missing := targetProjSpec.MissingRequiredConfigs(); len(missing) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "project %s is missing required config: %s",
+			req.GetTargetProjectName(), strings.Join(missing, ", "))
+	}
+
+	// secrets are never copied, operator has to register them for the target
+	sourceSecrets, err := sv.secretRepoFactory.New(sourceProjSpec).GetAll()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to fetch secrets of project %s", err.Error(), sourceProjSpec.Name)
+	}
+	var secretNames []string
+	for _, secret := range sourceSecrets {
+		secretNames = append(secretNames, secret.Name)
+	}
+
+	sourceNamespaces, err := sv.namespaceRepoFactory.New(sourceProjSpec).GetAll()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to fetch namespaces of project %s", err.Error(), sourceProjSpec.Name)
+	}
+
+	if err := projectRepo.Save(targetProjSpec); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to save project %s", err.Error(), targetProjSpec.Name)
+	}
+	if err := sv.cloneNamespaces(targetProjSpec.Name, sourceNamespaces, req.GetResourceProject()); err != nil {
+		// a half cloned project is removed so the clone can be retried
+		if delErr := projectRepo.Delete(targetProjSpec.Name); delErr != nil {
+			logger.FromContext(ctx).WithError(delErr).Errorf("failed to remove partially cloned project %s", targetProjSpec.Name)
+		}
+		return nil, err
+	}
+
+	return &pb.CloneProjectResponse{
+		Success: true,
+		Message: fmt.Sprintf("project %s cloned to %s", sourceProjSpec.Name, targetProjSpec.Name),
+		Secrets: secretNames,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) RegisterInstance(ctx context.Context, req *pb.RegisterInstanceRequest) (*pb.RegisterInstanceResponse, error) {
-	jobScheduledTime, err := ptypes.Timestamp(req.GetScheduledAt())
+func (sv *RuntimeServiceServer) ExportProject(req *pb.ExportProjectRequest, respStream pb.RuntimeService_ExportProjectServer) error {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	// secret values never leave the server, only their names are exported
+	secrets, err := sv.secretRepoFactory.New(projSpec).GetAll()
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s: failed to fetch secrets of project %s", err.Error(), projSpec.Name)
+	}
+	secretNames := []string{}
+	for _, secret := range secrets {
+		secretNames = append(secretNames, secret.Name)
+	}
+	sort.Strings(secretNames)
+
+	namespaceSpecs, err := sv.namespaceRepoFactory.New(projSpec).GetAll()
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s: failed to fetch namespaces of project %s", err.Error(), projSpec.Name)
+	}
+	var datastoreNames []string
+	for _, ds := range models.DatastoreRegistry.GetAll() {
+		datastoreNames = append(datastoreNames, ds.Name())
+	}
+	sort.Strings(datastoreNames)
+
+	if err := respStream.Send(&pb.ExportProjectResponse{
+		Project:     sv.adapter.ToProjectProto(projSpec),
+		SecretNames: secretNames,
+	}); err != nil {
+		return err
+	}
+
+	ctx := respStream.Context()
+	for _, namespaceSpec := range namespaceSpecs {
+		if err := respStream.Send(&pb.ExportProjectResponse{
+			Namespace: sv.adapter.ToNamespaceProto(namespaceSpec),
+		}); err != nil {
+			return err
+		}
+
+		jobSpecs, err := sv.jobSvc.GetAll(namespaceSpec)
+		if err != nil {
+			return status.Errorf(codes.Internal, "%s: failed to fetch jobs of namespace %s", err.Error(), namespaceSpec.Name)
+		}
+		for _, jobSpec := range jobSpecs {
+			if jobSpec.Assets, err = instance.ResolveAssets(ctx, sv.assetLoaderOf(projSpec), jobSpec.Assets); err != nil {
+				return status.Errorf(errorCode(err), "%s: failed to resolve assets of job %s", err.Error(), jobSpec.Name)
+			}
+			jobProto, err := sv.adapter.ToJobProto(jobSpec)
+			if err != nil {
+				return status.Errorf(codes.Internal, "%s: failed to parse job spec %s", err.Error(), jobSpec.Name)
+			}
+			if err := respStream.Send(&pb.ExportProjectResponse{
+				Job:           jobProto,
+				NamespaceName: namespaceSpec.Name,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, datastoreName := range datastoreNames {
+			resourceSpecs, err := sv.resourceSvc.GetAll(namespaceSpec, datastoreName)
+			if err != nil {
+				return status.Errorf(codes.Internal, "%s: failed to fetch resources of namespace %s", err.Error(), namespaceSpec.Name)
+			}
+			for _, resourceSpec := range resourceSpecs {
+				if resourceSpec.Assets, err = instance.ResolveResourceAssets(ctx, sv.assetLoaderOf(projSpec), resourceSpec.Assets); err != nil {
+					return status.Errorf(errorCode(err), "%s: failed to resolve assets of resource %s", err.Error(), resourceSpec.Name)
+				}
+				resourceProto, err := sv.adapter.ToResourceProto(resourceSpec)
+				if err != nil {
+					return status.Errorf(codes.Internal, "%s: failed to adapt resource %s", err.Error(), resourceSpec.Name)
+				}
+				if err := respStream.Send(&pb.ExportProjectResponse{
+					Resource:      resourceProto,
+					NamespaceName: namespaceSpec.Name,
+					DatastoreName: datastoreName,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// importBundle is a bundle staged for import along with the project it
+// replaces, existingProject is nil if the import registers the project
+type importBundle struct {
+	project         models.ProjectSpec
+	existingProject *models.ProjectSpec
+	namespaces      []*importNamespace
+}
+
+// importNamespace is a namespace of a bundle staged for import along with
+// the spec, jobs and resources it had before the import
+type importNamespace struct {
+	spec          models.NamespaceSpec
+	jobSpecs      []models.JobSpec
+	resourceSpecs map[string][]models.ResourceSpec
+
+	existing          *models.NamespaceSpec
+	existingJobs      []models.JobSpec
+	existingResources map[string][]models.ResourceSpec
+}
+
+func (sv *RuntimeServiceServer) ImportProject(req *pb.ImportProjectRequest, respStream pb.RuntimeService_ImportProjectServer) error {
+	if !req.GetDryRun() && req.GetProject().GetName() != "" {
+		// deploys of the project are rejected till the import is done
+		importDone, err := sv.projectGuard.StartImport(req.GetProject().GetName())
+		if err != nil {
+			return err
+		}
+		defer importDone()
+	}
+
+	bundle, err := sv.stageImport(req)
+	if err != nil {
+		return err
+	}
+	projSpec, namespaces := bundle.project, bundle.namespaces
+
+	var jobCount, resourceCount int
+	for _, staged := range namespaces {
+		jobCount += len(staged.jobSpecs)
+		for _, resourceSpecs := range staged.resourceSpecs {
+			resourceCount += len(resourceSpecs)
+		}
+	}
+	summary := fmt.Sprintf("%d namespaces, %d jobs and %d resources", len(namespaces), jobCount, resourceCount)
+	if req.GetDryRun() {
+		return respStream.Send(&pb.ImportProjectResponse{
+			Success: true,
+			Message: fmt.Sprintf("bundle of project %s with %s is valid", projSpec.Name, summary),
+		})
+	}
+
+	ctx := respStream.Context()
+	applied, importErr := sv.applyImport(ctx, bundle, respStream)
+	if importErr != nil {
+		message := fmt.Sprintf("%s: failed to import project %s, rolled back jobs and resources created by the import",
+			importErr.Error(), projSpec.Name)
+		if err := sv.rollbackImport(ctx, bundle, applied); err != nil {
+			message = fmt.Sprintf("%s: failed to import project %s, rollback failed: %s", importErr.Error(), projSpec.Name, err.Error())
+		}
+		if err := respStream.Send(&pb.ImportProjectResponse{
+			Success: false,
+			Message: message,
+		}); err != nil {
+			return err
+		}
+		return status.Error(codes.Internal, message)
+	}
+
+	return respStream.Send(&pb.ImportProjectResponse{
+		Success: true,
+		Message: fmt.Sprintf("imported %s to project %s", summary, projSpec.Name),
+	})
+}
+
+// stageImport validates the whole bundle before anything is persisted, jobs
+// are checked the same way as on deploy and jobs and resources already
+// present in the project can't be overwritten by it
+func (sv *RuntimeServiceServer) stageImport(req *pb.ImportProjectRequest) (*importBundle, error) {
+	var problems []string
+	projSpec := sv.adapter.FromProjectProto(req.GetProject())
+	if projSpec.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "project name of bundle is required")
+	}
+	bundle := &importBundle{project: projSpec}
+	if projSpec.Scheduler.Type != "" {
+		if _, err := models.SchedulerRegistry.GetByName(projSpec.Scheduler.Type); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid scheduler for project %s", err.Error(), projSpec.Name))
+		}
+	}
+	if missing := projSpec.MissingRequiredConfigs(); len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("project %s is missing required config: %s", projSpec.Name, strings.Join(missing, ", ")))
+	}
+
+	existingNamespaces := map[string]models.NamespaceSpec{}
+	savedProjSpec, err := sv.projectRepoFactory.New().GetByName(projSpec.Name)
+	if err == nil {
+		bundle.existingProject = &savedProjSpec
+		namespaceSpecs, err := sv.namespaceRepoFactory.New(savedProjSpec).GetAll()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: failed to fetch namespaces of project %s", err.Error(), projSpec.Name)
+		}
+		for _, namespaceSpec := range namespaceSpecs {
+			existingNamespaces[namespaceSpec.Name] = namespaceSpec
+		}
+	} else if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, status.Errorf(codes.Internal, "%s: failed to find project %s", err.Error(), projSpec.Name)
+	}
+
+	stagedNamespaces := map[string]*importNamespace{}
+	for _, namespaceProto := range req.GetNamespaces() {
+		namespaceSpec := sv.adapter.FromNamespaceProto(namespaceProto)
+		if namespaceSpec.Name == "" {
+			problems = append(problems, "namespace name is required")
+			continue
+		}
+		if _, ok := stagedNamespaces[namespaceSpec.Name]; ok {
+			problems = append(problems, fmt.Sprintf("duplicate namespace %s", namespaceSpec.Name))
+			continue
+		}
+		staged := &importNamespace{
+			spec:              namespaceSpec,
+			resourceSpecs:     map[string][]models.ResourceSpec{},
+			existingResources: map[string][]models.ResourceSpec{},
+		}
+		if existingSpec, ok := existingNamespaces[namespaceSpec.Name]; ok {
+			staged.existing = &existingSpec
+			if staged.existingJobs, err = sv.jobSvc.GetAll(existingSpec); err != nil {
+				return nil, status.Errorf(codes.Internal, "%s: failed to fetch jobs of namespace %s", err.Error(), namespaceSpec.Name)
+			}
+		}
+		stagedNamespaces[namespaceSpec.Name] = staged
+		bundle.namespaces = append(bundle.namespaces, staged)
+	}
+
+	jobNames := map[string]bool{}
+	for _, item := range req.GetJobs() {
+		staged, ok := stagedNamespaces[item.GetNamespaceName()]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("job %s refers namespace %s missing from bundle", item.GetJob().GetName(), item.GetNamespaceName()))
+			continue
+		}
+		jobSpec, err := sv.adapter.FromJobProto(item.GetJob())
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid job %s", err.Error(), item.GetJob().GetName()))
+			continue
+		}
+		if jobNames[jobSpec.Name] {
+			problems = append(problems, fmt.Sprintf("duplicate job %s", jobSpec.Name))
+			continue
+		}
+		jobNames[jobSpec.Name] = true
+		namespaceSpec := staged.spec
+		namespaceSpec.ProjectSpec = projSpec
+		if err := sv.jobSvc.Validate(namespaceSpec, jobSpec); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid job %s", err.Error(), jobSpec.Name))
+		}
+		for _, existingJob := range staged.existingJobs {
+			if existingJob.Name == jobSpec.Name {
+				problems = append(problems, fmt.Sprintf("job %s already exists in namespace %s", jobSpec.Name, staged.spec.Name))
+			}
+		}
+		staged.jobSpecs = append(staged.jobSpecs, jobSpec)
+	}
+
+	for _, item := range req.GetResources() {
+		staged, ok := stagedNamespaces[item.GetNamespaceName()]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("resource %s refers namespace %s missing from bundle", item.GetResource().GetName(), item.GetNamespaceName()))
+			continue
+		}
+		datastoreName := item.GetDatastoreName()
+		resourceSpec, err := sv.adapter.FromResourceProto(item.GetResource(), datastoreName)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid resource %s", err.Error(), item.GetResource().GetName()))
+			continue
+		}
+		if _, fetched := staged.existingResources[datastoreName]; staged.existing != nil && !fetched {
+			if staged.existingResources[datastoreName], err = sv.resourceSvc.GetAll(*staged.existing, datastoreName); err != nil {
+				return nil, status.Errorf(codes.Internal, "%s: failed to fetch resources of namespace %s", err.Error(), staged.spec.Name)
+			}
+		}
+		for _, existingSpec := range staged.existingResources[datastoreName] {
+			if existingSpec.Name == resourceSpec.Name {
+				problems = append(problems, fmt.Sprintf("resource %s already exists in namespace %s", resourceSpec.Name, staged.spec.Name))
+			}
+		}
+		for _, stagedSpec := range staged.resourceSpecs[datastoreName] {
+			if stagedSpec.Name == resourceSpec.Name {
+				problems = append(problems, fmt.Sprintf("duplicate resource %s in namespace %s", resourceSpec.Name, staged.spec.Name))
+			}
+		}
+		staged.resourceSpecs[datastoreName] = append(staged.resourceSpecs[datastoreName], resourceSpec)
+	}
+
+	if len(problems) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid bundle of project %s:\n%s",
+			projSpec.Name, strings.Join(problems, "\n"))
+	}
+	return bundle, nil
+}
+
+// applyImport persists the staged bundle, returns the namespaces it touched
+// so the jobs and resources created in them can be rolled back on failure
+func (sv *RuntimeServiceServer) applyImport(ctx context.Context, bundle *importBundle,
+	respStream pb.RuntimeService_ImportProjectServer) ([]*importNamespace, error) {
+	projSpec := bundle.project
+	projectRepo := sv.projectRepoFactory.New()
+	if err := projectRepo.Save(projSpec); err != nil {
+		return nil, errors.Wrapf(err, "failed to save project %s", projSpec.Name)
+	}
+	savedProjSpec, err := projectRepo.GetByName(projSpec.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find project %s", projSpec.Name)
+	}
+
+	var applied []*importNamespace
+	namespaceRepo := sv.namespaceRepoFactory.New(savedProjSpec)
+	for _, staged := range bundle.namespaces {
+		if err := namespaceRepo.Save(staged.spec); err != nil {
+			return applied, errors.Wrapf(err, "failed to save namespace %s", staged.spec.Name)
+		}
+		if staged.spec, err = namespaceRepo.GetByName(staged.spec.Name); err != nil {
+			return applied, errors.Wrapf(err, "failed to find namespace %s", staged.spec.Name)
+		}
+		applied = append(applied, staged)
+
+		for _, jobSpec := range staged.jobSpecs {
+			if err := sv.jobSvc.Create(staged.spec, jobSpec); err != nil {
+				return applied, errors.Wrapf(err, "failed to save job %s", jobSpec.Name)
+			}
+		}
+
+		var datastoreNames []string
+		for datastoreName := range staged.resourceSpecs {
+			datastoreNames = append(datastoreNames, datastoreName)
+		}
+		sort.Strings(datastoreNames)
+		var resourceCount int
+		for _, datastoreName := range datastoreNames {
+			if err := sv.resourceSvc.CreateResource(ctx, staged.spec, staged.resourceSpecs[datastoreName], sv.progressObserver); err != nil {
+				return applied, errors.Wrapf(err, "failed to create resources of datastore %s", datastoreName)
+			}
+			resourceCount += len(staged.resourceSpecs[datastoreName])
+		}
+
+		if err := respStream.Send(&pb.ImportProjectResponse{
+			Success: true,
+			Message: fmt.Sprintf("imported %d jobs and %d resources of namespace %s", len(staged.jobSpecs), resourceCount, staged.spec.Name),
+		}); err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// rollbackImport deletes jobs and resources created by the import and
+// restores the project and namespaces it changed, a project registered by
+// the import is deleted. Jobs and resources to keep are listed at rollback
+// so anything saved outside the import is kept, namespaces the import
+// added to an existing project stay registered
+func (sv *RuntimeServiceServer) rollbackImport(ctx context.Context, bundle *importBundle, applied []*importNamespace) error {
+	var failures []string
+	for _, staged := range applied {
+		if len(staged.jobSpecs) > 0 {
+			if err := sv.rollbackImportedJobs(staged); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: failed to delete jobs of namespace %s", err.Error(), staged.spec.Name))
+			}
+		}
+		for datastoreName, resourceSpecs := range staged.resourceSpecs {
+			if err := sv.rollbackImportedResources(ctx, staged.spec, datastoreName, resourceSpecs); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: failed to delete resources of namespace %s", err.Error(), staged.spec.Name))
+			}
+		}
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	if bundle.existingProject == nil {
+		if len(failures) == 0 {
+			if err := projectRepo.Delete(bundle.project.Name); err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+				failures = append(failures, fmt.Sprintf("%s: failed to delete project %s", err.Error(), bundle.project.Name))
+			}
+		}
+	} else {
+		if err := projectRepo.Save(*bundle.existingProject); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: failed to restore project %s", err.Error(), bundle.project.Name))
+		}
+		namespaceRepo := sv.namespaceRepoFactory.New(*bundle.existingProject)
+		for _, staged := range applied {
+			if staged.existing == nil {
+				continue
+			}
+			if err := namespaceRepo.Save(*staged.existing); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: failed to restore namespace %s", err.Error(), staged.spec.Name))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+// rollbackImportedJobs deletes jobs of the namespace created by the import
+func (sv *RuntimeServiceServer) rollbackImportedJobs(staged *importNamespace) error {
+	imported := map[string]bool{}
+	for _, jobSpec := range staged.jobSpecs {
+		imported[jobSpec.Name] = true
+	}
+	currentSpecs, err := sv.jobSvc.GetAll(staged.spec)
+	if err != nil {
+		return err
+	}
+	var jobsToKeep []models.JobSpec
+	for _, jobSpec := range currentSpecs {
+		if !imported[jobSpec.Name] {
+			jobsToKeep = append(jobsToKeep, jobSpec)
+		}
+	}
+	return sv.jobSvc.KeepOnly(staged.spec, jobsToKeep, true, nil)
+}
+
+// rollbackImportedResources deletes resources of the namespace created by the import
+func (sv *RuntimeServiceServer) rollbackImportedResources(ctx context.Context, namespace models.NamespaceSpec,
+	datastoreName string, resourceSpecs []models.ResourceSpec) error {
+	imported := map[string]bool{}
+	for _, resourceSpec := range resourceSpecs {
+		imported[resourceSpec.Name] = true
+	}
+	currentSpecs, err := sv.resourceSvc.GetAll(namespace, datastoreName)
+	if err != nil {
+		return err
+	}
+	var resourcesToKeep []models.ResourceSpec
+	for _, resourceSpec := range currentSpecs {
+		if !imported[resourceSpec.Name] {
+			resourcesToKeep = append(resourcesToKeep, resourceSpec)
+		}
+	}
+	return sv.resourceSvc.KeepOnly(ctx, namespace, datastoreName, resourcesToKeep, nil)
+}
+
+// cloneNamespaces copies namespaces of source project along with their jobs
+// and resources to the target project. Resource specs are only saved, they
+// are created in the datastore when the target project deploys them
+func (sv *RuntimeServiceServer) cloneNamespaces(targetProjectName string, sourceNamespaces []models.NamespaceSpec, resourceProject string) error {
+	targetProjSpec, err := sv.projectRepoFactory.New().GetByName(targetProjectName)
+	if err != nil {
+		return status.Errorf(errorCode(err), "%s: failed to find project %s", err.Error(), targetProjectName)
+	}
+
+	targetNamespaceRepo := sv.namespaceRepoFactory.New(targetProjSpec)
+	for _, sourceNamespace := range sourceNamespaces {
+		if err := targetNamespaceRepo.Save(models.NamespaceSpec{
+			Name:   sourceNamespace.Name,
+			Config: sourceNamespace.Config,
+		}); err != nil {
+			return status.Errorf(codes.Internal, "%s: failed to save namespace %s for project %s", err.Error(), sourceNamespace.Name, targetProjSpec.Name)
+		}
+		targetNamespace, err := targetNamespaceRepo.GetByName(sourceNamespace.Name)
+		if err != nil {
+			return status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), sourceNamespace.Name)
+		}
+
+		jobSpecs, err := sv.jobSvc.GetAll(sourceNamespace)
+		if err != nil {
+			return status.Errorf(codes.Internal, "%s: failed to fetch jobs of namespace %s", err.Error(), sourceNamespace.Name)
+		}
+		for _, jobSpec := range jobSpecs {
+			jobSpec.ID = uuid.Nil
+			if err := sv.jobSvc.Create(targetNamespace, jobSpec); err != nil {
+				return status.Errorf(errorCode(err), "%s: failed to save %s", err.Error(), jobSpec.Name)
+			}
+		}
+
+		for _, ds := range models.DatastoreRegistry.GetAll() {
+			resourceSpecs, err := sv.resourceSvc.GetAll(sourceNamespace, ds.Name())
+			if err != nil {
+				return status.Errorf(codes.Internal, "%s: failed to fetch resources of namespace %s", err.Error(), sourceNamespace.Name)
+			}
+			if len(resourceSpecs) == 0 {
+				continue
+			}
+			for idx, resourceSpec := range resourceSpecs {
+				if resourceSpecs[idx], err = sv.cloneResourceSpec(resourceSpec, ds.Name(), resourceProject); err != nil {
+					return status.Errorf(errorCode(err), "%s: failed to clone resource %s", err.Error(), resourceSpec.Name)
+				}
+			}
+			if err := sv.resourceSvc.SaveResource(targetNamespace, resourceSpecs); err != nil {
+				return status.Errorf(errorCode(err), "%s: failed to save resources of namespace %s", err.Error(), targetNamespace.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// cloneResourceSpec prepares a copy of resource to be saved in another
+// project, replacing the project part of its name if resourceProject is set
+func (sv *RuntimeServiceServer) cloneResourceSpec(spec models.ResourceSpec, datastoreName, resourceProject string) (models.ResourceSpec, error) {
+	spec.ID = uuid.Nil
+	nameParts := strings.SplitN(spec.Name, ".", 2)
+	if resourceProject == "" || len(nameParts) < 2 {
+		return spec, nil
+	}
+
+	// name is parsed by the datastore, going through its adapter keeps the
+	// parsed spec in sync with the new name
+	protoSpec, err := sv.adapter.ToResourceProto(spec)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	protoSpec.Name = resourceProject + "." + nameParts[1]
+	return sv.adapter.FromResourceProto(protoSpec, datastoreName)
+}
+
+func (sv *RuntimeServiceServer) ListProjectNamespaces(ctx context.Context, req *pb.ListProjectNamespacesRequest) (*pb.ListProjectNamespacesResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpecs, err := namespaceRepo.GetAll()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: error while fetching namespaces", err.Error())
+	}
+
+	namespaceSpecsProto := []*pb.NamespaceSpecification{}
+	for _, namespace := range namespaceSpecs {
+		namespaceSpecsProto = append(namespaceSpecsProto, sv.adapter.ToNamespaceProto(namespace))
+	}
+
+	return &pb.ListProjectNamespacesResponse{
+		Namespaces: namespaceSpecsProto,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) RegisterInstance(ctx context.Context, req *pb.RegisterInstanceRequest) (*pb.RegisterInstanceResponse, error) {
+	jobScheduledTime, err := ptypes.Timestamp(req.GetScheduledAt())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse schedule time of job %s", err.Error(), req.GetScheduledAt())
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	jobSpec, namespaceSpec, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: job %s not found", err.Error(), req.GetJobName())
+	}
+	// windows of dependencies are made available in the instance context
+	jobSpec, err = sv.jobSvc.ResolveDependencies(projSpec, jobSpec)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to resolve dependencies of job %s", err.Error(), req.GetJobName())
+	}
+	jobProto, err := sv.adapter.ToJobProto(jobSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: cannot adapt job %s", err.Error(), jobSpec.Name)
+	}
+	compileNamespaceSpec, err := withExecutorSecrets(ctx, namespaceSpec, jobSpec)
+	if err != nil {
+		return nil, err
+	}
+	// destination of the instance and its compiled assets are generated
+	// from remote assets of job
+	if jobSpec.Assets, err = instance.ResolveAssets(ctx, sv.assetLoaderOf(projSpec), jobSpec.Assets); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to resolve assets of job %s", err.Error(), req.GetJobName())
+	}
+
+	instanceType, err := models.InstanceType("").New(req.InstanceType.String())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: instance type %s not found", err.Error(), req.InstanceType.String())
+	}
+	instance, err := sv.instSvc.Register(jobSpec, jobScheduledTime, instanceType)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to register instance of job %s", err.Error(), req.GetJobName())
+	}
+	envMap, fileMap, err := sv.instSvc.Compile(compileNamespaceSpec, jobSpec, instance, instanceType, req.InstanceName)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to compile instance of job %s", err.Error(), req.GetJobName())
+	}
+
+	instanceProto, err := sv.adapter.ToInstanceProto(instance)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: cannot adapt instance for job %s", err.Error(), jobSpec.Name)
+	}
+	return &pb.RegisterInstanceResponse{
+		Project:   sv.adapter.ToProjectProto(projSpec),
+		Job:       jobProto,
+		Instance:  instanceProto,
+		Namespace: sv.adapter.ToNamespaceProto(namespaceSpec),
+		Context: &pb.InstanceContext{
+			Envs:  envMap,
+			Files: fileMap,
+		},
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) GetInstance(ctx context.Context, req *pb.GetInstanceRequest) (*pb.GetInstanceResponse, error) {
+	jobScheduledTime, err := ptypes.Timestamp(req.GetScheduledAt())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse schedule time of job %s", err.Error(), req.GetScheduledAt())
+	}
+
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	jobSpec, namespaceSpec, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: job %s not found", err.Error(), req.GetJobName())
+	}
+	jobProto, err := sv.adapter.ToJobProto(jobSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: cannot adapt job %s", err.Error(), jobSpec.Name)
+	}
+	compileNamespaceSpec, err := withExecutorSecrets(ctx, namespaceSpec, jobSpec)
+	if err != nil {
+		return nil, err
+	}
+	// destination of the instance and its compiled assets are generated
+	// from remote assets of job
+	if jobSpec.Assets, err = instance.ResolveAssets(ctx, sv.assetLoaderOf(projSpec), jobSpec.Assets); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to resolve assets of job %s", err.Error(), req.GetJobName())
+	}
+
+	instanceType, err := models.InstanceType("").New(req.InstanceType.String())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: instance type %s not found", err.Error(), req.InstanceType.String())
+	}
+	instance, err := sv.instSvc.Get(jobSpec, jobScheduledTime)
+	if err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%s: instance of job %s scheduled at %s is not registered",
+				err.Error(), req.GetJobName(), jobScheduledTime.Format(time.RFC3339))
+		}
+		return nil, status.Errorf(codes.Internal, "%s: failed to get instance of job %s", err.Error(), req.GetJobName())
+	}
+	envMap, fileMap, err := sv.instSvc.Compile(compileNamespaceSpec, jobSpec, instance, instanceType, req.InstanceName)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to compile instance of job %s", err.Error(), req.GetJobName())
+	}
+
+	instanceProto, err := sv.adapter.ToInstanceProto(instance)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: cannot adapt instance for job %s", err.Error(), jobSpec.Name)
+	}
+	return &pb.GetInstanceResponse{
+		Project:   sv.adapter.ToProjectProto(projSpec),
+		Job:       jobProto,
+		Instance:  instanceProto,
+		Namespace: sv.adapter.ToNamespaceProto(namespaceSpec),
+		Context: &pb.InstanceContext{
+			Envs:  envMap,
+			Files: fileMap,
+		},
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) JobStatus(ctx context.Context, req *pb.JobStatusRequest) (*pb.JobStatusResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	_, _, err = sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
+	}
+
+	filter := models.JobStatusFilter{
+		Offset: int(req.GetPageOffset()),
+	}
+	if req.GetStartDate() != nil {
+		filter.StartDate = req.GetStartDate().AsTime()
+	}
+	if req.GetEndDate() != nil {
+		filter.EndDate = req.GetEndDate().AsTime()
+	}
+	if !filter.StartDate.IsZero() && !filter.EndDate.IsZero() && filter.EndDate.Before(filter.StartDate) {
+		return nil, status.Errorf(codes.InvalidArgument, "job status end date cannot be before start date")
+	}
+	for _, state := range req.GetStates() {
+		filter.States = append(filter.States, models.JobStatusState(strings.ToLower(state)))
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 || pageSize > jobRunHistoryMaxPageSize || filter.Offset < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page size should be between 0 and %d with a non negative offset",
+			jobRunHistoryMaxPageSize)
+	}
+	if pageSize > 0 {
+		// one more run tells if there is a next page
+		filter.Limit = pageSize + 1
+	}
+
+	scheduler, err := sv.schedulerForProject(projSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: failed to find scheduler for project %s", err.Error(), req.GetProjectName())
+	}
+
+	jobStatuses, err := scheduler.GetJobStatus(ctx, projSpec, req.GetJobName(), filter)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s: failed to fetch jobStatus %s", err.Error(),
+			req.GetJobName())
+	}
+
+	var nextPageOffset int32
+	if pageSize > 0 && len(jobStatuses) > pageSize {
+		jobStatuses = jobStatuses[:pageSize]
+		nextPageOffset = int32(filter.Offset + pageSize)
+	}
+
+	var adaptedJobStatus []*pb.JobStatus
+	for _, jobStatus := range jobStatuses {
+		jobStatusProto, err := toJobStatusProto(jobStatus)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: failed to parse time for %s", err.Error(), req.GetJobName())
+		}
+		adaptedJobStatus = append(adaptedJobStatus, jobStatusProto)
+	}
+	return &pb.JobStatusResponse{
+		Statuses:       adaptedJobStatus,
+		NextPageOffset: nextPageOffset,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) GetJobRunHistory(ctx context.Context, req *pb.GetJobRunHistoryRequest) (*pb.GetJobRunHistoryResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	jobSpec, _, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
+	}
+
+	if req.GetStartDate() == nil {
+		return nil, status.Error(codes.InvalidArgument, "start date of run history is required")
+	}
+	startDate := req.GetStartDate().AsTime()
+	endDate := time.Now().UTC()
+	if req.GetEndDate() != nil {
+		endDate = req.GetEndDate().AsTime()
+	}
+	if endDate.Before(startDate) {
+		return nil, status.Errorf(codes.InvalidArgument, "run history end date cannot be before start date")
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = jobRunHistoryDefaultPageSize
+	}
+	pageOffset := int(req.GetPageOffset())
+	if pageSize < 0 || pageSize > jobRunHistoryMaxPageSize || pageOffset < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page size should be between 1 and %d with a non negative offset",
+			jobRunHistoryMaxPageSize)
+	}
+
+	scheduler, err := sv.schedulerForProject(projSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: failed to find scheduler for project %s", err.Error(), req.GetProjectName())
+	}
+	jobRuns, err := scheduler.GetDagRunStatus(ctx, projSpec, jobSpec.Name, startDate, endDate, jobRunHistoryBatchSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to fetch runs of job %s", err.Error(), req.GetJobName())
+	}
+	// latest run first
+	sort.SliceStable(jobRuns, func(i, j int) bool {
+		return jobRuns[i].ScheduledAt.After(jobRuns[j].ScheduledAt)
+	})
+
+	stats, err := toJobRunStatsProto(jobRuns)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to compute run stats of job %s", err.Error(), req.GetJobName())
+	}
+
+	var nextPageOffset int32
+	pageRuns := []*pb.JobStatus{}
+	if pageOffset < len(jobRuns) {
+		pageEnd := pageOffset + pageSize
+		if pageEnd < len(jobRuns) {
+			nextPageOffset = int32(pageEnd)
+		} else {
+			pageEnd = len(jobRuns)
+		}
+		for _, jobRun := range jobRuns[pageOffset:pageEnd] {
+			jobRunProto, err := toJobStatusProto(jobRun)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "%s: failed to parse time for %s", err.Error(), req.GetJobName())
+			}
+			pageRuns = append(pageRuns, jobRunProto)
+		}
+	}
+	return &pb.GetJobRunHistoryResponse{
+		Runs:           pageRuns,
+		Stats:          stats,
+		NextPageOffset: nextPageOffset,
+	}, nil
+}
+
+// RunJob triggers or clears the run of a job at a scheduled time through the
+// scheduler of project, scheduled time should be produced by job schedule
+func (sv *RuntimeServiceServer) RunJob(ctx context.Context, req *pb.RunJobRequest) (*pb.RunJobResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	jobSpec, _, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
+	}
+
+	if req.GetScheduledAt() == nil {
+		return nil, status.Error(codes.InvalidArgument, "scheduled time of the run is required")
+	}
+	scheduledAt := req.GetScheduledAt().AsTime()
+	if scheduledAt.After(time.Now()) {
+		return nil, status.Errorf(codes.InvalidArgument, "run of %s cannot be scheduled in future", req.GetJobName())
+	}
+	schedule, err := cron.ParseCronSchedule(jobSpec.Schedule.Interval)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to parse schedule of job %s", err.Error(), req.GetJobName())
+	}
+	loc, err := jobSpec.Schedule.Location()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to read schedule of job %s", err.Error(), req.GetJobName())
+	}
+	// schedule is evaluated in the timezone of job
+	if !schedule.Next(scheduledAt.In(loc).Add(-time.Second)).Equal(scheduledAt) {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a scheduled time of job %s with interval %s",
+			scheduledAt.Format(time.RFC3339), req.GetJobName(), jobSpec.Schedule.Interval)
+	}
+
+	scheduler, err := sv.schedulerForProject(projSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: failed to find scheduler for project %s", err.Error(), req.GetProjectName())
+	}
+	if err := scheduler.RunJob(ctx, projSpec, jobSpec.Name, scheduledAt); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to run job %s", err.Error(), req.GetJobName())
+	}
+	return &pb.RunJobResponse{
+		Success: true,
+		Message: fmt.Sprintf("run of %s scheduled at %s requested", jobSpec.Name, scheduledAt.Format(time.RFC3339)),
+	}, nil
+}
+
+// GetJobRunLogs streams logs of task attempts of a job run pulled from the
+// scheduler of project
+func (sv *RuntimeServiceServer) GetJobRunLogs(req *pb.GetJobRunLogsRequest, respStream pb.RuntimeService_GetJobRunLogsServer) error {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	jobSpec, _, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return status.Errorf(errorCode(err), "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
+	}
+
+	if req.GetScheduledAt() == nil {
+		return status.Error(codes.InvalidArgument, "scheduled time of the run is required")
+	}
+	if req.GetTryNumber() < 0 {
+		return status.Error(codes.InvalidArgument, "try number cannot be negative")
+	}
+
+	scheduler, err := sv.schedulerForProject(projSpec)
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "%s: failed to find scheduler for project %s", err.Error(), req.GetProjectName())
+	}
+	logRequest := models.JobRunLogRequest{
+		ScheduledAt: req.GetScheduledAt().AsTime(),
+		TaskName:    req.GetTaskName(),
+		TryNumber:   int(req.GetTryNumber()),
+	}
+	if err := scheduler.GetJobRunLogs(respStream.Context(), projSpec, jobSpec.Name, logRequest, func(log models.JobRunLog) error {
+		return respStream.Send(&pb.GetJobRunLogsResponse{
+			TaskName:  log.TaskName,
+			TryNumber: int32(log.TryNumber),
+			Content:   log.Content,
+		})
+	}); err != nil {
+		return status.Errorf(errorCode(err), "%s: failed to fetch logs of job %s", err.Error(), req.GetJobName())
+	}
+	return nil
+}
+
+func toJobStatusProto(jobStatus models.JobStatus) (*pb.JobStatus, error) {
+	scheduledAt, err := ptypes.TimestampProto(jobStatus.ScheduledAt)
+	if err != nil {
+		return nil, err
+	}
+	jobStatusProto := &pb.JobStatus{
+		State:       jobStatus.State.String(),
+		ScheduledAt: scheduledAt,
+		TryNumber:   int32(jobStatus.TryNumber),
+	}
+	if !jobStatus.StartedAt.IsZero() {
+		if jobStatusProto.StartedAt, err = ptypes.TimestampProto(jobStatus.StartedAt); err != nil {
+			return nil, err
+		}
+	}
+	if !jobStatus.EndedAt.IsZero() {
+		if jobStatusProto.EndedAt, err = ptypes.TimestampProto(jobStatus.EndedAt); err != nil {
+			return nil, err
+		}
+	}
+	if duration := jobStatus.Duration(); duration > 0 {
+		jobStatusProto.Duration = ptypes.DurationProto(duration)
+	}
+	return jobStatusProto, nil
+}
+
+// toJobRunStatsProto aggregates the runs, success rate and average duration
+// only account runs that have finished
+func toJobRunStatsProto(jobRuns []models.JobStatus) (*pb.JobRunStats, error) {
+	stats := &pb.JobRunStats{
+		TotalRuns: int32(len(jobRuns)),
+	}
+	var lastFailure time.Time
+	var totalDuration time.Duration
+	var finishedRuns int
+	for _, jobRun := range jobRuns {
+		switch jobRun.State {
+		case models.JobStatusStateSuccess:
+			stats.SuccessfulRuns++
+		case models.JobStatusStateFailed:
+			stats.FailedRuns++
+			if jobRun.ScheduledAt.After(lastFailure) {
+				lastFailure = jobRun.ScheduledAt
+			}
+		default:
+			continue
+		}
+		if duration := jobRun.Duration(); duration > 0 {
+			totalDuration += duration
+			finishedRuns++
+		}
+	}
+
+	if completed := stats.SuccessfulRuns + stats.FailedRuns; completed > 0 {
+		stats.SuccessRate = float64(stats.SuccessfulRuns) / float64(completed)
+	}
+	if finishedRuns > 0 {
+		stats.AverageDuration = ptypes.DurationProto(totalDuration / time.Duration(finishedRuns))
+	}
+	if !lastFailure.IsZero() {
+		lastFailureAt, err := ptypes.TimestampProto(lastFailure)
+		if err != nil {
+			return nil, err
+		}
+		stats.LastFailureAt = lastFailureAt
+	}
+	return stats, nil
+}
+
+// PreviewSchedule computes upcoming runs of an interval using the same parser
+// that validates job schedules during deployment
+func (sv *RuntimeServiceServer) PreviewSchedule(ctx context.Context, req *pb.PreviewScheduleRequest) (*pb.PreviewScheduleResponse, error) {
+	schedule, err := cron.ParseCronSchedule(req.GetInterval())
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse schedule time of job %s", err.Error(), req.GetScheduledAt())
+		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse schedule interval %s", err.Error(), req.GetInterval())
+	}
+
+	startDate := time.Now().UTC()
+	if req.GetStartDate() != nil {
+		startDate = req.GetStartDate().AsTime()
+	}
+	var endDate time.Time
+	if req.GetEndDate() != nil {
+		endDate = req.GetEndDate().AsTime()
+		if endDate.Before(startDate) {
+			return nil, status.Error(codes.InvalidArgument, "schedule preview end date cannot be before start date")
+		}
+	}
+	count := int(req.GetCount())
+	if count < 0 || count > schedulePreviewMaxCount {
+		return nil, status.Errorf(codes.InvalidArgument, "count of runs should be between 1 and %d", schedulePreviewMaxCount)
+	}
+	if count == 0 {
+		count = schedulePreviewMaxCount
+		if endDate.IsZero() {
+			count = schedulePreviewDefaultCount
+		}
 	}
 
+	runs := []*timestamppb.Timestamp{}
+	// start date is inclusive
+	for run := schedule.Next(startDate.Add(-time.Second)); len(runs) < count; run = schedule.Next(run) {
+		// schedules that can never be satisfied, e.g. 30th of february, return zero time
+		if run.IsZero() || (!endDate.IsZero() && run.After(endDate)) {
+			break
+		}
+		runs = append(runs, timestamppb.New(run))
+	}
+	return &pb.PreviewScheduleResponse{
+		Runs: runs,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ListDownstreamJobs(ctx context.Context, req *pb.ListDownstreamJobsRequest) (*pb.ListDownstreamJobsResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	jobSpec, namespaceSpec, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	_, _, err = sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: job %s not found", err.Error(), req.GetJobName())
+		return nil, status.Errorf(errorCode(err), "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
 	}
-	jobProto, err := sv.adapter.ToJobProto(jobSpec)
+
+	downstreamSpecs, err := sv.jobSvc.GetDownstream(projSpec, req.GetJobName(), req.GetTransitive())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: cannot adapt job %s", err.Error(), jobSpec.Name)
+		return nil, status.Errorf(codes.Internal, "%s: failed to resolve downstream jobs of %s", err.Error(),
+			req.GetJobName())
 	}
 
-	instanceType, err := models.InstanceType("").New(req.InstanceType.String())
-	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "%s: instance type %s not found", err.Error(), req.InstanceType.String())
+	jobProtos := []*pb.JobSpecification{}
+	for _, jobSpec := range downstreamSpecs {
+		jobProto, err := sv.adapter.ToJobProto(jobSpec)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: failed to parse job spec %s", err.Error(), jobSpec.Name)
+		}
+		jobProtos = append(jobProtos, jobProto)
 	}
-	instance, err := sv.instSvc.Register(jobSpec, jobScheduledTime, instanceType)
+	return &pb.ListDownstreamJobsResponse{
+		Jobs: jobProtos,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ExplainDependency(ctx context.Context, req *pb.ExplainDependencyRequest) (*pb.ExplainDependencyResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to register instance of job %s", err.Error(), req.GetJobName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
-	envMap, fileMap, err := sv.instSvc.Compile(namespaceSpec, jobSpec, instance, instanceType, req.InstanceName)
+
+	jobSpec, _, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to compile instance of job %s", err.Error(), req.GetJobName())
+		return nil, status.Errorf(errorCode(err), "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
 	}
 
-	instanceProto, err := sv.adapter.ToInstanceProto(instance)
+	explanations, err := sv.jobSvc.ExplainDependency(projSpec, jobSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: cannot adapt instance for job %s", err.Error(), jobSpec.Name)
+		return nil, status.Errorf(codes.Internal, "%s: failed to explain dependencies of %s", err.Error(),
+			req.GetJobName())
 	}
-	return &pb.RegisterInstanceResponse{
-		Project:   sv.adapter.ToProjectProto(projSpec),
-		Job:       jobProto,
-		Instance:  instanceProto,
-		Namespace: sv.adapter.ToNamespaceProto(namespaceSpec),
-		Context: &pb.InstanceContext{
-			Envs:  envMap,
-			Files: fileMap,
-		},
+
+	explanationProtos := []*pb.DependencyExplanation{}
+	for _, explanation := range explanations {
+		candidateProtos := []*pb.DependencyCandidate{}
+		for _, candidate := range explanation.Candidates {
+			candidateProtos = append(candidateProtos, &pb.DependencyCandidate{
+				JobName:     candidate.Job,
+				ProjectName: candidate.Project,
+				Matched:     candidate.Matched,
+				Reason:      candidate.Reason,
+			})
+		}
+		explanationProtos = append(explanationProtos, &pb.DependencyExplanation{
+			Destination: explanation.Destination,
+			Resolved:    explanation.Resolved,
+			Candidates:  candidateProtos,
+		})
+	}
+	return &pb.ExplainDependencyResponse{
+		Dependencies: explanationProtos,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) JobStatus(ctx context.Context, req *pb.JobStatusRequest) (*pb.JobStatusResponse, error) {
+func (sv *RuntimeServiceServer) GetJobDependencyGraph(ctx context.Context, req *pb.GetJobDependencyGraphRequest) (*pb.GetJobDependencyGraphResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
-	}
-
-	_, _, err = sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
-	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to find the job %s for project %s", err.Error(),
-			req.GetJobName(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	jobStatuses, err := sv.scheduler.GetJobStatus(ctx, projSpec, req.GetJobName())
+	graph, err := sv.jobSvc.GetDependencyGraph(projSpec, req.GetJobName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to fetch jobStatus %s", err.Error(),
-			req.GetJobName())
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%s: failed to find the job %s for project %s", err.Error(),
+				req.GetJobName(), req.GetProjectName())
+		}
+		return nil, status.Errorf(codes.Internal, "%s: failed to resolve dependency graph of project %s", err.Error(),
+			req.GetProjectName())
 	}
 
-	var adaptedJobStatus []*pb.JobStatus
-	for _, jobStatus := range jobStatuses {
-		ts, err := ptypes.TimestampProto(jobStatus.ScheduledAt)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "%s: failed to parse time for %s", err.Error(), req.GetJobName())
+	nodeProto := func(node models.JobDependencyGraphNode) *pb.JobDependencyGraphNode {
+		return &pb.JobDependencyGraphNode{
+			JobName:     node.Name,
+			ProjectName: node.Project,
 		}
-		adaptedJobStatus = append(adaptedJobStatus, &pb.JobStatus{
-			State:       jobStatus.State.String(),
-			ScheduledAt: ts,
+	}
+	jobProtos := []*pb.JobDependencyGraphNode{}
+	for _, node := range graph.Jobs {
+		jobProtos = append(jobProtos, nodeProto(node))
+	}
+	edgeProtos := []*pb.JobDependencyGraphEdge{}
+	for _, edge := range graph.Edges {
+		edgeProtos = append(edgeProtos, &pb.JobDependencyGraphEdge{
+			Upstream:   nodeProto(edge.Upstream),
+			Downstream: nodeProto(edge.Downstream),
+			Type:       edge.Type.String(),
+			Inferred:   edge.Inferred,
 		})
 	}
-	return &pb.JobStatusResponse{
-		Statuses: adaptedJobStatus,
+	return &pb.GetJobDependencyGraphResponse{
+		Jobs:  jobProtos,
+		Edges: edgeProtos,
 	}, nil
 }
 
@@ -526,18 +2419,18 @@ func (sv *RuntimeServiceServer) RegisterJobEvent(ctx context.Context, req *pb.Re
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to find the job %s for namespace %s", err.Error(),
+		return nil, status.Errorf(errorCode(err), "%s: failed to find the job %s for namespace %s", err.Error(),
 			req.GetJobName(), req.GetNamespace())
 	}
 
@@ -553,7 +2446,7 @@ func (sv *RuntimeServiceServer) RegisterJobEvent(ctx context.Context, req *pb.Re
 		Type:  models.JobEventType(strings.ToLower(req.GetEvent().Type.String())),
 		Value: eventValues,
 	}); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to register event: %s", err)
+		return nil, status.Errorf(errorCode(err), "failed to register event: %s", err)
 	}
 
 	return &pb.RegisterJobEventResponse{}, nil
@@ -562,7 +2455,7 @@ func (sv *RuntimeServiceServer) RegisterJobEvent(ctx context.Context, req *pb.Re
 func (sv *RuntimeServiceServer) GetWindow(ctx context.Context, req *pb.GetWindowRequest) (*pb.GetWindowResponse, error) {
 	scheduledTime, err := ptypes.Timestamp(req.GetScheduledAt())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to parse schedule time %s", err.Error(), req.GetScheduledAt())
+		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse schedule time %s", err.Error(), req.GetScheduledAt())
 	}
 
 	if req.GetSize() == "" || req.GetOffset() == "" || req.GetTruncateTo() == "" {
@@ -571,7 +2464,19 @@ func (sv *RuntimeServiceServer) GetWindow(ctx context.Context, req *pb.GetWindow
 
 	window, err := prepareWindow(req.GetSize(), req.GetOffset(), req.GetTruncateTo())
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// windows are aligned to the requested timezone, runs of interval are
+	// evaluated in it as well
+	loc, err := models.JobSpecSchedule{Timezone: req.GetTimezone()}.Location()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	scheduledTime = scheduledTime.In(loc)
+
+	if req.GetInterval() != "" {
+		return windowSeries(window, req.GetInterval(), scheduledTime, int(req.GetCount()))
 	}
 
 	windowStart, err1 := ptypes.TimestampProto(window.GetStart(scheduledTime))
@@ -586,9 +2491,67 @@ func (sv *RuntimeServiceServer) GetWindow(ctx context.Context, req *pb.GetWindow
 	}, nil
 }
 
+// windowSeries returns windows of the last count runs of the cron schedule
+// at or before reference time, ordered from the oldest run
+func windowSeries(window models.JobSpecTaskWindow, interval string, reference time.Time, count int) (*pb.GetWindowResponse, error) {
+	schedule, err := cron.ParseCronSchedule(interval)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse schedule interval %s", err.Error(), interval)
+	}
+	if count < 0 || count > windowSeriesMaxCount {
+		return nil, status.Errorf(codes.InvalidArgument, "count of windows should be between 1 and %d", windowSeriesMaxCount)
+	}
+	if count == 0 {
+		count = windowSeriesDefaultCount
+	}
+
+	windows := []*pb.GetWindowResponse_Window{}
+	for _, run := range lastScheduledRuns(schedule, reference, count) {
+		windows = append(windows, &pb.GetWindowResponse_Window{
+			ScheduledAt: timestamppb.New(run),
+			Start:       timestamppb.New(window.GetStart(run)),
+			End:         timestamppb.New(window.GetEnd(run)),
+		})
+	}
+	return &pb.GetWindowResponse{
+		Windows: windows,
+	}, nil
+}
+
+// lastScheduledRuns returns the last count runs of schedule at or before
+// reference time, runs can only be computed forwards so the lookback is
+// widened until enough runs are found
+func lastScheduledRuns(schedule *cron.ScheduleSpec, reference time.Time, count int) []time.Time {
+	next := schedule.Next(reference)
+	if next.IsZero() {
+		return nil
+	}
+	lookback := windowSeriesMaxLookback
+	if step := schedule.Next(next).Sub(next); step > 0 && step < windowSeriesMaxLookback/time.Duration(count+1) {
+		lookback = step * time.Duration(count+1)
+	}
+
+	for {
+		var runs []time.Time
+		for run := schedule.Next(reference.Add(-lookback)); !run.IsZero() && !run.After(reference); run = schedule.Next(run) {
+			runs = append(runs, run)
+		}
+		if len(runs) >= count {
+			return runs[len(runs)-count:]
+		}
+		if lookback >= windowSeriesMaxLookback {
+			return runs
+		}
+		lookback *= 2
+		if lookback > windowSeriesMaxLookback {
+			lookback = windowSeriesMaxLookback
+		}
+	}
+}
+
 func (sv *RuntimeServiceServer) RegisterSecret(ctx context.Context, req *pb.RegisterSecretRequest) (*pb.RegisterSecretResponse, error) {
 	if req.GetValue() == "" {
-		return nil, status.Error(codes.Internal, "empty value for secret")
+		return nil, status.Error(codes.InvalidArgument, "empty value for secret")
 	}
 	// decode base64
 	base64Decoded, err := base64.StdEncoding.DecodeString(req.GetValue())
@@ -599,7 +2562,7 @@ func (sv *RuntimeServiceServer) RegisterSecret(ctx context.Context, req *pb.Regi
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	secretRepo := sv.secretRepoFactory.New(projSpec)
@@ -607,7 +2570,7 @@ func (sv *RuntimeServiceServer) RegisterSecret(ctx context.Context, req *pb.Regi
 		Name:  req.GetSecretName(),
 		Value: string(base64Decoded),
 	}); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to save secret %s", err.Error(), req.GetSecretName())
+		return nil, status.Errorf(errorCode(err), "%s: failed to save secret %s", err.Error(), req.GetSecretName())
 	}
 
 	return &pb.RegisterSecretResponse{
@@ -615,84 +2578,449 @@ func (sv *RuntimeServiceServer) RegisterSecret(ctx context.Context, req *pb.Regi
 	}, nil
 }
 
+func (sv *RuntimeServiceServer) ListSecrets(ctx context.Context, req *pb.ListSecretsRequest) (*pb.ListSecretsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	secrets, err := sv.secretRepoFactory.New(projSpec).GetAll()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to retrieve secrets of project %s", err.Error(), req.GetProjectName())
+	}
+
+	secretNames := []string{}
+	for _, secret := range secrets {
+		secretNames = append(secretNames, secret.Name)
+	}
+	sort.Strings(secretNames)
+
+	return &pb.ListSecretsResponse{
+		SecretNames: secretNames,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) DeleteSecret(ctx context.Context, req *pb.DeleteSecretRequest) (*pb.DeleteSecretResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	if err := sv.secretRepoFactory.New(projSpec).Delete(req.GetSecretName()); err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%s: secret %s not found", err.Error(), req.GetSecretName())
+		}
+		return nil, status.Errorf(codes.Internal, "%s: failed to delete secret %s", err.Error(), req.GetSecretName())
+	}
+
+	return &pb.DeleteSecretResponse{
+		Success: true,
+		Message: fmt.Sprintf("secret %s has been deleted", req.GetSecretName()),
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) RotateSecretKey(ctx context.Context, req *pb.RotateSecretKeyRequest) (*pb.RotateSecretKeyResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpecs, err := projectRepo.GetAll()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to retrieve saved projects", err.Error())
+	}
+
+	rotated := 0
+	for _, projSpec := range projSpecs {
+		count, err := sv.secretRepoFactory.New(projSpec).RotateKey()
+		rotated += count
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: failed to rotate secrets of project %s after rotating %d secrets",
+				err.Error(), projSpec.Name, rotated)
+		}
+	}
+
+	return &pb.RotateSecretKeyResponse{
+		Success:        true,
+		RotatedSecrets: int32(rotated),
+	}, nil
+}
+
 func (sv *RuntimeServiceServer) CreateResource(ctx context.Context, req *pb.CreateResourceRequest) (*pb.CreateResourceResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	optResource, err := sv.adapter.FromResourceProto(req.Resource, req.DatastoreName)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to parse resource %s", err.Error(), req.Resource.GetName())
+		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse resource %s", err.Error(), req.Resource.GetName())
 	}
 
 	if err := sv.resourceSvc.CreateResource(ctx, namespaceSpec, []models.ResourceSpec{optResource}, sv.progressObserver); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to create resource %s", err.Error(), req.Resource.GetName())
+		return nil, status.Errorf(errorCode(err), "%s: failed to create resource %s", err.Error(), req.Resource.GetName())
 	}
 	return &pb.CreateResourceResponse{
 		Success: true,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) UpdateResource(ctx context.Context, req *pb.UpdateResourceRequest) (*pb.UpdateResourceResponse, error) {
+func (sv *RuntimeServiceServer) UpdateResource(ctx context.Context, req *pb.UpdateResourceRequest) (*pb.UpdateResourceResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	optResource, err := sv.adapter.FromResourceProto(req.Resource, req.DatastoreName)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: failed to parse resource %s", err.Error(), req.Resource.GetName())
+	}
+
+	if req.GetBackup() {
+		if err := sv.backupResource(ctx, namespaceSpec, req.GetDatastoreName(), optResource.Name, "before update"); err != nil {
+			return nil, status.Errorf(errorCode(err), "%s: failed to backup resource %s", err.Error(), req.Resource.GetName())
+		}
+	}
+
+	if err := sv.resourceSvc.UpdateResource(ctx, namespaceSpec, []models.ResourceSpec{optResource}, req.GetForce(), sv.progressObserver); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to create resource %s", err.Error(), req.Resource.GetName())
+	}
+	return &pb.UpdateResourceResponse{
+		Success: true,
+	}, nil
+}
+
+// RenameResource moves resource to a new name, resources that can't be renamed
+// in place by datastore are copied and deleted only when copy is allowed
+func (sv *RuntimeServiceServer) RenameResource(ctx context.Context, req *pb.RenameResourceRequest) (*pb.RenameResourceResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	if err := sv.resourceSvc.RenameResource(ctx, namespaceSpec, req.GetDatastoreName(), req.GetOldName(), req.GetNewName(), req.GetAllowCopy()); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to rename resource %s to %s", err.Error(), req.GetOldName(), req.GetNewName())
+	}
+	return &pb.RenameResourceResponse{
+		Success: true,
+		Message: fmt.Sprintf("resource %s renamed to %s", req.GetOldName(), req.GetNewName()),
+	}, nil
+}
+
+// DeleteResource removes stored spec of a resource, backing resource in the
+// datastore is deleted as well only when asked for
+func (sv *RuntimeServiceServer) DeleteResource(ctx context.Context, req *pb.DeleteResourceRequest) (*pb.DeleteResourceResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	if req.GetBackup() && req.GetDeleteFromDatastore() {
+		if err := sv.backupResource(ctx, namespaceSpec, req.GetDatastoreName(), req.GetResourceName(), "before delete"); err != nil {
+			return nil, status.Errorf(errorCode(err), "%s: failed to backup resource %s", err.Error(), req.GetResourceName())
+		}
+	}
+
+	if err := sv.resourceSvc.DeleteResource(ctx, namespaceSpec, req.GetDatastoreName(), req.GetResourceName(), req.GetDeleteFromDatastore()); err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to delete resource %s", err.Error(), req.GetResourceName())
+	}
+
+	message := fmt.Sprintf("resource %s deleted", req.GetResourceName())
+	if !req.GetDeleteFromDatastore() {
+		message = fmt.Sprintf("spec of resource %s deleted, resource is kept in datastore", req.GetResourceName())
+	}
+	return &pb.DeleteResourceResponse{
+		Success: true,
+		Message: message,
+	}, nil
+}
+
+// backupResource snapshots resource before an operation that can lose its
+// data, resources without a stored spec are new and have nothing to backup
+func (sv *RuntimeServiceServer) backupResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name, description string) error {
+	if _, err := sv.resourceSvc.BackupResource(ctx, namespace, datastoreName, name, 0, description); err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+		return err
+	}
+	return nil
+}
+
+// BackupResource copies a resource with its data in datastore, copy can be
+// restored until it expires
+func (sv *RuntimeServiceServer) BackupResource(ctx context.Context, req *pb.BackupResourceRequest) (*pb.BackupResourceResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	if req.GetTtl() != nil && req.GetTtl().AsDuration() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "ttl of backup should be positive")
+	}
+	backupSpec, err := sv.resourceSvc.BackupResource(ctx, namespaceSpec, req.GetDatastoreName(), req.GetResourceName(),
+		req.GetTtl().AsDuration(), req.GetDescription())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to backup resource %s", err.Error(), req.GetResourceName())
+	}
+
+	backupProto, err := sv.adapter.ToBackupProto(backupSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to adapt backup %s", err.Error(), backupSpec.ID)
+	}
+	return &pb.BackupResourceResponse{
+		Backup: backupProto,
+	}, nil
+}
+
+// RestoreResource overwrites a resource with its backup and brings back the
+// spec it had when backup was taken
+func (sv *RuntimeServiceServer) RestoreResource(ctx context.Context, req *pb.RestoreResourceRequest) (*pb.RestoreResourceResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	backupID, err := uuid.Parse(req.GetBackupId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s: invalid backup id %s", err.Error(), req.GetBackupId())
+	}
+	resourceSpec, err := sv.resourceSvc.RestoreResource(ctx, namespaceSpec, req.GetDatastoreName(), backupID)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to restore backup %s", err.Error(), req.GetBackupId())
+	}
+	return &pb.RestoreResourceResponse{
+		Success: true,
+		Message: fmt.Sprintf("resource %s restored from backup %s", resourceSpec.Name, req.GetBackupId()),
+	}, nil
+}
+
+// ListResourceBackups lists backups taken of resources of a datastore in namespace
+func (sv *RuntimeServiceServer) ListResourceBackups(ctx context.Context, req *pb.ListResourceBackupsRequest) (*pb.ListResourceBackupsResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	backupSpecs, err := sv.resourceSvc.GetBackups(namespaceSpec, req.GetDatastoreName())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to list backups of datastore %s", err.Error(), req.GetDatastoreName())
+	}
+	var backupProtos []*pb.BackupSpecification
+	for _, backupSpec := range backupSpecs {
+		backupProto, err := sv.adapter.ToBackupProto(backupSpec)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s: failed to adapt backup %s", err.Error(), backupSpec.ID)
+		}
+		backupProtos = append(backupProtos, backupProto)
+	}
+	return &pb.ListResourceBackupsResponse{
+		Backups: backupProtos,
+	}, nil
+}
+
+func (sv *RuntimeServiceServer) ReadResource(ctx context.Context, req *pb.ReadResourceRequest) (*pb.ReadResourceResponse, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	optResource, err := sv.adapter.FromResourceProto(req.Resource, req.DatastoreName)
+	protoResource, err := sv.readResource(ctx, namespaceSpec, req.DatastoreName, req.ResourceName, req.GetResolveAssets())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to parse resource %s", err.Error(), req.Resource.GetName())
+		return nil, err
 	}
 
-	if err := sv.resourceSvc.UpdateResource(ctx, namespaceSpec, []models.ResourceSpec{optResource}, sv.progressObserver); err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to create resource %s", err.Error(), req.Resource.GetName())
-	}
-	return &pb.UpdateResourceResponse{
-		Success: true,
+	return &pb.ReadResourceResponse{
+		Success:  true,
+		Resource: protoResource,
 	}, nil
 }
 
-func (sv *RuntimeServiceServer) ReadResource(ctx context.Context, req *pb.ReadResourceRequest) (*pb.ReadResourceResponse, error) {
+func (sv *RuntimeServiceServer) readResource(ctx context.Context, namespaceSpec models.NamespaceSpec, datastoreName, resourceName string,
+	resolveAssets bool) (*pb.ResourceSpecification, error) {
+	response, err := sv.resourceSvc.ReadResource(ctx, namespaceSpec, datastoreName, resourceName)
+	if err != nil {
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return nil, status.Errorf(codes.NotFound, "%s: resource %s not found", err.Error(), resourceName)
+		}
+		return nil, status.Errorf(errorCode(err), "%s: failed to read resource %s", err.Error(), resourceName)
+	}
+	if resolveAssets {
+		if response.Assets, err = instance.ResolveResourceAssets(ctx, sv.assetLoaderOf(namespaceSpec.ProjectSpec), response.Assets); err != nil {
+			return nil, status.Errorf(errorCode(err), "%s: failed to resolve assets of resource %s", err.Error(), resourceName)
+		}
+	}
+
+	protoResource, err := sv.adapter.ToResourceProto(response)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to adapt resource %s", err.Error(), resourceName)
+	}
+	return protoResource, nil
+}
+
+func (sv *RuntimeServiceServer) ReadResources(req *pb.ReadResourcesRequest, respStream pb.RuntimeService_ReadResourcesServer) error {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	ctx := respStream.Context()
+	for _, reqResource := range req.GetResources() {
+		resourceNames := []string{reqResource.GetResourceName()}
+		if reqResource.GetResourceName() == "" {
+			resourceSpecs, err := sv.resourceSvc.GetAll(namespaceSpec, reqResource.GetDatastoreName())
+			if err != nil {
+				if err := respStream.Send(&pb.ReadResourcesResponse{
+					Success:       false,
+					Message:       fmt.Sprintf("%s: failed to list resources of datastore %s", err.Error(), reqResource.GetDatastoreName()),
+					DatastoreName: reqResource.GetDatastoreName(),
+				}); err != nil {
+					return err
+				}
+				continue
+			}
+			resourceNames = []string{}
+			for _, resourceSpec := range resourceSpecs {
+				resourceNames = append(resourceNames, resourceSpec.Name)
+			}
+		}
+
+		for _, resourceName := range resourceNames {
+			resp := &pb.ReadResourcesResponse{
+				Success:       true,
+				DatastoreName: reqResource.GetDatastoreName(),
+				ResourceName:  resourceName,
+			}
+			if resp.Resource, err = sv.readResource(ctx, namespaceSpec, reqResource.GetDatastoreName(), resourceName, req.GetResolveAssets()); err != nil {
+				resp.Success = false
+				resp.Message = status.Convert(err).Message()
+			}
+			if err := respStream.Send(resp); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
+}
 
-	response, err := sv.resourceSvc.ReadResource(ctx, namespaceSpec, req.DatastoreName, req.ResourceName)
+func (sv *RuntimeServiceServer) CheckDatastore(ctx context.Context, req *pb.CheckDatastoreRequest) (*pb.CheckDatastoreResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to read resource %s", err.Error(), req.ResourceName)
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
-	protoResource, err := sv.adapter.ToResourceProto(response)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s: failed to adapt resource %s", err.Error(), req.ResourceName)
+	if err := sv.resourceSvc.CheckDatastore(ctx, projSpec, req.GetDatastoreName()); err != nil {
+		if errors.Is(err, models.ErrUnsupportedDatastore) {
+			return nil, status.Errorf(codes.NotFound, "%s: datastore %s not found", err.Error(), req.GetDatastoreName())
+		}
+
+		category := models.DatastoreErrorCategoryUnknown
+		var dsErr *models.DatastoreError
+		if errors.As(err, &dsErr) {
+			category = dsErr.Category
+		}
+		return &pb.CheckDatastoreResponse{
+			Success:  false,
+			Category: string(category),
+			Message:  err.Error(),
+		}, nil
 	}
+	return &pb.CheckDatastoreResponse{
+		Success: true,
+	}, nil
+}
 
-	return &pb.ReadResourceResponse{
-		Success:  true,
-		Resource: protoResource,
+// ListDatastores returns the datastores supported by server along with the
+// resource types each of them can manage
+func (sv *RuntimeServiceServer) ListDatastores(ctx context.Context, req *pb.ListDatastoresRequest) (*pb.ListDatastoresResponse, error) {
+	datastores := sv.Datastores.GetAll()
+	sort.Slice(datastores, func(i, j int) bool {
+		return datastores[i].Name() < datastores[j].Name()
+	})
+
+	datastoreSpecs := []*pb.DatastoreSpecification{}
+	for _, ds := range datastores {
+		dsTypes := ds.Types()
+		typeNames := []string{}
+		for resourceType := range dsTypes {
+			typeNames = append(typeNames, string(resourceType))
+		}
+		sort.Strings(typeNames)
+
+		resourceTypes := []*pb.DatastoreSpecification_ResourceType{}
+		for _, typeName := range typeNames {
+			resourceTypes = append(resourceTypes, &pb.DatastoreSpecification_ResourceType{
+				Name:       typeName,
+				NameFormat: dsTypes[models.ResourceType(typeName)].NameFormat(),
+			})
+		}
+		datastoreSpecs = append(datastoreSpecs, &pb.DatastoreSpecification{
+			Name:        ds.Name(),
+			Description: ds.Description(),
+			Types:       resourceTypes,
+		})
+	}
+	return &pb.ListDatastoresResponse{
+		Datastores: datastoreSpecs,
 	}, nil
 }
 
@@ -702,52 +3030,140 @@ func (sv *RuntimeServiceServer) DeployResourceSpecification(req *pb.DeployResour
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	var resourceSpecs []models.ResourceSpec
 	for _, resourceProto := range req.GetResources() {
+		if err := sv.checkSpecSize("resource", resourceProto.GetName(), resourceProto); err != nil {
+			return err
+		}
 		adapted, err := sv.adapter.FromResourceProto(resourceProto, req.DatastoreName)
 		if err != nil {
-			return status.Errorf(codes.Internal, "%s: cannot adapt resource %s", err.Error(), resourceProto.GetName())
+			return status.Errorf(codes.InvalidArgument, "%s: cannot adapt resource %s", err.Error(), resourceProto.GetName())
 		}
 		resourceSpecs = append(resourceSpecs, adapted)
 	}
 
+	deployDone, err := sv.projectGuard.StartDeploy(projSpec.Name)
+	if err != nil {
+		return err
+	}
+	defer deployDone()
+
+	release, err := sv.deployLimiter.Acquire(respStream.Context())
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(respStream.Context())
+	defer cancel()
+	sender := newStreamSender(cancel, sv.StreamSendTimeout)
+
 	observers := new(progress.ObserverChain)
 	observers.Join(sv.progressObserver)
 	observers.Join(&resourceObserver{
 		stream: respStream,
-		log:    logrus.New(),
+		sender: sender,
 	})
 
-	if err := sv.resourceSvc.UpdateResource(respStream.Context(), namespaceSpec, resourceSpecs, observers); err != nil {
-		return status.Errorf(codes.Internal, "failed to update resources:\n%s", err.Error())
+	deployErr := sv.resourceSvc.UpdateResource(ctx, namespaceSpec, resourceSpecs, req.GetForce(), observers)
+	if deployErr != nil {
+		deployErr = errors.Wrap(deployErr, "failed to update resources")
+	} else if req.GetMode() == pb.DeployResourceSpecificationRequest_MANAGED {
+		if err := sv.resourceSvc.KeepOnly(ctx, namespaceSpec, req.GetDatastoreName(), resourceSpecs, observers); err != nil {
+			deployErr = errors.Wrap(err, "failed to delete resources")
+		}
+	}
+	if err := sender.Close(); err != nil {
+		return status.Errorf(codes.DeadlineExceeded, "%s: aborted resource deployment", err.Error())
+	}
+	if deployErr != nil {
+		return status.Errorf(errorCode(deployErr), "%s", deployErr.Error())
+	}
+	logger.FromContext(ctx).WithField("duration", time.Since(startTime).String()).Info("finished resource deployment")
+	return nil
+}
+
+// ValidateResourceSpecification runs each resource through adapter and the validator
+// of its datastore type, none of the datastore methods are called
+func (sv *RuntimeServiceServer) ValidateResourceSpecification(req *pb.ValidateResourceSpecificationRequest, respStream pb.RuntimeService_ValidateResourceSpecificationServer) error {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
+	if _, err := namespaceRepo.GetByName(req.GetNamespace()); err != nil {
+		return status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
+	}
+
+	for _, resourceProto := range req.GetResources() {
+		resp := &pb.ValidateResourceSpecificationResponse{
+			Success:      true,
+			ResourceName: resourceProto.GetName(),
+		}
+		if err := sv.validateResourceProto(resourceProto, req.GetDatastoreName()); err != nil {
+			resp.Success = false
+			resp.Message = err.Error()
+		}
+		if err := respStream.Send(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sv *RuntimeServiceServer) validateResourceProto(resourceProto *pb.ResourceSpecification, datastoreName string) error {
+	resourceSpec, err := sv.adapter.FromResourceProto(resourceProto, datastoreName)
+	if err != nil {
+		return errors.Wrap(err, "cannot adapt resource")
+	}
+	if resourceSpec.Datastore == nil {
+		return errors.Errorf("datastore %s is not set for resource", datastoreName)
+	}
+	typeController, ok := resourceSpec.Datastore.Types()[resourceSpec.Type]
+	if !ok {
+		return errors.Errorf("unsupported type %s for datastore %s", resourceSpec.Type, datastoreName)
+	}
+	if err := typeController.Validator()(resourceSpec); err != nil {
+		return errors.Wrap(err, "invalid resource")
 	}
-	logger.I("finished resource deployment in", time.Since(startTime))
 	return nil
 }
 
 func (sv *RuntimeServiceServer) ListResourceSpecification(ctx context.Context, req *pb.ListResourceSpecificationRequest) (*pb.ListResourceSpecificationResponse, error) {
+	reqPage, err := newPage(req.GetPageSize(), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
-	resourceSpecs, err := sv.resourceSvc.GetAll(namespaceSpec, req.DatastoreName)
+	var resourceSpecs []models.ResourceSpec
+	if reqPage.isAll() {
+		resourceSpecs, err = sv.resourceSvc.GetAll(namespaceSpec, req.DatastoreName)
+	} else {
+		resourceSpecs, err = sv.resourceSvc.GetPage(namespaceSpec, req.DatastoreName, reqPage.after, reqPage.size)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "%s: failed to retrieve jobs for project %s", err.Error(), req.GetProjectName())
 	}
@@ -760,9 +3176,13 @@ func (sv *RuntimeServiceServer) ListResourceSpecification(ctx context.Context, r
 		}
 		resourceProtos = append(resourceProtos, resourceProto)
 	}
-	return &pb.ListResourceSpecificationResponse{
+	resp := &pb.ListResourceSpecificationResponse{
 		Resources: resourceProtos,
-	}, nil
+	}
+	if !reqPage.isAll() && len(resourceSpecs) == reqPage.size {
+		resp.NextPageToken = nextPageToken(resourceSpecs[len(resourceSpecs)-1].Name)
+	}
+	return resp, nil
 }
 
 func (sv *RuntimeServiceServer) ReplayDryRun(ctx context.Context, req *pb.ReplayRequest) (*pb.ReplayDryRunResponse, error) {
@@ -773,7 +3193,7 @@ func (sv *RuntimeServiceServer) ReplayDryRun(ctx context.Context, req *pb.Replay
 
 	rootNode, err := sv.jobSvc.ReplayDryRun(replayWorkerRequest)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error while processing replay dry run: %v", err)
+		return nil, status.Errorf(errorCode(err), "error while processing replay dry run: %v", err)
 	}
 
 	node, err := sv.adapter.ToReplayExecutionTreeNode(rootNode)
@@ -794,7 +3214,7 @@ func (sv *RuntimeServiceServer) Replay(ctx context.Context, req *pb.ReplayReques
 
 	replayUUID, err := sv.jobSvc.Replay(ctx, replayWorkerRequest)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error while processing replay: %v", err)
+		return nil, status.Errorf(errorCode(err), "error while processing replay: %v", err)
 	}
 
 	return &pb.ReplayResponse{
@@ -802,22 +3222,94 @@ func (sv *RuntimeServiceServer) Replay(ctx context.Context, req *pb.ReplayReques
 	}, nil
 }
 
+func (sv *RuntimeServiceServer) GetReplayPlan(ctx context.Context, req *pb.GetReplayPlanRequest) (*pb.GetReplayPlanResponse, error) {
+	projectRepo := sv.projectRepoFactory.New()
+	projSpec, err := projectRepo.GetByName(req.GetProjectName())
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
+	}
+
+	jobSpec, _, err := sv.jobSvc.GetByNameForProject(req.GetJobName(), projSpec)
+	if err != nil {
+		return nil, status.Errorf(errorCode(err), "%s: failed to find the job %s for project %s", err.Error(),
+			req.GetJobName(), req.GetProjectName())
+	}
+
+	startDate, err := time.Parse(job.ReplayDateFormat, req.GetStartDate())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to parse replay start date(e.g. %s): %v", job.ReplayDateFormat, err)
+	}
+	endDate := startDate
+	if req.GetEndDate() != "" {
+		if endDate, err = time.Parse(job.ReplayDateFormat, req.GetEndDate()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unable to parse replay end date(e.g. %s): %v", job.ReplayDateFormat, err)
+		}
+	}
+	if endDate.Before(startDate) {
+		return nil, status.Errorf(codes.InvalidArgument, "replay end date cannot be before start date")
+	}
+
+	runs, err := job.GetReplayRuns(jobSpec, startDate, endDate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to compute runs of job %s", err.Error(), req.GetJobName())
+	}
+	if len(runs) == 0 {
+		return &pb.GetReplayPlanResponse{Runs: []*pb.ReplayPlanRun{}}, nil
+	}
+
+	scheduler, err := sv.schedulerForProject(projSpec)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: failed to find scheduler for project %s", err.Error(), req.GetProjectName())
+	}
+	jobStatuses, err := scheduler.GetDagRunStatus(ctx, projSpec, jobSpec.Name, runs[0], runs[len(runs)-1], replayPlanBatchSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s: failed to fetch runs of job %s", err.Error(), req.GetJobName())
+	}
+	runStates := map[time.Time]models.JobStatusState{}
+	for _, jobStatus := range jobStatuses {
+		runStates[jobStatus.ScheduledAt.UTC()] = jobStatus.State
+	}
+
+	// runs are valid at this point so is the timezone of job
+	loc, _ := jobSpec.Schedule.Location()
+	planRuns := []*pb.ReplayPlanRun{}
+	for _, run := range runs {
+		scheduledAt, err1 := ptypes.TimestampProto(run)
+		windowStart, err2 := ptypes.TimestampProto(jobSpec.Task.Window.GetStart(run.In(loc)))
+		windowEnd, err3 := ptypes.TimestampProto(jobSpec.Task.Window.GetEnd(run.In(loc)))
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert timestamp of run %s", run)
+		}
+		state := runStates[run.UTC()]
+		planRuns = append(planRuns, &pb.ReplayPlanRun{
+			ScheduledAt: scheduledAt,
+			WindowStart: windowStart,
+			WindowEnd:   windowEnd,
+			State:       state.String(),
+			Succeeded:   state == models.JobStatusStateSuccess,
+		})
+	}
+	return &pb.GetReplayPlanResponse{
+		Runs: planRuns,
+	}, nil
+}
+
 func (sv *RuntimeServiceServer) parseReplayRequest(req *pb.ReplayRequest) (*models.ReplayWorkerRequest, error) {
 	projectRepo := sv.projectRepoFactory.New()
 	projSpec, err := projectRepo.GetByName(req.GetProjectName())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: project %s not found", err.Error(), req.GetProjectName())
+		return nil, status.Errorf(errorCode(err), "%s: project %s not found", err.Error(), req.GetProjectName())
 	}
 
 	namespaceRepo := sv.namespaceRepoFactory.New(projSpec)
 	namespaceSpec, err := namespaceRepo.GetByName(req.GetNamespace())
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: namespace %s not found", err.Error(), req.GetNamespace())
+		return nil, status.Errorf(errorCode(err), "%s: namespace %s not found", err.Error(), req.GetNamespace())
 	}
 
 	jobSpec, err := sv.jobSvc.GetByName(req.GetJobName(), namespaceSpec)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "%s: failed to find the job %s for namespace %s", err.Error(),
+		return nil, status.Errorf(errorCode(err), "%s: failed to find the job %s for namespace %s", err.Error(),
 			req.GetJobName(), req.GetNamespace())
 	}
 
@@ -845,6 +3337,21 @@ func (sv *RuntimeServiceServer) parseReplayRequest(req *pb.ReplayRequest) (*mode
 	return &replayRequest, nil
 }
 
+// schedulerForProject returns the scheduler a project is configured to use,
+// falling back to the server default when the project doesn't specify one
+func (sv *RuntimeServiceServer) schedulerForProject(projSpec models.ProjectSpec) (models.SchedulerUnit, error) {
+	if projSpec.Scheduler.Type == "" {
+		if sv.scheduler == nil {
+			return nil, errors.Errorf("no scheduler configured for project %s and server has no default", projSpec.Name)
+		}
+		return sv.scheduler, nil
+	}
+	if sv.scheduler != nil && projSpec.Scheduler.Type == sv.scheduler.GetName() {
+		return sv.scheduler, nil
+	}
+	return models.SchedulerRegistry.GetByName(projSpec.Scheduler.Type)
+}
+
 func NewRuntimeServiceServer(
 	version string,
 	jobSvc models.JobService,
@@ -857,6 +3364,7 @@ func NewRuntimeServiceServer(
 	progressObserver progress.Observer,
 	instSvc models.InstanceService,
 	scheduler models.SchedulerUnit,
+	assetLoaderFactory models.AssetLoaderFactory,
 ) *RuntimeServiceServer {
 	return &RuntimeServiceServer{
 		version:              version,
@@ -869,16 +3377,51 @@ func NewRuntimeServiceServer(
 		progressObserver:     progressObserver,
 		instSvc:              instSvc,
 		scheduler:            scheduler,
+		assetLoaderFactory:   assetLoaderFactory,
 		secretRepoFactory:    secretRepoFactory,
+		StreamSendTimeout:    StreamSendTimeout,
+		Datastores:           models.DatastoreRegistry,
+		deploys:              newDeployRegistry(),
+		projectGuard:         newProjectGuard(),
+	}
+}
+
+// assetLoaderOf gives the loader of remote assets of project, remote
+// assets are not resolved if server has no loaders of them
+func (sv *RuntimeServiceServer) assetLoaderOf(proj models.ProjectSpec) models.AssetLoader {
+	if sv.assetLoaderFactory == nil {
+		return nil
 	}
+	return sv.assetLoaderFactory.New(proj)
+}
+
+// deployErrorCategories maps job deploy failures to their proto counterpart
+var deployErrorCategories = map[job.ErrorCategory]pb.DeployJobSpecificationResponse_ErrorCategory{
+	job.ErrorCategoryValidation: pb.DeployJobSpecificationResponse_VALIDATION,
+	job.ErrorCategoryDependency: pb.DeployJobSpecificationResponse_DEPENDENCY,
+	job.ErrorCategoryCompile:    pb.DeployJobSpecificationResponse_COMPILE,
+	job.ErrorCategoryUpload:     pb.DeployJobSpecificationResponse_UPLOAD,
+	job.ErrorCategoryCancelled:  pb.DeployJobSpecificationResponse_CANCELLED,
 }
 
 type jobSyncObserver struct {
 	stream pb.RuntimeService_DeployJobSpecificationServer
-	log    logrus.FieldLogger
+	sender *streamSender
 }
 
 func (obs *jobSyncObserver) Notify(e progress.Event) {
+	resp := jobDeployResponse(e)
+	if resp == nil {
+		return
+	}
+	obs.sender.Send(func() error {
+		return errors.Wrap(obs.stream.Send(resp), "failed to send deploy progress")
+	})
+}
+
+// jobDeployResponse converts a deploy event to the progress message sent
+// to clients, nil if clients are not interested in the event
+func jobDeployResponse(e progress.Event) *pb.DeployJobSpecificationResponse {
 	switch evt := e.(type) {
 	case *job.EventJobUpload:
 		resp := &pb.DeployJobSpecificationResponse{
@@ -889,33 +3432,66 @@ func (obs *jobSyncObserver) Notify(e progress.Event) {
 		if evt.Err != nil {
 			resp.Success = false
 			resp.Message = evt.Err.Error()
+			resp.ErrorCategory = deployErrorCategories[evt.Category]
+		} else if evt.Unchanged {
+			resp.Message = evt.String()
 		}
-
-		if err := obs.stream.Send(resp); err != nil {
-			obs.log.Error(errors.Wrapf(err, "failed to send deploy spec ack for: %s", evt.Job.Name))
-		}
+		return resp
 	case *job.EventJobRemoteDelete:
-		resp := &pb.DeployJobSpecificationResponse{
+		return &pb.DeployJobSpecificationResponse{
 			JobName: evt.Name,
 			Message: evt.String(),
 		}
-		if err := obs.stream.Send(resp); err != nil {
-			obs.log.Error(errors.Wrapf(err, "failed to send delete notification for: %s", evt.Name))
+	case *job.EventSavedJobDeleteBlocked:
+		return &pb.DeployJobSpecificationResponse{
+			Success: false,
+			JobName: evt.Name,
+			Message: evt.String(),
 		}
 	case *job.EventJobSpecUnknownDependencyUsed:
-		resp := &pb.DeployJobSpecificationResponse{
+		return &pb.DeployJobSpecificationResponse{
 			JobName: evt.Job,
 			Message: evt.String(),
 		}
-		if err := obs.stream.Send(resp); err != nil {
-			obs.log.Error(errors.Wrapf(err, "failed to send unknown dependency notification for: %s", evt.Job))
+	case *job.EventJobSyncCancelled:
+		return &pb.DeployJobSpecificationResponse{
+			Success:       false,
+			Message:       evt.String(),
+			ErrorCategory: pb.DeployJobSpecificationResponse_CANCELLED,
+		}
+	case *job.EventJobSyncFailed:
+		return &pb.DeployJobSpecificationResponse{
+			Success:       false,
+			Message:       evt.String(),
+			ErrorCategory: deployErrorCategories[evt.Category],
+		}
+	case *job.EventJobSpecEndDatePassed:
+		return &pb.DeployJobSpecificationResponse{
+			JobName: evt.Name,
+			Message: evt.String(),
+		}
+	case *job.EventJobSpecDuplicateDestination:
+		return &pb.DeployJobSpecificationResponse{
+			Message: evt.String(),
+		}
+	case *job.EventDeploySummary:
+		return &pb.DeployJobSpecificationResponse{
+			Success: evt.Failed == 0,
+			Message: evt.String(),
+			Summary: &pb.DeployJobSpecificationResponse_Summary{
+				Succeeded: int32(evt.Succeeded),
+				Failed:    int32(evt.Failed),
+				Deleted:   int32(evt.Deleted),
+				Unchanged: int32(evt.Unchanged),
+			},
 		}
 	}
+	return nil
 }
 
 type resourceObserver struct {
 	stream pb.RuntimeService_DeployResourceSpecificationServer
-	log    logrus.FieldLogger
+	sender *streamSender
 }
 
 func (obs *resourceObserver) Notify(e progress.Event) {
@@ -931,12 +3507,141 @@ func (obs *resourceObserver) Notify(e progress.Event) {
 			resp.Message = evt.Err.Error()
 		}
 
-		if err := obs.stream.Send(resp); err != nil {
-			obs.log.Error(errors.Wrapf(err, "failed to send deploy spec ack for: %s", evt.Spec.Name))
+		obs.sender.Send(func() error {
+			return errors.Wrapf(obs.stream.Send(resp), "failed to send deploy spec ack for: %s", evt.Spec.Name)
+		})
+	case *datastore.EventResourceDeleted:
+		resp := &pb.DeployResourceSpecificationResponse{
+			Success:      true,
+			Ack:          true,
+			ResourceName: evt.Spec.Name,
+			Message:      evt.String(),
+		}
+		if evt.Err != nil {
+			resp.Success = false
+		}
+
+		obs.sender.Send(func() error {
+			return errors.Wrapf(obs.stream.Send(resp), "failed to send delete ack for: %s", evt.Spec.Name)
+		})
+	case *datastore.EventResourceSkipped:
+		resp := &pb.DeployResourceSpecificationResponse{
+			Success:      false,
+			Ack:          true,
+			ResourceName: evt.Spec.Name,
+			Message:      evt.String(),
+		}
+
+		obs.sender.Send(func() error {
+			return errors.Wrapf(obs.stream.Send(resp), "failed to send deploy skip for: %s", evt.Spec.Name)
+		})
+	case *datastore.EventResourceApplyTimeout:
+		resp := &pb.DeployResourceSpecificationResponse{
+			Success:      false,
+			Ack:          true,
+			ResourceName: evt.Spec.Name,
+			Message:      evt.String(),
+		}
+
+		obs.sender.Send(func() error {
+			return errors.Wrapf(obs.stream.Send(resp), "failed to send deploy timeout for: %s", evt.Spec.Name)
+		})
+	}
+}
+
+// streamSender sends progress messages on a grpc stream from a bounded
+// buffer. If the client doesn't accept a message within timeout, the
+// deploy is cancelled and error is surfaced on Close
+type streamSender struct {
+	queue   chan func() error
+	errChan chan error
+	done    chan struct{}
+	cancel  context.CancelFunc
+	timeout time.Duration
+
+	mu     sync.Mutex
+	failed bool
+}
+
+func newStreamSender(cancel context.CancelFunc, timeout time.Duration) *streamSender {
+	sender := &streamSender{
+		queue:   make(chan func() error, streamBufferSize),
+		errChan: make(chan error, 1),
+		done:    make(chan struct{}),
+		cancel:  cancel,
+		timeout: timeout,
+	}
+	go sender.run()
+	return sender
+}
+
+// Send queues the message, it gives up if the buffer stays full
+// for longer than timeout
+func (s *streamSender) Send(send func() error) {
+	if s.hasFailed() {
+		return
+	}
+	select {
+	case s.queue <- send:
+	case <-time.After(s.timeout):
+		s.fail(errors.Errorf("client did not read progress for %s", s.timeout))
+	}
+}
+
+// Close waits for buffered messages to be sent and returns
+// the error that aborted sending if any
+func (s *streamSender) Close() error {
+	close(s.queue)
+	<-s.done
+	select {
+	case err := <-s.errChan:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (s *streamSender) run() {
+	defer close(s.done)
+	for send := range s.queue {
+		if s.hasFailed() {
+			// drain the buffer
+			continue
+		}
+
+		sent := make(chan error, 1)
+		go func(send func() error) {
+			sent <- send()
+		}(send)
+
+		select {
+		case err := <-sent:
+			if err != nil {
+				s.fail(err)
+			}
+		case <-time.After(s.timeout):
+			s.fail(errors.Errorf("client did not read progress for %s", s.timeout))
 		}
 	}
 }
 
+func (s *streamSender) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failed {
+		return
+	}
+	s.failed = true
+	s.errChan <- err
+	s.cancel()
+}
+
+func (s *streamSender) hasFailed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failed
+}
+
 type jobCheckObserver struct {
 	stream pb.RuntimeService_CheckJobSpecificationsServer
 	log    logrus.FieldLogger
@@ -965,3 +3670,179 @@ func (obs *jobCheckObserver) Notify(e progress.Event) {
 		}
 	}
 }
+
+// deployRegistry keeps cancel functions of running deployments so they
+// can be stopped from a different request
+type deployRegistry struct {
+	mu      sync.Mutex
+	deploys map[string]*runningDeploy
+}
+
+type runningDeploy struct {
+	project   string
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+func newDeployRegistry() *deployRegistry {
+	return &deployRegistry{
+		deploys: map[string]*runningDeploy{},
+	}
+}
+
+// Add tracks a deploy of the project and returns its identifier
+func (r *deployRegistry) Add(project string, cancel context.CancelFunc) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := uuid.New().String()
+	r.deploys[id] = &runningDeploy{
+		project: project,
+		cancel:  cancel,
+	}
+	return id
+}
+
+func (r *deployRegistry) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.deploys, id)
+}
+
+// Cancel stops the deploy if it is running for the project
+func (r *deployRegistry) Cancel(project, id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deploy, ok := r.deploys[id]
+	if !ok || deploy.project != project {
+		return false
+	}
+	deploy.cancelled = true
+	deploy.cancel()
+	return true
+}
+
+func (r *deployRegistry) IsCancelled(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deploy, ok := r.deploys[id]
+	return ok && deploy.cancelled
+}
+
+// projectGuard rejects an import of a project while its deploys are running
+// and its deploys while it is imported, rolling back a failed import would
+// otherwise delete what the deploys saved meanwhile
+type projectGuard struct {
+	mu        sync.Mutex
+	deploys   map[string]int
+	importing map[string]bool
+}
+
+func newProjectGuard() *projectGuard {
+	return &projectGuard{
+		deploys:   map[string]int{},
+		importing: map[string]bool{},
+	}
+}
+
+// StartDeploy marks a deploy of the project as running, the returned
+// function marks it done
+func (g *projectGuard) StartDeploy(project string) (func(), error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.importing[project] {
+		return nil, status.Errorf(codes.Aborted, "project %s is being imported, try again later", project)
+	}
+	g.deploys[project]++
+	return func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if g.deploys[project]--; g.deploys[project] <= 0 {
+			delete(g.deploys, project)
+		}
+	}, nil
+}
+
+// StartImport marks the project as being imported, the returned function
+// marks the import done
+func (g *projectGuard) StartImport(project string) (func(), error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.importing[project] {
+		return nil, status.Errorf(codes.Aborted, "project %s is already being imported", project)
+	}
+	if g.deploys[project] > 0 {
+		return nil, status.Errorf(codes.Aborted, "project %s has %d deploys running, try again later", project, g.deploys[project])
+	}
+	g.importing[project] = true
+	return func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		delete(g.importing, project)
+	}, nil
+}
+
+// checkSpecSize rejects a spec whose serialized size is over MaxSpecBytes
+func (sv *RuntimeServiceServer) checkSpecSize(kind, name string, spec proto.Message) error {
+	if sv.MaxSpecBytes <= 0 {
+		return nil
+	}
+	if size := proto.Size(spec); size > sv.MaxSpecBytes {
+		return status.Errorf(codes.InvalidArgument, "%s %s is %d bytes, limit is %d bytes per spec",
+			kind, name, size, sv.MaxSpecBytes)
+	}
+	return nil
+}
+
+// deployLimiter caps the number of job and resource deploys running at a time
+// across all projects, excess deploys either wait for a slot or get rejected
+type deployLimiter struct {
+	slots  chan struct{}
+	reject bool
+}
+
+// Acquire takes a slot for the deploy of the request, the returned
+// function releases it
+func (l *deployLimiter) Acquire(ctx context.Context) (func(), error) {
+	return l.acquire(ctx, l != nil && l.reject)
+}
+
+// Wait takes a slot for a queued deploy, it was already accepted so it
+// waits for a slot even if excess deploys are rejected
+func (l *deployLimiter) Wait(ctx context.Context) (func(), error) {
+	return l.acquire(ctx, false)
+}
+
+func (l *deployLimiter) acquire(ctx context.Context, reject bool) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	release := func() { <-l.slots }
+	if reject {
+		select {
+		case l.slots <- struct{}{}:
+			return release, nil
+		default:
+			return nil, status.Errorf(codes.ResourceExhausted, "%d deploys are already running, try again later", cap(l.slots))
+		}
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// LimitDeploys caps the number of concurrent deploys across projects, excess
+// deploys wait for a running one to finish unless reject is set, in which case
+// they fail with ResourceExhausted. A limit of zero or less disables the cap
+func (sv *RuntimeServiceServer) LimitDeploys(limit int, reject bool) {
+	if limit <= 0 {
+		sv.deployLimiter = nil
+		return
+	}
+	sv.deployLimiter = &deployLimiter{
+		slots:  make(chan struct{}, limit),
+		reject: reject,
+	}
+}
@@ -6,28 +6,49 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/odpf/optimus/core/tree"
 
 	"github.com/odpf/optimus/instance"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
 	v1 "github.com/odpf/optimus/api/handler/v1"
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
 	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/datastore"
+	"github.com/odpf/optimus/ext/datastore/bigquery"
+	"github.com/odpf/optimus/job"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	mock2 "github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 )
 
+// namedScheduler is a mock scheduler registered under a name
+type namedScheduler struct {
+	*mock.Scheduler
+	name string
+}
+
+func (s *namedScheduler) GetName() string {
+	return s.name
+}
+
 func TestRuntimeServiceServer(t *testing.T) {
 	logger.InitWithWriter("INFO", ioutil.Discard)
 
@@ -45,6 +66,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			versionRequest := pb.VersionRequest{Client: Version}
 			resp, err := runtimeServiceServer.Version(context.Background(), &versionRequest)
@@ -143,6 +165,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 			jobService := new(mock.JobService)
 			//jobService.On("GetByName", jobName, projectSpec).Return(jobSpec, nil)
 			jobService.On("GetByNameForProject", jobName, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			jobService.On("ResolveDependencies", projectSpec, jobSpec).Return(jobSpec, nil)
 			defer jobService.AssertExpectations(t)
 
 			instanceService := new(mock.InstanceService)
@@ -169,6 +192,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				instanceService,
 				nil,
+				nil,
 			)
 
 			versionRequest := pb.RegisterInstanceRequest{ProjectName: projectName, JobName: jobName,
@@ -201,6 +225,241 @@ func TestRuntimeServiceServer(t *testing.T) {
 
 			assert.Equal(t, expectedResponse, resp)
 		})
+		t.Run("should resolve secrets referred by the job only for executors of the project", func(t *testing.T) {
+			projectName := "a-data-project"
+			jobName := "a-data-job"
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			scheduledAtTimestamp, _ := ptypes.TimestampProto(scheduledAt)
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Secret: models.ProjectSecrets{
+					{Name: models.ProjectSecretExecutorTokenKey, Value: "executor-token"},
+					{Name: "BQ_SERVICE_ACCOUNT", Value: "base64-account"},
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "namespace-124",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit := new(mock.TaskPlugin)
+			execUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: "bq2bq",
+			}, nil)
+			jobSpec := models.JobSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: jobName,
+				Task: models.JobSpecTask{
+					Unit: execUnit,
+					Config: models.JobSpecConfigs{
+						{Name: "SERVICE_ACCOUNT", Value: "{{ .secret.BQ_SERVICE_ACCOUNT }}"},
+					},
+				},
+				Assets: *models.JobAssets{}.New(nil),
+			}
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.InstanceStateRunning,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobName, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			jobService.On("ResolveDependencies", projectSpec, jobSpec).Return(jobSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			instanceService := new(mock.InstanceService)
+			instanceService.On("Register", jobSpec, scheduledAt, models.InstanceTypeTask).Return(instanceSpec, nil).Once()
+			instanceService.On("Compile", namespaceSpec, jobSpec, instanceSpec, models.InstanceTypeTask, "bq2bq").Return(
+				map[string]string{"SERVICE_ACCOUNT": "base64-account"}, map[string]string{}, nil).Once()
+			defer instanceService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				instanceService,
+				nil,
+				nil,
+			)
+			request := pb.RegisterInstanceRequest{ProjectName: projectName, JobName: jobName,
+				InstanceType: pb.InstanceSpec_Type(pb.InstanceSpec_Type_value[strings.ToUpper(string(models.InstanceTypeTask))]),
+				ScheduledAt:  scheduledAtTimestamp,
+				InstanceName: "bq2bq",
+			}
+
+			_, err := runtimeServiceServer.RegisterInstance(context.Background(), &request)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+			badTokenCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(v1.ExecutorTokenHeader, "guessed"))
+			_, err = runtimeServiceServer.RegisterInstance(badTokenCtx, &request)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+			executorCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(v1.ExecutorTokenHeader, "executor-token"))
+			resp, err := runtimeServiceServer.RegisterInstance(executorCtx, &request)
+			assert.Nil(t, err)
+			assert.Equal(t, "base64-account", resp.Context.Envs["SERVICE_ACCOUNT"])
+		})
+	})
+
+	t.Run("GetInstance", func(t *testing.T) {
+		projectName := "a-data-project"
+		jobName := "a-data-job"
+		scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+		scheduledAtTimestamp, _ := ptypes.TimestampProto(scheduledAt)
+
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "namespace-124",
+			ProjectSpec: projectSpec,
+		}
+
+		execUnit := new(mock.TaskPlugin)
+		execUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+			Name: "a-data-task",
+		}, nil)
+		jobSpec := models.JobSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: jobName,
+			Task: models.JobSpecTask{
+				Unit: execUnit,
+			},
+		}
+		request := pb.GetInstanceRequest{ProjectName: projectName, JobName: jobName,
+			InstanceType: pb.InstanceSpec_Type(pb.InstanceSpec_Type_value[strings.ToUpper(string(models.InstanceTypeTask))]),
+			ScheduledAt:  scheduledAtTimestamp,
+			InstanceName: "test",
+		}
+
+		t.Run("should return a registered instance with its context", func(t *testing.T) {
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.InstanceStateRunning,
+				Data: []models.InstanceSpecData{
+					{
+						Name:  instance.ConfigKeyDstart,
+						Value: jobSpec.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+						Type:  models.InstanceDataTypeEnv,
+					},
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobName, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			instanceService := new(mock.InstanceService)
+			instanceService.On("Get", jobSpec, scheduledAt).Return(instanceSpec, nil)
+			instanceService.On("Compile", namespaceSpec, jobSpec, instanceSpec, models.InstanceTypeTask, "test").Return(
+				map[string]string{
+					instance.ConfigKeyDstart: jobSpec.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+				},
+				map[string]string{
+					"query.sql": "select * from 1",
+				}, nil)
+			defer instanceService.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				adapter,
+				nil,
+				instanceService,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetInstance(context.Background(), &request)
+			assert.Nil(t, err)
+
+			jobSpecProto, _ := adapter.ToJobProto(jobSpec)
+			instanceSpecProto, _ := adapter.ToInstanceProto(instanceSpec)
+			assert.Equal(t, &pb.GetInstanceResponse{
+				Project:   adapter.ToProjectProto(projectSpec),
+				Job:       jobSpecProto,
+				Instance:  instanceSpecProto,
+				Namespace: adapter.ToNamespaceProto(namespaceSpec),
+				Context: &pb.InstanceContext{
+					Envs: map[string]string{
+						instance.ConfigKeyDstart: jobSpec.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+					},
+					Files: map[string]string{
+						"query.sql": "select * from 1",
+					},
+				},
+			}, resp)
+		})
+		t.Run("should return not found if instance was never registered", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobName, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			instanceService := new(mock.InstanceService)
+			instanceService.On("Get", jobSpec, scheduledAt).Return(models.InstanceSpec{},
+				errors.Wrap(store.ErrResourceNotFound, "failed to get instance"))
+			defer instanceService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				instanceService,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetInstance(context.Background(), &request)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			assert.Contains(t, err.Error(), "instance of job a-data-job scheduled at 2020-11-11T00:00:00Z is not registered")
+			assert.Nil(t, resp)
+			instanceService.AssertNotCalled(t, "Compile", mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything)
+		})
 	})
 
 	t.Run("RegisterProject", func(t *testing.T) {
@@ -210,7 +469,9 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectSpec := models.ProjectSpec{
 				Name: projectName,
 				Config: map[string]string{
-					"BUCKET": "gs://some_folder",
+					"BUCKET":         "gs://some_folder",
+					"STORAGE_PATH":   "gs://some_folder",
+					"SCHEDULER_HOST": "http://airflow.example.io",
 				},
 			}
 			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
@@ -237,6 +498,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 
 			projectRequest := pb.RegisterProjectRequest{Project: adapter.ToProjectProto(projectSpec)}
@@ -250,7 +512,9 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectSpec := models.ProjectSpec{
 				Name: projectName,
 				Config: map[string]string{
-					"BUCKET": "gs://some_folder",
+					"BUCKET":         "gs://some_folder",
+					"STORAGE_PATH":   "gs://some_folder",
+					"SCHEDULER_HOST": "http://airflow.example.io",
 				},
 			}
 			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
@@ -277,6 +541,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 
 			projectRequest := pb.RegisterProjectRequest{Project: adapter.ToProjectProto(projectSpec)}
@@ -293,7 +558,9 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectSpec := models.ProjectSpec{
 				Name: projectName,
 				Config: map[string]string{
-					"BUCKET": "gs://some_folder",
+					"BUCKET":         "gs://some_folder",
+					"STORAGE_PATH":   "gs://some_folder",
+					"SCHEDULER_HOST": "http://airflow.example.io",
 				},
 			}
 
@@ -335,6 +602,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 
 			projectRequest := pb.RegisterProjectRequest{
@@ -348,178 +616,139 @@ func TestRuntimeServiceServer(t *testing.T) {
 				Message: "saved successfully",
 			}, resp)
 		})
-	})
-
-	t.Run("RegisterProjectNamespace", func(t *testing.T) {
-		t.Run("should save a new namespace", func(t *testing.T) {
-			projectName := "a-data-project"
-
+		t.Run("should register a project with scheduler config", func(t *testing.T) {
 			projectSpec := models.ProjectSpec{
-				Name: projectName,
+				Name: "a-data-project",
 				Config: map[string]string{
-					"BUCKET": "gs://some_folder",
+					"BUCKET":       "gs://some_folder",
+					"STORAGE_PATH": "gs://some_folder",
+				},
+				Scheduler: models.ProjectSchedulerConfig{
+					Type:       "project-scheduler",
+					Host:       "http://airflow.example.io",
+					AuthSecret: "AIRFLOW_AUTH",
 				},
 			}
-
-			namespaceSpec := models.NamespaceSpec{
-				Name:   "dev-test-namespace-1",
-				Config: map[string]string{},
-			}
-
 			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
 
+			scheduler := &namedScheduler{Scheduler: new(mock.Scheduler), name: "project-scheduler"}
+			_ = models.SchedulerRegistry.Add(scheduler)
+
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepository.On("Save", projectSpec).Return(nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			jobSvc := new(mock.JobService)
-			defer jobSvc.AssertExpectations(t)
-
-			namespaceRepository := new(mock.NamespaceRepository)
-			namespaceRepository.On("Save", namespaceSpec).Return(nil)
-			defer namespaceRepository.AssertExpectations(t)
-
-			namespaceRepoFact := new(mock.NamespaceRepoFactory)
-			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
-			defer namespaceRepoFact.AssertExpectations(t)
-
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
 				"someVersion1.0",
-				jobSvc,
-				nil, nil,
+				nil, nil, nil,
 				projectRepoFactory,
-				namespaceRepoFact,
 				nil,
-				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				adapter,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			namespaceRequest := pb.RegisterProjectNamespaceRequest{
-				ProjectName: projectName,
-				Namespace:   adapter.ToNamespaceProto(namespaceSpec),
-			}
-			resp, err := runtimeServiceServer.RegisterProjectNamespace(context.Background(), &namespaceRequest)
+			projectRequest := pb.RegisterProjectRequest{Project: adapter.ToProjectProto(projectSpec)}
+			resp, err := runtimeServiceServer.RegisterProject(context.Background(), &projectRequest)
 			assert.Nil(t, err)
-			assert.Equal(t, &pb.RegisterProjectNamespaceResponse{
-				Success: true,
-				Message: "saved successfully",
-			}, resp)
+			assert.True(t, resp.GetSuccess())
 		})
-		t.Run("should throw error if project does not exist while saving a namespace", func(t *testing.T) {
-			projectName := "a-data-project"
-
+		t.Run("should reject a project missing required config", func(t *testing.T) {
 			projectSpec := models.ProjectSpec{
-				Name: projectName,
+				Name: "a-data-project",
 				Config: map[string]string{
-					"BUCKET": "gs://some_folder",
+					"BUCKET":         "gs://some_folder",
+					"SCHEDULER_HOST": "http://airflow.example.io",
 				},
 			}
-
-			namespaceSpec := models.NamespaceSpec{
-				Name: "dev-test-namespace-1",
-			}
-
 			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
 
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, errors.New("project does not exist"))
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			jobSvc := new(mock.JobService)
-			defer jobSvc.AssertExpectations(t)
-
-			namespaceRepository := new(mock.NamespaceRepository)
-			defer namespaceRepository.AssertExpectations(t)
-
-			namespaceRepoFact := new(mock.NamespaceRepoFactory)
-			defer namespaceRepoFact.AssertExpectations(t)
-
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
 				"someVersion1.0",
-				jobSvc,
-				nil, nil,
+				nil, nil, nil,
 				projectRepoFactory,
-				namespaceRepoFact,
 				nil,
-				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				adapter,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			namespaceRequest := pb.RegisterProjectNamespaceRequest{
-				ProjectName: projectName,
-				Namespace:   adapter.ToNamespaceProto(namespaceSpec),
+			projectRequest := pb.RegisterProjectRequest{Project: adapter.ToProjectProto(projectSpec)}
+			resp, err := runtimeServiceServer.RegisterProject(context.Background(), &projectRequest)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Contains(t, err.Error(), "project a-data-project is missing required config: STORAGE_PATH")
+			assert.Nil(t, resp)
+			projectRepository.AssertNotCalled(t, "Save", mock2.Anything)
+		})
+		t.Run("should reject a project with unknown scheduler type", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				Name: "a-data-project",
+				Scheduler: models.ProjectSchedulerConfig{
+					Type: "unknown-scheduler",
+					Host: "http://airflow.example.io",
+				},
 			}
-			_, err := runtimeServiceServer.RegisterProjectNamespace(context.Background(), &namespaceRequest)
-			assert.NotNil(t, err)
-			assert.Equal(t, "rpc error: code = NotFound desc = project does not exist: project a-data-project not found", err.Error())
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			projectRequest := pb.RegisterProjectRequest{Project: adapter.ToProjectProto(projectSpec)}
+			resp, err := runtimeServiceServer.RegisterProject(context.Background(), &projectRequest)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, err.Error(), "invalid scheduler for project a-data-project")
+			assert.Nil(t, resp)
 		})
 	})
 
-	t.Run("RegisterJobSpecification", func(t *testing.T) {
-		t.Run("should save a job specification", func(t *testing.T) {
-			projectName := "a-data-project"
-
+	t.Run("GetProject", func(t *testing.T) {
+		t.Run("should return project with its scheduler config", func(t *testing.T) {
 			projectSpec := models.ProjectSpec{
-				Name: projectName,
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
 				Config: map[string]string{
 					"BUCKET": "gs://some_folder",
 				},
-			}
-
-			namespaceSpec := models.NamespaceSpec{
-				Name:        "dev-test-namespace-1",
-				ProjectSpec: projectSpec,
-			}
-
-			jobName := "my-job"
-			taskName := "bq2bq"
-			execUnit1 := new(mock.TaskPlugin)
-			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
-				Name:  taskName,
-				Image: "random-image",
-			}, nil)
-			execUnit1.On("DefaultTaskAssets", context.Background(), mock2.Anything).Return(models.DefaultTaskAssetsResponse{}, nil)
-			defer execUnit1.AssertExpectations(t)
-			_ = models.TaskRegistry.Add(execUnit1)
-
-			jobSpec := models.JobSpec{
-				Name: jobName,
-				Task: models.JobSpecTask{
-					Unit: execUnit1,
-					Config: models.JobSpecConfigs{
-						{
-							Name:  "DO",
-							Value: "THIS",
-						},
-					},
-					Window: models.JobSpecTaskWindow{
-						Size:       time.Hour,
-						Offset:     0,
-						TruncateTo: "d",
-					},
+				Scheduler: models.ProjectSchedulerConfig{
+					Type:       "airflow2",
+					Host:       "http://airflow.example.io",
+					AuthSecret: "AIRFLOW_AUTH",
 				},
-				Assets: *models.JobAssets{}.New(
-					[]models.JobSpecAsset{
-						{
-							Name:  "query.sql",
-							Value: "select * from 1",
-						},
-					}),
-				Dependencies: map[string]models.JobSpecDependency{},
 			}
-
 			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
 
 			projectRepository := new(mock.ProjectRepository)
@@ -530,235 +759,6186 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			jobSvc := new(mock.JobService)
-			jobSvc.On("Create", jobSpec, namespaceSpec).Return(nil)
-			jobSvc.On("Check", namespaceSpec, []models.JobSpec{jobSpec}, mock2.Anything).Return(nil)
-			jobSvc.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
-			defer jobSvc.AssertExpectations(t)
-
-			namespaceRepository := new(mock.NamespaceRepository)
-			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
-			defer namespaceRepository.AssertExpectations(t)
-
-			namespaceRepoFact := new(mock.NamespaceRepoFactory)
-			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
-			defer namespaceRepoFact.AssertExpectations(t)
-
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
 				"someVersion1.0",
-				jobSvc,
-				nil, nil,
+				nil, nil, nil,
 				projectRepoFactory,
-				namespaceRepoFact,
 				nil,
-				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				adapter,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			jobProto, _ := adapter.ToJobProto(jobSpec)
-			request := pb.CreateJobSpecificationRequest{
-				ProjectName: projectName,
-				Namespace:   namespaceSpec.Name,
-				Spec:        jobProto,
-			}
-			resp, err := runtimeServiceServer.CreateJobSpecification(context.Background(), &request)
+			resp, err := runtimeServiceServer.GetProject(context.Background(), &pb.GetProjectRequest{ProjectName: projectSpec.Name})
 			assert.Nil(t, err)
-			assert.Equal(t, &pb.CreateJobSpecificationResponse{
-				Success: true,
-				Message: "job my-job is created and deployed successfully on project a-data-project",
-			}, resp)
+			assert.Equal(t, &pb.ProjectSpecification_ProjectSchedulerConfig{
+				Type:       "airflow2",
+				Host:       "http://airflow.example.io",
+				AuthSecret: "AIRFLOW_AUTH",
+			}, resp.GetProject().GetScheduler())
+
+			projSpec := adapter.FromProjectProto(resp.GetProject())
+			assert.Equal(t, projectSpec.Scheduler, projSpec.Scheduler)
 		})
 	})
 
-	t.Run("RegisterSecret", func(t *testing.T) {
-		t.Run("should register a secret successfully", func(t *testing.T) {
-			projectName := "a-data-project"
-
-			projectSpec := models.ProjectSpec{
-				Name: projectName,
+	t.Run("CloneProject", func(t *testing.T) {
+		sourceProject := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+			Config: map[string]string{
+				models.ProjectStoragePathKey: "gs://some_folder",
+				models.ProjectSchedulerHost:  "http://airflow.example.io",
+			},
+		}
+		t.Run("should copy namespaces, jobs and resources and report secrets without copying them", func(t *testing.T) {
+			targetProject := models.ProjectSpec{
+				Name: "a-staging-project",
 				Config: map[string]string{
-					"BUCKET": "gs://some_folder",
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://staging-airflow.example.io",
 				},
 			}
-			adapter := v1.NewAdapter(nil, nil, nil)
+			savedTargetProject := targetProject
+			savedTargetProject.ID = uuid.Must(uuid.NewRandom())
+
+			sourceNamespace := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				Config:      map[string]string{"bucket": "gs://some_folder"},
+				ProjectSpec: sourceProject,
+			}
+			targetNamespace := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        sourceNamespace.Name,
+				Config:      sourceNamespace.Config,
+				ProjectSpec: savedTargetProject,
+			}
 
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepository.On("GetByName", sourceProject.Name).Return(sourceProject, nil)
+			projectRepository.On("GetByName", targetProject.Name).Return(models.ProjectSpec{}, store.ErrResourceNotFound).Once()
+			projectRepository.On("Save", targetProject).Return(nil)
+			projectRepository.On("GetByName", targetProject.Name).Return(savedTargetProject, nil).Once()
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			sec := models.ProjectSecretItem{
-				Name:  "hello",
-				Value: "world",
-			}
+			secretRepository := new(mock.ProjectSecretRepository)
+			secretRepository.On("GetAll").Return([]models.ProjectSecretItem{
+				{Name: "STORAGE", Value: "c2VjcmV0"},
+				{Name: "TASK_BQ2BQ", Value: "c2VjcmV0"},
+			}, nil)
+			defer secretRepository.AssertExpectations(t)
 
-			projectSecretRepository := new(mock.ProjectSecretRepository)
-			projectSecretRepository.On("Save", sec).Return(nil)
-			defer projectSecretRepository.AssertExpectations(t)
+			secretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			secretRepoFactory.On("New", sourceProject).Return(secretRepository)
+			defer secretRepoFactory.AssertExpectations(t)
 
-			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
-			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
-			defer projectSecretRepoFactory.AssertExpectations(t)
+			sourceNamespaceRepo := new(mock.NamespaceRepository)
+			sourceNamespaceRepo.On("GetAll").Return([]models.NamespaceSpec{sourceNamespace}, nil)
+			defer sourceNamespaceRepo.AssertExpectations(t)
+
+			targetNamespaceRepo := new(mock.NamespaceRepository)
+			targetNamespaceRepo.On("Save", models.NamespaceSpec{Name: sourceNamespace.Name, Config: sourceNamespace.Config}).Return(nil)
+			targetNamespaceRepo.On("GetByName", sourceNamespace.Name).Return(targetNamespace, nil)
+			defer targetNamespaceRepo.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", sourceProject).Return(sourceNamespaceRepo)
+			namespaceRepoFact.On("New", savedTargetProject).Return(targetNamespaceRepo)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobSpec := models.JobSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "my-job",
+			}
+			clonedJobSpec := jobSpec
+			clonedJobSpec.ID = uuid.Nil
 
 			jobService := new(mock.JobService)
+			jobService.On("GetAll", sourceNamespace).Return([]models.JobSpec{jobSpec}, nil)
+			jobService.On("Create", clonedJobSpec, targetNamespace).Return(nil)
 			defer jobService.AssertExpectations(t)
 
+			// prepare mocked datastore
+			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+			dsTypeTableController := new(mock.DatastoreTypeController)
+			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("clone-store")
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeTable: dsTypeTableController,
+			})
+			_ = models.DatastoreRegistry.Add(datastorer)
+
+			resourceSpec := models.ResourceSpec{
+				ID:        uuid.Must(uuid.NewRandom()),
+				Version:   1,
+				Name:      "prod-gcp.dataset.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			clonedResourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "staging-gcp.dataset.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			resourceProto := &pb.ResourceSpecification{
+				Version: 1,
+				Name:    resourceSpec.Name,
+				Type:    resourceSpec.Type.String(),
+			}
+			resourceProtoBytes, _ := proto.Marshal(resourceProto)
+			resourceSpecWithoutID := resourceSpec
+			resourceSpecWithoutID.ID = uuid.Nil
+			dsTypeTableAdapter.On("ToProtobuf", resourceSpecWithoutID).Return(resourceProtoBytes, nil)
+			dsTypeTableAdapter.On("FromProtobuf", mock2.MatchedBy(func(b []byte) bool {
+				adapted := &pb.ResourceSpecification{}
+				return proto.Unmarshal(b, adapted) == nil && adapted.GetName() == clonedResourceSpec.Name
+			})).Return(clonedResourceSpec, nil)
+			defer dsTypeTableAdapter.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("GetAll", sourceNamespace, "clone-store").Return([]models.ResourceSpec{resourceSpec}, nil)
+			resourceSvc.On("GetAll", sourceNamespace, mock2.Anything).Return([]models.ResourceSpec{}, nil)
+			resourceSvc.On("SaveResource", targetNamespace, []models.ResourceSpec{clonedResourceSpec}).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				"someVersion1.0",
-				jobService, nil, nil,
+				"Version",
+				jobService,
+				nil,
+				resourceSvc,
 				projectRepoFactory,
+				namespaceRepoFact,
+				secretRepoFactory,
+				v1.NewAdapter(nil, nil, models.DatastoreRegistry),
 				nil,
-				projectSecretRepoFactory,
-				adapter,
 				nil,
 				nil,
 				nil,
 			)
 
-			secretRequest := pb.RegisterSecretRequest{
-				ProjectName: projectSpec.Name,
-				SecretName:  "hello",
-				Value:       base64.StdEncoding.EncodeToString([]byte("world")),
-			}
-			resp, err := runtimeServiceServer.RegisterSecret(context.Background(), &secretRequest)
+			resp, err := runtimeServiceServer.CloneProject(context.Background(), &pb.CloneProjectRequest{
+				SourceProjectName: sourceProject.Name,
+				TargetProjectName: targetProject.Name,
+				Config: map[string]string{
+					"scheduler_host": "http://staging-airflow.example.io",
+				},
+				ResourceProject: "staging-gcp",
+			})
 			assert.Nil(t, err)
-			assert.Equal(t, &pb.RegisterSecretResponse{
-				Success: true,
-			}, resp)
+			assert.Equal(t, true, resp.GetSuccess())
+			assert.Equal(t, []string{"STORAGE", "TASK_BQ2BQ"}, resp.GetSecrets())
+			secretRepository.AssertNotCalled(t, "Save", mock2.Anything)
+			resourceSvc.AssertNotCalled(t, "UpdateResource", mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything)
+			resourceSvc.AssertNotCalled(t, "CreateResource", mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything)
 		})
-		t.Run("should return error if saving to secret repository fails", func(t *testing.T) {
-			projectName := "a-data-project"
+		t.Run("should remove the target project if cloning fails", func(t *testing.T) {
+			targetProject := models.ProjectSpec{
+				Name:   "a-staging-project",
+				Config: sourceProject.Config,
+			}
+			savedTargetProject := targetProject
+			savedTargetProject.ID = uuid.Must(uuid.NewRandom())
 
-			projectSpec := models.ProjectSpec{
-				Name: projectName,
-				Config: map[string]string{
-					"BUCKET": "gs://some_folder",
-				},
+			sourceNamespace := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: sourceProject,
+			}
+			targetNamespace := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        sourceNamespace.Name,
+				ProjectSpec: savedTargetProject,
 			}
-			adapter := v1.NewAdapter(nil, nil, nil)
 
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepository.On("GetByName", sourceProject.Name).Return(sourceProject, nil)
+			projectRepository.On("GetByName", targetProject.Name).Return(models.ProjectSpec{}, store.ErrResourceNotFound).Once()
+			projectRepository.On("Save", targetProject).Return(nil)
+			projectRepository.On("GetByName", targetProject.Name).Return(savedTargetProject, nil).Once()
+			projectRepository.On("Delete", targetProject.Name).Return(nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			sec := models.ProjectSecretItem{
-				Name:  "hello",
-				Value: "world",
-			}
+			secretRepository := new(mock.ProjectSecretRepository)
+			secretRepository.On("GetAll").Return([]models.ProjectSecretItem{}, nil)
+			defer secretRepository.AssertExpectations(t)
 
-			projectSecretRepository := new(mock.ProjectSecretRepository)
-			projectSecretRepository.On("Save", sec).Return(errors.New("random error"))
-			defer projectSecretRepository.AssertExpectations(t)
+			secretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			secretRepoFactory.On("New", sourceProject).Return(secretRepository)
+			defer secretRepoFactory.AssertExpectations(t)
 
-			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
-			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
-			defer projectSecretRepoFactory.AssertExpectations(t)
+			sourceNamespaceRepo := new(mock.NamespaceRepository)
+			sourceNamespaceRepo.On("GetAll").Return([]models.NamespaceSpec{sourceNamespace}, nil)
+			defer sourceNamespaceRepo.AssertExpectations(t)
+
+			targetNamespaceRepo := new(mock.NamespaceRepository)
+			targetNamespaceRepo.On("Save", models.NamespaceSpec{Name: sourceNamespace.Name}).Return(nil)
+			targetNamespaceRepo.On("GetByName", sourceNamespace.Name).Return(targetNamespace, nil)
+			defer targetNamespaceRepo.AssertExpectations(t)
 
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", sourceProject).Return(sourceNamespaceRepo)
+			namespaceRepoFact.On("New", savedTargetProject).Return(targetNamespaceRepo)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobSpec := models.JobSpec{
+				Name: "my-job",
+			}
 			jobService := new(mock.JobService)
+			jobService.On("GetAll", sourceNamespace).Return([]models.JobSpec{jobSpec}, nil)
+			jobService.On("Create", jobSpec, targetNamespace).Return(errors.Wrap(job.ErrInvalidJobSpec, "bad job"))
 			defer jobService.AssertExpectations(t)
 
+			resourceSvc := new(mock.DatastoreService)
+			defer resourceSvc.AssertExpectations(t)
+
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				"someVersion1.0",
-				jobService, nil, nil,
+				"Version",
+				jobService,
+				nil,
+				resourceSvc,
 				projectRepoFactory,
+				namespaceRepoFact,
+				secretRepoFactory,
+				v1.NewAdapter(nil, nil, models.DatastoreRegistry),
 				nil,
-				projectSecretRepoFactory,
-				adapter,
 				nil,
 				nil,
 				nil,
 			)
 
-			secretRequest := pb.RegisterSecretRequest{
-				ProjectName: projectSpec.Name,
-				SecretName:  "hello",
-				Value:       base64.StdEncoding.EncodeToString([]byte("world")),
-			}
-			resp, err := runtimeServiceServer.RegisterSecret(context.Background(), &secretRequest)
+			resp, err := runtimeServiceServer.CloneProject(context.Background(), &pb.CloneProjectRequest{
+				SourceProjectName: sourceProject.Name,
+				TargetProjectName: targetProject.Name,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
 			assert.Nil(t, resp)
-			assert.Equal(t, "rpc error: code = Internal desc = random error: failed to save secret hello", err.Error())
 		})
-	})
+		t.Run("should fail if target project already exists", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", sourceProject.Name).Return(sourceProject, nil)
+			projectRepository.On("GetByName", "a-staging-project").Return(models.ProjectSpec{Name: "a-staging-project"}, nil)
+			defer projectRepository.AssertExpectations(t)
 
-	t.Run("DeployJobSpecification", func(t *testing.T) {
-		t.Run("should deploy the job", func(t *testing.T) {
-			Version := "1.0.1"
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
 
-			projectName := "a-data-project"
-			jobName1 := "a-data-job"
-			taskName := "a-data-task"
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.CloneProject(context.Background(), &pb.CloneProjectRequest{
+				SourceProjectName: sourceProject.Name,
+				TargetProjectName: "a-staging-project",
+			})
+			assert.Equal(t, codes.AlreadyExists, status.Code(err))
+			assert.Nil(t, resp)
+		})
+	})
 
+	t.Run("ExportProject", func(t *testing.T) {
+		t.Run("should stream project, namespaces, jobs with inlined assets and resources without secret values", func(t *testing.T) {
 			projectSpec := models.ProjectSpec{
 				ID:   uuid.Must(uuid.NewRandom()),
-				Name: projectName,
+				Name: "a-data-project",
 				Config: map[string]string{
-					"bucket": "gs://some_folder",
+					models.ProjectStoragePathKey: "gs://some_folder",
 				},
 			}
-
-			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
+			namespaceSpecs := []models.NamespaceSpec{
+				{
+					ID:          uuid.Must(uuid.NewRandom()),
+					Name:        "dev-test-namespace-1",
+					ProjectSpec: projectSpec,
+				},
+				{
+					ID:          uuid.Must(uuid.NewRandom()),
+					Name:        "dev-test-namespace-2",
+					ProjectSpec: projectSpec,
 				},
-				ProjectSpec: projectSpec,
 			}
 
-			execUnit1 := new(mock.TaskPlugin)
-			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
-				Name: taskName,
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			secretRepository := new(mock.ProjectSecretRepository)
+			secretRepository.On("GetAll").Return([]models.ProjectSecretItem{
+				{Name: "TASK_BQ2BQ", Value: "c2VjcmV0LXRhc2s="},
+				{Name: "STORAGE", Value: "c2VjcmV0LXN0b3JhZ2U="},
 			}, nil)
-			defer execUnit1.AssertExpectations(t)
+			defer secretRepository.AssertExpectations(t)
+
+			secretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			secretRepoFactory.On("New", projectSpec).Return(secretRepository)
+			defer secretRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetAll").Return(namespaceSpecs, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			execUnit := new(mock.TaskPlugin)
+			execUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: "bq2bq",
+			}, nil)
+			defer execUnit.AssertExpectations(t)
 
 			jobSpecs := []models.JobSpec{
 				{
-					Name: jobName1,
-					Task: models.JobSpecTask{
-						Unit: execUnit1,
-						Config: models.JobSpecConfigs{
-							{
-								Name:  "do",
-								Value: "this",
-							},
-						},
+					Name: "job-1",
+					Task: models.JobSpecTask{Unit: execUnit},
+					Assets: *models.JobAssets{}.New([]models.JobSpecAsset{
+						{Name: "query.sql", Value: "gs://shared-assets/query.sql"},
+					}),
+				},
+				{
+					Name: "job-2",
+					Task: models.JobSpecTask{Unit: execUnit},
+				},
+			}
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpecs[0]).Return(jobSpecs[:1], nil)
+			jobService.On("GetAll", namespaceSpecs[1]).Return(jobSpecs[1:], nil)
+			defer jobService.AssertExpectations(t)
+
+			assetLoader := new(mock.AssetLoader)
+			assetLoader.On("Load", context.Background(), "gs://shared-assets/query.sql").Return([]byte("select 1"), nil)
+			defer assetLoader.AssertExpectations(t)
+
+			assetLoaderFactory := new(mock.AssetLoaderFactory)
+			assetLoaderFactory.On("New", projectSpec).Return(assetLoader)
+			defer assetLoaderFactory.AssertExpectations(t)
+
+			// prepare mocked datastore
+			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+			dsTypeTableController := new(mock.DatastoreTypeController)
+			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("export-store")
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeTable: dsTypeTableController,
+			})
+			_ = models.DatastoreRegistry.Add(datastorer)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.dataset.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			resourceProtoBytes, _ := proto.Marshal(&pb.ResourceSpecification{
+				Version: 1,
+				Name:    resourceSpec.Name,
+				Type:    resourceSpec.Type.String(),
+			})
+			dsTypeTableAdapter.On("ToProtobuf", resourceSpec).Return(resourceProtoBytes, nil)
+			defer dsTypeTableAdapter.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("GetAll", namespaceSpecs[1], "export-store").Return([]models.ResourceSpec{resourceSpec}, nil)
+			resourceSvc.On("GetAll", mock2.Anything, mock2.Anything).Return([]models.ResourceSpec{}, nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			var sent []*pb.ExportProjectResponse
+			respStream := new(mock.RuntimeService_ExportProjectServer)
+			respStream.On("Context").Return(context.Background())
+			respStream.On("Send", mock2.Anything).Run(func(args mock2.Arguments) {
+				sent = append(sent, args.Get(0).(*pb.ExportProjectResponse))
+			}).Return(nil)
+			defer respStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				jobService,
+				nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				secretRepoFactory,
+				v1.NewAdapter(nil, nil, models.DatastoreRegistry),
+				nil,
+				nil,
+				nil,
+				assetLoaderFactory,
+			)
+
+			err := runtimeServiceServer.ExportProject(&pb.ExportProjectRequest{
+				ProjectName: projectSpec.Name,
+			}, respStream)
+			assert.Nil(t, err)
+
+			assert.Equal(t, 6, len(sent))
+			assert.Equal(t, projectSpec.Name, sent[0].GetProject().GetName())
+			assert.Equal(t, []string{"STORAGE", "TASK_BQ2BQ"}, sent[0].GetSecretNames())
+			assert.Equal(t, namespaceSpecs[0].Name, sent[1].GetNamespace().GetName())
+			assert.Equal(t, "job-1", sent[2].GetJob().GetName())
+			assert.Equal(t, namespaceSpecs[0].Name, sent[2].GetNamespaceName())
+			assert.Equal(t, map[string]string{"query.sql": "select 1"}, sent[2].GetJob().GetAssets())
+			assert.Equal(t, namespaceSpecs[1].Name, sent[3].GetNamespace().GetName())
+			assert.Equal(t, "job-2", sent[4].GetJob().GetName())
+			assert.Equal(t, resourceSpec.Name, sent[5].GetResource().GetName())
+			assert.Equal(t, namespaceSpecs[1].Name, sent[5].GetNamespaceName())
+			assert.Equal(t, "export-store", sent[5].GetDatastoreName())
+
+			for _, resp := range sent {
+				respBytes, err := proto.Marshal(resp)
+				assert.Nil(t, err)
+				assert.NotContains(t, string(respBytes), "c2VjcmV0")
+			}
+		})
+	})
+
+	t.Run("ImportProject", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			Name: "a-data-project",
+			Config: map[string]string{
+				models.ProjectStoragePathKey: "gs://some_folder",
+				models.ProjectSchedulerHost:  "http://airflow.example.io",
+			},
+		}
+		savedProjectSpec := projectSpec
+		savedProjectSpec.ID = uuid.Must(uuid.NewRandom())
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			Config:      map[string]string{},
+			ProjectSpec: savedProjectSpec,
+		}
+		bundle := &pb.ImportProjectRequest{
+			Project: &pb.ProjectSpecification{
+				Name:   projectSpec.Name,
+				Config: projectSpec.Config,
+			},
+			Namespaces: []*pb.NamespaceSpecification{
+				{Name: namespaceSpec.Name},
+			},
+			Jobs: []*pb.ImportProjectRequest_Job{
+				{
+					NamespaceName: namespaceSpec.Name,
+					Job: &pb.JobSpecification{
+						Version:   1,
+						Name:      "job-1",
+						Owner:     "optimus",
+						StartDate: "2021-01-01",
+						Interval:  "@daily",
+						TaskName:  "bq2bq",
+					},
+				},
+			},
+			Resources: []*pb.ImportProjectRequest_Resource{
+				{
+					NamespaceName: namespaceSpec.Name,
+					DatastoreName: "bq",
+					Resource: &pb.ResourceSpecification{
+						Version: 1,
+						Name:    "proj.dataset.user",
+						Type:    models.ResourceTypeTable.String(),
 					},
-					Assets: *models.JobAssets{}.New(
-						[]models.JobSpecAsset{
-							{
-								Name:  "query.sql",
-								Value: "select * from 1",
-							},
-						}),
 				},
+			},
+		}
+		resourceSpec := models.ResourceSpec{
+			Version: 1,
+			Name:    "proj.dataset.user",
+			Type:    models.ResourceTypeTable,
+		}
+		isImportedJob := mock2.MatchedBy(func(jobSpec models.JobSpec) bool {
+			return jobSpec.Name == "job-1"
+		})
+
+		importedJob := models.JobSpec{Name: "job-1"}
+		stagedNamespaceSpec := models.NamespaceSpec{Name: namespaceSpec.Name, Config: map[string]string{}, ProjectSpec: projectSpec}
+
+		// newAdapter prepares an adapter able to read jobs and resources of the bundle
+		newAdapter := func(t *testing.T) (v1.ProtoAdapter, func()) {
+			execUnit := new(mock.TaskPlugin)
+			taskRepo := new(mock.SupportedTaskRepo)
+			taskRepo.On("GetByName", "bq2bq").Return(execUnit, nil)
+
+			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+			dsTypeTableAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
+			dsTypeTableController := new(mock.DatastoreTypeController)
+			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeTable: dsTypeTableController,
+			})
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+
+			return v1.NewAdapter(taskRepo, nil, dsRepo), func() {
+				taskRepo.AssertExpectations(t)
+				dsRepo.AssertExpectations(t)
 			}
+		}
 
+		// setup prepares repositories for importing the bundle to a new project
+		setup := func(t *testing.T) (*mock.ProjectRepository, *mock.ProjectRepoFactory, *mock.NamespaceRepoFactory, v1.ProtoAdapter, func()) {
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
-			defer projectRepository.AssertExpectations(t)
+			projectRepository.On("GetByName", projectSpec.Name).Return(models.ProjectSpec{}, store.ErrResourceNotFound).Once()
+			projectRepository.On("Save", projectSpec).Return(nil)
+			projectRepository.On("GetByName", projectSpec.Name).Return(savedProjectSpec, nil).Once()
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
-			defer projectRepoFactory.AssertExpectations(t)
 
-			jobSpecRepository := new(mock.JobSpecRepository)
-			defer jobSpecRepository.AssertExpectations(t)
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("Save", models.NamespaceSpec{Name: namespaceSpec.Name, Config: map[string]string{}}).Return(nil)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 
-			jobSpecRepoFactory := new(mock.JobSpecRepoFactory)
-			defer jobSpecRepoFactory.AssertExpectations(t)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", savedProjectSpec).Return(namespaceRepository)
+
+			adapter, assertAdapter := newAdapter(t)
+			return projectRepository, projectRepoFactory, namespaceRepoFact, adapter, func() {
+				projectRepository.AssertExpectations(t)
+				projectRepoFactory.AssertExpectations(t)
+				namespaceRepository.AssertExpectations(t)
+				namespaceRepoFact.AssertExpectations(t)
+				assertAdapter()
+			}
+		}
+
+		t.Run("should validate and apply the whole bundle", func(t *testing.T) {
+			_, projectRepoFactory, namespaceRepoFact, adapter, assertAll := setup(t)
+			defer assertAll()
+
+			jobService := new(mock.JobService)
+			jobService.On("Validate", stagedNamespaceSpec, isImportedJob).Return(nil)
+			jobService.On("Create", isImportedJob, namespaceSpec).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("CreateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			var sent []*pb.ImportProjectResponse
+			respStream := new(mock.RuntimeService_ImportProjectServer)
+			respStream.On("Context").Return(context.Background())
+			respStream.On("Send", mock2.Anything).Run(func(args mock2.Arguments) {
+				sent = append(sent, args.Get(0).(*pb.ImportProjectResponse))
+			}).Return(nil)
+			defer respStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				jobService,
+				nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			err := runtimeServiceServer.ImportProject(bundle, respStream)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(sent))
+			assert.True(t, sent[1].GetSuccess())
+			assert.Equal(t, "imported 1 namespaces, 1 jobs and 1 resources to project a-data-project", sent[1].GetMessage())
+			jobService.AssertNotCalled(t, "KeepOnly", mock2.Anything, mock2.Anything, mock2.Anything)
+		})
+		t.Run("should delete jobs, resources and the project created by the import if it fails midway", func(t *testing.T) {
+			projectRepository, projectRepoFactory, namespaceRepoFact, adapter, assertAll := setup(t)
+			projectRepository.On("Delete", projectSpec.Name).Return(nil)
+			defer assertAll()
+
+			jobService := new(mock.JobService)
+			jobService.On("Validate", stagedNamespaceSpec, isImportedJob).Return(nil)
+			jobService.On("Create", isImportedJob, namespaceSpec).Return(nil)
+			jobService.On("GetAll", namespaceSpec).Return([]models.JobSpec{importedJob}, nil)
+			jobService.On("KeepOnly", namespaceSpec, []models.JobSpec(nil), true).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("CreateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).
+				Return(errors.New("dataset not found"))
+			resourceSvc.On("GetAll", namespaceSpec, "bq").Return([]models.ResourceSpec{resourceSpec}, nil)
+			resourceSvc.On("KeepOnly", context.Background(), namespaceSpec, "bq", []models.ResourceSpec(nil), nil).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			var sent []*pb.ImportProjectResponse
+			respStream := new(mock.RuntimeService_ImportProjectServer)
+			respStream.On("Context").Return(context.Background())
+			respStream.On("Send", mock2.Anything).Run(func(args mock2.Arguments) {
+				sent = append(sent, args.Get(0).(*pb.ImportProjectResponse))
+			}).Return(nil)
+			defer respStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				jobService,
+				nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			err := runtimeServiceServer.ImportProject(bundle, respStream)
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Contains(t, err.Error(), "dataset not found")
+			assert.Equal(t, 1, len(sent))
+			assert.False(t, sent[0].GetSuccess())
+			assert.Contains(t, sent[0].GetMessage(), "rolled back jobs and resources created by the import")
+		})
+		t.Run("should restore the project it changed and keep jobs saved meanwhile if it fails midway", func(t *testing.T) {
+			existingProjectSpec := savedProjectSpec
+			existingProjectSpec.Config = map[string]string{
+				models.ProjectStoragePathKey: "gs://old_folder",
+				models.ProjectSchedulerHost:  "http://old-airflow.example.io",
+			}
+			existingNamespaceSpec := namespaceSpec
+			existingNamespaceSpec.Config = map[string]string{"BUCKET": "old_bucket"}
+			existingNamespaceSpec.ProjectSpec = existingProjectSpec
+			existingJob := models.JobSpec{Name: "job-0"}
+			concurrentJob := models.JobSpec{Name: "job-2"}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(existingProjectSpec, nil)
+			projectRepository.On("Save", projectSpec).Return(nil)
+			projectRepository.On("Save", existingProjectSpec).Return(nil)
+			defer projectRepository.AssertExpectations(t)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetAll").Return([]models.NamespaceSpec{existingNamespaceSpec}, nil)
+			namespaceRepository.On("Save", models.NamespaceSpec{Name: namespaceSpec.Name, Config: map[string]string{}}).Return(nil)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			namespaceRepository.On("Save", existingNamespaceSpec).Return(nil)
+			defer namespaceRepository.AssertExpectations(t)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", existingProjectSpec).Return(namespaceRepository)
+
+			adapter, assertAdapter := newAdapter(t)
+			defer assertAdapter()
+
+			var runtimeServiceServer *v1.RuntimeServiceServer
+			var deployErr error
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", existingNamespaceSpec).Return([]models.JobSpec{existingJob}, nil)
+			jobService.On("Validate", stagedNamespaceSpec, isImportedJob).Return(nil)
+			jobService.On("Create", isImportedJob, namespaceSpec).Run(func(args mock2.Arguments) {
+				deployErr = runtimeServiceServer.DeployResourceSpecification(&pb.DeployResourceSpecificationRequest{
+					ProjectName:   projectSpec.Name,
+					Namespace:     namespaceSpec.Name,
+					DatastoreName: "bq",
+				}, new(mock.RuntimeService_DeployResourceSpecificationServer))
+			}).Return(nil)
+			jobService.On("GetAll", namespaceSpec).Return([]models.JobSpec{existingJob, importedJob, concurrentJob}, nil)
+			jobService.On("KeepOnly", namespaceSpec, []models.JobSpec{existingJob, concurrentJob}, true).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("GetAll", existingNamespaceSpec, "bq").Return([]models.ResourceSpec{}, nil)
+			resourceSvc.On("CreateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).
+				Return(errors.New("dataset not found"))
+			resourceSvc.On("GetAll", namespaceSpec, "bq").Return([]models.ResourceSpec{resourceSpec}, nil)
+			resourceSvc.On("KeepOnly", context.Background(), namespaceSpec, "bq", []models.ResourceSpec(nil), nil).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			respStream := new(mock.RuntimeService_ImportProjectServer)
+			respStream.On("Context").Return(context.Background())
+			respStream.On("Send", mock2.Anything).Return(nil)
+			defer respStream.AssertExpectations(t)
+
+			runtimeServiceServer = v1.NewRuntimeServiceServer(
+				"Version",
+				jobService,
+				nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			err := runtimeServiceServer.ImportProject(bundle, respStream)
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Equal(t, codes.Aborted, status.Code(deployErr))
+			projectRepository.AssertNotCalled(t, "Delete", mock2.Anything)
+		})
+		t.Run("should not persist anything if a job of bundle is invalid", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(models.ProjectSpec{}, store.ErrResourceNotFound)
+			defer projectRepository.AssertExpectations(t)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			adapter, assertAdapter := newAdapter(t)
+			defer assertAdapter()
+
+			jobService := new(mock.JobService)
+			jobService.On("Validate", stagedNamespaceSpec, isImportedJob).Return(job.ErrInvalidJobSpec)
+			defer jobService.AssertExpectations(t)
+
+			respStream := new(mock.RuntimeService_ImportProjectServer)
+			defer respStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				jobService,
+				nil,
+				nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			err := runtimeServiceServer.ImportProject(&pb.ImportProjectRequest{
+				Project:    bundle.Project,
+				Namespaces: bundle.Namespaces,
+				Jobs:       bundle.Jobs,
+				Resources:  bundle.Resources,
+			}, respStream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, err.Error(), "invalid job job-1")
+			projectRepository.AssertNotCalled(t, "Save", mock2.Anything)
+		})
+		t.Run("should not persist anything if bundle is invalid", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(models.ProjectSpec{}, store.ErrResourceNotFound)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			respStream := new(mock.RuntimeService_ImportProjectServer)
+			defer respStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil,
+				nil,
+				nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			err := runtimeServiceServer.ImportProject(&pb.ImportProjectRequest{
+				Project: bundle.Project,
+				Jobs: []*pb.ImportProjectRequest_Job{
+					bundle.Jobs[0],
+					{NamespaceName: "unknown-namespace", Job: bundle.Jobs[0].Job},
+				},
+			}, respStream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, err.Error(), "job job-1 refers namespace unknown-namespace missing from bundle")
+			projectRepository.AssertNotCalled(t, "Save", mock2.Anything)
+		})
+	})
+
+	t.Run("ListAuditEvents", func(t *testing.T) {
+		newServer := func(repo *mock.AuditEventRepository) *v1.RuntimeServiceServer {
+			server := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			if repo != nil {
+				server.AuditEventRepo = repo
+			}
+			return server
+		}
+		t.Run("should list audit events as per filters of the request", func(t *testing.T) {
+			createdAt := time.Date(2021, 11, 1, 10, 0, 0, 0, time.UTC)
+			startTime := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+			repo := new(mock.AuditEventRepository)
+			repo.On("List", context.Background(), models.AuditEventFilter{
+				ProjectName: "a-data-project",
+				Method:      "/odpf.optimus.RuntimeService/RegisterSecret",
+				StartTime:   startTime,
+				Offset:      10,
+				Limit:       50,
+			}).Return([]models.AuditEvent{
+				{
+					Method:        "/odpf.optimus.RuntimeService/RegisterSecret",
+					Identity:      "jane@example.com",
+					UserAgent:     "optimus-cli/0.1",
+					ProjectName:   "a-data-project",
+					RequestDigest: "abc",
+					Code:          "OK",
+					CreatedAt:     createdAt,
+				},
+			}, nil)
+			defer repo.AssertExpectations(t)
+
+			resp, err := newServer(repo).ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+				ProjectName: "a-data-project",
+				Method:      "/odpf.optimus.RuntimeService/RegisterSecret",
+				StartTime:   timestamppb.New(startTime),
+				PageOffset:  10,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, []*pb.AuditEvent{
+				{
+					Method:        "/odpf.optimus.RuntimeService/RegisterSecret",
+					Identity:      "jane@example.com",
+					UserAgent:     "optimus-cli/0.1",
+					ProjectName:   "a-data-project",
+					RequestDigest: "abc",
+					Code:          "OK",
+					CreatedAt:     timestamppb.New(createdAt),
+				},
+			}, resp.GetEvents())
+		})
+		t.Run("should reject invalid pages", func(t *testing.T) {
+			_, err := newServer(new(mock.AuditEventRepository)).ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{
+				PageSize: 1000,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should return unimplemented if audit log is not enabled", func(t *testing.T) {
+			_, err := newServer(nil).ListAuditEvents(context.Background(), &pb.ListAuditEventsRequest{})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		})
+	})
+
+	t.Run("UpdateProjectConfig", func(t *testing.T) {
+		projectName := "a-data-project"
+		newServer := func(projectRepoFactory *mock.ProjectRepoFactory) *v1.RuntimeServiceServer {
+			return v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+		}
+		t.Run("should merge requested configs into configs of the project", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"STORAGE_PATH":   "gs://some_folder",
+					"SCHEDULER_HOST": "http://airflow.example.io",
+					"BUCKET":         "gs://some_folder",
+					"ENV":            "staging",
+				},
+				Secret: models.ProjectSecrets{{Name: "STORAGE", Value: "base64"}},
+			}
+			updatedSpec := projectSpec
+			updatedSpec.Config = map[string]string{
+				"STORAGE_PATH":   "gs://some_folder",
+				"SCHEDULER_HOST": "http://airflow.example.io",
+				"ENV":            "production",
+				"TEAM":           "data",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			projectRepository.On("Save", updatedSpec).Return(nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			resp, err := newServer(projectRepoFactory).UpdateProjectConfig(context.Background(), &pb.UpdateProjectConfigRequest{
+				ProjectName: projectName,
+				Config:      map[string]string{"env": "production", "TEAM": "data"},
+				RemoveKeys:  []string{"BUCKET"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, updatedSpec.Config, resp.GetProject().GetConfig())
+			assert.Empty(t, resp.GetProject().GetSecrets())
+		})
+		t.Run("should not remove required configs of the project", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				Name: projectName,
+				Config: map[string]string{
+					"STORAGE_PATH":   "gs://some_folder",
+					"SCHEDULER_HOST": "http://airflow.example.io",
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			_, err := newServer(projectRepoFactory).UpdateProjectConfig(context.Background(), &pb.UpdateProjectConfigRequest{
+				ProjectName: projectName,
+				RemoveKeys:  []string{"storage_path"},
+			})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+		t.Run("should return not found for unknown projects", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(models.ProjectSpec{}, store.ErrResourceNotFound)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			_, err := newServer(projectRepoFactory).UpdateProjectConfig(context.Background(), &pb.UpdateProjectConfigRequest{
+				ProjectName: projectName,
+				Config:      map[string]string{"ENV": "production"},
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("RegisterProjectNamespace", func(t *testing.T) {
+		t.Run("should save a new namespace", func(t *testing.T) {
+			projectName := "a-data-project"
+
+			projectSpec := models.ProjectSpec{
+				Name: projectName,
+				Config: map[string]string{
+					"BUCKET": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				Name:   "dev-test-namespace-1",
+				Config: map[string]string{},
+			}
+
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobSvc := new(mock.JobService)
+			defer jobSvc.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("Save", namespaceSpec).Return(nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobSvc,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			namespaceRequest := pb.RegisterProjectNamespaceRequest{
+				ProjectName: projectName,
+				Namespace:   adapter.ToNamespaceProto(namespaceSpec),
+			}
+			resp, err := runtimeServiceServer.RegisterProjectNamespace(context.Background(), &namespaceRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.RegisterProjectNamespaceResponse{
+				Success: true,
+				Message: "saved successfully",
+			}, resp)
+		})
+		t.Run("should throw error if project does not exist while saving a namespace", func(t *testing.T) {
+			projectName := "a-data-project"
+
+			projectSpec := models.ProjectSpec{
+				Name: projectName,
+				Config: map[string]string{
+					"BUCKET": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				Name: "dev-test-namespace-1",
+			}
+
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, store.ErrResourceNotFound)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobSvc := new(mock.JobService)
+			defer jobSvc.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobSvc,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			namespaceRequest := pb.RegisterProjectNamespaceRequest{
+				ProjectName: projectName,
+				Namespace:   adapter.ToNamespaceProto(namespaceSpec),
+			}
+			_, err := runtimeServiceServer.RegisterProjectNamespace(context.Background(), &namespaceRequest)
+			assert.NotNil(t, err)
+			assert.Equal(t, "rpc error: code = NotFound desc = resource not found: project a-data-project not found", err.Error())
+		})
+		t.Run("should return internal error if project lookup fails while saving a namespace", func(t *testing.T) {
+			projectName := "a-data-project"
+
+			projectSpec := models.ProjectSpec{
+				Name: projectName,
+				Config: map[string]string{
+					"BUCKET": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				Name: "dev-test-namespace-1",
+			}
+
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, errors.New("connection refused"))
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobSvc := new(mock.JobService)
+			defer jobSvc.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobSvc,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			namespaceRequest := pb.RegisterProjectNamespaceRequest{
+				ProjectName: projectName,
+				Namespace:   adapter.ToNamespaceProto(namespaceSpec),
+			}
+			_, err := runtimeServiceServer.RegisterProjectNamespace(context.Background(), &namespaceRequest)
+			assert.NotNil(t, err)
+			assert.Equal(t, "rpc error: code = Internal desc = connection refused: project a-data-project not found", err.Error())
+		})
+	})
+
+	t.Run("RegisterJobSpecification", func(t *testing.T) {
+		t.Run("should save a job specification", func(t *testing.T) {
+			projectName := "a-data-project"
+
+			projectSpec := models.ProjectSpec{
+				Name: projectName,
+				Config: map[string]string{
+					"BUCKET": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			jobName := "my-job"
+			taskName := "bq2bq"
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name:  taskName,
+				Image: "random-image",
+			}, nil)
+			execUnit1.On("DefaultTaskAssets", context.Background(), mock2.Anything).Return(models.DefaultTaskAssetsResponse{}, nil)
+			defer execUnit1.AssertExpectations(t)
+			_ = models.TaskRegistry.Add(execUnit1)
+
+			jobSpec := models.JobSpec{
+				Name: jobName,
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "DO",
+							Value: "THIS",
+						},
+					},
+					Window: models.JobSpecTaskWindow{
+						Size:       time.Hour,
+						Offset:     0,
+						TruncateTo: "d",
+					},
+				},
+				Assets: *models.JobAssets{}.New(
+					[]models.JobSpecAsset{
+						{
+							Name:  "query.sql",
+							Value: "select * from 1",
+						},
+					}),
+				Dependencies: map[string]models.JobSpecDependency{},
+			}
+
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("Create", jobSpec, namespaceSpec).Return(nil)
+			jobSvc.On("Check", namespaceSpec, []models.JobSpec{jobSpec}, mock2.Anything).Return(nil)
+			jobSvc.On("SyncJob", mock2.Anything, namespaceSpec, jobName, mock2.Anything).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobSvc,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			jobProto, _ := adapter.ToJobProto(jobSpec)
+			request := pb.CreateJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				Spec:        jobProto,
+			}
+			resp, err := runtimeServiceServer.CreateJobSpecification(context.Background(), &request)
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.CreateJobSpecificationResponse{
+				Success: true,
+				Message: "job my-job is created and deployed successfully on project a-data-project",
+			}, resp)
+			jobSvc.AssertNotCalled(t, "Sync", mock2.Anything, mock2.Anything, mock2.Anything)
+			jobSvc.AssertNotCalled(t, "KeepOnly", mock2.Anything, mock2.Anything, mock2.Anything)
+		})
+	})
+
+	t.Run("RegisterSecret", func(t *testing.T) {
+		t.Run("should register a secret successfully", func(t *testing.T) {
+			projectName := "a-data-project"
+
+			projectSpec := models.ProjectSpec{
+				Name: projectName,
+				Config: map[string]string{
+					"BUCKET": "gs://some_folder",
+				},
+			}
+			adapter := v1.NewAdapter(nil, nil, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			sec := models.ProjectSecretItem{
+				Name:  "hello",
+				Value: "world",
+			}
+
+			projectSecretRepository := new(mock.ProjectSecretRepository)
+			projectSecretRepository.On("Save", sec).Return(nil)
+			defer projectSecretRepository.AssertExpectations(t)
+
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
+			defer projectSecretRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				projectSecretRepoFactory,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			secretRequest := pb.RegisterSecretRequest{
+				ProjectName: projectSpec.Name,
+				SecretName:  "hello",
+				Value:       base64.StdEncoding.EncodeToString([]byte("world")),
+			}
+			resp, err := runtimeServiceServer.RegisterSecret(context.Background(), &secretRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.RegisterSecretResponse{
+				Success: true,
+			}, resp)
+		})
+		t.Run("should return error if saving to secret repository fails", func(t *testing.T) {
+			projectName := "a-data-project"
+
+			projectSpec := models.ProjectSpec{
+				Name: projectName,
+				Config: map[string]string{
+					"BUCKET": "gs://some_folder",
+				},
+			}
+			adapter := v1.NewAdapter(nil, nil, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			sec := models.ProjectSecretItem{
+				Name:  "hello",
+				Value: "world",
+			}
+
+			projectSecretRepository := new(mock.ProjectSecretRepository)
+			projectSecretRepository.On("Save", sec).Return(errors.New("random error"))
+			defer projectSecretRepository.AssertExpectations(t)
+
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
+			defer projectSecretRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				projectSecretRepoFactory,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			secretRequest := pb.RegisterSecretRequest{
+				ProjectName: projectSpec.Name,
+				SecretName:  "hello",
+				Value:       base64.StdEncoding.EncodeToString([]byte("world")),
+			}
+			resp, err := runtimeServiceServer.RegisterSecret(context.Background(), &secretRequest)
+			assert.Nil(t, resp)
+			assert.Equal(t, "rpc error: code = Internal desc = random error: failed to save secret hello", err.Error())
+		})
+		t.Run("should return invalid argument for an empty secret value", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RegisterSecret(context.Background(), &pb.RegisterSecretRequest{
+				ProjectName: "a-data-project",
+				SecretName:  "hello",
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("DeployJobSpecification", func(t *testing.T) {
+		t.Run("should deploy the job", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+			jobName1 := "a-data-job"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket":                     "gs://some_folder",
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name: jobName1,
+					Task: models.JobSpecTask{
+						Unit: execUnit1,
+						Config: models.JobSpecConfigs{
+							{
+								Name:  "do",
+								Value: "this",
+							},
+						},
+					},
+					Assets: *models.JobAssets{}.New(
+						[]models.JobSpecAsset{
+							{
+								Name:  "query.sql",
+								Value: "select * from 1",
+							},
+						}),
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobSpecRepository := new(mock.JobSpecRepository)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			jobSpecRepoFactory := new(mock.JobSpecRepoFactory)
+			defer jobSpecRepoFactory.AssertExpectations(t)
+
+			allTasksRepo := new(mock.SupportedTaskRepo)
+			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
+			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			projectJobSpecRepository := new(mock.ProjectJobSpecRepository)
+			defer projectJobSpecRepository.AssertExpectations(t)
+
+			projectJobSpecRepoFactory := new(mock.ProjectJobSpecRepoFactory)
+			defer projectJobSpecRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Create", mock2.Anything, namespaceSpec).Return(nil)
+			jobService.On("KeepOnly", namespaceSpec, mock2.Anything, mock2.Anything).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.DeployJobSpecificationResponse) bool {
+				return resp.GetDeployId() != ""
+			})).Return(nil).Once()
+			grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.DeployJobSpecificationResponse) bool {
+				return resp.GetSummary() != nil
+			})).Return(nil).Once()
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			jobSpecsAdapted := []*pb.JobSpecification{}
+			for _, jobSpec := range jobSpecs {
+				jobSpecAdapted, _ := adapter.ToJobProto(jobSpec)
+				jobSpecsAdapted = append(jobSpecsAdapted, jobSpecAdapted)
+			}
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Jobs: jobSpecsAdapted, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Nil(t, err)
+		})
+		t.Run("should report unknown dependencies", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+			taskName := "a-data-task"
+			unknownDep := "bigquery://proj.dataset.unknown_table"
+
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+
+			jobSpec := models.JobSpec{
+				Name: "a-data-job",
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+					Window: models.JobSpecTaskWindow{
+						Size:       time.Hour,
+						TruncateTo: "d",
+					},
+				},
+				Dependencies: map[string]models.JobSpecDependency{},
+			}
+
+			newServer := func(jobService *mock.JobService) *v1.RuntimeServiceServer {
+				projectRepository := new(mock.ProjectRepository)
+				projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+
+				projectRepoFactory := new(mock.ProjectRepoFactory)
+				projectRepoFactory.On("New").Return(projectRepository)
+
+				namespaceRepository := new(mock.NamespaceRepository)
+				namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+
+				namespaceRepoFact := new(mock.NamespaceRepoFactory)
+				namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+				allTasksRepo := new(mock.SupportedTaskRepo)
+				allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
+
+				return v1.NewRuntimeServiceServer(
+					"1.0.1",
+					jobService,
+					nil, nil,
+					projectRepoFactory,
+					namespaceRepoFact,
+					nil,
+					v1.NewAdapter(allTasksRepo, nil, nil),
+					nil,
+					nil,
+					nil,
+					nil,
+				)
+			}
+			jobProto, _ := v1.NewAdapter(nil, nil, nil).ToJobProto(jobSpec)
+
+			t.Run("and fail the deploy before saving jobs in strict mode", func(t *testing.T) {
+				jobService := new(mock.JobService)
+				jobService.On("UnknownDependencies", projectSpec, mock2.Anything).Return(map[string][]string{
+					jobSpec.Name: {unknownDep},
+				}, nil)
+				defer jobService.AssertExpectations(t)
+
+				grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+				grpcRespStream.On("Context").Return(context.Background())
+				grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.DeployJobSpecificationResponse) bool {
+					return resp.GetDeployId() != ""
+				})).Return(nil).Once()
+				grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+					Success:       false,
+					JobName:       jobSpec.Name,
+					Message:       (&job.EventJobSpecUnknownDependencyUsed{Job: jobSpec.Name, Dependency: unknownDep}).String(),
+					ErrorCategory: pb.DeployJobSpecificationResponse_DEPENDENCY,
+				}).Return(nil).Once()
+				defer grpcRespStream.AssertExpectations(t)
+
+				deployRequest := pb.DeployJobSpecificationRequest{
+					ProjectName: projectSpec.Name,
+					Namespace:   namespaceSpec.Name,
+					Jobs:        []*pb.JobSpecification{jobProto},
+					Strict:      true,
+				}
+				err := newServer(jobService).DeployJobSpecification(&deployRequest, grpcRespStream)
+				assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+				assert.Contains(t, err.Error(), "a-data-job uses "+unknownDep)
+				jobService.AssertNotCalled(t, "Create", mock2.Anything, mock2.Anything)
+				jobService.AssertNotCalled(t, "KeepOnly", mock2.Anything, mock2.Anything, mock2.Anything)
+				jobService.AssertNotCalled(t, "Sync", mock2.Anything, mock2.Anything, mock2.Anything)
+			})
+			t.Run("and keep deploying when not strict", func(t *testing.T) {
+				jobService := new(mock.JobService)
+				jobService.On("Create", mock2.Anything, namespaceSpec).Return(nil)
+				jobService.On("KeepOnly", namespaceSpec, mock2.Anything, mock2.Anything).Return(nil)
+				jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+					observer := args.Get(2).(progress.Observer)
+					observer.Notify(&job.EventJobSpecUnknownDependencyUsed{Job: jobSpec.Name, Dependency: unknownDep})
+					observer.Notify(&job.EventJobUpload{Job: jobSpec})
+				}).Return(nil)
+				defer jobService.AssertExpectations(t)
+
+				var warnings []*pb.DeployJobSpecificationResponse
+				grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+				grpcRespStream.On("Context").Return(context.Background())
+				grpcRespStream.On("Send", mock2.Anything).Run(func(args mock2.Arguments) {
+					resp := args.Get(0).(*pb.DeployJobSpecificationResponse)
+					if strings.Contains(resp.GetMessage(), unknownDep) {
+						warnings = append(warnings, resp)
+					}
+				}).Return(nil)
+				defer grpcRespStream.AssertExpectations(t)
+
+				deployRequest := pb.DeployJobSpecificationRequest{
+					ProjectName: projectSpec.Name,
+					Namespace:   namespaceSpec.Name,
+					Jobs:        []*pb.JobSpecification{jobProto},
+				}
+				err := newServer(jobService).DeployJobSpecification(&deployRequest, grpcRespStream)
+				assert.Nil(t, err)
+				assert.Len(t, warnings, 1)
+				assert.Equal(t, jobSpec.Name, warnings[0].GetJobName())
+				jobService.AssertNotCalled(t, "UnknownDependencies", mock2.Anything, mock2.Anything)
+			})
+		})
+		t.Run("should abort the deploy if client stops reading progress", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("KeepOnly", namespaceSpec, mock2.Anything, mock2.Anything).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				obs := args.Get(2).(progress.Observer)
+				obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-1"}})
+
+				// sync should be cancelled once client fails to read
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Second):
+					t.Error("deploy was not cancelled for a blocked stream")
+				}
+			}).Return(context.Canceled)
+			defer jobService.AssertExpectations(t)
+
+			blocked := make(chan time.Time)
+			defer close(blocked)
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.Anything).WaitUntil(blocked).Return(nil)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.StreamSendTimeout = time.Millisecond * 10
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+			assert.Contains(t, err.Error(), "client did not read progress")
+		})
+		t.Run("should stop the deploy when cancelled using deploy id", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("KeepOnly", namespaceSpec, mock2.Anything, mock2.Anything).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Second):
+					t.Error("deploy was not cancelled")
+				}
+			}).Return(context.Canceled)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			cancelErrs := make(chan error, 1)
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.Anything).Run(func(args mock2.Arguments) {
+				resp := args.Get(0).(*pb.DeployJobSpecificationResponse)
+				if resp.GetDeployId() == "" {
+					return
+				}
+				go func() {
+					_, err := runtimeServiceServer.CancelDeploy(context.Background(), &pb.CancelDeployRequest{
+						ProjectName: projectSpec.Name,
+						DeployId:    resp.GetDeployId(),
+					})
+					cancelErrs <- err
+				}()
+			}).Return(nil)
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.Canceled, status.Code(err))
+			assert.Nil(t, <-cancelErrs)
+		})
+		t.Run("should stop saving jobs when cancelled using deploy id", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name:  "bq2bq",
+				Image: "random-image",
+			}, nil)
+			execUnit1.On("DefaultTaskAssets", context.Background(), mock2.Anything).Return(models.DefaultTaskAssetsResponse{}, nil)
+			_ = models.TaskRegistry.Add(execUnit1)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			// first job is saved only after the deploy is cancelled
+			cancelErrs := make(chan error, 1)
+			jobService := new(mock.JobService)
+			jobService.On("Create", mock2.Anything, namespaceSpec).Run(func(args mock2.Arguments) {
+				assert.Nil(t, <-cancelErrs)
+			}).Return(nil).Once()
+			defer jobService.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.DeployJobSpecificationResponse) bool {
+				return resp.GetDeployId() != ""
+			})).Run(func(args mock2.Arguments) {
+				resp := args.Get(0).(*pb.DeployJobSpecificationResponse)
+				_, err := runtimeServiceServer.CancelDeploy(context.Background(), &pb.CancelDeployRequest{
+					ProjectName: projectSpec.Name,
+					DeployId:    resp.GetDeployId(),
+				})
+				cancelErrs <- err
+			}).Return(nil).Once()
+			defer grpcRespStream.AssertExpectations(t)
+
+			var jobProtos []*pb.JobSpecification
+			for _, name := range []string{"job-1", "job-2"} {
+				jobProto, _ := adapter.ToJobProto(models.JobSpec{
+					Name:         name,
+					Task:         models.JobSpecTask{Unit: execUnit1},
+					Dependencies: map[string]models.JobSpecDependency{},
+				})
+				jobProtos = append(jobProtos, jobProto)
+			}
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, Jobs: jobProtos}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.Canceled, status.Code(err))
+			jobService.AssertNotCalled(t, "KeepOnly", mock2.Anything, mock2.Anything, mock2.Anything)
+			jobService.AssertNotCalled(t, "Sync", mock2.Anything, mock2.Anything, mock2.Anything)
+		})
+		t.Run("should send the error category of failed jobs", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("KeepOnly", namespaceSpec, mock2.Anything, mock2.Anything).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+				obs := args.Get(2).(progress.Observer)
+				obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-1"}, Err: errors.New("bad template"), Category: job.ErrorCategoryCompile})
+				obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-2"}, Err: errors.New("bucket not found"), Category: job.ErrorCategoryUpload})
+				obs.Notify(&job.EventJobSyncFailed{Err: errors.New("failed to list jobs"), Category: job.ErrorCategoryUpload})
+			}).Return(errors.New("failed to list jobs"))
+			defer jobService.AssertExpectations(t)
+
+			var categories []pb.DeployJobSpecificationResponse_ErrorCategory
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.Anything).Run(func(args mock2.Arguments) {
+				resp := args.Get(0).(*pb.DeployJobSpecificationResponse)
+				if resp.GetDeployId() != "" || resp.GetSummary() != nil {
+					return
+				}
+				categories = append(categories, resp.GetErrorCategory())
+			}).Return(nil)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.Internal, status.Code(err))
+			assert.Equal(t, []pb.DeployJobSpecificationResponse_ErrorCategory{
+				pb.DeployJobSpecificationResponse_COMPILE,
+				pb.DeployJobSpecificationResponse_UPLOAD,
+				pb.DeployJobSpecificationResponse_UPLOAD,
+			}, categories)
+		})
+		t.Run("should send a summary matching the job events as the last response", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("KeepOnly", namespaceSpec, mock2.Anything, mock2.Anything).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+				obs := args.Get(2).(progress.Observer)
+				obs.Notify(&job.EventSavedJobDelete{Name: "job-4"})
+				obs.Notify(&job.EventSavedJobDeleteBlocked{Name: "job-5", Dependents: []string{"job-1"}})
+				obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-1"}})
+				obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-2"}})
+				obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-3"}, Err: errors.New("bad template"), Category: job.ErrorCategoryCompile})
+				obs.Notify(&job.EventJobRemoteDelete{Name: "job-4"})
+			}).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			var responses []*pb.DeployJobSpecificationResponse
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.Anything).Run(func(args mock2.Arguments) {
+				responses = append(responses, args.Get(0).(*pb.DeployJobSpecificationResponse))
+			}).Return(nil)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Nil(t, err)
+
+			var acked, failed int
+			for _, resp := range responses {
+				if !resp.GetAck() {
+					continue
+				}
+				if resp.GetSuccess() {
+					acked++
+				} else {
+					failed++
+				}
+			}
+			last := responses[len(responses)-1]
+			assert.Equal(t, int32(acked), last.GetSummary().GetSucceeded())
+			assert.Equal(t, int32(failed), last.GetSummary().GetFailed())
+			assert.Equal(t, int32(1), last.GetSummary().GetDeleted())
+			assert.Equal(t, int32(1), last.GetSummary().GetUnchanged())
+			assert.Equal(t, "deployed: 2 ok, 1 failed, 1 deleted, 1 unchanged", last.GetMessage())
+			assert.False(t, last.GetSuccess())
+		})
+		t.Run("should send a validation error category for a rejected job", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name:  "bq2bq",
+				Image: "random-image",
+			}, nil)
+			execUnit1.On("DefaultTaskAssets", context.Background(), mock2.Anything).Return(models.DefaultTaskAssetsResponse{}, nil)
+			_ = models.TaskRegistry.Add(execUnit1)
+
+			jobSpec := models.JobSpec{
+				Name: "my-job",
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+				},
+				Assets: *models.JobAssets{}.New([]models.JobSpecAsset{
+					{Name: "query.sql", Value: "select * from {{.proj.DATASET}}"},
+				}),
+				Dependencies: map[string]models.JobSpecDependency{},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			createErr := errors.Wrap(job.ErrMissingProjectConfig, "job my-job refers DATASET")
+			jobService := new(mock.JobService)
+			jobService.On("Create", mock2.Anything, namespaceSpec).Return(createErr)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.DeployJobSpecificationResponse) bool {
+				return resp.GetDeployId() != ""
+			})).Return(nil).Once()
+			grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+				Ack:           true,
+				JobName:       "my-job",
+				Message:       createErr.Error(),
+				ErrorCategory: pb.DeployJobSpecificationResponse_VALIDATION,
+			}).Return(nil).Once()
+			defer grpcRespStream.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			jobProto, _ := adapter.ToJobProto(jobSpec)
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, Jobs: []*pb.JobSpecification{jobProto}}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+		t.Run("should return invalid argument for a job violating name rules", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name:  "bq2bq",
+				Image: "random-image",
+			}, nil)
+			execUnit1.On("DefaultTaskAssets", context.Background(), mock2.Anything).Return(models.DefaultTaskAssetsResponse{}, nil)
+			_ = models.TaskRegistry.Add(execUnit1)
+
+			jobSpec := models.JobSpec{
+				Name: "My Job",
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+				},
+				Dependencies: map[string]models.JobSpecDependency{},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			createErr := errors.Wrap(job.ErrInvalidJobSpec, "job name My Job should only contain lowercase letters, digits, _ and -")
+			jobService := new(mock.JobService)
+			jobService.On("Create", mock2.Anything, namespaceSpec).Return(createErr)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.DeployJobSpecificationResponse) bool {
+				return resp.GetDeployId() != ""
+			})).Return(nil).Once()
+			grpcRespStream.On("Send", &pb.DeployJobSpecificationResponse{
+				Ack:           true,
+				JobName:       "My Job",
+				Message:       createErr.Error(),
+				ErrorCategory: pb.DeployJobSpecificationResponse_VALIDATION,
+			}).Return(nil).Once()
+			defer grpcRespStream.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(models.TaskRegistry, nil, nil)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			jobProto, _ := adapter.ToJobProto(jobSpec)
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: namespaceSpec.Name, Jobs: []*pb.JobSpecification{jobProto}}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should fail to cancel a deploy which is not running", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			resp, err := runtimeServiceServer.CancelDeploy(context.Background(), &pb.CancelDeployRequest{
+				ProjectName: "a-data-project",
+				DeployId:    "unknown-deploy",
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			assert.Nil(t, resp)
+		})
+		t.Run("should reject the deploy if project is missing required config", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.ProjectSchedulerHost: "http://airflow.example.io",
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectSpec.Name, Namespace: "dev-test-namespace-1"}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			assert.Contains(t, err.Error(), models.ProjectStoragePathKey)
+		})
+		t.Run("should reject the deploy if request has more jobs than the limit", func(t *testing.T) {
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.MaxDeployJobs = 2
+
+			deployRequest := pb.DeployJobSpecificationRequest{
+				ProjectName: "a-data-project",
+				Namespace:   "dev-test-namespace-1",
+				Jobs: []*pb.JobSpecification{
+					{Name: "test-1"},
+					{Name: "test-2"},
+					{Name: "test-3"},
+				},
+			}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			assert.Contains(t, err.Error(), "deploy request has 3 jobs, limit is 2 jobs per deploy")
+		})
+		t.Run("should reject the deploy if a job spec is over the size limit", func(t *testing.T) {
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.MaxSpecBytes = 1024
+
+			bigJob := &pb.JobSpecification{
+				Name:   "big-job",
+				Assets: map[string]string{"query.sql": strings.Repeat("select 1;", 200)},
+			}
+			deployRequest := pb.DeployJobSpecificationRequest{
+				ProjectName: "a-data-project",
+				Namespace:   "dev-test-namespace-1",
+				Jobs: []*pb.JobSpecification{
+					{Name: "small-job"},
+					bigJob,
+				},
+			}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, err.Error(), fmt.Sprintf("job big-job is %d bytes, limit is 1024 bytes per spec", proto.Size(bigJob)))
+		})
+		t.Run("should reject the deploy if request contains duplicate job names", func(t *testing.T) {
+			projectName := "a-data-project"
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
+			defer grpcRespStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeployJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   "dev-test-namespace-1",
+				Jobs: []*pb.JobSpecification{
+					{Name: "test", Owner: "optimus"},
+					{Name: "test", Owner: "someone-else"},
+				},
+			}
+			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, err.Error(), "test")
+			jobService.AssertNotCalled(t, "Create", mock2.Anything, mock2.Anything)
+		})
+	})
+
+	t.Run("QueueJobDeployment", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+			Config: map[string]string{
+				models.ProjectStoragePathKey: "gs://some_folder",
+				models.ProjectSchedulerHost:  "http://airflow.example.io",
+			},
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		taskName := "a-data-task"
+
+		t.Run("should queue the deployment and sync jobs in background", func(t *testing.T) {
+			execUnit := new(mock.TaskPlugin)
+			execUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+
+			allTasksRepo := new(mock.SupportedTaskRepo)
+			allTasksRepo.On("GetByName", taskName).Return(execUnit, nil)
+			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+
+			jobSpec := models.JobSpec{
+				Name: "a-data-job",
+				Task: models.JobSpecTask{
+					Unit: execUnit,
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Create", mock2.Anything, namespaceSpec).Return(nil)
+			jobService.On("KeepOnly", namespaceSpec, mock2.Anything, false).Return(nil)
+			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			deployManager := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 1, QueueSize: 1}, nil)
+			defer deployManager.Close()
+			runtimeServiceServer.DeployManager = deployManager
+
+			jobProto, _ := adapter.ToJobProto(jobSpec)
+			resp, err := runtimeServiceServer.QueueJobDeployment(context.Background(), &pb.DeployJobSpecificationRequest{
+				ProjectName: projectSpec.Name,
+				Namespace:   namespaceSpec.Name,
+				Jobs:        []*pb.JobSpecification{jobProto},
+			})
+			assert.Nil(t, err)
+			assert.NotEmpty(t, resp.GetDeployId())
+
+			for {
+				_, finished, changed, err := deployManager.Events(projectSpec.Name, resp.GetDeployId(), 0)
+				assert.Nil(t, err)
+				if finished {
+					break
+				}
+				<-changed
+			}
+
+			statusResp, err := runtimeServiceServer.GetDeploymentStatus(context.Background(), &pb.GetDeploymentStatusRequest{
+				ProjectName: projectSpec.Name,
+				DeployId:    resp.GetDeployId(),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, job.DeploymentStatusSucceeded, statusResp.GetStatus())
+			assert.Equal(t, namespaceSpec.Name, statusResp.GetNamespace())
+		})
+		t.Run("should reject a strict deployment using unknown dependencies before saving jobs", func(t *testing.T) {
+			execUnit := new(mock.TaskPlugin)
+			execUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+
+			allTasksRepo := new(mock.SupportedTaskRepo)
+			allTasksRepo.On("GetByName", taskName).Return(execUnit, nil)
+			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+
+			jobSpec := models.JobSpec{
+				Name: "a-data-job",
+				Task: models.JobSpecTask{
+					Unit: execUnit,
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			jobService := new(mock.JobService)
+			jobService.On("UnknownDependencies", projectSpec, mock2.Anything).Return(map[string][]string{
+				jobSpec.Name: {"bigquery://proj.dataset.unknown_table"},
+			}, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			deployManager := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 1, QueueSize: 1}, nil)
+			defer deployManager.Close()
+			runtimeServiceServer.DeployManager = deployManager
+
+			jobProto, _ := adapter.ToJobProto(jobSpec)
+			resp, err := runtimeServiceServer.QueueJobDeployment(context.Background(), &pb.DeployJobSpecificationRequest{
+				ProjectName: projectSpec.Name,
+				Namespace:   namespaceSpec.Name,
+				Jobs:        []*pb.JobSpecification{jobProto},
+				Strict:      true,
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+			jobService.AssertNotCalled(t, "Create", mock2.Anything, mock2.Anything)
+		})
+		t.Run("should fail if queued deployments are not enabled", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.QueueJobDeployment(context.Background(), &pb.DeployJobSpecificationRequest{
+				ProjectName: projectSpec.Name,
+				Namespace:   namespaceSpec.Name,
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		})
+	})
+	t.Run("GetDeploymentStatus", func(t *testing.T) {
+		t.Run("should return not found for unknown deployments", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			deployManager := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 1, QueueSize: 1}, nil)
+			defer deployManager.Close()
+			runtimeServiceServer.DeployManager = deployManager
+
+			resp, err := runtimeServiceServer.GetDeploymentStatus(context.Background(), &pb.GetDeploymentStatusRequest{
+				ProjectName: "a-data-project",
+				DeployId:    "unknown",
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("ReadJobSpecification", func(t *testing.T) {
+		t.Run("should read a job spec", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+			jobName1 := "a-data-job"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name: jobName1,
+					Task: models.JobSpecTask{
+						Unit: execUnit1,
+						Config: models.JobSpecConfigs{
+							{
+								Name:  "do",
+								Value: "this",
+							},
+						},
+					},
+					Assets: *models.JobAssets{}.New(
+						[]models.JobSpecAsset{
+							{
+								Name:  "query.sql",
+								Value: "select * from 1",
+							},
+						}),
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			allTasksRepo := new(mock.SupportedTaskRepo)
+			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
+			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			jobSpecAdapted, _ := adapter.ToJobProto(jobSpecs[0])
+			deployRequest := pb.ReadJobSpecificationRequest{ProjectName: projectName, JobName: jobSpecs[0].Name, Namespace: namespaceSpec.Name}
+			jobSpecResp, err := runtimeServiceServer.ReadJobSpecification(context.Background(), &deployRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, jobSpecAdapted, jobSpecResp.Spec)
+		})
+	})
+
+	t.Run("ListJobSpecification", func(t *testing.T) {
+		projectName := "a-data-project"
+		taskName := "a-data-task"
+
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+
+		t.Run("should list only jobs matching label selector", func(t *testing.T) {
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name:   "growth-critical-job",
+					Labels: map[string]string{"team": "growth", "tier": "critical"},
+					Task:   models.JobSpecTask{Unit: execUnit1},
+				},
+				{
+					Name:   "growth-job",
+					Labels: map[string]string{"team": "growth"},
+					Task:   models.JobSpecTask{Unit: execUnit1},
+				},
+				{
+					Name: "unlabelled-job",
+					Task: models.JobSpecTask{Unit: execUnit1},
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetAll", namespaceSpec).Return(jobSpecs, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.ListJobSpecification(context.Background(), &pb.ListJobSpecificationRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				LabelSelector: "team=growth,tier=critical",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.GetJobs()))
+			assert.Equal(t, "growth-critical-job", resp.GetJobs()[0].GetName())
+
+			resp, err = runtimeServiceServer.ListJobSpecification(context.Background(), &pb.ListJobSpecificationRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				LabelSelector: "tier!=critical",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(resp.GetJobs()))
+		})
+		t.Run("should list a page of jobs and token to the next one", func(t *testing.T) {
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			firstPage := []models.JobSpec{
+				{Name: "job-a", Labels: map[string]string{"team": "growth"}, Task: models.JobSpecTask{Unit: execUnit1}},
+				{Name: "job-b", Task: models.JobSpecTask{Unit: execUnit1}},
+			}
+			secondPage := []models.JobSpec{
+				{Name: "job-c", Labels: map[string]string{"team": "growth"}, Task: models.JobSpecTask{Unit: execUnit1}},
+				{Name: "job-d", Labels: map[string]string{"team": "growth"}, Task: models.JobSpecTask{Unit: execUnit1}},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			// job-b doesn't match the selector, a second page is read to fill the response
+			jobService := new(mock.JobService)
+			jobService.On("GetPage", namespaceSpec, "", 2).Return(firstPage, nil)
+			jobService.On("GetPage", namespaceSpec, "job-b", 2).Return(secondPage, nil)
+			jobService.On("GetPage", namespaceSpec, "job-c", 2).Return(secondPage[1:], nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.ListJobSpecification(context.Background(), &pb.ListJobSpecificationRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				LabelSelector: "team=growth",
+				PageSize:      2,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(resp.GetJobs()))
+			assert.Equal(t, "job-a", resp.GetJobs()[0].GetName())
+			assert.Equal(t, "job-c", resp.GetJobs()[1].GetName())
+			assert.NotEmpty(t, resp.GetNextPageToken())
+
+			resp, err = runtimeServiceServer.ListJobSpecification(context.Background(), &pb.ListJobSpecificationRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				LabelSelector: "team=growth",
+				PageSize:      2,
+				PageToken:     resp.GetNextPageToken(),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.GetJobs()))
+			assert.Equal(t, "job-d", resp.GetJobs()[0].GetName())
+			assert.Empty(t, resp.GetNextPageToken())
+		})
+		t.Run("should fail for an invalid label selector", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.ListJobSpecification(context.Background(), &pb.ListJobSpecificationRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				LabelSelector: "=growth",
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("ListResourceSpecification", func(t *testing.T) {
+		t.Run("should list a page of resources and token to the next one", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			dsTypeDatasetAdapter := new(mock.DatastoreTypeAdapter)
+			dsTypeDatasetAdapter.On("ToProtobuf", mock2.Anything).Return([]byte{}, nil)
+			defer dsTypeDatasetAdapter.AssertExpectations(t)
+
+			dsTypeDatasetController := new(mock.DatastoreTypeController)
+			dsTypeDatasetController.On("Adapter").Return(dsTypeDatasetAdapter)
+
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeDataset: dsTypeDatasetController,
+			})
+			resourceSpecs := []models.ResourceSpec{
+				{Name: "proj.datas_b", Type: models.ResourceTypeDataset, Datastore: datastorer},
+				{Name: "proj.datas_c", Type: models.ResourceTypeDataset, Datastore: datastorer},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("GetPage", namespaceSpec, "bigquery", "proj.datas_a", 2).Return(resourceSpecs, nil)
+			resourceSvc.On("GetPage", namespaceSpec, "bigquery", "proj.datas_c", 2).Return([]models.ResourceSpec{}, nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			// token of a previous page ending with proj.datas_a
+			resp, err := runtimeServiceServer.ListResourceSpecification(context.Background(), &pb.ListResourceSpecificationRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+				PageSize:      2,
+				PageToken:     base64.RawURLEncoding.EncodeToString([]byte("proj.datas_a")),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(resp.GetResources()))
+			assert.NotEmpty(t, resp.GetNextPageToken())
+
+			resp, err = runtimeServiceServer.ListResourceSpecification(context.Background(), &pb.ListResourceSpecificationRequest{
+				ProjectName:   projectName,
+				Namespace:     namespaceSpec.Name,
+				DatastoreName: "bigquery",
+				PageSize:      2,
+				PageToken:     resp.GetNextPageToken(),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(resp.GetResources()))
+			assert.Empty(t, resp.GetNextPageToken())
+		})
+		t.Run("should fail for an invalid page token", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.ListResourceSpecification(context.Background(), &pb.ListResourceSpecificationRequest{
+				ProjectName: "a-data-project",
+				PageSize:    2,
+				PageToken:   "not a token!",
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("PatchJobSpecification", func(t *testing.T) {
+		projectName := "a-data-project"
+		jobName := "a-data-job"
+		taskName := "a-data-task"
+
+		projectSpec := models.ProjectSpec{
+			Name: projectName,
+			Config: map[string]string{
+				"bucket": "gs://some_folder",
+			},
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+
+		execUnit1 := new(mock.TaskPlugin)
+		execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+			Name: taskName,
+		}, nil)
+
+		newJobSpec := func() models.JobSpec {
+			return models.JobSpec{
+				Name:  jobName,
+				Owner: "mary@example.io",
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "DATASET",
+							Value: "playground",
+						},
+						{
+							Name:  "TABLE",
+							Value: "events",
+						},
+					},
+					Window: models.JobSpecTaskWindow{
+						Size:       time.Hour,
+						Offset:     0,
+						TruncateTo: "d",
+					},
+				},
+				Assets: *models.JobAssets{}.New(
+					[]models.JobSpecAsset{
+						{
+							Name:  "query.sql",
+							Value: "select * from 1",
+						},
+					}),
+				Dependencies: map[string]models.JobSpecDependency{},
+			}
+		}
+
+		newServer := func(jobSvc *mock.JobService) *v1.RuntimeServiceServer {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+			allTasksRepo := new(mock.SupportedTaskRepo)
+			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
+
+			return v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobSvc,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(allTasksRepo, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+		}
+		adapter := v1.NewAdapter(nil, nil, nil)
+
+		t.Run("should only change owner of the job", func(t *testing.T) {
+			expectedSpec := newJobSpec()
+			expectedSpec.Owner = "john@example.io"
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetByName", jobName, namespaceSpec).Return(newJobSpec(), nil)
+			jobSvc.On("Check", namespaceSpec, []models.JobSpec{expectedSpec}, mock2.Anything).Return(nil)
+			jobSvc.On("Create", expectedSpec, namespaceSpec).Return(nil)
+			jobSvc.On("SyncJob", mock2.Anything, namespaceSpec, jobName, mock2.Anything).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			resp, err := newServer(jobSvc).PatchJobSpecification(context.Background(), &pb.PatchJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+				Spec: &pb.JobSpecification{
+					Owner:  "john@example.io",
+					Config: []*pb.JobConfigItem{{Name: "DATASET", Value: "ignored"}},
+				},
+				UpdateMask: []string{"owner"},
+			})
+			assert.Nil(t, err)
+			assert.True(t, resp.Success)
+
+			expectedProto, _ := adapter.ToJobProto(expectedSpec)
+			assert.Equal(t, expectedProto, resp.Spec)
+			assert.Equal(t, "playground", resp.Spec.Config[0].Value)
+			assert.Equal(t, "select * from 1", resp.Spec.Assets["query.sql"])
+			jobSvc.AssertNotCalled(t, "Sync", mock2.Anything, mock2.Anything, mock2.Anything)
+		})
+		t.Run("should only change a single task config key", func(t *testing.T) {
+			expectedSpec := newJobSpec()
+			expectedSpec.Task.Config[1].Value = "clicks"
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetByName", jobName, namespaceSpec).Return(newJobSpec(), nil)
+			jobSvc.On("Check", namespaceSpec, []models.JobSpec{expectedSpec}, mock2.Anything).Return(nil)
+			jobSvc.On("Create", expectedSpec, namespaceSpec).Return(nil)
+			jobSvc.On("SyncJob", mock2.Anything, namespaceSpec, jobName, mock2.Anything).Return(nil)
+			defer jobSvc.AssertExpectations(t)
+
+			resp, err := newServer(jobSvc).PatchJobSpecification(context.Background(), &pb.PatchJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+				Spec: &pb.JobSpecification{
+					Owner:  "ignored@example.io",
+					Config: []*pb.JobConfigItem{{Name: "TABLE", Value: "clicks"}},
+				},
+				UpdateMask: []string{"config.TABLE"},
+			})
+			assert.Nil(t, err)
+			assert.True(t, resp.Success)
+
+			expectedProto, _ := adapter.ToJobProto(expectedSpec)
+			assert.Equal(t, expectedProto, resp.Spec)
+			assert.Equal(t, "mary@example.io", resp.Spec.Owner)
+			assert.Equal(t, []*pb.JobConfigItem{
+				{Name: "DATASET", Value: "playground"},
+				{Name: "TABLE", Value: "clicks"},
+			}, resp.Spec.Config)
+		})
+		t.Run("should fail when syncing the patched job fails", func(t *testing.T) {
+			expectedSpec := newJobSpec()
+			expectedSpec.Owner = "john@example.io"
+
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetByName", jobName, namespaceSpec).Return(newJobSpec(), nil)
+			jobSvc.On("Check", namespaceSpec, []models.JobSpec{expectedSpec}, mock2.Anything).Return(nil)
+			jobSvc.On("Create", expectedSpec, namespaceSpec).Return(nil)
+			jobSvc.On("SyncJob", mock2.Anything, namespaceSpec, jobName, mock2.Anything).Return(errors.New("scheduler unavailable"))
+			defer jobSvc.AssertExpectations(t)
+
+			resp, err := newServer(jobSvc).PatchJobSpecification(context.Background(), &pb.PatchJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+				Spec:        &pb.JobSpecification{Owner: "john@example.io"},
+				UpdateMask:  []string{"owner"},
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.Internal, status.Code(err))
+			jobSvc.AssertNotCalled(t, "Sync", mock2.Anything, mock2.Anything, mock2.Anything)
+		})
+		t.Run("should reject unknown field paths", func(t *testing.T) {
+			jobSvc := new(mock.JobService)
+			jobSvc.On("GetByName", jobName, namespaceSpec).Return(newJobSpec(), nil)
+			defer jobSvc.AssertExpectations(t)
+
+			_, err := newServer(jobSvc).PatchJobSpecification(context.Background(), &pb.PatchJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     jobName,
+				Spec:        &pb.JobSpecification{Name: "another-job"},
+				UpdateMask:  []string{"name"},
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("ListDatastores", func(t *testing.T) {
+		t.Run("should list supported datastores with their resource types", func(t *testing.T) {
+			datastoreRepo := new(mock.SupportedDatastoreRepo)
+			datastoreRepo.On("GetAll").Return([]models.Datastorer{bigquery.This})
+			defer datastoreRepo.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil,
+				nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			runtimeServiceServer.Datastores = datastoreRepo
+
+			resp, err := runtimeServiceServer.ListDatastores(context.Background(), &pb.ListDatastoresRequest{})
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.ListDatastoresResponse{
+				Datastores: []*pb.DatastoreSpecification{
+					{
+						Name:        "bigquery",
+						Description: "GCP BigQuery",
+						Types: []*pb.DatastoreSpecification_ResourceType{
+							{Name: "dataset", NameFormat: "project_name.dataset_name"},
+							{Name: "external_table", NameFormat: "project_name.dataset_name.table_name"},
+							{Name: "table", NameFormat: "project_name.dataset_name.table_name"},
+							{Name: "view", NameFormat: "project_name.dataset_name.table_name"},
+						},
+					},
+				},
+			}, resp)
+		})
+	})
+
+	t.Run("ListProjectNamespaces", func(t *testing.T) {
+		t.Run("should read namespaces of a project", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			allTasksRepo := new(mock.SupportedTaskRepo)
+			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			namespaceAdapted := adapter.ToNamespaceProto(namespaceSpec)
+			request := pb.ListProjectNamespacesRequest{ProjectName: projectName}
+			resp, err := runtimeServiceServer.ListProjectNamespaces(context.Background(), &request)
+			assert.Nil(t, err)
+			assert.Equal(t, []*pb.NamespaceSpecification{namespaceAdapted}, resp.GetNamespaces())
+		})
+	})
+
+	t.Run("DeleteJobSpecification", func(t *testing.T) {
+		t.Run("should delete the job", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+			jobName1 := "a-data-job"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.TaskPlugin)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name: jobName1,
+					Task: models.JobSpecTask{
+						Unit: execUnit1,
+						Config: models.JobSpecConfigs{
+							{
+								Name:  "do",
+								Value: "this",
+							},
+						},
+					},
+					Assets: *models.JobAssets{}.New(
+						[]models.JobSpecAsset{
+							{
+								Name:  "query.sql",
+								Value: "select * from 1",
+							},
+						}),
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			allTasksRepo := new(mock.SupportedTaskRepo)
+			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
+			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobSpec := jobSpecs[0]
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
+			jobService.On("Delete", mock2.Anything, namespaceSpec, jobSpec).Return(nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			deployRequest := pb.DeleteJobSpecificationRequest{ProjectName: projectName, JobName: jobSpec.Name, Namespace: namespaceSpec.Name}
+			resp, err := runtimeServiceServer.DeleteJobSpecification(context.Background(), &deployRequest)
+			assert.Nil(t, err)
+			assert.Equal(t, "job a-data-job has been deleted", resp.GetMessage())
+		})
+	})
+
+	t.Run("GetJobSpecHistory", func(t *testing.T) {
+		t.Run("should return revisions of the job latest first", func(t *testing.T) {
+			projectName := "a-data-project"
+			taskName := "a-data-task"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpecV1 := models.JobSpec{
+				Name:  "a-data-job",
+				Owner: "optimus",
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "do",
+							Value: "this",
+						},
+					},
+				},
+			}
+			jobSpecV2 := jobSpecV1
+			jobSpecV2.Owner = "optimus-2"
+			savedAt := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+			revisions := []models.JobSpecRevision{
+				{Revision: 2, CreatedAt: savedAt.Add(time.Hour), Spec: jobSpecV2},
+				{Revision: 1, CreatedAt: savedAt, Spec: jobSpecV1},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetHistory", namespaceSpec, "a-data-job").Return(revisions, nil)
+			defer jobService.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(nil, nil, nil)
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetJobSpecHistory(context.Background(), &pb.GetJobSpecHistoryRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     "a-data-job",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(resp.GetRevisions()))
+			assert.Equal(t, int32(2), resp.GetRevisions()[0].GetRevision())
+			assert.Equal(t, "optimus-2", resp.GetRevisions()[0].GetSpec().GetOwner())
+			assert.Equal(t, savedAt, resp.GetRevisions()[1].GetCreatedAt().AsTime())
+			assert.Equal(t, "optimus", resp.GetRevisions()[1].GetSpec().GetOwner())
+		})
+	})
+
+	t.Run("RollbackJobSpecification", func(t *testing.T) {
+		projectName := "a-data-project"
+		taskName := "a-data-task"
+
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+
+		t.Run("should restore the job to the requested revision", func(t *testing.T) {
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: taskName,
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			restoredSpec := models.JobSpec{
+				Name:  "a-data-job",
+				Owner: "optimus",
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Rollback", mock2.Anything, namespaceSpec, "a-data-job", 1, mock2.Anything).Return(restoredSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RollbackJobSpecification(context.Background(), &pb.RollbackJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     "a-data-job",
+				Revision:    1,
+			})
+			assert.Nil(t, err)
+			assert.True(t, resp.GetSuccess())
+			assert.Equal(t, "job a-data-job is rolled back to revision 1", resp.GetMessage())
+			assert.Equal(t, "optimus", resp.GetSpec().GetOwner())
+		})
+		t.Run("should return not found if the revision does not exist", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("Rollback", mock2.Anything, namespaceSpec, "a-data-job", 5, mock2.Anything).
+				Return(models.JobSpec{}, errors.Wrap(store.ErrResourceNotFound, "failed to fetch revision 5 of a-data-job"))
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.RollbackJobSpecification(context.Background(), &pb.RollbackJobSpecificationRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				JobName:     "a-data-job",
+				Revision:    5,
+			})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("GetReplayPlan", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "game_jam",
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				Interval:  "0 2 * * *",
+			},
+			Task: models.JobSpecTask{
+				Window: models.JobSpecTaskWindow{
+					Size:       time.Hour * 24,
+					Offset:     0,
+					TruncateTo: "d",
+				},
+			},
+		}
+
+		t.Run("should return runs in range flagging the ones already succeeded", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			firstRun := time.Date(2020, 8, 5, 2, 0, 0, 0, time.UTC)
+			lastRun := time.Date(2020, 8, 7, 2, 0, 0, 0, time.UTC)
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetDagRunStatus", context.Background(), projectSpec, jobSpec.Name, firstRun, lastRun, 100).Return([]models.JobStatus{
+				{
+					ScheduledAt: time.Date(2020, 8, 6, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateSuccess,
+				},
+				{
+					ScheduledAt: lastRun,
+					State:       models.JobStatusStateFailed,
+				},
+			}, nil)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetReplayPlan(context.Background(), &pb.GetReplayPlanRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   "2020-08-05",
+				EndDate:     "2020-08-07",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 3, len(resp.Runs))
+
+			expectedStates := []string{"", "success", "failed"}
+			for i, run := range resp.Runs {
+				scheduledAt := firstRun.AddDate(0, 0, i)
+				assert.Equal(t, scheduledAt, run.ScheduledAt.AsTime())
+				assert.Equal(t, jobSpec.Task.Window.GetStart(scheduledAt), run.WindowStart.AsTime())
+				assert.Equal(t, jobSpec.Task.Window.GetEnd(scheduledAt), run.WindowEnd.AsTime())
+				assert.Equal(t, expectedStates[i], run.State)
+				assert.Equal(t, expectedStates[i] == "success", run.Succeeded)
+			}
+		})
+		t.Run("should fail if end date is before start date", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetReplayPlan(context.Background(), &pb.GetReplayPlanRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   "2020-08-07",
+				EndDate:     "2020-08-05",
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, resp)
+		})
+	})
+
+	t.Run("JobStatus", func(t *testing.T) {
+		t.Run("should return all job status via scheduler if valid inputs", func(t *testing.T) {
+			Version := "1.0.0"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(nil, nil, nil)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			jobStatuses := []models.JobStatus{
+				{
+					ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
+					State:       "failed",
+				},
+				{
+					ScheduledAt: time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC),
+					State:       "success",
+				},
+			}
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetJobStatus", context.Background(), projectSpec, jobSpec.Name, models.JobStatusFilter{}).Return(jobStatuses, nil)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				adapter,
+				nil,
+				nil,
+				scheduler,
+				nil,
+			)
+
+			req := &pb.JobStatusRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			}
+			resp, err := runtimeServiceServer.JobStatus(context.Background(), req)
+			assert.Nil(t, err)
+			assert.Equal(t, len(jobStatuses), len(resp.Statuses))
+			for _, expectedStatus := range jobStatuses {
+				var found bool
+				for _, respVal := range resp.Statuses {
+					if expectedStatus.ScheduledAt.Equal(respVal.ScheduledAt.AsTime()) &&
+						expectedStatus.State.String() == respVal.State {
+						found = true
+						break
+					}
+				}
+				if !found {
+					assert.Fail(t, fmt.Sprintf("failed to find expected job status %v", expectedStatus))
+				}
+			}
+		})
+		t.Run("should return a page of job status matching filters", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			startDate := time.Date(2020, 11, 4, 0, 0, 0, 0, time.UTC)
+			filter := models.JobStatusFilter{
+				StartDate: startDate,
+				States:    []models.JobStatusState{models.JobStatusStateFailed},
+				Offset:    2,
+				Limit:     3,
+			}
+			jobStatuses := []models.JobStatus{
+				{ScheduledAt: time.Date(2020, 11, 9, 0, 0, 0, 0, time.UTC), State: models.JobStatusStateFailed},
+				{ScheduledAt: time.Date(2020, 11, 7, 0, 0, 0, 0, time.UTC), State: models.JobStatusStateFailed},
+				{ScheduledAt: time.Date(2020, 11, 5, 0, 0, 0, 0, time.UTC), State: models.JobStatusStateFailed},
+			}
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetJobStatus", context.Background(), projectSpec, jobSpec.Name, filter).Return(jobStatuses, nil)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer("1.0.0", jobService, nil, nil, projectRepoFactory, nil,
+				nil, v1.NewAdapter(nil, nil, nil), nil, nil, scheduler, nil)
+			resp, err := runtimeServiceServer.JobStatus(context.Background(), &pb.JobStatusRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   timestamppb.New(startDate),
+				States:      []string{"FAILED"},
+				PageSize:    2,
+				PageOffset:  2,
+			})
+			assert.Nil(t, err)
+			assert.Len(t, resp.Statuses, 2)
+			assert.Equal(t, jobStatuses[1].ScheduledAt, resp.Statuses[1].ScheduledAt.AsTime())
+			assert.Equal(t, int32(4), resp.NextPageOffset)
+		})
+		t.Run("should fail if end date is before start date", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, models.NamespaceSpec{}, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer("1.0.0", jobService, nil, nil, projectRepoFactory, nil,
+				nil, v1.NewAdapter(nil, nil, nil), nil, nil, nil, nil)
+			resp, err := runtimeServiceServer.JobStatus(context.Background(), &pb.JobStatusRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   timestamppb.New(time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC)),
+				EndDate:     timestamppb.New(time.Date(2020, 11, 9, 0, 0, 0, 0, time.UTC)),
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, resp)
+		})
+		t.Run("should return failed precondition if project has no scheduler and server has no default", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.JobStatus(context.Background(), &pb.JobStatusRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+	})
+
+	t.Run("GetJobRunHistory", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "game_jam",
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+		}
+		startDate := time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2020, 11, 14, 0, 0, 0, 0, time.UTC)
+		runAt := func(day int, state models.JobStatusState, duration time.Duration) models.JobStatus {
+			scheduledAt := time.Date(2020, 11, day, 0, 0, 0, 0, time.UTC)
+			jobRun := models.JobStatus{
+				ScheduledAt: scheduledAt,
+				State:       state,
+				TryNumber:   1,
+			}
+			if duration > 0 {
+				jobRun.StartedAt = scheduledAt.Add(time.Minute)
+				jobRun.EndedAt = jobRun.StartedAt.Add(duration)
+			}
+			return jobRun
+		}
+		jobRuns := []models.JobStatus{
+			runAt(10, models.JobStatusStateSuccess, time.Minute*10),
+			runAt(11, models.JobStatusStateFailed, time.Minute*2),
+			runAt(12, models.JobStatusStateSuccess, time.Minute*20),
+			runAt(13, models.JobStatusStateFailed, time.Minute*4),
+			runAt(14, models.JobStatusStateRunning, 0),
+		}
+
+		t.Run("should return paginated runs with stats of all runs in window", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetDagRunStatus", context.Background(), projectSpec, jobSpec.Name, startDate, endDate, 100).Return(jobRuns, nil)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetJobRunHistory(context.Background(), &pb.GetJobRunHistoryRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   timestamppb.New(startDate),
+				EndDate:     timestamppb.New(endDate),
+				PageSize:    2,
+				PageOffset:  1,
+			})
+			assert.Nil(t, err)
+
+			assert.Equal(t, int32(3), resp.NextPageOffset)
+			assert.Equal(t, 2, len(resp.Runs))
+			assert.Equal(t, time.Date(2020, 11, 13, 0, 0, 0, 0, time.UTC), resp.Runs[0].ScheduledAt.AsTime())
+			assert.Equal(t, "failed", resp.Runs[0].State)
+			assert.Equal(t, time.Minute*4, resp.Runs[0].Duration.AsDuration())
+			assert.Equal(t, int32(1), resp.Runs[0].TryNumber)
+			assert.Equal(t, time.Date(2020, 11, 12, 0, 0, 0, 0, time.UTC), resp.Runs[1].ScheduledAt.AsTime())
+			assert.Equal(t, "success", resp.Runs[1].State)
+
+			assert.Equal(t, int32(5), resp.Stats.TotalRuns)
+			assert.Equal(t, int32(2), resp.Stats.SuccessfulRuns)
+			assert.Equal(t, int32(2), resp.Stats.FailedRuns)
+			assert.Equal(t, 0.5, resp.Stats.SuccessRate)
+			assert.Equal(t, time.Minute*9, resp.Stats.AverageDuration.AsDuration())
+			assert.Equal(t, time.Date(2020, 11, 13, 0, 0, 0, 0, time.UTC), resp.Stats.LastFailureAt.AsTime())
+		})
+		t.Run("should return empty last page with stats if offset is past all runs", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetDagRunStatus", context.Background(), projectSpec, jobSpec.Name, startDate, endDate, 100).Return(jobRuns, nil)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetJobRunHistory(context.Background(), &pb.GetJobRunHistoryRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				StartDate:   timestamppb.New(startDate),
+				EndDate:     timestamppb.New(endDate),
+				PageOffset:  10,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 0, len(resp.Runs))
+			assert.Equal(t, int32(0), resp.NextPageOffset)
+			assert.Equal(t, int32(5), resp.Stats.TotalRuns)
+		})
+		t.Run("should fail if start date is missing", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				new(mock.Scheduler),
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetJobRunHistory(context.Background(), &pb.GetJobRunHistoryRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Nil(t, resp)
+		})
+	})
+	t.Run("RunJob", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "game_jam",
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+			Schedule: models.JobSpecSchedule{
+				Interval: "0 2 * * *",
+			},
+		}
+		scheduledAt := time.Date(2020, 11, 11, 2, 0, 0, 0, time.UTC)
+
+		t.Run("should run job at scheduled time through scheduler", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("RunJob", context.Background(), projectSpec, jobSpec.Name, scheduledAt).Return(nil)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RunJob(context.Background(), &pb.RunJobRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				ScheduledAt: timestamppb.New(scheduledAt),
+			})
+			assert.Nil(t, err)
+			assert.True(t, resp.Success)
+			assert.Equal(t, "run of transform-tables scheduled at 2020-11-11T02:00:00Z requested", resp.Message)
+		})
+		t.Run("should evaluate schedule of job in its timezone", func(t *testing.T) {
+			jakartaJob := jobSpec
+			jakartaJob.Schedule.Timezone = "Asia/Jakarta"
+			// 2020-11-11T02:00 in Asia/Jakarta
+			jakartaScheduledAt := time.Date(2020, 11, 10, 19, 0, 0, 0, time.UTC)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jakartaJob, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("RunJob", context.Background(), projectSpec, jobSpec.Name, jakartaScheduledAt).Return(nil).Once()
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.RunJob(context.Background(), &pb.RunJobRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				ScheduledAt: timestamppb.New(jakartaScheduledAt),
+			})
+			assert.Nil(t, err)
+
+			_, err = runtimeServiceServer.RunJob(context.Background(), &pb.RunJobRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				ScheduledAt: timestamppb.New(scheduledAt),
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should fail if scheduled time is not produced by job schedule", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			defer scheduler.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				scheduler,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.RunJob(context.Background(), &pb.RunJobRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				ScheduledAt: timestamppb.New(scheduledAt.Add(time.Hour)),
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should fail if scheduled time is missing", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.RunJob(context.Background(), &pb.RunJobRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+	t.Run("GetJobRunLogs", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "game_jam",
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+		}
+		scheduledAt := time.Date(2020, 11, 11, 2, 0, 0, 0, time.UTC)
+
+		t.Run("should stream logs of run sent by scheduler", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			logs := []models.JobRunLog{
+				{TaskName: "bq2bq", TryNumber: 1, Content: []byte("failed")},
+				{TaskName: "bq2bq", TryNumber: 2, Content: []byte("done")},
+			}
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetJobRunLogs", context.Background(), projectSpec, jobSpec.Name, models.JobRunLogRequest{
+				ScheduledAt: scheduledAt,
+				TaskName:    "bq2bq",
+			}, mock2.Anything).Return(logs, nil)
+			defer scheduler.AssertExpectations(t)
+
+			var sent []*pb.GetJobRunLogsResponse
+			respStream := new(mock.RuntimeService_GetJobRunLogsServer)
+			respStream.On("Context").Return(context.Background())
+			respStream.On("Send", mock2.Anything).Run(func(args mock2.Arguments) {
+				sent = append(sent, args.Get(0).(*pb.GetJobRunLogsResponse))
+			}).Return(nil)
+			defer respStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer("1.0.0", jobService, nil, nil, projectRepoFactory, nil,
+				nil, v1.NewAdapter(nil, nil, nil), nil, nil, scheduler, nil)
+			err := runtimeServiceServer.GetJobRunLogs(&pb.GetJobRunLogsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				ScheduledAt: timestamppb.New(scheduledAt),
+				TaskName:    "bq2bq",
+			}, respStream)
+			assert.Nil(t, err)
+			assert.Len(t, sent, 2)
+			assert.Equal(t, "bq2bq", sent[1].TaskName)
+			assert.Equal(t, int32(2), sent[1].TryNumber)
+			assert.Equal(t, []byte("done"), sent[1].Content)
+		})
+		t.Run("should fail with unimplemented if scheduler doesn't serve logs", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("GetJobRunLogs", context.Background(), projectSpec, jobSpec.Name, models.JobRunLogRequest{
+				ScheduledAt: scheduledAt,
+			}, mock2.Anything).Return(nil, models.ErrJobRunLogsNotSupported)
+			defer scheduler.AssertExpectations(t)
+
+			respStream := new(mock.RuntimeService_GetJobRunLogsServer)
+			respStream.On("Context").Return(context.Background())
+			defer respStream.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer("1.0.0", jobService, nil, nil, projectRepoFactory, nil,
+				nil, v1.NewAdapter(nil, nil, nil), nil, nil, scheduler, nil)
+			err := runtimeServiceServer.GetJobRunLogs(&pb.GetJobRunLogsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				ScheduledAt: timestamppb.New(scheduledAt),
+			}, respStream)
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		})
+		t.Run("should fail if scheduled time is missing", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer("1.0.0", jobService, nil, nil, projectRepoFactory, nil,
+				nil, v1.NewAdapter(nil, nil, nil), nil, nil, nil, nil)
+			err := runtimeServiceServer.GetJobRunLogs(&pb.GetJobRunLogsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			}, new(mock.RuntimeService_GetJobRunLogsServer))
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+	t.Run("PreviewSchedule", func(t *testing.T) {
+		t.Run("should return runs starting from start date inclusive", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.PreviewSchedule(context.Background(), &pb.PreviewScheduleRequest{
+				Interval:  "0 2 * * *",
+				StartDate: timestamppb.New(time.Date(2020, 11, 10, 2, 0, 0, 0, time.UTC)),
+				Count:     3,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 3, len(resp.Runs))
+			assert.Equal(t, time.Date(2020, 11, 10, 2, 0, 0, 0, time.UTC), resp.Runs[0].AsTime())
+			assert.Equal(t, time.Date(2020, 11, 11, 2, 0, 0, 0, time.UTC), resp.Runs[1].AsTime())
+			assert.Equal(t, time.Date(2020, 11, 12, 2, 0, 0, 0, time.UTC), resp.Runs[2].AsTime())
+		})
+		t.Run("should stop at end date if provided", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.PreviewSchedule(context.Background(), &pb.PreviewScheduleRequest{
+				Interval:  "@daily",
+				StartDate: timestamppb.New(time.Date(2020, 11, 10, 1, 0, 0, 0, time.UTC)),
+				EndDate:   timestamppb.New(time.Date(2020, 11, 14, 0, 0, 0, 0, time.UTC)),
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 4, len(resp.Runs))
+			assert.Equal(t, time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC), resp.Runs[0].AsTime())
+			assert.Equal(t, time.Date(2020, 11, 14, 0, 0, 0, 0, time.UTC), resp.Runs[3].AsTime())
+		})
+		t.Run("should return invalid argument for unparseable interval", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.PreviewSchedule(context.Background(), &pb.PreviewScheduleRequest{
+				Interval: "0 2 * *",
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	})
+
+	t.Run("ListDownstreamJobs", func(t *testing.T) {
+		Version := "1.0.0"
+
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "game_jam",
+			ProjectSpec: projectSpec,
+		}
+
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+		}
+
+		t.Run("should return jobs depending on the requested job", func(t *testing.T) {
+			execUnit1 := new(mock.TaskPlugin)
+			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+				Name: "bq2bq",
+			}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			downstreamSpec := models.JobSpec{
+				Name: "downstream-tables",
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+				},
+				Dependencies: map[string]models.JobSpecDependency{
+					jobSpec.Name: {Job: &jobSpec, Project: &projectSpec, Type: models.JobSpecDependencyTypeIntra},
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			jobService.On("GetDownstream", projectSpec, jobSpec.Name, true).Return([]models.JobSpec{downstreamSpec}, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			req := &pb.ListDownstreamJobsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+				Transitive:  true,
+			}
+			resp, err := runtimeServiceServer.ListDownstreamJobs(context.Background(), req)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(resp.Jobs))
+			assert.Equal(t, downstreamSpec.Name, resp.Jobs[0].Name)
+		})
+		t.Run("should return empty list if no job depends on the requested job", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			jobService.On("GetDownstream", projectSpec, jobSpec.Name, false).Return([]models.JobSpec{}, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			req := &pb.ListDownstreamJobsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			}
+			resp, err := runtimeServiceServer.ListDownstreamJobs(context.Background(), req)
+			assert.Nil(t, err)
+			assert.NotNil(t, resp.Jobs)
+			assert.Equal(t, 0, len(resp.Jobs))
+		})
+		t.Run("should return not found if the requested job doesn't exist", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(models.JobSpec{}, models.NamespaceSpec{}, fmt.Errorf("job not found: %w", store.ErrResourceNotFound))
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				v1.NewAdapter(nil, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			req := &pb.ListDownstreamJobsRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			}
+			resp, err := runtimeServiceServer.ListDownstreamJobs(context.Background(), req)
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("ExplainDependency", func(t *testing.T) {
+		t.Run("should return candidates considered for every destination of the job", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+			jobSpec := models.JobSpec{
+				Name: "transform-tables",
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
+			jobService.On("ExplainDependency", projectSpec, jobSpec).Return([]models.DependencyExplanation{
+				{
+					Destination: "bigquery://project.dataset.table",
+					Resolved:    true,
+					Candidates: []models.DependencyCandidate{
+						{Job: "upstream-tables", Project: projectSpec.Name, Matched: true, Reason: "picked"},
+					},
+				},
+			}, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.0",
+				jobService, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.ExplainDependency(context.Background(), &pb.ExplainDependencyRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpec.Name,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, []*pb.DependencyExplanation{
+				{
+					Destination: "bigquery://project.dataset.table",
+					Resolved:    true,
+					Candidates: []*pb.DependencyCandidate{
+						{JobName: "upstream-tables", ProjectName: projectSpec.Name, Matched: true, Reason: "picked"},
+					},
+				},
+			}, resp.GetDependencies())
+		})
+	})
+
+	t.Run("RegisterJobEvent", func(t *testing.T) {
+		t.Run("should register the event if valid inputs", func(t *testing.T) {
+			Version := "1.0.0"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "game_jam",
+				ProjectSpec: projectSpec,
+			}
+
+			jobSpecs := []models.JobSpec{
+				{
+					Name: "transform-tables",
+				},
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			adapter := v1.NewAdapter(nil, nil, nil)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
+			defer jobService.AssertExpectations(t)
+
+			eventValues, _ := structpb.NewStruct(
+				map[string]interface{}{
+					"url": "http://example.io",
+				},
+			)
+			eventSvc := new(mock.EventService)
+			eventSvc.On("Register", context.Background(), namespaceSpec, jobSpecs[0], models.JobEvent{
+				Type:  models.JobEventTypeFailure,
+				Value: eventValues.GetFields(),
+			}).Return(nil)
+			defer eventSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService, eventSvc, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				adapter,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			req := &pb.RegisterJobEventRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     jobSpecs[0].Name,
+				Namespace:   namespaceSpec.Name,
+				Event: &pb.JobEvent{
+					Type:  pb.JobEvent_FAILURE,
+					Value: eventValues,
+				},
+			}
+			_, err := runtimeServiceServer.RegisterJobEvent(context.Background(), req)
+			assert.Nil(t, err)
+		})
+	})
+
+	t.Run("GetWindow", func(t *testing.T) {
+		t.Run("should return the correct window date range", func(t *testing.T) {
+			Version := "1.0.1"
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			scheduledAtTimestamp, _ := ptypes.TimestampProto(scheduledAt)
+			req := pb.GetWindowRequest{
+				ScheduledAt: scheduledAtTimestamp,
+				Size:        "24h",
+				Offset:      "24h",
+				TruncateTo:  "d",
+			}
+			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Nil(t, err)
+
+			assert.Equal(t, "2020-11-11T00:00:00Z", ptypes.TimestampString(resp.GetStart()))
+			assert.Equal(t, "2020-11-12T00:00:00Z", ptypes.TimestampString(resp.GetEnd()))
+		})
+		t.Run("should align window to the requested timezone", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			// 02:00 of 2020-11-11 in Asia/Jakarta
+			req := pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(time.Date(2020, 11, 10, 19, 0, 0, 0, time.UTC)),
+				Size:        "24h",
+				Offset:      "0",
+				TruncateTo:  "d",
+				Timezone:    "Asia/Jakarta",
+			}
+			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Nil(t, err)
+			assert.Equal(t, "2020-11-09T17:00:00Z", ptypes.TimestampString(resp.GetStart()))
+			assert.Equal(t, "2020-11-10T17:00:00Z", ptypes.TimestampString(resp.GetEnd()))
+
+			req.Timezone = "Mars/Olympus"
+			_, err = runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+		t.Run("should return contiguous hourly windows aligned to the schedule in series mode", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			req := pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(time.Date(2020, 11, 11, 5, 30, 0, 0, time.UTC)),
+				Size:        "1h",
+				Offset:      "0",
+				TruncateTo:  "h",
+				Interval:    "0 * * * *",
+				Count:       3,
+			}
+			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Nil(t, err)
+
+			var scheduledAt []string
+			for _, window := range resp.GetWindows() {
+				scheduledAt = append(scheduledAt, ptypes.TimestampString(window.GetScheduledAt()))
+				assert.Equal(t, window.GetScheduledAt().AsTime(), window.GetEnd().AsTime())
+			}
+			assert.Equal(t, []string{"2020-11-11T03:00:00Z", "2020-11-11T04:00:00Z", "2020-11-11T05:00:00Z"}, scheduledAt)
+			for i := 1; i < len(resp.GetWindows()); i++ {
+				assert.Equal(t, resp.GetWindows()[i-1].GetEnd().AsTime(), resp.GetWindows()[i].GetStart().AsTime())
+			}
+		})
+		t.Run("should return contiguous daily windows aligned to the schedule in series mode", func(t *testing.T) {
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			req := pb.GetWindowRequest{
+				ScheduledAt: timestamppb.New(time.Date(2020, 11, 11, 2, 0, 0, 0, time.UTC)),
+				Size:        "24h",
+				Offset:      "0",
+				TruncateTo:  "d",
+				Interval:    "0 2 * * *",
+				Count:       4,
+			}
+			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Nil(t, err)
+
+			windows := resp.GetWindows()
+			assert.Equal(t, 4, len(windows))
+			assert.Equal(t, "2020-11-08T02:00:00Z", ptypes.TimestampString(windows[0].GetScheduledAt()))
+			assert.Equal(t, "2020-11-11T02:00:00Z", ptypes.TimestampString(windows[3].GetScheduledAt()))
+			assert.Equal(t, "2020-11-07T00:00:00Z", ptypes.TimestampString(windows[0].GetStart()))
+			assert.Equal(t, "2020-11-11T00:00:00Z", ptypes.TimestampString(windows[3].GetEnd()))
+			for i := 1; i < len(windows); i++ {
+				assert.Equal(t, windows[i-1].GetEnd().AsTime(), windows[i].GetStart().AsTime())
+				assert.Equal(t, 24*time.Hour, windows[i].GetScheduledAt().AsTime().Sub(windows[i-1].GetScheduledAt().AsTime()))
+			}
+		})
+		t.Run("should return error if any of the required fields in request is missing", func(t *testing.T) {
+			Version := "1.0.1"
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				nil, nil,
+				nil, nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			scheduledAtTimestamp, _ := ptypes.TimestampProto(scheduledAt)
+			req := pb.GetWindowRequest{
+				ScheduledAt: scheduledAtTimestamp,
+				Size:        "",
+				Offset:      "24h",
+				TruncateTo:  "d",
+			}
+			_, err := runtimeServiceServer.GetWindow(context.Background(), &req)
+			assert.Equal(t, "rpc error: code = InvalidArgument desc = window size, offset and truncate_to must be provided", err.Error())
+		})
+	})
+
+	t.Run("DumpJobSpecification", func(t *testing.T) {
+		t.Run("should dump specification of a job", func(t *testing.T) {
+			Version := "1.0.1"
+
+			projectName := "a-data-project"
+			jobName := "a-data-job"
+
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			compiledJob := models.Job{
+				Name:        jobName,
+				NamespaceID: namespaceSpec.ID.String(),
+				Contents:    []byte("content-of-dag"),
+			}
+
+			execUnit1 := new(mock.TaskPlugin)
+			defer execUnit1.AssertExpectations(t)
+
+			jobSpec := models.JobSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: jobName,
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "do",
+							Value: "this",
+						},
+					},
+				},
+				Assets: *models.JobAssets{}.New(
+					[]models.JobSpecAsset{
+						{
+							Name:  "query.sql",
+							Value: "select * from 1",
+						},
+					}),
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", jobName, namespaceSpec).Return(jobSpec, nil)
+			jobService.On("Dump", namespaceSpec, jobSpec).Return(compiledJob, nil)
+			defer jobService.AssertExpectations(t)
+
+			jobSpecRepository := new(mock.JobSpecRepository)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			jobSpecRepoFactory := new(mock.JobSpecRepoFactory)
+			defer jobSpecRepoFactory.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			defer compiler.AssertExpectations(t)
+
+			dependencyResolver := new(mock.DependencyResolver)
+			defer dependencyResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			defer priorityResolver.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			projectJobSpecRepository := new(mock.ProjectJobSpecRepository)
+			defer projectJobSpecRepository.AssertExpectations(t)
+
+			projectJobSpecRepoFactory := new(mock.ProjectJobSpecRepoFactory)
+			defer projectJobSpecRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				Version,
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			req := pb.DumpJobSpecificationRequest{
+				ProjectName: projectName,
+				JobName:     jobName,
+				Namespace:   namespaceSpec.Name,
+			}
+			resp, err := runtimeServiceServer.DumpJobSpecification(context.Background(), &req)
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+			assert.Equal(t, "content-of-dag", resp.GetContent())
+		})
+		t.Run("should return not found if job doesn't exist", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetByName", "a-data-job", namespaceSpec).Return(models.JobSpec{}, store.ErrResourceNotFound)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.DumpJobSpecification(context.Background(), &pb.DumpJobSpecificationRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     "a-data-job",
+				Namespace:   namespaceSpec.Name,
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+
+	t.Run("CheckDatastore", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+
+		t.Run("should return success if datastore is reachable", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("CheckDatastore", context.Background(), projectSpec, "bigquery").Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.CheckDatastore(context.Background(), &pb.CheckDatastoreRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bigquery",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.CheckDatastoreResponse{Success: true}, resp)
+		})
+		t.Run("should return failure category if datastore check fails", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			checkErr := &models.DatastoreError{
+				Category: models.DatastoreErrorCategoryPermission,
+				Err:      errors.New("access denied"),
+			}
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("CheckDatastore", context.Background(), projectSpec, "bigquery").Return(checkErr)
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"1.0.1",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.CheckDatastore(context.Background(), &pb.CheckDatastoreRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bigquery",
+			})
+			assert.Nil(t, err)
+			assert.False(t, resp.GetSuccess())
+			assert.Equal(t, "permission", resp.GetCategory())
+			assert.Equal(t, checkErr.Error(), resp.GetMessage())
+		})
+	})
+
+	t.Run("ListSecrets", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			Name: "a-data-project",
+		}
+
+		t.Run("should return names of secrets without their values", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			projectSecretRepository := new(mock.ProjectSecretRepository)
+			projectSecretRepository.On("GetAll").Return([]models.ProjectSecretItem{
+				{Name: "world", Value: "secret"},
+				{Name: "hello", Value: "secret"},
+			}, nil)
+			defer projectSecretRepository.AssertExpectations(t)
+
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
+			defer projectSecretRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				projectSecretRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.ListSecrets(context.Background(), &pb.ListSecretsRequest{
+				ProjectName: projectSpec.Name,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.ListSecretsResponse{
+				SecretNames: []string{"hello", "world"},
+			}, resp)
+		})
+		t.Run("should return not found if project doesn't exist", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(models.ProjectSpec{}, store.ErrResourceNotFound)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.ListSecrets(context.Background(), &pb.ListSecretsRequest{
+				ProjectName: projectSpec.Name,
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("GetJobDependencyGraph", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			Name: "a-data-project",
+		}
+
+		t.Run("should return dependency graph of a job", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobA := models.JobDependencyGraphNode{Name: "job-a", Project: projectSpec.Name}
+			jobB := models.JobDependencyGraphNode{Name: "job-b", Project: projectSpec.Name}
+			jobService := new(mock.JobService)
+			jobService.On("GetDependencyGraph", projectSpec, "job-b").Return(models.JobDependencyGraph{
+				Jobs: []models.JobDependencyGraphNode{jobA, jobB},
+				Edges: []models.JobDependencyGraphEdge{
+					{Upstream: jobA, Downstream: jobB, Type: models.JobSpecDependencyTypeIntra, Inferred: true},
+				},
+			}, nil)
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetJobDependencyGraph(context.Background(), &pb.GetJobDependencyGraphRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     "job-b",
+			})
+			assert.Nil(t, err)
+			jobAProto := &pb.JobDependencyGraphNode{JobName: "job-a", ProjectName: projectSpec.Name}
+			jobBProto := &pb.JobDependencyGraphNode{JobName: "job-b", ProjectName: projectSpec.Name}
+			assert.Equal(t, &pb.GetJobDependencyGraphResponse{
+				Jobs: []*pb.JobDependencyGraphNode{jobAProto, jobBProto},
+				Edges: []*pb.JobDependencyGraphEdge{
+					{Upstream: jobAProto, Downstream: jobBProto, Type: models.JobSpecDependencyTypeIntra.String(), Inferred: true},
+				},
+			}, resp)
+		})
+		t.Run("should return not found if job doesn't exist", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			jobService := new(mock.JobService)
+			jobService.On("GetDependencyGraph", projectSpec, "job-x").Return(models.JobDependencyGraph{},
+				errors.Wrap(store.ErrResourceNotFound, "job job-x"))
+			defer jobService.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				jobService,
+				nil, nil,
+				projectRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.GetJobDependencyGraph(context.Background(), &pb.GetJobDependencyGraphRequest{
+				ProjectName: projectSpec.Name,
+				JobName:     "job-x",
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("DeleteSecret", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			Name: "a-data-project",
+		}
+
+		t.Run("should delete the secret from project", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			projectSecretRepository := new(mock.ProjectSecretRepository)
+			projectSecretRepository.On("Delete", "hello").Return(nil)
+			defer projectSecretRepository.AssertExpectations(t)
+
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
+			defer projectSecretRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				projectSecretRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.DeleteSecret(context.Background(), &pb.DeleteSecretRequest{
+				ProjectName: projectSpec.Name,
+				SecretName:  "hello",
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.DeleteSecretResponse{
+				Success: true,
+				Message: "secret hello has been deleted",
+			}, resp)
+		})
+		t.Run("should return not found if secret doesn't exist", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			projectSecretRepository := new(mock.ProjectSecretRepository)
+			projectSecretRepository.On("Delete", "hello").Return(store.ErrResourceNotFound)
+			defer projectSecretRepository.AssertExpectations(t)
+
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpec).Return(projectSecretRepository)
+			defer projectSecretRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				projectSecretRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.DeleteSecret(context.Background(), &pb.DeleteSecretRequest{
+				ProjectName: projectSpec.Name,
+				SecretName:  "hello",
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+	})
+	t.Run("RotateSecretKey", func(t *testing.T) {
+		projectSpecA := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		projectSpecB := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "b-data-project",
+		}
+
+		t.Run("should rotate secrets of all projects", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetAll").Return([]models.ProjectSpec{projectSpecA, projectSpecB}, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			secretRepositoryA := new(mock.ProjectSecretRepository)
+			secretRepositoryA.On("RotateKey").Return(2, nil)
+			defer secretRepositoryA.AssertExpectations(t)
+
+			secretRepositoryB := new(mock.ProjectSecretRepository)
+			secretRepositoryB.On("RotateKey").Return(1, nil)
+			defer secretRepositoryB.AssertExpectations(t)
+
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpecA).Return(secretRepositoryA)
+			projectSecretRepoFactory.On("New", projectSpecB).Return(secretRepositoryB)
+			defer projectSecretRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				projectSecretRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RotateSecretKey(context.Background(), &pb.RotateSecretKeyRequest{})
+			assert.Nil(t, err)
+			assert.Equal(t, &pb.RotateSecretKeyResponse{
+				Success:        true,
+				RotatedSecrets: 3,
+			}, resp)
+		})
+		t.Run("should return error if secrets of a project fail to rotate", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetAll").Return([]models.ProjectSpec{projectSpecA, projectSpecB}, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			secretRepositoryA := new(mock.ProjectSecretRepository)
+			secretRepositoryA.On("RotateKey").Return(1, errors.New("random error"))
+			defer secretRepositoryA.AssertExpectations(t)
+
+			projectSecretRepoFactory := new(mock.ProjectSecretRepoFactory)
+			projectSecretRepoFactory.On("New", projectSpecA).Return(secretRepositoryA)
+			defer projectSecretRepoFactory.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"someVersion1.0",
+				nil, nil, nil,
+				projectRepoFactory,
+				nil,
+				projectSecretRepoFactory,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RotateSecretKey(context.Background(), &pb.RotateSecretKeyRequest{})
+			assert.Nil(t, resp)
+			assert.Equal(t, "rpc error: code = Internal desc = random error: failed to rotate secrets of project a-data-project after rotating 1 secrets", err.Error())
+		})
+	})
+	t.Run("CreateResource", func(t *testing.T) {
+		t.Run("should create datastore resource successfully", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			// prepare mocked datastore
+			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+
+			dsTypeTableController := new(mock.DatastoreTypeController)
+			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+
+			dsTypeDatasetController := new(mock.DatastoreTypeController)
+			dsTypeDatasetController.On("Adapter").Return(dsTypeTableAdapter)
+
+			dsController := map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeDataset: dsTypeTableController,
+			}
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Types").Return(dsController)
+			datastorer.On("Name").Return("bq")
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			dsTypeTableAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
+
+			req := pb.CreateResourceRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bq",
+				Resource: &pb.ResourceSpecification{
+					Version: 1,
+					Name:    "proj.datas",
+					Type:    models.ResourceTypeDataset.String(),
+				},
+				Namespace: namespaceSpec.Name,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("CreateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, dsRepo),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.CreateResource(context.Background(), &req)
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+		})
+		t.Run("should return already exists if resource is in another namespace", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "a-data-project",
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+			dsTypeTableController := new(mock.DatastoreTypeController)
+			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeDataset: dsTypeTableController,
+			})
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			dsTypeTableAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("CreateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).
+				Return(errors.Wrap(store.ErrResourceAlreadyExists, "resource proj.datas"))
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, dsRepo),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.CreateResource(context.Background(), &pb.CreateResourceRequest{
+				ProjectName:   projectSpec.Name,
+				DatastoreName: "bq",
+				Resource: &pb.ResourceSpecification{
+					Version: 1,
+					Name:    "proj.datas",
+					Type:    models.ResourceTypeDataset.String(),
+				},
+				Namespace: namespaceSpec.Name,
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		})
+	})
+
+	t.Run("UpdateResource", func(t *testing.T) {
+		t.Run("should update datastore resource successfully", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			// prepare mocked datastore
+			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+
+			dsTypeTableController := new(mock.DatastoreTypeController)
+			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+
+			dsTypeDatasetController := new(mock.DatastoreTypeController)
+			dsTypeDatasetController.On("Adapter").Return(dsTypeTableAdapter)
+
+			dsController := map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeDataset: dsTypeTableController,
+			}
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Types").Return(dsController)
+			datastorer.On("Name").Return("bq")
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			dsTypeTableAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
+
+			req := pb.UpdateResourceRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bq",
+				Resource: &pb.ResourceSpecification{
+					Version: 1,
+					Name:    "proj.datas",
+					Type:    models.ResourceTypeDataset.String(),
+				},
+				Namespace: namespaceSpec.Name,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("UpdateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, false, nil).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, dsRepo),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.UpdateResource(context.Background(), &req)
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+		})
+		t.Run("should fail with failed precondition if update has destructive changes and is not forced", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+			}
+
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-test-namespace-1",
+				Config: map[string]string{
+					"bucket": "gs://some_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			// prepare mocked datastore
+			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+
+			dsTypeTableController := new(mock.DatastoreTypeController)
+			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+
+			dsTypeDatasetController := new(mock.DatastoreTypeController)
+			dsTypeDatasetController.On("Adapter").Return(dsTypeTableAdapter)
+
+			dsController := map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeDataset: dsTypeTableController,
+			}
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Types").Return(dsController)
+			datastorer.On("Name").Return("bq")
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			dsTypeTableAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
+
+			req := pb.UpdateResourceRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bq",
+				Resource: &pb.ResourceSpecification{
+					Version: 1,
+					Name:    "proj.datas",
+					Type:    models.ResourceTypeDataset.String(),
+				},
+				Namespace: namespaceSpec.Name,
+				Force:     false,
+			}
+
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("UpdateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, false, nil).
+				Return(errors.Wrap(models.ErrDestructiveChange, "table proj.datas.user: column id is removed"))
+			defer resourceSvc.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(nil, nil, dsRepo),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.UpdateResource(context.Background(), &req)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+	})
+
+	t.Run("RenameResource", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		req := pb.RenameResourceRequest{
+			ProjectName:   projectName,
+			Namespace:     namespaceSpec.Name,
+			DatastoreName: "bq",
+			OldName:       "proj.datas.old_table",
+			NewName:       "proj.datas.new_table",
+			AllowCopy:     true,
+		}
+
+		t.Run("should rename resource successfully", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("RenameResource", context.Background(), namespaceSpec, "bq", req.OldName, req.NewName, true).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RenameResource(context.Background(), &req)
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+		})
+		t.Run("should return already exists if new name is in use", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("RenameResource", context.Background(), namespaceSpec, "bq", req.OldName, req.NewName, true).
+				Return(errors.Wrapf(models.ErrResourceExists, "resource %s", req.NewName))
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.RenameResource(context.Background(), &req)
+			assert.Nil(t, resp)
+			assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		})
+	})
+
+	t.Run("DeleteResource", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		req := pb.DeleteResourceRequest{
+			ProjectName:         projectName,
+			Namespace:           namespaceSpec.Name,
+			DatastoreName:       "bq",
+			ResourceName:        "proj.datas.table",
+			DeleteFromDatastore: true,
+		}
+
+		t.Run("should delete resource successfully", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("DeleteResource", context.Background(), namespaceSpec, "bq", req.ResourceName, true).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.DeleteResource(context.Background(), &req)
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+			assert.Equal(t, "resource proj.datas.table deleted", resp.GetMessage())
+		})
+		t.Run("should return not found if resource spec doesn't exist", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("DeleteResource", context.Background(), namespaceSpec, "bq", req.ResourceName, true).Return(store.ErrResourceNotFound)
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.DeleteResource(context.Background(), &req)
+			assert.Equal(t, codes.NotFound, status.Code(err))
+		})
+		t.Run("should backup resource before deleting it from datastore when asked", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
+
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
+
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
+
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			backupReq := proto.Clone(&req).(*pb.DeleteResourceRequest)
+			backupReq.Backup = true
+			resourceSvc.On("BackupResource", context.Background(), namespaceSpec, "bq", req.ResourceName, time.Duration(0), "before delete").
+				Return(models.BackupSpec{}, nil)
+			resourceSvc.On("DeleteResource", context.Background(), namespaceSpec, "bq", req.ResourceName, true).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			resp, err := runtimeServiceServer.DeleteResource(context.Background(), backupReq)
+			assert.Nil(t, err)
+			assert.Equal(t, true, resp.GetSuccess())
+		})
+		t.Run("should not delete resource if backup fails", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
 
-			allTasksRepo := new(mock.SupportedTaskRepo)
-			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
-			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
 
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
@@ -768,99 +6948,103 @@ func TestRuntimeServiceServer(t *testing.T) {
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
-			projectJobSpecRepository := new(mock.ProjectJobSpecRepository)
-			defer projectJobSpecRepository.AssertExpectations(t)
-
-			projectJobSpecRepoFactory := new(mock.ProjectJobSpecRepoFactory)
-			defer projectJobSpecRepoFactory.AssertExpectations(t)
-
-			jobService := new(mock.JobService)
-			jobService.On("Create", mock2.Anything, namespaceSpec).Return(nil)
-			jobService.On("KeepOnly", namespaceSpec, mock2.Anything, mock2.Anything).Return(nil)
-			jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Return(nil)
-			defer jobService.AssertExpectations(t)
-
-			grpcRespStream := new(mock.RuntimeService_DeployJobSpecificationServer)
-			grpcRespStream.On("Context").Return(context.Background())
-			defer grpcRespStream.AssertExpectations(t)
+			resourceSvc := new(mock.DatastoreService)
+			backupReq := proto.Clone(&req).(*pb.DeleteResourceRequest)
+			backupReq.Backup = true
+			resourceSvc.On("BackupResource", context.Background(), namespaceSpec, "bq", req.ResourceName, time.Duration(0), "before delete").
+				Return(models.BackupSpec{}, errors.New("access denied"))
+			defer resourceSvc.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
+				"Version",
 				nil, nil,
+				resourceSvc,
 				projectRepoFactory,
 				namespaceRepoFact,
 				nil,
-				adapter,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			jobSpecsAdapted := []*pb.JobSpecification{}
-			for _, jobSpec := range jobSpecs {
-				jobSpecAdapted, _ := adapter.ToJobProto(jobSpec)
-				jobSpecsAdapted = append(jobSpecsAdapted, jobSpecAdapted)
-			}
-			deployRequest := pb.DeployJobSpecificationRequest{ProjectName: projectName, Jobs: jobSpecsAdapted, Namespace: namespaceSpec.Name}
-			err := runtimeServiceServer.DeployJobSpecification(&deployRequest, grpcRespStream)
-			assert.Nil(t, err)
+			_, err := runtimeServiceServer.DeleteResource(context.Background(), backupReq)
+			assert.Equal(t, codes.Internal, status.Code(err))
+			resourceSvc.AssertNotCalled(t, "DeleteResource", mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything)
 		})
 	})
+	t.Run("BackupResource", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		req := pb.BackupResourceRequest{
+			ProjectName:   projectName,
+			Namespace:     namespaceSpec.Name,
+			DatastoreName: "bq",
+			ResourceName:  "proj.datas.table",
+			Description:   "before migration",
+			Ttl:           ptypes.DurationProto(time.Hour),
+		}
 
-	t.Run("ReadJobSpecification", func(t *testing.T) {
-		t.Run("should read a job spec", func(t *testing.T) {
-			Version := "1.0.1"
-
-			projectName := "a-data-project"
-			jobName1 := "a-data-job"
-			taskName := "a-data-task"
+		t.Run("should backup resource and return the backup", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
 
-			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-			}
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
 
-			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-				ProjectSpec: projectSpec,
-			}
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
 
-			execUnit1 := new(mock.TaskPlugin)
-			execUnit1.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
-				Name: taskName,
-			}, nil)
-			defer execUnit1.AssertExpectations(t)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
 
-			jobSpecs := []models.JobSpec{
-				{
-					Name: jobName1,
-					Task: models.JobSpecTask{
-						Unit: execUnit1,
-						Config: models.JobSpecConfigs{
-							{
-								Name:  "do",
-								Value: "this",
-							},
-						},
-					},
-					Assets: *models.JobAssets{}.New(
-						[]models.JobSpecAsset{
-							{
-								Name:  "query.sql",
-								Value: "select * from 1",
-							},
-						}),
-				},
+			backupSpec := models.BackupSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				URN:         "proj.optimus_backup.datas_table_1",
+				Description: req.Description,
+				Resource:    models.ResourceSpec{Name: req.ResourceName},
+				CreatedAt:   time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+				ExpiresAt:   time.Date(2021, 3, 1, 1, 0, 0, 0, time.UTC),
 			}
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("BackupResource", context.Background(), namespaceSpec, "bq", req.ResourceName, time.Hour, req.Description).
+				Return(backupSpec, nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				nil,
+				nil,
+				nil,
+				nil,
+			)
 
+			resp, err := runtimeServiceServer.BackupResource(context.Background(), &req)
+			assert.Nil(t, err)
+			assert.Equal(t, backupSpec.ID.String(), resp.GetBackup().GetId())
+			assert.Equal(t, backupSpec.URN, resp.GetBackup().GetUrn())
+			assert.Equal(t, req.ResourceName, resp.GetBackup().GetResourceName())
+		})
+		t.Run("should return not found if resource spec doesn't exist", func(t *testing.T) {
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
@@ -869,10 +7053,6 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			allTasksRepo := new(mock.SupportedTaskRepo)
-			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
-			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
-
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 			defer namespaceRepository.AssertExpectations(t)
@@ -881,54 +7061,50 @@ func TestRuntimeServiceServer(t *testing.T) {
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
-			defer jobService.AssertExpectations(t)
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("BackupResource", context.Background(), namespaceSpec, "bq", req.ResourceName, time.Hour, req.Description).
+				Return(models.BackupSpec{}, store.ErrResourceNotFound)
+			defer resourceSvc.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
+				"Version",
 				nil, nil,
+				resourceSvc,
 				projectRepoFactory,
 				namespaceRepoFact,
 				nil,
-				adapter,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			jobSpecAdapted, _ := adapter.ToJobProto(jobSpecs[0])
-			deployRequest := pb.ReadJobSpecificationRequest{ProjectName: projectName, JobName: jobSpecs[0].Name, Namespace: namespaceSpec.Name}
-			jobSpecResp, err := runtimeServiceServer.ReadJobSpecification(context.Background(), &deployRequest)
-			assert.Nil(t, err)
-			assert.Equal(t, jobSpecAdapted, jobSpecResp.Spec)
+			_, err := runtimeServiceServer.BackupResource(context.Background(), &req)
+			assert.Equal(t, codes.NotFound, status.Code(err))
 		})
 	})
 
-	t.Run("ListProjectNamespaces", func(t *testing.T) {
-		t.Run("should read namespaces of a project", func(t *testing.T) {
-			Version := "1.0.1"
-
-			projectName := "a-data-project"
-
-			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-			}
-
-			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-				ProjectSpec: projectSpec,
-			}
+	t.Run("RestoreResource", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		backupID := uuid.Must(uuid.NewRandom())
+		req := pb.RestoreResourceRequest{
+			ProjectName:   projectName,
+			Namespace:     namespaceSpec.Name,
+			DatastoreName: "bq",
+			BackupId:      backupID.String(),
+		}
 
+		t.Run("should restore resource from backup", func(t *testing.T) {
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
@@ -937,91 +7113,78 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			allTasksRepo := new(mock.SupportedTaskRepo)
-			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
-
 			namespaceRepository := new(mock.NamespaceRepository)
-			namespaceRepository.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 			defer namespaceRepository.AssertExpectations(t)
 
 			namespaceRepoFact := new(mock.NamespaceRepoFactory)
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			defer jobService.AssertExpectations(t)
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("RestoreResource", context.Background(), namespaceSpec, "bq", backupID).
+				Return(models.ResourceSpec{Name: "proj.datas.table"}, nil)
+			defer resourceSvc.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
+				"Version",
 				nil, nil,
+				resourceSvc,
 				projectRepoFactory,
 				namespaceRepoFact,
 				nil,
-				adapter,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			namespaceAdapted := adapter.ToNamespaceProto(namespaceSpec)
-			request := pb.ListProjectNamespacesRequest{ProjectName: projectName}
-			resp, err := runtimeServiceServer.ListProjectNamespaces(context.Background(), &request)
+			resp, err := runtimeServiceServer.RestoreResource(context.Background(), &req)
 			assert.Nil(t, err)
-			assert.Equal(t, []*pb.NamespaceSpecification{namespaceAdapted}, resp.GetNamespaces())
+			assert.Equal(t, true, resp.GetSuccess())
+			assert.Equal(t, fmt.Sprintf("resource proj.datas.table restored from backup %s", backupID), resp.GetMessage())
 		})
-	})
-
-	t.Run("DeleteJobSpecification", func(t *testing.T) {
-		t.Run("should delete the job", func(t *testing.T) {
-			Version := "1.0.1"
-
-			projectName := "a-data-project"
-			jobName1 := "a-data-job"
-			taskName := "a-data-task"
-
-			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-			}
-
-			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
-				ProjectSpec: projectSpec,
-			}
+		t.Run("should return failed precondition if backup has expired", func(t *testing.T) {
+			projectRepository := new(mock.ProjectRepository)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+			defer projectRepository.AssertExpectations(t)
 
-			execUnit1 := new(mock.TaskPlugin)
-			defer execUnit1.AssertExpectations(t)
+			projectRepoFactory := new(mock.ProjectRepoFactory)
+			projectRepoFactory.On("New").Return(projectRepository)
+			defer projectRepoFactory.AssertExpectations(t)
 
-			jobSpecs := []models.JobSpec{
-				{
-					Name: jobName1,
-					Task: models.JobSpecTask{
-						Unit: execUnit1,
-						Config: models.JobSpecConfigs{
-							{
-								Name:  "do",
-								Value: "this",
-							},
-						},
-					},
-					Assets: *models.JobAssets{}.New(
-						[]models.JobSpecAsset{
-							{
-								Name:  "query.sql",
-								Value: "select * from 1",
-							},
-						}),
-				},
-			}
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
 
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
+
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("RestoreResource", context.Background(), namespaceSpec, "bq", backupID).
+				Return(models.ResourceSpec{}, errors.Wrap(datastore.ErrBackupExpired, "backup expired"))
+			defer resourceSvc.AssertExpectations(t)
+
+			runtimeServiceServer := v1.NewRuntimeServiceServer(
+				"Version",
+				nil, nil,
+				resourceSvc,
+				projectRepoFactory,
+				namespaceRepoFact,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+			)
+
+			_, err := runtimeServiceServer.RestoreResource(context.Background(), &req)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		})
+		t.Run("should return invalid argument if backup id is malformed", func(t *testing.T) {
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
@@ -1030,10 +7193,6 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			allTasksRepo := new(mock.SupportedTaskRepo)
-			allTasksRepo.On("GetByName", taskName).Return(execUnit1, nil)
-			adapter := v1.NewAdapter(allTasksRepo, nil, nil)
-
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 			defer namespaceRepository.AssertExpectations(t)
@@ -1042,146 +7201,243 @@ func TestRuntimeServiceServer(t *testing.T) {
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
-			jobSpec := jobSpecs[0]
-
-			jobService := new(mock.JobService)
-			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
-			jobService.On("Delete", mock2.Anything, namespaceSpec, jobSpec).Return(nil)
-			defer jobService.AssertExpectations(t)
+			resourceSvc := new(mock.DatastoreService)
+			invalidReq := proto.Clone(&req).(*pb.RestoreResourceRequest)
+			invalidReq.BackupId = "not-a-uuid"
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
+				"Version",
 				nil, nil,
+				resourceSvc,
 				projectRepoFactory,
 				namespaceRepoFact,
 				nil,
-				adapter,
+				nil,
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			deployRequest := pb.DeleteJobSpecificationRequest{ProjectName: projectName, JobName: jobSpec.Name, Namespace: namespaceSpec.Name}
-			resp, err := runtimeServiceServer.DeleteJobSpecification(context.Background(), &deployRequest)
-			assert.Nil(t, err)
-			assert.Equal(t, "job a-data-job has been deleted", resp.GetMessage())
+			_, err := runtimeServiceServer.RestoreResource(context.Background(), invalidReq)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
 		})
 	})
 
-	t.Run("JobStatus", func(t *testing.T) {
-		t.Run("should return all job status via scheduler if valid inputs", func(t *testing.T) {
-			Version := "1.0.0"
+	t.Run("ReadResource", func(t *testing.T) {
+		projectName := "a-data-project"
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: projectName,
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+		assetURI := "gs://shared-bucket/views/user.sql"
+
+		cases := []struct {
+			name          string
+			resolveAssets bool
+			expectedAsset string
+		}{
+			{
+				name:          "should inline content of referenced assets if asked to resolve them",
+				resolveAssets: true,
+				expectedAsset: "select * from users",
+			},
+			{
+				name:          "should keep referenced assets as is by default",
+				resolveAssets: false,
+				expectedAsset: assetURI,
+			},
+		}
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				dsTypeViewAdapter := new(mock.DatastoreTypeAdapter)
+				dsTypeViewAdapter.On("ToProtobuf", mock2.MatchedBy(func(spec models.ResourceSpec) bool {
+					return spec.Assets["view.sql"] == tt.expectedAsset
+				})).Return([]byte{}, nil)
+				defer dsTypeViewAdapter.AssertExpectations(t)
+
+				dsTypeViewController := new(mock.DatastoreTypeController)
+				dsTypeViewController.On("Adapter").Return(dsTypeViewAdapter)
+
+				datastorer := new(mock.Datastorer)
+				datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+					models.ResourceTypeView: dsTypeViewController,
+				})
+
+				resourceSpec := models.ResourceSpec{
+					Version:   1,
+					Name:      "proj.datas.user_view",
+					Type:      models.ResourceTypeView,
+					Datastore: datastorer,
+					Assets: map[string]string{
+						"view.sql": assetURI,
+					},
+				}
 
-			projectSpec := models.ProjectSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "a-data-project",
-			}
+				projectRepository := new(mock.ProjectRepository)
+				projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+				defer projectRepository.AssertExpectations(t)
 
-			namespaceSpec := models.NamespaceSpec{
-				ID:          uuid.Must(uuid.NewRandom()),
-				Name:        "game_jam",
-				ProjectSpec: projectSpec,
-			}
+				projectRepoFactory := new(mock.ProjectRepoFactory)
+				projectRepoFactory.On("New").Return(projectRepository)
+				defer projectRepoFactory.AssertExpectations(t)
 
-			jobSpec := models.JobSpec{
-				Name: "transform-tables",
+				namespaceRepository := new(mock.NamespaceRepository)
+				namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+				defer namespaceRepository.AssertExpectations(t)
+
+				namespaceRepoFact := new(mock.NamespaceRepoFactory)
+				namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+				defer namespaceRepoFact.AssertExpectations(t)
+
+				resourceSvc := new(mock.DatastoreService)
+				resourceSvc.On("ReadResource", context.Background(), namespaceSpec, "bq", resourceSpec.Name).Return(resourceSpec, nil)
+				defer resourceSvc.AssertExpectations(t)
+
+				assetLoader := new(mock.AssetLoader)
+				assetLoaderFactory := new(mock.AssetLoaderFactory)
+				if tt.resolveAssets {
+					assetLoader.On("Load", context.Background(), assetURI).Return([]byte("select * from users"), nil)
+					assetLoaderFactory.On("New", projectSpec).Return(assetLoader)
+				}
+				defer assetLoader.AssertExpectations(t)
+				defer assetLoaderFactory.AssertExpectations(t)
+
+				runtimeServiceServer := v1.NewRuntimeServiceServer(
+					"Version",
+					nil, nil,
+					resourceSvc,
+					projectRepoFactory,
+					namespaceRepoFact,
+					nil,
+					v1.NewAdapter(nil, nil, nil),
+					nil,
+					nil,
+					nil,
+					assetLoaderFactory,
+				)
+
+				resp, err := runtimeServiceServer.ReadResource(context.Background(), &pb.ReadResourceRequest{
+					ProjectName:   projectName,
+					DatastoreName: "bq",
+					ResourceName:  resourceSpec.Name,
+					Namespace:     namespaceSpec.Name,
+					ResolveAssets: tt.resolveAssets,
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, true, resp.GetSuccess())
+			})
+		}
+		t.Run("should fail with failed precondition for assets the server doesn't fetch", func(t *testing.T) {
+			httpURI := "https://internal.example.io/views/user.sql"
+			resourceSpec := models.ResourceSpec{
+				Version: 1,
+				Name:    "proj.datas.user_view",
+				Type:    models.ResourceTypeView,
+				Assets: map[string]string{
+					"view.sql": httpURI,
+				},
 			}
 
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			adapter := v1.NewAdapter(nil, nil, nil)
+			namespaceRepository := new(mock.NamespaceRepository)
+			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+			defer namespaceRepository.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			jobService.On("GetByNameForProject", jobSpec.Name, projectSpec).Return(jobSpec, namespaceSpec, nil)
-			defer jobService.AssertExpectations(t)
+			namespaceRepoFact := new(mock.NamespaceRepoFactory)
+			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+			defer namespaceRepoFact.AssertExpectations(t)
 
-			jobStatuses := []models.JobStatus{
-				{
-					ScheduledAt: time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC),
-					State:       "failed",
-				},
-				{
-					ScheduledAt: time.Date(2020, 11, 10, 0, 0, 0, 0, time.UTC),
-					State:       "success",
-				},
-			}
-			scheduler := new(mock.Scheduler)
-			scheduler.On("GetJobStatus", context.Background(), projectSpec, jobSpec.Name).Return(jobStatuses, nil)
-			defer scheduler.AssertExpectations(t)
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("ReadResource", context.Background(), namespaceSpec, "bq", resourceSpec.Name).Return(resourceSpec, nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			assetLoader := new(mock.AssetLoader)
+			assetLoader.On("Load", context.Background(), httpURI).Return([]byte(nil),
+				errors.Wrap(instance.ErrAssetHostNotAllowed, "internal.example.io"))
+			defer assetLoader.AssertExpectations(t)
+
+			assetLoaderFactory := new(mock.AssetLoaderFactory)
+			assetLoaderFactory.On("New", projectSpec).Return(assetLoader)
+			defer assetLoaderFactory.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService, nil, nil,
+				"Version",
+				nil, nil,
+				resourceSvc,
 				projectRepoFactory,
+				namespaceRepoFact,
 				nil,
+				v1.NewAdapter(nil, nil, nil),
 				nil,
-				adapter,
 				nil,
 				nil,
-				scheduler,
+				assetLoaderFactory,
 			)
 
-			req := &pb.JobStatusRequest{
-				ProjectName: projectSpec.Name,
-				JobName:     jobSpec.Name,
-			}
-			resp, err := runtimeServiceServer.JobStatus(context.Background(), req)
-			assert.Nil(t, err)
-			assert.Equal(t, len(jobStatuses), len(resp.Statuses))
-			for _, expectedStatus := range jobStatuses {
-				var found bool
-				for _, respVal := range resp.Statuses {
-					if expectedStatus.ScheduledAt.Equal(respVal.ScheduledAt.AsTime()) &&
-						expectedStatus.State.String() == respVal.State {
-						found = true
-						break
-					}
-				}
-				if !found {
-					assert.Fail(t, fmt.Sprintf("failed to find expected job status %v", expectedStatus))
-				}
-			}
+			_, err := runtimeServiceServer.ReadResource(context.Background(), &pb.ReadResourceRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bq",
+				ResourceName:  resourceSpec.Name,
+				Namespace:     namespaceSpec.Name,
+				ResolveAssets: true,
+			})
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
 		})
 	})
 
-	t.Run("RegisterJobEvent", func(t *testing.T) {
-		t.Run("should register the event if valid inputs", func(t *testing.T) {
-			Version := "1.0.0"
-
+	t.Run("ReadResources", func(t *testing.T) {
+		t.Run("should stream each resource and report missing ones without failing the rest", func(t *testing.T) {
+			projectName := "a-data-project"
 			projectSpec := models.ProjectSpec{
 				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "a-data-project",
+				Name: projectName,
 			}
-
 			namespaceSpec := models.NamespaceSpec{
 				ID:          uuid.Must(uuid.NewRandom()),
-				Name:        "game_jam",
+				Name:        "dev-test-namespace-1",
 				ProjectSpec: projectSpec,
 			}
 
-			jobSpecs := []models.JobSpec{
-				{
-					Name: "transform-tables",
-				},
+			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+			dsTypeTableAdapter.On("ToProtobuf", mock2.Anything).Return([]byte{}, nil)
+			defer dsTypeTableAdapter.AssertExpectations(t)
+
+			dsTypeTableController := new(mock.DatastoreTypeController)
+			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeTable: dsTypeTableController,
+			})
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
 			}
 
 			projectRepository := new(mock.ProjectRepository)
-			projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
 			defer projectRepository.AssertExpectations(t)
 
 			projectRepoFactory := new(mock.ProjectRepoFactory)
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			adapter := v1.NewAdapter(nil, nil, nil)
-
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 			defer namespaceRepository.AssertExpectations(t)
@@ -1190,156 +7446,84 @@ func TestRuntimeServiceServer(t *testing.T) {
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			jobService.On("GetByName", jobSpecs[0].Name, namespaceSpec).Return(jobSpecs[0], nil)
-			defer jobService.AssertExpectations(t)
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("ReadResource", context.Background(), namespaceSpec, "bq", resourceSpec.Name).Return(resourceSpec, nil)
+			resourceSvc.On("ReadResource", context.Background(), namespaceSpec, "bq", "proj.datas.missing").Return(models.ResourceSpec{}, store.ErrResourceNotFound)
+			defer resourceSvc.AssertExpectations(t)
 
-			eventValues, _ := structpb.NewStruct(
-				map[string]interface{}{
-					"url": "http://example.io",
-				},
-			)
-			eventSvc := new(mock.EventService)
-			eventSvc.On("Register", context.Background(), namespaceSpec, jobSpecs[0], models.JobEvent{
-				Type:  models.JobEventTypeFailure,
-				Value: eventValues.GetFields(),
-			}).Return(nil)
-			defer eventSvc.AssertExpectations(t)
+			grpcRespStream := new(mock.RuntimeService_ReadResourcesServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.ReadResourcesResponse) bool {
+				return resp.GetSuccess() && resp.GetResourceName() == resourceSpec.Name && resp.GetResource() != nil
+			})).Return(nil).Once()
+			grpcRespStream.On("Send", mock2.MatchedBy(func(resp *pb.ReadResourcesResponse) bool {
+				return !resp.GetSuccess() && resp.GetResourceName() == "proj.datas.missing" &&
+					strings.Contains(resp.GetMessage(), "resource proj.datas.missing not found")
+			})).Return(nil).Once()
+			defer grpcRespStream.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService, eventSvc, nil,
+				"Version",
+				nil, nil,
+				resourceSvc,
 				projectRepoFactory,
 				namespaceRepoFact,
 				nil,
-				adapter,
-				nil,
-				nil,
-				nil,
-			)
-			req := &pb.RegisterJobEventRequest{
-				ProjectName: projectSpec.Name,
-				JobName:     jobSpecs[0].Name,
-				Namespace:   namespaceSpec.Name,
-				Event: &pb.JobEvent{
-					Type:  pb.JobEvent_FAILURE,
-					Value: eventValues,
-				},
-			}
-			_, err := runtimeServiceServer.RegisterJobEvent(context.Background(), req)
-			assert.Nil(t, err)
-		})
-	})
-
-	t.Run("GetWindow", func(t *testing.T) {
-		t.Run("should return the correct window date range", func(t *testing.T) {
-			Version := "1.0.1"
-
-			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				nil, nil, nil,
-				nil,
-				nil,
-				nil,
-				nil,
-				nil,
-				nil,
-				nil,
-			)
-			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
-			scheduledAtTimestamp, _ := ptypes.TimestampProto(scheduledAt)
-			req := pb.GetWindowRequest{
-				ScheduledAt: scheduledAtTimestamp,
-				Size:        "24h",
-				Offset:      "24h",
-				TruncateTo:  "d",
-			}
-			resp, err := runtimeServiceServer.GetWindow(context.Background(), &req)
-			assert.Nil(t, err)
-
-			assert.Equal(t, "2020-11-11T00:00:00Z", ptypes.TimestampString(resp.GetStart()))
-			assert.Equal(t, "2020-11-12T00:00:00Z", ptypes.TimestampString(resp.GetEnd()))
-		})
-		t.Run("should return error if any of the required fields in request is missing", func(t *testing.T) {
-			Version := "1.0.1"
-
-			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				nil, nil,
-				nil, nil,
-				nil,
-				nil,
+				v1.NewAdapter(nil, nil, nil),
 				nil,
 				nil,
 				nil,
-				nil,
-			)
-			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
-			scheduledAtTimestamp, _ := ptypes.TimestampProto(scheduledAt)
-			req := pb.GetWindowRequest{
-				ScheduledAt: scheduledAtTimestamp,
-				Size:        "",
-				Offset:      "24h",
-				TruncateTo:  "d",
-			}
-			_, err := runtimeServiceServer.GetWindow(context.Background(), &req)
-			assert.Equal(t, "rpc error: code = InvalidArgument desc = window size, offset and truncate_to must be provided", err.Error())
+				nil,
+			)
+
+			err := runtimeServiceServer.ReadResources(&pb.ReadResourcesRequest{
+				ProjectName: projectName,
+				Namespace:   namespaceSpec.Name,
+				Resources: []*pb.ReadResourcesRequest_Resource{
+					{DatastoreName: "bq", ResourceName: resourceSpec.Name},
+					{DatastoreName: "bq", ResourceName: "proj.datas.missing"},
+				},
+			}, grpcRespStream)
+			assert.Nil(t, err)
 		})
 	})
 
-	t.Run("DumpJobSpecification", func(t *testing.T) {
-		t.Run("should dump specification of a job", func(t *testing.T) {
-			Version := "1.0.1"
-
+	t.Run("DeployResourceSpecification", func(t *testing.T) {
+		t.Run("should only update requested resources in additive mode", func(t *testing.T) {
 			projectName := "a-data-project"
-			jobName := "a-data-job"
-
 			projectSpec := models.ProjectSpec{
 				ID:   uuid.Must(uuid.NewRandom()),
 				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
 			}
 
 			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
 				ProjectSpec: projectSpec,
 			}
 
-			compiledJob := models.Job{
-				Name:        jobName,
-				NamespaceID: namespaceSpec.ID.String(),
-				Contents:    []byte("content-of-dag"),
-			}
+			// prepare mocked datastore
+			dsTypeDatasetAdapter := new(mock.DatastoreTypeAdapter)
 
-			execUnit1 := new(mock.TaskPlugin)
-			defer execUnit1.AssertExpectations(t)
+			dsTypeDatasetController := new(mock.DatastoreTypeController)
+			dsTypeDatasetController.On("Adapter").Return(dsTypeDatasetAdapter)
 
-			jobSpec := models.JobSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: jobName,
-				Task: models.JobSpecTask{
-					Unit: execUnit1,
-					Config: models.JobSpecConfigs{
-						{
-							Name:  "do",
-							Value: "this",
-						},
-					},
-				},
-				Assets: *models.JobAssets{}.New(
-					[]models.JobSpecAsset{
-						{
-							Name:  "query.sql",
-							Value: "select * from 1",
-						},
-					}),
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeDataset: dsTypeDatasetController,
+			})
+			datastorer.On("Name").Return("bq")
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
 			}
+			dsTypeDatasetAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
 
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
@@ -1349,26 +7533,6 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			jobService := new(mock.JobService)
-			jobService.On("GetByName", jobName, namespaceSpec).Return(jobSpec, nil)
-			jobService.On("Dump", namespaceSpec, jobSpec).Return(compiledJob, nil)
-			defer jobService.AssertExpectations(t)
-
-			jobSpecRepository := new(mock.JobSpecRepository)
-			defer jobSpecRepository.AssertExpectations(t)
-
-			jobSpecRepoFactory := new(mock.JobSpecRepoFactory)
-			defer jobSpecRepoFactory.AssertExpectations(t)
-
-			compiler := new(mock.Compiler)
-			defer compiler.AssertExpectations(t)
-
-			dependencyResolver := new(mock.DependencyResolver)
-			defer dependencyResolver.AssertExpectations(t)
-
-			priorityResolver := new(mock.PriorityResolver)
-			defer priorityResolver.AssertExpectations(t)
-
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 			defer namespaceRepository.AssertExpectations(t)
@@ -1377,71 +7541,67 @@ func TestRuntimeServiceServer(t *testing.T) {
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
-			projectJobSpecRepository := new(mock.ProjectJobSpecRepository)
-			defer projectJobSpecRepository.AssertExpectations(t)
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("UpdateResource", mock2.Anything, namespaceSpec, []models.ResourceSpec{resourceSpec}, false, mock2.Anything).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
 
-			projectJobSpecRepoFactory := new(mock.ProjectJobSpecRepoFactory)
-			defer projectJobSpecRepoFactory.AssertExpectations(t)
+			grpcRespStream := new(mock.RuntimeService_DeployResourceSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			defer grpcRespStream.AssertExpectations(t)
 
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
-				Version,
-				jobService,
+				"Version",
 				nil, nil,
+				resourceSvc,
 				projectRepoFactory,
 				namespaceRepoFact,
 				nil,
-				v1.NewAdapter(models.TaskRegistry, nil, nil),
+				v1.NewAdapter(nil, nil, dsRepo),
+				nil,
 				nil,
 				nil,
 				nil,
 			)
 
-			req := pb.DumpJobSpecificationRequest{
-				ProjectName: projectName,
-				JobName:     jobName,
-				Namespace:   namespaceSpec.Name,
+			deployRequest := pb.DeployResourceSpecificationRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bq",
+				Resources: []*pb.ResourceSpecification{
+					{
+						Version: 1,
+						Name:    "proj.datas",
+						Type:    models.ResourceTypeDataset.String(),
+					},
+				},
+				Namespace: namespaceSpec.Name,
 			}
-			resp, err := runtimeServiceServer.DumpJobSpecification(context.Background(), &req)
+			err := runtimeServiceServer.DeployResourceSpecification(&deployRequest, grpcRespStream)
 			assert.Nil(t, err)
-			assert.Equal(t, true, resp.GetSuccess())
-			assert.Equal(t, "content-of-dag", resp.GetContent())
+			resourceSvc.AssertNotCalled(t, "KeepOnly", mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything, mock2.Anything)
 		})
-	})
-
-	t.Run("CreateResource", func(t *testing.T) {
-		t.Run("should create datastore resource successfully", func(t *testing.T) {
+		t.Run("should delete resources missing from request in managed mode", func(t *testing.T) {
 			projectName := "a-data-project"
 			projectSpec := models.ProjectSpec{
 				ID:   uuid.Must(uuid.NewRandom()),
 				Name: projectName,
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
 			}
 
 			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
 				ProjectSpec: projectSpec,
 			}
 
 			// prepare mocked datastore
-			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
-
-			dsTypeTableController := new(mock.DatastoreTypeController)
-			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+			dsTypeDatasetAdapter := new(mock.DatastoreTypeAdapter)
 
 			dsTypeDatasetController := new(mock.DatastoreTypeController)
-			dsTypeDatasetController.On("Adapter").Return(dsTypeTableAdapter)
+			dsTypeDatasetController.On("Adapter").Return(dsTypeDatasetAdapter)
 
-			dsController := map[models.ResourceType]models.DatastoreTypeController{
-				models.ResourceTypeDataset: dsTypeTableController,
-			}
 			datastorer := new(mock.Datastorer)
-			datastorer.On("Types").Return(dsController)
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeDataset: dsTypeDatasetController,
+			})
 			datastorer.On("Name").Return("bq")
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
@@ -1453,19 +7613,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
-
-			dsTypeTableAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
-
-			req := pb.CreateResourceRequest{
-				ProjectName:   projectName,
-				DatastoreName: "bq",
-				Resource: &pb.ResourceSpecification{
-					Version: 1,
-					Name:    "proj.datas",
-					Type:    models.ResourceTypeDataset.String(),
-				},
-				Namespace: namespaceSpec.Name,
-			}
+			dsTypeDatasetAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
 
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
@@ -1475,10 +7623,6 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			resourceSvc := new(mock.DatastoreService)
-			resourceSvc.On("CreateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).Return(nil)
-			defer resourceSvc.AssertExpectations(t)
-
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 			defer namespaceRepository.AssertExpectations(t)
@@ -1487,6 +7631,15 @@ func TestRuntimeServiceServer(t *testing.T) {
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
+			resourceSvc := new(mock.DatastoreService)
+			resourceSvc.On("UpdateResource", mock2.Anything, namespaceSpec, []models.ResourceSpec{resourceSpec}, false, mock2.Anything).Return(nil)
+			resourceSvc.On("KeepOnly", mock2.Anything, namespaceSpec, "bq", []models.ResourceSpec{resourceSpec}, mock2.Anything).Return(nil)
+			defer resourceSvc.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_DeployResourceSpecificationServer)
+			grpcRespStream.On("Context").Return(context.Background())
+			defer grpcRespStream.AssertExpectations(t)
+
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
 				"Version",
 				nil, nil,
@@ -1498,72 +7651,238 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 
-			resp, err := runtimeServiceServer.CreateResource(context.Background(), &req)
+			deployRequest := pb.DeployResourceSpecificationRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bq",
+				Resources: []*pb.ResourceSpecification{
+					{
+						Version: 1,
+						Name:    "proj.datas",
+						Type:    models.ResourceTypeDataset.String(),
+					},
+				},
+				Namespace: namespaceSpec.Name,
+				Mode:      pb.DeployResourceSpecificationRequest_MANAGED,
+			}
+			err := runtimeServiceServer.DeployResourceSpecification(&deployRequest, grpcRespStream)
 			assert.Nil(t, err)
-			assert.Equal(t, true, resp.GetSuccess())
 		})
-	})
-
-	t.Run("UpdateResource", func(t *testing.T) {
-		t.Run("should update datastore resource successfully", func(t *testing.T) {
+		t.Run("should limit number of deploys running at a time", func(t *testing.T) {
 			projectName := "a-data-project"
 			projectSpec := models.ProjectSpec{
 				ID:   uuid.Must(uuid.NewRandom()),
 				Name: projectName,
 				Config: map[string]string{
-					"bucket": "gs://some_folder",
+					models.ProjectStoragePathKey: "gs://some_folder",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
 				},
 			}
-
 			namespaceSpec := models.NamespaceSpec{
-				ID:   uuid.Must(uuid.NewRandom()),
-				Name: "dev-test-namespace-1",
-				Config: map[string]string{
-					"bucket": "gs://some_folder",
-				},
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
 				ProjectSpec: projectSpec,
 			}
+			deployRequest := pb.DeployResourceSpecificationRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bq",
+				Namespace:     namespaceSpec.Name,
+			}
 
-			// prepare mocked datastore
-			dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+			// deploys block in datastore until finish is closed, tracking how
+			// many of them were running at the same time
+			setup := func(jobService *mock.JobService) (*v1.RuntimeServiceServer, *mock.RuntimeService_DeployResourceSpecificationServer,
+				chan struct{}, chan struct{}, *int32) {
+				projectRepository := new(mock.ProjectRepository)
+				projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
+
+				projectRepoFactory := new(mock.ProjectRepoFactory)
+				projectRepoFactory.On("New").Return(projectRepository)
+
+				namespaceRepository := new(mock.NamespaceRepository)
+				namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+
+				namespaceRepoFact := new(mock.NamespaceRepoFactory)
+				namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+				started := make(chan struct{}, 3)
+				finish := make(chan struct{})
+				var running, maxRunning int32
+				resourceSvc := new(mock.DatastoreService)
+				resourceSvc.On("UpdateResource", mock2.Anything, namespaceSpec, mock2.Anything, false, mock2.Anything).Run(func(args mock2.Arguments) {
+					current := atomic.AddInt32(&running, 1)
+					for {
+						prev := atomic.LoadInt32(&maxRunning)
+						if current <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, current) {
+							break
+						}
+					}
+					started <- struct{}{}
+					<-finish
+					atomic.AddInt32(&running, -1)
+				}).Return(nil)
+
+				grpcRespStream := new(mock.RuntimeService_DeployResourceSpecificationServer)
+				grpcRespStream.On("Context").Return(context.Background())
+
+				runtimeServiceServer := v1.NewRuntimeServiceServer(
+					"Version",
+					jobService, nil,
+					resourceSvc,
+					projectRepoFactory,
+					namespaceRepoFact,
+					nil,
+					v1.NewAdapter(nil, nil, nil),
+					nil,
+					nil,
+					nil,
+					nil,
+				)
+				return runtimeServiceServer, grpcRespStream, started, finish, &maxRunning
+			}
 
-			dsTypeTableController := new(mock.DatastoreTypeController)
-			dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+			t.Run("should reject deploys over the limit when configured to reject", func(t *testing.T) {
+				runtimeServiceServer, grpcRespStream, started, finish, maxRunning := setup(nil)
+				runtimeServiceServer.LimitDeploys(1, true)
+
+				firstErr := make(chan error)
+				go func() {
+					firstErr <- runtimeServiceServer.DeployResourceSpecification(&deployRequest, grpcRespStream)
+				}()
+				<-started
+
+				err := runtimeServiceServer.DeployResourceSpecification(&deployRequest, grpcRespStream)
+				assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+				close(finish)
+				assert.Nil(t, <-firstErr)
+				assert.Equal(t, int32(1), atomic.LoadInt32(maxRunning))
+
+				// slot is released once the running deploy finishes
+				err = runtimeServiceServer.DeployResourceSpecification(&deployRequest, grpcRespStream)
+				assert.Nil(t, err)
+			})
+			t.Run("should queue deploys over the limit by default", func(t *testing.T) {
+				runtimeServiceServer, grpcRespStream, started, finish, maxRunning := setup(nil)
+				runtimeServiceServer.LimitDeploys(1, false)
+
+				deployErrs := make(chan error, 2)
+				for i := 0; i < 2; i++ {
+					go func() {
+						deployErrs <- runtimeServiceServer.DeployResourceSpecification(&deployRequest, grpcRespStream)
+					}()
+				}
+				<-started
+				select {
+				case <-started:
+					t.Fatal("second deploy started while the first one was running")
+				case <-time.After(time.Millisecond * 100):
+				}
 
-			dsTypeDatasetController := new(mock.DatastoreTypeController)
-			dsTypeDatasetController.On("Adapter").Return(dsTypeTableAdapter)
+				close(finish)
+				assert.Nil(t, <-deployErrs)
+				assert.Nil(t, <-deployErrs)
+				assert.Equal(t, int32(1), atomic.LoadInt32(maxRunning))
+			})
+			t.Run("should make queued job deploys wait for a slot even when configured to reject", func(t *testing.T) {
+				synced := make(chan struct{}, 1)
+				jobService := new(mock.JobService)
+				jobService.On("KeepOnly", namespaceSpec, []models.JobSpec(nil), false).Return(nil)
+				jobService.On("Sync", mock2.Anything, namespaceSpec, mock2.Anything).Run(func(args mock2.Arguments) {
+					synced <- struct{}{}
+				}).Return(nil)
+				defer jobService.AssertExpectations(t)
+
+				runtimeServiceServer, grpcRespStream, started, finish, _ := setup(jobService)
+				runtimeServiceServer.LimitDeploys(1, true)
+				deployManager := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 1, QueueSize: 1}, nil)
+				defer deployManager.Close()
+				runtimeServiceServer.DeployManager = deployManager
+
+				deployErr := make(chan error)
+				go func() {
+					deployErr <- runtimeServiceServer.DeployResourceSpecification(&deployRequest, grpcRespStream)
+				}()
+				<-started
+
+				_, err := runtimeServiceServer.QueueJobDeployment(context.Background(), &pb.DeployJobSpecificationRequest{
+					ProjectName: projectName,
+					Namespace:   namespaceSpec.Name,
+				})
+				assert.Nil(t, err)
+				select {
+				case <-synced:
+					t.Fatal("queued deploy ran while another deploy held the only slot")
+				case <-time.After(time.Millisecond * 100):
+				}
 
-			dsController := map[models.ResourceType]models.DatastoreTypeController{
-				models.ResourceTypeDataset: dsTypeTableController,
+				close(finish)
+				assert.Nil(t, <-deployErr)
+				<-synced
+			})
+		})
+	})
+
+	t.Run("ValidateResourceSpecification", func(t *testing.T) {
+		t.Run("should stream result of each resource without touching datastore", func(t *testing.T) {
+			projectName := "a-data-project"
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: projectName,
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "dev-test-namespace-1",
+				ProjectSpec: projectSpec,
 			}
+
+			dsTypeDatasetAdapter := new(mock.DatastoreTypeAdapter)
+			dsTypeDatasetController := new(mock.DatastoreTypeController)
+			dsTypeDatasetController.On("Adapter").Return(dsTypeDatasetAdapter)
+			dsTypeDatasetController.On("Validator").Return(models.DatastoreSpecValidator(func(spec models.ResourceSpec) error {
+				if !strings.Contains(spec.Name, ".") {
+					return errors.New("for example 'project_name.dataset_name'")
+				}
+				for key := range spec.Labels {
+					if strings.ToLower(key) != key {
+						return errors.Errorf("label key %s can only contain lowercase letters", key)
+					}
+				}
+				return nil
+			}))
+
 			datastorer := new(mock.Datastorer)
-			datastorer.On("Types").Return(dsController)
-			datastorer.On("Name").Return("bq")
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeDataset: dsTypeDatasetController,
+			})
+			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
 			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
 
-			resourceSpec := models.ResourceSpec{
-				Version:   1,
+			validSpec := models.ResourceSpec{
 				Name:      "proj.datas",
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
-
-			dsTypeTableAdapter.On("FromProtobuf", mock2.Anything).Return(resourceSpec, nil)
-
-			req := pb.UpdateResourceRequest{
-				ProjectName:   projectName,
-				DatastoreName: "bq",
-				Resource: &pb.ResourceSpecification{
-					Version: 1,
-					Name:    "proj.datas",
-					Type:    models.ResourceTypeDataset.String(),
+			invalidNameSpec := models.ResourceSpec{
+				Name:      "datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			invalidLabelSpec := models.ResourceSpec{
+				Name:      "proj.labelled",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+				Labels: map[string]string{
+					"Owner": "optimus",
 				},
-				Namespace: namespaceSpec.Name,
 			}
+			dsTypeDatasetAdapter.On("FromProtobuf", mock2.Anything).Return(validSpec, nil).Once()
+			dsTypeDatasetAdapter.On("FromProtobuf", mock2.Anything).Return(invalidNameSpec, nil).Once()
+			dsTypeDatasetAdapter.On("FromProtobuf", mock2.Anything).Return(invalidLabelSpec, nil).Once()
 
 			projectRepository := new(mock.ProjectRepository)
 			projectRepository.On("GetByName", projectName).Return(projectSpec, nil)
@@ -1573,10 +7892,6 @@ func TestRuntimeServiceServer(t *testing.T) {
 			projectRepoFactory.On("New").Return(projectRepository)
 			defer projectRepoFactory.AssertExpectations(t)
 
-			resourceSvc := new(mock.DatastoreService)
-			resourceSvc.On("UpdateResource", context.Background(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil).Return(nil)
-			defer resourceSvc.AssertExpectations(t)
-
 			namespaceRepository := new(mock.NamespaceRepository)
 			namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
 			defer namespaceRepository.AssertExpectations(t)
@@ -1585,6 +7900,26 @@ func TestRuntimeServiceServer(t *testing.T) {
 			namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
 			defer namespaceRepoFact.AssertExpectations(t)
 
+			resourceSvc := new(mock.DatastoreService)
+			defer resourceSvc.AssertExpectations(t)
+
+			grpcRespStream := new(mock.RuntimeService_ValidateResourceSpecificationServer)
+			grpcRespStream.On("Send", &pb.ValidateResourceSpecificationResponse{
+				Success:      true,
+				ResourceName: "proj.datas",
+			}).Return(nil).Once()
+			grpcRespStream.On("Send", &pb.ValidateResourceSpecificationResponse{
+				Success:      false,
+				Message:      "invalid resource: for example 'project_name.dataset_name'",
+				ResourceName: "datas",
+			}).Return(nil).Once()
+			grpcRespStream.On("Send", &pb.ValidateResourceSpecificationResponse{
+				Success:      false,
+				Message:      "invalid resource: label key Owner can only contain lowercase letters",
+				ResourceName: "proj.labelled",
+			}).Return(nil).Once()
+			defer grpcRespStream.AssertExpectations(t)
+
 			runtimeServiceServer := v1.NewRuntimeServiceServer(
 				"Version",
 				nil, nil,
@@ -1596,14 +7931,25 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 
-			resp, err := runtimeServiceServer.UpdateResource(context.Background(), &req)
+			err := runtimeServiceServer.ValidateResourceSpecification(&pb.ValidateResourceSpecificationRequest{
+				ProjectName:   projectName,
+				DatastoreName: "bq",
+				Namespace:     namespaceSpec.Name,
+				Resources: []*pb.ResourceSpecification{
+					{Version: 1, Name: "proj.datas", Type: "dataset"},
+					{Version: 1, Name: "datas", Type: "dataset"},
+					{Version: 1, Name: "proj.labelled", Type: "dataset", Labels: map[string]string{"Owner": "optimus"}},
+				},
+			}, grpcRespStream)
 			assert.Nil(t, err)
-			assert.Equal(t, true, resp.GetSuccess())
+			datastorer.AssertNotCalled(t, "CreateResource", mock2.Anything, mock2.Anything)
+			datastorer.AssertNotCalled(t, "UpdateResource", mock2.Anything, mock2.Anything)
+			datastorer.AssertNotCalled(t, "DeleteResource", mock2.Anything, mock2.Anything)
 		})
 	})
-
 	t.Run("ReplayDryRun", func(t *testing.T) {
 		projectName := "a-data-project"
 		jobName := "a-data-job"
@@ -1689,6 +8035,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1742,6 +8089,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1798,6 +8146,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1894,6 +8243,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1935,6 +8285,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -1990,6 +8341,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -2026,6 +8378,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -2074,6 +8427,7 @@ func TestRuntimeServiceServer(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 			)
 			replayRequest := pb.ReplayRequest{
 				ProjectName: projectName,
@@ -26,12 +26,31 @@ type RuntimeServiceClient interface {
 	// events
 	// State of the world request
 	DeployJobSpecification(ctx context.Context, in *DeployJobSpecificationRequest, opts ...grpc.CallOption) (RuntimeService_DeployJobSpecificationClient, error)
+	// CancelDeploy stops a running job deployment started with DeployJobSpecification
+	CancelDeploy(ctx context.Context, in *CancelDeployRequest, opts ...grpc.CallOption) (*CancelDeployResponse, error)
+	// QueueJobDeployment accepts the same request as DeployJobSpecification but
+	// returns as soon as the deployment is queued, its progress can be followed
+	// with GetDeploymentStatus or StreamDeploymentEvents. The queue is kept in
+	// memory of the server, queued deployments are lost on restart and are only
+	// known to the replica that accepted them
+	QueueJobDeployment(ctx context.Context, in *DeployJobSpecificationRequest, opts ...grpc.CallOption) (*QueueJobDeploymentResponse, error)
+	// GetDeploymentStatus returns the state of a queued deployment
+	GetDeploymentStatus(ctx context.Context, in *GetDeploymentStatusRequest, opts ...grpc.CallOption) (*GetDeploymentStatusResponse, error)
+	// StreamDeploymentEvents streams progress of a queued deployment from its
+	// start until it finishes
+	StreamDeploymentEvents(ctx context.Context, in *StreamDeploymentEventsRequest, opts ...grpc.CallOption) (RuntimeService_StreamDeploymentEventsClient, error)
 	// CreateJobSpecification registers a new job for a namespace which belongs to a project
 	CreateJobSpecification(ctx context.Context, in *CreateJobSpecificationRequest, opts ...grpc.CallOption) (*CreateJobSpecificationResponse, error)
 	// ReadJobSpecification reads a provided job spec of a namespace
 	ReadJobSpecification(ctx context.Context, in *ReadJobSpecificationRequest, opts ...grpc.CallOption) (*ReadJobSpecificationResponse, error)
+	// PatchJobSpecification changes only the listed fields of a job spec and deploys it
+	PatchJobSpecification(ctx context.Context, in *PatchJobSpecificationRequest, opts ...grpc.CallOption) (*PatchJobSpecificationResponse, error)
 	// DeleteJobSpecification deletes a job spec of a namespace
 	DeleteJobSpecification(ctx context.Context, in *DeleteJobSpecificationRequest, opts ...grpc.CallOption) (*DeleteJobSpecificationResponse, error)
+	// GetJobSpecHistory lists every saved revision of a job specification
+	GetJobSpecHistory(ctx context.Context, in *GetJobSpecHistoryRequest, opts ...grpc.CallOption) (*GetJobSpecHistoryResponse, error)
+	// RollbackJobSpecification restores a job to an earlier revision and deploys it
+	RollbackJobSpecification(ctx context.Context, in *RollbackJobSpecificationRequest, opts ...grpc.CallOption) (*RollbackJobSpecificationResponse, error)
 	// ListJobSpecification returns list of jobs created in a project
 	ListJobSpecification(ctx context.Context, in *ListJobSpecificationRequest, opts ...grpc.CallOption) (*ListJobSpecificationResponse, error)
 	// DumpJobSpecification returns compiled representation of the job in a scheduler
@@ -43,19 +62,66 @@ type RuntimeServiceClient interface {
 	CheckJobSpecifications(ctx context.Context, in *CheckJobSpecificationsRequest, opts ...grpc.CallOption) (RuntimeService_CheckJobSpecificationsClient, error)
 	// RegisterProject creates a new optimus project
 	RegisterProject(ctx context.Context, in *RegisterProjectRequest, opts ...grpc.CallOption) (*RegisterProjectResponse, error)
+	// UpdateProjectConfig adds, overrides and removes configs of a project
+	// without registering it again
+	UpdateProjectConfig(ctx context.Context, in *UpdateProjectConfigRequest, opts ...grpc.CallOption) (*UpdateProjectConfigResponse, error)
+	// ListAuditEvents returns requests which changed state of optimus
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 	// RegisterProjectNamespace creates a new namespace for a project
 	RegisterProjectNamespace(ctx context.Context, in *RegisterProjectNamespaceRequest, opts ...grpc.CallOption) (*RegisterProjectNamespaceResponse, error)
 	// RegisterSecret creates a new secret of a project
 	RegisterSecret(ctx context.Context, in *RegisterSecretRequest, opts ...grpc.CallOption) (*RegisterSecretResponse, error)
+	// ListSecrets returns names of the secrets registered in a project
+	ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error)
+	// DeleteSecret removes a secret from a project
+	DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error)
+	// RotateSecretKey re-encrypts secrets of all projects still encrypted with a
+	// previous app key using the current one
+	RotateSecretKey(ctx context.Context, in *RotateSecretKeyRequest, opts ...grpc.CallOption) (*RotateSecretKeyResponse, error)
 	// ListProjects returns list of registered projects and configurations
 	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	// GetProject fetches a registered project by name
+	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error)
+	// CloneProject copies config, namespaces, jobs and resource specs of a project
+	// into a new project, secrets are not copied. Resources are not created in the
+	// datastore until the new project deploys them, nothing is kept if clone fails
+	CloneProject(ctx context.Context, in *CloneProjectRequest, opts ...grpc.CallOption) (*CloneProjectResponse, error)
+	// ExportProject streams project config, namespaces, jobs and resources of a project
+	// as a self-contained bundle, secrets are listed by name without values
+	ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (RuntimeService_ExportProjectClient, error)
+	// ImportProject applies a project bundle, as produced by ExportProject, after validating
+	// all of it. Jobs and resources created by a failed import are deleted again and the
+	// project is restored, deploys of the project are rejected while it is imported
+	ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (RuntimeService_ImportProjectClient, error)
 	// ListProjectNamespaces returns list of namespaces of a project
 	ListProjectNamespaces(ctx context.Context, in *ListProjectNamespacesRequest, opts ...grpc.CallOption) (*ListProjectNamespacesResponse, error)
 	// RegisterInstance is an internal admin command used during task/hook execution
 	// to pull task/hook compiled configuration and assets.
 	RegisterInstance(ctx context.Context, in *RegisterInstanceRequest, opts ...grpc.CallOption) (*RegisterInstanceResponse, error)
+	// GetInstance fetches an instance registered earlier using RegisterInstance with
+	// its compiled configuration and assets, used when a task/hook is retried
+	GetInstance(ctx context.Context, in *GetInstanceRequest, opts ...grpc.CallOption) (*GetInstanceResponse, error)
 	// JobStatus returns the current and past run status of jobs
 	JobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusResponse, error)
+	// GetJobRunHistory returns paginated runs of a job scheduled within a window
+	// along with aggregated stats of all the runs in that window
+	GetJobRunHistory(ctx context.Context, in *GetJobRunHistoryRequest, opts ...grpc.CallOption) (*GetJobRunHistoryResponse, error)
+	// RunJob asks the scheduler of the project to run a job for a scheduled time,
+	// an existing run of that time is cleared and runs again
+	RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error)
+	// GetJobRunLogs streams logs of every task attempt of a job run as reported by
+	// the scheduler of project
+	GetJobRunLogs(ctx context.Context, in *GetJobRunLogsRequest, opts ...grpc.CallOption) (RuntimeService_GetJobRunLogsClient, error)
+	// PreviewSchedule returns the run times a job schedule interval would produce
+	PreviewSchedule(ctx context.Context, in *PreviewScheduleRequest, opts ...grpc.CallOption) (*PreviewScheduleResponse, error)
+	// ListDownstreamJobs returns jobs that depend on the provided job
+	ListDownstreamJobs(ctx context.Context, in *ListDownstreamJobsRequest, opts ...grpc.CallOption) (*ListDownstreamJobsResponse, error)
+	// ExplainDependency tells which jobs were considered as dependency for
+	// every destination used by a job and why they were picked or not
+	ExplainDependency(ctx context.Context, in *ExplainDependencyRequest, opts ...grpc.CallOption) (*ExplainDependencyResponse, error)
+	// GetJobDependencyGraph returns resolved dependencies between jobs of a
+	// project, or only the upstream and downstream of a job
+	GetJobDependencyGraph(ctx context.Context, in *GetJobDependencyGraphRequest, opts ...grpc.CallOption) (*GetJobDependencyGraphResponse, error)
 	// RegisterJobEvent notifies optimus service about an event related to job
 	RegisterJobEvent(ctx context.Context, in *RegisterJobEventRequest, opts ...grpc.CallOption) (*RegisterJobEventResponse, error)
 	// GetWindow provides the start and end dates provided a scheduled date
@@ -64,14 +130,40 @@ type RuntimeServiceClient interface {
 	// DeployResourceSpecification migrate all resource specifications of a datastore in project
 	// State of the world request
 	DeployResourceSpecification(ctx context.Context, in *DeployResourceSpecificationRequest, opts ...grpc.CallOption) (RuntimeService_DeployResourceSpecificationClient, error)
+	// ValidateResourceSpecification checks resource specifications against their datastore
+	// without creating or updating any of them, result of each resource is streamed
+	ValidateResourceSpecification(ctx context.Context, in *ValidateResourceSpecificationRequest, opts ...grpc.CallOption) (RuntimeService_ValidateResourceSpecificationClient, error)
 	// ListResourceSpecification lists all resource specifications of a datastore in project
 	ListResourceSpecification(ctx context.Context, in *ListResourceSpecificationRequest, opts ...grpc.CallOption) (*ListResourceSpecificationResponse, error)
 	// Datastore CRUD
 	CreateResource(ctx context.Context, in *CreateResourceRequest, opts ...grpc.CallOption) (*CreateResourceResponse, error)
 	ReadResource(ctx context.Context, in *ReadResourceRequest, opts ...grpc.CallOption) (*ReadResourceResponse, error)
+	// ReadResources streams multiple resources in a single call, failure of a
+	// resource is reported in its response without failing the rest
+	ReadResources(ctx context.Context, in *ReadResourcesRequest, opts ...grpc.CallOption) (RuntimeService_ReadResourcesClient, error)
 	UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*UpdateResourceResponse, error)
+	// RenameResource moves a resource to a new name in datastore and updates its spec
+	RenameResource(ctx context.Context, in *RenameResourceRequest, opts ...grpc.CallOption) (*RenameResourceResponse, error)
+	// DeleteResource removes stored spec of a resource and optionally the resource
+	// from datastore as well
+	DeleteResource(ctx context.Context, in *DeleteResourceRequest, opts ...grpc.CallOption) (*DeleteResourceResponse, error)
+	// BackupResource copies a resource along with its data in datastore and records
+	// the backup so resource can be restored later
+	BackupResource(ctx context.Context, in *BackupResourceRequest, opts ...grpc.CallOption) (*BackupResourceResponse, error)
+	// RestoreResource overwrites a resource with its backup and restores its spec
+	RestoreResource(ctx context.Context, in *RestoreResourceRequest, opts ...grpc.CallOption) (*RestoreResourceResponse, error)
+	// ListResourceBackups lists backups taken of resources of a datastore
+	ListResourceBackups(ctx context.Context, in *ListResourceBackupsRequest, opts ...grpc.CallOption) (*ListResourceBackupsResponse, error)
 	ReplayDryRun(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayDryRunResponse, error)
 	Replay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error)
+	// GetReplayPlan previews the runs a backfill of the job between two dates would
+	// execute, without scheduling anything
+	GetReplayPlan(ctx context.Context, in *GetReplayPlanRequest, opts ...grpc.CallOption) (*GetReplayPlanResponse, error)
+	// CheckDatastore verifies if a datastore is reachable using credentials
+	// registered for the project
+	CheckDatastore(ctx context.Context, in *CheckDatastoreRequest, opts ...grpc.CallOption) (*CheckDatastoreResponse, error)
+	// ListDatastores returns datastores supported by server with their resource types
+	ListDatastores(ctx context.Context, in *ListDatastoresRequest, opts ...grpc.CallOption) (*ListDatastoresResponse, error)
 }
 
 type runtimeServiceClient struct {
@@ -123,6 +215,65 @@ func (x *runtimeServiceDeployJobSpecificationClient) Recv() (*DeployJobSpecifica
 	return m, nil
 }
 
+func (c *runtimeServiceClient) CancelDeploy(ctx context.Context, in *CancelDeployRequest, opts ...grpc.CallOption) (*CancelDeployResponse, error) {
+	out := new(CancelDeployResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CancelDeploy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) QueueJobDeployment(ctx context.Context, in *DeployJobSpecificationRequest, opts ...grpc.CallOption) (*QueueJobDeploymentResponse, error) {
+	out := new(QueueJobDeploymentResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/QueueJobDeployment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) GetDeploymentStatus(ctx context.Context, in *GetDeploymentStatusRequest, opts ...grpc.CallOption) (*GetDeploymentStatusResponse, error) {
+	out := new(GetDeploymentStatusResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetDeploymentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) StreamDeploymentEvents(ctx context.Context, in *StreamDeploymentEventsRequest, opts ...grpc.CallOption) (RuntimeService_StreamDeploymentEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[1], "/odpf.optimus.RuntimeService/StreamDeploymentEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeServiceStreamDeploymentEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RuntimeService_StreamDeploymentEventsClient interface {
+	Recv() (*DeployJobSpecificationResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceStreamDeploymentEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceStreamDeploymentEventsClient) Recv() (*DeployJobSpecificationResponse, error) {
+	m := new(DeployJobSpecificationResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *runtimeServiceClient) CreateJobSpecification(ctx context.Context, in *CreateJobSpecificationRequest, opts ...grpc.CallOption) (*CreateJobSpecificationResponse, error) {
 	out := new(CreateJobSpecificationResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CreateJobSpecification", in, out, opts...)
@@ -141,6 +292,15 @@ func (c *runtimeServiceClient) ReadJobSpecification(ctx context.Context, in *Rea
 	return out, nil
 }
 
+func (c *runtimeServiceClient) PatchJobSpecification(ctx context.Context, in *PatchJobSpecificationRequest, opts ...grpc.CallOption) (*PatchJobSpecificationResponse, error) {
+	out := new(PatchJobSpecificationResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/PatchJobSpecification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) DeleteJobSpecification(ctx context.Context, in *DeleteJobSpecificationRequest, opts ...grpc.CallOption) (*DeleteJobSpecificationResponse, error) {
 	out := new(DeleteJobSpecificationResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/DeleteJobSpecification", in, out, opts...)
@@ -150,6 +310,24 @@ func (c *runtimeServiceClient) DeleteJobSpecification(ctx context.Context, in *D
 	return out, nil
 }
 
+func (c *runtimeServiceClient) GetJobSpecHistory(ctx context.Context, in *GetJobSpecHistoryRequest, opts ...grpc.CallOption) (*GetJobSpecHistoryResponse, error) {
+	out := new(GetJobSpecHistoryResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetJobSpecHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RollbackJobSpecification(ctx context.Context, in *RollbackJobSpecificationRequest, opts ...grpc.CallOption) (*RollbackJobSpecificationResponse, error) {
+	out := new(RollbackJobSpecificationResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RollbackJobSpecification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) ListJobSpecification(ctx context.Context, in *ListJobSpecificationRequest, opts ...grpc.CallOption) (*ListJobSpecificationResponse, error) {
 	out := new(ListJobSpecificationResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListJobSpecification", in, out, opts...)
@@ -178,7 +356,7 @@ func (c *runtimeServiceClient) CheckJobSpecification(ctx context.Context, in *Ch
 }
 
 func (c *runtimeServiceClient) CheckJobSpecifications(ctx context.Context, in *CheckJobSpecificationsRequest, opts ...grpc.CallOption) (RuntimeService_CheckJobSpecificationsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[1], "/odpf.optimus.RuntimeService/CheckJobSpecifications", opts...)
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[2], "/odpf.optimus.RuntimeService/CheckJobSpecifications", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -218,6 +396,24 @@ func (c *runtimeServiceClient) RegisterProject(ctx context.Context, in *Register
 	return out, nil
 }
 
+func (c *runtimeServiceClient) UpdateProjectConfig(ctx context.Context, in *UpdateProjectConfigRequest, opts ...grpc.CallOption) (*UpdateProjectConfigResponse, error) {
+	out := new(UpdateProjectConfigResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/UpdateProjectConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) RegisterProjectNamespace(ctx context.Context, in *RegisterProjectNamespaceRequest, opts ...grpc.CallOption) (*RegisterProjectNamespaceResponse, error) {
 	out := new(RegisterProjectNamespaceResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RegisterProjectNamespace", in, out, opts...)
@@ -236,6 +432,33 @@ func (c *runtimeServiceClient) RegisterSecret(ctx context.Context, in *RegisterS
 	return out, nil
 }
 
+func (c *runtimeServiceClient) ListSecrets(ctx context.Context, in *ListSecretsRequest, opts ...grpc.CallOption) (*ListSecretsResponse, error) {
+	out := new(ListSecretsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListSecrets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) DeleteSecret(ctx context.Context, in *DeleteSecretRequest, opts ...grpc.CallOption) (*DeleteSecretResponse, error) {
+	out := new(DeleteSecretResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/DeleteSecret", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RotateSecretKey(ctx context.Context, in *RotateSecretKeyRequest, opts ...grpc.CallOption) (*RotateSecretKeyResponse, error) {
+	out := new(RotateSecretKeyResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RotateSecretKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
 	out := new(ListProjectsResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListProjects", in, out, opts...)
@@ -245,6 +468,88 @@ func (c *runtimeServiceClient) ListProjects(ctx context.Context, in *ListProject
 	return out, nil
 }
 
+func (c *runtimeServiceClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*GetProjectResponse, error) {
+	out := new(GetProjectResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) CloneProject(ctx context.Context, in *CloneProjectRequest, opts ...grpc.CallOption) (*CloneProjectResponse, error) {
+	out := new(CloneProjectResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CloneProject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ExportProject(ctx context.Context, in *ExportProjectRequest, opts ...grpc.CallOption) (RuntimeService_ExportProjectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[3], "/odpf.optimus.RuntimeService/ExportProject", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeServiceExportProjectClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RuntimeService_ExportProjectClient interface {
+	Recv() (*ExportProjectResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceExportProjectClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceExportProjectClient) Recv() (*ExportProjectResponse, error) {
+	m := new(ExportProjectResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeServiceClient) ImportProject(ctx context.Context, in *ImportProjectRequest, opts ...grpc.CallOption) (RuntimeService_ImportProjectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[4], "/odpf.optimus.RuntimeService/ImportProject", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeServiceImportProjectClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RuntimeService_ImportProjectClient interface {
+	Recv() (*ImportProjectResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceImportProjectClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceImportProjectClient) Recv() (*ImportProjectResponse, error) {
+	m := new(ImportProjectResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *runtimeServiceClient) ListProjectNamespaces(ctx context.Context, in *ListProjectNamespacesRequest, opts ...grpc.CallOption) (*ListProjectNamespacesResponse, error) {
 	out := new(ListProjectNamespacesResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListProjectNamespaces", in, out, opts...)
@@ -263,6 +568,15 @@ func (c *runtimeServiceClient) RegisterInstance(ctx context.Context, in *Registe
 	return out, nil
 }
 
+func (c *runtimeServiceClient) GetInstance(ctx context.Context, in *GetInstanceRequest, opts ...grpc.CallOption) (*GetInstanceResponse, error) {
+	out := new(GetInstanceResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) JobStatus(ctx context.Context, in *JobStatusRequest, opts ...grpc.CallOption) (*JobStatusResponse, error) {
 	out := new(JobStatusResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/JobStatus", in, out, opts...)
@@ -272,6 +586,92 @@ func (c *runtimeServiceClient) JobStatus(ctx context.Context, in *JobStatusReque
 	return out, nil
 }
 
+func (c *runtimeServiceClient) GetJobRunHistory(ctx context.Context, in *GetJobRunHistoryRequest, opts ...grpc.CallOption) (*GetJobRunHistoryResponse, error) {
+	out := new(GetJobRunHistoryResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetJobRunHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error) {
+	out := new(RunJobResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RunJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) GetJobRunLogs(ctx context.Context, in *GetJobRunLogsRequest, opts ...grpc.CallOption) (RuntimeService_GetJobRunLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[5], "/odpf.optimus.RuntimeService/GetJobRunLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeServiceGetJobRunLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RuntimeService_GetJobRunLogsClient interface {
+	Recv() (*GetJobRunLogsResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceGetJobRunLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceGetJobRunLogsClient) Recv() (*GetJobRunLogsResponse, error) {
+	m := new(GetJobRunLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeServiceClient) PreviewSchedule(ctx context.Context, in *PreviewScheduleRequest, opts ...grpc.CallOption) (*PreviewScheduleResponse, error) {
+	out := new(PreviewScheduleResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/PreviewSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListDownstreamJobs(ctx context.Context, in *ListDownstreamJobsRequest, opts ...grpc.CallOption) (*ListDownstreamJobsResponse, error) {
+	out := new(ListDownstreamJobsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListDownstreamJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ExplainDependency(ctx context.Context, in *ExplainDependencyRequest, opts ...grpc.CallOption) (*ExplainDependencyResponse, error) {
+	out := new(ExplainDependencyResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ExplainDependency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) GetJobDependencyGraph(ctx context.Context, in *GetJobDependencyGraphRequest, opts ...grpc.CallOption) (*GetJobDependencyGraphResponse, error) {
+	out := new(GetJobDependencyGraphResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetJobDependencyGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) RegisterJobEvent(ctx context.Context, in *RegisterJobEventRequest, opts ...grpc.CallOption) (*RegisterJobEventResponse, error) {
 	out := new(RegisterJobEventResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RegisterJobEvent", in, out, opts...)
@@ -291,7 +691,7 @@ func (c *runtimeServiceClient) GetWindow(ctx context.Context, in *GetWindowReque
 }
 
 func (c *runtimeServiceClient) DeployResourceSpecification(ctx context.Context, in *DeployResourceSpecificationRequest, opts ...grpc.CallOption) (RuntimeService_DeployResourceSpecificationClient, error) {
-	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[2], "/odpf.optimus.RuntimeService/DeployResourceSpecification", opts...)
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[6], "/odpf.optimus.RuntimeService/DeployResourceSpecification", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -322,6 +722,38 @@ func (x *runtimeServiceDeployResourceSpecificationClient) Recv() (*DeployResourc
 	return m, nil
 }
 
+func (c *runtimeServiceClient) ValidateResourceSpecification(ctx context.Context, in *ValidateResourceSpecificationRequest, opts ...grpc.CallOption) (RuntimeService_ValidateResourceSpecificationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[7], "/odpf.optimus.RuntimeService/ValidateResourceSpecification", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &runtimeServiceValidateResourceSpecificationClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RuntimeService_ValidateResourceSpecificationClient interface {
+	Recv() (*ValidateResourceSpecificationResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceValidateResourceSpecificationClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceValidateResourceSpecificationClient) Recv() (*ValidateResourceSpecificationResponse, error) {
+	m := new(ValidateResourceSpecificationResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *runtimeServiceClient) ListResourceSpecification(ctx context.Context, in *ListResourceSpecificationRequest, opts ...grpc.CallOption) (*ListResourceSpecificationResponse, error) {
 	out := new(ListResourceSpecificationResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListResourceSpecification", in, out, opts...)
@@ -349,24 +781,101 @@ func (c *runtimeServiceClient) ReadResource(ctx context.Context, in *ReadResourc
 	return out, nil
 }
 
-func (c *runtimeServiceClient) UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*UpdateResourceResponse, error) {
-	out := new(UpdateResourceResponse)
-	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/UpdateResource", in, out, opts...)
+func (c *runtimeServiceClient) ReadResources(ctx context.Context, in *ReadResourcesRequest, opts ...grpc.CallOption) (RuntimeService_ReadResourcesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RuntimeService_ServiceDesc.Streams[8], "/odpf.optimus.RuntimeService/ReadResources", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *runtimeServiceClient) ReplayDryRun(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayDryRunResponse, error) {
-	out := new(ReplayDryRunResponse)
-	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ReplayDryRun", in, out, opts...)
-	if err != nil {
+	x := &runtimeServiceReadResourcesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RuntimeService_ReadResourcesClient interface {
+	Recv() (*ReadResourcesResponse, error)
+	grpc.ClientStream
+}
+
+type runtimeServiceReadResourcesClient struct {
+	grpc.ClientStream
+}
+
+func (x *runtimeServiceReadResourcesClient) Recv() (*ReadResourcesResponse, error) {
+	m := new(ReadResourcesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *runtimeServiceClient) UpdateResource(ctx context.Context, in *UpdateResourceRequest, opts ...grpc.CallOption) (*UpdateResourceResponse, error) {
+	out := new(UpdateResourceResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/UpdateResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RenameResource(ctx context.Context, in *RenameResourceRequest, opts ...grpc.CallOption) (*RenameResourceResponse, error) {
+	out := new(RenameResourceResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RenameResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) DeleteResource(ctx context.Context, in *DeleteResourceRequest, opts ...grpc.CallOption) (*DeleteResourceResponse, error) {
+	out := new(DeleteResourceResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/DeleteResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) BackupResource(ctx context.Context, in *BackupResourceRequest, opts ...grpc.CallOption) (*BackupResourceResponse, error) {
+	out := new(BackupResourceResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/BackupResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) RestoreResource(ctx context.Context, in *RestoreResourceRequest, opts ...grpc.CallOption) (*RestoreResourceResponse, error) {
+	out := new(RestoreResourceResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/RestoreResource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListResourceBackups(ctx context.Context, in *ListResourceBackupsRequest, opts ...grpc.CallOption) (*ListResourceBackupsResponse, error) {
+	out := new(ListResourceBackupsResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListResourceBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ReplayDryRun(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayDryRunResponse, error) {
+	out := new(ReplayDryRunResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ReplayDryRun", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *runtimeServiceClient) Replay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (*ReplayResponse, error) {
 	out := new(ReplayResponse)
 	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/Replay", in, out, opts...)
@@ -376,6 +885,33 @@ func (c *runtimeServiceClient) Replay(ctx context.Context, in *ReplayRequest, op
 	return out, nil
 }
 
+func (c *runtimeServiceClient) GetReplayPlan(ctx context.Context, in *GetReplayPlanRequest, opts ...grpc.CallOption) (*GetReplayPlanResponse, error) {
+	out := new(GetReplayPlanResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/GetReplayPlan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) CheckDatastore(ctx context.Context, in *CheckDatastoreRequest, opts ...grpc.CallOption) (*CheckDatastoreResponse, error) {
+	out := new(CheckDatastoreResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/CheckDatastore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *runtimeServiceClient) ListDatastores(ctx context.Context, in *ListDatastoresRequest, opts ...grpc.CallOption) (*ListDatastoresResponse, error) {
+	out := new(ListDatastoresResponse)
+	err := c.cc.Invoke(ctx, "/odpf.optimus.RuntimeService/ListDatastores", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RuntimeServiceServer is the server API for RuntimeService service.
 // All implementations must embed UnimplementedRuntimeServiceServer
 // for forward compatibility
@@ -388,12 +924,31 @@ type RuntimeServiceServer interface {
 	// events
 	// State of the world request
 	DeployJobSpecification(*DeployJobSpecificationRequest, RuntimeService_DeployJobSpecificationServer) error
+	// CancelDeploy stops a running job deployment started with DeployJobSpecification
+	CancelDeploy(context.Context, *CancelDeployRequest) (*CancelDeployResponse, error)
+	// QueueJobDeployment accepts the same request as DeployJobSpecification but
+	// returns as soon as the deployment is queued, its progress can be followed
+	// with GetDeploymentStatus or StreamDeploymentEvents. The queue is kept in
+	// memory of the server, queued deployments are lost on restart and are only
+	// known to the replica that accepted them
+	QueueJobDeployment(context.Context, *DeployJobSpecificationRequest) (*QueueJobDeploymentResponse, error)
+	// GetDeploymentStatus returns the state of a queued deployment
+	GetDeploymentStatus(context.Context, *GetDeploymentStatusRequest) (*GetDeploymentStatusResponse, error)
+	// StreamDeploymentEvents streams progress of a queued deployment from its
+	// start until it finishes
+	StreamDeploymentEvents(*StreamDeploymentEventsRequest, RuntimeService_StreamDeploymentEventsServer) error
 	// CreateJobSpecification registers a new job for a namespace which belongs to a project
 	CreateJobSpecification(context.Context, *CreateJobSpecificationRequest) (*CreateJobSpecificationResponse, error)
 	// ReadJobSpecification reads a provided job spec of a namespace
 	ReadJobSpecification(context.Context, *ReadJobSpecificationRequest) (*ReadJobSpecificationResponse, error)
+	// PatchJobSpecification changes only the listed fields of a job spec and deploys it
+	PatchJobSpecification(context.Context, *PatchJobSpecificationRequest) (*PatchJobSpecificationResponse, error)
 	// DeleteJobSpecification deletes a job spec of a namespace
 	DeleteJobSpecification(context.Context, *DeleteJobSpecificationRequest) (*DeleteJobSpecificationResponse, error)
+	// GetJobSpecHistory lists every saved revision of a job specification
+	GetJobSpecHistory(context.Context, *GetJobSpecHistoryRequest) (*GetJobSpecHistoryResponse, error)
+	// RollbackJobSpecification restores a job to an earlier revision and deploys it
+	RollbackJobSpecification(context.Context, *RollbackJobSpecificationRequest) (*RollbackJobSpecificationResponse, error)
 	// ListJobSpecification returns list of jobs created in a project
 	ListJobSpecification(context.Context, *ListJobSpecificationRequest) (*ListJobSpecificationResponse, error)
 	// DumpJobSpecification returns compiled representation of the job in a scheduler
@@ -405,19 +960,66 @@ type RuntimeServiceServer interface {
 	CheckJobSpecifications(*CheckJobSpecificationsRequest, RuntimeService_CheckJobSpecificationsServer) error
 	// RegisterProject creates a new optimus project
 	RegisterProject(context.Context, *RegisterProjectRequest) (*RegisterProjectResponse, error)
+	// UpdateProjectConfig adds, overrides and removes configs of a project
+	// without registering it again
+	UpdateProjectConfig(context.Context, *UpdateProjectConfigRequest) (*UpdateProjectConfigResponse, error)
+	// ListAuditEvents returns requests which changed state of optimus
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
 	// RegisterProjectNamespace creates a new namespace for a project
 	RegisterProjectNamespace(context.Context, *RegisterProjectNamespaceRequest) (*RegisterProjectNamespaceResponse, error)
 	// RegisterSecret creates a new secret of a project
 	RegisterSecret(context.Context, *RegisterSecretRequest) (*RegisterSecretResponse, error)
+	// ListSecrets returns names of the secrets registered in a project
+	ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error)
+	// DeleteSecret removes a secret from a project
+	DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error)
+	// RotateSecretKey re-encrypts secrets of all projects still encrypted with a
+	// previous app key using the current one
+	RotateSecretKey(context.Context, *RotateSecretKeyRequest) (*RotateSecretKeyResponse, error)
 	// ListProjects returns list of registered projects and configurations
 	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	// GetProject fetches a registered project by name
+	GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error)
+	// CloneProject copies config, namespaces, jobs and resource specs of a project
+	// into a new project, secrets are not copied. Resources are not created in the
+	// datastore until the new project deploys them, nothing is kept if clone fails
+	CloneProject(context.Context, *CloneProjectRequest) (*CloneProjectResponse, error)
+	// ExportProject streams project config, namespaces, jobs and resources of a project
+	// as a self-contained bundle, secrets are listed by name without values
+	ExportProject(*ExportProjectRequest, RuntimeService_ExportProjectServer) error
+	// ImportProject applies a project bundle, as produced by ExportProject, after validating
+	// all of it. Jobs and resources created by a failed import are deleted again and the
+	// project is restored, deploys of the project are rejected while it is imported
+	ImportProject(*ImportProjectRequest, RuntimeService_ImportProjectServer) error
 	// ListProjectNamespaces returns list of namespaces of a project
 	ListProjectNamespaces(context.Context, *ListProjectNamespacesRequest) (*ListProjectNamespacesResponse, error)
 	// RegisterInstance is an internal admin command used during task/hook execution
 	// to pull task/hook compiled configuration and assets.
 	RegisterInstance(context.Context, *RegisterInstanceRequest) (*RegisterInstanceResponse, error)
+	// GetInstance fetches an instance registered earlier using RegisterInstance with
+	// its compiled configuration and assets, used when a task/hook is retried
+	GetInstance(context.Context, *GetInstanceRequest) (*GetInstanceResponse, error)
 	// JobStatus returns the current and past run status of jobs
 	JobStatus(context.Context, *JobStatusRequest) (*JobStatusResponse, error)
+	// GetJobRunHistory returns paginated runs of a job scheduled within a window
+	// along with aggregated stats of all the runs in that window
+	GetJobRunHistory(context.Context, *GetJobRunHistoryRequest) (*GetJobRunHistoryResponse, error)
+	// RunJob asks the scheduler of the project to run a job for a scheduled time,
+	// an existing run of that time is cleared and runs again
+	RunJob(context.Context, *RunJobRequest) (*RunJobResponse, error)
+	// GetJobRunLogs streams logs of every task attempt of a job run as reported by
+	// the scheduler of project
+	GetJobRunLogs(*GetJobRunLogsRequest, RuntimeService_GetJobRunLogsServer) error
+	// PreviewSchedule returns the run times a job schedule interval would produce
+	PreviewSchedule(context.Context, *PreviewScheduleRequest) (*PreviewScheduleResponse, error)
+	// ListDownstreamJobs returns jobs that depend on the provided job
+	ListDownstreamJobs(context.Context, *ListDownstreamJobsRequest) (*ListDownstreamJobsResponse, error)
+	// ExplainDependency tells which jobs were considered as dependency for
+	// every destination used by a job and why they were picked or not
+	ExplainDependency(context.Context, *ExplainDependencyRequest) (*ExplainDependencyResponse, error)
+	// GetJobDependencyGraph returns resolved dependencies between jobs of a
+	// project, or only the upstream and downstream of a job
+	GetJobDependencyGraph(context.Context, *GetJobDependencyGraphRequest) (*GetJobDependencyGraphResponse, error)
 	// RegisterJobEvent notifies optimus service about an event related to job
 	RegisterJobEvent(context.Context, *RegisterJobEventRequest) (*RegisterJobEventResponse, error)
 	// GetWindow provides the start and end dates provided a scheduled date
@@ -426,14 +1028,40 @@ type RuntimeServiceServer interface {
 	// DeployResourceSpecification migrate all resource specifications of a datastore in project
 	// State of the world request
 	DeployResourceSpecification(*DeployResourceSpecificationRequest, RuntimeService_DeployResourceSpecificationServer) error
+	// ValidateResourceSpecification checks resource specifications against their datastore
+	// without creating or updating any of them, result of each resource is streamed
+	ValidateResourceSpecification(*ValidateResourceSpecificationRequest, RuntimeService_ValidateResourceSpecificationServer) error
 	// ListResourceSpecification lists all resource specifications of a datastore in project
 	ListResourceSpecification(context.Context, *ListResourceSpecificationRequest) (*ListResourceSpecificationResponse, error)
 	// Datastore CRUD
 	CreateResource(context.Context, *CreateResourceRequest) (*CreateResourceResponse, error)
 	ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResponse, error)
+	// ReadResources streams multiple resources in a single call, failure of a
+	// resource is reported in its response without failing the rest
+	ReadResources(*ReadResourcesRequest, RuntimeService_ReadResourcesServer) error
 	UpdateResource(context.Context, *UpdateResourceRequest) (*UpdateResourceResponse, error)
+	// RenameResource moves a resource to a new name in datastore and updates its spec
+	RenameResource(context.Context, *RenameResourceRequest) (*RenameResourceResponse, error)
+	// DeleteResource removes stored spec of a resource and optionally the resource
+	// from datastore as well
+	DeleteResource(context.Context, *DeleteResourceRequest) (*DeleteResourceResponse, error)
+	// BackupResource copies a resource along with its data in datastore and records
+	// the backup so resource can be restored later
+	BackupResource(context.Context, *BackupResourceRequest) (*BackupResourceResponse, error)
+	// RestoreResource overwrites a resource with its backup and restores its spec
+	RestoreResource(context.Context, *RestoreResourceRequest) (*RestoreResourceResponse, error)
+	// ListResourceBackups lists backups taken of resources of a datastore
+	ListResourceBackups(context.Context, *ListResourceBackupsRequest) (*ListResourceBackupsResponse, error)
 	ReplayDryRun(context.Context, *ReplayRequest) (*ReplayDryRunResponse, error)
 	Replay(context.Context, *ReplayRequest) (*ReplayResponse, error)
+	// GetReplayPlan previews the runs a backfill of the job between two dates would
+	// execute, without scheduling anything
+	GetReplayPlan(context.Context, *GetReplayPlanRequest) (*GetReplayPlanResponse, error)
+	// CheckDatastore verifies if a datastore is reachable using credentials
+	// registered for the project
+	CheckDatastore(context.Context, *CheckDatastoreRequest) (*CheckDatastoreResponse, error)
+	// ListDatastores returns datastores supported by server with their resource types
+	ListDatastores(context.Context, *ListDatastoresRequest) (*ListDatastoresResponse, error)
 	mustEmbedUnimplementedRuntimeServiceServer()
 }
 
@@ -447,15 +1075,36 @@ func (UnimplementedRuntimeServiceServer) Version(context.Context, *VersionReques
 func (UnimplementedRuntimeServiceServer) DeployJobSpecification(*DeployJobSpecificationRequest, RuntimeService_DeployJobSpecificationServer) error {
 	return status.Errorf(codes.Unimplemented, "method DeployJobSpecification not implemented")
 }
+func (UnimplementedRuntimeServiceServer) CancelDeploy(context.Context, *CancelDeployRequest) (*CancelDeployResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelDeploy not implemented")
+}
+func (UnimplementedRuntimeServiceServer) QueueJobDeployment(context.Context, *DeployJobSpecificationRequest) (*QueueJobDeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueueJobDeployment not implemented")
+}
+func (UnimplementedRuntimeServiceServer) GetDeploymentStatus(context.Context, *GetDeploymentStatusRequest) (*GetDeploymentStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeploymentStatus not implemented")
+}
+func (UnimplementedRuntimeServiceServer) StreamDeploymentEvents(*StreamDeploymentEventsRequest, RuntimeService_StreamDeploymentEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamDeploymentEvents not implemented")
+}
 func (UnimplementedRuntimeServiceServer) CreateJobSpecification(context.Context, *CreateJobSpecificationRequest) (*CreateJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateJobSpecification not implemented")
 }
 func (UnimplementedRuntimeServiceServer) ReadJobSpecification(context.Context, *ReadJobSpecificationRequest) (*ReadJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadJobSpecification not implemented")
 }
+func (UnimplementedRuntimeServiceServer) PatchJobSpecification(context.Context, *PatchJobSpecificationRequest) (*PatchJobSpecificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchJobSpecification not implemented")
+}
 func (UnimplementedRuntimeServiceServer) DeleteJobSpecification(context.Context, *DeleteJobSpecificationRequest) (*DeleteJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteJobSpecification not implemented")
 }
+func (UnimplementedRuntimeServiceServer) GetJobSpecHistory(context.Context, *GetJobSpecHistoryRequest) (*GetJobSpecHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobSpecHistory not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RollbackJobSpecification(context.Context, *RollbackJobSpecificationRequest) (*RollbackJobSpecificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackJobSpecification not implemented")
+}
 func (UnimplementedRuntimeServiceServer) ListJobSpecification(context.Context, *ListJobSpecificationRequest) (*ListJobSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListJobSpecification not implemented")
 }
@@ -471,24 +1120,75 @@ func (UnimplementedRuntimeServiceServer) CheckJobSpecifications(*CheckJobSpecifi
 func (UnimplementedRuntimeServiceServer) RegisterProject(context.Context, *RegisterProjectRequest) (*RegisterProjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterProject not implemented")
 }
+func (UnimplementedRuntimeServiceServer) UpdateProjectConfig(context.Context, *UpdateProjectConfigRequest) (*UpdateProjectConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProjectConfig not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedRuntimeServiceServer) RegisterProjectNamespace(context.Context, *RegisterProjectNamespaceRequest) (*RegisterProjectNamespaceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterProjectNamespace not implemented")
 }
 func (UnimplementedRuntimeServiceServer) RegisterSecret(context.Context, *RegisterSecretRequest) (*RegisterSecretResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterSecret not implemented")
 }
+func (UnimplementedRuntimeServiceServer) ListSecrets(context.Context, *ListSecretsRequest) (*ListSecretsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSecrets not implemented")
+}
+func (UnimplementedRuntimeServiceServer) DeleteSecret(context.Context, *DeleteSecretRequest) (*DeleteSecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSecret not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RotateSecretKey(context.Context, *RotateSecretKeyRequest) (*RotateSecretKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateSecretKey not implemented")
+}
 func (UnimplementedRuntimeServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
 }
+func (UnimplementedRuntimeServiceServer) GetProject(context.Context, *GetProjectRequest) (*GetProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedRuntimeServiceServer) CloneProject(context.Context, *CloneProjectRequest) (*CloneProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneProject not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ExportProject(*ExportProjectRequest, RuntimeService_ExportProjectServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProject not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ImportProject(*ImportProjectRequest, RuntimeService_ImportProjectServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportProject not implemented")
+}
 func (UnimplementedRuntimeServiceServer) ListProjectNamespaces(context.Context, *ListProjectNamespacesRequest) (*ListProjectNamespacesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProjectNamespaces not implemented")
 }
 func (UnimplementedRuntimeServiceServer) RegisterInstance(context.Context, *RegisterInstanceRequest) (*RegisterInstanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterInstance not implemented")
 }
+func (UnimplementedRuntimeServiceServer) GetInstance(context.Context, *GetInstanceRequest) (*GetInstanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstance not implemented")
+}
 func (UnimplementedRuntimeServiceServer) JobStatus(context.Context, *JobStatusRequest) (*JobStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method JobStatus not implemented")
 }
+func (UnimplementedRuntimeServiceServer) GetJobRunHistory(context.Context, *GetJobRunHistoryRequest) (*GetJobRunHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobRunHistory not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RunJob(context.Context, *RunJobRequest) (*RunJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunJob not implemented")
+}
+func (UnimplementedRuntimeServiceServer) GetJobRunLogs(*GetJobRunLogsRequest, RuntimeService_GetJobRunLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetJobRunLogs not implemented")
+}
+func (UnimplementedRuntimeServiceServer) PreviewSchedule(context.Context, *PreviewScheduleRequest) (*PreviewScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewSchedule not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListDownstreamJobs(context.Context, *ListDownstreamJobsRequest) (*ListDownstreamJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDownstreamJobs not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ExplainDependency(context.Context, *ExplainDependencyRequest) (*ExplainDependencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExplainDependency not implemented")
+}
+func (UnimplementedRuntimeServiceServer) GetJobDependencyGraph(context.Context, *GetJobDependencyGraphRequest) (*GetJobDependencyGraphResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobDependencyGraph not implemented")
+}
 func (UnimplementedRuntimeServiceServer) RegisterJobEvent(context.Context, *RegisterJobEventRequest) (*RegisterJobEventResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterJobEvent not implemented")
 }
@@ -498,6 +1198,9 @@ func (UnimplementedRuntimeServiceServer) GetWindow(context.Context, *GetWindowRe
 func (UnimplementedRuntimeServiceServer) DeployResourceSpecification(*DeployResourceSpecificationRequest, RuntimeService_DeployResourceSpecificationServer) error {
 	return status.Errorf(codes.Unimplemented, "method DeployResourceSpecification not implemented")
 }
+func (UnimplementedRuntimeServiceServer) ValidateResourceSpecification(*ValidateResourceSpecificationRequest, RuntimeService_ValidateResourceSpecificationServer) error {
+	return status.Errorf(codes.Unimplemented, "method ValidateResourceSpecification not implemented")
+}
 func (UnimplementedRuntimeServiceServer) ListResourceSpecification(context.Context, *ListResourceSpecificationRequest) (*ListResourceSpecificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListResourceSpecification not implemented")
 }
@@ -507,15 +1210,42 @@ func (UnimplementedRuntimeServiceServer) CreateResource(context.Context, *Create
 func (UnimplementedRuntimeServiceServer) ReadResource(context.Context, *ReadResourceRequest) (*ReadResourceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadResource not implemented")
 }
+func (UnimplementedRuntimeServiceServer) ReadResources(*ReadResourcesRequest, RuntimeService_ReadResourcesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadResources not implemented")
+}
 func (UnimplementedRuntimeServiceServer) UpdateResource(context.Context, *UpdateResourceRequest) (*UpdateResourceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateResource not implemented")
 }
+func (UnimplementedRuntimeServiceServer) RenameResource(context.Context, *RenameResourceRequest) (*RenameResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameResource not implemented")
+}
+func (UnimplementedRuntimeServiceServer) DeleteResource(context.Context, *DeleteResourceRequest) (*DeleteResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteResource not implemented")
+}
+func (UnimplementedRuntimeServiceServer) BackupResource(context.Context, *BackupResourceRequest) (*BackupResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupResource not implemented")
+}
+func (UnimplementedRuntimeServiceServer) RestoreResource(context.Context, *RestoreResourceRequest) (*RestoreResourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreResource not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListResourceBackups(context.Context, *ListResourceBackupsRequest) (*ListResourceBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListResourceBackups not implemented")
+}
 func (UnimplementedRuntimeServiceServer) ReplayDryRun(context.Context, *ReplayRequest) (*ReplayDryRunResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReplayDryRun not implemented")
 }
 func (UnimplementedRuntimeServiceServer) Replay(context.Context, *ReplayRequest) (*ReplayResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Replay not implemented")
 }
+func (UnimplementedRuntimeServiceServer) GetReplayPlan(context.Context, *GetReplayPlanRequest) (*GetReplayPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplayPlan not implemented")
+}
+func (UnimplementedRuntimeServiceServer) CheckDatastore(context.Context, *CheckDatastoreRequest) (*CheckDatastoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckDatastore not implemented")
+}
+func (UnimplementedRuntimeServiceServer) ListDatastores(context.Context, *ListDatastoresRequest) (*ListDatastoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDatastores not implemented")
+}
 func (UnimplementedRuntimeServiceServer) mustEmbedUnimplementedRuntimeServiceServer() {}
 
 // UnsafeRuntimeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -568,6 +1298,81 @@ func (x *runtimeServiceDeployJobSpecificationServer) Send(m *DeployJobSpecificat
 	return x.ServerStream.SendMsg(m)
 }
 
+func _RuntimeService_CancelDeploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelDeployRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).CancelDeploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/CancelDeploy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).CancelDeploy(ctx, req.(*CancelDeployRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_QueueJobDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeployJobSpecificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).QueueJobDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/QueueJobDeployment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).QueueJobDeployment(ctx, req.(*DeployJobSpecificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_GetDeploymentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeploymentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetDeploymentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetDeploymentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetDeploymentStatus(ctx, req.(*GetDeploymentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_StreamDeploymentEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamDeploymentEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServiceServer).StreamDeploymentEvents(m, &runtimeServiceStreamDeploymentEventsServer{stream})
+}
+
+type RuntimeService_StreamDeploymentEventsServer interface {
+	Send(*DeployJobSpecificationResponse) error
+	grpc.ServerStream
+}
+
+type runtimeServiceStreamDeploymentEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceStreamDeploymentEventsServer) Send(m *DeployJobSpecificationResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _RuntimeService_CreateJobSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateJobSpecificationRequest)
 	if err := dec(in); err != nil {
@@ -604,6 +1409,24 @@ func _RuntimeService_ReadJobSpecification_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_PatchJobSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchJobSpecificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).PatchJobSpecification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/PatchJobSpecification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).PatchJobSpecification(ctx, req.(*PatchJobSpecificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_DeleteJobSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteJobSpecificationRequest)
 	if err := dec(in); err != nil {
@@ -622,6 +1445,42 @@ func _RuntimeService_DeleteJobSpecification_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_GetJobSpecHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobSpecHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetJobSpecHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetJobSpecHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetJobSpecHistory(ctx, req.(*GetJobSpecHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RollbackJobSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackJobSpecificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RollbackJobSpecification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RollbackJobSpecification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RollbackJobSpecification(ctx, req.(*RollbackJobSpecificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_ListJobSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListJobSpecificationRequest)
 	if err := dec(in); err != nil {
@@ -703,122 +1562,437 @@ func _RuntimeService_RegisterProject_Handler(srv interface{}, ctx context.Contex
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).RegisterProject(ctx, in)
+		return srv.(RuntimeServiceServer).RegisterProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RegisterProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RegisterProject(ctx, req.(*RegisterProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_UpdateProjectConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).UpdateProjectConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/UpdateProjectConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).UpdateProjectConfig(ctx, req.(*UpdateProjectConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RegisterProjectNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterProjectNamespaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RegisterProjectNamespace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RegisterProjectNamespace",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RegisterProjectNamespace(ctx, req.(*RegisterProjectNamespaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RegisterSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RegisterSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RegisterSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RegisterSecret(ctx, req.(*RegisterSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListSecrets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSecretsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListSecrets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListSecrets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListSecrets(ctx, req.(*ListSecretsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_DeleteSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).DeleteSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/DeleteSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).DeleteSecret(ctx, req.(*DeleteSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RotateSecretKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateSecretKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RotateSecretKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RotateSecretKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RotateSecretKey(ctx, req.(*RotateSecretKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListProjects",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetProject(ctx, req.(*GetProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_CloneProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).CloneProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/CloneProject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).CloneProject(ctx, req.(*CloneProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ExportProject_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProjectRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServiceServer).ExportProject(m, &runtimeServiceExportProjectServer{stream})
+}
+
+type RuntimeService_ExportProjectServer interface {
+	Send(*ExportProjectResponse) error
+	grpc.ServerStream
+}
+
+type runtimeServiceExportProjectServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceExportProjectServer) Send(m *ExportProjectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RuntimeService_ImportProject_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImportProjectRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServiceServer).ImportProject(m, &runtimeServiceImportProjectServer{stream})
+}
+
+type RuntimeService_ImportProjectServer interface {
+	Send(*ImportProjectResponse) error
+	grpc.ServerStream
+}
+
+type runtimeServiceImportProjectServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceImportProjectServer) Send(m *ImportProjectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RuntimeService_ListProjectNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectNamespacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListProjectNamespaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListProjectNamespaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListProjectNamespaces(ctx, req.(*ListProjectNamespacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RegisterInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RegisterInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RegisterInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RegisterInstance(ctx, req.(*RegisterInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_GetInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetInstance(ctx, req.(*GetInstanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_JobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).JobStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/RegisterProject",
+		FullMethod: "/odpf.optimus.RuntimeService/JobStatus",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).RegisterProject(ctx, req.(*RegisterProjectRequest))
+		return srv.(RuntimeServiceServer).JobStatus(ctx, req.(*JobStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RuntimeService_RegisterProjectNamespace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterProjectNamespaceRequest)
+func _RuntimeService_GetJobRunHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRunHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).RegisterProjectNamespace(ctx, in)
+		return srv.(RuntimeServiceServer).GetJobRunHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/RegisterProjectNamespace",
+		FullMethod: "/odpf.optimus.RuntimeService/GetJobRunHistory",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).RegisterProjectNamespace(ctx, req.(*RegisterProjectNamespaceRequest))
+		return srv.(RuntimeServiceServer).GetJobRunHistory(ctx, req.(*GetJobRunHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RuntimeService_RegisterSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterSecretRequest)
+func _RuntimeService_RunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).RegisterSecret(ctx, in)
+		return srv.(RuntimeServiceServer).RunJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/RegisterSecret",
+		FullMethod: "/odpf.optimus.RuntimeService/RunJob",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).RegisterSecret(ctx, req.(*RegisterSecretRequest))
+		return srv.(RuntimeServiceServer).RunJob(ctx, req.(*RunJobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RuntimeService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListProjectsRequest)
+func _RuntimeService_GetJobRunLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetJobRunLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServiceServer).GetJobRunLogs(m, &runtimeServiceGetJobRunLogsServer{stream})
+}
+
+type RuntimeService_GetJobRunLogsServer interface {
+	Send(*GetJobRunLogsResponse) error
+	grpc.ServerStream
+}
+
+type runtimeServiceGetJobRunLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceGetJobRunLogsServer) Send(m *GetJobRunLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RuntimeService_PreviewSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewScheduleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).ListProjects(ctx, in)
+		return srv.(RuntimeServiceServer).PreviewSchedule(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/ListProjects",
+		FullMethod: "/odpf.optimus.RuntimeService/PreviewSchedule",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+		return srv.(RuntimeServiceServer).PreviewSchedule(ctx, req.(*PreviewScheduleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RuntimeService_ListProjectNamespaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListProjectNamespacesRequest)
+func _RuntimeService_ListDownstreamJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDownstreamJobsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).ListProjectNamespaces(ctx, in)
+		return srv.(RuntimeServiceServer).ListDownstreamJobs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/ListProjectNamespaces",
+		FullMethod: "/odpf.optimus.RuntimeService/ListDownstreamJobs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).ListProjectNamespaces(ctx, req.(*ListProjectNamespacesRequest))
+		return srv.(RuntimeServiceServer).ListDownstreamJobs(ctx, req.(*ListDownstreamJobsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RuntimeService_RegisterInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterInstanceRequest)
+func _RuntimeService_ExplainDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainDependencyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).RegisterInstance(ctx, in)
+		return srv.(RuntimeServiceServer).ExplainDependency(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/RegisterInstance",
+		FullMethod: "/odpf.optimus.RuntimeService/ExplainDependency",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).RegisterInstance(ctx, req.(*RegisterInstanceRequest))
+		return srv.(RuntimeServiceServer).ExplainDependency(ctx, req.(*ExplainDependencyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RuntimeService_JobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JobStatusRequest)
+func _RuntimeService_GetJobDependencyGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobDependencyGraphRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RuntimeServiceServer).JobStatus(ctx, in)
+		return srv.(RuntimeServiceServer).GetJobDependencyGraph(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/odpf.optimus.RuntimeService/JobStatus",
+		FullMethod: "/odpf.optimus.RuntimeService/GetJobDependencyGraph",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RuntimeServiceServer).JobStatus(ctx, req.(*JobStatusRequest))
+		return srv.(RuntimeServiceServer).GetJobDependencyGraph(ctx, req.(*GetJobDependencyGraphRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -880,6 +2054,27 @@ func (x *runtimeServiceDeployResourceSpecificationServer) Send(m *DeployResource
 	return x.ServerStream.SendMsg(m)
 }
 
+func _RuntimeService_ValidateResourceSpecification_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ValidateResourceSpecificationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServiceServer).ValidateResourceSpecification(m, &runtimeServiceValidateResourceSpecificationServer{stream})
+}
+
+type RuntimeService_ValidateResourceSpecificationServer interface {
+	Send(*ValidateResourceSpecificationResponse) error
+	grpc.ServerStream
+}
+
+type runtimeServiceValidateResourceSpecificationServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceValidateResourceSpecificationServer) Send(m *ValidateResourceSpecificationResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _RuntimeService_ListResourceSpecification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListResourceSpecificationRequest)
 	if err := dec(in); err != nil {
@@ -934,6 +2129,27 @@ func _RuntimeService_ReadResource_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_ReadResources_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadResourcesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RuntimeServiceServer).ReadResources(m, &runtimeServiceReadResourcesServer{stream})
+}
+
+type RuntimeService_ReadResourcesServer interface {
+	Send(*ReadResourcesResponse) error
+	grpc.ServerStream
+}
+
+type runtimeServiceReadResourcesServer struct {
+	grpc.ServerStream
+}
+
+func (x *runtimeServiceReadResourcesServer) Send(m *ReadResourcesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _RuntimeService_UpdateResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateResourceRequest)
 	if err := dec(in); err != nil {
@@ -952,6 +2168,96 @@ func _RuntimeService_UpdateResource_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_RenameResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RenameResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RenameResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RenameResource(ctx, req.(*RenameResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_DeleteResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).DeleteResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/DeleteResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).DeleteResource(ctx, req.(*DeleteResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_BackupResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).BackupResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/BackupResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).BackupResource(ctx, req.(*BackupResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_RestoreResource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreResourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).RestoreResource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/RestoreResource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).RestoreResource(ctx, req.(*RestoreResourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListResourceBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListResourceBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListResourceBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListResourceBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListResourceBackups(ctx, req.(*ListResourceBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RuntimeService_ReplayDryRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReplayRequest)
 	if err := dec(in); err != nil {
@@ -988,6 +2294,60 @@ func _RuntimeService_Replay_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RuntimeService_GetReplayPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplayPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).GetReplayPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/GetReplayPlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).GetReplayPlan(ctx, req.(*GetReplayPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_CheckDatastore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDatastoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).CheckDatastore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/CheckDatastore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).CheckDatastore(ctx, req.(*CheckDatastoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RuntimeService_ListDatastores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDatastoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeServiceServer).ListDatastores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/odpf.optimus.RuntimeService/ListDatastores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeServiceServer).ListDatastores(ctx, req.(*ListDatastoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RuntimeService_ServiceDesc is the grpc.ServiceDesc for RuntimeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -999,6 +2359,18 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Version",
 			Handler:    _RuntimeService_Version_Handler,
 		},
+		{
+			MethodName: "CancelDeploy",
+			Handler:    _RuntimeService_CancelDeploy_Handler,
+		},
+		{
+			MethodName: "QueueJobDeployment",
+			Handler:    _RuntimeService_QueueJobDeployment_Handler,
+		},
+		{
+			MethodName: "GetDeploymentStatus",
+			Handler:    _RuntimeService_GetDeploymentStatus_Handler,
+		},
 		{
 			MethodName: "CreateJobSpecification",
 			Handler:    _RuntimeService_CreateJobSpecification_Handler,
@@ -1007,10 +2379,22 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadJobSpecification",
 			Handler:    _RuntimeService_ReadJobSpecification_Handler,
 		},
+		{
+			MethodName: "PatchJobSpecification",
+			Handler:    _RuntimeService_PatchJobSpecification_Handler,
+		},
 		{
 			MethodName: "DeleteJobSpecification",
 			Handler:    _RuntimeService_DeleteJobSpecification_Handler,
 		},
+		{
+			MethodName: "GetJobSpecHistory",
+			Handler:    _RuntimeService_GetJobSpecHistory_Handler,
+		},
+		{
+			MethodName: "RollbackJobSpecification",
+			Handler:    _RuntimeService_RollbackJobSpecification_Handler,
+		},
 		{
 			MethodName: "ListJobSpecification",
 			Handler:    _RuntimeService_ListJobSpecification_Handler,
@@ -1027,6 +2411,14 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterProject",
 			Handler:    _RuntimeService_RegisterProject_Handler,
 		},
+		{
+			MethodName: "UpdateProjectConfig",
+			Handler:    _RuntimeService_UpdateProjectConfig_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _RuntimeService_ListAuditEvents_Handler,
+		},
 		{
 			MethodName: "RegisterProjectNamespace",
 			Handler:    _RuntimeService_RegisterProjectNamespace_Handler,
@@ -1035,10 +2427,30 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterSecret",
 			Handler:    _RuntimeService_RegisterSecret_Handler,
 		},
+		{
+			MethodName: "ListSecrets",
+			Handler:    _RuntimeService_ListSecrets_Handler,
+		},
+		{
+			MethodName: "DeleteSecret",
+			Handler:    _RuntimeService_DeleteSecret_Handler,
+		},
+		{
+			MethodName: "RotateSecretKey",
+			Handler:    _RuntimeService_RotateSecretKey_Handler,
+		},
 		{
 			MethodName: "ListProjects",
 			Handler:    _RuntimeService_ListProjects_Handler,
 		},
+		{
+			MethodName: "GetProject",
+			Handler:    _RuntimeService_GetProject_Handler,
+		},
+		{
+			MethodName: "CloneProject",
+			Handler:    _RuntimeService_CloneProject_Handler,
+		},
 		{
 			MethodName: "ListProjectNamespaces",
 			Handler:    _RuntimeService_ListProjectNamespaces_Handler,
@@ -1047,10 +2459,38 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterInstance",
 			Handler:    _RuntimeService_RegisterInstance_Handler,
 		},
+		{
+			MethodName: "GetInstance",
+			Handler:    _RuntimeService_GetInstance_Handler,
+		},
 		{
 			MethodName: "JobStatus",
 			Handler:    _RuntimeService_JobStatus_Handler,
 		},
+		{
+			MethodName: "GetJobRunHistory",
+			Handler:    _RuntimeService_GetJobRunHistory_Handler,
+		},
+		{
+			MethodName: "RunJob",
+			Handler:    _RuntimeService_RunJob_Handler,
+		},
+		{
+			MethodName: "PreviewSchedule",
+			Handler:    _RuntimeService_PreviewSchedule_Handler,
+		},
+		{
+			MethodName: "ListDownstreamJobs",
+			Handler:    _RuntimeService_ListDownstreamJobs_Handler,
+		},
+		{
+			MethodName: "ExplainDependency",
+			Handler:    _RuntimeService_ExplainDependency_Handler,
+		},
+		{
+			MethodName: "GetJobDependencyGraph",
+			Handler:    _RuntimeService_GetJobDependencyGraph_Handler,
+		},
 		{
 			MethodName: "RegisterJobEvent",
 			Handler:    _RuntimeService_RegisterJobEvent_Handler,
@@ -1075,6 +2515,26 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateResource",
 			Handler:    _RuntimeService_UpdateResource_Handler,
 		},
+		{
+			MethodName: "RenameResource",
+			Handler:    _RuntimeService_RenameResource_Handler,
+		},
+		{
+			MethodName: "DeleteResource",
+			Handler:    _RuntimeService_DeleteResource_Handler,
+		},
+		{
+			MethodName: "BackupResource",
+			Handler:    _RuntimeService_BackupResource_Handler,
+		},
+		{
+			MethodName: "RestoreResource",
+			Handler:    _RuntimeService_RestoreResource_Handler,
+		},
+		{
+			MethodName: "ListResourceBackups",
+			Handler:    _RuntimeService_ListResourceBackups_Handler,
+		},
 		{
 			MethodName: "ReplayDryRun",
 			Handler:    _RuntimeService_ReplayDryRun_Handler,
@@ -1083,6 +2543,18 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Replay",
 			Handler:    _RuntimeService_Replay_Handler,
 		},
+		{
+			MethodName: "GetReplayPlan",
+			Handler:    _RuntimeService_GetReplayPlan_Handler,
+		},
+		{
+			MethodName: "CheckDatastore",
+			Handler:    _RuntimeService_CheckDatastore_Handler,
+		},
+		{
+			MethodName: "ListDatastores",
+			Handler:    _RuntimeService_ListDatastores_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1090,16 +2562,46 @@ var RuntimeService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RuntimeService_DeployJobSpecification_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamDeploymentEvents",
+			Handler:       _RuntimeService_StreamDeploymentEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "CheckJobSpecifications",
 			Handler:       _RuntimeService_CheckJobSpecifications_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportProject",
+			Handler:       _RuntimeService_ExportProject_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportProject",
+			Handler:       _RuntimeService_ImportProject_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetJobRunLogs",
+			Handler:       _RuntimeService_GetJobRunLogs_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "DeployResourceSpecification",
 			Handler:       _RuntimeService_DeployResourceSpecification_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ValidateResourceSpecification",
+			Handler:       _RuntimeService_ValidateResourceSpecification_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadResources",
+			Handler:       _RuntimeService_ReadResources_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "odpf/optimus/runtime_service.proto",
 }
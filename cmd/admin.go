@@ -32,6 +32,7 @@ func adminGetCommand(l logger, taskRepo models.TaskPluginRepository, hookRepo mo
 		Use: "get",
 	}
 	cmd.AddCommand(adminGetStatusCommand(l))
+	cmd.AddCommand(adminGetLogsCommand(l))
 	cmd.AddCommand(adminGetPluginsCommand(l, taskRepo, hookRepo))
 	return cmd
 }
@@ -9,18 +9,24 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
+	v1handler "github.com/odpf/optimus/api/handler/v1"
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/utils"
 	"github.com/pkg/errors"
 	cli "github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
 	taskInputDirectory = "in"
 
 	adminBuildInstanceTimeout = time.Minute * 1
+
+	// executorTokenEnv holds the executor token of the project, secrets
+	// referred by the job are only resolved if it is sent
+	executorTokenEnv = "OPTIMUS_EXECUTOR_TOKEN"
 )
 
 func adminBuildInstanceCommand(l logger) *cli.Command {
@@ -95,6 +101,9 @@ func getInstanceBuildRequest(l logger, jobName, inputDirectory, host, projectNam
 
 	timeoutCtx, cancel := context.WithTimeout(context.Background(), adminBuildInstanceTimeout)
 	defer cancel()
+	if token := os.Getenv(executorTokenEnv); token != "" {
+		timeoutCtx = metadata.AppendToOutgoingContext(timeoutCtx, v1handler.ExecutorTokenHeader, token)
+	}
 
 	// fetch Instance by calling the optimus API
 	runtime := pb.NewRuntimeServiceClient(conn)
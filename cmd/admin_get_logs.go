@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"time"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/pkg/errors"
+	cli "github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	adminLogsTimeout = time.Minute * 5
+)
+
+func adminGetLogsCommand(l logger) *cli.Command {
+	var (
+		optimusHost string
+		projectName string
+		scheduledAt string
+		taskName    string
+		tryNumber   int32
+	)
+	cmd := &cli.Command{
+		Use:     "logs",
+		Short:   "Get task logs of a job run",
+		Example: `optimus admin get logs sample_replace --project \"project-id\" --scheduled-at 2021-05-20T02:00:00Z`,
+		Args:    cli.MinimumNArgs(1),
+	}
+	cmd.Flags().StringVar(&projectName, "project", "", "name of the tenant")
+	cmd.MarkFlagRequired("project")
+	cmd.Flags().StringVar(&optimusHost, "host", "", "optimus service endpoint url")
+	cmd.MarkFlagRequired("host")
+	cmd.Flags().StringVar(&scheduledAt, "scheduled-at", "", "scheduled time of the run in RFC3339, e.g. 2021-05-20T02:00:00Z")
+	cmd.MarkFlagRequired("scheduled-at")
+	cmd.Flags().StringVar(&taskName, "task", "", "only show logs of this task")
+	cmd.Flags().Int32Var(&tryNumber, "try", 0, "only show logs of this attempt, all attempts if zero")
+
+	cmd.RunE = func(c *cli.Command, args []string) error {
+		jobName := args[0]
+		runTime, err := time.Parse(time.RFC3339, scheduledAt)
+		if err != nil {
+			return errors.Wrapf(err, "invalid scheduled time %s", scheduledAt)
+		}
+		l.Printf("requesting logs for project %s, job %s run at %s from %s\nplease wait...\n",
+			projectName, jobName, scheduledAt, optimusHost)
+
+		return getJobRunLogsRequest(l, &pb.GetJobRunLogsRequest{
+			ProjectName: projectName,
+			JobName:     jobName,
+			ScheduledAt: timestamppb.New(runTime),
+			TaskName:    taskName,
+			TryNumber:   tryNumber,
+		}, optimusHost)
+	}
+	return cmd
+}
+
+func getJobRunLogsRequest(l logger, req *pb.GetJobRunLogsRequest, host string) error {
+	var err error
+	dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
+	defer dialCancel()
+
+	var conn *grpc.ClientConn
+	if conn, err = createConnection(dialTimeoutCtx, host); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			l.Println("can't reach optimus service, timing out")
+		}
+		return err
+	}
+	defer conn.Close()
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), adminLogsTimeout)
+	defer cancel()
+
+	runtime := pb.NewRuntimeServiceClient(conn)
+	respStream, err := runtime.GetJobRunLogs(timeoutCtx, req)
+	if err != nil {
+		return errors.Wrapf(err, "request failed for job %s", req.GetJobName())
+	}
+
+	var taskName string
+	var tryNumber int32
+	for {
+		resp, err := respStream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.Wrapf(err, "failed to receive logs of job %s", req.GetJobName())
+		}
+		if resp.GetTaskName() != taskName || resp.GetTryNumber() != tryNumber {
+			taskName, tryNumber = resp.GetTaskName(), resp.GetTryNumber()
+			l.Printf("\n--- %s, attempt %d ---\n", taskName, tryNumber)
+		}
+		l.Print(string(resp.GetContent()))
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	cli "github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -19,24 +20,39 @@ func adminGetStatusCommand(l logger) *cli.Command {
 	var (
 		optimusHost string
 		projectName string
+		since       time.Duration
+		states      []string
+		limit       int32
 	)
 	cmd := &cli.Command{
 		Use:     "status",
 		Short:   "Get current job status",
-		Example: `optimus admin get status sample_replace --project \"project-id\"`,
+		Example: `optimus admin get status sample_replace --project \"project-id\" --since 168h --state failed`,
 		Args:    cli.MinimumNArgs(1),
 	}
 	cmd.Flags().StringVar(&projectName, "project", "", "name of the tenant")
 	cmd.MarkFlagRequired("project")
 	cmd.Flags().StringVar(&optimusHost, "host", "", "optimus service endpoint url")
 	cmd.MarkFlagRequired("host")
+	cmd.Flags().DurationVar(&since, "since", 0, "only show runs scheduled within this duration, e.g. 168h for the last 7 days")
+	cmd.Flags().StringSliceVar(&states, "state", nil, "only show runs in these states, e.g. failed")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "maximum number of latest runs to show, all runs if zero")
 
 	cmd.RunE = func(c *cli.Command, args []string) error {
 		jobName := args[0]
 		l.Printf("requesting status for project %s, job %s[%s] at %s\nplease wait...\n",
 			projectName, jobName, optimusHost)
 
-		if err := getJobStatusRequest(l, jobName, optimusHost, projectName); err != nil {
+		req := &pb.JobStatusRequest{
+			ProjectName: projectName,
+			JobName:     jobName,
+			States:      states,
+			PageSize:    limit,
+		}
+		if since > 0 {
+			req.StartDate = timestamppb.New(time.Now().Add(-since))
+		}
+		if err := getJobStatusRequest(l, req, optimusHost); err != nil {
 			return err
 		}
 
@@ -45,7 +61,7 @@ func adminGetStatusCommand(l logger) *cli.Command {
 	return cmd
 }
 
-func getJobStatusRequest(l logger, jobName, host, projectName string) error {
+func getJobStatusRequest(l logger, req *pb.JobStatusRequest, host string) error {
 	var err error
 	dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
 	defer dialCancel()
@@ -63,12 +79,9 @@ func getJobStatusRequest(l logger, jobName, host, projectName string) error {
 	defer cancel()
 
 	runtime := pb.NewRuntimeServiceClient(conn)
-	jobStatusResponse, err := runtime.JobStatus(timeoutCtx, &pb.JobStatusRequest{
-		ProjectName: projectName,
-		JobName:     jobName,
-	})
+	jobStatusResponse, err := runtime.JobStatus(timeoutCtx, req)
 	if err != nil {
-		return errors.Wrapf(err, "request failed for job %s", jobName)
+		return errors.Wrapf(err, "request failed for job %s", req.GetJobName())
 	}
 
 	jobStatuses := jobStatusResponse.GetStatuses()
@@ -94,6 +94,7 @@ func New(
 	cmd.AddCommand(validateCommand(l, conf.GetHost(), jobSpecRepo))
 	cmd.AddCommand(optimusServeCommand(l, conf))
 	cmd.AddCommand(replayCommand(l, conf))
+	cmd.AddCommand(scheduleCommand(l, conf))
 
 	// admin specific commands
 	if conf.GetAdmin().Enabled {
@@ -239,6 +239,7 @@ this effects runtime dependencies and template macros`,
 	}
 
 	// define defaults
+	catchUp := false
 	jobInput := local.Job{
 		Version: local.JobConfigVersion,
 		Name:    baseInputs["name"],
@@ -246,6 +247,8 @@ this effects runtime dependencies and template macros`,
 		Schedule: local.JobSchedule{
 			StartDate: baseInputs["start_date"],
 			Interval:  baseInputs["interval"],
+			// new jobs don't backfill runs since start date unless asked to
+			CatchUp: &catchUp,
 		},
 		Task: local.JobTask{
 			Name:   baseInputs["task"],
@@ -253,7 +256,6 @@ this effects runtime dependencies and template macros`,
 		},
 		Asset: map[string]string{},
 		Behavior: local.JobBehavior{
-			Catchup:       true,
 			DependsOnPast: false,
 		},
 		Dependencies: []local.JobDependency{},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/spf13/afero"
@@ -31,6 +32,9 @@ func deployCommand(l logger, conf config.Provider, jobSpecRepo JobSpecRepository
 	var namespace string
 	var ignoreJobs bool
 	var ignoreResources bool
+	var forceDelete bool
+	var forceResources bool
+	var strict bool
 
 	cmd := &cli.Command{
 		Use:   "deploy",
@@ -42,6 +46,9 @@ func deployCommand(l logger, conf config.Provider, jobSpecRepo JobSpecRepository
 	cmd.MarkFlagRequired("namespace")
 	cmd.Flags().BoolVar(&ignoreJobs, "ignore-jobs", false, "ignore deployment of jobs")
 	cmd.Flags().BoolVar(&ignoreResources, "ignore-resources", false, "ignore deployment of resources")
+	cmd.Flags().BoolVar(&forceDelete, "force-delete", false, "delete removed jobs even if other jobs depend on them")
+	cmd.Flags().BoolVar(&forceResources, "force-resources", false, "apply compatible changes of resources even if some of their changes are destructive")
+	cmd.Flags().BoolVar(&strict, "strict", false, "fail deployment if jobs use destinations no job produces")
 
 	cmd.RunE = func(c *cli.Command, args []string) error {
 		l.Printf("deploying project %s for namespace %s at %s\nplease wait...\n", projectName, namespace, conf.GetHost())
@@ -52,7 +59,7 @@ func deployCommand(l logger, conf config.Provider, jobSpecRepo JobSpecRepository
 		}
 
 		if err := postDeploymentRequest(l, projectName, namespace, jobSpecRepo, conf, datastoreRepo,
-			datastoreSpecFs, ignoreJobs, ignoreResources); err != nil {
+			datastoreSpecFs, ignoreJobs, ignoreResources, forceDelete, forceResources, strict); err != nil {
 			return err
 		}
 
@@ -66,7 +73,7 @@ func deployCommand(l logger, conf config.Provider, jobSpecRepo JobSpecRepository
 // postDeploymentRequest send a deployment request to service
 func postDeploymentRequest(l logger, projectName string, namespace string, jobSpecRepo JobSpecRepository,
 	conf config.Provider, datastoreRepo models.DatastoreRepo, datastoreSpecFs map[string]afero.Fs,
-	ignoreJobDeployment, ignoreResources bool) (err error) {
+	ignoreJobDeployment, ignoreResources, forceDelete, forceResources, strict bool) (err error) {
 	dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
 	defer dialCancel()
 
@@ -136,6 +143,7 @@ func postDeploymentRequest(l logger, projectName string, namespace string, jobSp
 				ProjectName:   projectName,
 				DatastoreName: storeName,
 				Namespace:     namespace,
+				Force:         forceResources,
 			})
 			if err != nil {
 				if errors.Is(err, context.DeadlineExceeded) {
@@ -193,6 +201,8 @@ func postDeploymentRequest(l logger, projectName string, namespace string, jobSp
 			Jobs:        adaptedJobSpecs,
 			ProjectName: projectName,
 			Namespace:   namespace,
+			ForceDelete: forceDelete,
+			Strict:      strict,
 		})
 		if err != nil {
 			if errors.Is(err, context.DeadlineExceeded) {
@@ -211,10 +221,20 @@ func postDeploymentRequest(l logger, projectName string, namespace string, jobSp
 				}
 				return errors.Wrapf(err, "failed to receive deployment ack")
 			}
+			if resp.GetDeployId() != "" {
+				l.Printf("deploy id: %s\n", resp.GetDeployId())
+				continue
+			}
+			if summary := resp.GetSummary(); summary != nil {
+				l.Printf("Deployed: %d ok, %d failed, %d deleted, %d unchanged\n", summary.GetSucceeded(),
+					summary.GetFailed(), summary.GetDeleted(), summary.GetUnchanged())
+				continue
+			}
 			if resp.Ack {
 				// ack for the job spec
 				if !resp.GetSuccess() {
-					return errors.Errorf("unable to deploy: %s %s", resp.GetJobName(), resp.GetMessage())
+					return errors.Errorf("unable to deploy: %s %s (%s)", resp.GetJobName(), resp.GetMessage(),
+						strings.ToLower(resp.GetErrorCategory().String()))
 				}
 				jobCounter++
 				l.Printf("%d/%d. %s successfully deployed\n", jobCounter, totalJobs, resp.GetJobName())
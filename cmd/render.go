@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -13,6 +14,7 @@ import (
 
 	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	cli "github.com/spf13/cobra"
 	"google.golang.org/grpc"
 )
@@ -64,6 +66,17 @@ func renderTemplateCommand(l logger, jobSpecRepo JobSpecRepository) *cli.Command
 		now := time.Now()
 		l.Println("assuming execution time as current time of", now.Format(models.InstanceScheduledAtTimeLayout))
 
+		// assets might be referencing local files or urls instead of having the content
+		httpLoader := instance.NewHTTPAssetLoader(&http.Client{Timeout: renderTimeout}, nil, 0)
+		assetLoader := instance.NewCachedAssetLoader(map[string]models.AssetLoader{
+			instance.AssetSchemeFile:  instance.NewFileAssetLoader(afero.NewOsFs()),
+			instance.AssetSchemeHTTP:  httpLoader,
+			instance.AssetSchemeHTTPS: httpLoader,
+		}, 0, time.Now)
+		if jobSpec.Assets, err = instance.ResolveAssets(context.Background(), assetLoader, jobSpec.Assets); err != nil {
+			return err
+		}
+
 		templates, err := instance.DumpAssets(jobSpec, now, templateEngine, true)
 		if err != nil {
 			return err
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/config"
+	"github.com/pkg/errors"
+	cli "github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	schedulePreviewTimeout = time.Second * 10
+)
+
+func scheduleCommand(l logger, conf config.Provider) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "schedule",
+		Short: "inspect job schedule intervals",
+	}
+	cmd.AddCommand(schedulePreviewSubCommand(l, conf))
+	return cmd
+}
+
+func schedulePreviewSubCommand(l logger, conf config.Provider) *cli.Command {
+	var (
+		startDate string
+		endDate   string
+		count     int32
+	)
+	cmd := &cli.Command{
+		Use:     "preview",
+		Short:   "list upcoming run times of a schedule interval",
+		Example: `optimus schedule preview "0 2 * * *" --start 2021-01-01T00:00:00Z --count 5`,
+		Long: `
+This operation takes interval[required] in cron notation as used
+in job specification and prints the runs it would schedule, start
+and end dates are inclusive and should be in RFC3339 format.
+		`,
+		Args: cli.ExactArgs(1),
+	}
+	cmd.Flags().StringVar(&startDate, "start", "", "time to start listing runs from, defaults to now")
+	cmd.Flags().StringVar(&endDate, "end", "", "time after which runs are not listed")
+	cmd.Flags().Int32Var(&count, "count", 0, "number of runs to list")
+
+	cmd.RunE = func(c *cli.Command, args []string) error {
+		req := &pb.PreviewScheduleRequest{
+			Interval: args[0],
+			Count:    count,
+		}
+		if startDate != "" {
+			start, err := time.Parse(time.RFC3339, startDate)
+			if err != nil {
+				return errors.Wrapf(err, "invalid start date %s", startDate)
+			}
+			req.StartDate = timestamppb.New(start)
+		}
+		if endDate != "" {
+			end, err := time.Parse(time.RFC3339, endDate)
+			if err != nil {
+				return errors.Wrapf(err, "invalid end date %s", endDate)
+			}
+			req.EndDate = timestamppb.New(end)
+		}
+		return previewScheduleRequest(l, conf.GetHost(), req)
+	}
+	return cmd
+}
+
+func previewScheduleRequest(l logger, host string, req *pb.PreviewScheduleRequest) error {
+	var err error
+	dialTimeoutCtx, dialCancel := context.WithTimeout(context.Background(), OptimusDialTimeout)
+	defer dialCancel()
+
+	var conn *grpc.ClientConn
+	if conn, err = createConnection(dialTimeoutCtx, host); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			l.Println("can't reach optimus service, timing out")
+		}
+		return err
+	}
+	defer conn.Close()
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), schedulePreviewTimeout)
+	defer cancel()
+
+	runtime := pb.NewRuntimeServiceClient(conn)
+	previewResponse, err := runtime.PreviewSchedule(timeoutCtx, req)
+	if err != nil {
+		return errors.Wrapf(err, "request failed for interval %s", req.GetInterval())
+	}
+
+	for _, run := range previewResponse.GetRuns() {
+		l.Println(run.AsTime().Format(time.RFC3339))
+	}
+	return nil
+}
@@ -2,19 +2,24 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/odpf/optimus/ext/notify/pagerduty"
 	"github.com/odpf/optimus/ext/notify/slack"
+	"github.com/odpf/optimus/ext/notify/webhook"
 
 	"github.com/odpf/optimus/utils"
 
@@ -31,15 +36,21 @@ import (
 	"cloud.google.com/go/storage"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/logging/logrus"
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	grpctags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 	slackapi "github.com/slack-go/slack"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"google.golang.org/grpc"
+	// registers gzip compressor, clients can opt in to compressed responses
+	// by using grpc.UseCompressor(gzip.Name) as call option
+	_ "google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/reflection"
 
 	v1 "github.com/odpf/optimus/api/handler/v1"
@@ -49,6 +60,8 @@ import (
 	"github.com/odpf/optimus/core/progress"
 	_ "github.com/odpf/optimus/ext/datastore"
 	"github.com/odpf/optimus/ext/scheduler/airflow2"
+	"github.com/odpf/optimus/ext/scheduler/kubernetes"
+	"github.com/odpf/optimus/ext/scheduler/temporal"
 	"github.com/odpf/optimus/instance"
 	"github.com/odpf/optimus/job"
 	"github.com/odpf/optimus/models"
@@ -64,6 +77,9 @@ var (
 
 	shutdownWait = 30 * time.Second
 
+	// max time to wait while fetching a remote job asset
+	assetFetchTimeout = 30 * time.Second
+
 	GRPCMaxRecvMsgSize = 45 << 20 // 45MB
 )
 
@@ -100,13 +116,35 @@ func (fac *jobSpecRepoFactory) New(namespace models.NamespaceSpec) job.SpecRepos
 	)
 }
 
+// projectScheduler returns the scheduler configured for the project, the
+// server default scheduler is used if project doesn't specify any
+func projectScheduler(proj models.ProjectSpec) (models.SchedulerUnit, error) {
+	if proj.Scheduler.Type == "" {
+		return models.Scheduler, nil
+	}
+	return models.SchedulerRegistry.GetByName(proj.Scheduler.Type)
+}
+
+// jobRepoStoringScheduler is a scheduler compiled jobs are deployed to
+// directly instead of the storage path of project
+type jobRepoStoringScheduler interface {
+	models.JobStoringScheduler
+	NewJobRepository(models.ProjectSpec) (store.JobRepository, error)
+}
+
 // jobRepoFactory stores compiled specifications that will be consumed by a
 // scheduler
-type jobRepoFactory struct {
-	schd models.SchedulerUnit
-}
+type jobRepoFactory struct{}
 
 func (fac *jobRepoFactory) New(ctx context.Context, proj models.ProjectSpec) (store.JobRepository, error) {
+	schd, err := projectScheduler(proj)
+	if err != nil {
+		return nil, err
+	}
+	if storing, ok := schd.(jobRepoStoringScheduler); ok && storing.StoresCompiledJobs() {
+		return storing.NewJobRepository(proj)
+	}
+
 	storagePath, ok := proj.Config[models.ProjectStoragePathKey]
 	if !ok {
 		return nil, errors.Errorf("%s not configured for project %s", models.ProjectStoragePathKey, proj.Name)
@@ -126,11 +164,60 @@ func (fac *jobRepoFactory) New(ctx context.Context, proj models.ProjectSpec) (st
 		if err != nil {
 			return nil, errors.Wrap(err, "error creating google storage client")
 		}
-		return gcs.NewJobRepository(p.Hostname(), filepath.Join(p.Path, fac.schd.GetJobsDir()), fac.schd.GetJobsExtension(), storageClient), nil
+		pathTemplate, ok := proj.Config[models.ProjectStoragePathTemplateKey]
+		if !ok || strings.TrimSpace(pathTemplate) == "" {
+			return gcs.NewJobRepository(p.Hostname(), filepath.Join(p.Path, schd.GetJobsDir()), schd.GetJobsExtension(), storageClient), nil
+		}
+		jobPathTemplate, err := gcs.NewJobPathTemplate(pathTemplate, proj.Name, schd.GetJobsExtension())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s of project %s", models.ProjectStoragePathTemplateKey, proj.Name)
+		}
+		repo := gcs.NewJobRepository(p.Hostname(), p.Path, schd.GetJobsExtension(), storageClient)
+		repo.PathTemplate = jobPathTemplate
+		return repo, nil
 	}
 	return nil, errors.Errorf("unsupported storage config %s in %s of project %s", storagePath, models.ProjectStoragePathKey, proj.Name)
 }
 
+// compileCacheSeed changes whenever compiled jobs could change without a
+// change in job specs, invalidating the persisted compile cache
+func compileCacheSeed(schedulers []models.SchedulerUnit, hostname string) string {
+	sort.Slice(schedulers, func(i, j int) bool {
+		return schedulers[i].GetName() < schedulers[j].GetName()
+	})
+	hash := sha256.New()
+	for _, schd := range schedulers {
+		hash.Write([]byte(schd.GetName()))
+		hash.Write(schd.GetTemplate())
+	}
+	hash.Write([]byte(hostname))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func restoreCompileCache(compiler *job.CachedCompiler, path string) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer fd.Close()
+	return compiler.Restore(fd)
+}
+
+func dumpCompileCache(compiler *job.CachedCompiler, path string) error {
+	fd, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := compiler.Dump(fd); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
 type projectRepoFactory struct {
 	db   *gorm.DB
 	hash models.ApplicationKey
@@ -185,6 +272,31 @@ func (fac *resourceSpecRepoFactory) New(namespace models.NamespaceSpec, ds model
 	return postgres.NewResourceSpecRepository(fac.db, namespace, ds, fac.projectResourceSpecRepoFac.New(namespace.ProjectSpec, ds))
 }
 
+// backupRepoFactory records backups taken of resources
+type backupRepoFactory struct {
+	db *gorm.DB
+}
+
+func (fac *backupRepoFactory) New(namespace models.NamespaceSpec, ds models.Datastorer) store.BackupRepository {
+	return postgres.NewBackupRepository(fac.db, namespace, ds)
+}
+
+type jobEventRepoFactory struct {
+	db *gorm.DB
+}
+
+func (fac *jobEventRepoFactory) New(namespace models.NamespaceSpec) store.JobEventRepository {
+	return postgres.NewJobEventRepository(fac.db, namespace)
+}
+
+type jobChecksumRepoFactory struct {
+	db *gorm.DB
+}
+
+func (fac *jobChecksumRepoFactory) New(namespace models.NamespaceSpec) store.JobChecksumRepository {
+	return postgres.NewJobChecksumRepository(fac.db, namespace)
+}
+
 type objectWriterFactory struct {
 }
 
@@ -207,6 +319,26 @@ func (o *objectWriterFactory) New(ctx context.Context, writerPath, writerSecret
 	return nil, errors.Errorf("unsupported storage config %s", writerPath)
 }
 
+type objectReaderFactory struct {
+}
+
+func (o *objectReaderFactory) New(ctx context.Context, readerPath, readerSecret string) (store.ObjectReader, error) {
+	p, err := url.Parse(readerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.Scheme {
+	case "gs":
+		gcsClient, err := storage.NewClient(ctx, option.WithCredentialsJSON([]byte(readerSecret)))
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating google storage client")
+		}
+		return gcs.NewObjectReader(gcsClient), nil
+	}
+	return nil, errors.Errorf("unsupported storage config %s", readerPath)
+}
+
 type metadataServiceFactory struct {
 	writer *meta.Writer
 }
@@ -226,9 +358,13 @@ func (obs *pipelineLogObserver) Notify(evt progress.Event) {
 	obs.log.Info(evt)
 }
 
-func jobSpecAssetDump() func(jobSpec models.JobSpec, scheduledAt time.Time) (models.JobAssets, error) {
+func jobSpecAssetDump(assetLoaderFactory models.AssetLoaderFactory) job.AssetCompiler {
 	engine := instance.NewGoEngine()
-	return func(jobSpec models.JobSpec, scheduledAt time.Time) (models.JobAssets, error) {
+	return func(proj models.ProjectSpec, jobSpec models.JobSpec, scheduledAt time.Time) (models.JobAssets, error) {
+		var err error
+		if jobSpec.Assets, err = instance.ResolveAssets(context.Background(), assetLoaderFactory.New(proj), jobSpec.Assets); err != nil {
+			return models.JobAssets{}, err
+		}
 		aMap, err := instance.DumpAssets(jobSpec, scheduledAt, engine, false)
 		if err != nil {
 			return models.JobAssets{}, err
@@ -237,6 +373,53 @@ func jobSpecAssetDump() func(jobSpec models.JobSpec, scheduledAt time.Time) (mod
 	}
 }
 
+// newAssetLoaderFactory prepares loaders for assets referenced from remote
+// locations, gcs assets are read with the storage secret of project and
+// http(s) assets are fetched only from allowed hosts
+func newAssetLoaderFactory(serve config.ServerConfig) *instance.ProjectAssetLoaderFactory {
+	var allowedHosts []string
+	for _, host := range strings.Split(serve.AssetAllowedHosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			allowedHosts = append(allowedHosts, host)
+		}
+	}
+	var httpLoader models.AssetLoader
+	if len(allowedHosts) > 0 {
+		httpLoader = instance.NewHTTPAssetLoader(&http.Client{Timeout: assetFetchTimeout}, allowedHosts, serve.AssetMaxBytes)
+	}
+	return instance.NewProjectAssetLoaderFactory(func(proj models.ProjectSpec) map[string]models.AssetLoader {
+		loaders := map[string]models.AssetLoader{
+			instance.AssetSchemeGCS: &projectGCSAssetLoader{project: proj, maxBytes: serve.AssetMaxBytes},
+		}
+		if httpLoader != nil {
+			loaders[instance.AssetSchemeHTTP] = httpLoader
+			loaders[instance.AssetSchemeHTTPS] = httpLoader
+		}
+		return loaders
+	}, instance.DefaultAssetCacheTTL, func() time.Time {
+		return time.Now().UTC()
+	})
+}
+
+// projectGCSAssetLoader reads gcs assets with the storage secret of project
+type projectGCSAssetLoader struct {
+	project  models.ProjectSpec
+	maxBytes int64
+}
+
+func (l *projectGCSAssetLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	storageSecret, ok := l.project.Secret.GetByName(models.ProjectSecretStorageKey)
+	if !ok {
+		return nil, errors.Errorf("%s secret not configured for project %s", models.ProjectSecretStorageKey, l.project.Name)
+	}
+	storageClient, err := storage.NewClient(ctx, option.WithCredentialsJSON([]byte(storageSecret)))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating google storage client")
+	}
+	defer storageClient.Close()
+	return gcs.NewAssetLoader(storageClient, l.maxBytes).Load(ctx, uri)
+}
+
 func checkRequiredConfigs(conf config.Provider) error {
 	errRequiredMissing := errors.New("required config missing")
 	if conf.GetServe().IngressHost == "" {
@@ -268,7 +451,8 @@ func Initialize(conf config.Provider) error {
 		log.Level = loglevel
 	}
 	log.SetOutput(os.Stdout)
-	logger.Init(conf.GetLog().Level)
+	log.SetFormatter(logger.NewFormatter(conf.GetLog().Format))
+	logger.InitWithFormat(conf.GetLog().Level, conf.GetLog().Format)
 
 	mainLog := log.WithField("reporter", "main")
 	mainLog.Infof("starting optimus %s", config.Version)
@@ -286,24 +470,43 @@ func Initialize(conf config.Provider) error {
 		return errors.Wrap(err, "postgres.Connect")
 	}
 
-	// init default scheduler
-	switch conf.GetScheduler().Name {
-	case "airflow":
-		models.Scheduler = airflow.NewScheduler(
+	// init schedulers projects can choose from
+	for _, schd := range []models.SchedulerUnit{
+		airflow.NewScheduler(
 			&objectWriterFactory{},
 			&http.Client{},
-		)
-	case "airflow2":
-		models.Scheduler = airflow2.NewScheduler(
+		),
+		airflow2.NewScheduler(
 			&objectWriterFactory{},
+			&objectReaderFactory{},
+			&http.Client{},
+		),
+		kubernetes.NewScheduler(
+			&http.Client{},
+		),
+		temporal.NewScheduler(
 			&http.Client{},
-		)
-	default:
+		),
+	} {
+		if err := models.SchedulerRegistry.Add(schd); err != nil {
+			return errors.Wrap(err, "models.SchedulerRegistry.Add")
+		}
+	}
+
+	// init default scheduler
+	models.Scheduler, err = models.SchedulerRegistry.GetByName(conf.GetScheduler().Name)
+	if err != nil {
 		return errors.Errorf("unsupported scheduler: %s", conf.GetScheduler().Name)
 	}
 
 	// used to encrypt secrets
-	appHash, err := models.NewApplicationSecret(conf.GetServe().AppKey)
+	var previousAppKeys []string
+	for _, key := range strings.Split(conf.GetServe().PreviousAppKeys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			previousAppKeys = append(previousAppKeys, key)
+		}
+	}
+	appHash, err := models.NewApplicationSecret(conf.GetServe().AppKey, previousAppKeys...)
 	if err != nil {
 		return errors.Wrap(err, "NewApplicationSecret")
 	}
@@ -325,7 +528,12 @@ func Initialize(conf config.Provider) error {
 			defer cancel()
 
 			logger.I("bootstrapping project ", proj.Name)
-			if err := models.Scheduler.Bootstrap(bootstrapCtx, proj); err != nil {
+			schd, err := projectScheduler(proj)
+			if err != nil {
+				logger.E(err)
+				return
+			}
+			if err := schd.Bootstrap(bootstrapCtx, proj); err != nil {
 				// Major ERROR, but we can't make this fatal
 				// other projects might be working fine though
 				logger.E(err)
@@ -351,29 +559,23 @@ func Initialize(conf config.Provider) error {
 		db:                    dbConn,
 		projectJobSpecRepoFac: projectJobSpecRepoFac,
 	}
-	jobCompiler := job.NewCompiler(models.Scheduler.GetTemplate(), conf.GetServe().IngressHost)
+	jobCompiler := job.NewCachedCompiler(
+		job.NewProjectCompiler(projectScheduler, conf.GetServe().IngressHost),
+		conf.GetServe().CompileCacheSize,
+		compileCacheSeed(models.SchedulerRegistry.GetAll(), conf.GetServe().IngressHost),
+	)
+	if cachePath := conf.GetServe().CompileCachePath; cachePath != "" {
+		if err := restoreCompileCache(jobCompiler, cachePath); err != nil {
+			mainLog.Warnf("failed to restore compile cache from %s: %v", cachePath, err)
+		}
+	}
+	assetLoaderFactory := newAssetLoaderFactory(conf.GetServe())
 	dependencyResolver := job.NewDependencyResolver()
 	priorityResolver := job.NewPriorityResolver()
 
-	// Logrus entry is used, allowing pre-definition of certain fields by the user.
-	logrusEntry := logrus.NewEntry(log)
-	// Shared options for the logger, with a custom gRPC code to log level function.
-	opts := []grpc_logrus.Option{
-		grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel),
-	}
-	// Make sure that log statements internal to gRPC library are logged using the logrus Logger as well.
-	grpc_logrus.ReplaceGrpcLogger(logrusEntry)
-
+	auditEventRepo := postgres.NewAuditEventRepository(dbConn)
 	grpcAddr := fmt.Sprintf("%s:%d", conf.GetServe().Host, conf.GetServe().Port)
-	grpcOpts := []grpc.ServerOption{
-		grpc_middleware.WithUnaryServerChain(
-			grpctags.UnaryServerInterceptor(grpctags.WithFieldExtractor(grpctags.CodeGenRequestFieldExtractor)),
-			grpc_logrus.UnaryServerInterceptor(logrusEntry, opts...),
-		),
-		grpc.MaxRecvMsgSize(GRPCMaxRecvMsgSize),
-	}
-	grpcServer := grpc.NewServer(grpcOpts...)
-	reflection.Register(grpcServer)
+	grpcServer := newGRPCServer(conf.GetServe(), log, auditEventRepo)
 
 	// prepare factory writer for metadata
 	var metaSvcFactory meta.MetaSvcFactory
@@ -406,11 +608,13 @@ func Initialize(conf config.Provider) error {
 		jobSpecRepoFac: jobSpecRepoFac,
 	}
 	replayWorker := job.NewReplayWorker(replaySpecRepoFac, models.Scheduler)
+	replayWorker.ProjectScheduler = projectScheduler
 	replayManager := job.NewManager(replayWorker, replaySpecRepoFac, utils.NewUUIDProvider(), job.ReplayManagerConfig{
 		NumWorkers:    conf.GetServe().ReplayNumWorkers,
 		WorkerTimeout: conf.GetServe().ReplayWorkerTimeoutSecs,
 		RunTimeout:    conf.GetServe().ReplayRunTimeoutSecs,
 	}, models.Scheduler)
+	replayManager.ProjectScheduler = projectScheduler
 
 	notificationContext, cancelNotifiers := context.WithCancel(context.Background())
 	defer cancelNotifiers()
@@ -421,42 +625,104 @@ func Initialize(conf config.Provider) error {
 				logger.E(err)
 			},
 		),
+		"pagerduty": pagerduty.NewNotifier(notificationContext, pagerduty.EventsAPIURL,
+			pagerduty.DefaultMaxAttempts, pagerduty.DefaultRetryBackoff,
+			func(err error) {
+				logger.E(err)
+			},
+		),
 	})
+	eventService.EventRepoFac = &jobEventRepoFactory{db: dbConn}
+	eventService.Webhook = webhook.NewNotifier(notificationContext, webhook.DefaultMaxAttempts,
+		webhook.DefaultRetryBackoff,
+		func(err error) {
+			logger.E(err)
+		},
+	)
+
+	datastoreService := datastore.NewService(&resourceSpecRepoFac, &backupRepoFactory{db: dbConn}, models.DatastoreRegistry)
+	if timeout := conf.GetServe().ResourceApplyTimeoutSecs; timeout > 0 {
+		datastoreService.ApplyTimeout = timeout
+	}
+	if concurrency := conf.GetServe().ResourceApplyConcurrency; concurrency > 0 {
+		datastoreService.ApplyConcurrency = concurrency
+	}
+
+	jobService := job.NewService(
+		&jobSpecRepoFac,
+		&jobRepoFactory{},
+		jobCompiler,
+		jobSpecAssetDump(assetLoaderFactory),
+		dependencyResolver,
+		priorityResolver,
+		metaSvcFactory,
+		&projectJobSpecRepoFac,
+		replayManager,
+	)
+	jobService.ProjectScheduler = projectScheduler
+	jobService.AllowDuplicateDestinations = conf.GetServe().AllowDuplicateDestinations
+	jobService.GlobalConfig = conf.GetServe().GlobalConfig
+	jobService.SyncConfig = job.SyncConfig{
+		Workers:       conf.GetServe().JobSyncWorkers,
+		TicketsPerSec: conf.GetServe().JobSyncTicketsPerSec,
+	}
+	jobService.ChecksumRepoFactory = &jobChecksumRepoFactory{db: dbConn}
+
+	instanceService := instance.NewService(
+		&instanceRepoFactory{
+			db: dbConn,
+		},
+		func() time.Time {
+			return time.Now().UTC()
+		},
+		instance.NewGoEngine(),
+	)
+	instanceService.GlobalConfig = conf.GetServe().GlobalConfig
 
 	// runtime service instance over grpc
-	pb.RegisterRuntimeServiceServer(grpcServer, v1handler.NewRuntimeServiceServer(
+	runtimeService := v1handler.NewRuntimeServiceServer(
 		config.Version,
-		job.NewService(
-			&jobSpecRepoFac,
-			&jobRepoFactory{
-				schd: models.Scheduler,
-			},
-			jobCompiler,
-			jobSpecAssetDump(),
-			dependencyResolver,
-			priorityResolver,
-			metaSvcFactory,
-			&projectJobSpecRepoFac,
-			replayManager,
-		),
+		jobService,
 		eventService,
-		datastore.NewService(&resourceSpecRepoFac, models.DatastoreRegistry),
+		datastoreService,
 		projectRepoFac,
 		namespaceSpecRepoFac,
 		projectSecretRepoFac,
 		v1.NewAdapter(models.TaskRegistry, models.HookRegistry, models.DatastoreRegistry),
 		progressObs,
-		instance.NewService(
-			&instanceRepoFactory{
-				db: dbConn,
-			},
-			func() time.Time {
-				return time.Now().UTC()
-			},
-			instance.NewGoEngine(),
-		),
+		instanceService,
 		models.Scheduler,
-	))
+		assetLoaderFactory,
+	)
+	runtimeService.LimitDeploys(conf.GetServe().DeployConcurrency, conf.GetServe().DeployRejectExcess)
+	runtimeService.MaxDeployJobs = conf.GetServe().DeployMaxJobs
+	runtimeService.MaxSpecBytes = conf.GetServe().DeployMaxSpecBytes
+	runtimeService.DeployAlerter = eventService
+	runtimeService.AuditEventRepo = auditEventRepo
+
+	var deployManager *job.DeployManager
+	if conf.GetServe().DeployQueueWorkers > 0 {
+		deployManager = job.NewDeployManager(job.DeployManagerConfig{
+			NumWorkers: conf.GetServe().DeployQueueWorkers,
+			QueueSize:  conf.GetServe().DeployQueueSize,
+		}, progressObs)
+		runtimeService.DeployManager = deployManager
+	}
+	pb.RegisterRuntimeServiceServer(grpcServer, runtimeService)
+
+	// request counts and handling time of every grpc method, including the
+	// long running deploys, are exposed along with other metrics at /metrics
+	grpc_prometheus.EnableHandlingTimeHistogram()
+	grpc_prometheus.Register(grpcServer)
+
+	var slaMonitor *job.SLAMonitor
+	if interval := conf.GetServe().SLACheckIntervalSecs; interval > 0 {
+		slaMonitor = job.NewSLAMonitor(job.SLAMonitorConfig{
+			Interval: interval,
+		}, projectRepoFac, namespaceSpecRepoFac, &jobSpecRepoFac, eventService)
+		slaMonitor.ProjectScheduler = projectScheduler
+		slaMonitor.Init()
+	}
 
 	timeoutGrpcDialCtx, grpcDialCancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer grpcDialCancel()
@@ -464,6 +730,7 @@ func Initialize(conf config.Provider) error {
 	// prepare http proxy
 	gwmux := runtime.NewServeMux(
 		runtime.WithErrorHandler(runtime.DefaultHTTPErrorHandler),
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher(conf.GetServe().IdentityHeader)),
 	)
 	// gRPC dialup options to proxy http connections
 	grpcConn, err := grpc.DialContext(timeoutGrpcDialCtx, grpcAddr, []grpc.DialOption{
@@ -483,6 +750,7 @@ func Initialize(conf config.Provider) error {
 	baseMux.HandleFunc("/ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "pong")
 	}))
+	baseMux.Handle("/metrics", promhttp.Handler())
 	baseMux.Handle("/", gwmux)
 
 	srv := &http.Server{
@@ -503,6 +771,28 @@ func Initialize(conf config.Provider) error {
 		}
 	}()
 
+	// http gateway can additionally be served on its own port, grpc port
+	// keeps accepting both protocols
+	var httpSrv *http.Server
+	if httpPort := conf.GetServe().HTTPPort; httpPort > 0 && httpPort != conf.GetServe().Port {
+		httpAddr := fmt.Sprintf("%s:%d", conf.GetServe().Host, httpPort)
+		httpSrv = &http.Server{
+			Handler:      baseMux,
+			Addr:         httpAddr,
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			IdleTimeout:  120 * time.Second,
+		}
+		go func() {
+			mainLog.Infoln("starting http gateway at ", httpAddr)
+			if err := httpSrv.ListenAndServe(); err != nil {
+				if err != http.ErrServerClosed {
+					mainLog.Fatalf("http gateway error: %v\n", err)
+				}
+			}
+		}()
+	}
+
 	// We'll accept graceful shutdowns when quit via SIGINT (Ctrl+C)
 	signal.Notify(termChan, os.Interrupt)
 	signal.Notify(termChan, os.Kill)
@@ -516,6 +806,11 @@ func Initialize(conf config.Provider) error {
 	if err = replayManager.Close(); err != nil {
 		terminalError = multierror.Append(terminalError, errors.Wrap(err, "replayManager.Close"))
 	}
+	if slaMonitor != nil {
+		if err := slaMonitor.Close(); err != nil {
+			terminalError = multierror.Append(terminalError, errors.Wrap(err, "slaMonitor.Close"))
+		}
+	}
 
 	// Create a deadline to wait for server
 	ctxProxy, cancelProxy := context.WithTimeout(context.Background(), shutdownWait)
@@ -526,19 +821,90 @@ func Initialize(conf config.Provider) error {
 	if err := srv.Shutdown(ctxProxy); err != nil {
 		terminalError = multierror.Append(terminalError, errors.Wrap(err, "srv.Shutdown"))
 	}
+	if httpSrv != nil {
+		if err := httpSrv.Shutdown(ctxProxy); err != nil {
+			terminalError = multierror.Append(terminalError, errors.Wrap(err, "httpSrv.Shutdown"))
+		}
+	}
 	grpcServer.GracefulStop()
 
+	// queued deploys are finished before exiting
+	if deployManager != nil {
+		if err := deployManager.Close(); err != nil {
+			terminalError = multierror.Append(terminalError, errors.Wrap(err, "deployManager.Close"))
+		}
+	}
+
 	// gracefully shutdown event service, e.g. slack notifiers flush in memory batches
 	cancelNotifiers()
 	if err := eventService.Close(); err != nil && len(err.Error()) != 0 {
 		terminalError = multierror.Append(terminalError, errors.Wrap(err, "eventService.Close"))
 	}
 
+	if cachePath := conf.GetServe().CompileCachePath; cachePath != "" {
+		if err := dumpCompileCache(jobCompiler, cachePath); err != nil {
+			terminalError = multierror.Append(terminalError, errors.Wrap(err, "dumpCompileCache"))
+		}
+	}
+
 	mainLog.Info("bye")
 
 	return terminalError
 }
 
+// newGRPCServer creates the grpc server with interceptors and message limits
+// optimus is served with, services are registered on it by the caller
+func newGRPCServer(serve config.ServerConfig, log *logrus.Logger, auditEventRepo store.AuditEventRepository) *grpc.Server {
+	// Logrus entry is used, allowing pre-definition of certain fields by the user.
+	logrusEntry := logrus.NewEntry(log)
+	// Shared options for the logger, with a custom gRPC code to log level function.
+	opts := []grpc_logrus.Option{
+		grpc_logrus.WithLevels(grpc_logrus.DefaultCodeToLevel),
+	}
+	// Make sure that log statements internal to gRPC library are logged using the logrus Logger as well.
+	grpc_logrus.ReplaceGrpcLogger(logrusEntry)
+
+	maxMessageBytes := GRPCMaxRecvMsgSize
+	if serve.MaxMessageBytes > 0 {
+		maxMessageBytes = serve.MaxMessageBytes
+	}
+	grpcOpts := []grpc.ServerOption{
+		grpc_middleware.WithUnaryServerChain(
+			grpctags.UnaryServerInterceptor(grpctags.WithFieldExtractor(grpctags.CodeGenRequestFieldExtractor)),
+			grpc_logrus.UnaryServerInterceptor(logrusEntry, opts...),
+			v1handler.UnaryLogInterceptor(log.WithField("reporter", "request")),
+			v1handler.UnaryAuditInterceptor(auditEventRepo, serve.IdentityHeader),
+			grpc_recovery.UnaryServerInterceptor(v1handler.RecoveryOption()),
+			v1handler.UnaryValidationInterceptor(),
+			v1handler.UnaryDeadlineInterceptor(serve.RequestTimeoutSecs),
+			grpc_prometheus.UnaryServerInterceptor,
+		),
+		grpc_middleware.WithStreamServerChain(
+			v1handler.StreamLogInterceptor(log.WithField("reporter", "request")),
+			v1handler.StreamAuditInterceptor(auditEventRepo, serve.IdentityHeader),
+			grpc_recovery.StreamServerInterceptor(v1handler.RecoveryOption()),
+			v1handler.StreamValidationInterceptor(),
+			grpc_prometheus.StreamServerInterceptor,
+		),
+		grpc.MaxRecvMsgSize(maxMessageBytes),
+		grpc.MaxSendMsgSize(maxMessageBytes),
+	}
+	grpcServer := grpc.NewServer(grpcOpts...)
+	reflection.Register(grpcServer)
+	return grpcServer
+}
+
+// gatewayHeaderMatcher forwards the identity header set by the proxy
+// authenticating callers to grpc along with the headers forwarded by default
+func gatewayHeaderMatcher(identityHeader string) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		if identityHeader != "" && strings.EqualFold(key, identityHeader) {
+			return strings.ToLower(key), true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
+
 // grpcHandlerFunc routes http1 calls to baseMux and http2 with grpc header to grpcServer.
 // Using a single port for proxying both http1 & 2 protocols will degrade http performance
 // but for our usecase the convenience per performance tradeoff is better suited
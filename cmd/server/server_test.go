@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	v1 "github.com/odpf/optimus/api/handler/v1"
+	pb "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/config"
+	"github.com/odpf/optimus/instance"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCompression(t *testing.T) {
+	t.Run("should return large job list to a client requesting gzip compression", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-test-namespace-1",
+			ProjectSpec: projectSpec,
+		}
+
+		execUnit := new(mock.TaskPlugin)
+		execUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+			Name: "bq2bq",
+		}, nil)
+
+		// a few megabytes of job specifications
+		var jobSpecs []models.JobSpec
+		for i := 0; i < 2000; i++ {
+			jobSpecs = append(jobSpecs, models.JobSpec{
+				Name: fmt.Sprintf("job-%d", i),
+				Task: models.JobSpecTask{
+					Unit: execUnit,
+				},
+				Assets: *models.JobAssets{}.New([]models.JobSpecAsset{
+					{
+						Name:  "query.sql",
+						Value: strings.Repeat("select * from `proj.datas.table`;\n", 50),
+					},
+				}),
+			})
+		}
+
+		projectRepository := new(mock.ProjectRepository)
+		projectRepository.On("GetByName", projectSpec.Name).Return(projectSpec, nil)
+		projectRepoFactory := new(mock.ProjectRepoFactory)
+		projectRepoFactory.On("New").Return(projectRepository)
+
+		namespaceRepository := new(mock.NamespaceRepository)
+		namespaceRepository.On("GetByName", namespaceSpec.Name).Return(namespaceSpec, nil)
+		namespaceRepoFact := new(mock.NamespaceRepoFactory)
+		namespaceRepoFact.On("New", projectSpec).Return(namespaceRepository)
+
+		jobService := new(mock.JobService)
+		jobService.On("GetAll", namespaceSpec).Return(jobSpecs, nil)
+		defer jobService.AssertExpectations(t)
+
+		// served the way optimus serves requests, gzip is only available
+		// to clients if the server registers it
+		log := logrus.New()
+		log.SetOutput(ioutil.Discard)
+		grpcServer := newGRPCServer(config.ServerConfig{}, log, new(mock.AuditEventRepository))
+		pb.RegisterRuntimeServiceServer(grpcServer, v1.NewRuntimeServiceServer(
+			"Version",
+			jobService, nil, nil,
+			projectRepoFactory,
+			namespaceRepoFact,
+			nil,
+			v1.NewAdapter(nil, nil, nil),
+			nil,
+			nil,
+			nil,
+			nil,
+		))
+		listener := bufconn.Listen(1024 * 1024)
+		srv := &http.Server{Handler: grpcHandlerFunc(grpcServer, http.NotFoundHandler())}
+		go srv.Serve(listener)
+		defer srv.Close()
+
+		clientConn := &countingConn{}
+		conn, err := grpc.DialContext(context.Background(), "bufnet",
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+				var err error
+				clientConn.Conn, err = listener.Dial()
+				return clientConn, err
+			}),
+			grpc.WithInsecure(),
+			grpc.WithDefaultCallOptions(
+				grpc.UseCompressor("gzip"),
+				grpc.MaxCallRecvMsgSize(64<<20),
+			),
+		)
+		assert.Nil(t, err)
+		defer conn.Close()
+
+		resp, err := pb.NewRuntimeServiceClient(conn).ListJobSpecification(context.Background(), &pb.ListJobSpecificationRequest{
+			ProjectName: projectSpec.Name,
+			Namespace:   namespaceSpec.Name,
+		})
+		assert.Nil(t, err)
+		// repeated queries compress well, uncompressed response would be larger
+		assert.Less(t, atomic.LoadInt64(&clientConn.read), int64(proto.Size(resp)/4))
+		assert.Equal(t, len(jobSpecs), len(resp.GetJobs()))
+		assert.Equal(t, jobSpecs[0].Assets.ToMap()["query.sql"], resp.GetJobs()[0].GetAssets()["query.sql"])
+	})
+}
+
+// countingConn counts bytes client reads from the server
+type countingConn struct {
+	net.Conn
+	read int64
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	atomic.AddInt64(&c.read, int64(n))
+	return n, err
+}
+
+func TestAssetLoaderFactory(t *testing.T) {
+	ctx := context.Background()
+	t.Run("should not fetch http assets unless their host is allowed", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "select 1")
+		}))
+		defer srv.Close()
+		proj := models.ProjectSpec{Name: "a-data-project"}
+
+		_, err := newAssetLoaderFactory(config.ServerConfig{}).New(proj).Load(ctx, srv.URL+"/query.sql")
+		assert.True(t, errors.Is(err, instance.ErrUnsupportedAssetScheme))
+
+		_, err = newAssetLoaderFactory(config.ServerConfig{AssetAllowedHosts: "assets.example.io"}).New(proj).
+			Load(ctx, srv.URL+"/query.sql")
+		assert.True(t, errors.Is(err, instance.ErrAssetHostNotAllowed))
+
+		content, err := newAssetLoaderFactory(config.ServerConfig{AssetAllowedHosts: "assets.example.io, 127.0.0.1"}).New(proj).
+			Load(ctx, srv.URL+"/query.sql")
+		assert.Nil(t, err)
+		assert.Equal(t, "select 1", string(content))
+	})
+	t.Run("should not read gcs assets of a project without storage secret", func(t *testing.T) {
+		_, err := newAssetLoaderFactory(config.ServerConfig{}).New(models.ProjectSpec{Name: "a-data-project"}).
+			Load(ctx, "gs://shared-assets/query.sql")
+		assert.Equal(t, "STORAGE secret not configured for project a-data-project", err.Error())
+	})
+}
@@ -22,20 +22,42 @@ var (
 	KeyLogLevel  = "log.level"
 	KeyLogFormat = "log.format"
 
-	KeyServeHost                    = "serve.host"
-	KeyServePort                    = "serve.port"
-	KeyServeAppKey                  = "serve.app_key"
-	KeyServeIngressHost             = "serve.ingress_host"
-	KeyServeDBDSN                   = "serve.db.dsn"
-	KeyServeDBMaxIdleConnection     = "serve.db.max_idle_connection"
-	KeyServeDBMaxOpenConnection     = "serve.db.max_open_connection"
-	KeyServeMetadataWriterBatchSize = "serve.metadata.writer_batch_size"
-	KeyServeMetadataKafkaBrokers    = "serve.metadata.kafka_brokers"
-	KeyServeMetadataKafkaJobTopic   = "serve.metadata.kafka_job_topic"
-	KeyServeMetadataKafkaBatchSize  = "serve.metadata.kafka_batch_size"
-	KeyServeReplayNumWorkers        = "serve.replay_num_workers"
-	KeyServeReplayWorkerTimeoutSecs = "serve.replay_worker_timeout_secs"
-	KeyServeReplayRunTimeoutSecs    = "serve.replay_run_timeout_secs"
+	KeyServeHost                     = "serve.host"
+	KeyServePort                     = "serve.port"
+	KeyServeHTTPPort                 = "serve.http_port"
+	KeyServeAppKey                   = "serve.app_key"
+	KeyServePreviousAppKeys          = "serve.previous_app_keys"
+	KeyServeIngressHost              = "serve.ingress_host"
+	KeyServeDBDSN                    = "serve.db.dsn"
+	KeyServeDBMaxIdleConnection      = "serve.db.max_idle_connection"
+	KeyServeDBMaxOpenConnection      = "serve.db.max_open_connection"
+	KeyServeMetadataWriterBatchSize  = "serve.metadata.writer_batch_size"
+	KeyServeMetadataKafkaBrokers     = "serve.metadata.kafka_brokers"
+	KeyServeMetadataKafkaJobTopic    = "serve.metadata.kafka_job_topic"
+	KeyServeMetadataKafkaBatchSize   = "serve.metadata.kafka_batch_size"
+	KeyServeReplayNumWorkers         = "serve.replay_num_workers"
+	KeyServeReplayWorkerTimeoutSecs  = "serve.replay_worker_timeout_secs"
+	KeyServeReplayRunTimeoutSecs     = "serve.replay_run_timeout_secs"
+	KeyServeCompileCacheSize         = "serve.compile_cache_size"
+	KeyServeCompileCachePath         = "serve.compile_cache_path"
+	KeyServeResourceApplyTimeout     = "serve.resource_apply_timeout_secs"
+	KeyServeResourceApplyConcurrency = "serve.resource_apply_concurrency"
+	KeyServeDeployConcurrency        = "serve.deploy_concurrency"
+	KeyServeDeployRejectExcess       = "serve.deploy_reject_excess"
+	KeyServeDeployMaxJobs            = "serve.deploy_max_jobs"
+	KeyServeDeployMaxSpecBytes       = "serve.deploy_max_spec_bytes"
+	KeyServeDeployQueueWorkers       = "serve.deploy_queue_workers"
+	KeyServeDeployQueueSize          = "serve.deploy_queue_size"
+	KeyServeAllowDuplicateDests      = "serve.allow_duplicate_destinations"
+	KeyServeSLACheckIntervalSecs     = "serve.sla_check_interval_secs"
+	KeyServeJobSyncWorkers           = "serve.job_sync_workers"
+	KeyServeJobSyncTicketsPerSec     = "serve.job_sync_tickets_per_sec"
+	KeyServeGlobalConfig             = "serve.global_config"
+	KeyServeRequestTimeoutSecs       = "serve.request_timeout_secs"
+	KeyServeMaxMessageBytes          = "serve.max_message_bytes"
+	KeyServeIdentityHeader           = "serve.identity_header"
+	KeyServeAssetAllowedHosts        = "serve.asset_allowed_hosts"
+	KeyServeAssetMaxBytes            = "serve.asset_max_bytes"
 
 	KeySchedulerName = "scheduler.name"
 
@@ -91,6 +113,9 @@ type LogConfig struct {
 type ServerConfig struct {
 	// port to listen on
 	Port int `yaml:"port"`
+	// optional port to serve the http gateway on, gateway shares the grpc
+	// port if not set
+	HTTPPort int `yaml:"http_port"`
 	// the network interface to listen on
 	Host string `yaml:"host"`
 
@@ -99,12 +124,69 @@ type ServerConfig struct {
 
 	// random 32 character hash used for encrypting secrets
 	AppKey string `yaml:"app_key"`
+	// comma separated app keys used before the current one, secrets
+	// encrypted with them stay readable until they are rotated
+	PreviousAppKeys string `yaml:"previous_app_keys"`
 
 	DB                      DBConfig       `yaml:"db"`
 	Metadata                MetadataConfig `yaml:"metadata"`
 	ReplayNumWorkers        int            `yaml:"replay_num_workers"`
 	ReplayWorkerTimeoutSecs time.Duration  `yaml:"replay_worker_timeout_secs"`
 	ReplayRunTimeoutSecs    time.Duration  `yaml:"replay_run_timeout_secs"`
+
+	// number of compiled jobs kept in memory to skip recompiling unchanged jobs
+	CompileCacheSize int `yaml:"compile_cache_size"`
+	// optional file used to persist compiled jobs across restarts
+	CompileCachePath string `yaml:"compile_cache_path"`
+
+	// time allowed for each resource to be created/updated in datastore
+	ResourceApplyTimeoutSecs time.Duration `yaml:"resource_apply_timeout_secs"`
+	// number of independent resources applied in datastore at a time
+	ResourceApplyConcurrency int `yaml:"resource_apply_concurrency"`
+
+	// number of job and resource deploys running at a time across projects
+	DeployConcurrency int `yaml:"deploy_concurrency"`
+	// reject deploys over the concurrency limit instead of queuing them
+	DeployRejectExcess bool `yaml:"deploy_reject_excess"`
+	// number of jobs accepted in a single deploy request, unlimited if not set
+	DeployMaxJobs int `yaml:"deploy_max_jobs"`
+	// serialized size in bytes of a single job or resource spec, unlimited if not set
+	DeployMaxSpecBytes int `yaml:"deploy_max_spec_bytes"`
+	// number of workers running queued deploys, queued deploys are
+	// disabled if not set
+	DeployQueueWorkers int `yaml:"deploy_queue_workers"`
+	// number of queued deploys waiting for a worker
+	DeployQueueSize int `yaml:"deploy_queue_size"`
+
+	// only warn about jobs writing the same destination instead of failing the deploy
+	AllowDuplicateDestinations bool `yaml:"allow_duplicate_destinations"`
+
+	// time between checks of job runs against their sla, sla of jobs is
+	// not monitored if not set
+	SLACheckIntervalSecs time.Duration `yaml:"sla_check_interval_secs"`
+
+	// number of jobs resolved, compiled and uploaded concurrently by a sync
+	// and number of jobs picked up per second across them
+	JobSyncWorkers       int `yaml:"job_sync_workers"`
+	JobSyncTicketsPerSec int `yaml:"job_sync_tickets_per_sec"`
+
+	// configs shared by jobs of all projects, referred as {{.global.KEY}}
+	// and used for {{.proj.KEY}} when neither namespace nor project has it
+	GlobalConfig map[string]string `yaml:"global_config"`
+
+	// time a unary request is served for, unlimited if not set
+	RequestTimeoutSecs time.Duration `yaml:"request_timeout_secs"`
+	// size in bytes of a single message received or sent by server
+	MaxMessageBytes int `yaml:"max_message_bytes"`
+	// header the proxy authenticating callers sets to the caller, recorded
+	// in audit events, not read if not set
+	IdentityHeader string `yaml:"identity_header"`
+
+	// comma separated hosts job and resource assets can be fetched from
+	// over http(s), such assets are not fetched if not set
+	AssetAllowedHosts string `yaml:"asset_allowed_hosts"`
+	// size in bytes of a remote asset read at most, defaults to 1MB
+	AssetMaxBytes int64 `yaml:"asset_max_bytes"`
 }
 
 type DBConfig struct {
@@ -178,9 +260,12 @@ func (o Optimus) GetLog() LogConfig {
 func (o Optimus) GetServe() ServerConfig {
 	return ServerConfig{
 		Port:        o.k.Int(KeyServePort),
+		HTTPPort:    o.eKi(KeyServeHTTPPort),
 		Host:        o.k.String(KeyServeHost),
 		IngressHost: o.eKs(KeyServeIngressHost),
 		AppKey:      o.eKs(KeyServeAppKey),
+
+		PreviousAppKeys: o.eKs(KeyServePreviousAppKeys),
 		DB: DBConfig{
 			DSN:               o.k.String(KeyServeDBDSN),
 			MaxIdleConnection: o.eKi(KeyServeDBMaxIdleConnection),
@@ -195,6 +280,29 @@ func (o Optimus) GetServe() ServerConfig {
 		ReplayNumWorkers:        o.k.Int(KeyServeReplayNumWorkers),
 		ReplayWorkerTimeoutSecs: time.Second * time.Duration(o.k.Int(KeyServeReplayWorkerTimeoutSecs)),
 		ReplayRunTimeoutSecs:    time.Second * time.Duration(o.k.Int(KeyServeReplayRunTimeoutSecs)),
+		CompileCacheSize:        o.k.Int(KeyServeCompileCacheSize),
+		CompileCachePath:        o.k.String(KeyServeCompileCachePath),
+
+		ResourceApplyTimeoutSecs: time.Second * time.Duration(o.k.Int(KeyServeResourceApplyTimeout)),
+		ResourceApplyConcurrency: o.k.Int(KeyServeResourceApplyConcurrency),
+
+		DeployConcurrency:  o.k.Int(KeyServeDeployConcurrency),
+		DeployRejectExcess: o.k.Bool(KeyServeDeployRejectExcess),
+		DeployMaxJobs:      o.eKi(KeyServeDeployMaxJobs),
+		DeployMaxSpecBytes: o.eKi(KeyServeDeployMaxSpecBytes),
+		DeployQueueWorkers: o.eKi(KeyServeDeployQueueWorkers),
+		DeployQueueSize:    o.eKi(KeyServeDeployQueueSize),
+
+		AllowDuplicateDestinations: o.k.Bool(KeyServeAllowDuplicateDests),
+		SLACheckIntervalSecs:       time.Second * time.Duration(o.eKi(KeyServeSLACheckIntervalSecs)),
+		JobSyncWorkers:             o.eKi(KeyServeJobSyncWorkers),
+		JobSyncTicketsPerSec:       o.eKi(KeyServeJobSyncTicketsPerSec),
+		GlobalConfig:               o.k.StringMap(KeyServeGlobalConfig),
+		RequestTimeoutSecs:         time.Second * time.Duration(o.eKi(KeyServeRequestTimeoutSecs)),
+		MaxMessageBytes:            o.eKi(KeyServeMaxMessageBytes),
+		IdentityHeader:             o.eKs(KeyServeIdentityHeader),
+		AssetAllowedHosts:          o.eKs(KeyServeAssetAllowedHosts),
+		AssetMaxBytes:              int64(o.eKi(KeyServeAssetMaxBytes)),
 	}
 }
 
@@ -50,17 +50,27 @@ func InitOptimus() (*Optimus, error) {
 
 	// load defaults
 	if err := configuration.k.Load(confmap.Provider(map[string]interface{}{
-		KeyLogLevel:                     "info",
-		KeyServePort:                    9100,
-		KeyServeHost:                    "0.0.0.0",
-		KeyServeDBMaxOpenConnection:     10,
-		KeyServeDBMaxIdleConnection:     5,
-		KeyServeMetadataKafkaJobTopic:   "resource_optimus_job_log",
-		KeyServeMetadataKafkaBatchSize:  50,
-		KeyServeMetadataWriterBatchSize: 50,
-		KeySchedulerName:                "airflow2",
-		KeyServeReplayNumWorkers:        1,
-		KeyServeReplayWorkerTimeoutSecs: 120,
+		KeyLogLevel:                      "info",
+		KeyLogFormat:                     "json",
+		KeyServePort:                     9100,
+		KeyServeHost:                     "0.0.0.0",
+		KeyServeDBMaxOpenConnection:      10,
+		KeyServeDBMaxIdleConnection:      5,
+		KeyServeMetadataKafkaJobTopic:    "resource_optimus_job_log",
+		KeyServeMetadataKafkaBatchSize:   50,
+		KeyServeMetadataWriterBatchSize:  50,
+		KeySchedulerName:                 "airflow2",
+		KeyServeReplayNumWorkers:         1,
+		KeyServeReplayWorkerTimeoutSecs:  120,
+		KeyServeCompileCacheSize:         5000,
+		KeyServeResourceApplyTimeout:     300,
+		KeyServeResourceApplyConcurrency: 5,
+		KeyServeDeployQueueWorkers:       2,
+		KeyServeDeployQueueSize:          100,
+		KeyServeSLACheckIntervalSecs:     300,
+		KeyServeJobSyncWorkers:           600,
+		KeyServeJobSyncTicketsPerSec:     40,
+		KeyServeRequestTimeoutSecs:       300,
 	}, "."), nil); err != nil {
 		return nil, errors.Wrap(err, "k.Load: error loading config defaults")
 	}
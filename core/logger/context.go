@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fields added to logs scoped to a request
+const (
+	FieldRequestID = "request_id"
+	FieldCaller    = "caller"
+	FieldProject   = "project"
+	FieldNamespace = "namespace"
+	FieldJob       = "job"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying l, logs written with the logger
+// returned by FromContext for this context include fields of l
+func NewContext(ctx context.Context, l logrus.FieldLogger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// WithFields returns a copy of ctx whose logger additionally includes fields
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return NewContext(ctx, FromContext(ctx).WithFields(fields))
+}
+
+// FromContext returns the logger carried by ctx, or the default logger if
+// ctx doesn't carry one
+func FromContext(ctx context.Context) logrus.FieldLogger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(logrus.FieldLogger); ok {
+			return l
+		}
+	}
+	if entry == nil {
+		return logrus.StandardLogger()
+	}
+	return entry
+}
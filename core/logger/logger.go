@@ -5,6 +5,7 @@ import (
 	"io"
 	goLog "log"
 	"os"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -19,19 +20,44 @@ const (
 	WARNING = "WARNING"
 	ERROR   = "ERROR"
 	FATAL   = "FATAL"
+
+	// FormatJSON writes every log as a json object, used by default
+	FormatJSON = "json"
+	// FormatPlain writes logs as text lines with fields as key=value
+	FormatPlain = "plain"
 )
 
 func Init(mode string) {
 	InitWithWriter(mode, os.Stderr)
 }
 
+// InitWithFormat initializes the logger at mode level writing logs in the
+// given format, one of FormatJSON or FormatPlain
+func InitWithFormat(mode, format string) {
+	initLogger(mode, format, os.Stderr)
+}
+
 func InitWithWriter(mode string, writer io.Writer) {
+	initLogger(mode, FormatJSON, writer)
+}
+
+// NewFormatter returns the logrus formatter of a log format, unknown
+// formats fall back to FormatJSON
+func NewFormatter(format string) logrus.Formatter {
+	switch strings.ToLower(format) {
+	case FormatPlain, "text":
+		return &logrus.TextFormatter{FullTimestamp: true}
+	}
+	return new(logrus.JSONFormatter)
+}
+
+func initLogger(mode, format string, writer io.Writer) {
 	if log != nil {
 		return
 	}
 	log = logrus.New()
 	log.Out = writer
-	log.Formatter = new(logrus.JSONFormatter)
+	log.Formatter = NewFormatter(format)
 	log.Level = logrus.InfoLevel
 
 	if l, err := logrus.ParseLevel(mode); err != nil {
@@ -29,6 +29,41 @@ func (t *TreeNode) GetAllNodes() []*TreeNode {
 	return allNodes
 }
 
+// GetAllNodesInTopologicalOrder returns every node reachable from current
+// node once, a node is placed only after all of its parents in the tree
+func (t *TreeNode) GetAllNodesInTopologicalOrder() []*TreeNode {
+	// count parents of each node, nodes can be shared between parents
+	parentCount := map[string]int{}
+	visited := map[string]bool{t.GetName(): true}
+	nodesQueue := []*TreeNode{t}
+	for len(nodesQueue) != 0 {
+		topNode := nodesQueue[0]
+		nodesQueue = nodesQueue[1:]
+		for _, dependent := range topNode.Dependents {
+			parentCount[dependent.GetName()]++
+			if !visited[dependent.GetName()] {
+				visited[dependent.GetName()] = true
+				nodesQueue = append(nodesQueue, dependent)
+			}
+		}
+	}
+
+	allNodes := make([]*TreeNode, 0, len(visited))
+	nodesQueue = []*TreeNode{t}
+	for len(nodesQueue) != 0 {
+		topNode := nodesQueue[0]
+		nodesQueue = nodesQueue[1:]
+		allNodes = append(allNodes, topNode)
+		for _, dependent := range topNode.Dependents {
+			parentCount[dependent.GetName()]--
+			if parentCount[dependent.GetName()] == 0 {
+				nodesQueue = append(nodesQueue, dependent)
+			}
+		}
+	}
+	return allNodes
+}
+
 func (t *TreeNode) GetName() string {
 	return t.Data.GetName()
 }
@@ -50,4 +50,19 @@ func TestDagNode(t *testing.T) {
 		assert.Equal(t, "job-level-1", allNodes[1].Data.GetName())
 		assert.Equal(t, "job-level-2", allNodes[2].Data.GetName())
 	})
+	t.Run("GetAllNodesInTopologicalOrder", func(t *testing.T) {
+		// job-a -> job-b -> job-c and job-a -> job-c
+		nodeA := tree.NewTreeNode(models.JobSpec{Name: "job-a"})
+		nodeB := tree.NewTreeNode(models.JobSpec{Name: "job-b"})
+		nodeC := tree.NewTreeNode(models.JobSpec{Name: "job-c"})
+		nodeA.AddDependent(nodeC)
+		nodeA.AddDependent(nodeB)
+		nodeB.AddDependent(nodeC)
+
+		allNodes := nodeA.GetAllNodesInTopologicalOrder()
+		assert.Equal(t, 3, len(allNodes))
+		assert.Equal(t, "job-a", allNodes[0].GetName())
+		assert.Equal(t, "job-b", allNodes[1].GetName())
+		assert.Equal(t, "job-c", allNodes[2].GetName())
+	})
 }
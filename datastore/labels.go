@@ -0,0 +1,83 @@
+package datastore
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// labelValueMaxLength is the maximum length of a label value allowed by bigquery
+	labelValueMaxLength = 63
+)
+
+var (
+	// labelValueRegex follows bigquery label value rules, lowercase letters,
+	// international characters, numbers, underscores and dashes are allowed
+	labelValueRegex = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]*$`)
+)
+
+// renderLabels renders templated label values of the resource using configs
+// of the project overridden by namespace configs, e.g. {{.proj.ENV}}. Keys are
+// kept literal and stored spec keeps the template so the same spec can be
+// applied to projects with different configs
+func renderLabels(resourceSpec models.ResourceSpec, namespace models.NamespaceSpec) (models.ResourceSpec, error) {
+	if len(resourceSpec.Labels) == 0 {
+		return resourceSpec, nil
+	}
+
+	configs := map[string]string{}
+	for key, val := range namespace.ProjectSpec.Config {
+		configs[key] = val
+	}
+	for key, val := range namespace.Config {
+		configs[key] = val
+	}
+	templateContext := map[string]interface{}{
+		models.ProjectConfigTemplateKey: configs,
+	}
+
+	labels := map[string]string{}
+	for key, value := range resourceSpec.Labels {
+		if !strings.Contains(value, "{{") {
+			labels[key] = value
+			continue
+		}
+		for _, ref := range models.ProjectConfigReferencesIn(value) {
+			if _, ok := configs[ref]; !ok {
+				return resourceSpec, errors.Errorf("label %s of resource %s refers %s which is not a project config",
+					key, resourceSpec.Name, ref)
+			}
+		}
+
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return resourceSpec, errors.Wrapf(err, "failed to parse label %s of resource %s", key, resourceSpec.Name)
+		}
+		var rendered bytes.Buffer
+		if err := tmpl.Execute(&rendered, templateContext); err != nil {
+			return resourceSpec, errors.Wrapf(err, "failed to render label %s of resource %s", key, resourceSpec.Name)
+		}
+		if err := validateLabelValue(rendered.String()); err != nil {
+			return resourceSpec, errors.Wrapf(err, "invalid label %s of resource %s", key, resourceSpec.Name)
+		}
+		labels[key] = rendered.String()
+	}
+	resourceSpec.Labels = labels
+	return resourceSpec, nil
+}
+
+func validateLabelValue(value string) error {
+	if len([]rune(value)) > labelValueMaxLength {
+		return errors.Errorf("rendered value %s is longer than %d characters", value, labelValueMaxLength)
+	}
+	if !labelValueRegex.MatchString(value) {
+		return errors.Errorf("rendered value %s can only contain lowercase letters, numbers, underscores and dashes", value)
+	}
+	return nil
+}
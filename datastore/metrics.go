@@ -0,0 +1,94 @@
+package datastore
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/odpf/optimus/models"
+)
+
+const callResultSuccess = "success"
+
+var (
+	datastoreCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "optimus",
+		Subsystem: "datastore",
+		Name:      "calls_total",
+		Help:      "Number of calls made to datastores, result is the error category of failed calls",
+	}, []string{"datastore", "operation", "result"})
+
+	datastoreCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "optimus",
+		Subsystem: "datastore",
+		Name:      "call_duration_seconds",
+		Help:      "Time taken by calls made to datastores",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"datastore", "operation"})
+)
+
+// instrumentedDatastore records count and duration of every call made to
+// the wrapped datastore
+type instrumentedDatastore struct {
+	models.Datastorer
+}
+
+func instrument(ds models.Datastorer) models.Datastorer {
+	return &instrumentedDatastore{Datastorer: ds}
+}
+
+func (ds *instrumentedDatastore) observe(operation string, startTime time.Time, err error) {
+	result := callResultSuccess
+	if err != nil {
+		result = string(models.DatastoreErrorCategoryUnknown)
+		var dsErr *models.DatastoreError
+		if errors.As(err, &dsErr) {
+			result = string(dsErr.Category)
+		}
+	}
+	datastoreCalls.WithLabelValues(ds.Name(), operation, result).Inc()
+	datastoreCallDuration.WithLabelValues(ds.Name(), operation).Observe(time.Since(startTime).Seconds())
+}
+
+func (ds *instrumentedDatastore) CreateResource(ctx context.Context, req models.CreateResourceRequest) (err error) {
+	defer func(startTime time.Time) { ds.observe("create", startTime, err) }(time.Now())
+	return ds.Datastorer.CreateResource(ctx, req)
+}
+
+func (ds *instrumentedDatastore) UpdateResource(ctx context.Context, req models.UpdateResourceRequest) (err error) {
+	defer func(startTime time.Time) { ds.observe("update", startTime, err) }(time.Now())
+	return ds.Datastorer.UpdateResource(ctx, req)
+}
+
+func (ds *instrumentedDatastore) ReadResource(ctx context.Context, req models.ReadResourceRequest) (resp models.ReadResourceResponse, err error) {
+	defer func(startTime time.Time) { ds.observe("read", startTime, err) }(time.Now())
+	return ds.Datastorer.ReadResource(ctx, req)
+}
+
+func (ds *instrumentedDatastore) DeleteResource(ctx context.Context, req models.DeleteResourceRequest) (err error) {
+	defer func(startTime time.Time) { ds.observe("delete", startTime, err) }(time.Now())
+	return ds.Datastorer.DeleteResource(ctx, req)
+}
+
+func (ds *instrumentedDatastore) RenameResource(ctx context.Context, req models.RenameResourceRequest) (err error) {
+	defer func(startTime time.Time) { ds.observe("rename", startTime, err) }(time.Now())
+	return ds.Datastorer.RenameResource(ctx, req)
+}
+
+func (ds *instrumentedDatastore) BackupResource(ctx context.Context, req models.BackupResourceRequest) (resp models.BackupResourceResponse, err error) {
+	defer func(startTime time.Time) { ds.observe("backup", startTime, err) }(time.Now())
+	return ds.Datastorer.BackupResource(ctx, req)
+}
+
+func (ds *instrumentedDatastore) RestoreResource(ctx context.Context, req models.RestoreResourceRequest) (err error) {
+	defer func(startTime time.Time) { ds.observe("restore", startTime, err) }(time.Now())
+	return ds.Datastorer.RestoreResource(ctx, req)
+}
+
+func (ds *instrumentedDatastore) CheckDatastore(ctx context.Context, req models.CheckDatastoreRequest) (err error) {
+	defer func(startTime time.Time) { ds.observe("check", startTime, err) }(time.Now())
+	return ds.Datastorer.CheckDatastore(ctx, req)
+}
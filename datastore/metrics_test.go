@@ -0,0 +1,34 @@
+package datastore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+)
+
+func TestInstrumentedDatastore(t *testing.T) {
+	t.Run("should count calls by result and category of failure", func(t *testing.T) {
+		createReq := models.CreateResourceRequest{Resource: models.ResourceSpec{Name: "proj.datas"}}
+		checkReq := models.CheckDatastoreRequest{Project: models.ProjectSpec{Name: "a-data-project"}}
+		checkErr := &models.DatastoreError{Category: models.DatastoreErrorCategoryAuth, Err: errors.New("invalid token")}
+
+		datastorer := new(mock.Datastorer)
+		datastorer.On("Name").Return("metrics-store")
+		datastorer.On("CreateResource", context.Background(), createReq).Return(nil)
+		datastorer.On("CheckDatastore", context.Background(), checkReq).Return(errors.Wrap(checkErr, "check failed"))
+		defer datastorer.AssertExpectations(t)
+
+		ds := instrument(datastorer)
+		assert.Nil(t, ds.CreateResource(context.Background(), createReq))
+		assert.True(t, errors.Is(ds.CheckDatastore(context.Background(), checkReq), checkErr))
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(datastoreCalls.WithLabelValues("metrics-store", "create", "success")))
+		assert.Equal(t, float64(1), testutil.ToFloat64(datastoreCalls.WithLabelValues("metrics-store", "check", "auth")))
+	})
+}
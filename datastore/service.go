@@ -3,11 +3,16 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/odpf/optimus/core/progress"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
 	"github.com/kushsharma/parallel"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
 
 	"github.com/odpf/optimus/store"
 
@@ -17,19 +22,53 @@ import (
 const (
 	ConcurrentTicketPerSec = 5
 	ConcurrentLimit        = 20
+
+	// DefaultResourceApplyTimeout is the time a single resource is allowed
+	// to take while being created/updated in datastore
+	DefaultResourceApplyTimeout = 5 * time.Minute
+
+	// DefaultResourceApplyConcurrency is the number of independent resources
+	// created/updated in datastore at a time, kept small to respect rate
+	// limits of datastores
+	DefaultResourceApplyConcurrency = 5
+)
+
+var (
+	ErrResourceApplyTimeout = errors.New("timed out applying resource")
+
+	// ErrResourceDependencyCycle is returned when resources depend on each
+	// other and can't be ordered for applying
+	ErrResourceDependencyCycle = errors.New("cyclic dependency between resources")
+
+	// ErrBackupExpired is returned when restoring a backup whose copy in
+	// datastore has already expired
+	ErrBackupExpired = errors.New("backup has expired")
 )
 
 type ResourceSpecRepoFactory interface {
 	New(namespace models.NamespaceSpec, storer models.Datastorer) store.ResourceSpecRepository
 }
 
+type BackupRepoFactory interface {
+	New(namespace models.NamespaceSpec, storer models.Datastorer) store.BackupRepository
+}
+
 type ProjectResourceSpecRepoFactory interface {
 	New(spec models.ProjectSpec, storer models.Datastorer) store.ProjectResourceSpecRepository
 }
 
 type Service struct {
 	resourceRepoFactory ResourceSpecRepoFactory
+	backupRepoFactory   BackupRepoFactory
 	dsRepo              models.DatastoreRepo
+
+	// ApplyTimeout limits the time each resource can take while being
+	// applied, a stuck resource should not block rest of the deployment
+	ApplyTimeout time.Duration
+
+	// ApplyConcurrency limits the number of resources being applied at
+	// the same time
+	ApplyConcurrency int
 }
 
 func (srv Service) GetAll(namespace models.NamespaceSpec, datastoreName string) ([]models.ResourceSpec, error) {
@@ -40,68 +79,230 @@ func (srv Service) GetAll(namespace models.NamespaceSpec, datastoreName string)
 	return srv.resourceRepoFactory.New(namespace, ds).GetAll()
 }
 
+func (srv Service) GetPage(namespace models.NamespaceSpec, datastoreName, after string, limit int) ([]models.ResourceSpec, error) {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return nil, err
+	}
+	return srv.resourceRepoFactory.New(namespace, ds).GetPage(after, limit)
+}
+
 func (srv Service) CreateResource(ctx context.Context, namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec, obs progress.Observer) error {
-	runner := parallel.NewRunner(parallel.WithLimit(ConcurrentLimit), parallel.WithTicket(ConcurrentTicketPerSec))
-	for _, resourceSpec := range resourceSpecs {
-		currentSpec := resourceSpec
+	return srv.applyInDependencyOrder(resourceSpecs, obs, func(currentSpec models.ResourceSpec) error {
+		// spec is stored with templated labels, only the datastore gets rendered ones
+		renderedSpec, err := renderLabels(currentSpec, namespace)
+		if err != nil {
+			srv.notifyProgress(obs, &EventResourceCreated{
+				Spec: currentSpec,
+				Err:  err,
+			})
+			return err
+		}
+
 		repo := srv.resourceRepoFactory.New(namespace, currentSpec.Datastore)
-		runner.Add(func() (interface{}, error) {
-			if err := repo.Save(currentSpec); err != nil {
-				return nil, err
-			}
+		if err := repo.Save(currentSpec); err != nil {
+			return err
+		}
 
-			err := currentSpec.Datastore.CreateResource(ctx, models.CreateResourceRequest{
-				Resource: currentSpec,
+		err = srv.applyWithTimeout(ctx, currentSpec.Name, func(applyCtx context.Context) error {
+			return instrument(currentSpec.Datastore).CreateResource(applyCtx, models.CreateResourceRequest{
+				Resource: renderedSpec,
 				Project:  namespace.ProjectSpec,
 			})
+		})
+		if errors.Is(err, ErrResourceApplyTimeout) {
+			srv.notifyProgress(obs, &EventResourceApplyTimeout{
+				Spec:    currentSpec,
+				Timeout: srv.applyTimeout(),
+			})
+		} else {
 			srv.notifyProgress(obs, &EventResourceCreated{
 				Spec: currentSpec,
 				Err:  err,
 			})
-			return nil, err
-		})
-	}
-
-	var errorSet error
-	for _, result := range runner.Run() {
-		if result.Err != nil {
-			errorSet = multierror.Append(errorSet, result.Err)
 		}
-	}
-	return errorSet
+		return err
+	})
 }
 
-func (srv Service) UpdateResource(ctx context.Context, namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec, obs progress.Observer) error {
-	runner := parallel.NewRunner(parallel.WithLimit(ConcurrentLimit), parallel.WithTicket(ConcurrentTicketPerSec))
-	for _, resourceSpec := range resourceSpecs {
-		currentSpec := resourceSpec
+func (srv Service) UpdateResource(ctx context.Context, namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec, force bool, obs progress.Observer) error {
+	return srv.applyInDependencyOrder(resourceSpecs, obs, func(currentSpec models.ResourceSpec) error {
+		// spec is stored with templated labels, only the datastore gets rendered ones
+		renderedSpec, err := renderLabels(currentSpec, namespace)
+		if err != nil {
+			srv.notifyProgress(obs, &EventResourceUpdated{
+				Spec: currentSpec,
+				Err:  err,
+			})
+			return err
+		}
+
 		repo := srv.resourceRepoFactory.New(namespace, currentSpec.Datastore)
-		runner.Add(func() (interface{}, error) {
-			if err := repo.Save(currentSpec); err != nil {
-				return nil, err
-			}
+		if err := repo.Save(currentSpec); err != nil {
+			return err
+		}
 
-			err := currentSpec.Datastore.UpdateResource(ctx, models.UpdateResourceRequest{
-				Resource: currentSpec,
+		err = srv.applyWithTimeout(ctx, currentSpec.Name, func(applyCtx context.Context) error {
+			return instrument(currentSpec.Datastore).UpdateResource(applyCtx, models.UpdateResourceRequest{
+				Resource: renderedSpec,
 				Project:  namespace.ProjectSpec,
+				Force:    force,
+			})
+		})
+		if errors.Is(err, ErrResourceApplyTimeout) {
+			srv.notifyProgress(obs, &EventResourceApplyTimeout{
+				Spec:    currentSpec,
+				Timeout: srv.applyTimeout(),
 			})
+		} else {
 			srv.notifyProgress(obs, &EventResourceUpdated{
 				Spec: currentSpec,
 				Err:  err,
 			})
-			return nil, err
-		})
+		}
+		return err
+	})
+}
+
+func (srv Service) SaveResource(namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec) error {
+	for _, resourceSpec := range resourceSpecs {
+		if err := srv.resourceRepoFactory.New(namespace, resourceSpec.Datastore).Save(resourceSpec); err != nil {
+			return errors.Wrapf(err, "failed to save resource %s", resourceSpec.Name)
+		}
+	}
+	return nil
+}
+
+// applyInDependencyOrder applies resources concurrently in levels, a resource
+// is applied only after the resources it depends on in the same batch are
+// applied and is skipped if any of them failed. Dependencies outside the
+// batch are expected to exist already
+func (srv Service) applyInDependencyOrder(resourceSpecs []models.ResourceSpec, obs progress.Observer,
+	apply func(models.ResourceSpec) error) error {
+	levels, err := dependencyLevels(resourceSpecs)
+	if err != nil {
+		return err
+	}
+
+	concurrency := srv.ApplyConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultResourceApplyConcurrency
 	}
 
+	inBatch := batchSpecs(resourceSpecs)
+	failed := map[string]error{}
 	var errorSet error
-	for _, result := range runner.Run() {
-		if result.Err != nil {
-			errorSet = multierror.Append(errorSet, result.Err)
+	for _, level := range levels {
+		runner := parallel.NewRunner(parallel.WithLimit(concurrency), parallel.WithTicket(ConcurrentTicketPerSec))
+		var running []models.ResourceSpec
+		for _, resourceSpec := range level {
+			if dependency, depErr := failedDependency(resourceSpec, inBatch, failed); depErr != nil {
+				skipErr := errors.Errorf("skipped %s as %s failed: %s", resourceSpec.Name, dependency, depErr.Error())
+				srv.notifyProgress(obs, &EventResourceSkipped{
+					Spec:       resourceSpec,
+					Dependency: dependency,
+					Err:        depErr,
+				})
+				failed[resourceSpec.Name] = skipErr
+				errorSet = multierror.Append(errorSet, skipErr)
+				continue
+			}
+
+			currentSpec := resourceSpec
+			running = append(running, currentSpec)
+			runner.Add(func() (interface{}, error) {
+				return nil, apply(currentSpec)
+			})
+		}
+		for idx, result := range runner.Run() {
+			if result.Err != nil {
+				failed[running[idx].Name] = result.Err
+				errorSet = multierror.Append(errorSet, result.Err)
+			}
 		}
 	}
 	return errorSet
 }
 
+// batchSpecs indexes resources of the batch by name
+func batchSpecs(resourceSpecs []models.ResourceSpec) map[string]models.ResourceSpec {
+	inBatch := map[string]models.ResourceSpec{}
+	for _, spec := range resourceSpecs {
+		inBatch[spec.Name] = spec
+	}
+	return inBatch
+}
+
+// dependenciesInBatch returns the resources of the batch spec depends on,
+// besides the listed ones a resource depends on the dataset of the batch
+// it belongs to by name, e.g. table proj.dataset.table on proj.dataset
+func dependenciesInBatch(spec models.ResourceSpec, inBatch map[string]models.ResourceSpec) []string {
+	var dependencies []string
+	for _, dependency := range spec.DependsOn {
+		if _, ok := inBatch[dependency]; ok {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	if idx := strings.LastIndex(spec.Name, "."); idx > 0 {
+		parentName := spec.Name[:idx]
+		if parent, ok := inBatch[parentName]; ok && parent.Type == models.ResourceTypeDataset {
+			dependencies = append(dependencies, parentName)
+		}
+	}
+	return dependencies
+}
+
+// failedDependency returns the first dependency of spec that failed to be
+// applied along with its error
+func failedDependency(spec models.ResourceSpec, inBatch map[string]models.ResourceSpec, failed map[string]error) (string, error) {
+	for _, dependency := range dependenciesInBatch(spec, inBatch) {
+		if err, ok := failed[dependency]; ok {
+			return dependency, err
+		}
+	}
+	return "", nil
+}
+
+// dependencyLevels groups resources such that each resource only depends on
+// resources of earlier levels, order of the request is kept within a level
+func dependencyLevels(resourceSpecs []models.ResourceSpec) ([][]models.ResourceSpec, error) {
+	inBatch := batchSpecs(resourceSpecs)
+
+	applied := map[string]bool{}
+	pending := resourceSpecs
+	var levels [][]models.ResourceSpec
+	for len(pending) > 0 {
+		var level, blocked []models.ResourceSpec
+		for _, spec := range pending {
+			ready := true
+			for _, dependency := range dependenciesInBatch(spec, inBatch) {
+				if !applied[dependency] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				level = append(level, spec)
+			} else {
+				blocked = append(blocked, spec)
+			}
+		}
+		if len(level) == 0 {
+			var names []string
+			for _, spec := range blocked {
+				names = append(names, spec.Name)
+			}
+			return nil, errors.Wrapf(ErrResourceDependencyCycle, "resources %s", strings.Join(names, ", "))
+		}
+		for _, spec := range level {
+			applied[spec.Name] = true
+		}
+		levels = append(levels, level)
+		pending = blocked
+	}
+	return levels, nil
+}
+
 func (srv Service) ReadResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string) (models.ResourceSpec, error) {
 	ds, err := srv.dsRepo.GetByName(datastoreName)
 	if err != nil {
@@ -113,7 +314,7 @@ func (srv Service) ReadResource(ctx context.Context, namespace models.NamespaceS
 		return models.ResourceSpec{}, err
 	}
 
-	infoResponse, err := dbSpec.Datastore.ReadResource(ctx, models.ReadResourceRequest{
+	infoResponse, err := instrument(dbSpec.Datastore).ReadResource(ctx, models.ReadResourceRequest{
 		Resource: dbSpec,
 		Project:  namespace.ProjectSpec,
 	})
@@ -123,7 +324,9 @@ func (srv Service) ReadResource(ctx context.Context, namespace models.NamespaceS
 	return infoResponse.Resource, nil
 }
 
-func (srv Service) DeleteResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string) error {
+// DeleteResource removes stored spec of resource, the resource is deleted from
+// datastore first when deleteFromDatastore is set and spec is kept if that fails
+func (srv Service) DeleteResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string, deleteFromDatastore bool) error {
 	ds, err := srv.dsRepo.GetByName(datastoreName)
 	if err != nil {
 		return err
@@ -135,14 +338,268 @@ func (srv Service) DeleteResource(ctx context.Context, namespace models.Namespac
 	}
 
 	// migrate the deleted resource
-	if err := resourceSpec.Datastore.DeleteResource(ctx, models.DeleteResourceRequest{
+	if deleteFromDatastore {
+		if err := instrument(resourceSpec.Datastore).DeleteResource(ctx, models.DeleteResourceRequest{
+			Resource: resourceSpec,
+			Project:  namespace.ProjectSpec,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return repo.Delete(name)
+}
+
+// RenameResource moves resource to a new name in datastore and then moves
+// its stored spec, the new name should not be in use in the namespace
+func (srv Service) RenameResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, oldName, newName string, allowCopy bool) error {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return err
+	}
+	repo := srv.resourceRepoFactory.New(namespace, ds)
+	resourceSpec, err := repo.GetByName(oldName)
+	if err != nil {
+		return err
+	}
+	if _, err := repo.GetByName(newName); err == nil {
+		return errors.Wrapf(models.ErrResourceExists, "resource %s", newName)
+	} else if !errors.Is(err, store.ErrResourceNotFound) {
+		return err
+	}
+
+	newSpec, err := renamedSpec(resourceSpec, newName)
+	if err != nil {
+		return err
+	}
+	renderedSpec, err := renderLabels(resourceSpec, namespace)
+	if err != nil {
+		return err
+	}
+	renderedNewSpec, err := renderLabels(newSpec, namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := instrument(resourceSpec.Datastore).RenameResource(ctx, models.RenameResourceRequest{
+		Resource:    renderedSpec,
+		NewResource: renderedNewSpec,
+		Project:     namespace.ProjectSpec,
+		AllowCopy:   allowCopy,
+	}); err != nil {
+		return err
+	}
+
+	if err := repo.Save(newSpec); err != nil {
+		return err
+	}
+	return repo.Delete(oldName)
+}
+
+// renamedSpec prepares spec of the resource with its new name, name is parsed
+// by the datastore so spec goes through its adapter to stay in sync with name
+func renamedSpec(resourceSpec models.ResourceSpec, newName string) (models.ResourceSpec, error) {
+	typeController, ok := resourceSpec.Datastore.Types()[resourceSpec.Type]
+	if !ok {
+		return models.ResourceSpec{}, errors.Errorf("unsupported resource type %s", resourceSpec.Type)
+	}
+	adapter := typeController.Adapter()
+	specBytes, err := adapter.ToYaml(resourceSpec)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	var yamlSpec map[string]interface{}
+	if err := yaml.Unmarshal(specBytes, &yamlSpec); err != nil {
+		return models.ResourceSpec{}, err
+	}
+	yamlSpec["name"] = newName
+	if specBytes, err = yaml.Marshal(yamlSpec); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	newSpec, err := adapter.FromYaml(specBytes)
+	if err != nil {
+		return models.ResourceSpec{}, errors.Wrapf(err, "invalid resource name %s", newName)
+	}
+	if err := typeController.Validator()(newSpec); err != nil {
+		return models.ResourceSpec{}, errors.Wrapf(err, "invalid resource name %s", newName)
+	}
+	// assets are not part of yaml spec
+	newSpec.Assets = resourceSpec.Assets
+	return newSpec, nil
+}
+
+// BackupResource copies resource in datastore and records the backup along
+// with the spec resource has at the moment, copy expires after ttl
+func (srv Service) BackupResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string,
+	ttl time.Duration, description string) (models.BackupSpec, error) {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return models.BackupSpec{}, err
+	}
+	resourceSpec, err := srv.resourceRepoFactory.New(namespace, ds).GetByName(name)
+	if err != nil {
+		return models.BackupSpec{}, err
+	}
+	if ttl <= 0 {
+		ttl = models.DefaultBackupTTL
+	}
+
+	now := time.Now()
+	backupSpec := models.BackupSpec{
+		ID:          uuid.New(),
+		Resource:    resourceSpec,
+		Description: description,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+	resp, err := instrument(resourceSpec.Datastore).BackupResource(ctx, models.BackupResourceRequest{
+		Resource: resourceSpec,
+		Project:  namespace.ProjectSpec,
+		ID:       backupSpec.ID,
+		TTL:      ttl,
+	})
+	if err != nil {
+		return models.BackupSpec{}, errors.Wrapf(err, "failed to backup resource %s", name)
+	}
+	backupSpec.URN = resp.URN
+
+	if err := srv.backupRepoFactory.New(namespace, ds).Save(backupSpec); err != nil {
+		return models.BackupSpec{}, errors.Wrapf(err, "failed to record backup %s of resource %s", resp.URN, name)
+	}
+	return backupSpec, nil
+}
+
+// RestoreResource overwrites resource in datastore with the copy made during
+// backup and stores the spec resource had when backup was taken
+func (srv Service) RestoreResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName string,
+	backupID uuid.UUID) (models.ResourceSpec, error) {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	backupSpec, err := srv.backupRepoFactory.New(namespace, ds).GetByID(backupID)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	if time.Now().After(backupSpec.ExpiresAt) {
+		return models.ResourceSpec{}, errors.Wrapf(ErrBackupExpired, "backup %s expired at %s", backupID,
+			backupSpec.ExpiresAt.Format(time.RFC3339))
+	}
+
+	resourceSpec := backupSpec.Resource
+	if err := instrument(resourceSpec.Datastore).RestoreResource(ctx, models.RestoreResourceRequest{
 		Resource: resourceSpec,
 		Project:  namespace.ProjectSpec,
+		URN:      backupSpec.URN,
 	}); err != nil {
+		return models.ResourceSpec{}, errors.Wrapf(err, "failed to restore resource %s", resourceSpec.Name)
+	}
+	if err := srv.resourceRepoFactory.New(namespace, ds).Save(resourceSpec); err != nil {
+		return models.ResourceSpec{}, err
+	}
+	return resourceSpec, nil
+}
+
+// GetBackups lists backups taken of resources of a datastore in namespace
+func (srv Service) GetBackups(namespace models.NamespaceSpec, datastoreName string) ([]models.BackupSpec, error) {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return nil, err
+	}
+	return srv.backupRepoFactory.New(namespace, ds).GetAll()
+}
+
+// KeepOnly deletes all resources of the datastore in a namespace except the
+// ones provided, deleted resources are migrated from datastore as well
+func (srv Service) KeepOnly(ctx context.Context, namespace models.NamespaceSpec, datastoreName string, resourceSpecs []models.ResourceSpec, obs progress.Observer) error {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return err
+	}
+	repo := srv.resourceRepoFactory.New(namespace, ds)
+	existingSpecs, err := repo.GetAll()
+	if err != nil {
 		return err
 	}
 
-	return repo.Delete(name)
+	specsToKeep := map[string]bool{}
+	for _, resourceSpec := range resourceSpecs {
+		specsToKeep[resourceSpec.Name] = true
+	}
+
+	var errorSet error
+	for _, existingSpec := range existingSpecs {
+		if specsToKeep[existingSpec.Name] {
+			continue
+		}
+
+		err := instrument(existingSpec.Datastore).DeleteResource(ctx, models.DeleteResourceRequest{
+			Resource: existingSpec,
+			Project:  namespace.ProjectSpec,
+		})
+		if err == nil {
+			err = repo.Delete(existingSpec.Name)
+		}
+		srv.notifyProgress(obs, &EventResourceDeleted{
+			Spec: existingSpec,
+			Err:  err,
+		})
+		if err != nil {
+			errorSet = multierror.Append(errorSet, err)
+		}
+	}
+	return errorSet
+}
+
+// CheckDatastore verifies if datastore is reachable with project credentials
+func (srv Service) CheckDatastore(ctx context.Context, project models.ProjectSpec, datastoreName string) error {
+	ds, err := srv.dsRepo.GetByName(datastoreName)
+	if err != nil {
+		return err
+	}
+	return instrument(ds).CheckDatastore(ctx, models.CheckDatastoreRequest{
+		Project: project,
+	})
+}
+
+// applyTimeout is the effective time allowed to apply a single resource,
+// DefaultResourceApplyTimeout is used when ApplyTimeout is not set
+func (srv Service) applyTimeout() time.Duration {
+	if srv.ApplyTimeout <= 0 {
+		return DefaultResourceApplyTimeout
+	}
+	return srv.ApplyTimeout
+}
+
+// applyWithTimeout runs apply with a context derived from ctx which expires
+// after applyTimeout. Datastores are expected to honour the context but in
+// case they don't, the call is abandoned to let the deployment continue
+func (srv Service) applyWithTimeout(ctx context.Context, name string, apply func(context.Context) error) error {
+	timeout := srv.applyTimeout()
+	applyCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- apply(applyCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-errChan:
+		if err == nil {
+			return nil
+		}
+	case <-applyCtx.Done():
+	}
+	if ctx.Err() == nil && errors.Is(applyCtx.Err(), context.DeadlineExceeded) {
+		return errors.Wrapf(ErrResourceApplyTimeout, "%s exceeded %s", name, timeout)
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	return err
 }
 
 func (srv *Service) notifyProgress(po progress.Observer, event progress.Event) {
@@ -152,10 +609,13 @@ func (srv *Service) notifyProgress(po progress.Observer, event progress.Event) {
 	po.Notify(event)
 }
 
-func NewService(resourceRepoFactory ResourceSpecRepoFactory, dsRepo models.DatastoreRepo) *Service {
+func NewService(resourceRepoFactory ResourceSpecRepoFactory, backupRepoFactory BackupRepoFactory, dsRepo models.DatastoreRepo) *Service {
 	return &Service{
 		resourceRepoFactory: resourceRepoFactory,
+		backupRepoFactory:   backupRepoFactory,
 		dsRepo:              dsRepo,
+		ApplyTimeout:        DefaultResourceApplyTimeout,
+		ApplyConcurrency:    DefaultResourceApplyConcurrency,
 	}
 }
 
@@ -171,18 +631,54 @@ type (
 		Spec models.ResourceSpec
 		Err  error
 	}
+
+	// EventResourceDeleted represents the resource being deleted from datastore
+	EventResourceDeleted struct {
+		Spec models.ResourceSpec
+		Err  error
+	}
+
+	// EventResourceSkipped represents the resource not applied to datastore
+	// as a resource it depends on failed
+	EventResourceSkipped struct {
+		Spec       models.ResourceSpec
+		Dependency string
+		Err        error
+	}
+
+	// EventResourceApplyTimeout represents the resource which took longer
+	// than allowed to be created/updated in datastore
+	EventResourceApplyTimeout struct {
+		Spec    models.ResourceSpec
+		Timeout time.Duration
+	}
 )
 
 func (e *EventResourceUpdated) String() string {
 	if e.Err != nil {
-		return fmt.Sprintf("updating: %s, failed with error): %s", e.Spec.Name, e.Err.Error())
+		return fmt.Sprintf("updating: %s, failed with error: %s", e.Spec.Name, e.Err.Error())
 	}
 	return fmt.Sprintf("updated: %s", e.Spec.Name)
 }
 
 func (e *EventResourceCreated) String() string {
 	if e.Err != nil {
-		return fmt.Sprintf("creating: %s, failed with error): %s", e.Spec.Name, e.Err.Error())
+		return fmt.Sprintf("creating: %s, failed with error: %s", e.Spec.Name, e.Err.Error())
 	}
 	return fmt.Sprintf("created: %s", e.Spec.Name)
 }
+
+func (e *EventResourceDeleted) String() string {
+	if e.Err != nil {
+		return fmt.Sprintf("deleting: %s, failed with error: %s", e.Spec.Name, e.Err.Error())
+	}
+	return fmt.Sprintf("deleted: %s", e.Spec.Name)
+}
+
+func (e *EventResourceSkipped) String() string {
+	return fmt.Sprintf("skipped: %s, %s failed with error: %s", e.Spec.Name, e.Dependency, e.Err.Error())
+}
+
+func (e *EventResourceApplyTimeout) String() string {
+	return fmt.Sprintf("applying: %s, timed out after %s", e.Spec.Name, e.Timeout)
+}
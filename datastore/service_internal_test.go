@@ -0,0 +1,19 @@
+package datastore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceApplyTimeout(t *testing.T) {
+	t.Run("should use the configured apply timeout", func(t *testing.T) {
+		srv := Service{ApplyTimeout: time.Second}
+		assert.Equal(t, time.Second, srv.applyTimeout())
+	})
+	t.Run("should fall back to default apply timeout when not set", func(t *testing.T) {
+		assert.Equal(t, DefaultResourceApplyTimeout, Service{}.applyTimeout())
+		assert.Equal(t, DefaultResourceApplyTimeout, Service{ApplyTimeout: -time.Second}.applyTimeout())
+	})
+}
@@ -2,16 +2,20 @@ package datastore_test
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	mock2 "github.com/stretchr/testify/mock"
 
 	"github.com/odpf/optimus/datastore"
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 )
 
 func TestService(t *testing.T) {
@@ -33,6 +37,7 @@ func TestService(t *testing.T) {
 	t.Run("GetAll", func(t *testing.T) {
 		t.Run("should successfully read resources from persistent repository", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
@@ -57,7 +62,7 @@ func TestService(t *testing.T) {
 			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
 			defer projectResourceRepoFac.AssertExpectations(t)
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
 			res, err := service.GetAll(namespaceSpec, "bq")
 			assert.Nil(t, err)
 			assert.Equal(t, []models.ResourceSpec{resourceSpec1}, res)
@@ -67,6 +72,7 @@ func TestService(t *testing.T) {
 	t.Run("CreateResource", func(t *testing.T) {
 		t.Run("should successfully call datastore create resource individually for reach resource and save in persistent repository", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
@@ -84,11 +90,11 @@ func TestService(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
-			datastorer.On("CreateResource", context.TODO(), models.CreateResourceRequest{
+			datastorer.On("CreateResource", mock2.Anything, models.CreateResourceRequest{
 				Project:  projectSpec,
 				Resource: resourceSpec1,
 			}).Return(nil)
-			datastorer.On("CreateResource", context.TODO(), models.CreateResourceRequest{
+			datastorer.On("CreateResource", mock2.Anything, models.CreateResourceRequest{
 				Project:  projectSpec,
 				Resource: resourceSpec2,
 			}).Return(nil)
@@ -105,12 +111,13 @@ func TestService(t *testing.T) {
 			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
 			defer projectResourceRepoFac.AssertExpectations(t)
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
 			err := service.CreateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, nil)
 			assert.Nil(t, err)
 		})
 		t.Run("should not call create in datastore if failed to save in repository", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
@@ -128,7 +135,7 @@ func TestService(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
-			datastorer.On("CreateResource", context.TODO(), models.CreateResourceRequest{
+			datastorer.On("CreateResource", mock2.Anything, models.CreateResourceRequest{
 				Project:  projectSpec,
 				Resource: resourceSpec2,
 			}).Return(nil)
@@ -145,17 +152,673 @@ func TestService(t *testing.T) {
 			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
 			defer projectResourceRepoFac.AssertExpectations(t)
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
 			err := service.CreateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, nil)
 			assert.NotNil(t, err)
 		})
+		t.Run("should render templated labels using project config before creating in datastore", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			envNamespaceSpec := namespaceSpec
+			envNamespaceSpec.ProjectSpec.Config = map[string]string{
+				"ENV":  "staging",
+				"TEAM": "data",
+			}
+			envNamespaceSpec.Config = map[string]string{
+				"ENV": "prod",
+			}
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+				Labels: map[string]string{
+					"environment": "{{.proj.ENV}}",
+					"owner":       "{{.proj.TEAM}}-team",
+					"tier":        "gold",
+				},
+			}
+			renderedSpec := resourceSpec
+			renderedSpec.Labels = map[string]string{
+				"environment": "prod",
+				"owner":       "data-team",
+				"tier":        "gold",
+			}
+			datastorer.On("CreateResource", mock2.Anything, models.CreateResourceRequest{
+				Project:  envNamespaceSpec.ProjectSpec,
+				Resource: renderedSpec,
+			}).Return(nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", envNamespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.CreateResource(context.TODO(), envNamespaceSpec, []models.ResourceSpec{resourceSpec}, nil)
+			assert.Nil(t, err)
+		})
+		t.Run("should fail without saving if templated label refers missing project config", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+				Labels: map[string]string{
+					"environment": "{{.proj.ENV}}",
+				},
+			}
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.CreateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "label environment of resource proj.datas refers ENV which is not a project config")
+		})
+		t.Run("should fail if rendered label value is not a valid label", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+				Labels: map[string]string{
+					"bucket": "{{.proj.bucket}}",
+				},
+			}
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.CreateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec}, nil)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid label bucket of resource proj.datas")
+		})
+	})
+	t.Run("UpdateResource", func(t *testing.T) {
+		t.Run("should successfully call datastore update resource individually for reach resource and save in persistent repository", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			resourceSpec2 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.batas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec1,
+			}).Return(nil)
+			datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec2,
+			}).Return(nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec1).Return(nil)
+			resourceRepo.On("Save", resourceSpec2).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
+			defer projectResourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, false, nil)
+			assert.Nil(t, err)
+		})
+		t.Run("should pass force to datastore when updating resources", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec,
+				Force:    true,
+			}).Return(nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, new(mock.SupportedDatastoreRepo))
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec}, true, nil)
+			assert.Nil(t, err)
+		})
+		t.Run("should update resources after the resources they depend on", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			viewSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.user_view",
+				Type:      models.ResourceTypeView,
+				Datastore: datastorer,
+				DependsOn: []string{"proj.datas.user"},
+			}
+			tableSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+				DependsOn: []string{"proj.datas"},
+			}
+			datasetSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			var applyOrder []string
+			for _, spec := range []models.ResourceSpec{viewSpec, tableSpec, datasetSpec} {
+				name := spec.Name
+				datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+					Project:  projectSpec,
+					Resource: spec,
+				}).Run(func(args mock2.Arguments) {
+					applyOrder = append(applyOrder, name)
+				}).Return(nil)
+			}
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", mock2.AnythingOfType("models.ResourceSpec")).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{viewSpec, tableSpec, datasetSpec}, false, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"proj.datas", "proj.datas.user", "proj.datas.user_view"}, applyOrder)
+		})
+		t.Run("should update independent resources concurrently up to the configured limit", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			var mu sync.Mutex
+			active, maxActive := 0, 0
+			datastorer.On("UpdateResource", mock2.Anything, mock2.AnythingOfType("models.UpdateResourceRequest")).Run(func(args mock2.Arguments) {
+				mu.Lock()
+				active++
+				if active > maxActive {
+					maxActive = active
+				}
+				mu.Unlock()
+
+				time.Sleep(500 * time.Millisecond)
+
+				mu.Lock()
+				active--
+				mu.Unlock()
+			}).Return(nil).Times(4)
+
+			var resourceSpecs []models.ResourceSpec
+			for _, name := range []string{"proj.datas.a", "proj.datas.b", "proj.datas.c", "proj.datas.d"} {
+				resourceSpecs = append(resourceSpecs, models.ResourceSpec{
+					Version:   1,
+					Name:      name,
+					Type:      models.ResourceTypeTable,
+					Datastore: datastorer,
+				})
+			}
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", mock2.AnythingOfType("models.ResourceSpec")).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, new(mock.SupportedDatastoreRepo))
+			service.ApplyConcurrency = 2
+			err := service.UpdateResource(context.TODO(), namespaceSpec, resourceSpecs, false, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, maxActive)
+		})
+		t.Run("should update dependents concurrently only after their dependency is updated", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			datasetSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			tableSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.a",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+				DependsOn: []string{"proj.datas"},
+			}
+			tableSpec2 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.b",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+				DependsOn: []string{"proj.datas"},
+			}
+
+			var mu sync.Mutex
+			var events []string
+			for _, spec := range []models.ResourceSpec{datasetSpec, tableSpec1, tableSpec2} {
+				name := spec.Name
+				datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+					Project:  projectSpec,
+					Resource: spec,
+				}).Run(func(args mock2.Arguments) {
+					mu.Lock()
+					events = append(events, "start "+name)
+					mu.Unlock()
+
+					time.Sleep(300 * time.Millisecond)
+
+					mu.Lock()
+					events = append(events, "end "+name)
+					mu.Unlock()
+				}).Return(nil)
+			}
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", mock2.AnythingOfType("models.ResourceSpec")).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, new(mock.SupportedDatastoreRepo))
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{tableSpec1, tableSpec2, datasetSpec}, false, nil)
+			assert.Nil(t, err)
+
+			assert.Equal(t, []string{"start proj.datas", "end proj.datas"}, events[:2])
+			// both tables are applied together once dataset is ready
+			assert.ElementsMatch(t, []string{"start proj.datas.a", "start proj.datas.b"}, events[2:4])
+		})
+		t.Run("should update tables of a dataset after the dataset without listing it as dependency", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			tableSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			viewSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.user_view",
+				Type:      models.ResourceTypeView,
+				Datastore: datastorer,
+			}
+			datasetSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			var mu sync.Mutex
+			var applyOrder []string
+			for _, spec := range []models.ResourceSpec{tableSpec, viewSpec, datasetSpec} {
+				name := spec.Name
+				datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+					Project:  projectSpec,
+					Resource: spec,
+				}).Run(func(args mock2.Arguments) {
+					mu.Lock()
+					applyOrder = append(applyOrder, name)
+					mu.Unlock()
+				}).Return(nil)
+			}
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", mock2.AnythingOfType("models.ResourceSpec")).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, new(mock.SupportedDatastoreRepo))
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{tableSpec, viewSpec, datasetSpec}, false, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, "proj.datas", applyOrder[0])
+			assert.ElementsMatch(t, []string{"proj.datas.user", "proj.datas.user_view"}, applyOrder[1:])
+		})
+		t.Run("should skip tables of a dataset if the dataset failed to update", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			datasetSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			tableSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			viewSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.user_view",
+				Type:      models.ResourceTypeView,
+				Datastore: datastorer,
+				DependsOn: []string{"proj.datas.user"},
+			}
+			otherTableSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.other.user",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+
+			datasetErr := errors.New("permission denied")
+			datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: datasetSpec,
+			}).Return(datasetErr)
+			datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: otherTableSpec,
+			}).Return(nil)
+
+			observer := new(mock.PipelineLogObserver)
+			observer.On("Notify", &datastore.EventResourceSkipped{
+				Spec:       tableSpec,
+				Dependency: "proj.datas",
+				Err:        datasetErr,
+			}).Return().Once()
+			observer.On("Notify", mock2.MatchedBy(func(evt *datastore.EventResourceSkipped) bool {
+				return evt.Spec.Name == viewSpec.Name && evt.Dependency == tableSpec.Name
+			})).Return().Once()
+			observer.On("Notify", mock2.Anything).Return()
+			defer observer.AssertExpectations(t)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", datasetSpec).Return(nil)
+			resourceRepo.On("Save", otherTableSpec).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, new(mock.SupportedDatastoreRepo))
+			err := service.UpdateResource(context.TODO(), namespaceSpec,
+				[]models.ResourceSpec{datasetSpec, tableSpec, viewSpec, otherTableSpec}, false, observer)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "skipped proj.datas.user as proj.datas failed: permission denied")
+			datastorer.AssertNotCalled(t, "UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: tableSpec,
+			})
+			resourceRepo.AssertNotCalled(t, "Save", tableSpec)
+			resourceRepo.AssertNotCalled(t, "Save", viewSpec)
+		})
+		t.Run("should not update any resource if resources depend on each other", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.view_a",
+				Type:      models.ResourceTypeView,
+				Datastore: datastorer,
+				DependsOn: []string{"proj.datas.view_b"},
+			}
+			resourceSpec2 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.view_b",
+				Type:      models.ResourceTypeView,
+				Datastore: datastorer,
+				DependsOn: []string{"proj.datas.view_a"},
+			}
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, false, nil)
+			assert.True(t, errors.Is(err, datastore.ErrResourceDependencyCycle))
+			assert.Equal(t, "resources proj.datas.view_a, proj.datas.view_b: cyclic dependency between resources", err.Error())
+		})
+		t.Run("should not call update in datastore if failed to save in repository", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			resourceSpec2 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.batas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec2,
+			}).Return(nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec1).Return(errors.New("cant save, too busy"))
+			resourceRepo.On("Save", resourceSpec2).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
+			defer projectResourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, false, nil)
+			assert.NotNil(t, err)
+		})
+		t.Run("should time out a stuck resource and continue with rest of the resources", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			resourceSpec2 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.batas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			// datastore never returns for the first resource
+			blocked := make(chan time.Time)
+			defer close(blocked)
+			datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec1,
+			}).WaitUntil(blocked).Return(nil)
+			datastorer.On("UpdateResource", mock2.Anything, models.UpdateResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec2,
+			}).Return(nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec1).Return(nil)
+			resourceRepo.On("Save", resourceSpec2).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			obs := new(mock.PipelineLogObserver)
+			obs.On("Notify", &datastore.EventResourceApplyTimeout{
+				Spec:    resourceSpec1,
+				Timeout: time.Millisecond * 50,
+			}).Return().Once()
+			obs.On("Notify", &datastore.EventResourceUpdated{
+				Spec: resourceSpec2,
+			}).Return().Once()
+			defer obs.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			service.ApplyTimeout = time.Millisecond * 50
+			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, false, obs)
+			assert.NotNil(t, err)
+			assert.True(t, errors.Is(err, datastore.ErrResourceApplyTimeout))
+			assert.Contains(t, err.Error(), "proj.datas exceeded 50ms")
+		})
+	})
+	t.Run("SaveResource", func(t *testing.T) {
+		t.Run("should save resources in persistent repository without applying them to datastore", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+			resourceSpec2 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.batas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec1).Return(nil)
+			resourceRepo.On("Save", resourceSpec2).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, nil)
+			err := service.SaveResource(namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2})
+			assert.Nil(t, err)
+			datastorer.AssertNotCalled(t, "CreateResource", mock2.Anything, mock2.Anything)
+			datastorer.AssertNotCalled(t, "UpdateResource", mock2.Anything, mock2.Anything)
+		})
+		t.Run("should return error if saving a resource fails", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+
+			resourceSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec).Return(errors.New("random error"))
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, nil)
+			err := service.SaveResource(namespaceSpec, []models.ResourceSpec{resourceSpec})
+			assert.Equal(t, "failed to save resource proj.datas: random error", err.Error())
+		})
 	})
-	t.Run("UpdateResource", func(t *testing.T) {
-		t.Run("should successfully call datastore update resource individually for reach resource and save in persistent repository", func(t *testing.T) {
+	t.Run("ReadResource", func(t *testing.T) {
+		t.Run("should successfully call datastore read operation by reading from persistent repository", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
 			defer dsRepo.AssertExpectations(t)
 
 			resourceSpec1 := models.ResourceSpec{
@@ -164,24 +827,13 @@ func TestService(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
-			resourceSpec2 := models.ResourceSpec{
-				Version:   1,
-				Name:      "proj.batas",
-				Type:      models.ResourceTypeDataset,
-				Datastore: datastorer,
-			}
-			datastorer.On("UpdateResource", context.TODO(), models.UpdateResourceRequest{
+			datastorer.On("ReadResource", context.TODO(), models.ReadResourceRequest{
 				Project:  projectSpec,
 				Resource: resourceSpec1,
-			}).Return(nil)
-			datastorer.On("UpdateResource", context.TODO(), models.UpdateResourceRequest{
-				Project:  projectSpec,
-				Resource: resourceSpec2,
-			}).Return(nil)
+			}).Return(models.ReadResourceResponse{Resource: resourceSpec1}, nil)
 
 			resourceRepo := new(mock.ResourceSpecRepository)
-			resourceRepo.On("Save", resourceSpec1).Return(nil)
-			resourceRepo.On("Save", resourceSpec2).Return(nil)
+			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
 			defer resourceRepo.AssertExpectations(t)
 
 			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
@@ -191,15 +843,18 @@ func TestService(t *testing.T) {
 			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
 			defer projectResourceRepoFac.AssertExpectations(t)
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
-			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, nil)
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			resp, err := service.ReadResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
 			assert.Nil(t, err)
+			assert.Equal(t, resourceSpec1, resp)
 		})
-		t.Run("should not call update in datastore if failed to save in repository", func(t *testing.T) {
+		t.Run("should not call read in datastore if failed to read from repository", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
 			defer dsRepo.AssertExpectations(t)
 
 			resourceSpec1 := models.ResourceSpec{
@@ -208,20 +863,9 @@ func TestService(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
-			resourceSpec2 := models.ResourceSpec{
-				Version:   1,
-				Name:      "proj.batas",
-				Type:      models.ResourceTypeDataset,
-				Datastore: datastorer,
-			}
-			datastorer.On("UpdateResource", context.TODO(), models.UpdateResourceRequest{
-				Project:  projectSpec,
-				Resource: resourceSpec2,
-			}).Return(nil)
 
 			resourceRepo := new(mock.ResourceSpecRepository)
-			resourceRepo.On("Save", resourceSpec1).Return(errors.New("cant save, too busy"))
-			resourceRepo.On("Save", resourceSpec2).Return(nil)
+			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, errors.New("not found"))
 			defer resourceRepo.AssertExpectations(t)
 
 			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
@@ -231,14 +875,15 @@ func TestService(t *testing.T) {
 			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
 			defer projectResourceRepoFac.AssertExpectations(t)
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
-			err := service.UpdateResource(context.TODO(), namespaceSpec, []models.ResourceSpec{resourceSpec1, resourceSpec2}, nil)
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			_, err := service.ReadResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
 			assert.NotNil(t, err)
 		})
 	})
-	t.Run("ReadResource", func(t *testing.T) {
-		t.Run("should successfully call datastore read operation by reading from persistent repository", func(t *testing.T) {
+	t.Run("DeleteResource", func(t *testing.T) {
+		t.Run("should successfully call datastore delete operation and then from persistent repository", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
@@ -251,13 +896,14 @@ func TestService(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
-			datastorer.On("ReadResource", context.TODO(), models.ReadResourceRequest{
+			datastorer.On("DeleteResource", context.TODO(), models.DeleteResourceRequest{
 				Project:  projectSpec,
 				Resource: resourceSpec1,
-			}).Return(models.ReadResourceResponse{Resource: resourceSpec1}, nil)
+			}).Return(nil)
 
 			resourceRepo := new(mock.ResourceSpecRepository)
 			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
+			resourceRepo.On("Delete", resourceSpec1.Name).Return(nil)
 			defer resourceRepo.AssertExpectations(t)
 
 			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
@@ -267,13 +913,13 @@ func TestService(t *testing.T) {
 			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
 			defer projectResourceRepoFac.AssertExpectations(t)
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
-			resp, err := service.ReadResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.DeleteResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name, true)
 			assert.Nil(t, err)
-			assert.Equal(t, resourceSpec1, resp)
 		})
-		t.Run("should not call read in datastore if failed to read from repository", func(t *testing.T) {
+		t.Run("should not call delete in datastore if failed to delete from repository", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
@@ -286,9 +932,13 @@ func TestService(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
+			datastorer.On("DeleteResource", context.TODO(), models.DeleteResourceRequest{
+				Project:  projectSpec,
+				Resource: resourceSpec1,
+			}).Return(errors.New("failed to delete"))
 
 			resourceRepo := new(mock.ResourceSpecRepository)
-			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, errors.New("not found"))
+			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
 			defer resourceRepo.AssertExpectations(t)
 
 			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
@@ -298,14 +948,143 @@ func TestService(t *testing.T) {
 			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
 			defer projectResourceRepoFac.AssertExpectations(t)
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
-			_, err := service.ReadResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.DeleteResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name, true)
 			assert.NotNil(t, err)
 		})
+		t.Run("should only delete from persistent repository if not asked to delete from datastore", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      models.ResourceTypeDataset,
+				Datastore: datastorer,
+			}
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
+			resourceRepo.On("Delete", resourceSpec1.Name).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.DeleteResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name, false)
+			assert.Nil(t, err)
+			datastorer.AssertNotCalled(t, "DeleteResource", mock2.Anything, mock2.Anything)
+		})
 	})
-	t.Run("DeleteResource", func(t *testing.T) {
-		t.Run("should successfully call datastore delete operation and then from persistent repository", func(t *testing.T) {
+	t.Run("RenameResource", func(t *testing.T) {
+		t.Run("should rename resource in datastore and move its spec in persistent repository", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			oldSpec := models.ResourceSpec{
+				ID:        uuid.Must(uuid.NewRandom()),
+				Version:   1,
+				Name:      "proj.datas.old_table",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+				Assets:    map[string]string{"query.sql": "select 1"},
+			}
+			newSpec := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.new_table",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			renamedSpec := newSpec
+			renamedSpec.Assets = oldSpec.Assets
+
+			adapter := new(mock.DatastoreTypeAdapter)
+			adapter.On("ToYaml", oldSpec).Return([]byte("name: proj.datas.old_table\nversion: 1\n"), nil)
+			adapter.On("FromYaml", []byte("name: proj.datas.new_table\nversion: 1\n")).Return(newSpec, nil)
+			defer adapter.AssertExpectations(t)
+
+			typeController := new(mock.DatastoreTypeController)
+			typeController.On("Adapter").Return(adapter)
+			typeController.On("Validator").Return(models.DatastoreSpecValidator(func(spec models.ResourceSpec) error {
+				return nil
+			}))
+			defer typeController.AssertExpectations(t)
+
+			datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+				models.ResourceTypeTable: typeController,
+			})
+			datastorer.On("RenameResource", context.TODO(), models.RenameResourceRequest{
+				Resource:    oldSpec,
+				NewResource: renamedSpec,
+				Project:     projectSpec,
+				AllowCopy:   true,
+			}).Return(nil)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetByName", oldSpec.Name).Return(oldSpec, nil)
+			resourceRepo.On("GetByName", newSpec.Name).Return(models.ResourceSpec{}, store.ErrResourceNotFound)
+			resourceRepo.On("Save", renamedSpec).Return(nil)
+			resourceRepo.On("Delete", oldSpec.Name).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.RenameResource(context.TODO(), namespaceSpec, "bq", oldSpec.Name, newSpec.Name, true)
+			assert.Nil(t, err)
+		})
+		t.Run("should not rename in datastore if new name is already in use", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			oldSpec := models.ResourceSpec{
+				Name:      "proj.datas.old_table",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			existingSpec := models.ResourceSpec{
+				Name:      "proj.datas.new_table",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetByName", oldSpec.Name).Return(oldSpec, nil)
+			resourceRepo.On("GetByName", existingSpec.Name).Return(existingSpec, nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.RenameResource(context.TODO(), namespaceSpec, "bq", oldSpec.Name, existingSpec.Name, true)
+			assert.True(t, errors.Is(err, models.ErrResourceExists))
+		})
+	})
+	t.Run("KeepOnly", func(t *testing.T) {
+		t.Run("should delete resources of datastore not present in provided specs", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
@@ -318,29 +1097,37 @@ func TestService(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
+			resourceSpec2 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.stale",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
 			datastorer.On("DeleteResource", context.TODO(), models.DeleteResourceRequest{
 				Project:  projectSpec,
-				Resource: resourceSpec1,
+				Resource: resourceSpec2,
 			}).Return(nil)
 
 			resourceRepo := new(mock.ResourceSpecRepository)
-			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
-			resourceRepo.On("Delete", resourceSpec1.Name).Return(nil)
+			resourceRepo.On("GetAll").Return([]models.ResourceSpec{resourceSpec1, resourceSpec2}, nil)
+			resourceRepo.On("Delete", resourceSpec2.Name).Return(nil)
 			defer resourceRepo.AssertExpectations(t)
 
 			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
 			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
 			defer resourceRepoFac.AssertExpectations(t)
 
-			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
-			defer projectResourceRepoFac.AssertExpectations(t)
+			obs := new(mock.PipelineLogObserver)
+			obs.On("Notify", &datastore.EventResourceDeleted{Spec: resourceSpec2}).Return()
+			defer obs.AssertExpectations(t)
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
-			err := service.DeleteResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.KeepOnly(context.TODO(), namespaceSpec, "bq", []models.ResourceSpec{resourceSpec1}, obs)
 			assert.Nil(t, err)
 		})
-		t.Run("should not call delete in datastore if failed to delete from repository", func(t *testing.T) {
+		t.Run("should keep the resource in repository if failed to delete from datastore", func(t *testing.T) {
 			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
 			defer datastorer.AssertExpectations(t)
 
 			dsRepo := new(mock.SupportedDatastoreRepo)
@@ -353,10 +1140,76 @@ func TestService(t *testing.T) {
 				Type:      models.ResourceTypeDataset,
 				Datastore: datastorer,
 			}
+			deleteErr := errors.New("failed to delete")
 			datastorer.On("DeleteResource", context.TODO(), models.DeleteResourceRequest{
 				Project:  projectSpec,
 				Resource: resourceSpec1,
-			}).Return(errors.New("failed to delete"))
+			}).Return(deleteErr)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetAll").Return([]models.ResourceSpec{resourceSpec1}, nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			obs := new(mock.PipelineLogObserver)
+			obs.On("Notify", &datastore.EventResourceDeleted{Spec: resourceSpec1, Err: deleteErr}).Return()
+			defer obs.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, nil, dsRepo)
+			err := service.KeepOnly(context.TODO(), namespaceSpec, "bq", []models.ResourceSpec{}, obs)
+			assert.NotNil(t, err)
+		})
+	})
+
+	t.Run("CheckDatastore", func(t *testing.T) {
+		t.Run("should check the requested datastore with project credentials", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			datastorer.On("CheckDatastore", context.TODO(), models.CheckDatastoreRequest{
+				Project: projectSpec,
+			}).Return(nil)
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			service := datastore.NewService(nil, nil, dsRepo)
+			err := service.CheckDatastore(context.TODO(), projectSpec, "bq")
+			assert.Nil(t, err)
+		})
+		t.Run("should fail if datastore is not supported", func(t *testing.T) {
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "unknown").Return(new(mock.Datastorer), models.ErrUnsupportedDatastore)
+			defer dsRepo.AssertExpectations(t)
+
+			service := datastore.NewService(nil, nil, dsRepo)
+			err := service.CheckDatastore(context.TODO(), projectSpec, "unknown")
+			assert.Equal(t, models.ErrUnsupportedDatastore, err)
+		})
+	})
+	t.Run("BackupResource", func(t *testing.T) {
+		t.Run("should copy resource in datastore and record the backup", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.test",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			datastorer.On("BackupResource", context.TODO(), mock2.MatchedBy(func(req models.BackupResourceRequest) bool {
+				return req.Resource.Name == resourceSpec1.Name && req.TTL == models.DefaultBackupTTL && req.ID != uuid.Nil
+			})).Return(models.BackupResourceResponse{URN: "proj.optimus_backup.datas_test_1"}, nil)
 
 			resourceRepo := new(mock.ResourceSpecRepository)
 			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
@@ -366,12 +1219,136 @@ func TestService(t *testing.T) {
 			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
 			defer resourceRepoFac.AssertExpectations(t)
 
-			projectResourceRepoFac := new(mock.ProjectResourceSpecRepoFactory)
-			defer projectResourceRepoFac.AssertExpectations(t)
+			backupRepo := new(mock.BackupRepository)
+			backupRepo.On("Save", mock2.MatchedBy(func(spec models.BackupSpec) bool {
+				return spec.URN == "proj.optimus_backup.datas_test_1" && spec.Description == "before update"
+			})).Return(nil)
+			defer backupRepo.AssertExpectations(t)
+
+			backupRepoFac := new(mock.BackupRepoFactory)
+			backupRepoFac.On("New", namespaceSpec, datastorer).Return(backupRepo)
+			defer backupRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, backupRepoFac, dsRepo)
+			backupSpec, err := service.BackupResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name, 0, "before update")
+			assert.Nil(t, err)
+			assert.Equal(t, "proj.optimus_backup.datas_test_1", backupSpec.URN)
+			assert.Equal(t, resourceSpec1, backupSpec.Resource)
+			assert.True(t, backupSpec.ExpiresAt.After(time.Now().Add(models.DefaultBackupTTL-time.Minute)))
+		})
+		t.Run("should not record backup if datastore failed to copy resource", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.test",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			datastorer.On("BackupResource", context.TODO(), mock2.Anything).
+				Return(models.BackupResourceResponse{}, errors.New("access denied"))
 
-			service := datastore.NewService(resourceRepoFac, dsRepo)
-			err := service.DeleteResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name)
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("GetByName", resourceSpec1.Name).Return(resourceSpec1, nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			backupRepoFac := new(mock.BackupRepoFactory)
+			defer backupRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, backupRepoFac, dsRepo)
+			_, err := service.BackupResource(context.TODO(), namespaceSpec, "bq", resourceSpec1.Name, time.Hour, "")
 			assert.NotNil(t, err)
 		})
 	})
+	t.Run("RestoreResource", func(t *testing.T) {
+		backupID := uuid.Must(uuid.NewRandom())
+		t.Run("should restore resource in datastore and save the spec it had at backup", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			resourceSpec1 := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas.test",
+				Type:      models.ResourceTypeTable,
+				Datastore: datastorer,
+			}
+			backupSpec := models.BackupSpec{
+				ID:        backupID,
+				Resource:  resourceSpec1,
+				URN:       "proj.optimus_backup.datas_test_1",
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+			datastorer.On("RestoreResource", context.TODO(), models.RestoreResourceRequest{
+				Resource: resourceSpec1,
+				Project:  projectSpec,
+				URN:      backupSpec.URN,
+			}).Return(nil)
+
+			backupRepo := new(mock.BackupRepository)
+			backupRepo.On("GetByID", backupID).Return(backupSpec, nil)
+			defer backupRepo.AssertExpectations(t)
+
+			backupRepoFac := new(mock.BackupRepoFactory)
+			backupRepoFac.On("New", namespaceSpec, datastorer).Return(backupRepo)
+			defer backupRepoFac.AssertExpectations(t)
+
+			resourceRepo := new(mock.ResourceSpecRepository)
+			resourceRepo.On("Save", resourceSpec1).Return(nil)
+			defer resourceRepo.AssertExpectations(t)
+
+			resourceRepoFac := new(mock.ResourceSpecRepoFactory)
+			resourceRepoFac.On("New", namespaceSpec, datastorer).Return(resourceRepo)
+			defer resourceRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(resourceRepoFac, backupRepoFac, dsRepo)
+			restored, err := service.RestoreResource(context.TODO(), namespaceSpec, "bq", backupID)
+			assert.Nil(t, err)
+			assert.Equal(t, resourceSpec1, restored)
+		})
+		t.Run("should not restore an expired backup", func(t *testing.T) {
+			datastorer := new(mock.Datastorer)
+			datastorer.On("Name").Return("bq").Maybe()
+			defer datastorer.AssertExpectations(t)
+
+			dsRepo := new(mock.SupportedDatastoreRepo)
+			dsRepo.On("GetByName", "bq").Return(datastorer, nil)
+			defer dsRepo.AssertExpectations(t)
+
+			backupRepo := new(mock.BackupRepository)
+			backupRepo.On("GetByID", backupID).Return(models.BackupSpec{
+				ID: backupID,
+				Resource: models.ResourceSpec{
+					Name:      "proj.datas.test",
+					Type:      models.ResourceTypeTable,
+					Datastore: datastorer,
+				},
+				URN:       "proj.optimus_backup.datas_test_1",
+				ExpiresAt: time.Now().Add(-time.Hour),
+			}, nil)
+			defer backupRepo.AssertExpectations(t)
+
+			backupRepoFac := new(mock.BackupRepoFactory)
+			backupRepoFac.On("New", namespaceSpec, datastorer).Return(backupRepo)
+			defer backupRepoFac.AssertExpectations(t)
+
+			service := datastore.NewService(nil, backupRepoFac, dsRepo)
+			_, err := service.RestoreResource(context.TODO(), namespaceSpec, "bq", backupID)
+			assert.True(t, errors.Is(err, datastore.ErrBackupExpired))
+		})
+	})
 }
@@ -30,6 +30,11 @@ module.exports = {
         "guides/create-bigquery-dataset",
         "guides/create-bigquery-table",
         "guides/create-bigquery-view",
+        "guides/create-bigquery-external-table",
+        "guides/create-postgres-table",
+        "guides/create-kafka-topic",
+        "guides/create-gcs-bucket",
+        "guides/job-run-events",
         "guides/organising-specifications",
         "guides/optimus-serve",
         "guides/task-bq2bq"
@@ -204,3 +204,48 @@ func bqUpdateTableMetaAdapter(t BQTable) (meta bqapi.TableMetadataToUpdate, err
 	}
 	return
 }
+
+// bqExternalDataConfigTo describes the source of an external table, schema
+// is auto detected by bigquery when it is not provided
+func bqExternalDataConfigTo(source BQExternalSource, schema BQSchema) (*bqapi.ExternalDataConfig, error) {
+	bqSchema, err := bqSchemaTo(schema)
+	if err != nil {
+		return nil, err
+	}
+	config := &bqapi.ExternalDataConfig{
+		SourceFormat: bqapi.DataFormat(strings.ToUpper(source.SourceType)),
+		SourceURIs:   source.SourceURIs,
+		Schema:       bqSchema,
+		AutoDetect:   len(bqSchema) == 0,
+	}
+	switch config.SourceFormat {
+	case bqapi.GoogleSheets:
+		config.Options = &bqapi.GoogleSheetsOptions{
+			SkipLeadingRows: source.SkipLeadingRows,
+			Range:           source.Range,
+		}
+	case bqapi.CSV:
+		config.Options = &bqapi.CSVOptions{
+			SkipLeadingRows: source.SkipLeadingRows,
+		}
+	}
+	return config, nil
+}
+
+func bqExternalSourceFrom(config *bqapi.ExternalDataConfig) *BQExternalSource {
+	if config == nil {
+		return nil
+	}
+	source := &BQExternalSource{
+		SourceType: string(config.SourceFormat),
+		SourceURIs: config.SourceURIs,
+	}
+	switch options := config.Options.(type) {
+	case *bqapi.GoogleSheetsOptions:
+		source.SkipLeadingRows = options.SkipLeadingRows
+		source.Range = options.Range
+	case *bqapi.CSVOptions:
+		source.SkipLeadingRows = options.SkipLeadingRows
+	}
+	return source
+}
@@ -0,0 +1,101 @@
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	bqapi "cloud.google.com/go/bigquery"
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/pkg/errors"
+
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// backupDataset keeps copies of tables made during backup, it is
+	// created in the project of the table being backed up
+	backupDataset = "optimus_backup"
+)
+
+// backupTable copies table with its data to the backup dataset, copy expires
+// after ttl. Returns the name of the copy
+func backupTable(ctx context.Context, request models.BackupResourceRequest, client bqiface.Client) (string, error) {
+	bqTable, ok := request.Resource.Spec.(BQTable)
+	if !ok {
+		return "", errors.New("failed to read table spec for bigquery")
+	}
+
+	dataset := client.DatasetInProject(bqTable.Project, backupDataset)
+	if err := ensureDataset(ctx, dataset, BQDataset{
+		Project: bqTable.Project,
+		Dataset: backupDataset,
+		Metadata: BQDatasetMetadata{
+			Description: "backups of tables taken by optimus",
+		},
+	}, false); err != nil {
+		return "", err
+	}
+
+	backupName := fmt.Sprintf("backup_%s_%s_%s", bqTable.Dataset, bqTable.Table,
+		strings.ReplaceAll(request.ID.String(), "-", ""))
+	srcTable := client.DatasetInProject(bqTable.Project, bqTable.Dataset).Table(bqTable.Table)
+	dstTable := dataset.Table(backupName)
+	if err := copyTable(ctx, srcTable, dstTable, bqapi.WriteEmpty); err != nil {
+		return "", err
+	}
+
+	if _, err := dstTable.Update(ctx, bqapi.TableMetadataToUpdate{
+		ExpirationTime: time.Now().Add(request.TTL),
+	}, ""); err != nil {
+		return "", errors.Wrapf(err, "failed to set expiration of backup %s", backupName)
+	}
+	return fmt.Sprintf("%s.%s.%s", bqTable.Project, backupDataset, backupName), nil
+}
+
+// restoreTable overwrites data and schema of table with its copy made
+// during backup
+func restoreTable(ctx context.Context, request models.RestoreResourceRequest, client bqiface.Client) error {
+	bqTable, ok := request.Resource.Spec.(BQTable)
+	if !ok {
+		return errors.New("failed to read table spec for bigquery")
+	}
+	parts := tableNameParseRegex.FindStringSubmatch(request.URN)
+	if len(parts) < 4 {
+		return errors.Errorf("invalid backup %s, expected %s", request.URN, tableNameFormat)
+	}
+
+	dataset := client.DatasetInProject(bqTable.Project, bqTable.Dataset)
+	if err := ensureDataset(ctx, dataset, BQDataset{
+		Project:  bqTable.Project,
+		Dataset:  bqTable.Dataset,
+		Metadata: BQDatasetMetadata{},
+	}, false); err != nil {
+		return err
+	}
+	srcTable := client.DatasetInProject(parts[1], parts[2]).Table(parts[3])
+	return copyTable(ctx, srcTable, dataset.Table(bqTable.Table), bqapi.WriteTruncate)
+}
+
+// copyTable runs a copy job from src to dst and waits for it to finish
+func copyTable(ctx context.Context, srcTable, dstTable bqiface.Table, disposition bqapi.TableWriteDisposition) error {
+	copier := dstTable.CopierFrom(srcTable)
+	copier.SetCopyConfig(bqiface.CopyConfig{
+		CopyConfig: bqapi.CopyConfig{
+			CreateDisposition: bqapi.CreateIfNeeded,
+			WriteDisposition:  disposition,
+		},
+		Srcs: []bqiface.Table{srcTable},
+		Dst:  dstTable,
+	})
+	job, err := copier.Run(ctx)
+	if err != nil {
+		return err
+	}
+	jobStatus, err := job.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	return jobStatus.Err()
+}
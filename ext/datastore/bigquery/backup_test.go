@@ -0,0 +1,161 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/google/uuid"
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestBackup(t *testing.T) {
+	testingContext := context.Background()
+	bQResource := BQTable{
+		Project: "project",
+		Dataset: "dataset",
+		Table:   "table",
+	}
+	resourceSpec := models.ResourceSpec{
+		Name: "project.dataset.table",
+		Type: models.ResourceTypeTable,
+		Spec: bQResource,
+	}
+	datasetMetadata := &bqiface.DatasetMetadata{
+		DatasetMetadata: bigquery.DatasetMetadata{
+			ETag: "etag-0000",
+		},
+	}
+
+	t.Run("backupTable", func(t *testing.T) {
+		backupID := uuid.MustParse("0e8bd8e3-5cb4-4bb6-9b6f-7a8c0c9c2b51")
+		backupName := "backup_dataset_table_0e8bd8e35cb44bb69b6f7a8c0c9c2b51"
+
+		t.Run("should copy table to backup dataset and set its expiration", func(t *testing.T) {
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+			backupDatasetHandle := new(BqDatasetMock)
+			defer backupDatasetHandle.AssertExpectations(t)
+			datasetHandle := new(BqDatasetMock)
+			defer datasetHandle.AssertExpectations(t)
+			srcTable := new(BqTableMock)
+			dstTable := new(BqTableMock)
+			defer dstTable.AssertExpectations(t)
+			copier := new(BqCopierMock)
+			defer copier.AssertExpectations(t)
+			job := new(BqJobMock)
+			defer job.AssertExpectations(t)
+
+			bQClient.On("DatasetInProject", "project", backupDataset).Return(backupDatasetHandle)
+			bQClient.On("DatasetInProject", "project", "dataset").Return(datasetHandle)
+			backupDatasetHandle.On("Metadata", testingContext).Return(datasetMetadata, nil)
+			backupDatasetHandle.On("Table", backupName).Return(dstTable)
+			datasetHandle.On("Table", "table").Return(srcTable)
+
+			dstTable.On("CopierFrom", []bqiface.Table{srcTable}).Return(copier)
+			copier.On("SetCopyConfig", bqiface.CopyConfig{
+				CopyConfig: bigquery.CopyConfig{
+					CreateDisposition: bigquery.CreateIfNeeded,
+					WriteDisposition:  bigquery.WriteEmpty,
+				},
+				Srcs: []bqiface.Table{srcTable},
+				Dst:  dstTable,
+			}).Return()
+			copier.On("Run", testingContext).Return(job, nil)
+			job.On("Wait", testingContext).Return(&bigquery.JobStatus{State: bigquery.Done}, nil)
+
+			before := time.Now()
+			dstTable.On("Update", testingContext, mock.MatchedBy(func(meta bigquery.TableMetadataToUpdate) bool {
+				return !meta.ExpirationTime.Before(before.Add(time.Hour)) && meta.ExpirationTime.Before(time.Now().Add(time.Hour))
+			}), "").Return(&bigquery.TableMetadata{}, nil)
+
+			urn, err := backupTable(testingContext, models.BackupResourceRequest{
+				Resource: resourceSpec,
+				ID:       backupID,
+				TTL:      time.Hour,
+			}, bQClient)
+			assert.Nil(t, err)
+			assert.Equal(t, "project.optimus_backup."+backupName, urn)
+		})
+		t.Run("should return error if copy job fails", func(t *testing.T) {
+			bQClient := new(BqClientMock)
+			backupDatasetHandle := new(BqDatasetMock)
+			datasetHandle := new(BqDatasetMock)
+			srcTable := new(BqTableMock)
+			dstTable := new(BqTableMock)
+			defer dstTable.AssertExpectations(t)
+			copier := new(BqCopierMock)
+
+			bQClient.On("DatasetInProject", "project", backupDataset).Return(backupDatasetHandle)
+			bQClient.On("DatasetInProject", "project", "dataset").Return(datasetHandle)
+			backupDatasetHandle.On("Metadata", testingContext).Return(datasetMetadata, nil)
+			backupDatasetHandle.On("Table", backupName).Return(dstTable)
+			datasetHandle.On("Table", "table").Return(srcTable)
+			dstTable.On("CopierFrom", []bqiface.Table{srcTable}).Return(copier)
+			copier.On("SetCopyConfig", mock.Anything).Return()
+			copier.On("Run", testingContext).Return((*BqJobMock)(nil), errors.New("access denied"))
+
+			_, err := backupTable(testingContext, models.BackupResourceRequest{
+				Resource: resourceSpec,
+				ID:       backupID,
+				TTL:      time.Hour,
+			}, bQClient)
+			assert.Equal(t, "access denied", err.Error())
+			dstTable.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+	t.Run("restoreTable", func(t *testing.T) {
+		t.Run("should overwrite table with its backup", func(t *testing.T) {
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+			backupDatasetHandle := new(BqDatasetMock)
+			defer backupDatasetHandle.AssertExpectations(t)
+			datasetHandle := new(BqDatasetMock)
+			defer datasetHandle.AssertExpectations(t)
+			srcTable := new(BqTableMock)
+			dstTable := new(BqTableMock)
+			defer dstTable.AssertExpectations(t)
+			copier := new(BqCopierMock)
+			defer copier.AssertExpectations(t)
+			job := new(BqJobMock)
+			defer job.AssertExpectations(t)
+
+			bQClient.On("DatasetInProject", "project", "dataset").Return(datasetHandle)
+			bQClient.On("DatasetInProject", "project", backupDataset).Return(backupDatasetHandle)
+			datasetHandle.On("Metadata", testingContext).Return(datasetMetadata, nil)
+			datasetHandle.On("Table", "table").Return(dstTable)
+			backupDatasetHandle.On("Table", "backup_dataset_table_1").Return(srcTable)
+
+			dstTable.On("CopierFrom", []bqiface.Table{srcTable}).Return(copier)
+			copier.On("SetCopyConfig", bqiface.CopyConfig{
+				CopyConfig: bigquery.CopyConfig{
+					CreateDisposition: bigquery.CreateIfNeeded,
+					WriteDisposition:  bigquery.WriteTruncate,
+				},
+				Srcs: []bqiface.Table{srcTable},
+				Dst:  dstTable,
+			}).Return()
+			copier.On("Run", testingContext).Return(job, nil)
+			job.On("Wait", testingContext).Return(&bigquery.JobStatus{State: bigquery.Done}, nil)
+
+			err := restoreTable(testingContext, models.RestoreResourceRequest{
+				Resource: resourceSpec,
+				URN:      "project.optimus_backup.backup_dataset_table_1",
+			}, bQClient)
+			assert.Nil(t, err)
+		})
+		t.Run("should return error if backup name is invalid", func(t *testing.T) {
+			err := restoreTable(testingContext, models.RestoreResourceRequest{
+				Resource: resourceSpec,
+				URN:      "backup_dataset_table_1",
+			}, new(BqClientMock))
+			assert.NotNil(t, err)
+		})
+	})
+}
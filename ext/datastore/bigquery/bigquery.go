@@ -3,8 +3,13 @@ package bigquery
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
 
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
 
 	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
 	"github.com/odpf/optimus/models"
@@ -41,9 +46,10 @@ func (b BigQuery) Description() string {
 
 func (b BigQuery) Types() map[models.ResourceType]models.DatastoreTypeController {
 	return map[models.ResourceType]models.DatastoreTypeController{
-		models.ResourceTypeTable:   &tableSpec{},
-		models.ResourceTypeView:    &standardViewSpec{},
-		models.ResourceTypeDataset: &datasetSpec{},
+		models.ResourceTypeTable:         &tableSpec{},
+		models.ResourceTypeView:          &standardViewSpec{},
+		models.ResourceTypeExternalTable: &externalTableSpec{},
+		models.ResourceTypeDataset:       &datasetSpec{},
 	}
 }
 
@@ -60,9 +66,11 @@ func (b *BigQuery) CreateResource(ctx context.Context, request models.CreateReso
 
 	switch request.Resource.Type {
 	case models.ResourceTypeTable:
-		return createTable(ctx, request.Resource, client, false)
+		return createTable(ctx, request.Resource, client, false, false)
 	case models.ResourceTypeView:
 		return createStandardView(ctx, request.Resource, client, false)
+	case models.ResourceTypeExternalTable:
+		return createExternalTable(ctx, request.Resource, client, false)
 	case models.ResourceTypeDataset:
 		return createDataset(ctx, request.Resource, client, false)
 	}
@@ -82,9 +90,11 @@ func (b *BigQuery) UpdateResource(ctx context.Context, request models.UpdateReso
 
 	switch request.Resource.Type {
 	case models.ResourceTypeTable:
-		return createTable(ctx, request.Resource, client, true)
+		return createTable(ctx, request.Resource, client, true, request.Force)
 	case models.ResourceTypeView:
 		return createStandardView(ctx, request.Resource, client, true)
+	case models.ResourceTypeExternalTable:
+		return createExternalTable(ctx, request.Resource, client, true)
 	case models.ResourceTypeDataset:
 		return createDataset(ctx, request.Resource, client, true)
 	}
@@ -111,7 +121,7 @@ func (b *BigQuery) ReadResource(ctx context.Context, request models.ReadResource
 		return models.ReadResourceResponse{
 			Resource: info,
 		}, nil
-	case models.ResourceTypeView:
+	case models.ResourceTypeView, models.ResourceTypeExternalTable:
 		info, err := getTable(ctx, request.Resource, client)
 		if err != nil {
 			return models.ReadResourceResponse{}, err
@@ -145,7 +155,7 @@ func (b *BigQuery) DeleteResource(ctx context.Context, request models.DeleteReso
 	switch request.Resource.Type {
 	case models.ResourceTypeTable:
 		return deleteTable(ctx, request.Resource, client)
-	case models.ResourceTypeView:
+	case models.ResourceTypeView, models.ResourceTypeExternalTable:
 		return deleteTable(ctx, request.Resource, client)
 	case models.ResourceTypeDataset:
 		return deleteDataset(ctx, request.Resource, client)
@@ -153,6 +163,121 @@ func (b *BigQuery) DeleteResource(ctx context.Context, request models.DeleteReso
 	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
 }
 
+func (b *BigQuery) RenameResource(ctx context.Context, request models.RenameResourceRequest) error {
+	svcAcc, ok := request.Project.Secret.GetByName(SecretName)
+	if !ok || len(svcAcc) == 0 {
+		return errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, b.Name()))
+	}
+
+	client, err := b.ClientFac.New(ctx, svcAcc)
+	if err != nil {
+		return err
+	}
+
+	switch request.Resource.Type {
+	case models.ResourceTypeTable:
+		return renameTable(ctx, request.Resource, request.NewResource, client, request.AllowCopy)
+	case models.ResourceTypeView:
+		return renameStandardView(ctx, request.Resource, request.NewResource, client)
+	case models.ResourceTypeExternalTable:
+		return renameExternalTable(ctx, request.Resource, request.NewResource, client)
+	case models.ResourceTypeDataset:
+		return errors.New("bigquery datasets can't be renamed, create the new dataset and rename its tables instead")
+	}
+	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+}
+
+func (b *BigQuery) BackupResource(ctx context.Context, request models.BackupResourceRequest) (models.BackupResourceResponse, error) {
+	svcAcc, ok := request.Project.Secret.GetByName(SecretName)
+	if !ok || len(svcAcc) == 0 {
+		return models.BackupResourceResponse{}, errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, b.Name()))
+	}
+
+	client, err := b.ClientFac.New(ctx, svcAcc)
+	if err != nil {
+		return models.BackupResourceResponse{}, err
+	}
+
+	switch request.Resource.Type {
+	case models.ResourceTypeTable:
+		urn, err := backupTable(ctx, request, client)
+		if err != nil {
+			return models.BackupResourceResponse{}, err
+		}
+		return models.BackupResourceResponse{
+			URN: urn,
+		}, nil
+	}
+	return models.BackupResourceResponse{}, fmt.Errorf("backup of resource type %s is not supported", request.Resource.Type)
+}
+
+func (b *BigQuery) RestoreResource(ctx context.Context, request models.RestoreResourceRequest) error {
+	svcAcc, ok := request.Project.Secret.GetByName(SecretName)
+	if !ok || len(svcAcc) == 0 {
+		return errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, b.Name()))
+	}
+
+	client, err := b.ClientFac.New(ctx, svcAcc)
+	if err != nil {
+		return err
+	}
+
+	switch request.Resource.Type {
+	case models.ResourceTypeTable:
+		return restoreTable(ctx, request, client)
+	}
+	return fmt.Errorf("restore of resource type %s is not supported", request.Resource.Type)
+}
+
+func (b *BigQuery) CheckDatastore(ctx context.Context, request models.CheckDatastoreRequest) error {
+	svcAcc, ok := request.Project.Secret.GetByName(SecretName)
+	if !ok || len(svcAcc) == 0 {
+		return &models.DatastoreError{
+			Category: models.DatastoreErrorCategoryAuth,
+			Err:      errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, b.Name())),
+		}
+	}
+
+	client, err := b.ClientFac.New(ctx, svcAcc)
+	if err != nil {
+		return &models.DatastoreError{Category: models.DatastoreErrorCategoryAuth, Err: err}
+	}
+
+	// listing a single dataset is enough to verify credentials
+	it := client.Datasets(ctx)
+	it.PageInfo().MaxSize = 1
+	if _, err := it.Next(); err != nil && err != iterator.Done {
+		return &models.DatastoreError{Category: categorizeError(err), Err: err}
+	}
+	return nil
+}
+
+// categorizeError maps errors returned by bigquery client to datastore
+// error categories
+func categorizeError(err error) models.DatastoreErrorCategory {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusUnauthorized:
+			return models.DatastoreErrorCategoryAuth
+		case http.StatusForbidden:
+			return models.DatastoreErrorCategoryPermission
+		}
+		return models.DatastoreErrorCategoryUnknown
+	}
+
+	var tokenErr *oauth2.RetrieveError
+	if errors.As(err, &tokenErr) {
+		return models.DatastoreErrorCategoryAuth
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return models.DatastoreErrorCategoryNetwork
+	}
+	return models.DatastoreErrorCategoryUnknown
+}
+
 func init() {
 	if err := models.DatastoreRegistry.Add(This); err != nil {
 		panic(err)
@@ -3,10 +3,14 @@ package bigquery
 import (
 	"context"
 	"errors"
+	"net"
+	"net/url"
 	"testing"
 
 	"github.com/odpf/optimus/models"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
 )
 
 func TestBigquery(t *testing.T) {
@@ -448,4 +452,91 @@ func TestBigquery(t *testing.T) {
 			assert.NotNil(t, err)
 		})
 	})
+	t.Run("CheckDatastore", func(t *testing.T) {
+		t.Run("should return auth error when secret not found", func(t *testing.T) {
+			bq := BigQuery{}
+			err := bq.CheckDatastore(testingContext, models.CheckDatastoreRequest{Project: models.ProjectSpec{}})
+
+			var dsErr *models.DatastoreError
+			assert.True(t, errors.As(err, &dsErr))
+			assert.Equal(t, models.DatastoreErrorCategoryAuth, dsErr.Category)
+		})
+		t.Run("should return auth error when creating BQ client is failed", func(t *testing.T) {
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+
+			bQClientFactory := new(BQClientFactoryMock)
+			bQClientFactory.On("New", testingContext, secret).Return(bQClient, errors.New("failed to read secret"))
+			defer bQClientFactory.AssertExpectations(t)
+
+			bq := BigQuery{
+				ClientFac: bQClientFactory,
+			}
+			err := bq.CheckDatastore(testingContext, models.CheckDatastoreRequest{Project: projectSpec})
+
+			var dsErr *models.DatastoreError
+			assert.True(t, errors.As(err, &dsErr))
+			assert.Equal(t, models.DatastoreErrorCategoryAuth, dsErr.Category)
+		})
+		t.Run("should list a single dataset to verify the datastore", func(t *testing.T) {
+			pageInfo := &iterator.PageInfo{}
+			datasetIterator := new(BqDatasetIteratorMock)
+			datasetIterator.On("PageInfo").Return(pageInfo)
+			datasetIterator.On("Next").Return(nil, iterator.Done)
+			defer datasetIterator.AssertExpectations(t)
+
+			bQClient := new(BqClientMock)
+			bQClient.On("Datasets", testingContext).Return(datasetIterator)
+			defer bQClient.AssertExpectations(t)
+
+			bQClientFactory := new(BQClientFactoryMock)
+			bQClientFactory.On("New", testingContext, secret).Return(bQClient, nil)
+			defer bQClientFactory.AssertExpectations(t)
+
+			bq := BigQuery{
+				ClientFac: bQClientFactory,
+			}
+			err := bq.CheckDatastore(testingContext, models.CheckDatastoreRequest{Project: projectSpec})
+
+			assert.Nil(t, err)
+			assert.Equal(t, 1, pageInfo.MaxSize)
+		})
+		t.Run("should categorize errors returned while listing datasets", func(t *testing.T) {
+			testCases := []struct {
+				name     string
+				err      error
+				category models.DatastoreErrorCategory
+			}{
+				{"unauthenticated", &googleapi.Error{Code: 401}, models.DatastoreErrorCategoryAuth},
+				{"permission denied", &googleapi.Error{Code: 403}, models.DatastoreErrorCategoryPermission},
+				{"network", &url.Error{Op: "Get", URL: "https://bigquery.googleapis.com", Err: &net.DNSError{Err: "no such host"}}, models.DatastoreErrorCategoryNetwork},
+				{"unknown", errors.New("random error"), models.DatastoreErrorCategoryUnknown},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					datasetIterator := new(BqDatasetIteratorMock)
+					datasetIterator.On("PageInfo").Return(&iterator.PageInfo{})
+					datasetIterator.On("Next").Return(nil, tc.err)
+					defer datasetIterator.AssertExpectations(t)
+
+					bQClient := new(BqClientMock)
+					bQClient.On("Datasets", testingContext).Return(datasetIterator)
+					defer bQClient.AssertExpectations(t)
+
+					bQClientFactory := new(BQClientFactoryMock)
+					bQClientFactory.On("New", testingContext, secret).Return(bQClient, nil)
+					defer bQClientFactory.AssertExpectations(t)
+
+					bq := BigQuery{
+						ClientFac: bQClientFactory,
+					}
+					err := bq.CheckDatastore(testingContext, models.CheckDatastoreRequest{Project: projectSpec})
+
+					var dsErr *models.DatastoreError
+					assert.True(t, errors.As(err, &dsErr))
+					assert.Equal(t, tc.category, dsErr.Category)
+				})
+			}
+		})
+	})
 }
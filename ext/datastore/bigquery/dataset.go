@@ -27,6 +27,11 @@ func createDataset(ctx context.Context, spec models.ResourceSpec, client bqiface
 
 	// inherit from base
 	bqResource.Metadata.Labels = spec.Labels
+	description, err := resolveDescription(bqResource.Metadata.Description, spec.Assets)
+	if err != nil {
+		return err
+	}
+	bqResource.Metadata.Description = description
 
 	dataset := client.DatasetInProject(bqResource.Project, bqResource.Dataset)
 	if err := ensureDataset(ctx, dataset, bqResource, upsert); err != nil {
@@ -52,6 +57,8 @@ func ensureDataset(ctx context.Context, datasetHandle bqiface.Dataset, bqResourc
 		if bqResource.Metadata.DefaultTableExpiration > 0 {
 			meta.DefaultTableExpiration = time.Hour * time.Duration(bqResource.Metadata.DefaultTableExpiration)
 		}
+		// DefaultPartitionExpiration is kept only in spec, the bigquery client
+		// in use doesn't expose default partition expiration of a dataset yet
 		return datasetHandle.Create(ctx, &bqiface.DatasetMetadata{
 			DatasetMetadata: meta,
 		})
@@ -14,6 +14,11 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// datasetNameFormat is how dataset names are written
+	datasetNameFormat = "project_name.dataset_name"
+)
+
 var (
 	datasetNameParseRegex = regexp.MustCompile(`^([\w-]+)\.(\w+)$`)
 )
@@ -25,6 +30,8 @@ type DatasetResourceSpec struct {
 	Type    models.ResourceType
 	Spec    BQDatasetMetadata
 	Labels  map[string]string
+
+	DependsOn []string `yaml:"depends_on,omitempty"`
 }
 
 // BQDataset is a specification for a BigQuery Dataset
@@ -36,9 +43,14 @@ type BQDataset struct {
 }
 
 type BQDatasetMetadata struct {
-	Description            string            `yaml:",omitempty" structs:"description,omitempty"`
-	DefaultTableExpiration int64             `yaml:"table_expiration,omitempty" structs:"table_expiration,omitempty"`
-	Labels                 map[string]string `yaml:"-" structs:"-"` // will be inherited by base resource
+	Description string            `yaml:",omitempty" structs:"description,omitempty"`
+	Labels      map[string]string `yaml:"-" structs:"-"` // will be inherited by base resource
+
+	// DefaultTableExpiration in hours, applied to tables created in the dataset
+	DefaultTableExpiration int64 `yaml:"table_expiration,omitempty" structs:"table_expiration,omitempty"`
+	// DefaultPartitionExpiration in hours, applied to each partition of partitioned
+	// tables created in the dataset, independent of the table expiration
+	DefaultPartitionExpiration int64 `yaml:"partition_expiration,omitempty" structs:"partition_expiration,omitempty"`
 
 	Location string `yaml:",omitempty" structs:"location,omitempty"`
 }
@@ -63,11 +75,18 @@ func (s datasetSpecHandler) ToYaml(optResource models.ResourceSpec) ([]byte, err
 		Type:    optResource.Type,
 		Spec:    bqResource.Metadata,
 		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
 	}
 	return yaml.Marshal(yamlResource)
 }
 
 func (s datasetSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
+	b, err := datasetSpecMigrator.migrateYaml(b)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+
 	var yamlResource DatasetResourceSpec
 	if err := yaml.Unmarshal(b, &yamlResource); err != nil {
 		return models.ResourceSpec{}, err
@@ -88,7 +107,8 @@ func (s datasetSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
 			Dataset:  parsedNames[2],
 			Metadata: yamlResource.Spec,
 		},
-		Labels: yamlResource.Labels,
+		Labels:    yamlResource.Labels,
+		DependsOn: yamlResource.DependsOn,
 	}
 	return optResource, nil
 }
@@ -109,6 +129,8 @@ func (s datasetSpecHandler) ToProtobuf(optResource models.ResourceSpec) ([]byte,
 		Spec:    bqResourceProtoSpec,
 		Assets:  optResource.Assets,
 		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
 	}
 	return proto.Marshal(resSpec)
 }
@@ -118,6 +140,11 @@ func (s datasetSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error)
 	if err := proto.Unmarshal(b, baseSpec); err != nil {
 		return models.ResourceSpec{}, err
 	}
+	version, spec, err := datasetSpecMigrator.migrateProto(baseSpec.Version, baseSpec.Spec)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	baseSpec.Version, baseSpec.Spec = version, spec
 
 	parsedNames := datasetNameParseRegex.FindStringSubmatch(baseSpec.Name)
 	if len(parsedNames) < 3 {
@@ -137,6 +164,10 @@ func (s datasetSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error)
 		if protoSpecField, ok := baseSpec.Spec.Fields["table_expiration"]; ok {
 			bqMeta.DefaultTableExpiration = int64(protoSpecField.GetNumberValue())
 		}
+
+		if protoSpecField, ok := baseSpec.Spec.Fields["partition_expiration"]; ok {
+			bqMeta.DefaultPartitionExpiration = int64(protoSpecField.GetNumberValue())
+		}
 	}
 
 	optResource := models.ResourceSpec{
@@ -150,7 +181,8 @@ func (s datasetSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error)
 			Dataset:  parsedNames[2],
 			Metadata: bqMeta,
 		},
-		Labels: baseSpec.Labels,
+		Labels:    baseSpec.Labels,
+		DependsOn: baseSpec.DependsOn,
 	}
 	return optResource, nil
 }
@@ -164,16 +196,34 @@ func (s datasetSpec) Adapter() models.DatastoreSpecAdapter {
 func (s datasetSpec) Validator() models.DatastoreSpecValidator {
 	return func(spec models.ResourceSpec) error {
 		if !datasetNameParseRegex.MatchString(spec.Name) {
-			return fmt.Errorf("for example 'project_name.dataset_name'")
+			return fmt.Errorf("for example '%s'", datasetNameFormat)
 		}
 		parsedNames := datasetNameParseRegex.FindStringSubmatch(spec.Name)
 		if len(parsedNames) < 3 || len(parsedNames[1]) == 0 || len(parsedNames[2]) == 0 {
-			return fmt.Errorf("for example 'project_name.dataset_name'")
+			return fmt.Errorf("for example '%s'", datasetNameFormat)
+		}
+
+		if err := validateLabels(spec.Labels); err != nil {
+			return err
+		}
+
+		// spec is not available when only the name is being validated
+		if bqResource, ok := spec.Spec.(BQDataset); ok {
+			if bqResource.Metadata.DefaultTableExpiration < 0 {
+				return fmt.Errorf("table expiration of dataset %s cannot be negative", spec.Name)
+			}
+			if bqResource.Metadata.DefaultPartitionExpiration < 0 {
+				return fmt.Errorf("partition expiration of dataset %s cannot be negative", spec.Name)
+			}
 		}
 		return nil
 	}
 }
 
+func (s datasetSpec) NameFormat() string {
+	return datasetNameFormat
+}
+
 func (s datasetSpec) DefaultAssets() map[string]string {
 	return map[string]string{}
 }
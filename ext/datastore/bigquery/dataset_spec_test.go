@@ -55,4 +55,109 @@ labels:
 		assert.Nil(t, err)
 		assert.Equal(t, originalRes, resBack)
 	})
+	t.Run("should keep table and partition expiration independent in yaml", func(t *testing.T) {
+		handler := datasetSpecHandler{}
+		for _, meta := range []BQDatasetMetadata{
+			{DefaultTableExpiration: 24},
+			{DefaultPartitionExpiration: 48},
+			{DefaultTableExpiration: 24, DefaultPartitionExpiration: 48},
+		} {
+			originalRes := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      "dataset",
+				Datastore: This,
+				Spec: BQDataset{
+					Project:  "proj",
+					Dataset:  "datas",
+					Metadata: meta,
+				},
+			}
+			yamlInBytes, err := handler.ToYaml(originalRes)
+			assert.Nil(t, err)
+			resBack, err := handler.FromYaml(yamlInBytes)
+			assert.Nil(t, err)
+			assert.Equal(t, meta, resBack.Spec.(BQDataset).Metadata)
+		}
+	})
+	t.Run("should keep table and partition expiration independent in proto", func(t *testing.T) {
+		handler := datasetSpecHandler{}
+		for _, meta := range []BQDatasetMetadata{
+			{DefaultTableExpiration: 24},
+			{DefaultPartitionExpiration: 48},
+			{DefaultTableExpiration: 24, DefaultPartitionExpiration: 48},
+		} {
+			originalRes := models.ResourceSpec{
+				Version:   1,
+				Name:      "proj.datas",
+				Type:      "dataset",
+				Datastore: This,
+				Spec: BQDataset{
+					Project:  "proj",
+					Dataset:  "datas",
+					Metadata: meta,
+				},
+			}
+			protoInBytes, err := handler.ToProtobuf(originalRes)
+			assert.Nil(t, err)
+			resBack, err := handler.FromProtobuf(protoInBytes)
+			assert.Nil(t, err)
+			assert.Equal(t, meta, resBack.Spec.(BQDataset).Metadata)
+		}
+	})
+	t.Run("should read partition expiration from yaml", func(t *testing.T) {
+		fl := `
+version: 1
+name: prj.datas
+type: dataset
+spec:
+  table_expiration: 24
+  partition_expiration: 720
+`
+		handler := datasetSpecHandler{}
+		res, err := handler.FromYaml([]byte(fl))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(24), res.Spec.(BQDataset).Metadata.DefaultTableExpiration)
+		assert.Equal(t, int64(720), res.Spec.(BQDataset).Metadata.DefaultPartitionExpiration)
+	})
+}
+
+func TestDatasetSpecValidator(t *testing.T) {
+	validator := datasetSpec{}.Validator()
+	t.Run("should accept name without spec", func(t *testing.T) {
+		assert.Nil(t, validator(models.ResourceSpec{Name: "proj.datas"}))
+	})
+	t.Run("should reject negative table expiration", func(t *testing.T) {
+		err := validator(models.ResourceSpec{
+			Name: "proj.datas",
+			Spec: BQDataset{Metadata: BQDatasetMetadata{DefaultTableExpiration: -1}},
+		})
+		assert.Equal(t, "table expiration of dataset proj.datas cannot be negative", err.Error())
+	})
+	t.Run("should reject negative partition expiration", func(t *testing.T) {
+		err := validator(models.ResourceSpec{
+			Name: "proj.datas",
+			Spec: BQDataset{Metadata: BQDatasetMetadata{DefaultPartitionExpiration: -1}},
+		})
+		assert.Equal(t, "partition expiration of dataset proj.datas cannot be negative", err.Error())
+	})
+	t.Run("should reject labels not following bigquery rules", func(t *testing.T) {
+		err := validator(models.ResourceSpec{
+			Name:   "proj.datas",
+			Labels: map[string]string{"Owner": "optimus"},
+		})
+		assert.Contains(t, err.Error(), "invalid label key Owner")
+
+		err = validator(models.ResourceSpec{
+			Name:   "proj.datas",
+			Labels: map[string]string{"owner": "Optimus"},
+		})
+		assert.Contains(t, err.Error(), "invalid value of label owner")
+	})
+	t.Run("should skip templated label values", func(t *testing.T) {
+		assert.Nil(t, validator(models.ResourceSpec{
+			Name:   "proj.datas",
+			Labels: map[string]string{"environment": "{{.proj.ENV}}"},
+		}))
+	})
 }
@@ -0,0 +1,29 @@
+package bigquery
+
+import (
+	"strings"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// descriptionAssetPrefix marks a description as the name of a resource
+	// asset holding the actual text, e.g. @description.md
+	descriptionAssetPrefix = "@"
+)
+
+// resolveDescription returns the text of an asset when description refers
+// to one, any other description is used as it is
+func resolveDescription(description string, assets models.ResourceAssets) (string, error) {
+	ref := strings.TrimSpace(description)
+	if !strings.HasPrefix(ref, descriptionAssetPrefix) {
+		return description, nil
+	}
+	assetName := strings.TrimPrefix(ref, descriptionAssetPrefix)
+	text, ok := assets.GetByName(assetName)
+	if !ok {
+		return "", errors.Errorf("description asset %s not found", assetName)
+	}
+	return strings.TrimSpace(text), nil
+}
@@ -0,0 +1,118 @@
+package bigquery
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"time"
+
+	bqapi "cloud.google.com/go/bigquery"
+
+	"google.golang.org/api/googleapi"
+
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+func createExternalTable(ctx context.Context, spec models.ResourceSpec, client bqiface.Client, upsert bool) error {
+	bqResource, ok := spec.Spec.(BQTable)
+	if !ok {
+		return errors.New("failed to read table spec for bigquery")
+	}
+	if err := validateExternalSource(bqResource.Metadata.Source); err != nil {
+		return err
+	}
+
+	// inherit from base
+	bqResource.Metadata.Labels = spec.Labels
+	description, err := resolveDescription(bqResource.Metadata.Description, spec.Assets)
+	if err != nil {
+		return err
+	}
+	bqResource.Metadata.Description = description
+
+	dataset := client.DatasetInProject(bqResource.Project, bqResource.Dataset)
+	if err := ensureDataset(ctx, dataset, BQDataset{
+		Project:  bqResource.Project,
+		Dataset:  bqResource.Dataset,
+		Metadata: BQDatasetMetadata{},
+	}, false); err != nil {
+		return err
+	}
+	table := dataset.Table(bqResource.Table)
+	return ensureExternalTable(ctx, table, bqResource, upsert)
+}
+
+// ensureExternalTable make sures external table exists reading from the
+// source of spec. Source of an existing table can't be updated, the table
+// is recreated instead as it holds no data of its own
+func ensureExternalTable(ctx context.Context, tableHandle bqiface.Table, t BQTable, upsert bool) error {
+	externalConfig, err := bqExternalDataConfigTo(*t.Metadata.Source, t.Metadata.Schema)
+	if err != nil {
+		return err
+	}
+	meta := &bqapi.TableMetadata{
+		Description:        t.Metadata.Description,
+		Labels:             t.Metadata.Labels,
+		ExternalDataConfig: externalConfig,
+	}
+	if t.Metadata.ExpirationTime != "" {
+		expiryTime, err := time.Parse(time.RFC3339, t.Metadata.ExpirationTime)
+		if err != nil {
+			return errors.Wrapf(err, "unable to parse timestamp %s", t.Metadata.ExpirationTime)
+		}
+		meta.ExpirationTime = expiryTime
+	}
+
+	existing, err := tableHandle.Metadata(ctx)
+	if err != nil {
+		if metaErr, ok := err.(*googleapi.Error); !ok || metaErr.Code != http.StatusNotFound {
+			return err
+		}
+		return tableHandle.Create(ctx, meta)
+	}
+	if !upsert {
+		return nil
+	}
+
+	if !reflect.DeepEqual(bqExternalSourceFrom(existing.ExternalDataConfig), bqExternalSourceFrom(externalConfig)) {
+		if err := tableHandle.Delete(ctx); err != nil {
+			return errors.Wrap(err, "failed to delete external table to change its source")
+		}
+		return tableHandle.Create(ctx, meta)
+	}
+
+	// update if already exists
+	m := bqapi.TableMetadataToUpdate{
+		Description:    t.Metadata.Description,
+		Schema:         externalConfig.Schema,
+		ExpirationTime: meta.ExpirationTime,
+	}
+	for k, v := range t.Metadata.Labels {
+		m.SetLabel(k, v)
+	}
+	_, err = tableHandle.Update(ctx, m, existing.ETag)
+	return err
+}
+
+// renameExternalTable recreates the external table with its new name and
+// deletes the old one, data stays in the source
+func renameExternalTable(ctx context.Context, oldSpec, newSpec models.ResourceSpec, client bqiface.Client) error {
+	newTable, ok := newSpec.Spec.(BQTable)
+	if !ok {
+		return errors.New("failed to read table spec for bigquery")
+	}
+	exists, err := tableExists(ctx, client.DatasetInProject(newTable.Project, newTable.Dataset).Table(newTable.Table))
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Wrapf(models.ErrResourceExists, "external table %s", newSpec.Name)
+	}
+
+	if err := createExternalTable(ctx, newSpec, client, false); err != nil {
+		return err
+	}
+	return deleteTable(ctx, oldSpec, client)
+}
@@ -0,0 +1,76 @@
+package bigquery
+
+import (
+	"fmt"
+	"strings"
+
+	bqapi "cloud.google.com/go/bigquery"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+var (
+	// supportedExternalSources are formats external tables can read, google
+	// sheets are read from drive and rest of them from cloud storage
+	supportedExternalSources = map[bqapi.DataFormat]string{
+		bqapi.GoogleSheets: "https://docs.google.com/spreadsheets/",
+		bqapi.CSV:          "gs://",
+		bqapi.JSON:         "gs://",
+		bqapi.Avro:         "gs://",
+		bqapi.Parquet:      "gs://",
+		bqapi.ORC:          "gs://",
+	}
+)
+
+type externalTableSpec struct{}
+
+func (s externalTableSpec) Adapter() models.DatastoreSpecAdapter {
+	return &tableSpecHandler{}
+}
+
+func (s externalTableSpec) Validator() models.DatastoreSpecValidator {
+	return func(spec models.ResourceSpec) error {
+		if !tableNameParseRegex.MatchString(spec.Name) {
+			return fmt.Errorf("for example '%s'", tableNameFormat)
+		}
+		parsedNames := tableNameParseRegex.FindStringSubmatch(spec.Name)
+		if len(parsedNames) < 3 || len(parsedNames[1]) == 0 || len(parsedNames[2]) == 0 || len(parsedNames[3]) == 0 {
+			return fmt.Errorf("for example '%s'", tableNameFormat)
+		}
+		if bqResource, ok := spec.Spec.(BQTable); ok {
+			if err := validateExternalSource(bqResource.Metadata.Source); err != nil {
+				return err
+			}
+		}
+		return validateLabels(spec.Labels)
+	}
+}
+
+func (s externalTableSpec) NameFormat() string {
+	return tableNameFormat
+}
+
+func (s externalTableSpec) DefaultAssets() map[string]string {
+	return map[string]string{}
+}
+
+// validateExternalSource checks the source format is supported and every
+// uri points to where data of that format is read from
+func validateExternalSource(source *BQExternalSource) error {
+	if source == nil {
+		return errors.New("source of external table is required")
+	}
+	uriPrefix, ok := supportedExternalSources[bqapi.DataFormat(strings.ToUpper(source.SourceType))]
+	if !ok {
+		return fmt.Errorf("unsupported source type %s of external table", source.SourceType)
+	}
+	if len(source.SourceURIs) == 0 {
+		return errors.New("source uris of external table are required")
+	}
+	for _, uri := range source.SourceURIs {
+		if !strings.HasPrefix(uri, uriPrefix) {
+			return fmt.Errorf("source uri %s of %s external table should start with %s", uri, source.SourceType, uriPrefix)
+		}
+	}
+	return nil
+}
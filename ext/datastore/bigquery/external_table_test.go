@@ -0,0 +1,182 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestExternalTable(t *testing.T) {
+	testingContext := context.Background()
+	eTag := "etag-0000"
+	errNotFound := &googleapi.Error{
+		Code: 404,
+	}
+	sheetURI := "https://docs.google.com/spreadsheets/d/sheet-id"
+	bQResource := BQTable{
+		Project: "project",
+		Dataset: "dataset",
+		Table:   "sheet",
+		Metadata: BQTableMetadata{
+			Description: "table description",
+			Schema: BQSchema{
+				{Name: "id", Type: "STRING"},
+			},
+			Source: &BQExternalSource{
+				SourceType:      "google_sheets",
+				SourceURIs:      []string{sheetURI},
+				SkipLeadingRows: 1,
+				Range:           "sheet1!A1:B20",
+			},
+		},
+	}
+	externalConfig := &bigquery.ExternalDataConfig{
+		SourceFormat: bigquery.GoogleSheets,
+		SourceURIs:   []string{sheetURI},
+		Schema: bigquery.Schema{
+			{Name: "id", Type: bigquery.StringFieldType},
+		},
+		Options: &bigquery.GoogleSheetsOptions{
+			SkipLeadingRows: 1,
+			Range:           "sheet1!A1:B20",
+		},
+	}
+	createTableMeta := &bigquery.TableMetadata{
+		Description:        "table description",
+		ExternalDataConfig: externalConfig,
+	}
+
+	t.Run("ensureExternalTable", func(t *testing.T) {
+		t.Run("should create external table if it does not exist", func(t *testing.T) {
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			bQTable.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), errNotFound)
+			bQTable.On("Create", testingContext, createTableMeta).Return(nil)
+
+			err := ensureExternalTable(testingContext, bQTable, bQResource, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should not do insert nor update if table exists and not an upsert call", func(t *testing.T) {
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			bQTable.On("Metadata", testingContext).Return(&bigquery.TableMetadata{}, nil)
+
+			err := ensureExternalTable(testingContext, bQTable, bQResource, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should update table if source is unchanged and an upsert call", func(t *testing.T) {
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			bQTable.On("Metadata", testingContext).Return(&bigquery.TableMetadata{
+				ExternalDataConfig: externalConfig,
+				ETag:               eTag,
+			}, nil)
+			bQTable.On("Update", testingContext, bigquery.TableMetadataToUpdate{
+				Description: "table description",
+				Schema:      externalConfig.Schema,
+			}, eTag).Return((*bigquery.TableMetadata)(nil), nil)
+
+			err := ensureExternalTable(testingContext, bQTable, bQResource, true)
+			assert.Nil(t, err)
+		})
+		t.Run("should recreate table if source is changed and an upsert call", func(t *testing.T) {
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			bQTable.On("Metadata", testingContext).Return(&bigquery.TableMetadata{
+				ExternalDataConfig: &bigquery.ExternalDataConfig{
+					SourceFormat: bigquery.GoogleSheets,
+					SourceURIs:   []string{"https://docs.google.com/spreadsheets/d/old-sheet-id"},
+				},
+				ETag: eTag,
+			}, nil)
+			bQTable.On("Delete", testingContext).Return(nil)
+			bQTable.On("Create", testingContext, createTableMeta).Return(nil)
+
+			err := ensureExternalTable(testingContext, bQTable, bQResource, true)
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("createExternalTable", func(t *testing.T) {
+		t.Run("should create external table if given valid input", func(t *testing.T) {
+			resourceSpec := models.ResourceSpec{
+				Spec: bQResource,
+			}
+
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+
+			bQDatasetHandle := new(BqDatasetMock)
+			defer bQDatasetHandle.AssertExpectations(t)
+
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			bQClient.On("DatasetInProject", bQResource.Project, bQResource.Dataset).Return(bQDatasetHandle)
+			datasetMetadata := bqiface.DatasetMetadata{
+				DatasetMetadata: bigquery.DatasetMetadata{},
+			}
+			bQDatasetHandle.On("Metadata", testingContext).Return(&datasetMetadata, nil)
+			bQTable.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), errNotFound)
+			bQDatasetHandle.On("Table", bQResource.Table).Return(bQTable, nil)
+			bQTable.On("Create", testingContext, createTableMeta).Return(nil)
+
+			err := createExternalTable(testingContext, resourceSpec, bQClient, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should return error if source is not defined", func(t *testing.T) {
+			resourceSpec := models.ResourceSpec{
+				Spec: BQTable{
+					Project: "project",
+					Dataset: "dataset",
+					Table:   "sheet",
+				},
+			}
+
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+
+			err := createExternalTable(testingContext, resourceSpec, bQClient, false)
+			assert.EqualError(t, err, "source of external table is required")
+		})
+	})
+	t.Run("Validator", func(t *testing.T) {
+		validate := externalTableSpec{}.Validator()
+		t.Run("should accept sheets and cloud storage sources", func(t *testing.T) {
+			assert.Nil(t, validate(models.ResourceSpec{Name: "project.dataset.sheet", Spec: bQResource}))
+			assert.Nil(t, validate(models.ResourceSpec{Name: "project.dataset.files", Spec: BQTable{
+				Metadata: BQTableMetadata{Source: &BQExternalSource{
+					SourceType: "CSV",
+					SourceURIs: []string{"gs://bucket/files/*.csv"},
+				}},
+			}}))
+		})
+		t.Run("should fail if source is unsupported", func(t *testing.T) {
+			err := validate(models.ResourceSpec{Name: "project.dataset.sheet", Spec: BQTable{
+				Metadata: BQTableMetadata{Source: &BQExternalSource{
+					SourceType: "BIGTABLE",
+					SourceURIs: []string{"https://googleapis.com/bigtable/projects/p/instances/i/tables/t"},
+				}},
+			}})
+			assert.EqualError(t, err, "unsupported source type BIGTABLE of external table")
+		})
+		t.Run("should fail if source uri doesn't match its type", func(t *testing.T) {
+			err := validate(models.ResourceSpec{Name: "project.dataset.sheet", Spec: BQTable{
+				Metadata: BQTableMetadata{Source: &BQExternalSource{
+					SourceType: "GOOGLE_SHEETS",
+					SourceURIs: []string{"gs://bucket/sheet.csv"},
+				}},
+			}})
+			assert.EqualError(t, err, "source uri gs://bucket/sheet.csv of GOOGLE_SHEETS external table should "+
+				"start with https://docs.google.com/spreadsheets/")
+		})
+	})
+}
@@ -0,0 +1,34 @@
+package bigquery
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// labels follow bigquery rules, lowercase letters, international characters,
+	// numbers, underscores and dashes are allowed upto 63 characters where
+	// keys should start with a letter
+	labelKeyRegex   = regexp.MustCompile(`^[\p{Ll}\p{Lo}][\p{Ll}\p{Lo}\p{N}_-]{0,62}$`)
+	labelValueRegex = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]{0,63}$`)
+)
+
+// validateLabels checks labels of the resource, templated values are
+// skipped as they are only known once rendered during deployment
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if !labelKeyRegex.MatchString(key) {
+			return fmt.Errorf("invalid label key %s, should start with a lowercase letter and contain "+
+				"only lowercase letters, numbers, underscores and dashes upto 63 characters", key)
+		}
+		if strings.Contains(value, "{{") {
+			continue
+		}
+		if !labelValueRegex.MatchString(value) {
+			return fmt.Errorf("invalid value of label %s, should contain only lowercase letters, numbers, "+
+				"underscores and dashes upto 63 characters", key)
+		}
+	}
+	return nil
+}
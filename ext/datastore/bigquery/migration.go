@@ -0,0 +1,125 @@
+package bigquery
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// current version of resource specs understood by this datastore,
+	// bump it along with a registered migration whenever spec schema changes
+	datasetSpecVersion = 1
+	tableSpecVersion   = 1
+)
+
+var (
+	ErrUnsupportedSpecVersion = errors.New("unsupported resource spec version")
+
+	datasetSpecMigrator = newSpecMigrator(datasetSpecVersion)
+	tableSpecMigrator   = newSpecMigrator(tableSpecVersion)
+)
+
+// SpecMigration upgrades the raw spec of a resource by a single version
+// e.g. renaming the fields of spec that changed in the next version
+type SpecMigration func(spec map[string]interface{}) error
+
+// specMigrator upgrades specs of older versions to the current version by
+// applying all the migrations in between one after another
+type specMigrator struct {
+	current    int
+	migrations map[int]SpecMigration // keyed by version it upgrades from
+}
+
+// Register adds a migration upgrading a spec from fromVersion to fromVersion+1
+func (m *specMigrator) Register(fromVersion int, migration SpecMigration) error {
+	if fromVersion < 1 || fromVersion >= m.current {
+		return fmt.Errorf("invalid migration from version %d, current version is %d", fromVersion, m.current)
+	}
+	if _, ok := m.migrations[fromVersion]; ok {
+		return fmt.Errorf("migration from version %d already registered", fromVersion)
+	}
+	m.migrations[fromVersion] = migration
+	return nil
+}
+
+// Migrate upgrades spec in place and returns the version it got upgraded to.
+// Specs without a version are considered to be of current version
+func (m *specMigrator) Migrate(version int, spec map[string]interface{}) (int, error) {
+	if version > m.current {
+		return version, errors.Wrapf(ErrUnsupportedSpecVersion, "version %d is newer than the supported version %d",
+			version, m.current)
+	}
+	if version == 0 {
+		return version, nil
+	}
+	for from := version; from < m.current; from++ {
+		migration, ok := m.migrations[from]
+		if !ok {
+			return version, fmt.Errorf("missing migration of spec from version %d to %d", from, from+1)
+		}
+		if err := migration(spec); err != nil {
+			return version, errors.Wrapf(err, "failed to migrate spec from version %d to %d", from, from+1)
+		}
+	}
+	return m.current, nil
+}
+
+// migrateYaml upgrades a yaml resource to the current version, returned
+// bytes are same as input if the resource is already up to date
+func (m *specMigrator) migrateYaml(b []byte) ([]byte, error) {
+	var rawResource map[string]interface{}
+	if err := yaml.Unmarshal(b, &rawResource); err != nil {
+		return nil, err
+	}
+	version, _ := rawResource["version"].(int)
+	if version == m.current {
+		return b, nil
+	}
+
+	rawSpec, _ := rawResource["spec"].(map[string]interface{})
+	if rawSpec == nil {
+		rawSpec = map[string]interface{}{}
+	}
+	migratedVersion, err := m.Migrate(version, rawSpec)
+	if err != nil {
+		return nil, err
+	}
+	if migratedVersion == version {
+		return b, nil
+	}
+	rawResource["version"] = migratedVersion
+	rawResource["spec"] = rawSpec
+	return yaml.Marshal(rawResource)
+}
+
+// migrateProto upgrades the spec struct of a protobuf resource to the
+// current version
+func (m *specMigrator) migrateProto(version int32, spec *structpb.Struct) (int32, *structpb.Struct, error) {
+	if int(version) == m.current {
+		return version, spec, nil
+	}
+
+	rawSpec := spec.AsMap()
+	migratedVersion, err := m.Migrate(int(version), rawSpec)
+	if err != nil {
+		return version, nil, err
+	}
+	if migratedVersion == int(version) {
+		return version, spec, nil
+	}
+	migratedSpec, err := structpb.NewStruct(rawSpec)
+	if err != nil {
+		return version, nil, err
+	}
+	return int32(migratedVersion), migratedSpec, nil
+}
+
+func newSpecMigrator(current int) *specMigrator {
+	return &specMigrator{
+		current:    current,
+		migrations: map[int]SpecMigration{},
+	}
+}
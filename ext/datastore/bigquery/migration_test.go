@@ -0,0 +1,138 @@
+package bigquery
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	v1 "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+)
+
+func TestSpecMigrator(t *testing.T) {
+	// version 1 of dataset spec used `expiration` which got renamed
+	// to `table_expiration` in version 2
+	renameExpiration := func(spec map[string]interface{}) error {
+		if val, ok := spec["expiration"]; ok {
+			spec["table_expiration"] = val
+			delete(spec, "expiration")
+		}
+		return nil
+	}
+	withDatasetMigrator := func(t *testing.T, migrator *specMigrator) {
+		original := datasetSpecMigrator
+		datasetSpecMigrator = migrator
+		t.Cleanup(func() {
+			datasetSpecMigrator = original
+		})
+	}
+
+	t.Run("should migrate v1 yaml fixture to current spec", func(t *testing.T) {
+		migrator := newSpecMigrator(2)
+		assert.Nil(t, migrator.Register(1, renameExpiration))
+		withDatasetMigrator(t, migrator)
+
+		fl := `
+version: 1
+name: prj.datas
+type: dataset
+spec:
+  description: hello-world
+  expiration: 24
+labels:
+  key: value
+`
+		res, err := datasetSpecHandler{}.FromYaml([]byte(fl))
+		assert.Nil(t, err)
+		assert.Equal(t, models.ResourceSpec{
+			Version:   2,
+			Name:      "prj.datas",
+			Type:      "dataset",
+			Datastore: This,
+			Spec: BQDataset{
+				Project: "prj",
+				Dataset: "datas",
+				Metadata: BQDatasetMetadata{
+					Description:            "hello-world",
+					DefaultTableExpiration: 24,
+				},
+			},
+			Labels: map[string]string{
+				"key": "value",
+			},
+		}, res)
+	})
+	t.Run("should migrate v1 proto fixture to current spec", func(t *testing.T) {
+		migrator := newSpecMigrator(2)
+		assert.Nil(t, migrator.Register(1, renameExpiration))
+		withDatasetMigrator(t, migrator)
+
+		spec, err := structpb.NewStruct(map[string]interface{}{
+			"description": "hello-world",
+			"expiration":  24,
+		})
+		assert.Nil(t, err)
+		protoInBytes, err := proto.Marshal(&v1.ResourceSpecification{
+			Version: 1,
+			Name:    "prj.datas",
+			Type:    "dataset",
+			Spec:    spec,
+		})
+		assert.Nil(t, err)
+
+		res, err := datasetSpecHandler{}.FromProtobuf(protoInBytes)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, res.Version)
+		assert.Equal(t, BQDatasetMetadata{
+			Description:            "hello-world",
+			DefaultTableExpiration: 24,
+		}, res.Spec.(BQDataset).Metadata)
+	})
+	t.Run("should not touch specs of current version", func(t *testing.T) {
+		migrator := newSpecMigrator(2)
+		assert.Nil(t, migrator.Register(1, func(spec map[string]interface{}) error {
+			return errors.New("should not be called")
+		}))
+
+		spec := map[string]interface{}{"description": "hello-world"}
+		version, err := migrator.Migrate(2, spec)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, version)
+		assert.Equal(t, map[string]interface{}{"description": "hello-world"}, spec)
+	})
+	t.Run("should reject versions newer than supported", func(t *testing.T) {
+		fl := `
+version: 99
+name: prj.datas
+type: dataset
+`
+		_, err := datasetSpecHandler{}.FromYaml([]byte(fl))
+		assert.True(t, errors.Is(err, ErrUnsupportedSpecVersion))
+
+		protoInBytes, err := proto.Marshal(&v1.ResourceSpecification{
+			Version: 99,
+			Name:    "prj.datas.tab",
+			Type:    "table",
+		})
+		assert.Nil(t, err)
+		_, err = tableSpecHandler{}.FromProtobuf(protoInBytes)
+		assert.True(t, errors.Is(err, ErrUnsupportedSpecVersion))
+	})
+	t.Run("should fail if a migration in between is missing", func(t *testing.T) {
+		migrator := newSpecMigrator(3)
+		assert.Nil(t, migrator.Register(2, renameExpiration))
+
+		_, err := migrator.Migrate(1, map[string]interface{}{})
+		assert.NotNil(t, err)
+	})
+	t.Run("should not register migrations outside known versions", func(t *testing.T) {
+		migrator := newSpecMigrator(2)
+		assert.NotNil(t, migrator.Register(2, renameExpiration))
+		assert.NotNil(t, migrator.Register(0, renameExpiration))
+		assert.Nil(t, migrator.Register(1, renameExpiration))
+		assert.NotNil(t, migrator.Register(1, renameExpiration))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/googleapis/google-cloud-go-testing/bigquery/bqiface"
 	"github.com/odpf/optimus/models"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/api/iterator"
 )
 
 type BqClientMock struct {
@@ -34,8 +35,8 @@ func (cli *BqClientMock) DatasetInProject(project string, dataset string) bqifac
 	return cli.Called(project, dataset).Get(0).(bqiface.Dataset)
 }
 
-func (cli *BqClientMock) Datasets(context.Context) bqiface.DatasetIterator {
-	panic("not implemented")
+func (cli *BqClientMock) Datasets(ctx context.Context) bqiface.DatasetIterator {
+	return cli.Called(ctx).Get(0).(bqiface.DatasetIterator)
 }
 
 func (cli *BqClientMock) DatasetsInProject(context.Context, string) bqiface.DatasetIterator {
@@ -101,6 +102,23 @@ func (ds *BqDatasetMock) Tables(context.Context) bqiface.TableIterator {
 	panic("not implemented")
 }
 
+type BqDatasetIteratorMock struct {
+	mock.Mock
+	bqiface.DatasetIterator
+}
+
+func (it *BqDatasetIteratorMock) Next() (bqiface.Dataset, error) {
+	args := it.Called()
+	if args.Get(0) != nil {
+		return args.Get(0).(bqiface.Dataset), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (it *BqDatasetIteratorMock) PageInfo() *iterator.PageInfo {
+	return it.Called().Get(0).(*iterator.PageInfo)
+}
+
 type BqTableMock struct {
 	mock.Mock
 	bqiface.Table
@@ -161,6 +179,30 @@ func (table *BqTableMock) Uploader() bqiface.Uploader {
 	panic("not implemented")
 }
 
+type BqCopierMock struct {
+	mock.Mock
+	bqiface.Copier
+}
+
+func (copier *BqCopierMock) SetCopyConfig(config bqiface.CopyConfig) {
+	copier.Called(config)
+}
+
+func (copier *BqCopierMock) Run(ctx context.Context) (bqiface.Job, error) {
+	args := copier.Called(ctx)
+	return args.Get(0).(bqiface.Job), args.Error(1)
+}
+
+type BqJobMock struct {
+	mock.Mock
+	bqiface.Job
+}
+
+func (job *BqJobMock) Wait(ctx context.Context) (*bigquery.JobStatus, error) {
+	args := job.Called(ctx)
+	return args.Get(0).(*bigquery.JobStatus), args.Error(1)
+}
+
 type BQClientFactoryMock struct {
 	mock.Mock
 }
@@ -189,3 +231,15 @@ func (b *BigQueryMock) ReadResource(ctx context.Context, request models.ReadReso
 func (b *BigQueryMock) DeleteResource(ctx context.Context, request models.DeleteResourceRequest) error {
 	panic("not implemented")
 }
+
+func (b *BigQueryMock) RenameResource(ctx context.Context, request models.RenameResourceRequest) error {
+	panic("not implemented")
+}
+
+func (b *BigQueryMock) BackupResource(ctx context.Context, request models.BackupResourceRequest) (models.BackupResourceResponse, error) {
+	panic("not implemented")
+}
+
+func (b *BigQueryMock) RestoreResource(ctx context.Context, request models.RestoreResourceRequest) error {
+	panic("not implemented")
+}
@@ -0,0 +1,100 @@
+package bigquery
+
+import (
+	"fmt"
+	"strings"
+
+	bqapi "cloud.google.com/go/bigquery"
+)
+
+// standardSQLFieldTypes maps standard sql names of column types to the legacy
+// names bigquery reports them with in table metadata
+var standardSQLFieldTypes = map[bqapi.FieldType]bqapi.FieldType{
+	"INT64":   bqapi.IntegerFieldType,
+	"FLOAT64": bqapi.FloatFieldType,
+	"BOOL":    bqapi.BooleanFieldType,
+	"STRUCT":  bqapi.RecordFieldType,
+}
+
+func normalizeFieldType(fieldType bqapi.FieldType) bqapi.FieldType {
+	if legacy, ok := standardSQLFieldTypes[fieldType]; ok {
+		return legacy
+	}
+	return fieldType
+}
+
+func fieldModeName(field *bqapi.FieldSchema) string {
+	return strings.ToUpper(bqFieldModeFrom(fieldMode{
+		repeated: field.Repeated,
+		required: field.Required,
+	}))
+}
+
+// mergeSchema evolves schema of an existing table towards the declared one.
+// Bigquery only allows adding columns, relaxing REQUIRED columns to NULLABLE
+// and changing descriptions in place, every other change is destructive and
+// is returned in changes while the column is kept as it is live
+func mergeSchema(live, declared bqapi.Schema) (merged bqapi.Schema, changes []string) {
+	return mergeSchemaAt("", live, declared)
+}
+
+func mergeSchemaAt(prefix string, live, declared bqapi.Schema) (merged bqapi.Schema, changes []string) {
+	// column names are case insensitive
+	declaredFields := map[string]*bqapi.FieldSchema{}
+	for _, field := range declared {
+		declaredFields[strings.ToLower(field.Name)] = field
+	}
+
+	liveFields := map[string]bool{}
+	for _, liveField := range live {
+		liveFields[strings.ToLower(liveField.Name)] = true
+		name := prefix + liveField.Name
+
+		declaredField, ok := declaredFields[strings.ToLower(liveField.Name)]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("column %s is removed", name))
+			merged = append(merged, liveField)
+			continue
+		}
+
+		liveType, declaredType := normalizeFieldType(liveField.Type), normalizeFieldType(declaredField.Type)
+		if liveType != declaredType {
+			changes = append(changes, fmt.Sprintf("column %s changes type from %s to %s", name, liveField.Type, declaredField.Type))
+			merged = append(merged, liveField)
+			continue
+		}
+		if liveField.Repeated != declaredField.Repeated || (!liveField.Required && declaredField.Required) {
+			changes = append(changes, fmt.Sprintf("column %s changes mode from %s to %s", name,
+				fieldModeName(liveField), fieldModeName(declaredField)))
+			merged = append(merged, liveField)
+			continue
+		}
+
+		field := *liveField
+		field.Description = declaredField.Description
+		// REQUIRED columns can be relaxed to NULLABLE
+		field.Required = declaredField.Required
+		if liveType == bqapi.RecordFieldType {
+			var nestedChanges []string
+			field.Schema, nestedChanges = mergeSchemaAt(name+".", liveField.Schema, declaredField.Schema)
+			changes = append(changes, nestedChanges...)
+		}
+		merged = append(merged, &field)
+	}
+
+	for _, declaredField := range declared {
+		if liveFields[strings.ToLower(declaredField.Name)] {
+			continue
+		}
+		field := declaredField
+		if declaredField.Required {
+			// columns added to a table with data can't be REQUIRED
+			changes = append(changes, fmt.Sprintf("new column %s%s can't be REQUIRED", prefix, declaredField.Name))
+			relaxed := *declaredField
+			relaxed.Required = false
+			field = &relaxed
+		}
+		merged = append(merged, field)
+	}
+	return merged, changes
+}
@@ -0,0 +1,69 @@
+package bigquery
+
+import (
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeSchema(t *testing.T) {
+	t.Run("should treat standard sql type names same as legacy ones", func(t *testing.T) {
+		live := bigquery.Schema{
+			{Name: "count", Type: "INTEGER"},
+			{Name: "active", Type: "BOOLEAN"},
+		}
+		declared := bigquery.Schema{
+			{Name: "Count", Type: "INT64", Description: "number of events"},
+			{Name: "active", Type: "BOOL"},
+		}
+
+		merged, changes := mergeSchema(live, declared)
+		assert.Empty(t, changes)
+		assert.Equal(t, bigquery.Schema{
+			{Name: "count", Type: "INTEGER", Description: "number of events"},
+			{Name: "active", Type: "BOOLEAN"},
+		}, merged)
+	})
+	t.Run("should evolve columns of nested records", func(t *testing.T) {
+		live := bigquery.Schema{
+			{Name: "event", Type: "RECORD", Schema: bigquery.Schema{
+				{Name: "id", Type: "STRING", Required: true},
+				{Name: "source", Type: "STRING"},
+			}},
+		}
+		declared := bigquery.Schema{
+			{Name: "event", Type: "RECORD", Schema: bigquery.Schema{
+				{Name: "id", Type: "STRING"},
+				{Name: "source", Type: "STRING", Required: true},
+				{Name: "tags", Type: "STRING", Repeated: true},
+			}},
+		}
+
+		merged, changes := mergeSchema(live, declared)
+		assert.Equal(t, []string{"column event.source changes mode from NULLABLE to REQUIRED"}, changes)
+		assert.Equal(t, bigquery.Schema{
+			{Name: "event", Type: "RECORD", Schema: bigquery.Schema{
+				{Name: "id", Type: "STRING"},
+				{Name: "source", Type: "STRING"},
+				{Name: "tags", Type: "STRING", Repeated: true},
+			}},
+		}, merged)
+	})
+	t.Run("should add new required columns as nullable and report them", func(t *testing.T) {
+		live := bigquery.Schema{
+			{Name: "name", Type: "STRING"},
+		}
+		declared := bigquery.Schema{
+			{Name: "id", Type: "STRING", Required: true},
+			{Name: "name", Type: "STRING"},
+		}
+
+		merged, changes := mergeSchema(live, declared)
+		assert.Equal(t, []string{"new column id can't be REQUIRED"}, changes)
+		assert.Equal(t, bigquery.Schema{
+			{Name: "name", Type: "STRING"},
+			{Name: "id", Type: "STRING"},
+		}, merged)
+	})
+}
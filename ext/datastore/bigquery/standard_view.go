@@ -29,6 +29,11 @@ func createStandardView(ctx context.Context, spec models.ResourceSpec, client bq
 
 	// inherit from base
 	bqResource.Metadata.Labels = spec.Labels
+	description, err := resolveDescription(bqResource.Metadata.Description, spec.Assets)
+	if err != nil {
+		return err
+	}
+	bqResource.Metadata.Description = description
 
 	dataset := client.DatasetInProject(bqResource.Project, bqResource.Dataset)
 	if err := ensureDataset(ctx, dataset, BQDataset{
@@ -86,3 +91,24 @@ func ensureStandardView(ctx context.Context, tableHandle bqiface.Table, t BQTabl
 	}
 	return nil
 }
+
+// renameStandardView recreates the view with its new name and deletes the old
+// one, views hold no data so nothing needs to be copied
+func renameStandardView(ctx context.Context, oldSpec, newSpec models.ResourceSpec, client bqiface.Client) error {
+	newView, ok := newSpec.Spec.(BQTable)
+	if !ok {
+		return errors.New("failed to read table spec for bigquery")
+	}
+	exists, err := tableExists(ctx, client.DatasetInProject(newView.Project, newView.Dataset).Table(newView.Table))
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Wrapf(models.ErrResourceExists, "view %s", newSpec.Name)
+	}
+
+	if err := createStandardView(ctx, newSpec, client, false); err != nil {
+		return err
+	}
+	return deleteTable(ctx, oldSpec, client)
+}
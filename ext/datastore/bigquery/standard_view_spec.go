@@ -19,16 +19,20 @@ func (s standardViewSpec) Adapter() models.DatastoreSpecAdapter {
 func (s standardViewSpec) Validator() models.DatastoreSpecValidator {
 	return func(spec models.ResourceSpec) error {
 		if !tableNameParseRegex.MatchString(spec.Name) {
-			return fmt.Errorf("for example 'project_name.dataset_name.table_name'")
+			return fmt.Errorf("for example '%s'", tableNameFormat)
 		}
 		parsedNames := tableNameParseRegex.FindStringSubmatch(spec.Name)
 		if len(parsedNames) < 3 || len(parsedNames[1]) == 0 || len(parsedNames[2]) == 0 || len(parsedNames[3]) == 0 {
-			return fmt.Errorf("for example 'project_name.dataset_name.table_name'")
+			return fmt.Errorf("for example '%s'", tableNameFormat)
 		}
-		return nil
+		return validateLabels(spec.Labels)
 	}
 }
 
+func (s standardViewSpec) NameFormat() string {
+	return tableNameFormat
+}
+
 func (s standardViewSpec) DefaultAssets() map[string]string {
 	return map[string]string{
 		ViewQueryFile: `-- view query goes here`,
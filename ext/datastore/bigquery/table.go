@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -13,11 +14,16 @@ import (
 	"github.com/odpf/optimus/models"
 )
 
+const (
+	// tableNameFormat is how names of tables and views are written
+	tableNameFormat = "project_name.dataset_name.table_name"
+)
+
 var (
 	tableNameParseRegex = regexp.MustCompile(`^([\w-]+)\.(\w+)\.([\w-]+)$`)
 )
 
-func createTable(ctx context.Context, spec models.ResourceSpec, client bqiface.Client, upsert bool) error {
+func createTable(ctx context.Context, spec models.ResourceSpec, client bqiface.Client, upsert, force bool) error {
 	bqResource, ok := spec.Spec.(BQTable)
 	if !ok {
 		return errors.New("failed to read table spec for bigquery")
@@ -25,6 +31,11 @@ func createTable(ctx context.Context, spec models.ResourceSpec, client bqiface.C
 
 	// inherit from base
 	bqResource.Metadata.Labels = spec.Labels
+	description, err := resolveDescription(bqResource.Metadata.Description, spec.Assets)
+	if err != nil {
+		return err
+	}
+	bqResource.Metadata.Description = description
 
 	dataset := client.DatasetInProject(bqResource.Project, bqResource.Dataset)
 	if err := ensureDataset(ctx, dataset, BQDataset{
@@ -35,11 +46,14 @@ func createTable(ctx context.Context, spec models.ResourceSpec, client bqiface.C
 		return err
 	}
 	table := dataset.Table(bqResource.Table)
-	return ensureTable(ctx, table, bqResource, upsert)
+	return ensureTable(ctx, table, bqResource, upsert, force)
 }
 
-// ensureTable make sures table exists with provided config and update if required
-func ensureTable(ctx context.Context, tableHandle bqiface.Table, t BQTable, upsert bool) error {
+// ensureTable make sures table exists with provided config and update if required.
+// Schema of an existing table is evolved with the compatible changes of the
+// declared one, destructive changes fail the update unless it is forced in
+// which case they are left unapplied
+func ensureTable(ctx context.Context, tableHandle bqiface.Table, t BQTable, upsert, force bool) error {
 	meta, err := tableHandle.Metadata(ctx)
 	if err != nil {
 		if metaErr, ok := err.(*googleapi.Error); !ok || metaErr.Code != http.StatusNotFound {
@@ -60,6 +74,15 @@ func ensureTable(ctx context.Context, tableHandle bqiface.Table, t BQTable, upse
 	if err != nil {
 		return err
 	}
+	// a table declared without schema doesn't manage it
+	if len(m.Schema) > 0 {
+		schema, changes := mergeSchema(meta.Schema, m.Schema)
+		if len(changes) > 0 && !force {
+			return errors.Wrapf(models.ErrDestructiveChange, "table %s.%s.%s: %s",
+				t.Project, t.Dataset, t.Table, strings.Join(changes, ", "))
+		}
+		m.Schema = schema
+	}
 	_, err = tableHandle.Update(ctx, m, meta.ETag)
 	return err
 }
@@ -97,6 +120,7 @@ func getTable(ctx context.Context, resourceSpec models.ResourceSpec, client bqif
 		Cluster:     bqClusteringFrom(tableMeta.Clustering),
 		ViewQuery:   tableMeta.ViewQuery,
 		Location:    tableMeta.Location,
+		Source:      bqExternalSourceFrom(tableMeta.ExternalDataConfig),
 	}
 
 	// if table is partitioned
@@ -126,3 +150,73 @@ func deleteTable(ctx context.Context, resourceSpec models.ResourceSpec, client b
 	table := dataset.Table(bqTable.Table)
 	return table.Delete(ctx)
 }
+
+// renameTable moves table to its new name, bigquery can't rename tables in
+// place so the table is copied with its data to the new name and the old one
+// is deleted after the copy succeeds, copying is done only when allowed
+func renameTable(ctx context.Context, oldSpec, newSpec models.ResourceSpec, client bqiface.Client, allowCopy bool) error {
+	oldTable, ok := oldSpec.Spec.(BQTable)
+	if !ok {
+		return errors.New("failed to read table spec for bigquery")
+	}
+	newTable, ok := newSpec.Spec.(BQTable)
+	if !ok {
+		return errors.New("failed to read table spec for bigquery")
+	}
+	description, err := resolveDescription(newTable.Metadata.Description, newSpec.Assets)
+	if err != nil {
+		return err
+	}
+	newTable.Metadata.Description = description
+
+	dataset := client.DatasetInProject(newTable.Project, newTable.Dataset)
+	if err := ensureDataset(ctx, dataset, BQDataset{
+		Project:  newTable.Project,
+		Dataset:  newTable.Dataset,
+		Metadata: BQDatasetMetadata{},
+	}, false); err != nil {
+		return err
+	}
+	dstTable := dataset.Table(newTable.Table)
+	exists, err := tableExists(ctx, dstTable)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.Wrapf(models.ErrResourceExists, "table %s", newSpec.Name)
+	}
+	if !allowCopy {
+		return errors.Wrapf(models.ErrRenameRequiresCopy, "table %s", oldSpec.Name)
+	}
+
+	srcTable := client.DatasetInProject(oldTable.Project, oldTable.Dataset).Table(oldTable.Table)
+	job, err := dstTable.CopierFrom(srcTable).Run(ctx)
+	if err != nil {
+		return err
+	}
+	jobStatus, err := job.Wait(ctx)
+	if err != nil {
+		return err
+	}
+	if err := jobStatus.Err(); err != nil {
+		return err
+	}
+
+	// copy keeps the schema and data, rest of the metadata comes from spec
+	newTable.Metadata.Labels = newSpec.Labels
+	if err := ensureTable(ctx, dstTable, newTable, true, false); err != nil {
+		return err
+	}
+	return srcTable.Delete(ctx)
+}
+
+// tableExists checks if table or view is already present in bigquery
+func tableExists(ctx context.Context, tableHandle bqiface.Table) (bool, error) {
+	if _, err := tableHandle.Metadata(ctx); err != nil {
+		if metaErr, ok := err.(*googleapi.Error); ok && metaErr.Code == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
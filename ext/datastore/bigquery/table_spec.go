@@ -29,6 +29,8 @@ type TableResourceSpec struct {
 	Type    models.ResourceType
 	Spec    BQTableMetadata
 	Labels  map[string]string
+
+	DependsOn []string `yaml:"depends_on,omitempty"`
 }
 
 // BQTable is a specification for a BigQuery Table
@@ -71,6 +73,9 @@ type BQTableMetadata struct {
 	// regular view query
 	ViewQuery string `yaml:"view_query,omitempty" structs:"view_query,omitempty"`
 
+	// external table source
+	Source *BQExternalSource `yaml:",omitempty" structs:"source,omitempty"`
+
 	Location string            `yaml:",omitempty" structs:"location,omitempty"`
 	Labels   map[string]string `yaml:"-" structs:"-"` // inherited
 }
@@ -116,6 +121,18 @@ type BQPartitioningRange struct {
 	Interval int64 `yaml:",omitempty" structs:"interval,omitempty"`
 }
 
+// BQExternalSource describes where data of an external table is read from
+type BQExternalSource struct {
+	// SourceType is the format of data, e.g. GOOGLE_SHEETS, CSV
+	SourceType string   `yaml:"type" structs:"type"`
+	SourceURIs []string `yaml:"uris" structs:"uris"`
+
+	// SkipLeadingRows of sheets and csv files, usually headers
+	SkipLeadingRows int64 `yaml:"skip_leading_rows,omitempty" structs:"skip_leading_rows,omitempty"`
+	// Range of cells to read from google sheets, e.g. sheet1!A1:B20
+	Range string `yaml:",omitempty" structs:"range,omitempty"`
+}
+
 // tableSpecHandler helps serializing/deserializing datastore resource for table
 type tableSpecHandler struct {
 }
@@ -135,6 +152,8 @@ func (s tableSpecHandler) ToYaml(optResource models.ResourceSpec) ([]byte, error
 		Name:    optResource.Name,
 		Type:    optResource.Type,
 		Spec:    spec.Metadata,
+
+		DependsOn: optResource.DependsOn,
 	}
 	if len(yamlResource.Labels) > 0 {
 		yamlResource.Labels = optResource.Labels
@@ -143,6 +162,11 @@ func (s tableSpecHandler) ToYaml(optResource models.ResourceSpec) ([]byte, error
 }
 
 func (s tableSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
+	b, err := tableSpecMigrator.migrateYaml(b)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+
 	var yamlResource TableResourceSpec
 	if err := yaml.Unmarshal(b, &yamlResource); err != nil {
 		return models.ResourceSpec{}, err
@@ -164,6 +188,7 @@ func (s tableSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
 			Table:    parsedTableName[3],
 			Metadata: yamlResource.Spec,
 		},
+		DependsOn: yamlResource.DependsOn,
 	}
 	if len(yamlResource.Labels) > 0 {
 		optResource.Labels = yamlResource.Labels
@@ -187,6 +212,8 @@ func (s tableSpecHandler) ToProtobuf(optResource models.ResourceSpec) ([]byte, e
 		Spec:    bqResourceProtoSpec,
 		Assets:  optResource.Assets,
 		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
 	}
 	return proto.Marshal(resSpec)
 }
@@ -196,6 +223,11 @@ func (s tableSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error) {
 	if err := proto.Unmarshal(b, protoSpec); err != nil {
 		return models.ResourceSpec{}, err
 	}
+	version, spec, err := tableSpecMigrator.migrateProto(protoSpec.Version, protoSpec.Spec)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	protoSpec.Version, protoSpec.Spec = version, spec
 
 	parsedTableName := tableNameParseRegex.FindStringSubmatch(protoSpec.Name)
 	if len(parsedTableName) < 4 {
@@ -259,6 +291,10 @@ func (s tableSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error) {
 		if protoSpecField, ok := protoSpec.Spec.Fields["partition"]; ok {
 			bqTable.Metadata.Partition = extractTablePartitionFromProtoStruct(protoSpecField)
 		}
+
+		if protoSpecField, ok := protoSpec.Spec.Fields["source"]; ok {
+			bqTable.Metadata.Source = extractExternalSourceFromProtoStruct(protoSpecField)
+		}
 	}
 	return models.ResourceSpec{
 		Version:   int(protoSpec.Version),
@@ -268,6 +304,7 @@ func (s tableSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error) {
 		Spec:      bqTable,
 		Datastore: This,
 		Labels:    protoSpec.Labels,
+		DependsOn: protoSpec.DependsOn,
 	}, nil
 }
 
@@ -331,6 +368,28 @@ func extractTablePartitionFromProtoStruct(protoVal *structpb.Value) *BQPartition
 	return pInfo
 }
 
+func extractExternalSourceFromProtoStruct(protoVal *structpb.Value) *BQExternalSource {
+	source := &BQExternalSource{}
+	if protoVal.GetStructValue() == nil {
+		return source
+	}
+	if f, ok := protoVal.GetStructValue().Fields["type"]; ok {
+		source.SourceType = f.GetStringValue()
+	}
+	if f, ok := protoVal.GetStructValue().Fields["uris"]; ok {
+		for _, uri := range f.GetListValue().GetValues() {
+			source.SourceURIs = append(source.SourceURIs, uri.GetStringValue())
+		}
+	}
+	if f, ok := protoVal.GetStructValue().Fields["skip_leading_rows"]; ok {
+		source.SkipLeadingRows = int64(f.GetNumberValue())
+	}
+	if f, ok := protoVal.GetStructValue().Fields["range"]; ok {
+		source.Range = f.GetStringValue()
+	}
+	return source
+}
+
 type tableSpec struct{}
 
 func (s tableSpec) Adapter() models.DatastoreSpecAdapter {
@@ -340,16 +399,20 @@ func (s tableSpec) Adapter() models.DatastoreSpecAdapter {
 func (s tableSpec) Validator() models.DatastoreSpecValidator {
 	return func(spec models.ResourceSpec) error {
 		if !tableNameParseRegex.MatchString(spec.Name) {
-			return fmt.Errorf("for example 'project_name.dataset_name.table_name'")
+			return fmt.Errorf("for example '%s'", tableNameFormat)
 		}
 		parsedNames := tableNameParseRegex.FindStringSubmatch(spec.Name)
 		if len(parsedNames) < 3 || len(parsedNames[1]) == 0 || len(parsedNames[2]) == 0 || len(parsedNames[3]) == 0 {
-			return fmt.Errorf("for example 'project_name.dataset_name.table_name'")
+			return fmt.Errorf("for example '%s'", tableNameFormat)
 		}
-		return nil
+		return validateLabels(spec.Labels)
 	}
 }
 
+func (s tableSpec) NameFormat() string {
+	return tableNameFormat
+}
+
 func (s tableSpec) DefaultAssets() map[string]string {
 	return map[string]string{}
 }
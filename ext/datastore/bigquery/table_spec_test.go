@@ -71,4 +71,32 @@ spec:
 		assert.Nil(t, err)
 		assert.Equal(t, originalRes, resBack)
 	})
+	t.Run("should convert external table from and to proto successfully", func(t *testing.T) {
+		originalRes := models.ResourceSpec{
+			Version:   1,
+			Name:      "proj.datas.sheet",
+			Type:      models.ResourceTypeExternalTable,
+			Datastore: This,
+			Spec: BQTable{
+				Project: "proj",
+				Dataset: "datas",
+				Table:   "sheet",
+				Metadata: BQTableMetadata{
+					Schema: BQSchema{},
+					Source: &BQExternalSource{
+						SourceType:      "GOOGLE_SHEETS",
+						SourceURIs:      []string{"https://docs.google.com/spreadsheets/d/sheet-id"},
+						SkipLeadingRows: 1,
+						Range:           "sheet1!A1:B20",
+					},
+				},
+			},
+		}
+		s := tableSpecHandler{}
+		protoInBytes, err := s.ToProtobuf(originalRes)
+		assert.Nil(t, err)
+		resBack, err := s.FromProtobuf(protoInBytes)
+		assert.Nil(t, err)
+		assert.Equal(t, originalRes, resBack)
+	})
 }
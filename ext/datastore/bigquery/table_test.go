@@ -93,7 +93,7 @@ func TestTable(t *testing.T) {
 			bQTable.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), errNotFound)
 			bQTable.On("Create", testingContext, createTableMeta).Return(nil)
 
-			err := ensureTable(testingContext, bQTable, bQResource, upsert)
+			err := ensureTable(testingContext, bQTable, bQResource, upsert, false)
 			assert.Nil(t, err)
 		})
 		t.Run("should not do insert nor update if table is exist and not an upsert call", func(t *testing.T) {
@@ -104,7 +104,7 @@ func TestTable(t *testing.T) {
 
 			bQTable.On("Metadata", testingContext).Return(createTableMeta, nil)
 
-			err := ensureTable(testingContext, bQTable, bQResource, upsert)
+			err := ensureTable(testingContext, bQTable, bQResource, upsert, false)
 			assert.Nil(t, err)
 		})
 		t.Run("should return any error encountered, except for an *googleapi.Error{Code: 404}", func(t *testing.T) {
@@ -118,7 +118,7 @@ func TestTable(t *testing.T) {
 				defer bQTable.AssertExpectations(t)
 
 				bQTable.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), e)
-				err := ensureTable(testingContext, bQTable, bQResource, upsert)
+				err := ensureTable(testingContext, bQTable, bQResource, upsert, false)
 				assert.Equal(t, e, err)
 			}
 		})
@@ -139,7 +139,84 @@ func TestTable(t *testing.T) {
 			bQTable.On("Metadata", testingContext).Return(tableMeta, nil)
 			bQTable.On("Update", testingContext, updateTableMeta, tableMeta.ETag).Return(tableMeta, nil)
 
-			err := ensureTable(testingContext, bQTable, bQResource, upsert)
+			err := ensureTable(testingContext, bQTable, bQResource, upsert, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should add new columns and relax required columns of existing table on upsert", func(t *testing.T) {
+			upsert := true
+
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			tableMeta := &bigquery.TableMetadata{
+				ETag: "etag-0000",
+				Schema: bigquery.Schema{
+					{Name: "message", Type: "STRING", Required: true},
+					{Name: "message_type", Type: "STRING", Description: "old description"},
+				},
+			}
+			updateTableMeta := bigquery.TableMetadataToUpdate{
+				Name:   bQResource.Table,
+				Schema: createTableMeta.Schema,
+			}
+
+			bQTable.On("Metadata", testingContext).Return(tableMeta, nil)
+			bQTable.On("Update", testingContext, updateTableMeta, tableMeta.ETag).Return(tableMeta, nil)
+
+			err := ensureTable(testingContext, bQTable, bQResource, upsert, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should reject destructive schema changes of existing table on upsert", func(t *testing.T) {
+			upsert := true
+
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			tableMeta := &bigquery.TableMetadata{
+				ETag: "etag-0000",
+				Schema: bigquery.Schema{
+					{Name: "message", Type: "STRING"},
+					{Name: "message_type", Type: "INTEGER"},
+					{Name: "sender", Type: "STRING"},
+				},
+			}
+
+			bQTable.On("Metadata", testingContext).Return(tableMeta, nil)
+
+			err := ensureTable(testingContext, bQTable, bQResource, upsert, false)
+			assert.True(t, errors.Is(err, models.ErrDestructiveChange))
+			assert.Contains(t, err.Error(), "column message_type changes type from INTEGER to STRING")
+			assert.Contains(t, err.Error(), "column sender is removed")
+		})
+		t.Run("should apply compatible schema changes and keep destructive ones unapplied when forced", func(t *testing.T) {
+			upsert := true
+
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			tableMeta := &bigquery.TableMetadata{
+				ETag: "etag-0000",
+				Schema: bigquery.Schema{
+					{Name: "message", Type: "STRING"},
+					{Name: "message_type", Type: "INTEGER"},
+					{Name: "sender", Type: "STRING"},
+				},
+			}
+			updateTableMeta := bigquery.TableMetadataToUpdate{
+				Name: bQResource.Table,
+				Schema: bigquery.Schema{
+					{Name: "message", Type: "STRING"},
+					{Name: "message_type", Type: "INTEGER"},
+					{Name: "sender", Type: "STRING"},
+					{Name: "recipient", Type: "STRING", Repeated: true},
+					{Name: "time", Type: "TIME"},
+				},
+			}
+
+			bQTable.On("Metadata", testingContext).Return(tableMeta, nil)
+			bQTable.On("Update", testingContext, updateTableMeta, tableMeta.ETag).Return(tableMeta, nil)
+
+			err := ensureTable(testingContext, bQTable, bQResource, upsert, true)
 			assert.Nil(t, err)
 		})
 		t.Run("should return an error if bigquery field specification is invalid (on create)", func(t *testing.T) {
@@ -164,7 +241,7 @@ func TestTable(t *testing.T) {
 
 			bQTable.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), errNotFound)
 
-			err := ensureTable(testingContext, bQTable, invalidTable, upsert)
+			err := ensureTable(testingContext, bQTable, invalidTable, upsert, false)
 			assert.NotNil(t, err)
 		})
 		t.Run("should return an error if bigquery field specification is invalid (on update)", func(t *testing.T) {
@@ -189,7 +266,7 @@ func TestTable(t *testing.T) {
 
 			bQTable.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), nil)
 
-			err := ensureTable(testingContext, bQTable, invalidTable, upsert)
+			err := ensureTable(testingContext, bQTable, invalidTable, upsert, false)
 			assert.NotNil(t, err)
 		})
 	})
@@ -222,9 +299,84 @@ func TestTable(t *testing.T) {
 			bQDatasetHandle.On("Table", bQResource.Table).Return(bQTable, nil)
 			bQTable.On("Create", testingContext, createTableMeta).Return(nil)
 
-			err := createTable(testingContext, resourceSpec, bQClient, upsert)
+			err := createTable(testingContext, resourceSpec, bQClient, upsert, false)
 			assert.Nil(t, err)
 		})
+		t.Run("should create table with a literal description", func(t *testing.T) {
+			bQResourceWithDesc := bQResource
+			bQResourceWithDesc.Metadata.Description = "events received from clients"
+			resourceSpec := models.ResourceSpec{
+				Spec: bQResourceWithDesc,
+			}
+
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+
+			bQDatasetHandle := new(BqDatasetMock)
+			defer bQDatasetHandle.AssertExpectations(t)
+
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			createMetaWithDesc := *createTableMeta
+			createMetaWithDesc.Description = "events received from clients"
+
+			bQClient.On("DatasetInProject", bQResource.Project, bQResource.Dataset).Return(bQDatasetHandle)
+			bQDatasetHandle.On("Metadata", testingContext).Return(&bqiface.DatasetMetadata{}, nil)
+			bQDatasetHandle.On("Table", bQResource.Table).Return(bQTable, nil)
+			bQTable.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), errNotFound)
+			bQTable.On("Create", testingContext, &createMetaWithDesc).Return(nil)
+
+			err := createTable(testingContext, resourceSpec, bQClient, false, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should create table with description read from an asset", func(t *testing.T) {
+			bQResourceWithDesc := bQResource
+			bQResourceWithDesc.Metadata.Description = "@description.md"
+			resourceSpec := models.ResourceSpec{
+				Spec: bQResourceWithDesc,
+				Assets: map[string]string{
+					"description.md": "# Events\n\nevents received from clients\n",
+				},
+			}
+
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+
+			bQDatasetHandle := new(BqDatasetMock)
+			defer bQDatasetHandle.AssertExpectations(t)
+
+			bQTable := new(BqTableMock)
+			defer bQTable.AssertExpectations(t)
+
+			createMetaWithDesc := *createTableMeta
+			createMetaWithDesc.Description = "# Events\n\nevents received from clients"
+
+			bQClient.On("DatasetInProject", bQResource.Project, bQResource.Dataset).Return(bQDatasetHandle)
+			bQDatasetHandle.On("Metadata", testingContext).Return(&bqiface.DatasetMetadata{}, nil)
+			bQDatasetHandle.On("Table", bQResource.Table).Return(bQTable, nil)
+			bQTable.On("Metadata", testingContext).Return((*bigquery.TableMetadata)(nil), errNotFound)
+			bQTable.On("Create", testingContext, &createMetaWithDesc).Return(nil)
+
+			err := createTable(testingContext, resourceSpec, bQClient, false, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should return error if description asset is missing", func(t *testing.T) {
+			bQResourceWithDesc := bQResource
+			bQResourceWithDesc.Metadata.Description = "@description.md"
+			resourceSpec := models.ResourceSpec{
+				Spec: bQResourceWithDesc,
+				Assets: map[string]string{
+					"notes.md": "unrelated",
+				},
+			}
+
+			bQClient := new(BqClientMock)
+			defer bQClient.AssertExpectations(t)
+
+			err := createTable(testingContext, resourceSpec, bQClient, false, false)
+			assert.EqualError(t, err, "description asset description.md not found")
+		})
 		t.Run("should return error if read BQ table spec is failed", func(t *testing.T) {
 			upsert := false
 			resourceSpec := models.ResourceSpec{
@@ -234,7 +386,7 @@ func TestTable(t *testing.T) {
 			bQClient := new(BqClientMock)
 			defer bQClient.AssertExpectations(t)
 
-			err := createTable(testingContext, resourceSpec, bQClient, upsert)
+			err := createTable(testingContext, resourceSpec, bQClient, upsert, false)
 			assert.NotNil(t, err)
 		})
 		t.Run("should return error if ensuring dataset is failed", func(t *testing.T) {
@@ -252,7 +404,7 @@ func TestTable(t *testing.T) {
 			bQClient.On("DatasetInProject", bQResource.Project, bQResource.Dataset).Return(bQDatasetHandle)
 			bQDatasetHandle.On("Metadata", testingContext).Return((*bqiface.DatasetMetadata)(nil), errors.New("some error"))
 
-			err := createTable(testingContext, resourceSpec, bQClient, upsert)
+			err := createTable(testingContext, resourceSpec, bQClient, upsert, false)
 			assert.NotNil(t, err)
 		})
 	})
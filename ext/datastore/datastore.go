@@ -1,3 +1,8 @@
 package datastore
 
-import _ "github.com/odpf/optimus/ext/datastore/bigquery"
+import (
+	_ "github.com/odpf/optimus/ext/datastore/bigquery"
+	_ "github.com/odpf/optimus/ext/datastore/gcs"
+	_ "github.com/odpf/optimus/ext/datastore/kafka"
+	_ "github.com/odpf/optimus/ext/datastore/postgres"
+)
@@ -0,0 +1,209 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+
+	"github.com/odpf/optimus/models"
+)
+
+func createBucket(ctx context.Context, spec models.ResourceSpec, client Client, upsert, force bool) error {
+	gcsResource, ok := spec.Spec.(GCSBucket)
+	if !ok {
+		return errors.New("failed to read bucket spec for gcs")
+	}
+
+	// inherit from base
+	gcsResource.Metadata.Labels = spec.Labels
+
+	live, err := client.ReadBucket(ctx, gcsResource.Bucket)
+	if err != nil {
+		if !errors.Is(err, errNotFound) {
+			return err
+		}
+		attrs := &storage.BucketAttrs{
+			Location:          gcsResource.Metadata.Location,
+			StorageClass:      strings.ToUpper(gcsResource.Metadata.StorageClass),
+			VersioningEnabled: gcsResource.Metadata.Versioning,
+			Labels:            gcsResource.Metadata.Labels,
+			Lifecycle:         toLifecycle(gcsResource.Metadata.Lifecycle),
+		}
+		if gcsResource.Metadata.Retention > 0 {
+			attrs.RetentionPolicy = &storage.RetentionPolicy{
+				RetentionPeriod: time.Hour * time.Duration(gcsResource.Metadata.Retention),
+			}
+		}
+		return client.CreateBucket(ctx, gcsResource.Bucket, attrs)
+	}
+	if !upsert {
+		return nil
+	}
+
+	update, changed, changes := bucketChanges(gcsResource.Metadata, live)
+	if len(changes) > 0 && !force {
+		return errors.Wrapf(models.ErrDestructiveChange, "bucket %s: %s", gcsResource.Bucket, strings.Join(changes, ", "))
+	}
+	if !changed {
+		return nil
+	}
+	return client.UpdateBucket(ctx, gcsResource.Bucket, update)
+}
+
+// bucketChanges returns the update for live bucket to match the declared
+// one, changes cloud storage can't apply in place or which allow objects to
+// be deleted earlier are returned as changes instead, i.e. another location
+// or storage class, disabled versioning, shorter retention and new rules
+// deleting objects
+func bucketChanges(declared GCSBucketMetadata, live *storage.BucketAttrs) (update storage.BucketAttrsToUpdate, changed bool, changes []string) {
+	if !strings.EqualFold(declared.Location, live.Location) {
+		changes = append(changes, fmt.Sprintf("location can't be changed from %s to %s", live.Location, declared.Location))
+	}
+	if declared.StorageClass != "" && !strings.EqualFold(declared.StorageClass, live.StorageClass) {
+		changes = append(changes, fmt.Sprintf("storage class can't be changed from %s to %s", live.StorageClass, declared.StorageClass))
+	}
+
+	if declared.Versioning != live.VersioningEnabled {
+		if declared.Versioning {
+			update.VersioningEnabled = true
+			changed = true
+		} else {
+			changes = append(changes, "versioning disabled")
+		}
+	}
+
+	declaredRetention := time.Hour * time.Duration(declared.Retention)
+	var liveRetention time.Duration
+	if live.RetentionPolicy != nil {
+		liveRetention = live.RetentionPolicy.RetentionPeriod
+	}
+	switch {
+	case declaredRetention == liveRetention:
+	case live.RetentionPolicy != nil && live.RetentionPolicy.IsLocked:
+		changes = append(changes, fmt.Sprintf("locked retention of %s can't be changed", liveRetention))
+	case declaredRetention < liveRetention:
+		changes = append(changes, fmt.Sprintf("retention decreased from %s to %s", liveRetention, declaredRetention))
+	default:
+		update.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: declaredRetention}
+		changed = true
+	}
+
+	declaredLifecycle := toLifecycle(declared.Lifecycle)
+	if !reflect.DeepEqual(declaredLifecycle.Rules, live.Lifecycle.Rules) {
+		if addsDeleteRule(declaredLifecycle.Rules, live.Lifecycle.Rules) {
+			changes = append(changes, "lifecycle rule deleting objects added")
+		} else {
+			update.Lifecycle = &declaredLifecycle
+			changed = true
+		}
+	}
+
+	for key, value := range declared.Labels {
+		if liveValue, ok := live.Labels[key]; !ok || liveValue != value {
+			update.SetLabel(key, value)
+			changed = true
+		}
+	}
+	for key := range live.Labels {
+		if _, ok := declared.Labels[key]; !ok {
+			update.DeleteLabel(key)
+			changed = true
+		}
+	}
+	return update, changed, changes
+}
+
+// addsDeleteRule tells if declared rules delete objects in a way none of
+// the live rules does
+func addsDeleteRule(declared, live []storage.LifecycleRule) bool {
+	for _, rule := range declared {
+		if rule.Action.Type != storage.DeleteAction {
+			continue
+		}
+		found := false
+		for _, liveRule := range live {
+			if reflect.DeepEqual(rule, liveRule) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return true
+		}
+	}
+	return false
+}
+
+func toLifecycle(rules []GCSLifecycleRule) storage.Lifecycle {
+	lifecycle := storage.Lifecycle{}
+	for _, rule := range rules {
+		var matchesStorageClasses []string
+		for _, class := range rule.MatchesStorageClasses {
+			matchesStorageClasses = append(matchesStorageClasses, strings.ToUpper(class))
+		}
+		lifecycle.Rules = append(lifecycle.Rules, storage.LifecycleRule{
+			Action: storage.LifecycleAction{
+				Type:         rule.Action,
+				StorageClass: strings.ToUpper(rule.StorageClass),
+			},
+			Condition: storage.LifecycleCondition{
+				AgeInDays:             rule.Age,
+				NumNewerVersions:      rule.NumNewerVersions,
+				MatchesStorageClasses: matchesStorageClasses,
+			},
+		})
+	}
+	return lifecycle
+}
+
+func fromLifecycle(lifecycle storage.Lifecycle) []GCSLifecycleRule {
+	var rules []GCSLifecycleRule
+	for _, rule := range lifecycle.Rules {
+		rules = append(rules, GCSLifecycleRule{
+			Action:                rule.Action.Type,
+			StorageClass:          rule.Action.StorageClass,
+			Age:                   rule.Condition.AgeInDays,
+			NumNewerVersions:      rule.Condition.NumNewerVersions,
+			MatchesStorageClasses: rule.Condition.MatchesStorageClasses,
+		})
+	}
+	return rules
+}
+
+// getBucket retrieves gcs bucket information
+func getBucket(ctx context.Context, spec models.ResourceSpec, client Client) (models.ResourceSpec, error) {
+	gcsResource, ok := spec.Spec.(GCSBucket)
+	if !ok {
+		return models.ResourceSpec{}, errors.New("failed to read bucket spec for gcs")
+	}
+	attrs, err := client.ReadBucket(ctx, gcsResource.Bucket)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	gcsResource.Metadata = GCSBucketMetadata{
+		Location:     attrs.Location,
+		StorageClass: attrs.StorageClass,
+		Versioning:   attrs.VersioningEnabled,
+		Lifecycle:    fromLifecycle(attrs.Lifecycle),
+		Labels:       attrs.Labels,
+	}
+	if attrs.RetentionPolicy != nil {
+		gcsResource.Metadata.Retention = int64(attrs.RetentionPolicy.RetentionPeriod.Hours())
+	}
+	spec.Spec = gcsResource
+	return spec, nil
+}
+
+func deleteBucket(ctx context.Context, spec models.ResourceSpec, client Client) error {
+	gcsResource, ok := spec.Spec.(GCSBucket)
+	if !ok {
+		return errors.New("failed to read bucket spec for gcs")
+	}
+	return client.DeleteBucket(ctx, gcsResource.Bucket)
+}
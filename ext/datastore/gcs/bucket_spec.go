@@ -0,0 +1,306 @@
+package gcs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/kushsharma/structs"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// bucketNameFormat is how bucket names are written, project is the one
+	// the service account in datastore secret belongs to
+	bucketNameFormat = "bucket_name"
+)
+
+var (
+	bucketNameParseRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,220}[a-z0-9]$`)
+
+	storageClasses = map[string]bool{
+		"STANDARD": true,
+		"NEARLINE": true,
+		"COLDLINE": true,
+		"ARCHIVE":  true,
+	}
+)
+
+// BucketResourceSpec is how bucket should be represented in yaml
+type BucketResourceSpec struct {
+	Version int
+	Name    string
+	Type    models.ResourceType
+	Spec    GCSBucketMetadata
+	Labels  map[string]string `yaml:",omitempty"`
+
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+// GCSBucket is a specification for a cloud storage bucket
+// The bucket may or may not exist
+type GCSBucket struct {
+	Bucket   string
+	Metadata GCSBucketMetadata
+}
+
+// GCSBucketMetadata holds configuration for a bucket
+type GCSBucketMetadata struct {
+	Location     string `yaml:"location" structs:"location"`
+	StorageClass string `yaml:"storage_class,omitempty" structs:"storage_class,omitempty"`
+	Versioning   bool   `yaml:",omitempty" structs:"versioning,omitempty"`
+
+	// Retention in hours objects can't be deleted or replaced for after
+	// they are written
+	Retention int64 `yaml:",omitempty" structs:"retention,omitempty"`
+
+	Lifecycle []GCSLifecycleRule `yaml:",omitempty" structs:"-"` // converted separately for proto
+	Labels    map[string]string  `yaml:"-" structs:"-"`          // will be inherited by base resource
+}
+
+// GCSLifecycleRule deletes objects or changes their storage class once all
+// of the conditions set are met
+type GCSLifecycleRule struct {
+	// Action is either Delete or SetStorageClass
+	Action       string `yaml:"action"`
+	StorageClass string `yaml:"storage_class,omitempty"`
+
+	// Age in days since the object was written
+	Age                   int64    `yaml:",omitempty"`
+	NumNewerVersions      int64    `yaml:"num_newer_versions,omitempty"`
+	MatchesStorageClasses []string `yaml:"matches_storage_classes,omitempty"`
+}
+
+func (r GCSLifecycleRule) toProtoValue() map[string]interface{} {
+	rule := map[string]interface{}{
+		"action": r.Action,
+	}
+	if r.StorageClass != "" {
+		rule["storage_class"] = r.StorageClass
+	}
+	if r.Age > 0 {
+		rule["age"] = r.Age
+	}
+	if r.NumNewerVersions > 0 {
+		rule["num_newer_versions"] = r.NumNewerVersions
+	}
+	if len(r.MatchesStorageClasses) > 0 {
+		var classes []interface{}
+		for _, class := range r.MatchesStorageClasses {
+			classes = append(classes, class)
+		}
+		rule["matches_storage_classes"] = classes
+	}
+	return rule
+}
+
+func lifecycleRuleFromProto(fields map[string]*structpb.Value) GCSLifecycleRule {
+	rule := GCSLifecycleRule{}
+	if field, ok := fields["action"]; ok {
+		rule.Action = field.GetStringValue()
+	}
+	if field, ok := fields["storage_class"]; ok {
+		rule.StorageClass = field.GetStringValue()
+	}
+	if field, ok := fields["age"]; ok {
+		rule.Age = int64(field.GetNumberValue())
+	}
+	if field, ok := fields["num_newer_versions"]; ok {
+		rule.NumNewerVersions = int64(field.GetNumberValue())
+	}
+	if field, ok := fields["matches_storage_classes"]; ok {
+		for _, class := range field.GetListValue().GetValues() {
+			rule.MatchesStorageClasses = append(rule.MatchesStorageClasses, class.GetStringValue())
+		}
+	}
+	return rule
+}
+
+// bucketSpecHandler helps serializing/deserializing datastore resource for bucket
+type bucketSpecHandler struct {
+}
+
+func (s bucketSpecHandler) ToYaml(optResource models.ResourceSpec) ([]byte, error) {
+	if optResource.Spec == nil {
+		// usually happens when resource is requested to be created for the first time via optimus cli
+		optResource.Spec = GCSBucket{}
+	}
+	gcsResource, ok := optResource.Spec.(GCSBucket)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+
+	yamlResource := BucketResourceSpec{
+		Version: optResource.Version,
+		Name:    optResource.Name,
+		Type:    optResource.Type,
+		Spec:    gcsResource.Metadata,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return yaml.Marshal(yamlResource)
+}
+
+func (s bucketSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
+	var yamlResource BucketResourceSpec
+	if err := yaml.Unmarshal(b, &yamlResource); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	if !bucketNameParseRegex.MatchString(yamlResource.Name) {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", yamlResource.Name)
+	}
+
+	return models.ResourceSpec{
+		Version:   yamlResource.Version,
+		Name:      yamlResource.Name,
+		Type:      yamlResource.Type,
+		Datastore: This,
+		Spec: GCSBucket{
+			Bucket:   yamlResource.Name,
+			Metadata: yamlResource.Spec,
+		},
+		Labels:    yamlResource.Labels,
+		DependsOn: yamlResource.DependsOn,
+	}, nil
+}
+
+func (s bucketSpecHandler) ToProtobuf(optResource models.ResourceSpec) ([]byte, error) {
+	gcsResource, ok := optResource.Spec.(GCSBucket)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+	protoSpecMap := structs.Map(gcsResource.Metadata)
+	if len(gcsResource.Metadata.Lifecycle) > 0 {
+		var rules []interface{}
+		for _, rule := range gcsResource.Metadata.Lifecycle {
+			rules = append(rules, rule.toProtoValue())
+		}
+		protoSpecMap["lifecycle"] = rules
+	}
+	gcsResourceProtoSpec, err := structpb.NewStruct(protoSpecMap)
+	if err != nil {
+		return nil, err
+	}
+	resSpec := &v1.ResourceSpecification{
+		Version: int32(optResource.Version),
+		Name:    optResource.Name,
+		Type:    optResource.Type.String(),
+		Spec:    gcsResourceProtoSpec,
+		Assets:  optResource.Assets,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return proto.Marshal(resSpec)
+}
+
+func (s bucketSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error) {
+	protoSpec := &v1.ResourceSpecification{}
+	if err := proto.Unmarshal(b, protoSpec); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	if !bucketNameParseRegex.MatchString(protoSpec.Name) {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", protoSpec.Name)
+	}
+
+	gcsMeta := GCSBucketMetadata{}
+	if protoSpec.Spec != nil {
+		if protoSpecField, ok := protoSpec.Spec.Fields["location"]; ok {
+			gcsMeta.Location = protoSpecField.GetStringValue()
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["storage_class"]; ok {
+			gcsMeta.StorageClass = protoSpecField.GetStringValue()
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["versioning"]; ok {
+			gcsMeta.Versioning = protoSpecField.GetBoolValue()
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["retention"]; ok {
+			gcsMeta.Retention = int64(protoSpecField.GetNumberValue())
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["lifecycle"]; ok {
+			for _, rule := range protoSpecField.GetListValue().GetValues() {
+				gcsMeta.Lifecycle = append(gcsMeta.Lifecycle, lifecycleRuleFromProto(rule.GetStructValue().GetFields()))
+			}
+		}
+	}
+	return models.ResourceSpec{
+		Version:   int(protoSpec.Version),
+		Name:      protoSpec.Name,
+		Type:      models.ResourceType(protoSpec.Type),
+		Assets:    protoSpec.Assets,
+		Datastore: This,
+		Spec: GCSBucket{
+			Bucket:   protoSpec.Name,
+			Metadata: gcsMeta,
+		},
+		Labels:    protoSpec.Labels,
+		DependsOn: protoSpec.DependsOn,
+	}, nil
+}
+
+type bucketSpec struct{}
+
+func (s bucketSpec) Adapter() models.DatastoreSpecAdapter {
+	return &bucketSpecHandler{}
+}
+
+func (s bucketSpec) Validator() models.DatastoreSpecValidator {
+	return func(spec models.ResourceSpec) error {
+		if !bucketNameParseRegex.MatchString(spec.Name) {
+			return fmt.Errorf("for example '%s'", bucketNameFormat)
+		}
+
+		// spec is not available when only the name is being validated
+		gcsResource, ok := spec.Spec.(GCSBucket)
+		if !ok {
+			return nil
+		}
+		meta := gcsResource.Metadata
+		if meta.Location == "" {
+			return fmt.Errorf("location of bucket %s is required", spec.Name)
+		}
+		if meta.StorageClass != "" && !storageClasses[strings.ToUpper(meta.StorageClass)] {
+			return fmt.Errorf("invalid storage class %s of bucket %s", meta.StorageClass, spec.Name)
+		}
+		if meta.Retention < 0 {
+			return fmt.Errorf("retention of bucket %s should be positive", spec.Name)
+		}
+		for i, rule := range meta.Lifecycle {
+			switch rule.Action {
+			case storage.DeleteAction:
+				if rule.StorageClass != "" {
+					return fmt.Errorf("lifecycle rule %d of bucket %s deletes objects and can't set storage class", i, spec.Name)
+				}
+			case storage.SetStorageClassAction:
+				if !storageClasses[strings.ToUpper(rule.StorageClass)] {
+					return fmt.Errorf("invalid storage class %s in lifecycle rule %d of bucket %s", rule.StorageClass, i, spec.Name)
+				}
+			default:
+				return fmt.Errorf("lifecycle rule %d of bucket %s should either be %s or %s",
+					i, spec.Name, storage.DeleteAction, storage.SetStorageClassAction)
+			}
+			if rule.Age <= 0 && rule.NumNewerVersions <= 0 && len(rule.MatchesStorageClasses) == 0 {
+				return fmt.Errorf("lifecycle rule %d of bucket %s has no condition", i, spec.Name)
+			}
+		}
+		return nil
+	}
+}
+
+func (s bucketSpec) NameFormat() string {
+	return bucketNameFormat
+}
+
+func (s bucketSpec) DefaultAssets() map[string]string {
+	return map[string]string{}
+}
@@ -0,0 +1,123 @@
+package gcs
+
+import (
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestBucketSpecHandler(t *testing.T) {
+	t.Run("should convert from and to yaml successfully", func(t *testing.T) {
+		fl := `
+version: 1
+name: landing
+type: bucket
+spec:
+  location: asia-southeast1
+  storage_class: standard
+  versioning: true
+  retention: 24
+  lifecycle:
+  - action: SetStorageClass
+    storage_class: nearline
+    age: 30
+  - action: Delete
+    num_newer_versions: 3
+labels:
+  owner: sales
+`
+		handler := bucketSpecHandler{}
+		res, err := handler.FromYaml([]byte(fl))
+		assert.Nil(t, err)
+		assert.Equal(t, GCSBucket{
+			Bucket: "landing",
+			Metadata: GCSBucketMetadata{
+				Location:     "asia-southeast1",
+				StorageClass: "standard",
+				Versioning:   true,
+				Retention:    24,
+				Lifecycle: []GCSLifecycleRule{
+					{Action: storage.SetStorageClassAction, StorageClass: "nearline", Age: 30},
+					{Action: storage.DeleteAction, NumNewerVersions: 3},
+				},
+			},
+		}, res.Spec)
+		converted, err := handler.ToYaml(res)
+		assert.Nil(t, err)
+		resBack, err := handler.FromYaml(converted)
+		assert.Nil(t, err)
+		assert.Equal(t, res, resBack)
+	})
+	t.Run("should convert from and to proto successfully", func(t *testing.T) {
+		originalRes := models.ResourceSpec{
+			Version:   1,
+			Name:      "landing",
+			Type:      models.ResourceTypeBucket,
+			Datastore: This,
+			Spec: GCSBucket{
+				Bucket: "landing",
+				Metadata: GCSBucketMetadata{
+					Location:   "asia-southeast1",
+					Versioning: true,
+					Lifecycle: []GCSLifecycleRule{
+						{Action: storage.DeleteAction, Age: 90, MatchesStorageClasses: []string{"NEARLINE"}},
+					},
+				},
+			},
+			Labels: map[string]string{
+				"owner": "sales",
+			},
+		}
+		handler := bucketSpecHandler{}
+		protoInBytes, err := handler.ToProtobuf(originalRes)
+		assert.Nil(t, err)
+		resBack, err := handler.FromProtobuf(protoInBytes)
+		assert.Nil(t, err)
+		assert.Equal(t, originalRes, resBack)
+	})
+}
+
+func TestBucketSpecValidator(t *testing.T) {
+	validator := bucketSpec{}.Validator()
+	valid := GCSBucketMetadata{Location: "US", StorageClass: "standard"}
+	t.Run("should accept a valid bucket", func(t *testing.T) {
+		err := validator(models.ResourceSpec{
+			Name: "landing",
+			Spec: GCSBucket{Bucket: "landing", Metadata: valid},
+		})
+		assert.Nil(t, err)
+	})
+	t.Run("should reject invalid buckets", func(t *testing.T) {
+		noLocation := valid
+		noLocation.Location = ""
+		invalidClass := valid
+		invalidClass.StorageClass = "cold"
+		invalidAction := valid
+		invalidAction.Lifecycle = []GCSLifecycleRule{{Action: "Archive", Age: 1}}
+		noCondition := valid
+		noCondition.Lifecycle = []GCSLifecycleRule{{Action: storage.DeleteAction}}
+		noTargetClass := valid
+		noTargetClass.Lifecycle = []GCSLifecycleRule{{Action: storage.SetStorageClassAction, Age: 1}}
+
+		for name, meta := range map[string]GCSBucketMetadata{
+			"no location":     noLocation,
+			"invalid class":   invalidClass,
+			"invalid action":  invalidAction,
+			"no condition":    noCondition,
+			"no target class": noTargetClass,
+		} {
+			err := validator(models.ResourceSpec{
+				Name: "landing",
+				Spec: GCSBucket{Bucket: "landing", Metadata: meta},
+			})
+			assert.NotNil(t, err, name)
+		}
+	})
+	t.Run("should reject invalid bucket names", func(t *testing.T) {
+		err := validator(models.ResourceSpec{Name: "Landing"})
+		assert.NotNil(t, err)
+	})
+}
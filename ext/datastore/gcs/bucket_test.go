@@ -0,0 +1,186 @@
+package gcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestBucket(t *testing.T) {
+	testingContext := context.Background()
+	gcsBucket := GCSBucket{
+		Bucket: "landing",
+		Metadata: GCSBucketMetadata{
+			Location:     "asia-southeast1",
+			StorageClass: "standard",
+			Versioning:   true,
+			Retention:    24,
+			Lifecycle: []GCSLifecycleRule{
+				{Action: storage.SetStorageClassAction, StorageClass: "nearline", Age: 30},
+			},
+		},
+	}
+	resourceSpec := models.ResourceSpec{
+		Name:   "landing",
+		Type:   models.ResourceTypeBucket,
+		Spec:   gcsBucket,
+		Labels: map[string]string{"owner": "sales"},
+	}
+	lifecycle := storage.Lifecycle{
+		Rules: []storage.LifecycleRule{{
+			Action:    storage.LifecycleAction{Type: storage.SetStorageClassAction, StorageClass: "NEARLINE"},
+			Condition: storage.LifecycleCondition{AgeInDays: 30},
+		}},
+	}
+	liveBucket := func() *storage.BucketAttrs {
+		return &storage.BucketAttrs{
+			Name:              "landing",
+			Location:          "ASIA-SOUTHEAST1",
+			StorageClass:      "STANDARD",
+			VersioningEnabled: true,
+			RetentionPolicy:   &storage.RetentionPolicy{RetentionPeriod: 24 * time.Hour},
+			Lifecycle:         lifecycle,
+			Labels:            map[string]string{"owner": "sales"},
+		}
+	}
+
+	t.Run("createBucket", func(t *testing.T) {
+		t.Run("should create bucket if it doesn't exist", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadBucket", testingContext, "landing").Return((*storage.BucketAttrs)(nil), errNotFound)
+			client.On("CreateBucket", testingContext, "landing", &storage.BucketAttrs{
+				Location:          "asia-southeast1",
+				StorageClass:      "STANDARD",
+				VersioningEnabled: true,
+				Labels:            map[string]string{"owner": "sales"},
+				Lifecycle:         lifecycle,
+				RetentionPolicy:   &storage.RetentionPolicy{RetentionPeriod: 24 * time.Hour},
+			}).Return(nil)
+
+			err := createBucket(testingContext, resourceSpec, client, false, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should not update existing bucket if not upserting", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadBucket", testingContext, "landing").Return(&storage.BucketAttrs{Location: "US"}, nil)
+
+			err := createBucket(testingContext, resourceSpec, client, false, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should not update anything if bucket is up to date", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadBucket", testingContext, "landing").Return(liveBucket(), nil)
+
+			err := createBucket(testingContext, resourceSpec, client, true, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should update labels, retention and lifecycle of existing bucket", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			live := liveBucket()
+			live.RetentionPolicy = nil
+			live.Lifecycle = storage.Lifecycle{}
+			live.Labels = map[string]string{"owner": "finance", "team": "ops"}
+			client.On("ReadBucket", testingContext, "landing").Return(live, nil)
+
+			expected := storage.BucketAttrsToUpdate{
+				RetentionPolicy: &storage.RetentionPolicy{RetentionPeriod: 24 * time.Hour},
+				Lifecycle:       &lifecycle,
+			}
+			expected.SetLabel("owner", "sales")
+			expected.DeleteLabel("team")
+			client.On("UpdateBucket", testingContext, "landing", expected).Return(nil)
+
+			err := createBucket(testingContext, resourceSpec, client, true, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should reject destructive changes unless forced", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			live := liveBucket()
+			live.Location = "US"
+			live.VersioningEnabled = false
+			live.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: 48 * time.Hour}
+			client.On("ReadBucket", testingContext, "landing").Return(live, nil)
+
+			deleteRule := resourceSpec
+			deleteRule.Spec = GCSBucket{
+				Bucket: "landing",
+				Metadata: GCSBucketMetadata{
+					Location:  "asia-southeast1",
+					Retention: 24,
+					Lifecycle: []GCSLifecycleRule{{Action: storage.DeleteAction, Age: 90}},
+				},
+			}
+			err := createBucket(testingContext, deleteRule, client, true, false)
+			assert.True(t, errors.Is(err, models.ErrDestructiveChange))
+			assert.Contains(t, err.Error(), "location can't be changed from US to asia-southeast1")
+			assert.Contains(t, err.Error(), "retention decreased from 48h0m0s to 24h0m0s")
+			assert.Contains(t, err.Error(), "lifecycle rule deleting objects added")
+			client.AssertNotCalled(t, "UpdateBucket", mock.Anything, mock.Anything, mock.Anything)
+		})
+		t.Run("should apply only compatible changes when forced", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			live := liveBucket()
+			live.StorageClass = "COLDLINE"
+			live.VersioningEnabled = false
+			client.On("ReadBucket", testingContext, "landing").Return(live, nil)
+			client.On("UpdateBucket", testingContext, "landing", storage.BucketAttrsToUpdate{
+				VersioningEnabled: true,
+			}).Return(nil)
+
+			err := createBucket(testingContext, resourceSpec, client, true, true)
+			assert.Nil(t, err)
+		})
+		t.Run("should not change locked retention", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			live := liveBucket()
+			live.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: time.Hour, IsLocked: true}
+			client.On("ReadBucket", testingContext, "landing").Return(live, nil)
+
+			err := createBucket(testingContext, resourceSpec, client, true, false)
+			assert.True(t, errors.Is(err, models.ErrDestructiveChange))
+			assert.Contains(t, err.Error(), "locked retention of 1h0m0s can't be changed")
+		})
+	})
+	t.Run("getBucket", func(t *testing.T) {
+		t.Run("should read retention of bucket in hours", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadBucket", testingContext, "landing").Return(liveBucket(), nil)
+
+			res, err := getBucket(testingContext, resourceSpec, client)
+			assert.Nil(t, err)
+			assert.Equal(t, GCSBucketMetadata{
+				Location:     "ASIA-SOUTHEAST1",
+				StorageClass: "STANDARD",
+				Versioning:   true,
+				Retention:    24,
+				Lifecycle: []GCSLifecycleRule{
+					{Action: storage.SetStorageClassAction, StorageClass: "NEARLINE", Age: 30},
+				},
+				Labels: map[string]string{"owner": "sales"},
+			}, res.Spec.(GCSBucket).Metadata)
+		})
+	})
+}
@@ -0,0 +1,122 @@
+package gcs
+
+import (
+	"context"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+var (
+	// errNotFound is returned by client when the requested bucket or object
+	// doesn't exist
+	errNotFound = errors.New("not found")
+)
+
+// Client manages buckets and objects of the project the service account
+// belongs to
+type Client interface {
+	CreateBucket(ctx context.Context, name string, attrs *storage.BucketAttrs) error
+	ReadBucket(ctx context.Context, name string) (*storage.BucketAttrs, error)
+	UpdateBucket(ctx context.Context, name string, attrs storage.BucketAttrsToUpdate) error
+	DeleteBucket(ctx context.Context, name string) error
+
+	// WriteObject writes an empty object with metadata, replacing the object
+	// if it already exists
+	WriteObject(ctx context.Context, bucket, name string, metadata map[string]string) error
+	// ReadObject returns metadata of object
+	ReadObject(ctx context.Context, bucket, name string) (map[string]string, error)
+	DeleteObject(ctx context.Context, bucket, name string) error
+
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+type defaultClientFactory struct{}
+
+func (fac *defaultClientFactory) New(ctx context.Context, svcAccount string) (Client, error) {
+	cred, err := google.CredentialsFromJSON(ctx, []byte(svcAccount), storage.ScopeFullControl)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read secret")
+	}
+	client, err := storage.NewClient(ctx, option.WithCredentials(cred))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create GCS client")
+	}
+	return &storageClient{client: client, project: cred.ProjectID}, nil
+}
+
+type storageClient struct {
+	client  *storage.Client
+	project string
+}
+
+func (c *storageClient) CreateBucket(ctx context.Context, name string, attrs *storage.BucketAttrs) error {
+	return c.client.Bucket(name).Create(ctx, c.project, attrs)
+}
+
+func (c *storageClient) ReadBucket(ctx context.Context, name string) (*storage.BucketAttrs, error) {
+	attrs, err := c.client.Bucket(name).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrBucketNotExist) {
+			return nil, errNotFound
+		}
+		return nil, err
+	}
+	return attrs, nil
+}
+
+func (c *storageClient) UpdateBucket(ctx context.Context, name string, attrs storage.BucketAttrsToUpdate) error {
+	_, err := c.client.Bucket(name).Update(ctx, attrs)
+	return err
+}
+
+func (c *storageClient) DeleteBucket(ctx context.Context, name string) error {
+	err := c.client.Bucket(name).Delete(ctx)
+	if errors.Is(err, storage.ErrBucketNotExist) {
+		return errNotFound
+	}
+	return err
+}
+
+func (c *storageClient) WriteObject(ctx context.Context, bucket, name string, metadata map[string]string) error {
+	w := c.client.Bucket(bucket).Object(name).NewWriter(ctx)
+	w.Metadata = metadata
+	// nothing is written, closing the writer creates the empty object
+	return w.Close()
+}
+
+func (c *storageClient) ReadObject(ctx context.Context, bucket, name string) (map[string]string, error) {
+	attrs, err := c.client.Bucket(bucket).Object(name).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) || errors.Is(err, storage.ErrBucketNotExist) {
+			return nil, errNotFound
+		}
+		return nil, err
+	}
+	return attrs.Metadata, nil
+}
+
+func (c *storageClient) DeleteObject(ctx context.Context, bucket, name string) error {
+	err := c.client.Bucket(bucket).Object(name).Delete(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return errNotFound
+	}
+	return err
+}
+
+func (c *storageClient) Ping(ctx context.Context) error {
+	it := c.client.Buckets(ctx, c.project)
+	it.PageInfo().MaxSize = 1
+	if _, err := it.Next(); err != nil && err != iterator.Done {
+		return err
+	}
+	return nil
+}
+
+func (c *storageClient) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,190 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// Required secret, service account of the project buckets are managed in
+	SecretName = "DATASTORE_GCS"
+)
+
+var (
+	This = &GCS{
+		ClientFac: &defaultClientFactory{},
+	}
+
+	errSecretNotFoundStr = "secret %s required to migrate datastore not found for %s"
+)
+
+type ClientFactory interface {
+	New(ctx context.Context, svcAccount string) (Client, error)
+}
+
+type GCS struct {
+	ClientFac ClientFactory
+}
+
+func (g GCS) Name() string {
+	return "gcs"
+}
+
+func (g GCS) Description() string {
+	return "Google Cloud Storage"
+}
+
+func (g GCS) Types() map[models.ResourceType]models.DatastoreTypeController {
+	return map[models.ResourceType]models.DatastoreTypeController{
+		models.ResourceTypeBucket: &bucketSpec{},
+		models.ResourceTypePrefix: &prefixSpec{},
+	}
+}
+
+func (g *GCS) CreateResource(ctx context.Context, request models.CreateResourceRequest) error {
+	client, err := g.newClient(ctx, request.Project)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	switch request.Resource.Type {
+	case models.ResourceTypeBucket:
+		return createBucket(ctx, request.Resource, client, false, false)
+	case models.ResourceTypePrefix:
+		return createPrefix(ctx, request.Resource, client, false)
+	}
+	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+}
+
+func (g *GCS) UpdateResource(ctx context.Context, request models.UpdateResourceRequest) error {
+	client, err := g.newClient(ctx, request.Project)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	switch request.Resource.Type {
+	case models.ResourceTypeBucket:
+		return createBucket(ctx, request.Resource, client, true, request.Force)
+	case models.ResourceTypePrefix:
+		return createPrefix(ctx, request.Resource, client, true)
+	}
+	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+}
+
+func (g *GCS) ReadResource(ctx context.Context, request models.ReadResourceRequest) (models.ReadResourceResponse, error) {
+	client, err := g.newClient(ctx, request.Project)
+	if err != nil {
+		return models.ReadResourceResponse{}, err
+	}
+	defer client.Close()
+
+	var info models.ResourceSpec
+	switch request.Resource.Type {
+	case models.ResourceTypeBucket:
+		info, err = getBucket(ctx, request.Resource, client)
+	case models.ResourceTypePrefix:
+		info, err = getPrefix(ctx, request.Resource, client)
+	default:
+		return models.ReadResourceResponse{}, fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+	}
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return models.ReadResourceResponse{}, errors.Errorf("%s %s not found", request.Resource.Type, request.Resource.Name)
+		}
+		return models.ReadResourceResponse{}, err
+	}
+	return models.ReadResourceResponse{
+		Resource: info,
+	}, nil
+}
+
+func (g *GCS) DeleteResource(ctx context.Context, request models.DeleteResourceRequest) error {
+	client, err := g.newClient(ctx, request.Project)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	switch request.Resource.Type {
+	case models.ResourceTypeBucket:
+		return deleteBucket(ctx, request.Resource, client)
+	case models.ResourceTypePrefix:
+		return deletePrefix(ctx, request.Resource, client)
+	}
+	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+}
+
+func (g *GCS) RenameResource(ctx context.Context, request models.RenameResourceRequest) error {
+	return errors.New("gcs buckets and prefixes can't be renamed, create the new one and move the objects instead")
+}
+
+func (g *GCS) BackupResource(ctx context.Context, request models.BackupResourceRequest) (models.BackupResourceResponse, error) {
+	return models.BackupResourceResponse{}, fmt.Errorf("backup of resource type %s is not supported", request.Resource.Type)
+}
+
+func (g *GCS) RestoreResource(ctx context.Context, request models.RestoreResourceRequest) error {
+	return fmt.Errorf("restore of resource type %s is not supported", request.Resource.Type)
+}
+
+func (g *GCS) CheckDatastore(ctx context.Context, request models.CheckDatastoreRequest) error {
+	client, err := g.newClient(ctx, request.Project)
+	if err != nil {
+		return &models.DatastoreError{Category: models.DatastoreErrorCategoryAuth, Err: err}
+	}
+	defer client.Close()
+
+	if err := client.Ping(ctx); err != nil {
+		return &models.DatastoreError{Category: categorizeError(err), Err: err}
+	}
+	return nil
+}
+
+func (g *GCS) newClient(ctx context.Context, project models.ProjectSpec) (Client, error) {
+	svcAcc, ok := project.Secret.GetByName(SecretName)
+	if !ok || len(svcAcc) == 0 {
+		return nil, errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, g.Name()))
+	}
+	return g.ClientFac.New(ctx, svcAcc)
+}
+
+// categorizeError maps errors returned by cloud storage to datastore error
+// categories
+func categorizeError(err error) models.DatastoreErrorCategory {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusUnauthorized:
+			return models.DatastoreErrorCategoryAuth
+		case http.StatusForbidden:
+			return models.DatastoreErrorCategoryPermission
+		}
+		return models.DatastoreErrorCategoryUnknown
+	}
+
+	var tokenErr *oauth2.RetrieveError
+	if errors.As(err, &tokenErr) {
+		return models.DatastoreErrorCategoryAuth
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return models.DatastoreErrorCategoryNetwork
+	}
+	return models.DatastoreErrorCategoryUnknown
+}
+
+func init() {
+	if err := models.DatastoreRegistry.Add(This); err != nil {
+		panic(err)
+	}
+}
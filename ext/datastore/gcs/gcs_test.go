@@ -0,0 +1,105 @@
+package gcs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestGCS(t *testing.T) {
+	testingContext := context.Background()
+	svcAcc := "{\"type\": \"service_account\"}"
+	projectSpec := models.ProjectSpec{
+		Secret: models.ProjectSecrets{{
+			Name:  SecretName,
+			Value: svcAcc,
+		}},
+	}
+	prefixSpec := models.ResourceSpec{
+		Name: "landing_orders",
+		Type: models.ResourceTypePrefix,
+		Spec: GCSPrefix{
+			Metadata: GCSPrefixMetadata{Bucket: "landing", Path: "orders"},
+		},
+	}
+
+	t.Run("CreateResource", func(t *testing.T) {
+		t.Run("should return error when secret not found", func(t *testing.T) {
+			clientFac := new(ClientFactoryMock)
+			defer clientFac.AssertExpectations(t)
+
+			g := GCS{ClientFac: clientFac}
+			err := g.CreateResource(testingContext, models.CreateResourceRequest{
+				Resource: prefixSpec,
+				Project:  models.ProjectSpec{},
+			})
+			assert.NotNil(t, err)
+		})
+		t.Run("should create prefix in bucket of project", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+			clientFac := new(ClientFactoryMock)
+			defer clientFac.AssertExpectations(t)
+
+			clientFac.On("New", testingContext, svcAcc).Return(client, nil)
+			client.On("ReadObject", testingContext, "landing", "orders/").Return(map[string]string(nil), errNotFound)
+			client.On("WriteObject", testingContext, "landing", "orders/", map[string]string{}).Return(nil)
+			client.On("Close").Return(nil)
+
+			g := GCS{ClientFac: clientFac}
+			err := g.CreateResource(testingContext, models.CreateResourceRequest{
+				Resource: prefixSpec,
+				Project:  projectSpec,
+			})
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("ReadResource", func(t *testing.T) {
+		t.Run("should return error if bucket doesn't exist", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+			clientFac := new(ClientFactoryMock)
+			defer clientFac.AssertExpectations(t)
+
+			clientFac.On("New", testingContext, svcAcc).Return(client, nil)
+			client.On("ReadBucket", testingContext, "landing").Return((*storage.BucketAttrs)(nil), errNotFound)
+			client.On("Close").Return(nil)
+
+			g := GCS{ClientFac: clientFac}
+			_, err := g.ReadResource(testingContext, models.ReadResourceRequest{
+				Resource: models.ResourceSpec{
+					Name: "landing",
+					Type: models.ResourceTypeBucket,
+					Spec: GCSBucket{Bucket: "landing"},
+				},
+				Project: projectSpec,
+			})
+			assert.Equal(t, "bucket landing not found", err.Error())
+		})
+	})
+	t.Run("CheckDatastore", func(t *testing.T) {
+		t.Run("should categorize permission failures", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+			clientFac := new(ClientFactoryMock)
+			defer clientFac.AssertExpectations(t)
+
+			clientFac.On("New", testingContext, svcAcc).Return(client, nil)
+			client.On("Ping", testingContext).Return(&googleapi.Error{Code: http.StatusForbidden})
+			client.On("Close").Return(nil)
+
+			g := GCS{ClientFac: clientFac}
+			err := g.CheckDatastore(testingContext, models.CheckDatastoreRequest{Project: projectSpec})
+			var dsErr *models.DatastoreError
+			assert.True(t, errors.As(err, &dsErr))
+			assert.Equal(t, models.DatastoreErrorCategoryPermission, dsErr.Category)
+		})
+	})
+}
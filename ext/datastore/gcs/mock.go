@@ -0,0 +1,59 @@
+package gcs
+
+import (
+	"context"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/mock"
+)
+
+type ClientMock struct {
+	mock.Mock
+}
+
+func (cli *ClientMock) CreateBucket(ctx context.Context, name string, attrs *storage.BucketAttrs) error {
+	return cli.Called(ctx, name, attrs).Error(0)
+}
+
+func (cli *ClientMock) ReadBucket(ctx context.Context, name string) (*storage.BucketAttrs, error) {
+	args := cli.Called(ctx, name)
+	return args.Get(0).(*storage.BucketAttrs), args.Error(1)
+}
+
+func (cli *ClientMock) UpdateBucket(ctx context.Context, name string, attrs storage.BucketAttrsToUpdate) error {
+	return cli.Called(ctx, name, attrs).Error(0)
+}
+
+func (cli *ClientMock) DeleteBucket(ctx context.Context, name string) error {
+	return cli.Called(ctx, name).Error(0)
+}
+
+func (cli *ClientMock) WriteObject(ctx context.Context, bucket, name string, metadata map[string]string) error {
+	return cli.Called(ctx, bucket, name, metadata).Error(0)
+}
+
+func (cli *ClientMock) ReadObject(ctx context.Context, bucket, name string) (map[string]string, error) {
+	args := cli.Called(ctx, bucket, name)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (cli *ClientMock) DeleteObject(ctx context.Context, bucket, name string) error {
+	return cli.Called(ctx, bucket, name).Error(0)
+}
+
+func (cli *ClientMock) Ping(ctx context.Context) error {
+	return cli.Called(ctx).Error(0)
+}
+
+func (cli *ClientMock) Close() error {
+	return cli.Called().Error(0)
+}
+
+type ClientFactoryMock struct {
+	mock.Mock
+}
+
+func (fac *ClientFactoryMock) New(ctx context.Context, svcAccount string) (Client, error) {
+	args := fac.Called(ctx, svcAccount)
+	return args.Get(0).(Client), args.Error(1)
+}
@@ -0,0 +1,91 @@
+package gcs
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// metadata keys of the placeholder object of prefix
+	descriptionMetadataKey = "description"
+	labelMetadataKeyPrefix = "label-"
+)
+
+func createPrefix(ctx context.Context, spec models.ResourceSpec, client Client, upsert bool) error {
+	gcsResource, ok := spec.Spec.(GCSPrefix)
+	if !ok {
+		return errors.New("failed to read prefix spec for gcs")
+	}
+
+	// inherit from base
+	gcsResource.Metadata.Labels = spec.Labels
+
+	meta := gcsResource.Metadata
+	declared := prefixObjectMetadata(meta)
+	live, err := client.ReadObject(ctx, meta.Bucket, meta.placeholder())
+	if err != nil {
+		if !errors.Is(err, errNotFound) {
+			return err
+		}
+		return client.WriteObject(ctx, meta.Bucket, meta.placeholder(), declared)
+	}
+	if !upsert || (len(declared) == 0 && len(live) == 0) || reflect.DeepEqual(declared, live) {
+		return nil
+	}
+	return client.WriteObject(ctx, meta.Bucket, meta.placeholder(), declared)
+}
+
+// prefixObjectMetadata stores description and labels of prefix as metadata
+// of its placeholder object
+func prefixObjectMetadata(meta GCSPrefixMetadata) map[string]string {
+	metadata := map[string]string{}
+	if meta.Description != "" {
+		metadata[descriptionMetadataKey] = meta.Description
+	}
+	for key, value := range meta.Labels {
+		metadata[labelMetadataKeyPrefix+key] = value
+	}
+	return metadata
+}
+
+// getPrefix retrieves gcs prefix information
+func getPrefix(ctx context.Context, spec models.ResourceSpec, client Client) (models.ResourceSpec, error) {
+	gcsResource, ok := spec.Spec.(GCSPrefix)
+	if !ok {
+		return models.ResourceSpec{}, errors.New("failed to read prefix spec for gcs")
+	}
+	metadata, err := client.ReadObject(ctx, gcsResource.Metadata.Bucket, gcsResource.Metadata.placeholder())
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	gcsResource.Metadata.Description, gcsResource.Metadata.Labels = "", nil
+	for key, value := range metadata {
+		switch {
+		case key == descriptionMetadataKey:
+			gcsResource.Metadata.Description = value
+		case strings.HasPrefix(key, labelMetadataKeyPrefix):
+			if gcsResource.Metadata.Labels == nil {
+				gcsResource.Metadata.Labels = map[string]string{}
+			}
+			gcsResource.Metadata.Labels[strings.TrimPrefix(key, labelMetadataKeyPrefix)] = value
+		}
+	}
+	spec.Spec = gcsResource
+	return spec, nil
+}
+
+// deletePrefix removes only the placeholder of prefix, objects written
+// under the prefix are kept
+func deletePrefix(ctx context.Context, spec models.ResourceSpec, client Client) error {
+	gcsResource, ok := spec.Spec.(GCSPrefix)
+	if !ok {
+		return errors.New("failed to read prefix spec for gcs")
+	}
+	return client.DeleteObject(ctx, gcsResource.Metadata.Bucket, gcsResource.Metadata.placeholder())
+}
@@ -0,0 +1,200 @@
+package gcs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kushsharma/structs"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// prefixNameFormat is how prefix resources are named, bucket and path of
+	// the prefix are part of its spec as paths can't be used as names
+	prefixNameFormat = "prefix_name"
+)
+
+var (
+	prefixNameParseRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+)
+
+// PrefixResourceSpec is how prefix should be represented in yaml
+type PrefixResourceSpec struct {
+	Version int
+	Name    string
+	Type    models.ResourceType
+	Spec    GCSPrefixMetadata
+	Labels  map[string]string `yaml:",omitempty"`
+
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+// GCSPrefix is a specification for a path in a bucket files are written
+// under, e.g. landing/orders/
+// The prefix may or may not exist
+type GCSPrefix struct {
+	Metadata GCSPrefixMetadata
+}
+
+// GCSPrefixMetadata holds where the prefix is along with its description
+type GCSPrefixMetadata struct {
+	Bucket      string            `yaml:"bucket" structs:"bucket"`
+	Path        string            `yaml:"path" structs:"path"`
+	Description string            `yaml:",omitempty" structs:"description,omitempty"`
+	Labels      map[string]string `yaml:"-" structs:"-"` // will be inherited by base resource
+}
+
+// placeholder is the empty object marking the prefix, i.e. path ending
+// with a slash
+func (m GCSPrefixMetadata) placeholder() string {
+	return strings.Trim(m.Path, "/") + "/"
+}
+
+// prefixSpecHandler helps serializing/deserializing datastore resource for prefix
+type prefixSpecHandler struct {
+}
+
+func (s prefixSpecHandler) ToYaml(optResource models.ResourceSpec) ([]byte, error) {
+	if optResource.Spec == nil {
+		// usually happens when resource is requested to be created for the first time via optimus cli
+		optResource.Spec = GCSPrefix{}
+	}
+	gcsResource, ok := optResource.Spec.(GCSPrefix)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+
+	yamlResource := PrefixResourceSpec{
+		Version: optResource.Version,
+		Name:    optResource.Name,
+		Type:    optResource.Type,
+		Spec:    gcsResource.Metadata,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return yaml.Marshal(yamlResource)
+}
+
+func (s prefixSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
+	var yamlResource PrefixResourceSpec
+	if err := yaml.Unmarshal(b, &yamlResource); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	if !prefixNameParseRegex.MatchString(yamlResource.Name) {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", yamlResource.Name)
+	}
+
+	return models.ResourceSpec{
+		Version:   yamlResource.Version,
+		Name:      yamlResource.Name,
+		Type:      yamlResource.Type,
+		Datastore: This,
+		Spec: GCSPrefix{
+			Metadata: yamlResource.Spec,
+		},
+		Labels:    yamlResource.Labels,
+		DependsOn: yamlResource.DependsOn,
+	}, nil
+}
+
+func (s prefixSpecHandler) ToProtobuf(optResource models.ResourceSpec) ([]byte, error) {
+	gcsResource, ok := optResource.Spec.(GCSPrefix)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+	gcsResourceProtoSpec, err := structpb.NewStruct(structs.Map(gcsResource.Metadata))
+	if err != nil {
+		return nil, err
+	}
+	resSpec := &v1.ResourceSpecification{
+		Version: int32(optResource.Version),
+		Name:    optResource.Name,
+		Type:    optResource.Type.String(),
+		Spec:    gcsResourceProtoSpec,
+		Assets:  optResource.Assets,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return proto.Marshal(resSpec)
+}
+
+func (s prefixSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error) {
+	protoSpec := &v1.ResourceSpecification{}
+	if err := proto.Unmarshal(b, protoSpec); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	if !prefixNameParseRegex.MatchString(protoSpec.Name) {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", protoSpec.Name)
+	}
+
+	gcsMeta := GCSPrefixMetadata{}
+	if protoSpec.Spec != nil {
+		if protoSpecField, ok := protoSpec.Spec.Fields["bucket"]; ok {
+			gcsMeta.Bucket = protoSpecField.GetStringValue()
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["path"]; ok {
+			gcsMeta.Path = protoSpecField.GetStringValue()
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["description"]; ok {
+			gcsMeta.Description = protoSpecField.GetStringValue()
+		}
+	}
+	return models.ResourceSpec{
+		Version:   int(protoSpec.Version),
+		Name:      protoSpec.Name,
+		Type:      models.ResourceType(protoSpec.Type),
+		Assets:    protoSpec.Assets,
+		Datastore: This,
+		Spec: GCSPrefix{
+			Metadata: gcsMeta,
+		},
+		Labels:    protoSpec.Labels,
+		DependsOn: protoSpec.DependsOn,
+	}, nil
+}
+
+type prefixSpec struct{}
+
+func (s prefixSpec) Adapter() models.DatastoreSpecAdapter {
+	return &prefixSpecHandler{}
+}
+
+func (s prefixSpec) Validator() models.DatastoreSpecValidator {
+	return func(spec models.ResourceSpec) error {
+		if !prefixNameParseRegex.MatchString(spec.Name) {
+			return fmt.Errorf("for example '%s'", prefixNameFormat)
+		}
+
+		// spec is not available when only the name is being validated
+		gcsResource, ok := spec.Spec.(GCSPrefix)
+		if !ok {
+			return nil
+		}
+		if !bucketNameParseRegex.MatchString(gcsResource.Metadata.Bucket) {
+			return fmt.Errorf("invalid bucket %s of prefix %s", gcsResource.Metadata.Bucket, spec.Name)
+		}
+		if strings.Trim(gcsResource.Metadata.Path, "/") == "" {
+			return fmt.Errorf("path of prefix %s is required", spec.Name)
+		}
+		return nil
+	}
+}
+
+func (s prefixSpec) NameFormat() string {
+	return prefixNameFormat
+}
+
+func (s prefixSpec) DefaultAssets() map[string]string {
+	return map[string]string{}
+}
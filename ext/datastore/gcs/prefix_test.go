@@ -0,0 +1,81 @@
+package gcs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestPrefix(t *testing.T) {
+	testingContext := context.Background()
+	resourceSpec := models.ResourceSpec{
+		Name: "landing_orders",
+		Type: models.ResourceTypePrefix,
+		Spec: GCSPrefix{
+			Metadata: GCSPrefixMetadata{
+				Bucket:      "landing",
+				Path:        "/orders/daily",
+				Description: "daily order exports",
+			},
+		},
+		Labels: map[string]string{"owner": "sales"},
+	}
+	objectMetadata := map[string]string{
+		"description": "daily order exports",
+		"label-owner": "sales",
+	}
+
+	t.Run("createPrefix", func(t *testing.T) {
+		t.Run("should write placeholder of prefix if it doesn't exist", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadObject", testingContext, "landing", "orders/daily/").Return(map[string]string(nil), errNotFound)
+			client.On("WriteObject", testingContext, "landing", "orders/daily/", objectMetadata).Return(nil)
+
+			err := createPrefix(testingContext, resourceSpec, client, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should not rewrite placeholder if it is up to date", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadObject", testingContext, "landing", "orders/daily/").Return(objectMetadata, nil)
+
+			err := createPrefix(testingContext, resourceSpec, client, true)
+			assert.Nil(t, err)
+		})
+		t.Run("should rewrite placeholder if labels changed", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadObject", testingContext, "landing", "orders/daily/").Return(map[string]string{
+				"label-owner": "finance",
+			}, nil)
+			client.On("WriteObject", testingContext, "landing", "orders/daily/", objectMetadata).Return(nil)
+
+			err := createPrefix(testingContext, resourceSpec, client, true)
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("getPrefix", func(t *testing.T) {
+		t.Run("should read description and labels from placeholder", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadObject", testingContext, "landing", "orders/daily/").Return(objectMetadata, nil)
+
+			res, err := getPrefix(testingContext, resourceSpec, client)
+			assert.Nil(t, err)
+			assert.Equal(t, GCSPrefixMetadata{
+				Bucket:      "landing",
+				Path:        "/orders/daily",
+				Description: "daily order exports",
+				Labels:      map[string]string{"owner": "sales"},
+			}, res.Spec.(GCSPrefix).Metadata)
+		})
+	})
+}
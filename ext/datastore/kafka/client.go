@@ -0,0 +1,196 @@
+package kafka
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+const (
+	clientTimeout = 30 * time.Second
+
+	// dynamicTopicConfigSource marks configs overridden for a topic, the
+	// rest are broker defaults
+	dynamicTopicConfigSource = 1
+)
+
+var (
+	// errNotFound is returned by client when the requested topic doesn't
+	// exist in cluster
+	errNotFound = errors.New("not found")
+)
+
+// Client manages topics of a kafka cluster
+type Client interface {
+	CreateTopic(ctx context.Context, topic KafkaTopic) error
+	// ReadTopic returns partitions, replication factor and configs
+	// overridden for the topic
+	ReadTopic(ctx context.Context, name string) (KafkaTopicMetadata, error)
+	DeleteTopic(ctx context.Context, name string) error
+
+	// AddPartitions increases partitions of topic to count
+	AddPartitions(ctx context.Context, name string, count int) error
+	// AlterConfigs sets configs of topic, configs with empty values are
+	// reset to broker defaults
+	AlterConfigs(ctx context.Context, name string, configs map[string]string) error
+
+	Ping(ctx context.Context) error
+}
+
+type defaultClientFactory struct{}
+
+func (fac *defaultClientFactory) New(ctx context.Context, brokers string) (Client, error) {
+	var addrs []string
+	for _, broker := range strings.Split(brokers, ",") {
+		if broker = strings.TrimSpace(broker); broker != "" {
+			addrs = append(addrs, broker)
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New("no kafka brokers configured")
+	}
+	return &adminClient{
+		client: &kafkago.Client{
+			Addr:    kafkago.TCP(addrs...),
+			Timeout: clientTimeout,
+		},
+	}, nil
+}
+
+type adminClient struct {
+	client *kafkago.Client
+}
+
+func (c *adminClient) CreateTopic(ctx context.Context, topic KafkaTopic) error {
+	var configEntries []kafkago.ConfigEntry
+	for name, value := range topic.Metadata.topicConfigs() {
+		configEntries = append(configEntries, kafkago.ConfigEntry{ConfigName: name, ConfigValue: value})
+	}
+	resp, err := c.client.CreateTopics(ctx, &kafkago.CreateTopicsRequest{
+		Topics: []kafkago.TopicConfig{{
+			Topic:             topic.Topic,
+			NumPartitions:     topic.Metadata.Partitions,
+			ReplicationFactor: topic.Metadata.ReplicationFactor,
+			ConfigEntries:     configEntries,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	return resp.Errors[topic.Topic]
+}
+
+func (c *adminClient) ReadTopic(ctx context.Context, name string) (KafkaTopicMetadata, error) {
+	resp, err := c.client.Metadata(ctx, &kafkago.MetadataRequest{
+		Topics: []string{name},
+	})
+	if err != nil {
+		return KafkaTopicMetadata{}, err
+	}
+	if len(resp.Topics) == 0 {
+		return KafkaTopicMetadata{}, errNotFound
+	}
+	topic := resp.Topics[0]
+	if topic.Error != nil {
+		if errors.Is(topic.Error, kafkago.UnknownTopicOrPartition) {
+			return KafkaTopicMetadata{}, errNotFound
+		}
+		return KafkaTopicMetadata{}, topic.Error
+	}
+
+	meta := KafkaTopicMetadata{
+		Partitions: len(topic.Partitions),
+	}
+	if len(topic.Partitions) > 0 {
+		meta.ReplicationFactor = len(topic.Partitions[0].Replicas)
+	}
+
+	configResp, err := c.client.DescribeConfigs(ctx, &kafkago.DescribeConfigsRequest{
+		Resources: []kafkago.DescribeConfigRequestResource{{
+			ResourceType: kafkago.ResourceTypeTopic,
+			ResourceName: name,
+		}},
+	})
+	if err != nil {
+		return KafkaTopicMetadata{}, err
+	}
+	configs := map[string]string{}
+	for _, resource := range configResp.Resources {
+		if resource.Error != nil {
+			return KafkaTopicMetadata{}, resource.Error
+		}
+		for _, entry := range resource.ConfigEntries {
+			if entry.ConfigSource == dynamicTopicConfigSource {
+				configs[entry.ConfigName] = entry.ConfigValue
+			}
+		}
+	}
+	if err := meta.setTopicConfigs(configs); err != nil {
+		return KafkaTopicMetadata{}, err
+	}
+	return meta, nil
+}
+
+func (c *adminClient) DeleteTopic(ctx context.Context, name string) error {
+	resp, err := c.client.DeleteTopics(ctx, &kafkago.DeleteTopicsRequest{
+		Topics: []string{name},
+	})
+	if err != nil {
+		return err
+	}
+	return resp.Errors[name]
+}
+
+func (c *adminClient) AddPartitions(ctx context.Context, name string, count int) error {
+	resp, err := c.client.CreatePartitions(ctx, &kafkago.CreatePartitionsRequest{
+		Topics: []kafkago.TopicPartitionsConfig{{
+			Name:  name,
+			Count: int32(count),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	return resp.Errors[name]
+}
+
+func (c *adminClient) AlterConfigs(ctx context.Context, name string, configs map[string]string) error {
+	var alterConfigs []kafkago.IncrementalAlterConfigsRequestConfig
+	for configName, value := range configs {
+		operation := kafkago.ConfigOperationSet
+		if value == "" {
+			operation = kafkago.ConfigOperationDelete
+		}
+		alterConfigs = append(alterConfigs, kafkago.IncrementalAlterConfigsRequestConfig{
+			Name:            configName,
+			Value:           value,
+			ConfigOperation: operation,
+		})
+	}
+	resp, err := c.client.IncrementalAlterConfigs(ctx, &kafkago.IncrementalAlterConfigsRequest{
+		Resources: []kafkago.IncrementalAlterConfigsRequestResource{{
+			ResourceType: kafkago.ResourceTypeTopic,
+			ResourceName: name,
+			Configs:      alterConfigs,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	for _, resource := range resp.Resources {
+		if resource.Error != nil {
+			return resource.Error
+		}
+	}
+	return nil
+}
+
+func (c *adminClient) Ping(ctx context.Context) error {
+	_, err := c.client.Metadata(ctx, &kafkago.MetadataRequest{
+		Topics: []string{},
+	})
+	return err
+}
@@ -0,0 +1,167 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	kafkago "github.com/segmentio/kafka-go"
+
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// Required secret, comma separated brokers of the cluster topics are
+	// managed in, e.g. host1:9092,host2:9092
+	SecretName = "DATASTORE_KAFKA"
+)
+
+var (
+	This = &Kafka{
+		ClientFac: &defaultClientFactory{},
+	}
+
+	errSecretNotFoundStr = "secret %s required to migrate datastore not found for %s"
+)
+
+type ClientFactory interface {
+	New(ctx context.Context, brokers string) (Client, error)
+}
+
+type Kafka struct {
+	ClientFac ClientFactory
+}
+
+func (k Kafka) Name() string {
+	return "kafka"
+}
+
+func (k Kafka) Description() string {
+	return "Apache Kafka"
+}
+
+func (k Kafka) Types() map[models.ResourceType]models.DatastoreTypeController {
+	return map[models.ResourceType]models.DatastoreTypeController{
+		models.ResourceTypeTopic: &topicSpec{},
+	}
+}
+
+func (k *Kafka) CreateResource(ctx context.Context, request models.CreateResourceRequest) error {
+	client, err := k.newClient(ctx, request.Project)
+	if err != nil {
+		return err
+	}
+
+	switch request.Resource.Type {
+	case models.ResourceTypeTopic:
+		return createTopic(ctx, request.Resource, client, false, false)
+	}
+	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+}
+
+func (k *Kafka) UpdateResource(ctx context.Context, request models.UpdateResourceRequest) error {
+	client, err := k.newClient(ctx, request.Project)
+	if err != nil {
+		return err
+	}
+
+	switch request.Resource.Type {
+	case models.ResourceTypeTopic:
+		return createTopic(ctx, request.Resource, client, true, request.Force)
+	}
+	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+}
+
+func (k *Kafka) ReadResource(ctx context.Context, request models.ReadResourceRequest) (models.ReadResourceResponse, error) {
+	client, err := k.newClient(ctx, request.Project)
+	if err != nil {
+		return models.ReadResourceResponse{}, err
+	}
+
+	switch request.Resource.Type {
+	case models.ResourceTypeTopic:
+		info, err := getTopic(ctx, request.Resource, client)
+		if err != nil {
+			if errors.Is(err, errNotFound) {
+				return models.ReadResourceResponse{}, errors.Errorf("topic %s not found", request.Resource.Name)
+			}
+			return models.ReadResourceResponse{}, err
+		}
+		return models.ReadResourceResponse{
+			Resource: info,
+		}, nil
+	}
+	return models.ReadResourceResponse{}, fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+}
+
+func (k *Kafka) DeleteResource(ctx context.Context, request models.DeleteResourceRequest) error {
+	client, err := k.newClient(ctx, request.Project)
+	if err != nil {
+		return err
+	}
+
+	switch request.Resource.Type {
+	case models.ResourceTypeTopic:
+		return deleteTopic(ctx, request.Resource, client)
+	}
+	return fmt.Errorf("unsupported resource type %s", request.Resource.Type)
+}
+
+func (k *Kafka) RenameResource(ctx context.Context, request models.RenameResourceRequest) error {
+	return errors.New("kafka topics can't be renamed, create the new topic and move its consumers instead")
+}
+
+func (k *Kafka) BackupResource(ctx context.Context, request models.BackupResourceRequest) (models.BackupResourceResponse, error) {
+	return models.BackupResourceResponse{}, fmt.Errorf("backup of resource type %s is not supported", request.Resource.Type)
+}
+
+func (k *Kafka) RestoreResource(ctx context.Context, request models.RestoreResourceRequest) error {
+	return fmt.Errorf("restore of resource type %s is not supported", request.Resource.Type)
+}
+
+func (k *Kafka) CheckDatastore(ctx context.Context, request models.CheckDatastoreRequest) error {
+	client, err := k.newClient(ctx, request.Project)
+	if err != nil {
+		return &models.DatastoreError{Category: models.DatastoreErrorCategoryAuth, Err: err}
+	}
+	if err := client.Ping(ctx); err != nil {
+		return &models.DatastoreError{Category: categorizeError(err), Err: err}
+	}
+	return nil
+}
+
+func (k *Kafka) newClient(ctx context.Context, project models.ProjectSpec) (Client, error) {
+	brokers, ok := project.Secret.GetByName(SecretName)
+	if !ok || len(brokers) == 0 {
+		return nil, errors.New(fmt.Sprintf(errSecretNotFoundStr, SecretName, k.Name()))
+	}
+	return k.ClientFac.New(ctx, brokers)
+}
+
+// categorizeError maps errors returned by kafka to datastore error
+// categories
+func categorizeError(err error) models.DatastoreErrorCategory {
+	var kafkaErr kafkago.Error
+	if errors.As(err, &kafkaErr) {
+		switch kafkaErr {
+		case kafkago.SASLAuthenticationFailed:
+			return models.DatastoreErrorCategoryAuth
+		case kafkago.TopicAuthorizationFailed, kafkago.ClusterAuthorizationFailed:
+			return models.DatastoreErrorCategoryPermission
+		}
+		return models.DatastoreErrorCategoryUnknown
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return models.DatastoreErrorCategoryNetwork
+	}
+	return models.DatastoreErrorCategoryUnknown
+}
+
+func init() {
+	if err := models.DatastoreRegistry.Add(This); err != nil {
+		panic(err)
+	}
+}
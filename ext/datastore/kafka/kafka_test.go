@@ -0,0 +1,97 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestKafka(t *testing.T) {
+	testingContext := context.Background()
+	brokers := "localhost:9092,localhost:9093"
+	projectSpec := models.ProjectSpec{
+		Secret: models.ProjectSecrets{{
+			Name:  SecretName,
+			Value: brokers,
+		}},
+	}
+	topicSpec := models.ResourceSpec{
+		Name: "orders",
+		Type: models.ResourceTypeTopic,
+		Spec: KafkaTopic{
+			Topic:    "orders",
+			Metadata: KafkaTopicMetadata{Partitions: 3, ReplicationFactor: 2},
+		},
+	}
+
+	t.Run("CreateResource", func(t *testing.T) {
+		t.Run("should return error when secret not found", func(t *testing.T) {
+			clientFac := new(ClientFactoryMock)
+			defer clientFac.AssertExpectations(t)
+
+			k := Kafka{ClientFac: clientFac}
+			err := k.CreateResource(testingContext, models.CreateResourceRequest{
+				Resource: topicSpec,
+				Project:  models.ProjectSpec{},
+			})
+			assert.NotNil(t, err)
+		})
+		t.Run("should create topic in cluster of project", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+			clientFac := new(ClientFactoryMock)
+			defer clientFac.AssertExpectations(t)
+
+			clientFac.On("New", testingContext, brokers).Return(client, nil)
+			client.On("ReadTopic", testingContext, "orders").Return(KafkaTopicMetadata{}, errNotFound)
+			client.On("CreateTopic", testingContext, topicSpec.Spec).Return(nil)
+
+			k := Kafka{ClientFac: clientFac}
+			err := k.CreateResource(testingContext, models.CreateResourceRequest{
+				Resource: topicSpec,
+				Project:  projectSpec,
+			})
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("ReadResource", func(t *testing.T) {
+		t.Run("should return error if topic doesn't exist", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+			clientFac := new(ClientFactoryMock)
+			defer clientFac.AssertExpectations(t)
+
+			clientFac.On("New", testingContext, brokers).Return(client, nil)
+			client.On("ReadTopic", testingContext, "orders").Return(KafkaTopicMetadata{}, errNotFound)
+
+			k := Kafka{ClientFac: clientFac}
+			_, err := k.ReadResource(testingContext, models.ReadResourceRequest{
+				Resource: topicSpec,
+				Project:  projectSpec,
+			})
+			assert.Equal(t, "topic orders not found", err.Error())
+		})
+	})
+	t.Run("CheckDatastore", func(t *testing.T) {
+		t.Run("should categorize authorization failures", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+			clientFac := new(ClientFactoryMock)
+			defer clientFac.AssertExpectations(t)
+
+			clientFac.On("New", testingContext, brokers).Return(client, nil)
+			client.On("Ping", testingContext).Return(kafkago.ClusterAuthorizationFailed)
+
+			k := Kafka{ClientFac: clientFac}
+			err := k.CheckDatastore(testingContext, models.CheckDatastoreRequest{Project: projectSpec})
+			var dsErr *models.DatastoreError
+			assert.True(t, errors.As(err, &dsErr))
+			assert.Equal(t, models.DatastoreErrorCategoryPermission, dsErr.Category)
+		})
+	})
+}
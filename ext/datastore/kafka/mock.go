@@ -0,0 +1,45 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ClientMock struct {
+	mock.Mock
+}
+
+func (cli *ClientMock) CreateTopic(ctx context.Context, topic KafkaTopic) error {
+	return cli.Called(ctx, topic).Error(0)
+}
+
+func (cli *ClientMock) ReadTopic(ctx context.Context, name string) (KafkaTopicMetadata, error) {
+	args := cli.Called(ctx, name)
+	return args.Get(0).(KafkaTopicMetadata), args.Error(1)
+}
+
+func (cli *ClientMock) DeleteTopic(ctx context.Context, name string) error {
+	return cli.Called(ctx, name).Error(0)
+}
+
+func (cli *ClientMock) AddPartitions(ctx context.Context, name string, count int) error {
+	return cli.Called(ctx, name, count).Error(0)
+}
+
+func (cli *ClientMock) AlterConfigs(ctx context.Context, name string, configs map[string]string) error {
+	return cli.Called(ctx, name, configs).Error(0)
+}
+
+func (cli *ClientMock) Ping(ctx context.Context) error {
+	return cli.Called(ctx).Error(0)
+}
+
+type ClientFactoryMock struct {
+	mock.Mock
+}
+
+func (fac *ClientFactoryMock) New(ctx context.Context, brokers string) (Client, error) {
+	args := fac.Called(ctx, brokers)
+	return args.Get(0).(Client), args.Error(1)
+}
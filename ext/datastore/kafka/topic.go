@@ -0,0 +1,127 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/odpf/optimus/models"
+)
+
+func createTopic(ctx context.Context, spec models.ResourceSpec, client Client, upsert, force bool) error {
+	kafkaResource, ok := spec.Spec.(KafkaTopic)
+	if !ok {
+		return errors.New("failed to read topic spec for kafka")
+	}
+
+	live, err := client.ReadTopic(ctx, kafkaResource.Topic)
+	if err != nil {
+		if !errors.Is(err, errNotFound) {
+			return err
+		}
+		return client.CreateTopic(ctx, kafkaResource)
+	}
+	if !upsert {
+		return nil
+	}
+
+	alterConfigs, changes := topicChanges(kafkaResource.Metadata, live)
+	if len(changes) > 0 && !force {
+		return errors.Wrapf(models.ErrDestructiveChange, "topic %s: %s", kafkaResource.Topic, strings.Join(changes, ", "))
+	}
+	if kafkaResource.Metadata.Partitions > live.Partitions {
+		if err := client.AddPartitions(ctx, kafkaResource.Topic, kafkaResource.Metadata.Partitions); err != nil {
+			return errors.Wrapf(err, "failed to add partitions to topic %s", kafkaResource.Topic)
+		}
+	}
+	if len(alterConfigs) == 0 {
+		return nil
+	}
+	return client.AlterConfigs(ctx, kafkaResource.Topic, alterConfigs)
+}
+
+// topicChanges returns configs to alter for live topic to match the declared
+// one, changes kafka can't apply or which would drop messages are returned as
+// changes instead, i.e. fewer partitions, another replication factor and
+// shorter retention
+func topicChanges(declared, live KafkaTopicMetadata) (alterConfigs map[string]string, changes []string) {
+	if declared.Partitions < live.Partitions {
+		changes = append(changes, fmt.Sprintf("partitions can't be decreased from %d to %d", live.Partitions, declared.Partitions))
+	}
+	if declared.ReplicationFactor != live.ReplicationFactor {
+		changes = append(changes, fmt.Sprintf("replication factor can't be changed from %d to %d",
+			live.ReplicationFactor, declared.ReplicationFactor))
+	}
+
+	declaredConfigs, liveConfigs := declared.topicConfigs(), live.topicConfigs()
+	alterConfigs = map[string]string{}
+	for name, value := range declaredConfigs {
+		if liveValue, ok := liveConfigs[name]; ok && liveValue == value {
+			continue
+		}
+		if name == retentionConfig && shorterRetention(value, liveConfigs[name]) {
+			changes = append(changes, fmt.Sprintf("retention decreased from %s to %s ms", liveConfigs[name], value))
+			continue
+		}
+		alterConfigs[name] = value
+	}
+	for name := range liveConfigs {
+		if _, ok := declaredConfigs[name]; ok {
+			continue
+		}
+		if name == retentionConfig {
+			// broker default may be shorter than retention of topic
+			changes = append(changes, "retention reset to broker default")
+			continue
+		}
+		alterConfigs[name] = ""
+	}
+	return alterConfigs, changes
+}
+
+// shorterRetention tells if declared retention.ms keeps messages for less
+// time than the live one, -1 keeps messages forever and empty is the broker
+// default which is only replaced, never compared
+func shorterRetention(declared, live string) bool {
+	if live == "" {
+		return false
+	}
+	declaredMs, err := strconv.ParseInt(declared, 10, 64)
+	if err != nil {
+		return false
+	}
+	liveMs, err := strconv.ParseInt(live, 10, 64)
+	if err != nil {
+		return false
+	}
+	if liveMs < 0 {
+		return declaredMs >= 0
+	}
+	return declaredMs >= 0 && declaredMs < liveMs
+}
+
+// getTopic retrieves kafka topic information
+func getTopic(ctx context.Context, spec models.ResourceSpec, client Client) (models.ResourceSpec, error) {
+	kafkaResource, ok := spec.Spec.(KafkaTopic)
+	if !ok {
+		return models.ResourceSpec{}, errors.New("failed to read topic spec for kafka")
+	}
+	meta, err := client.ReadTopic(ctx, kafkaResource.Topic)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	kafkaResource.Metadata = meta
+	spec.Spec = kafkaResource
+	return spec, nil
+}
+
+func deleteTopic(ctx context.Context, spec models.ResourceSpec, client Client) error {
+	kafkaResource, ok := spec.Spec.(KafkaTopic)
+	if !ok {
+		return errors.New("failed to read topic spec for kafka")
+	}
+	return client.DeleteTopic(ctx, kafkaResource.Topic)
+}
@@ -0,0 +1,273 @@
+package kafka
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/kushsharma/structs"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// topicNameFormat is how topic names are written, cluster is the one
+	// configured in the datastore secret
+	topicNameFormat = "topic_name"
+
+	retentionConfig = "retention.ms"
+	hourInMillis    = int64(60 * 60 * 1000)
+)
+
+var (
+	topicNameParseRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+)
+
+// TopicResourceSpec is how topic should be represented in yaml
+type TopicResourceSpec struct {
+	Version int
+	Name    string
+	Type    models.ResourceType
+	Spec    KafkaTopicMetadata
+	Labels  map[string]string `yaml:",omitempty"`
+
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+// KafkaTopic is a specification for a Kafka topic
+// The topic may or may not exist
+type KafkaTopic struct {
+	Topic    string
+	Metadata KafkaTopicMetadata
+}
+
+// KafkaTopicMetadata holds configuration for a topic
+type KafkaTopicMetadata struct {
+	Partitions        int `yaml:"partitions" structs:"partitions"`
+	ReplicationFactor int `yaml:"replication_factor" structs:"replication_factor"`
+
+	// Retention in hours, -1 keeps messages forever, broker default is
+	// used when not set
+	Retention int64 `yaml:",omitempty" structs:"retention,omitempty"`
+
+	// Configs are topic level configs other than retention, e.g.
+	// cleanup.policy: compact
+	Configs map[string]string `yaml:",omitempty" structs:"-"` // converted separately for proto
+}
+
+// topicConfigs merges retention with rest of the configs of topic
+func (m KafkaTopicMetadata) topicConfigs() map[string]string {
+	configs := map[string]string{}
+	for name, value := range m.Configs {
+		configs[name] = value
+	}
+	switch {
+	case m.Retention < 0:
+		configs[retentionConfig] = "-1"
+	case m.Retention > 0:
+		configs[retentionConfig] = strconv.FormatInt(m.Retention*hourInMillis, 10)
+	}
+	return configs
+}
+
+// setTopicConfigs splits retention from configs of topic, retention which
+// isn't in whole hours is kept as a config
+func (m *KafkaTopicMetadata) setTopicConfigs(configs map[string]string) error {
+	m.Retention, m.Configs = 0, nil
+	for name, value := range configs {
+		if name == retentionConfig {
+			retention, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid %s %s", retentionConfig, value)
+			}
+			if retention < 0 {
+				m.Retention = -1
+				continue
+			}
+			if retention > 0 && retention%hourInMillis == 0 {
+				m.Retention = retention / hourInMillis
+				continue
+			}
+		}
+		if m.Configs == nil {
+			m.Configs = map[string]string{}
+		}
+		m.Configs[name] = value
+	}
+	return nil
+}
+
+// topicSpecHandler helps serializing/deserializing datastore resource for topic
+type topicSpecHandler struct {
+}
+
+func (s topicSpecHandler) ToYaml(optResource models.ResourceSpec) ([]byte, error) {
+	if optResource.Spec == nil {
+		// usually happens when resource is requested to be created for the first time via optimus cli
+		optResource.Spec = KafkaTopic{}
+	}
+	kafkaResource, ok := optResource.Spec.(KafkaTopic)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+
+	yamlResource := TopicResourceSpec{
+		Version: optResource.Version,
+		Name:    optResource.Name,
+		Type:    optResource.Type,
+		Spec:    kafkaResource.Metadata,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return yaml.Marshal(yamlResource)
+}
+
+func (s topicSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
+	var yamlResource TopicResourceSpec
+	if err := yaml.Unmarshal(b, &yamlResource); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	if !topicNameParseRegex.MatchString(yamlResource.Name) {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", yamlResource.Name)
+	}
+
+	return models.ResourceSpec{
+		Version:   yamlResource.Version,
+		Name:      yamlResource.Name,
+		Type:      yamlResource.Type,
+		Datastore: This,
+		Spec: KafkaTopic{
+			Topic:    yamlResource.Name,
+			Metadata: yamlResource.Spec,
+		},
+		Labels:    yamlResource.Labels,
+		DependsOn: yamlResource.DependsOn,
+	}, nil
+}
+
+func (s topicSpecHandler) ToProtobuf(optResource models.ResourceSpec) ([]byte, error) {
+	kafkaResource, ok := optResource.Spec.(KafkaTopic)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+	protoSpecMap := structs.Map(kafkaResource.Metadata)
+	if len(kafkaResource.Metadata.Configs) > 0 {
+		configs := map[string]interface{}{}
+		for name, value := range kafkaResource.Metadata.Configs {
+			configs[name] = value
+		}
+		protoSpecMap["configs"] = configs
+	}
+	kafkaResourceProtoSpec, err := structpb.NewStruct(protoSpecMap)
+	if err != nil {
+		return nil, err
+	}
+	resSpec := &v1.ResourceSpecification{
+		Version: int32(optResource.Version),
+		Name:    optResource.Name,
+		Type:    optResource.Type.String(),
+		Spec:    kafkaResourceProtoSpec,
+		Assets:  optResource.Assets,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return proto.Marshal(resSpec)
+}
+
+func (s topicSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error) {
+	protoSpec := &v1.ResourceSpecification{}
+	if err := proto.Unmarshal(b, protoSpec); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	if !topicNameParseRegex.MatchString(protoSpec.Name) {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", protoSpec.Name)
+	}
+
+	kafkaMeta := KafkaTopicMetadata{}
+	if protoSpec.Spec != nil {
+		if protoSpecField, ok := protoSpec.Spec.Fields["partitions"]; ok {
+			kafkaMeta.Partitions = int(protoSpecField.GetNumberValue())
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["replication_factor"]; ok {
+			kafkaMeta.ReplicationFactor = int(protoSpecField.GetNumberValue())
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["retention"]; ok {
+			kafkaMeta.Retention = int64(protoSpecField.GetNumberValue())
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["configs"]; ok {
+			kafkaMeta.Configs = map[string]string{}
+			for name, value := range protoSpecField.GetStructValue().GetFields() {
+				kafkaMeta.Configs[name] = value.GetStringValue()
+			}
+		}
+	}
+	return models.ResourceSpec{
+		Version:   int(protoSpec.Version),
+		Name:      protoSpec.Name,
+		Type:      models.ResourceType(protoSpec.Type),
+		Assets:    protoSpec.Assets,
+		Datastore: This,
+		Spec: KafkaTopic{
+			Topic:    protoSpec.Name,
+			Metadata: kafkaMeta,
+		},
+		Labels:    protoSpec.Labels,
+		DependsOn: protoSpec.DependsOn,
+	}, nil
+}
+
+type topicSpec struct{}
+
+func (s topicSpec) Adapter() models.DatastoreSpecAdapter {
+	return &topicSpecHandler{}
+}
+
+func (s topicSpec) Validator() models.DatastoreSpecValidator {
+	return func(spec models.ResourceSpec) error {
+		if !topicNameParseRegex.MatchString(spec.Name) {
+			return fmt.Errorf("for example '%s'", topicNameFormat)
+		}
+
+		// spec is not available when only the name is being validated
+		kafkaResource, ok := spec.Spec.(KafkaTopic)
+		if !ok {
+			return nil
+		}
+		meta := kafkaResource.Metadata
+		if meta.Partitions <= 0 {
+			return fmt.Errorf("partitions of topic %s should be positive", spec.Name)
+		}
+		if meta.ReplicationFactor <= 0 {
+			return fmt.Errorf("replication factor of topic %s should be positive", spec.Name)
+		}
+		if meta.Retention < -1 {
+			return fmt.Errorf("retention of topic %s should be positive or -1 to keep messages forever", spec.Name)
+		}
+		for name, value := range meta.Configs {
+			if name == retentionConfig && meta.Retention != 0 {
+				return fmt.Errorf("retention of topic %s is set both as retention and %s", spec.Name, retentionConfig)
+			}
+			if value == "" {
+				return fmt.Errorf("config %s of topic %s should have a value", name, spec.Name)
+			}
+		}
+		return nil
+	}
+}
+
+func (s topicSpec) NameFormat() string {
+	return topicNameFormat
+}
+
+func (s topicSpec) DefaultAssets() map[string]string {
+	return map[string]string{}
+}
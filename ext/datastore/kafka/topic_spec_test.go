@@ -0,0 +1,136 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestTopicSpecHandler(t *testing.T) {
+	t.Run("should convert from and to yaml successfully", func(t *testing.T) {
+		fl := `
+version: 1
+name: orders
+type: topic
+spec:
+  partitions: 6
+  replication_factor: 3
+  retention: 168
+  configs:
+    cleanup.policy: delete
+labels:
+  owner: sales
+`
+		handler := topicSpecHandler{}
+		res, err := handler.FromYaml([]byte(fl))
+		assert.Nil(t, err)
+		assert.Equal(t, KafkaTopic{
+			Topic: "orders",
+			Metadata: KafkaTopicMetadata{
+				Partitions:        6,
+				ReplicationFactor: 3,
+				Retention:         168,
+				Configs:           map[string]string{"cleanup.policy": "delete"},
+			},
+		}, res.Spec)
+		converted, err := handler.ToYaml(res)
+		assert.Nil(t, err)
+		resBack, err := handler.FromYaml(converted)
+		assert.Nil(t, err)
+		assert.Equal(t, res, resBack)
+	})
+	t.Run("should convert from and to proto successfully", func(t *testing.T) {
+		originalRes := models.ResourceSpec{
+			Version:   1,
+			Name:      "orders",
+			Type:      models.ResourceTypeTopic,
+			Datastore: This,
+			Spec: KafkaTopic{
+				Topic: "orders",
+				Metadata: KafkaTopicMetadata{
+					Partitions:        6,
+					ReplicationFactor: 3,
+					Retention:         -1,
+					Configs:           map[string]string{"cleanup.policy": "compact"},
+				},
+			},
+			Labels: map[string]string{
+				"owner": "sales",
+			},
+		}
+		handler := topicSpecHandler{}
+		protoInBytes, err := handler.ToProtobuf(originalRes)
+		assert.Nil(t, err)
+		resBack, err := handler.FromProtobuf(protoInBytes)
+		assert.Nil(t, err)
+		assert.Equal(t, originalRes, resBack)
+	})
+}
+
+func TestTopicSpecValidator(t *testing.T) {
+	validator := topicSpec{}.Validator()
+	valid := KafkaTopicMetadata{Partitions: 3, ReplicationFactor: 2, Retention: 24}
+	t.Run("should accept a valid topic", func(t *testing.T) {
+		err := validator(models.ResourceSpec{
+			Name: "orders.v1",
+			Spec: KafkaTopic{Topic: "orders.v1", Metadata: valid},
+		})
+		assert.Nil(t, err)
+	})
+	t.Run("should reject invalid topics", func(t *testing.T) {
+		noPartitions := valid
+		noPartitions.Partitions = 0
+		noReplicas := valid
+		noReplicas.ReplicationFactor = 0
+		invalidRetention := valid
+		invalidRetention.Retention = -2
+		twoRetentions := valid
+		twoRetentions.Configs = map[string]string{"retention.ms": "1000"}
+
+		for name, meta := range map[string]KafkaTopicMetadata{
+			"no partitions":       noPartitions,
+			"no replicas":         noReplicas,
+			"invalid retention":   invalidRetention,
+			"retention set twice": twoRetentions,
+		} {
+			err := validator(models.ResourceSpec{
+				Name: "orders",
+				Spec: KafkaTopic{Topic: "orders", Metadata: meta},
+			})
+			assert.NotNil(t, err, name)
+		}
+	})
+	t.Run("should reject invalid topic names", func(t *testing.T) {
+		err := validator(models.ResourceSpec{Name: "orders/v1"})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestTopicConfigs(t *testing.T) {
+	t.Run("should keep retention which is not in whole hours as config", func(t *testing.T) {
+		meta := KafkaTopicMetadata{}
+		err := meta.setTopicConfigs(map[string]string{
+			"retention.ms":   "90000",
+			"cleanup.policy": "delete",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), meta.Retention)
+		assert.Equal(t, map[string]string{
+			"retention.ms":   "90000",
+			"cleanup.policy": "delete",
+		}, meta.Configs)
+		assert.Equal(t, map[string]string{
+			"retention.ms":   "90000",
+			"cleanup.policy": "delete",
+		}, meta.topicConfigs())
+	})
+	t.Run("should read retention in hours", func(t *testing.T) {
+		meta := KafkaTopicMetadata{}
+		err := meta.setTopicConfigs(map[string]string{"retention.ms": "7200000"})
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), meta.Retention)
+		assert.Nil(t, meta.Configs)
+	})
+}
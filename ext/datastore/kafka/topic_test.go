@@ -0,0 +1,143 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestTopic(t *testing.T) {
+	testingContext := context.Background()
+	kafkaTopic := KafkaTopic{
+		Topic: "orders",
+		Metadata: KafkaTopicMetadata{
+			Partitions:        6,
+			ReplicationFactor: 3,
+			Retention:         168,
+			Configs: map[string]string{
+				"cleanup.policy": "delete",
+			},
+		},
+	}
+	resourceSpec := models.ResourceSpec{
+		Name: "orders",
+		Type: models.ResourceTypeTopic,
+		Spec: kafkaTopic,
+	}
+
+	t.Run("createTopic", func(t *testing.T) {
+		t.Run("should create topic if it doesn't exist", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTopic", testingContext, "orders").Return(KafkaTopicMetadata{}, errNotFound)
+			client.On("CreateTopic", testingContext, kafkaTopic).Return(nil)
+
+			err := createTopic(testingContext, resourceSpec, client, false, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should not alter existing topic if not upserting", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTopic", testingContext, "orders").Return(KafkaTopicMetadata{Partitions: 1}, nil)
+
+			err := createTopic(testingContext, resourceSpec, client, false, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should add partitions and alter configs of existing topic", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTopic", testingContext, "orders").Return(KafkaTopicMetadata{
+				Partitions:        3,
+				ReplicationFactor: 3,
+				Retention:         24,
+				Configs: map[string]string{
+					"cleanup.policy":      "compact",
+					"min.insync.replicas": "2",
+				},
+			}, nil)
+			client.On("AddPartitions", testingContext, "orders", 6).Return(nil)
+			client.On("AlterConfigs", testingContext, "orders", map[string]string{
+				"retention.ms":        "604800000",
+				"cleanup.policy":      "delete",
+				"min.insync.replicas": "",
+			}).Return(nil)
+
+			err := createTopic(testingContext, resourceSpec, client, true, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should not run anything if topic is up to date", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTopic", testingContext, "orders").Return(kafkaTopic.Metadata, nil)
+
+			err := createTopic(testingContext, resourceSpec, client, true, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should reject destructive changes unless forced", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTopic", testingContext, "orders").Return(KafkaTopicMetadata{
+				Partitions:        12,
+				ReplicationFactor: 2,
+				Retention:         -1,
+				Configs: map[string]string{
+					"cleanup.policy": "delete",
+				},
+			}, nil)
+
+			err := createTopic(testingContext, resourceSpec, client, true, false)
+			assert.True(t, errors.Is(err, models.ErrDestructiveChange))
+			assert.Contains(t, err.Error(), "partitions can't be decreased from 12 to 6")
+			assert.Contains(t, err.Error(), "replication factor can't be changed from 2 to 3")
+			assert.Contains(t, err.Error(), "retention decreased from -1 to 604800000 ms")
+			client.AssertNotCalled(t, "AlterConfigs", mock.Anything, mock.Anything, mock.Anything)
+		})
+		t.Run("should apply only compatible changes when forced", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTopic", testingContext, "orders").Return(KafkaTopicMetadata{
+				Partitions:        6,
+				ReplicationFactor: 3,
+				Retention:         720,
+			}, nil)
+			client.On("AlterConfigs", testingContext, "orders", map[string]string{
+				"cleanup.policy": "delete",
+			}).Return(nil)
+
+			err := createTopic(testingContext, resourceSpec, client, true, true)
+			assert.Nil(t, err)
+		})
+		t.Run("should treat removing retention as destructive", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTopic", testingContext, "orders").Return(KafkaTopicMetadata{
+				Partitions:        6,
+				ReplicationFactor: 3,
+				Retention:         -1,
+			}, nil)
+
+			noRetention := resourceSpec
+			noRetention.Spec = KafkaTopic{
+				Topic: "orders",
+				Metadata: KafkaTopicMetadata{
+					Partitions:        6,
+					ReplicationFactor: 3,
+				},
+			}
+			err := createTopic(testingContext, noRetention, client, true, false)
+			assert.True(t, errors.Is(err, models.ErrDestructiveChange))
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+var (
+	// errNotFound is returned by client when the requested table or schema
+	// doesn't exist in database
+	errNotFound = errors.New("not found")
+)
+
+// Client runs statements on a postgres database and reads back the state
+// of tables and schemas
+type Client interface {
+	// Exec runs all the statements in a single transaction
+	Exec(ctx context.Context, statements ...string) error
+
+	ReadTable(ctx context.Context, schema, table string) (PGTableMetadata, error)
+	ReadSchema(ctx context.Context, schema string) (PGSchemaMetadata, error)
+
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+type defaultClientFactory struct{}
+
+func (fac *defaultClientFactory) New(ctx context.Context, connURL string) (Client, error) {
+	db, err := sql.Open("postgres", connURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create postgres client")
+	}
+	return &sqlClient{db: db}, nil
+}
+
+type sqlClient struct {
+	db *sql.DB
+}
+
+func (c *sqlClient) Exec(ctx context.Context, statements ...string) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "failed to run %s", statement)
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *sqlClient) ReadTable(ctx context.Context, schema, table string) (PGTableMetadata, error) {
+	var meta PGTableMetadata
+	err := c.db.QueryRowContext(ctx, `SELECT COALESCE(obj_description(c.oid, 'pg_class'), '')
+		FROM pg_class c JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relname = $2 AND c.relkind IN ('r', 'p')`, schema, table).Scan(&meta.Description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PGTableMetadata{}, errNotFound
+		}
+		return PGTableMetadata{}, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, `SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull,
+			COALESCE(pg_get_expr(d.adbin, d.adrelid), ''), COALESCE(col_description(a.attrelid, a.attnum), '')
+		FROM pg_attribute a
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = format('%I.%I', $1::text, $2::text)::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, schema, table)
+	if err != nil {
+		return PGTableMetadata{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var column PGColumn
+		if err := rows.Scan(&column.Name, &column.Type, &column.NotNull, &column.Default, &column.Description); err != nil {
+			return PGTableMetadata{}, err
+		}
+		meta.Columns = append(meta.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return PGTableMetadata{}, err
+	}
+
+	var primaryKey pq.StringArray
+	err = c.db.QueryRowContext(ctx, `SELECT array_agg(a.attname ORDER BY array_position(i.indkey::int2[], a.attnum))
+		FROM pg_index i JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = ANY(i.indkey)
+		WHERE i.indrelid = format('%I.%I', $1::text, $2::text)::regclass AND i.indisprimary`, schema, table).Scan(&primaryKey)
+	if err != nil {
+		return PGTableMetadata{}, err
+	}
+	meta.PrimaryKey = primaryKey
+	return meta, nil
+}
+
+func (c *sqlClient) ReadSchema(ctx context.Context, schema string) (PGSchemaMetadata, error) {
+	var meta PGSchemaMetadata
+	err := c.db.QueryRowContext(ctx, `SELECT COALESCE(obj_description(n.oid, 'pg_namespace'), '')
+		FROM pg_namespace n WHERE n.nspname = $1`, schema).Scan(&meta.Description)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PGSchemaMetadata{}, errNotFound
+		}
+		return PGSchemaMetadata{}, err
+	}
+	return meta, nil
+}
+
+func (c *sqlClient) Ping(ctx context.Context) error {
+	return c.db.PingContext(ctx)
+}
+
+func (c *sqlClient) Close() error {
+	return c.db.Close()
+}
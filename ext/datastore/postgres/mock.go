@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ClientMock struct {
+	mock.Mock
+}
+
+func (cli *ClientMock) Exec(ctx context.Context, statements ...string) error {
+	return cli.Called(ctx, statements).Error(0)
+}
+
+func (cli *ClientMock) ReadTable(ctx context.Context, schema, table string) (PGTableMetadata, error) {
+	args := cli.Called(ctx, schema, table)
+	return args.Get(0).(PGTableMetadata), args.Error(1)
+}
+
+func (cli *ClientMock) ReadSchema(ctx context.Context, schema string) (PGSchemaMetadata, error) {
+	args := cli.Called(ctx, schema)
+	return args.Get(0).(PGSchemaMetadata), args.Error(1)
+}
+
+func (cli *ClientMock) Ping(ctx context.Context) error {
+	return cli.Called(ctx).Error(0)
+}
+
+func (cli *ClientMock) Close() error {
+	return cli.Called().Error(0)
+}
+
+type ClientFactoryMock struct {
+	mock.Mock
+}
+
+func (fac *ClientFactoryMock) New(ctx context.Context, connURL string) (Client, error) {
+	args := fac.Called(ctx, connURL)
+	return args.Get(0).(Client), args.Error(1)
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/odpf/optimus/models"
+)
+
+func createSchema(ctx context.Context, spec models.ResourceSpec, client Client, upsert bool) error {
+	pgResource, ok := spec.Spec.(PGSchema)
+	if !ok {
+		return errors.New("failed to read schema spec for postgres")
+	}
+
+	live, err := client.ReadSchema(ctx, pgResource.Schema)
+	if err != nil {
+		if !errors.Is(err, errNotFound) {
+			return err
+		}
+		return client.Exec(ctx,
+			fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pq.QuoteIdentifier(pgResource.Schema)),
+			commentOnSchema(pgResource.Schema, pgResource.Metadata.Description),
+		)
+	}
+	if !upsert || live.Description == pgResource.Metadata.Description {
+		return nil
+	}
+	return client.Exec(ctx, commentOnSchema(pgResource.Schema, pgResource.Metadata.Description))
+}
+
+// getSchema retrieves postgres schema information
+func getSchema(ctx context.Context, spec models.ResourceSpec, client Client) (models.ResourceSpec, error) {
+	pgResource, ok := spec.Spec.(PGSchema)
+	if !ok {
+		return models.ResourceSpec{}, errors.New("failed to read schema spec for postgres")
+	}
+	meta, err := client.ReadSchema(ctx, pgResource.Schema)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	pgResource.Metadata = meta
+	spec.Spec = pgResource
+	return spec, nil
+}
+
+func deleteSchema(ctx context.Context, spec models.ResourceSpec, client Client) error {
+	pgResource, ok := spec.Spec.(PGSchema)
+	if !ok {
+		return errors.New("failed to read schema spec for postgres")
+	}
+	// schema is dropped only when empty, tables should be deleted first
+	return client.Exec(ctx, fmt.Sprintf("DROP SCHEMA %s", pq.QuoteIdentifier(pgResource.Schema)))
+}
+
+func renameSchema(ctx context.Context, spec, newSpec models.ResourceSpec, client Client) error {
+	pgResource, ok := spec.Spec.(PGSchema)
+	if !ok {
+		return errors.New("failed to read schema spec for postgres")
+	}
+	newPGResource, ok := newSpec.Spec.(PGSchema)
+	if !ok {
+		return errors.New("failed to read schema spec for postgres")
+	}
+
+	if _, err := client.ReadSchema(ctx, newPGResource.Schema); err == nil {
+		return errors.Wrapf(models.ErrResourceExists, "schema %s", newPGResource.Schema)
+	} else if !errors.Is(err, errNotFound) {
+		return err
+	}
+	return client.Exec(ctx, fmt.Sprintf("ALTER SCHEMA %s RENAME TO %s",
+		pq.QuoteIdentifier(pgResource.Schema), pq.QuoteIdentifier(newPGResource.Schema)))
+}
+
+func commentOnSchema(schema, description string) string {
+	return fmt.Sprintf("COMMENT ON SCHEMA %s IS %s", pq.QuoteIdentifier(schema), commentLiteral(description))
+}
+
+// commentLiteral quotes comment text, empty text removes the comment
+func commentLiteral(description string) string {
+	if description == "" {
+		return "NULL"
+	}
+	return pq.QuoteLiteral(description)
+}
@@ -0,0 +1,172 @@
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/kushsharma/structs"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// schemaNameFormat is how schema names are written
+	schemaNameFormat = "schema_name"
+)
+
+var (
+	schemaNameParseRegex = regexp.MustCompile(`^(\w+)$`)
+)
+
+// SchemaResourceSpec is how schema should be represented in yaml
+type SchemaResourceSpec struct {
+	Version int
+	Name    string
+	Type    models.ResourceType
+	Spec    PGSchemaMetadata
+	Labels  map[string]string `yaml:",omitempty"`
+
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+// PGSchema is a specification for a Postgres schema
+// The schema may or may not exist
+type PGSchema struct {
+	Schema   string
+	Metadata PGSchemaMetadata
+}
+
+type PGSchemaMetadata struct {
+	Description string `yaml:",omitempty" structs:"description,omitempty"`
+}
+
+// schemaSpecHandler helps serializing/deserializing datastore resource for schema
+type schemaSpecHandler struct {
+}
+
+func (s schemaSpecHandler) ToYaml(optResource models.ResourceSpec) ([]byte, error) {
+	if optResource.Spec == nil {
+		// usually happens when resource is requested to be created for the first time via optimus cli
+		optResource.Spec = PGSchema{}
+	}
+	pgResource, ok := optResource.Spec.(PGSchema)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+
+	yamlResource := SchemaResourceSpec{
+		Version: optResource.Version,
+		Name:    optResource.Name,
+		Type:    optResource.Type,
+		Spec:    pgResource.Metadata,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return yaml.Marshal(yamlResource)
+}
+
+func (s schemaSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
+	var yamlResource SchemaResourceSpec
+	if err := yaml.Unmarshal(b, &yamlResource); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	if !schemaNameParseRegex.MatchString(yamlResource.Name) {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", yamlResource.Name)
+	}
+
+	return models.ResourceSpec{
+		Version:   yamlResource.Version,
+		Name:      yamlResource.Name,
+		Type:      yamlResource.Type,
+		Datastore: This,
+		Spec: PGSchema{
+			Schema:   yamlResource.Name,
+			Metadata: yamlResource.Spec,
+		},
+		Labels:    yamlResource.Labels,
+		DependsOn: yamlResource.DependsOn,
+	}, nil
+}
+
+func (s schemaSpecHandler) ToProtobuf(optResource models.ResourceSpec) ([]byte, error) {
+	pgResource, ok := optResource.Spec.(PGSchema)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+	pgResourceProtoSpec, err := structpb.NewStruct(structs.Map(pgResource.Metadata))
+	if err != nil {
+		return nil, err
+	}
+	resSpec := &v1.ResourceSpecification{
+		Version: int32(optResource.Version),
+		Name:    optResource.Name,
+		Type:    optResource.Type.String(),
+		Spec:    pgResourceProtoSpec,
+		Assets:  optResource.Assets,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return proto.Marshal(resSpec)
+}
+
+func (s schemaSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error) {
+	protoSpec := &v1.ResourceSpecification{}
+	if err := proto.Unmarshal(b, protoSpec); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	if !schemaNameParseRegex.MatchString(protoSpec.Name) {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", protoSpec.Name)
+	}
+
+	pgMeta := PGSchemaMetadata{}
+	if protoSpec.Spec != nil {
+		if protoSpecField, ok := protoSpec.Spec.Fields["description"]; ok {
+			pgMeta.Description = protoSpecField.GetStringValue()
+		}
+	}
+	return models.ResourceSpec{
+		Version:   int(protoSpec.Version),
+		Name:      protoSpec.Name,
+		Type:      models.ResourceType(protoSpec.Type),
+		Assets:    protoSpec.Assets,
+		Datastore: This,
+		Spec: PGSchema{
+			Schema:   protoSpec.Name,
+			Metadata: pgMeta,
+		},
+		Labels:    protoSpec.Labels,
+		DependsOn: protoSpec.DependsOn,
+	}, nil
+}
+
+type schemaSpec struct{}
+
+func (s schemaSpec) Adapter() models.DatastoreSpecAdapter {
+	return &schemaSpecHandler{}
+}
+
+func (s schemaSpec) Validator() models.DatastoreSpecValidator {
+	return func(spec models.ResourceSpec) error {
+		if !schemaNameParseRegex.MatchString(spec.Name) {
+			return fmt.Errorf("for example '%s'", schemaNameFormat)
+		}
+		return nil
+	}
+}
+
+func (s schemaSpec) NameFormat() string {
+	return schemaNameFormat
+}
+
+func (s schemaSpec) DefaultAssets() map[string]string {
+	return map[string]string{}
+}
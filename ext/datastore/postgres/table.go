@@ -0,0 +1,274 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/odpf/optimus/models"
+)
+
+var (
+	columnTypeParseRegex = regexp.MustCompile(`^([a-z][a-z0-9_ ]*?)\s*(\([0-9, ]*\))?\s*((\[\])*)$`)
+
+	// columnTypeAliases maps type names to the names postgres reports them
+	// with, so declared and live types can be compared
+	columnTypeAliases = map[string]string{
+		"int":         "integer",
+		"int4":        "integer",
+		"serial":      "integer",
+		"serial4":     "integer",
+		"int8":        "bigint",
+		"bigserial":   "bigint",
+		"serial8":     "bigint",
+		"int2":        "smallint",
+		"smallserial": "smallint",
+		"serial2":     "smallint",
+		"bool":        "boolean",
+		"float8":      "double precision",
+		"float4":      "real",
+		"decimal":     "numeric",
+		"varchar":     "character varying",
+		"char":        "character",
+		"timestamp":   "timestamp without time zone",
+		"timestamptz": "timestamp with time zone",
+		"time":        "time without time zone",
+		"timetz":      "time with time zone",
+	}
+)
+
+func createTable(ctx context.Context, spec models.ResourceSpec, client Client, upsert, force bool) error {
+	pgResource, ok := spec.Spec.(PGTable)
+	if !ok {
+		return errors.New("failed to read table spec for postgres")
+	}
+
+	live, err := client.ReadTable(ctx, pgResource.Schema, pgResource.Table)
+	if err != nil {
+		if !errors.Is(err, errNotFound) {
+			return err
+		}
+		return client.Exec(ctx, createTableStatements(pgResource)...)
+	}
+	if !upsert {
+		return nil
+	}
+
+	statements, changes := alterTableStatements(pgResource, live)
+	if len(changes) > 0 && !force {
+		return errors.Wrapf(models.ErrDestructiveChange, "table %s.%s: %s", pgResource.Schema, pgResource.Table,
+			strings.Join(changes, ", "))
+	}
+	if len(statements) == 0 {
+		return nil
+	}
+	return client.Exec(ctx, statements...)
+}
+
+// createTableStatements creates table along with its schema if needed
+func createTableStatements(pgResource PGTable) []string {
+	tableName := qualifiedTableName(pgResource.Schema, pgResource.Table)
+
+	var definitions []string
+	for _, column := range pgResource.Metadata.Columns {
+		definitions = append(definitions, columnDefinition(column))
+	}
+	if len(pgResource.Metadata.PrimaryKey) > 0 {
+		definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", quoteIdentifiers(pgResource.Metadata.PrimaryKey)))
+	}
+
+	statements := []string{
+		fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pq.QuoteIdentifier(pgResource.Schema)),
+		fmt.Sprintf("CREATE TABLE %s (%s)", tableName, strings.Join(definitions, ", ")),
+	}
+	if pgResource.Metadata.Description != "" {
+		statements = append(statements, commentOnTable(tableName, pgResource.Metadata.Description))
+	}
+	for _, column := range pgResource.Metadata.Columns {
+		if column.Description != "" {
+			statements = append(statements, commentOnColumn(tableName, column.Name, column.Description))
+		}
+	}
+	return statements
+}
+
+// alterTableStatements brings live table to the declared one, changes which
+// would lose data of table are not applied and returned as changes instead,
+// i.e. dropping a column, changing type of a column or its primary key
+func alterTableStatements(declared PGTable, live PGTableMetadata) (statements, changes []string) {
+	tableName := qualifiedTableName(declared.Schema, declared.Table)
+
+	liveColumns := map[string]PGColumn{}
+	for _, column := range live.Columns {
+		liveColumns[column.Name] = column
+	}
+	declaredColumns := map[string]bool{}
+	for _, column := range declared.Metadata.Columns {
+		declaredColumns[column.Name] = true
+		liveColumn, ok := liveColumns[column.Name]
+		if !ok {
+			statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", tableName, columnDefinition(column)))
+			if column.Description != "" {
+				statements = append(statements, commentOnColumn(tableName, column.Name, column.Description))
+			}
+			continue
+		}
+
+		if normalizeColumnType(liveColumn.Type) != normalizeColumnType(column.Type) {
+			changes = append(changes, fmt.Sprintf("type of column %s changed from %s to %s", column.Name, liveColumn.Type, column.Type))
+		}
+		alterColumn := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", tableName, pq.QuoteIdentifier(column.Name))
+		if liveColumn.NotNull != column.NotNull {
+			if column.NotNull {
+				statements = append(statements, alterColumn+" SET NOT NULL")
+			} else {
+				statements = append(statements, alterColumn+" DROP NOT NULL")
+			}
+		}
+		// defaults are only set, a column keeps its default when it isn't
+		// declared, e.g. sequences backing serial columns
+		if column.Default != "" && column.Default != liveColumn.Default {
+			statements = append(statements, fmt.Sprintf("%s SET DEFAULT %s", alterColumn, column.Default))
+		}
+		if column.Description != liveColumn.Description {
+			statements = append(statements, commentOnColumn(tableName, column.Name, column.Description))
+		}
+	}
+	for _, column := range live.Columns {
+		if !declaredColumns[column.Name] {
+			changes = append(changes, fmt.Sprintf("column %s removed", column.Name))
+		}
+	}
+
+	if declared.Metadata.Description != live.Description {
+		statements = append(statements, commentOnTable(tableName, declared.Metadata.Description))
+	}
+
+	if len(live.PrimaryKey) == 0 && len(declared.Metadata.PrimaryKey) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s)", tableName,
+			quoteIdentifiers(declared.Metadata.PrimaryKey)))
+	} else if strings.Join(live.PrimaryKey, ",") != strings.Join(declared.Metadata.PrimaryKey, ",") {
+		changes = append(changes, fmt.Sprintf("primary key changed from (%s) to (%s)",
+			strings.Join(live.PrimaryKey, ", "), strings.Join(declared.Metadata.PrimaryKey, ", ")))
+	}
+	return statements, changes
+}
+
+// normalizeColumnType writes type names the way postgres reports them,
+// e.g. varchar(10) as character varying(10)
+func normalizeColumnType(columnType string) string {
+	columnType = strings.Join(strings.Fields(strings.ToLower(columnType)), " ")
+	parts := columnTypeParseRegex.FindStringSubmatch(columnType)
+	if parts == nil {
+		return columnType
+	}
+	name, modifier, arraySuffix := parts[1], strings.ReplaceAll(parts[2], " ", ""), parts[3]
+	if alias, ok := columnTypeAliases[name]; ok {
+		name = alias
+	}
+	if name == "character" && modifier == "" {
+		modifier = "(1)"
+	}
+	// precision of time types is written before the time zone
+	for _, timeType := range []string{"timestamp", "time"} {
+		for _, zone := range []string{" without time zone", " with time zone"} {
+			if name == timeType+zone {
+				return timeType + modifier + zone + arraySuffix
+			}
+		}
+	}
+	return name + modifier + arraySuffix
+}
+
+// getTable retrieves postgres table information
+func getTable(ctx context.Context, spec models.ResourceSpec, client Client) (models.ResourceSpec, error) {
+	pgResource, ok := spec.Spec.(PGTable)
+	if !ok {
+		return models.ResourceSpec{}, errors.New("failed to read table spec for postgres")
+	}
+	meta, err := client.ReadTable(ctx, pgResource.Schema, pgResource.Table)
+	if err != nil {
+		return models.ResourceSpec{}, err
+	}
+	pgResource.Metadata = meta
+	spec.Spec = pgResource
+	return spec, nil
+}
+
+func deleteTable(ctx context.Context, spec models.ResourceSpec, client Client) error {
+	pgResource, ok := spec.Spec.(PGTable)
+	if !ok {
+		return errors.New("failed to read table spec for postgres")
+	}
+	return client.Exec(ctx, fmt.Sprintf("DROP TABLE %s", qualifiedTableName(pgResource.Schema, pgResource.Table)))
+}
+
+// renameTable moves table in place, to its new schema first if needed
+func renameTable(ctx context.Context, spec, newSpec models.ResourceSpec, client Client) error {
+	pgResource, ok := spec.Spec.(PGTable)
+	if !ok {
+		return errors.New("failed to read table spec for postgres")
+	}
+	newPGResource, ok := newSpec.Spec.(PGTable)
+	if !ok {
+		return errors.New("failed to read table spec for postgres")
+	}
+
+	if _, err := client.ReadTable(ctx, newPGResource.Schema, newPGResource.Table); err == nil {
+		return errors.Wrapf(models.ErrResourceExists, "table %s.%s", newPGResource.Schema, newPGResource.Table)
+	} else if !errors.Is(err, errNotFound) {
+		return err
+	}
+
+	var statements []string
+	if pgResource.Schema != newPGResource.Schema {
+		statements = append(statements,
+			fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pq.QuoteIdentifier(newPGResource.Schema)),
+			fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s", qualifiedTableName(pgResource.Schema, pgResource.Table),
+				pq.QuoteIdentifier(newPGResource.Schema)),
+		)
+	}
+	if pgResource.Table != newPGResource.Table {
+		statements = append(statements, fmt.Sprintf("ALTER TABLE %s RENAME TO %s",
+			qualifiedTableName(newPGResource.Schema, pgResource.Table), pq.QuoteIdentifier(newPGResource.Table)))
+	}
+	if len(statements) == 0 {
+		return nil
+	}
+	return client.Exec(ctx, statements...)
+}
+
+func columnDefinition(column PGColumn) string {
+	definition := fmt.Sprintf("%s %s", pq.QuoteIdentifier(column.Name), column.Type)
+	if column.NotNull {
+		definition += " NOT NULL"
+	}
+	if column.Default != "" {
+		definition += " DEFAULT " + column.Default
+	}
+	return definition
+}
+
+func commentOnTable(tableName, description string) string {
+	return fmt.Sprintf("COMMENT ON TABLE %s IS %s", tableName, commentLiteral(description))
+}
+
+func commentOnColumn(tableName, column, description string) string {
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s", tableName, pq.QuoteIdentifier(column), commentLiteral(description))
+}
+
+func qualifiedTableName(schema, table string) string {
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(table))
+}
+
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, pq.QuoteIdentifier(name))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,263 @@
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/kushsharma/structs"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/odpf/optimus/api/proto/odpf/optimus"
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// tableNameFormat is how table names are written, database is the one
+	// configured in the datastore secret
+	tableNameFormat = "schema_name.table_name"
+)
+
+var (
+	tableNameParseRegex = regexp.MustCompile(`^(\w+)\.(\w+)$`)
+
+	// validColumnType allows type names with optional modifiers and array
+	// suffix, e.g. numeric(10, 2), character varying(255), text[]
+	validColumnType = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_ ]*(\(\s*\d+\s*(,\s*\d+\s*)?\))?(\[\])*$`)
+
+	// validColumnDefault allows a literal, a keyword like NULL or
+	// CURRENT_TIMESTAMP, or a function call taking them, each optionally
+	// cast, e.g. 0, 'new'::text, now(), nextval('orders_id_seq'::regclass)
+	validColumnDefault = regexp.MustCompile(`^\s*(` + columnDefaultCall + `|` + columnDefaultTerm + `)\s*$`)
+)
+
+const (
+	columnDefaultCast = `(::[a-zA-Z][a-zA-Z0-9_ ]*(\(\s*\d+\s*(,\s*\d+\s*)?\))?(\[\])*)?`
+	columnDefaultTerm = `(-?\d+(\.\d+)?|'([^']|'')*'|[a-zA-Z_][a-zA-Z0-9_]*)` + columnDefaultCast
+	columnDefaultCall = `[a-zA-Z_][a-zA-Z0-9_.]*\(\s*(` + columnDefaultTerm + `(\s*,\s*` + columnDefaultTerm + `)*)?\s*\)` +
+		columnDefaultCast
+)
+
+// TableResourceSpec is how table should be represented in yaml
+type TableResourceSpec struct {
+	Version int
+	Name    string
+	Type    models.ResourceType
+	Spec    PGTableMetadata
+	Labels  map[string]string `yaml:",omitempty"`
+
+	DependsOn []string `yaml:"depends_on,omitempty"`
+}
+
+// PGTable is a specification for a Postgres table
+// The table may or may not exist
+type PGTable struct {
+	Schema string
+	Table  string
+
+	Metadata PGTableMetadata
+}
+
+// PGTableMetadata holds configuration for a table
+type PGTableMetadata struct {
+	Description string     `yaml:",omitempty" structs:"description,omitempty"`
+	Columns     []PGColumn `yaml:"columns" structs:"columns"`
+	PrimaryKey  []string   `yaml:"primary_key,omitempty" structs:"primary_key,omitempty"`
+}
+
+// PGColumn describes an individual column of a postgres table
+type PGColumn struct {
+	Name        string `yaml:",omitempty" structs:"name"`
+	Type        string `yaml:",omitempty" structs:"type"`
+	NotNull     bool   `yaml:"not_null,omitempty" structs:"not_null,omitempty"`
+	Default     string `yaml:",omitempty" structs:"default,omitempty"`
+	Description string `yaml:",omitempty" structs:"description,omitempty"`
+}
+
+// tableSpecHandler helps serializing/deserializing datastore resource for table
+type tableSpecHandler struct {
+}
+
+func (s tableSpecHandler) ToYaml(optResource models.ResourceSpec) ([]byte, error) {
+	if optResource.Spec == nil {
+		// usually happens when resource is requested to be created for the first time via optimus cli
+		optResource.Spec = PGTable{}
+	}
+	pgResource, ok := optResource.Spec.(PGTable)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+
+	yamlResource := TableResourceSpec{
+		Version: optResource.Version,
+		Name:    optResource.Name,
+		Type:    optResource.Type,
+		Spec:    pgResource.Metadata,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return yaml.Marshal(yamlResource)
+}
+
+func (s tableSpecHandler) FromYaml(b []byte) (models.ResourceSpec, error) {
+	var yamlResource TableResourceSpec
+	if err := yaml.Unmarshal(b, &yamlResource); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	parsedNames := tableNameParseRegex.FindStringSubmatch(yamlResource.Name)
+	if len(parsedNames) < 3 {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", yamlResource.Name)
+	}
+
+	return models.ResourceSpec{
+		Version:   yamlResource.Version,
+		Name:      yamlResource.Name,
+		Type:      yamlResource.Type,
+		Datastore: This,
+		Spec: PGTable{
+			Schema:   parsedNames[1],
+			Table:    parsedNames[2],
+			Metadata: yamlResource.Spec,
+		},
+		Labels:    yamlResource.Labels,
+		DependsOn: yamlResource.DependsOn,
+	}, nil
+}
+
+func (s tableSpecHandler) ToProtobuf(optResource models.ResourceSpec) ([]byte, error) {
+	pgResource, ok := optResource.Spec.(PGTable)
+	if !ok {
+		return nil, errors.New("failed to convert resource, malformed spec")
+	}
+	pgResourceProtoSpec, err := structpb.NewStruct(structs.Map(pgResource.Metadata))
+	if err != nil {
+		return nil, err
+	}
+	resSpec := &v1.ResourceSpecification{
+		Version: int32(optResource.Version),
+		Name:    optResource.Name,
+		Type:    optResource.Type.String(),
+		Spec:    pgResourceProtoSpec,
+		Assets:  optResource.Assets,
+		Labels:  optResource.Labels,
+
+		DependsOn: optResource.DependsOn,
+	}
+	return proto.Marshal(resSpec)
+}
+
+func (s tableSpecHandler) FromProtobuf(b []byte) (models.ResourceSpec, error) {
+	protoSpec := &v1.ResourceSpecification{}
+	if err := proto.Unmarshal(b, protoSpec); err != nil {
+		return models.ResourceSpec{}, err
+	}
+
+	parsedNames := tableNameParseRegex.FindStringSubmatch(protoSpec.Name)
+	if len(parsedNames) < 3 {
+		return models.ResourceSpec{}, fmt.Errorf("invalid resource name %s", protoSpec.Name)
+	}
+
+	pgTable := PGTable{
+		Schema: parsedNames[1],
+		Table:  parsedNames[2],
+	}
+	if protoSpec.Spec != nil {
+		if protoSpecField, ok := protoSpec.Spec.Fields["description"]; ok {
+			pgTable.Metadata.Description = protoSpecField.GetStringValue()
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["columns"]; ok {
+			for _, columnValue := range protoSpecField.GetListValue().GetValues() {
+				pgTable.Metadata.Columns = append(pgTable.Metadata.Columns, extractColumnFromProto(columnValue))
+			}
+		}
+		if protoSpecField, ok := protoSpec.Spec.Fields["primary_key"]; ok {
+			for _, keyValue := range protoSpecField.GetListValue().GetValues() {
+				pgTable.Metadata.PrimaryKey = append(pgTable.Metadata.PrimaryKey, keyValue.GetStringValue())
+			}
+		}
+	}
+	return models.ResourceSpec{
+		Version:   int(protoSpec.Version),
+		Name:      protoSpec.Name,
+		Type:      models.ResourceType(protoSpec.Type),
+		Assets:    protoSpec.Assets,
+		Spec:      pgTable,
+		Datastore: This,
+		Labels:    protoSpec.Labels,
+		DependsOn: protoSpec.DependsOn,
+	}, nil
+}
+
+func extractColumnFromProto(columnValue *structpb.Value) PGColumn {
+	column := PGColumn{}
+	for attr, attrVal := range columnValue.GetStructValue().GetFields() {
+		switch attr {
+		case "name":
+			column.Name = attrVal.GetStringValue()
+		case "type":
+			column.Type = attrVal.GetStringValue()
+		case "not_null":
+			column.NotNull = attrVal.GetBoolValue()
+		case "default":
+			column.Default = attrVal.GetStringValue()
+		case "description":
+			column.Description = attrVal.GetStringValue()
+		}
+	}
+	return column
+}
+
+type tableSpec struct{}
+
+func (s tableSpec) Adapter() models.DatastoreSpecAdapter {
+	return &tableSpecHandler{}
+}
+
+func (s tableSpec) Validator() models.DatastoreSpecValidator {
+	return func(spec models.ResourceSpec) error {
+		if !tableNameParseRegex.MatchString(spec.Name) {
+			return fmt.Errorf("for example '%s'", tableNameFormat)
+		}
+
+		// spec is not available when only the name is being validated
+		pgResource, ok := spec.Spec.(PGTable)
+		if !ok {
+			return nil
+		}
+		columns := map[string]bool{}
+		for _, column := range pgResource.Metadata.Columns {
+			if column.Name == "" {
+				return fmt.Errorf("columns of table %s should have a name", spec.Name)
+			}
+			if columns[column.Name] {
+				return fmt.Errorf("column %s of table %s is declared more than once", column.Name, spec.Name)
+			}
+			if !validColumnType.MatchString(column.Type) {
+				return fmt.Errorf("invalid type %s of column %s", column.Type, column.Name)
+			}
+			// defaults are written into the table ddl as is
+			if column.Default != "" && !validColumnDefault.MatchString(column.Default) {
+				return fmt.Errorf("invalid default %s of column %s, expected a literal or a function call", column.Default, column.Name)
+			}
+			columns[column.Name] = true
+		}
+		for _, key := range pgResource.Metadata.PrimaryKey {
+			if !columns[key] {
+				return fmt.Errorf("primary key column %s is not a column of table %s", key, spec.Name)
+			}
+		}
+		return nil
+	}
+}
+
+func (s tableSpec) NameFormat() string {
+	return tableNameFormat
+}
+
+func (s tableSpec) DefaultAssets() map[string]string {
+	return map[string]string{}
+}
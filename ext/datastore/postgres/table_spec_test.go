@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestTableSpecHandler(t *testing.T) {
+	t.Run("should convert from and to yaml successfully", func(t *testing.T) {
+		fl := `
+version: 1
+name: sales.orders
+type: table
+spec:
+  description: orders placed
+  columns:
+  - name: id
+    type: bigint
+    not_null: true
+  - name: amount
+    type: numeric(10, 2)
+    default: "0"
+    description: in cents
+  primary_key:
+  - id
+`
+		handler := tableSpecHandler{}
+		res, err := handler.FromYaml([]byte(fl))
+		assert.Nil(t, err)
+		assert.Equal(t, PGTable{
+			Schema: "sales",
+			Table:  "orders",
+			Metadata: PGTableMetadata{
+				Description: "orders placed",
+				Columns: []PGColumn{
+					{Name: "id", Type: "bigint", NotNull: true},
+					{Name: "amount", Type: "numeric(10, 2)", Default: "0", Description: "in cents"},
+				},
+				PrimaryKey: []string{"id"},
+			},
+		}, res.Spec)
+		converted, err := handler.ToYaml(res)
+		assert.Nil(t, err)
+		resBack, err := handler.FromYaml(converted)
+		assert.Nil(t, err)
+		assert.Equal(t, res, resBack)
+	})
+	t.Run("should convert from and to proto successfully", func(t *testing.T) {
+		originalRes := models.ResourceSpec{
+			Version:   1,
+			Name:      "sales.orders",
+			Type:      models.ResourceTypeTable,
+			Datastore: This,
+			Spec: PGTable{
+				Schema: "sales",
+				Table:  "orders",
+				Metadata: PGTableMetadata{
+					Description: "orders placed",
+					Columns: []PGColumn{
+						{Name: "id", Type: "bigint", NotNull: true},
+						{Name: "amount", Type: "numeric(10, 2)", Default: "0", Description: "in cents"},
+					},
+					PrimaryKey: []string{"id"},
+				},
+			},
+			Labels: map[string]string{
+				"owner": "sales",
+			},
+		}
+		handler := tableSpecHandler{}
+		protoInBytes, err := handler.ToProtobuf(originalRes)
+		assert.Nil(t, err)
+		resBack, err := handler.FromProtobuf(protoInBytes)
+		assert.Nil(t, err)
+		assert.Equal(t, originalRes, resBack)
+	})
+	t.Run("should fail to read resource with invalid name", func(t *testing.T) {
+		handler := tableSpecHandler{}
+		_, err := handler.FromYaml([]byte("name: db.sales.orders\ntype: table"))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestTableSpecValidator(t *testing.T) {
+	validator := tableSpec{}.Validator()
+	t.Run("should accept a valid table", func(t *testing.T) {
+		err := validator(models.ResourceSpec{
+			Name: "sales.orders",
+			Spec: PGTable{
+				Schema: "sales",
+				Table:  "orders",
+				Metadata: PGTableMetadata{
+					Columns: []PGColumn{
+						{Name: "id", Type: "bigint"},
+						{Name: "tags", Type: "character varying(32)[]"},
+						{Name: "status", Type: "text", Default: "'new'::text"},
+						{Name: "note", Type: "text", Default: "'it''s; -- fine'"},
+						{Name: "amount", Type: "numeric(10, 2)", Default: "-1.5"},
+						{Name: "created_at", Type: "timestamp", Default: "now()"},
+						{Name: "seq", Type: "bigint", Default: "nextval('orders_seq_seq'::regclass)"},
+						{Name: "deleted", Type: "boolean", Default: "NULL"},
+					},
+					PrimaryKey: []string{"id"},
+				},
+			},
+		})
+		assert.Nil(t, err)
+	})
+	t.Run("should reject invalid tables", func(t *testing.T) {
+		for name, columns := range map[string][]PGColumn{
+			"duplicate column":    {{Name: "id", Type: "bigint"}, {Name: "id", Type: "text"}},
+			"invalid type":        {{Name: "id", Type: "bigint; DROP TABLE users"}},
+			"injected default":    {{Name: "id", Type: "bigint", Default: "0; DROP TABLE users"}},
+			"commented default":   {{Name: "id", Type: "bigint", Default: "0 -- comment"}},
+			"unbalanced quotes":   {{Name: "id", Type: "text", Default: "'a''; DROP TABLE users; --"}},
+			"expression default":  {{Name: "id", Type: "bigint", Default: "(SELECT max(id) FROM users)"}},
+			"unknown primary key": {{Name: "code", Type: "text"}},
+		} {
+			err := validator(models.ResourceSpec{
+				Name: "sales.orders",
+				Spec: PGTable{
+					Schema: "sales",
+					Table:  "orders",
+					Metadata: PGTableMetadata{
+						Columns:    columns,
+						PrimaryKey: []string{"id"},
+					},
+				},
+			})
+			assert.NotNil(t, err, name)
+		}
+	})
+	t.Run("should reject names without schema", func(t *testing.T) {
+		err := validator(models.ResourceSpec{Name: "orders"})
+		assert.NotNil(t, err)
+	})
+}
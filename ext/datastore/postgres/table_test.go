@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestTable(t *testing.T) {
+	testingContext := context.Background()
+	pgTable := PGTable{
+		Schema: "sales",
+		Table:  "orders",
+		Metadata: PGTableMetadata{
+			Description: "orders placed",
+			Columns: []PGColumn{
+				{Name: "id", Type: "bigint", NotNull: true},
+				{Name: "amount", Type: "numeric(10, 2)", Default: "0", Description: "in cents"},
+			},
+			PrimaryKey: []string{"id"},
+		},
+	}
+	resourceSpec := models.ResourceSpec{
+		Name: "sales.orders",
+		Type: models.ResourceTypeTable,
+		Spec: pgTable,
+	}
+
+	t.Run("createTable", func(t *testing.T) {
+		t.Run("should create schema and table if table doesn't exist", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTable", testingContext, "sales", "orders").Return(PGTableMetadata{}, errNotFound)
+			client.On("Exec", testingContext, []string{
+				`CREATE SCHEMA IF NOT EXISTS "sales"`,
+				`CREATE TABLE "sales"."orders" ("id" bigint NOT NULL, "amount" numeric(10, 2) DEFAULT 0, PRIMARY KEY ("id"))`,
+				`COMMENT ON TABLE "sales"."orders" IS 'orders placed'`,
+				`COMMENT ON COLUMN "sales"."orders"."amount" IS 'in cents'`,
+			}).Return(nil)
+
+			err := createTable(testingContext, resourceSpec, client, false, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should not alter existing table if not upserting", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTable", testingContext, "sales", "orders").Return(PGTableMetadata{}, nil)
+
+			err := createTable(testingContext, resourceSpec, client, false, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should add new columns and update comments of existing table", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTable", testingContext, "sales", "orders").Return(PGTableMetadata{
+				Description: "orders placed",
+				Columns: []PGColumn{
+					{Name: "id", Type: "bigint", NotNull: true},
+				},
+				PrimaryKey: []string{"id"},
+			}, nil)
+			client.On("Exec", testingContext, []string{
+				`ALTER TABLE "sales"."orders" ADD COLUMN "amount" numeric(10, 2) DEFAULT 0`,
+				`COMMENT ON COLUMN "sales"."orders"."amount" IS 'in cents'`,
+			}).Return(nil)
+
+			err := createTable(testingContext, resourceSpec, client, true, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should not run anything if table is up to date", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTable", testingContext, "sales", "orders").Return(PGTableMetadata{
+				Description: "orders placed",
+				Columns: []PGColumn{
+					{Name: "id", Type: "bigint", NotNull: true},
+					{Name: "amount", Type: "numeric(10,2)", Default: "0", Description: "in cents"},
+				},
+				PrimaryKey: []string{"id"},
+			}, nil)
+
+			err := createTable(testingContext, resourceSpec, client, true, false)
+			assert.Nil(t, err)
+		})
+		t.Run("should reject destructive changes unless forced", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTable", testingContext, "sales", "orders").Return(PGTableMetadata{
+				Description: "orders placed",
+				Columns: []PGColumn{
+					{Name: "id", Type: "integer", NotNull: true},
+					{Name: "amount", Type: "numeric(10,2)", Default: "0", Description: "in cents"},
+					{Name: "note", Type: "text"},
+				},
+				PrimaryKey: []string{"id"},
+			}, nil)
+
+			err := createTable(testingContext, resourceSpec, client, true, false)
+			assert.True(t, errors.Is(err, models.ErrDestructiveChange))
+			assert.Contains(t, err.Error(), "type of column id changed from integer to bigint")
+			assert.Contains(t, err.Error(), "column note removed")
+			client.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything)
+		})
+		t.Run("should apply only compatible changes when forced", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTable", testingContext, "sales", "orders").Return(PGTableMetadata{
+				Columns: []PGColumn{
+					{Name: "id", Type: "integer", NotNull: true},
+					{Name: "amount", Type: "numeric(10,2)", NotNull: true, Default: "0", Description: "in cents"},
+				},
+				PrimaryKey: []string{"id"},
+			}, nil)
+			client.On("Exec", testingContext, []string{
+				`ALTER TABLE "sales"."orders" ALTER COLUMN "amount" DROP NOT NULL`,
+				`COMMENT ON TABLE "sales"."orders" IS 'orders placed'`,
+			}).Return(nil)
+
+			err := createTable(testingContext, resourceSpec, client, true, true)
+			assert.Nil(t, err)
+		})
+	})
+	t.Run("renameTable", func(t *testing.T) {
+		newSpec := models.ResourceSpec{
+			Name: "archive.old_orders",
+			Type: models.ResourceTypeTable,
+			Spec: PGTable{Schema: "archive", Table: "old_orders"},
+		}
+		t.Run("should move table to its new schema and rename it", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTable", testingContext, "archive", "old_orders").Return(PGTableMetadata{}, errNotFound)
+			client.On("Exec", testingContext, []string{
+				`CREATE SCHEMA IF NOT EXISTS "archive"`,
+				`ALTER TABLE "sales"."orders" SET SCHEMA "archive"`,
+				`ALTER TABLE "archive"."orders" RENAME TO "old_orders"`,
+			}).Return(nil)
+
+			err := renameTable(testingContext, resourceSpec, newSpec, client)
+			assert.Nil(t, err)
+		})
+		t.Run("should not rename if new table already exists", func(t *testing.T) {
+			client := new(ClientMock)
+			defer client.AssertExpectations(t)
+
+			client.On("ReadTable", testingContext, "archive", "old_orders").Return(PGTableMetadata{}, nil)
+
+			err := renameTable(testingContext, resourceSpec, newSpec, client)
+			assert.True(t, errors.Is(err, models.ErrResourceExists))
+		})
+	})
+	t.Run("normalizeColumnType", func(t *testing.T) {
+		cases := map[string]string{
+			"INT":              "integer",
+			"serial":           "integer",
+			"varchar(255)":     "character varying(255)",
+			"numeric(10, 2)":   "numeric(10,2)",
+			"char":             "character(1)",
+			"timestamptz":      "timestamp with time zone",
+			"timestamp(3)":     "timestamp(3) without time zone",
+			"int[]":            "integer[]",
+			"double precision": "double precision",
+			"jsonb":            "jsonb",
+		}
+		for declared, expected := range cases {
+			assert.Equal(t, expected, normalizeColumnType(declared), declared)
+		}
+	})
+}
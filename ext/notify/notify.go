@@ -1,3 +1,6 @@
 package notify
 
-import _ "github.com/odpf/optimus/ext/notify/slack"
+import (
+	_ "github.com/odpf/optimus/ext/notify/pagerduty"
+	_ "github.com/odpf/optimus/ext/notify/slack"
+)
@@ -0,0 +1,192 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// EventsAPIURL is the pagerduty events v2 endpoint alerts are triggered at
+	EventsAPIURL = "https://events.pagerduty.com/v2/enqueue"
+
+	DefaultMaxAttempts  = 3
+	DefaultRetryBackoff = time.Second * 2
+
+	requestTimeout = time.Second * 10
+	queueSize      = 100
+)
+
+// Notifier triggers pagerduty incidents for events, route is the routing
+// key of the pagerduty service integration. Events are sent in background
+// and retried when pagerduty is unreachable or throttling
+type Notifier struct {
+	io.Closer
+
+	eventsURL     string
+	client        *http.Client
+	queue         chan Event
+	wg            sync.WaitGroup
+	workerErrChan chan error
+
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+// Event is the body of a pagerduty events v2 trigger request
+type Event struct {
+	RoutingKey  string       `json:"routing_key"`
+	EventAction string       `json:"event_action"`
+	DedupKey    string       `json:"dedup_key"`
+	Payload     EventPayload `json:"payload"`
+}
+
+type EventPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Component     string                 `json:"component,omitempty"`
+	Group         string                 `json:"group"`
+	Class         string                 `json:"class"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// Notify queues an incident to be triggered for the event, repeated events
+// of a job run are grouped in the same incident
+func (n *Notifier) Notify(ctx context.Context, attr models.NotifyAttrs) error {
+	if attr.Route == "" {
+		return errors.New("pagerduty routing key is empty")
+	}
+	project, namespace := attr.Namespace.ProjectSpec.Name, attr.Namespace.Name
+	details := (&structpb.Struct{Fields: attr.JobEvent.Value}).AsMap()
+
+	source := fmt.Sprintf("%s/%s", project, namespace)
+	summary := fmt.Sprintf("[optimus] %s of namespace %s", attr.JobEvent.Type, source)
+	dedupKey := fmt.Sprintf("%s/%s", source, attr.JobEvent.Type)
+	if attr.JobSpec.Name != "" {
+		summary = fmt.Sprintf("[optimus] %s of job %s in %s", attr.JobEvent.Type, attr.JobSpec.Name, source)
+		dedupKey = fmt.Sprintf("%s/%s/%s", source, attr.JobSpec.Name, attr.JobEvent.Type)
+		details["owner"] = attr.JobSpec.Owner
+	}
+	if scheduledAt, ok := attr.JobEvent.Value["scheduled_at"]; ok && scheduledAt.GetStringValue() != "" {
+		dedupKey = fmt.Sprintf("%s/%s", dedupKey, scheduledAt.GetStringValue())
+	}
+
+	evt := Event{
+		RoutingKey:  attr.Route,
+		EventAction: "trigger",
+		DedupKey:    dedupKey,
+		Payload: EventPayload{
+			Summary:       summary,
+			Source:        source,
+			Severity:      string(models.JobEventSeverity(attr.JobEvent.Type)),
+			Component:     attr.JobSpec.Name,
+			Group:         namespace,
+			Class:         string(attr.JobEvent.Type),
+			CustomDetails: details,
+		},
+	}
+	select {
+	case n.queue <- evt:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *Notifier) Worker(ctx context.Context) {
+	defer n.wg.Done()
+	for evt := range n.queue {
+		if err := n.send(ctx, evt); err != nil {
+			n.workerErrChan <- errors.Wrapf(err, "Worker_send: %s", evt.DedupKey)
+		}
+	}
+	close(n.workerErrChan)
+}
+
+// send triggers the event, retrying on network errors, throttling and
+// server errors with a backoff doubling after every attempt
+func (n *Notifier) send(ctx context.Context, evt Event) error {
+	body, err := json.Marshal(evt)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode pagerduty event")
+	}
+	backoff := n.retryBackoff
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		if retry, err = n.post(ctx, body); err == nil || !retry || attempt >= n.maxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, body []byte) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.eventsURL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("pagerduty responded with %s", resp.Status)
+	}
+	return false, fmt.Errorf("pagerduty responded with %s: %s", resp.Status, string(respBody))
+}
+
+// Close stops accepting events and waits for the queued ones to be sent
+func (n *Notifier) Close() error {
+	close(n.queue)
+	n.wg.Wait()
+	return nil
+}
+
+func NewNotifier(ctx context.Context, eventsURL string, maxAttempts int, retryBackoff time.Duration,
+	errHandler func(error)) *Notifier {
+	this := &Notifier{
+		eventsURL:     eventsURL,
+		client:        &http.Client{Timeout: requestTimeout},
+		queue:         make(chan Event, queueSize),
+		workerErrChan: make(chan error),
+		maxAttempts:   maxAttempts,
+		retryBackoff:  retryBackoff,
+	}
+
+	this.wg.Add(1)
+	go func() {
+		for err := range this.workerErrChan {
+			errHandler(err)
+		}
+		this.wg.Done()
+	}()
+
+	this.wg.Add(1)
+	go this.Worker(ctx)
+	return this
+}
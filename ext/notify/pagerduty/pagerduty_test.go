@@ -0,0 +1,122 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestPagerDuty(t *testing.T) {
+	eventValues, _ := structpb.NewStruct(map[string]interface{}{
+		"scheduled_at": "2021-03-01T02:00:00Z",
+		"log_url":      "http://airflow.example.io/log",
+	})
+	namespace := models.NamespaceSpec{
+		Name: "game_jam",
+		ProjectSpec: models.ProjectSpec{
+			Name: "foo",
+		},
+	}
+
+	t.Run("should trigger incident for job event", func(t *testing.T) {
+		var received Event
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			rw.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		var sendErrors []error
+		notifier := NewNotifier(context.Background(), server.URL, DefaultMaxAttempts, time.Millisecond, func(err error) {
+			sendErrors = append(sendErrors, err)
+		})
+		assert.Nil(t, notifier.Notify(context.Background(), models.NotifyAttrs{
+			Namespace: namespace,
+			JobSpec: models.JobSpec{
+				Name:  "transform-tables",
+				Owner: "optimus@test.com",
+			},
+			JobEvent: models.JobEvent{
+				Type:  models.JobEventTypeFailure,
+				Value: eventValues.GetFields(),
+			},
+			Route: "r0ut1ngk3y",
+		}))
+		assert.Nil(t, notifier.Close())
+
+		assert.Nil(t, sendErrors)
+		assert.Equal(t, Event{
+			RoutingKey:  "r0ut1ngk3y",
+			EventAction: "trigger",
+			DedupKey:    "foo/game_jam/transform-tables/failure/2021-03-01T02:00:00Z",
+			Payload: EventPayload{
+				Summary:   "[optimus] failure of job transform-tables in foo/game_jam",
+				Source:    "foo/game_jam",
+				Severity:  "critical",
+				Component: "transform-tables",
+				Group:     "game_jam",
+				Class:     "failure",
+				CustomDetails: map[string]interface{}{
+					"scheduled_at": "2021-03-01T02:00:00Z",
+					"log_url":      "http://airflow.example.io/log",
+					"owner":        "optimus@test.com",
+				},
+			},
+		}, received)
+	})
+	t.Run("should trigger incident for namespace event without job", func(t *testing.T) {
+		var received Event
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			rw.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		notifier := NewNotifier(context.Background(), server.URL, DefaultMaxAttempts, time.Millisecond, func(err error) {})
+		assert.Nil(t, notifier.Notify(context.Background(), models.NotifyAttrs{
+			Namespace: namespace,
+			JobEvent: models.JobEvent{
+				Type: models.JobEventTypeDeployFailure,
+			},
+			Route: "r0ut1ngk3y",
+		}))
+		assert.Nil(t, notifier.Close())
+
+		assert.Equal(t, "foo/game_jam/deploy_failure", received.DedupKey)
+		assert.Equal(t, "[optimus] deploy_failure of namespace foo/game_jam", received.Payload.Summary)
+	})
+	t.Run("should retry when pagerduty is throttling", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 2 {
+				rw.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			rw.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		var sendErrors []error
+		notifier := NewNotifier(context.Background(), server.URL, DefaultMaxAttempts, time.Millisecond, func(err error) {
+			sendErrors = append(sendErrors, err)
+		})
+		assert.Nil(t, notifier.Notify(context.Background(), models.NotifyAttrs{
+			Namespace: namespace,
+			JobEvent:  models.JobEvent{Type: models.JobEventTypeSLAMiss},
+			Route:     "r0ut1ngk3y",
+		}))
+		assert.Nil(t, notifier.Close())
+
+		assert.Nil(t, sendErrors)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
@@ -132,10 +132,28 @@ func buildMessageBlocks(events []event) []api.Block {
 	// core details related to event
 	for evtIdx, evt := range events {
 		fieldSlice := make([]*api.TextBlockObject, 0)
-		fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Job:*\n%s", evt.jobName), false, false))
-		fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Owner:*\n%s", evt.owner), false, false))
+		// namespace level events don't belong to a job
+		if evt.jobName != "" {
+			fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Job:*\n%s", evt.jobName), false, false))
+			fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Owner:*\n%s", evt.owner), false, false))
+		}
 
 		switch evt.meta.Type {
+		case models.JobEventTypeDeployFailure:
+			heading := api.NewTextBlockObject("plain_text",
+				fmt.Sprintf("[Deploy] Failure | %s/%s", evt.projectName, evt.namespaceName), true, false)
+			blocks = append(blocks, api.NewHeaderBlock(heading))
+
+			if failedJobs, ok := evt.meta.Value["failed_jobs"]; ok {
+				for jobIdx, failedJob := range failedJobs.GetListValue().GetValues() {
+					if jobIdx >= MaxSLAEventsToProcess {
+						fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn",
+							fmt.Sprintf("*Failed jobs:*\n%d more", len(failedJobs.GetListValue().GetValues())-jobIdx), false, false))
+						break
+					}
+					fieldSlice = append(fieldSlice, api.NewTextBlockObject("mrkdwn", fmt.Sprintf("*Failed job:*\n%s", failedJob.GetStringValue()), false, false))
+				}
+			}
 		case models.JobEventTypeSLAMiss:
 			heading := api.NewTextBlockObject("plain_text",
 				fmt.Sprintf("[Job] SLA Breached | %s/%s", evt.projectName, evt.namespaceName), true, false)
@@ -183,8 +201,10 @@ func buildMessageBlocks(events []event) []api.Block {
 			continue
 		}
 
-		fieldsSection := api.NewSectionBlock(nil, fieldSlice, nil)
-		blocks = append(blocks, fieldsSection)
+		if len(fieldSlice) > 0 {
+			fieldsSection := api.NewSectionBlock(nil, fieldSlice, nil)
+			blocks = append(blocks, fieldsSection)
+		}
 
 		// event log url button
 		if logURL, ok := evt.meta.Value["log_url"]; ok && logURL.GetStringValue() != "" {
@@ -217,6 +217,12 @@ func TestBuildMessages(t *testing.T) {
                 }
             ]
         }`))
+	deployFailureValues, _ := structpb.NewStruct(map[string]interface{}{
+		"failed_jobs": []interface{}{
+			"job-a: failed to compile",
+			"job-b: failed to upload",
+		},
+	})
 	type args struct {
 		events []event
 	}
@@ -270,6 +276,43 @@ func TestBuildMessages(t *testing.T) {
             }
         ]
     }
+]`,
+		},
+		{
+			name: "should list failed jobs of deploy_failure without job details",
+			args: args{events: []event{
+				{
+					authToken:     "xx",
+					projectName:   "ss",
+					namespaceName: "bb",
+					meta: models.JobEvent{
+						Type:  models.JobEventTypeDeployFailure,
+						Value: deployFailureValues.GetFields(),
+					},
+				},
+			}},
+			want: `[
+    {
+        "type": "header",
+        "text": {
+            "type": "plain_text",
+            "text": "[Deploy] Failure | ss/bb",
+            "emoji": true
+        }
+    },
+    {
+        "type": "section",
+        "fields": [
+            {
+                "type": "mrkdwn",
+                "text": "*Failed job:*\njob-a: failed to compile"
+            },
+            {
+                "type": "mrkdwn",
+                "text": "*Failed job:*\njob-b: failed to upload"
+            }
+        ]
+    }
 ]`,
 		},
 	}
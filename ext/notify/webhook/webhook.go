@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/models"
+)
+
+const (
+	// AuthTokenSecretName is an optional project secret sent as bearer token
+	// with every request
+	AuthTokenSecretName = "NOTIFY_WEBHOOK"
+
+	DefaultMaxAttempts  = 3
+	DefaultRetryBackoff = time.Second * 2
+
+	requestTimeout = time.Second * 10
+	queueSize      = 100
+)
+
+// Notifier posts job events to http endpoints, requests are sent in
+// background and retried when endpoint is unreachable or fails
+type Notifier struct {
+	io.Closer
+
+	client        *http.Client
+	queue         chan request
+	wg            sync.WaitGroup
+	workerErrChan chan error
+
+	maxAttempts  int
+	retryBackoff time.Duration
+}
+
+type request struct {
+	url       string
+	authToken string
+	body      []byte
+}
+
+// Payload is the body posted to endpoints
+type Payload struct {
+	Project   string                 `json:"project"`
+	Namespace string                 `json:"namespace"`
+	Job       string                 `json:"job"`
+	Owner     string                 `json:"owner"`
+	Type      string                 `json:"type"`
+	Value     map[string]interface{} `json:"value"`
+}
+
+// Notify queues the event to be posted at route, route is the url of endpoint
+func (n *Notifier) Notify(ctx context.Context, attr models.NotifyAttrs) error {
+	body, err := json.Marshal(Payload{
+		Project:   attr.Namespace.ProjectSpec.Name,
+		Namespace: attr.Namespace.Name,
+		Job:       attr.JobSpec.Name,
+		Owner:     attr.JobSpec.Owner,
+		Type:      string(attr.JobEvent.Type),
+		Value:     (&structpb.Struct{Fields: attr.JobEvent.Value}).AsMap(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode job event")
+	}
+	authToken, _ := attr.Namespace.ProjectSpec.Secret.GetByName(AuthTokenSecretName)
+
+	select {
+	case n.queue <- request{url: attr.Route, authToken: authToken, body: body}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (n *Notifier) Worker(ctx context.Context) {
+	defer n.wg.Done()
+	for req := range n.queue {
+		if err := n.send(ctx, req); err != nil {
+			n.workerErrChan <- errors.Wrapf(err, "Worker_send: %s", req.url)
+		}
+	}
+	close(n.workerErrChan)
+}
+
+// send posts the request, retrying on network errors, throttling and server
+// errors with a backoff doubling after every attempt
+func (n *Notifier) send(ctx context.Context, req request) error {
+	backoff := n.retryBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		if retry, err = n.post(ctx, req); err == nil || !retry || attempt >= n.maxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+}
+
+func (n *Notifier) post(ctx context.Context, req request) (retry bool, err error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.url, bytes.NewReader(req.body))
+	if err != nil {
+		return false, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if req.authToken != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+req.authToken)
+	}
+
+	resp, err := n.client.Do(httpReq)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return false, fmt.Errorf("endpoint responded with %s", resp.Status)
+}
+
+// Close stops accepting events and waits for the queued ones to be sent
+func (n *Notifier) Close() error {
+	close(n.queue)
+	n.wg.Wait()
+	return nil
+}
+
+func NewNotifier(ctx context.Context, maxAttempts int, retryBackoff time.Duration, errHandler func(error)) *Notifier {
+	this := &Notifier{
+		client:        &http.Client{Timeout: requestTimeout},
+		queue:         make(chan request, queueSize),
+		workerErrChan: make(chan error),
+		maxAttempts:   maxAttempts,
+		retryBackoff:  retryBackoff,
+	}
+
+	this.wg.Add(1)
+	go func() {
+		for err := range this.workerErrChan {
+			errHandler(err)
+		}
+		this.wg.Done()
+	}()
+
+	this.wg.Add(1)
+	go this.Worker(ctx)
+	return this
+}
@@ -0,0 +1,115 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestWebhook(t *testing.T) {
+	eventValues, _ := structpb.NewStruct(map[string]interface{}{
+		"run_id": "scheduled__2021-03-01T00:00:00+00:00",
+	})
+	notifyAttrs := func(route string) models.NotifyAttrs {
+		return models.NotifyAttrs{
+			Namespace: models.NamespaceSpec{
+				Name: "game_jam",
+				ProjectSpec: models.ProjectSpec{
+					Name: "foo",
+					Secret: models.ProjectSecrets{{
+						Name:  AuthTokenSecretName,
+						Value: "secret-token",
+					}},
+				},
+			},
+			JobSpec: models.JobSpec{
+				Name:  "transform-tables",
+				Owner: "optimus@test.com",
+			},
+			JobEvent: models.JobEvent{
+				Type:  models.JobEventTypeStart,
+				Value: eventValues.GetFields(),
+			},
+			Route: route,
+		}
+	}
+
+	t.Run("should post event to endpoint with auth token", func(t *testing.T) {
+		var received Payload
+		var authHeader string
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			authHeader = r.Header.Get("Authorization")
+			_ = json.NewDecoder(r.Body).Decode(&received)
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		var sendErrors []error
+		notifier := NewNotifier(context.Background(), DefaultMaxAttempts, time.Millisecond, func(err error) {
+			sendErrors = append(sendErrors, err)
+		})
+		assert.Nil(t, notifier.Notify(context.Background(), notifyAttrs(server.URL)))
+		assert.Nil(t, notifier.Close())
+
+		assert.Nil(t, sendErrors)
+		assert.Equal(t, "Bearer secret-token", authHeader)
+		assert.Equal(t, Payload{
+			Project:   "foo",
+			Namespace: "game_jam",
+			Job:       "transform-tables",
+			Owner:     "optimus@test.com",
+			Type:      "start",
+			Value: map[string]interface{}{
+				"run_id": "scheduled__2021-03-01T00:00:00+00:00",
+			},
+		}, received)
+	})
+	t.Run("should retry when endpoint fails", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			rw.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		var sendErrors []error
+		notifier := NewNotifier(context.Background(), DefaultMaxAttempts, time.Millisecond, func(err error) {
+			sendErrors = append(sendErrors, err)
+		})
+		assert.Nil(t, notifier.Notify(context.Background(), notifyAttrs(server.URL)))
+		assert.Nil(t, notifier.Close())
+
+		assert.Nil(t, sendErrors)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+	t.Run("should give up without retrying if endpoint rejects event", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			rw.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		var sendErrors []error
+		notifier := NewNotifier(context.Background(), DefaultMaxAttempts, time.Millisecond, func(err error) {
+			sendErrors = append(sendErrors, err)
+		})
+		assert.Nil(t, notifier.Notify(context.Background(), notifyAttrs(server.URL)))
+		assert.Nil(t, notifier.Close())
+
+		assert.Len(t, sendErrors, 1)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
@@ -30,6 +30,7 @@ const (
 	baseLibFileName = "__lib.py"
 	dagStatusURL    = "api/experimental/dags/%s/dag_runs"
 	dagRunClearURL  = "clear&dag_id=%s&start_date=%s&end_date=%s"
+	dagPausedURL    = "api/experimental/dags/%s/paused"
 )
 
 type HTTPClient interface {
@@ -109,9 +110,20 @@ func (a *scheduler) migrateLibFileToWriter(ctx context.Context, objWriter store.
 	return
 }
 
-func (a *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus,
-	error) {
-	schdHost, ok := projSpec.Config[models.ProjectSchedulerHost]
+// GetJobStatus filters all runs of dag, experimental api of airflow
+// doesn't support filtering runs
+func (a *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	filter models.JobStatusFilter) ([]models.JobStatus, error) {
+	jobStatus, err := a.listDagRuns(projSpec, jobName)
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(jobStatus), nil
+}
+
+// listDagRuns returns all runs of dag in the order airflow reports them
+func (a *scheduler) listDagRuns(projSpec models.ProjectSpec, jobName string) ([]models.JobStatus, error) {
+	schdHost, ok := projSpec.SchedulerHost()
 	if !ok {
 		return nil, errors.Errorf("scheduler host not set for %s", projSpec.Name)
 	}
@@ -173,7 +185,7 @@ func (a *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpe
 }
 
 func (a *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate, endDate time.Time) error {
-	schdHost, ok := projSpec.Config[models.ProjectSchedulerHost]
+	schdHost, ok := projSpec.SchedulerHost()
 	if !ok {
 		return errors.Errorf("scheduler host not set for %s", projSpec.Name)
 	}
@@ -223,9 +235,53 @@ func (a *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobN
 	return nil
 }
 
+// RunJob triggers a dag run at scheduled time, an existing dag run of that
+// time is cleared instead
+func (a *scheduler) RunJob(ctx context.Context, projSpec models.ProjectSpec, jobName string, scheduledAt time.Time) error {
+	runs, err := a.GetDagRunStatus(ctx, projSpec, jobName, scheduledAt, scheduledAt, 1)
+	if err != nil {
+		return err
+	}
+	if len(runs) > 0 {
+		return a.Clear(ctx, projSpec, jobName, scheduledAt, scheduledAt)
+	}
+
+	schdHost, ok := projSpec.SchedulerHost()
+	if !ok {
+		return errors.Errorf("scheduler host not set for %s", projSpec.Name)
+	}
+	schdHost = strings.Trim(schdHost, "/")
+
+	executionDate := scheduledAt.UTC().Format(time.RFC3339)
+	var jsonStr = []byte(fmt.Sprintf(`{"run_id": "manual__%s", "execution_date": "%s"}`, executionDate, executionDate))
+	postURL := fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, dagStatusURL), jobName)
+	request, err := http.NewRequest(http.MethodPost, postURL, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", postURL)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to trigger airflow dag run from %s", postURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to trigger airflow dag run from %s: %d", postURL, resp.StatusCode)
+	}
+	return nil
+}
+
+// GetJobRunLogs is not supported, experimental api of airflow doesn't
+// serve task logs
+func (a *scheduler) GetJobRunLogs(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	request models.JobRunLogRequest, send func(models.JobRunLog) error) error {
+	return errors.Wrap(models.ErrJobRunLogsNotSupported, a.GetName())
+}
+
 func (a *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time, endDate time.Time,
 	batchSize int) ([]models.JobStatus, error) {
-	allJobStatus, err := a.GetJobStatus(ctx, projSpec, jobName)
+	allJobStatus, err := a.listDagRuns(projSpec, jobName)
 	if err != nil {
 		return nil, err
 	}
@@ -239,3 +295,71 @@ func (a *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.Project
 
 	return requestedJobStatus, nil
 }
+
+func (a *scheduler) IsJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string) (bool, error) {
+	schdHost, ok := projSpec.SchedulerHost()
+	if !ok {
+		return false, errors.Errorf("scheduler host not set for %s", projSpec.Name)
+	}
+	schdHost = strings.Trim(schdHost, "/")
+
+	fetchURL := fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, dagPausedURL), jobName)
+	request, err := http.NewRequest(http.MethodGet, fetchURL, nil)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to build http request for %s", fetchURL)
+	}
+
+	resp, err := a.httpClient.Do(request)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to fetch airflow dag paused state from %s", fetchURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		// dag is not parsed by airflow yet
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.Errorf("failed to fetch airflow dag paused state from %s: %d", fetchURL, resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read airflow response")
+	}
+
+	//{
+	//	"is_paused": true
+	//}
+	var responseJSON struct {
+		IsPaused bool `json:"is_paused"`
+	}
+	if err := json.Unmarshal(body, &responseJSON); err != nil {
+		return false, errors.Wrapf(err, "json error: %s", string(body))
+	}
+	return responseJSON.IsPaused, nil
+}
+
+func (a *scheduler) SetJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string, paused bool) error {
+	schdHost, ok := projSpec.SchedulerHost()
+	if !ok {
+		return errors.Errorf("scheduler host not set for %s", projSpec.Name)
+	}
+	schdHost = strings.Trim(schdHost, "/")
+
+	// experimental api changes paused state over a GET request
+	pauseURL := fmt.Sprintf("%s/%t", fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, dagPausedURL), jobName), paused)
+	request, err := http.NewRequest(http.MethodGet, pauseURL, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", pauseURL)
+	}
+
+	resp, err := a.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set airflow dag paused state from %s", pauseURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to set airflow dag paused state from %s: %d", pauseURL, resp.StatusCode)
+	}
+	return nil
+}
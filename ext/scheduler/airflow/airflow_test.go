@@ -138,7 +138,7 @@ func TestAirflow(t *testing.T) {
 				Config: map[string]string{
 					models.ProjectSchedulerHost: host,
 				},
-			}, "sample_select")
+			}, "sample_select", models.JobStatusFilter{})
 
 			assert.Nil(t, err)
 			assert.Len(t, status, 2)
@@ -161,7 +161,7 @@ func TestAirflow(t *testing.T) {
 				Config: map[string]string{
 					models.ProjectSchedulerHost: host,
 				},
-			}, "sample_select")
+			}, "sample_select", models.JobStatusFilter{})
 
 			assert.NotNil(t, err)
 			assert.Len(t, status, 0)
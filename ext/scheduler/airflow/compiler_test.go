@@ -155,6 +155,18 @@ func TestCompiler(t *testing.T) {
 			"destination1": {Job: &depSpecIntra, Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
 			"destination2": {Job: &depSpecInter, Project: &externalProjSpec, Type: models.JobSpecDependencyTypeInter},
 		},
+		ExternalDependencies: models.ExternalDependency{
+			HTTPDependencies: []models.HTTPDependency{
+				{
+					Name:           "foo-http-dep",
+					URL:            "https://api.example.io/ready",
+					Method:         "GET",
+					Headers:        map[string]string{"Authorization": "Bearer token"},
+					ExpectedStatus: 200,
+					ExpectedBody:   "ready",
+				},
+			},
+		},
 		Assets: *models.JobAssets{}.New(
 			[]models.JobSpecAsset{
 				{
@@ -389,6 +389,49 @@ class CrossTenantDependencySensor(BaseSensorOperator):
             return datetime.strptime(timestamp, self.TIMESTAMP_MS_FORMAT)
 
 
+class ExternalHttpSensor(BaseSensorOperator):
+    """
+    Pokes an http endpoint outside optimus until it responds with the expected
+    status code and a body containing expected_body, if it is set.
+    """
+    REQUEST_TIMEOUT_IN_SECS = 60
+    template_fields = ('endpoint', 'headers')
+
+    @apply_defaults
+    def __init__(
+            self,
+            endpoint: str,
+            method: str = "GET",
+            headers: dict = None,
+            expected_status: int = 200,
+            expected_body: str = "",
+            **kwargs) -> None:
+        super().__init__(**kwargs)
+        self.endpoint = endpoint
+        self.method = method
+        self.headers = headers or {}
+        self.expected_status = expected_status
+        self.expected_body = expected_body
+
+    def poke(self, context):
+        self.log.info("poking {} {}".format(self.method, self.endpoint))
+        try:
+            response = requests.request(self.method, self.endpoint, headers=self.headers,
+                                        timeout=self.REQUEST_TIMEOUT_IN_SECS)
+        except requests.exceptions.RequestException as e:
+            self.log.warning("request to {} failed, rescheduling sensor: {}".format(self.endpoint, e))
+            return False
+
+        if response.status_code != self.expected_status:
+            self.log.info("{} responded with status {} instead of {}".format(
+                self.endpoint, response.status_code, self.expected_status))
+            return False
+        if self.expected_body and self.expected_body not in response.text:
+            self.log.info("response of {} does not contain the expected body".format(self.endpoint))
+            return False
+        return True
+
+
 def optimus_failure_notify(context):
     params = context.get("params")
     optimus_client = OptimusAPIClient(params["optimus_hostname"])
@@ -474,6 +517,51 @@ def optimus_sla_miss_notify(dag, task_list, blocking_task_list, slas, blocking_t
     return
 
 
+def optimus_start_notify(context):
+    # every task reports its execution, only the first ones of a run mark its start
+    if context.get('task').upstream_list:
+        return
+    params = context.get("params")
+    optimus_client = OptimusAPIClient(params["optimus_hostname"])
+
+    message = {
+        "log_url": context.get('task_instance').log_url,
+        "task_id": context.get('task_instance').task_id,
+        "run_id": context.get('run_id'),
+        "try_number": context.get('task_instance').try_number,
+        "scheduled_at": context.get('execution_date').strftime("%Y-%m-%dT%H:%M:%SZ")
+    }
+    event = {
+        "type": "START",
+        "value": message,
+    }
+    # post event
+    resp = optimus_client.notify_event(params["project_name"], params["namespace"], params["job_name"], event)
+    print("posted event ", params, event, resp)
+    return
+
+
+def optimus_success_notify(context):
+    # called once for the run with context of its last task
+    params = context.get("params")
+    optimus_client = OptimusAPIClient(params["optimus_hostname"])
+
+    message = {
+        "log_url": context.get('task_instance').log_url,
+        "task_id": context.get('task_instance').task_id,
+        "run_id": context.get('run_id'),
+        "scheduled_at": context.get('execution_date').strftime("%Y-%m-%dT%H:%M:%SZ")
+    }
+    event = {
+        "type": "SUCCESS",
+        "value": message,
+    }
+    # post event
+    resp = optimus_client.notify_event(params["project_name"], params["namespace"], params["job_name"], event)
+    print("posted event ", params, event, resp)
+    return
+
+
 # everything below this is here for legacy reasons, should be cleaned up in future
 
 class SlackWebhookOperator(BaseOperator):
@@ -1,13 +1,14 @@
 from typing import Any, Callable, Dict, Optional
 from datetime import datetime, timedelta, timezone
+import pendulum
 
 from airflow.models import DAG, Variable, DagRun, DagModel, TaskInstance, BaseOperator, XCom, XCOM_RETURN_KEY
 from airflow.kubernetes.secret import Secret
 from airflow.configuration import conf
 from airflow.utils.weight_rule import WeightRule
 
-from __lib import optimus_failure_notify, optimus_sla_miss_notify, SuperKubernetesPodOperator, \
-    SuperExternalTaskSensor, CrossTenantDependencySensor
+from __lib import optimus_failure_notify, optimus_sla_miss_notify, optimus_start_notify, optimus_success_notify, \
+    SuperKubernetesPodOperator, SuperExternalTaskSensor, CrossTenantDependencySensor, ExternalHttpSensor
 
 SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS = int(Variable.get("sensor_poke_interval_in_secs", default_var=15 * 60))
 SENSOR_DEFAULT_TIMEOUT_IN_SECS = int(Variable.get("sensor_timeout_in_secs", default_var=15 * 60 * 60))
@@ -30,6 +31,7 @@ default_args = {
     "start_date": datetime.strptime("2000-11-11T00:00:00", "%Y-%m-%dT%H:%M:%S"),
     "end_date": datetime.strptime("2020-11-11T00:00:00","%Y-%m-%dT%H:%M:%S"),
     "on_failure_callback": optimus_failure_notify,
+    "on_execute_callback": optimus_start_notify,
     "weight_rule": WeightRule.ABSOLUTE
 }
 
@@ -38,6 +40,7 @@ dag = DAG(
     default_args=default_args,
     schedule_interval="* * * * *",
     sla_miss_callback=optimus_sla_miss_notify,
+    on_success_callback=optimus_success_notify,
     catchup = True
 )
 
@@ -179,6 +182,17 @@ wait_foo__dash__inter__dash__dep__dash__job = CrossTenantDependencySensor(
     task_id="wait_foo-inter-dep-job-bq",
     dag=dag
 )
+wait_http_foo__dash__http__dash__dep = ExternalHttpSensor(
+    endpoint="https://api.example.io/ready",
+    method="GET",
+    headers={"Authorization":"Bearer token"},
+    expected_status=200,
+    expected_body="ready",
+    poke_interval=SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
+    timeout=SENSOR_DEFAULT_TIMEOUT_IN_SECS,
+    task_id="wait_http_foo-http-dep",
+    dag=dag
+)
 
 # arrange inter task dependencies
 ####################################
@@ -186,6 +200,7 @@ wait_foo__dash__inter__dash__dep__dash__job = CrossTenantDependencySensor(
 # upstream sensors -> base transformation task
 wait_foo__dash__intra__dash__dep__dash__job >> transformation_bq
 wait_foo__dash__inter__dash__dep__dash__job >> transformation_bq
+wait_http_foo__dash__http__dash__dep >> transformation_bq
 
 # set inter-dependencies between task and hooks
 hook_transporter >> transformation_bq
@@ -32,6 +32,8 @@ const (
 	dagStatusUrl      = "api/v1/dags/%s/dagRuns?limit=99999"
 	dagStatusBatchUrl = "api/v1/dags/~/dagRuns/list"
 	dagRunClearURL    = "api/v1/dags/%s/clearTaskInstances"
+	dagRunCreateURL   = "api/v1/dags/%s/dagRuns"
+	dagURL            = "api/v1/dags/%s"
 	airflowDateFormat = "2006-01-02T15:04:05+00:00"
 )
 
@@ -45,12 +47,14 @@ type ObjectWriterFactory interface {
 
 type scheduler struct {
 	objWriterFac ObjectWriterFactory
+	objReaderFac ObjectReaderFactory
 	httpClient   HttpClient
 }
 
-func NewScheduler(ow ObjectWriterFactory, httpClient HttpClient) *scheduler {
+func NewScheduler(ow ObjectWriterFactory, or ObjectReaderFactory, httpClient HttpClient) *scheduler {
 	return &scheduler{
 		objWriterFac: ow,
+		objReaderFac: or,
 		httpClient:   httpClient,
 	}
 }
@@ -112,19 +116,27 @@ func (a *scheduler) migrateLibFileToWriter(ctx context.Context, objWriter store.
 	return
 }
 
-func (a *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus,
-	error) {
-	schdHost, ok := projSpec.Config[models.ProjectSchedulerHost]
+// GetJobStatus narrows down dag runs by execution date in airflow, rest
+// of the filter is applied on fetched runs
+func (a *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	filter models.JobStatusFilter) ([]models.JobStatus, error) {
+	schdHost, ok := projSpec.SchedulerHost()
 	if !ok {
 		return nil, errors.Errorf("scheduler host not set for %s", projSpec.Name)
 	}
 	schdHost = strings.Trim(schdHost, "/")
-	authToken, ok := projSpec.Secret.GetByName(models.ProjectSchedulerAuth)
+	authToken, ok := projSpec.Secret.GetByName(projSpec.SchedulerAuthSecret())
 	if !ok {
-		return nil, errors.Errorf("%s secret not configured for project %s", models.ProjectSchedulerAuth, projSpec.Name)
+		return nil, errors.Errorf("%s secret not configured for project %s", projSpec.SchedulerAuthSecret(), projSpec.Name)
 	}
 
 	fetchURL := fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, dagStatusUrl), jobName)
+	if !filter.StartDate.IsZero() {
+		fetchURL += "&execution_date_gte=" + url.QueryEscape(filter.StartDate.UTC().Format(airflowDateFormat))
+	}
+	if !filter.EndDate.IsZero() {
+		fetchURL += "&execution_date_lte=" + url.QueryEscape(filter.EndDate.UTC().Format(airflowDateFormat))
+	}
 	request, err := http.NewRequest(http.MethodGet, fetchURL, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to build http request for %s", fetchURL)
@@ -167,17 +179,21 @@ func (a *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpe
 		return nil, errors.Wrapf(err, "json error: %s", string(body))
 	}
 
-	return toJobStatus(responseJson.DagRuns, jobName, []models.JobStatus{})
+	jobStatus, err := toJobStatus(responseJson.DagRuns, jobName, []models.JobStatus{})
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(jobStatus), nil
 }
 
 func (a *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate, endDate time.Time) error {
-	schdHost, ok := projSpec.Config[models.ProjectSchedulerHost]
+	schdHost, ok := projSpec.SchedulerHost()
 	if !ok {
 		return errors.Errorf("scheduler host not set for %s", projSpec.Name)
 	}
-	authToken, ok := projSpec.Secret.GetByName(models.ProjectSchedulerAuth)
+	authToken, ok := projSpec.Secret.GetByName(projSpec.SchedulerAuthSecret())
 	if !ok {
-		return errors.Errorf("%s secret not configured for project %s", models.ProjectSchedulerAuth, projSpec.Name)
+		return errors.Errorf("%s secret not configured for project %s", projSpec.SchedulerAuthSecret(), projSpec.Name)
 	}
 
 	schdHost = strings.Trim(schdHost, "/")
@@ -207,15 +223,138 @@ func (a *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobN
 	return nil
 }
 
+// RunJob creates a dag run at scheduled time, an existing dag run of that
+// time is cleared instead
+func (a *scheduler) RunJob(ctx context.Context, projSpec models.ProjectSpec, jobName string, scheduledAt time.Time) error {
+	runs, err := a.GetDagRunStatus(ctx, projSpec, jobName, scheduledAt, scheduledAt, 1)
+	if err != nil {
+		return err
+	}
+	if len(runs) > 0 {
+		return a.Clear(ctx, projSpec, jobName, scheduledAt, scheduledAt)
+	}
+
+	schdHost, ok := projSpec.SchedulerHost()
+	if !ok {
+		return errors.Errorf("scheduler host not set for %s", projSpec.Name)
+	}
+	authToken, ok := projSpec.Secret.GetByName(projSpec.SchedulerAuthSecret())
+	if !ok {
+		return errors.Errorf("%s secret not configured for project %s", projSpec.SchedulerAuthSecret(), projSpec.Name)
+	}
+	schdHost = strings.Trim(schdHost, "/")
+
+	executionDate := scheduledAt.UTC().Format(airflowDateFormat)
+	var jsonStr = []byte(fmt.Sprintf(`{"dag_run_id": "manual__%s", "execution_date": "%s", "conf": {}}`, executionDate, executionDate))
+	postURL := fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, dagRunCreateURL), jobName)
+	request, err := http.NewRequest(http.MethodPost, postURL, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", postURL)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(authToken))))
+
+	resp, err := a.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create airflow dag run from %s", postURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to create airflow dag run from %s: %d", postURL, resp.StatusCode)
+	}
+	return nil
+}
+
+func (a *scheduler) IsJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string) (bool, error) {
+	schdHost, ok := projSpec.SchedulerHost()
+	if !ok {
+		return false, errors.Errorf("scheduler host not set for %s", projSpec.Name)
+	}
+	schdHost = strings.Trim(schdHost, "/")
+	authToken, ok := projSpec.Secret.GetByName(projSpec.SchedulerAuthSecret())
+	if !ok {
+		return false, errors.Errorf("%s secret not configured for project %s", projSpec.SchedulerAuthSecret(), projSpec.Name)
+	}
+
+	fetchURL := fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, dagURL), jobName)
+	request, err := http.NewRequest(http.MethodGet, fetchURL, nil)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to build http request for %s", fetchURL)
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(authToken))))
+
+	resp, err := a.httpClient.Do(request)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to fetch airflow dag from %s", fetchURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		// dag is not parsed by airflow yet
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.Errorf("failed to fetch airflow dag from %s: %d", fetchURL, resp.StatusCode)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read airflow response")
+	}
+
+	//{
+	//	"dag_id": "",
+	//	"is_paused": true,
+	//	...
+	//}
+	var responseJSON struct {
+		IsPaused bool `json:"is_paused"`
+	}
+	if err := json.Unmarshal(body, &responseJSON); err != nil {
+		return false, errors.Wrapf(err, "json error: %s", string(body))
+	}
+	return responseJSON.IsPaused, nil
+}
+
+func (a *scheduler) SetJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string, paused bool) error {
+	schdHost, ok := projSpec.SchedulerHost()
+	if !ok {
+		return errors.Errorf("scheduler host not set for %s", projSpec.Name)
+	}
+	schdHost = strings.Trim(schdHost, "/")
+	authToken, ok := projSpec.Secret.GetByName(projSpec.SchedulerAuthSecret())
+	if !ok {
+		return errors.Errorf("%s secret not configured for project %s", projSpec.SchedulerAuthSecret(), projSpec.Name)
+	}
+
+	patchURL := fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, dagURL), jobName) + "?update_mask=is_paused"
+	var jsonStr = []byte(fmt.Sprintf(`{"is_paused": %t}`, paused))
+	request, err := http.NewRequest(http.MethodPatch, patchURL, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", patchURL)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(authToken))))
+
+	resp, err := a.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update airflow dag from %s", patchURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to update airflow dag from %s: %d", patchURL, resp.StatusCode)
+	}
+	return nil
+}
+
 func (a *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time,
 	endDate time.Time, batchSize int) ([]models.JobStatus, error) {
-	schdHost, ok := projSpec.Config[models.ProjectSchedulerHost]
+	schdHost, ok := projSpec.SchedulerHost()
 	if !ok {
 		return nil, errors.Errorf("scheduler host not set for %s", projSpec.Name)
 	}
-	authToken, ok := projSpec.Secret.GetByName(models.ProjectSchedulerAuth)
+	authToken, ok := projSpec.Secret.GetByName(projSpec.SchedulerAuthSecret())
 	if !ok {
-		return []models.JobStatus{}, errors.Errorf("%s secret not configured for project %s", models.ProjectSchedulerAuth, projSpec.Name)
+		return []models.JobStatus{}, errors.Errorf("%s secret not configured for project %s", projSpec.SchedulerAuthSecret(), projSpec.Name)
 	}
 	schdHost = strings.Trim(schdHost, "/")
 	postURL := fmt.Sprintf("%s/%s", schdHost, dagStatusBatchUrl)
@@ -287,10 +426,22 @@ func toJobStatus(dagRuns []map[string]interface{}, jobName string, jobStatus []m
 		if err != nil {
 			return nil, errors.Errorf("error parsing date for %s, %s", jobName, status["execution_date"].(string))
 		}
-		jobStatus = append(jobStatus, models.JobStatus{
+		runStatus := models.JobStatus{
 			ScheduledAt: scheduledAt,
 			State:       models.JobStatusState(status["state"].(string)),
-		})
+		}
+		// start and end dates are null till the run starts/finishes
+		if startDate, ok := status["start_date"].(string); ok && startDate != "" {
+			if runStatus.StartedAt, err = time.Parse(time.RFC3339Nano, startDate); err != nil {
+				return nil, errors.Errorf("error parsing start date for %s, %s", jobName, startDate)
+			}
+		}
+		if endDate, ok := status["end_date"].(string); ok && endDate != "" {
+			if runStatus.EndedAt, err = time.Parse(time.RFC3339Nano, endDate); err != nil {
+				return nil, errors.Errorf("error parsing end date for %s, %s", jobName, endDate)
+			}
+		}
+		jobStatus = append(jobStatus, runStatus)
 	}
 	return jobStatus, nil
 }
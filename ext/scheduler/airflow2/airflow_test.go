@@ -42,6 +42,15 @@ func (m *MockedObjectWriterFactory) New(ctx context.Context, path, writerSecret
 	return args.Get(0).(store.ObjectWriter), args.Error(1)
 }
 
+type MockedObjectReaderFactory struct {
+	mock.Mock
+}
+
+func (m *MockedObjectReaderFactory) New(ctx context.Context, path, readerSecret string) (store.ObjectReader, error) {
+	args := m.Called(ctx, path, readerSecret)
+	return args.Get(0).(store.ObjectReader), args.Error(1)
+}
+
 func TestAirflow2(t *testing.T) {
 	ctx := context.Background()
 	t.Run("Bootstrap", func(t *testing.T) {
@@ -63,7 +72,7 @@ func TestAirflow2(t *testing.T) {
 			objectPath := fmt.Sprintf("hello/%s/%s", "dags", "__lib.py")
 			ow.On("NewWriter", ctx, bucket, objectPath).Return(wc, nil)
 
-			air := airflow2.NewScheduler(owf, nil)
+			air := airflow2.NewScheduler(owf, nil, nil)
 			err := air.Bootstrap(context.Background(), models.ProjectSpec{
 				Name: "proj-name",
 				Config: map[string]string{
@@ -79,7 +88,7 @@ func TestAirflow2(t *testing.T) {
 			assert.Nil(t, err)
 		})
 		t.Run("should fail if no storage config is set", func(t *testing.T) {
-			air := airflow2.NewScheduler(nil, nil)
+			air := airflow2.NewScheduler(nil, nil, nil)
 			err := air.Bootstrap(ctx, models.ProjectSpec{
 				Name:   "proj-name",
 				Config: map[string]string{},
@@ -87,7 +96,7 @@ func TestAirflow2(t *testing.T) {
 			assert.NotNil(t, err)
 		})
 		t.Run("should fail for unsupported storage interfaces", func(t *testing.T) {
-			air := airflow2.NewScheduler(nil, nil)
+			air := airflow2.NewScheduler(nil, nil, nil)
 			err := air.Bootstrap(ctx, models.ProjectSpec{
 				Name: "proj-name",
 				Config: map[string]string{
@@ -136,7 +145,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, nil, client)
 			status, err := air.GetJobStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -149,11 +158,48 @@ func TestAirflow2(t *testing.T) {
 						Value: "admin:admin",
 					},
 				},
-			}, "sample_select")
+			}, "sample_select", models.JobStatusFilter{})
 
 			assert.Nil(t, err)
 			assert.Len(t, status, 2)
 		})
+		t.Run("should filter runs by execution date in airflow", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "2020-03-26T02:00:00+00:00", req.URL.Query().Get("execution_date_gte"))
+					assert.Equal(t, "", req.URL.Query().Get("execution_date_lte"))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"dag_runs": [
+	{"execution_date": "2020-03-26T02:00:00+00:00", "state": "failed"},
+	{"execution_date": "2020-03-27T02:00:00+00:00", "state": "success"}
+]}`))),
+					}, nil
+				},
+			}
+
+			air := airflow2.NewScheduler(nil, nil, client)
+			status, err := air.GetJobStatus(ctx, models.ProjectSpec{
+				Name: "test-proj",
+				Config: map[string]string{
+					models.ProjectSchedulerHost: host,
+				},
+				Secret: []models.ProjectSecretItem{
+					{
+						Name:  models.ProjectSchedulerAuth,
+						Value: "admin:admin",
+					},
+				},
+			}, "sample_select", models.JobStatusFilter{
+				StartDate: time.Date(2020, 3, 26, 2, 0, 0, 0, time.UTC),
+				States:    []models.JobStatusState{models.JobStatusStateFailed},
+			})
+
+			assert.Nil(t, err)
+			assert.Len(t, status, 1)
+			assert.True(t, status[0].ScheduledAt.Equal(time.Date(2020, 3, 26, 2, 0, 0, 0, time.UTC)))
+			assert.Equal(t, models.JobStatusStateFailed, status[0].State)
+		})
 		t.Run("should fail if host fails to return OK", func(t *testing.T) {
 			respString := `INTERNAL ERROR`
 			r := ioutil.NopCloser(bytes.NewReader([]byte(respString)))
@@ -166,7 +212,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, nil, client)
 			status, err := air.GetJobStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -178,19 +224,19 @@ func TestAirflow2(t *testing.T) {
 						Value: "admin:admin",
 					},
 				},
-			}, "sample_select")
+			}, "sample_select", models.JobStatusFilter{})
 
 			assert.NotNil(t, err)
 			assert.Len(t, status, 0)
 		})
 		t.Run("should fail if not scheduler secret registered", func(t *testing.T) {
-			air := airflow2.NewScheduler(nil, nil)
+			air := airflow2.NewScheduler(nil, nil, nil)
 			_, err := air.GetJobStatus(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
 					models.ProjectSchedulerHost: host,
 				},
-			}, "sample_select")
+			}, "sample_select", models.JobStatusFilter{})
 			assert.NotNil(t, err)
 		})
 	})
@@ -213,7 +259,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, nil, client)
 			err := air.Clear(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -241,7 +287,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, nil, client)
 			err := air.Clear(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -258,7 +304,7 @@ func TestAirflow2(t *testing.T) {
 			assert.NotNil(t, err)
 		})
 		t.Run("should fail if not scheduler secret registered", func(t *testing.T) {
-			air := airflow2.NewScheduler(nil, nil)
+			air := airflow2.NewScheduler(nil, nil, nil)
 			err := air.Clear(ctx, models.ProjectSpec{
 				Name: "test-proj",
 				Config: map[string]string{
@@ -268,6 +314,146 @@ func TestAirflow2(t *testing.T) {
 			assert.NotNil(t, err)
 		})
 	})
+	t.Run("RunJob", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "test-proj",
+			Config: map[string]string{
+				models.ProjectSchedulerHost: "http://airflow.example.io",
+			},
+			Secret: []models.ProjectSecretItem{
+				{
+					Name:  models.ProjectSchedulerAuth,
+					Value: "admin:admin",
+				},
+			},
+		}
+		scheduledAt := time.Date(2021, 5, 20, 2, 0, 0, 0, time.UTC)
+
+		t.Run("should create dag run if none exists at scheduled time", func(t *testing.T) {
+			var requests []string
+			var createdRun string
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req.Method+" "+req.URL.String())
+					body, _ := ioutil.ReadAll(req.Body)
+					if req.URL.Path == "/api/v1/dags/sample_select/dagRuns" {
+						createdRun = string(body)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"dag_runs": [], "total_entries": 0}`))),
+					}, nil
+				},
+			}
+
+			air := airflow2.NewScheduler(nil, nil, client)
+			err := air.RunJob(ctx, projSpec, "sample_select", scheduledAt)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{
+				"POST http://airflow.example.io/api/v1/dags/~/dagRuns/list",
+				"POST http://airflow.example.io/api/v1/dags/sample_select/dagRuns",
+			}, requests)
+			assert.JSONEq(t, `{"dag_run_id": "manual__2021-05-20T02:00:00+00:00", "execution_date": "2021-05-20T02:00:00+00:00", "conf": {}}`, createdRun)
+		})
+		t.Run("should clear dag run if it exists at scheduled time", func(t *testing.T) {
+			var requests []string
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req.Method+" "+req.URL.String())
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(bytes.NewReader([]byte(`{"dag_runs": [
+							{"execution_date": "2021-05-20T02:00:00+00:00", "state": "failed"}
+						], "total_entries": 1}`))),
+					}, nil
+				},
+			}
+
+			air := airflow2.NewScheduler(nil, nil, client)
+			err := air.RunJob(ctx, projSpec, "sample_select", scheduledAt)
+			assert.Nil(t, err)
+			assert.Equal(t, []string{
+				"POST http://airflow.example.io/api/v1/dags/~/dagRuns/list",
+				"POST http://airflow.example.io/api/v1/dags/sample_select/clearTaskInstances",
+			}, requests)
+		})
+	})
+	t.Run("IsJobPaused", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "test-proj",
+			Config: map[string]string{
+				models.ProjectSchedulerHost: "http://airflow.example.io",
+			},
+			Secret: []models.ProjectSecretItem{
+				{
+					Name:  models.ProjectSchedulerAuth,
+					Value: "admin:admin",
+				},
+			},
+		}
+		t.Run("should return paused state of dag", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "http://airflow.example.io/api/v1/dags/sample_select", req.URL.String())
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte(`{"dag_id": "sample_select", "is_paused": true}`))),
+					}, nil
+				},
+			}
+
+			air := airflow2.NewScheduler(nil, nil, client)
+			paused, err := air.IsJobPaused(ctx, projSpec, "sample_select")
+			assert.Nil(t, err)
+			assert.True(t, paused)
+		})
+		t.Run("should report a dag unknown to airflow as not paused", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
+					}, nil
+				},
+			}
+
+			air := airflow2.NewScheduler(nil, nil, client)
+			paused, err := air.IsJobPaused(ctx, projSpec, "sample_select")
+			assert.Nil(t, err)
+			assert.False(t, paused)
+		})
+	})
+	t.Run("SetJobPaused", func(t *testing.T) {
+		t.Run("should update paused state of dag", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodPatch, req.Method)
+					assert.Equal(t, "http://airflow.example.io/api/v1/dags/sample_select?update_mask=is_paused", req.URL.String())
+					body, _ := ioutil.ReadAll(req.Body)
+					assert.JSONEq(t, `{"is_paused": true}`, string(body))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte("{}"))),
+					}, nil
+				},
+			}
+
+			air := airflow2.NewScheduler(nil, nil, client)
+			err := air.SetJobPaused(ctx, models.ProjectSpec{
+				Name: "test-proj",
+				Config: map[string]string{
+					models.ProjectSchedulerHost: "http://airflow.example.io",
+				},
+				Secret: []models.ProjectSecretItem{
+					{
+						Name:  models.ProjectSchedulerAuth,
+						Value: "admin:admin",
+					},
+				},
+			}, "sample_select", true)
+			assert.Nil(t, err)
+		})
+	})
 	t.Run("GetDagRunStatus", func(t *testing.T) {
 		host := "http://airflow.example.io"
 		dagStatusBatchUrl := "api/v1/dags/~/dagRuns/list"
@@ -319,14 +505,22 @@ func TestAirflow2(t *testing.T) {
 }`
 			expectedExecutionTime0, _ := time.Parse(models.InstanceScheduledAtTimeLayout, "2020-03-25T02:00:00+00:00")
 			expectedExecutionTime1, _ := time.Parse(models.InstanceScheduledAtTimeLayout, "2020-03-26T02:00:00+00:00")
+			expectedStartTime0, _ := time.Parse(time.RFC3339Nano, "2020-06-01T16:32:58.489042+00:00")
+			expectedEndTime0, _ := time.Parse(time.RFC3339Nano, "2020-06-01T17:32:58.489042+00:00")
+			expectedStartTime1, _ := time.Parse(time.RFC3339Nano, "2020-06-01T16:33:01.020645+00:00")
+			expectedEndTime1, _ := time.Parse(time.RFC3339Nano, "2020-06-01T16:33:01.020645+00:00")
 			expectedStatus := []models.JobStatus{
 				{
 					ScheduledAt: expectedExecutionTime0,
 					State:       models.JobStatusStateSuccess,
+					StartedAt:   expectedStartTime0,
+					EndedAt:     expectedEndTime0,
 				},
 				{
 					ScheduledAt: expectedExecutionTime1,
 					State:       models.JobStatusStateSuccess,
+					StartedAt:   expectedStartTime1,
+					EndedAt:     expectedEndTime1,
 				},
 			}
 
@@ -340,7 +534,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, nil, client)
 			status, err := air.GetDagRunStatus(ctx, projectSpec, jobName, startDateTime, endDateTime, batchSize)
 
 			assert.Nil(t, err)
@@ -390,18 +584,30 @@ func TestAirflow2(t *testing.T) {
 			expectedExecutionTime0, _ := time.Parse(models.InstanceScheduledAtTimeLayout, "2020-03-25T02:00:00+00:00")
 			expectedExecutionTime1, _ := time.Parse(models.InstanceScheduledAtTimeLayout, "2020-03-26T02:00:00+00:00")
 			expectedExecutionTime2, _ := time.Parse(models.InstanceScheduledAtTimeLayout, "2020-03-26T02:00:00+00:00")
+			expectedStartTime0, _ := time.Parse(time.RFC3339Nano, "2020-06-01T16:32:58.489042+00:00")
+			expectedEndTime0, _ := time.Parse(time.RFC3339Nano, "2020-06-01T17:32:58.489042+00:00")
+			expectedStartTime1, _ := time.Parse(time.RFC3339Nano, "2020-06-01T16:33:01.020645+00:00")
+			expectedEndTime1, _ := time.Parse(time.RFC3339Nano, "2020-06-01T16:33:01.020645+00:00")
+			expectedStartTime2, _ := time.Parse(time.RFC3339Nano, "2020-06-01T16:35:01.020645+00:00")
+			expectedEndTime2, _ := time.Parse(time.RFC3339Nano, "2020-06-01T16:34:01.020645+00:00")
 			expectedStatus := []models.JobStatus{
 				{
 					ScheduledAt: expectedExecutionTime0,
 					State:       models.JobStatusStateSuccess,
+					StartedAt:   expectedStartTime0,
+					EndedAt:     expectedEndTime0,
 				},
 				{
 					ScheduledAt: expectedExecutionTime1,
 					State:       models.JobStatusStateFailed,
+					StartedAt:   expectedStartTime1,
+					EndedAt:     expectedEndTime1,
 				},
 				{
 					ScheduledAt: expectedExecutionTime2,
 					State:       models.JobStatusStateRunning,
+					StartedAt:   expectedStartTime2,
+					EndedAt:     expectedEndTime2,
 				},
 			}
 
@@ -420,7 +626,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, nil, client)
 			status, err := air.GetDagRunStatus(ctx, projectSpec, jobName, startDateTime, endDateTime, batchSize)
 
 			assert.Nil(t, err)
@@ -438,7 +644,7 @@ func TestAirflow2(t *testing.T) {
 				},
 			}
 
-			air := airflow2.NewScheduler(nil, client)
+			air := airflow2.NewScheduler(nil, nil, client)
 			status, err := air.GetDagRunStatus(ctx, projectSpec, jobName, startDateTime, endDateTime, batchSize)
 
 			assert.NotNil(t, err)
@@ -446,4 +652,95 @@ func TestAirflow2(t *testing.T) {
 			assert.Len(t, status, 0)
 		})
 	})
+	t.Run("GetJobRunLogs", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "test-proj",
+			Config: map[string]string{
+				models.ProjectSchedulerHost: "http://airflow.example.io",
+			},
+			Secret: []models.ProjectSecretItem{
+				{
+					Name:  models.ProjectSchedulerAuth,
+					Value: "admin:admin",
+				},
+				{
+					Name:  models.ProjectSecretStorageKey,
+					Value: "storage-secret",
+				},
+			},
+		}
+		scheduledAt := time.Date(2021, 5, 20, 2, 0, 0, 0, time.UTC)
+		respond := func(req *http.Request) (*http.Response, error) {
+			body := ""
+			switch req.URL.Path {
+			case "/api/v1/dags/sample_select/dagRuns":
+				assert.Equal(t, "2021-05-20T02:00:00+00:00", req.URL.Query().Get("execution_date_gte"))
+				body = `{"dag_runs": [{"dag_run_id": "scheduled__2021-05-20T02:00:00+00:00"}]}`
+			case "/api/v1/dags/sample_select/dagRuns/scheduled__2021-05-20T02:00:00+00:00/taskInstances":
+				body = `{"task_instances": [
+	{"task_id": "publish", "try_number": 0, "start_date": null},
+	{"task_id": "transformation", "try_number": 2, "start_date": "2021-05-20T02:01:00+00:00"},
+	{"task_id": "wait_upstream", "try_number": 1, "start_date": "2021-05-20T02:00:05+00:00"}
+]}`
+			case "/api/v1/dags/sample_select/dagRuns/scheduled__2021-05-20T02:00:00+00:00/taskInstances/transformation/logs/2":
+				assert.Equal(t, "text/plain", req.Header.Get("Accept"))
+				body = "query failed"
+			default:
+				assert.Fail(t, "unexpected request", req.URL.Path)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader([]byte(body)))}, nil
+		}
+
+		t.Run("should send logs of requested attempts from airflow api", func(t *testing.T) {
+			var logs []models.JobRunLog
+			air := airflow2.NewScheduler(nil, nil, &MockHttpClient{DoFunc: respond})
+			err := air.GetJobRunLogs(ctx, projSpec, "sample_select", models.JobRunLogRequest{
+				ScheduledAt: scheduledAt,
+				TaskName:    "transformation",
+				TryNumber:   2,
+			}, func(log models.JobRunLog) error {
+				logs = append(logs, log)
+				return nil
+			})
+
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobRunLog{
+				{TaskName: "transformation", TryNumber: 2, Content: []byte("query failed")},
+			}, logs)
+		})
+		t.Run("should read logs of every attempt from remote log folder if configured", func(t *testing.T) {
+			remoteProjSpec := projSpec
+			remoteProjSpec.Config = map[string]string{
+				models.ProjectSchedulerHost: "http://airflow.example.io",
+				airflow2.ProjectLogPathKey:  "gs://airflow-logs/logs",
+			}
+			or := new(mocked.ObjectReader)
+			for _, objectPath := range []string{
+				"logs/sample_select/wait_upstream/2021-05-20T02:00:00+00:00/1.log",
+				"logs/sample_select/transformation/2021-05-20T02:00:00+00:00/1.log",
+				"logs/sample_select/transformation/2021-05-20T02:00:00+00:00/2.log",
+			} {
+				or.On("NewReader", "airflow-logs", objectPath).Return(ioutil.NopCloser(bytes.NewReader([]byte(objectPath))), nil)
+			}
+			defer or.AssertExpectations(t)
+			orf := new(MockedObjectReaderFactory)
+			orf.On("New", ctx, "gs://airflow-logs/logs", "storage-secret").Return(or, nil)
+			defer orf.AssertExpectations(t)
+
+			var logs []models.JobRunLog
+			air := airflow2.NewScheduler(nil, orf, &MockHttpClient{DoFunc: respond})
+			err := air.GetJobRunLogs(ctx, remoteProjSpec, "sample_select", models.JobRunLogRequest{
+				ScheduledAt: scheduledAt,
+			}, func(log models.JobRunLog) error {
+				logs = append(logs, log)
+				return nil
+			})
+
+			assert.Nil(t, err)
+			assert.Len(t, logs, 3)
+			assert.Equal(t, "wait_upstream", logs[0].TaskName)
+			assert.Equal(t, 2, logs[2].TryNumber)
+			assert.Equal(t, []byte("logs/sample_select/transformation/2021-05-20T02:00:00+00:00/2.log"), logs[2].Content)
+		})
+	})
 }
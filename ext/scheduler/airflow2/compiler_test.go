@@ -153,6 +153,7 @@ func TestCompiler(t *testing.T) {
 			StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
 			EndDate:   &scheduleEndDate,
 			Interval:  "* * * * *",
+			Timezone:  "Asia/Jakarta",
 		},
 		Task: models.JobSpecTask{
 			Unit:     execUnit,
@@ -168,6 +169,18 @@ func TestCompiler(t *testing.T) {
 			"destination1": {Job: &depSpecIntra, Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
 			"destination2": {Job: &depSpecInter, Project: &externalProjSpec, Type: models.JobSpecDependencyTypeInter},
 		},
+		ExternalDependencies: models.ExternalDependency{
+			HTTPDependencies: []models.HTTPDependency{
+				{
+					Name:           "foo-http-dep",
+					URL:            "https://api.example.io/ready",
+					Method:         "GET",
+					Headers:        map[string]string{"Authorization": "Bearer token"},
+					ExpectedStatus: 200,
+					ExpectedBody:   "ready",
+				},
+			},
+		},
 		Assets: *models.JobAssets{}.New(
 			[]models.JobSpecAsset{
 				{
@@ -184,7 +197,7 @@ func TestCompiler(t *testing.T) {
 
 	t.Run("Compile", func(t *testing.T) {
 		t.Run("should compile basic template without any error", func(t *testing.T) {
-			scheduler := NewScheduler(nil, nil)
+			scheduler := NewScheduler(nil, nil, nil)
 			com := job.NewCompiler(
 				scheduler.GetTemplate(),
 				"http://airflow.example.io",
@@ -0,0 +1,171 @@
+package airflow2
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+)
+
+const (
+	dagRunURL           = "api/v1/dags/%s/dagRuns?execution_date_gte=%s&execution_date_lte=%s"
+	taskInstancesURL    = "api/v1/dags/%s/dagRuns/%s/taskInstances"
+	taskInstanceLogsURL = "api/v1/dags/%s/dagRuns/%s/taskInstances/%s/logs/%d?full_content=true"
+
+	// ProjectLogPathKey is the project config holding remote base log folder
+	// of airflow, e.g. gs://bucket/logs, task logs are read from there
+	// instead of airflow api if it is set
+	ProjectLogPathKey = "SCHEDULER_LOG_PATH"
+)
+
+type ObjectReaderFactory interface {
+	New(ctx context.Context, readerPath, readerSecret string) (store.ObjectReader, error)
+}
+
+type taskInstance struct {
+	TaskID    string     `json:"task_id"`
+	TryNumber int        `json:"try_number"`
+	StartDate *time.Time `json:"start_date"`
+}
+
+// GetJobRunLogs sends logs of task instances of the dag run scheduled at
+// the requested time, logs are read from the remote log folder of airflow
+// if project configures ProjectLogPathKey and from airflow api otherwise
+func (a *scheduler) GetJobRunLogs(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	request models.JobRunLogRequest, send func(models.JobRunLog) error) error {
+	schdHost, ok := projSpec.SchedulerHost()
+	if !ok {
+		return errors.Errorf("scheduler host not set for %s", projSpec.Name)
+	}
+	schdHost = strings.Trim(schdHost, "/")
+	authToken, ok := projSpec.Secret.GetByName(projSpec.SchedulerAuthSecret())
+	if !ok {
+		return errors.Errorf("%s secret not configured for project %s", projSpec.SchedulerAuthSecret(), projSpec.Name)
+	}
+	get := func(fetchURL, accept string) (io.ReadCloser, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build http request for %s", fetchURL)
+		}
+		req.Header.Set("Accept", accept)
+		req.Header.Set("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(authToken))))
+		resp, err := a.httpClient.Do(req)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch from airflow %s", fetchURL)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, errors.Errorf("failed to fetch from airflow %s: %d", fetchURL, resp.StatusCode)
+		}
+		return resp.Body, nil
+	}
+	getJSON := func(fetchURL string, out interface{}) error {
+		body, err := get(fetchURL, "application/json")
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		content, err := ioutil.ReadAll(body)
+		if err != nil {
+			return errors.Wrap(err, "failed to read airflow response")
+		}
+		if err := json.Unmarshal(content, out); err != nil {
+			return errors.Wrapf(err, "json error: %s", string(content))
+		}
+		return nil
+	}
+
+	executionDate := request.ScheduledAt.UTC().Format(airflowDateFormat)
+	var dagRuns struct {
+		DagRuns []struct {
+			DagRunID string `json:"dag_run_id"`
+		} `json:"dag_runs"`
+	}
+	if err := getJSON(fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, dagRunURL), jobName,
+		url.QueryEscape(executionDate), url.QueryEscape(executionDate)), &dagRuns); err != nil {
+		return err
+	}
+	if len(dagRuns.DagRuns) == 0 {
+		return errors.Wrapf(models.ErrJobRunNotFound, "%s scheduled at %s", jobName, executionDate)
+	}
+	dagRunID := dagRuns.DagRuns[0].DagRunID
+
+	var taskInstances struct {
+		TaskInstances []taskInstance `json:"task_instances"`
+	}
+	if err := getJSON(fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, taskInstancesURL), jobName,
+		url.PathEscape(dagRunID)), &taskInstances); err != nil {
+		return err
+	}
+	// tasks which never started are sent last
+	sort.SliceStable(taskInstances.TaskInstances, func(i, j int) bool {
+		left, right := taskInstances.TaskInstances[i].StartDate, taskInstances.TaskInstances[j].StartDate
+		return left != nil && (right == nil || left.Before(*right))
+	})
+
+	var logReader store.ObjectReader
+	logPath, readRemoteLogs := projSpec.Config[ProjectLogPathKey]
+	if readRemoteLogs {
+		storageSecret, ok := projSpec.Secret.GetByName(models.ProjectSecretStorageKey)
+		if !ok {
+			return errors.Errorf("%s secret not configured for project %s", models.ProjectSecretStorageKey, projSpec.Name)
+		}
+		var err error
+		if logReader, err = a.objReaderFac.New(ctx, logPath, storageSecret); err != nil {
+			return errors.Wrapf(err, "object reader failed for %s", projSpec.Name)
+		}
+	}
+
+	for _, ti := range taskInstances.TaskInstances {
+		for tryNumber := 1; tryNumber <= ti.TryNumber; tryNumber++ {
+			if !request.Matches(ti.TaskID, tryNumber) {
+				continue
+			}
+			var logs io.ReadCloser
+			var err error
+			if readRemoteLogs {
+				logs, err = readRemoteLog(logReader, logPath, jobName, ti.TaskID, executionDate, tryNumber)
+			} else {
+				logs, err = get(fmt.Sprintf(fmt.Sprintf("%s/%s", schdHost, taskInstanceLogsURL), jobName,
+					url.PathEscape(dagRunID), ti.TaskID, tryNumber), "text/plain")
+			}
+			if err != nil {
+				return err
+			}
+			err = models.SendJobRunLog(logs, ti.TaskID, tryNumber, send)
+			logs.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readRemoteLog reads log of a task attempt from remote base log folder,
+// logs are placed as per default log filename template of airflow
+// {dag_id}/{task_id}/{ts}/{try_number}.log
+func readRemoteLog(reader store.ObjectReader, logPath, jobName, taskID, executionDate string, tryNumber int) (io.ReadCloser, error) {
+	p, err := url.Parse(logPath)
+	if err != nil {
+		return nil, err
+	}
+	objectPath := path.Join(strings.Trim(p.Path, "/"), jobName, taskID, executionDate, fmt.Sprintf("%d.log", tryNumber))
+	logs, err := reader.NewReader(p.Hostname(), objectPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read logs from %s", objectPath)
+	}
+	return logs, nil
+}
@@ -1,5 +1,6 @@
 from typing import Any, Callable, Dict, Optional
 from datetime import datetime, timedelta, timezone
+import pendulum
 
 from airflow.models import DAG, Variable, DagRun, DagModel, TaskInstance, BaseOperator, XCom, XCOM_RETURN_KEY
 from airflow.kubernetes.secret import Secret
@@ -7,8 +8,8 @@ from airflow.configuration import conf
 from airflow.utils.weight_rule import WeightRule
 from kubernetes.client import models as k8s
 
-from __lib import optimus_failure_notify, optimus_sla_miss_notify, SuperKubernetesPodOperator, \
-    SuperExternalTaskSensor, CrossTenantDependencySensor
+from __lib import optimus_failure_notify, optimus_sla_miss_notify, optimus_start_notify, optimus_success_notify, \
+    SuperKubernetesPodOperator, SuperExternalTaskSensor, CrossTenantDependencySensor, ExternalHttpSensor
 
 SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS = int(Variable.get("sensor_poke_interval_in_secs", default_var=15 * 60))
 SENSOR_DEFAULT_TIMEOUT_IN_SECS = int(Variable.get("sensor_timeout_in_secs", default_var=15 * 60 * 60))
@@ -28,9 +29,10 @@ default_args = {
     "retry_delay": {{ if gt .Job.Behavior.Retry.Delay.Nanoseconds 0 -}} timedelta(seconds={{.Job.Behavior.Retry.Delay.Seconds}}) {{- else -}} timedelta(seconds=DAG_RETRY_DELAY) {{- end}},
     "retry_exponential_backoff": {{if .Job.Behavior.Retry.ExponentialBackoff -}}True{{- else -}}False{{- end -}},
     "priority_weight": {{.Job.Task.Priority}},
-    "start_date": datetime.strptime({{ .Job.Schedule.StartDate.Format "2006-01-02T15:04:05" | quote }}, "%Y-%m-%dT%H:%M:%S"),
-    {{if .Job.Schedule.EndDate -}}"end_date": datetime.strptime({{ .Job.Schedule.EndDate.Format "2006-01-02T15:04:05" | quote}},"%Y-%m-%dT%H:%M:%S"),{{- else -}}{{- end}}
+    "start_date": datetime.strptime({{ .Job.Schedule.StartDate.Format "2006-01-02T15:04:05" | quote }}, "%Y-%m-%dT%H:%M:%S"){{ if .Job.Schedule.Timezone }}.replace(tzinfo=pendulum.timezone({{ .Job.Schedule.Timezone | quote }})){{ end }},
+    {{if .Job.Schedule.EndDate -}}"end_date": datetime.strptime({{ .Job.Schedule.EndDate.Format "2006-01-02T15:04:05" | quote}},"%Y-%m-%dT%H:%M:%S"){{ if .Job.Schedule.Timezone }}.replace(tzinfo=pendulum.timezone({{ .Job.Schedule.Timezone | quote }})){{ end }},{{- else -}}{{- end}}
     "on_failure_callback": optimus_failure_notify,
+    "on_execute_callback": optimus_start_notify,
     "weight_rule": WeightRule.ABSOLUTE
 }
 
@@ -39,6 +41,7 @@ dag = DAG(
     default_args=default_args,
     schedule_interval={{.Job.Schedule.Interval | quote}},
     sla_miss_callback=optimus_sla_miss_notify,
+    on_success_callback=optimus_success_notify,
     catchup = {{ if .Job.Behavior.CatchUp -}} True{{- else -}} False {{- end }}
 )
 
@@ -159,6 +162,19 @@ wait_{{$dependency.Job.Name | replace "-" "__dash__" | replace "." "__dot__"}} =
 )
 {{- end -}}
 {{- end}}
+{{- range $_, $httpDependency := $.Job.ExternalDependencies.HTTPDependencies}}
+wait_http_{{$httpDependency.Name | replace "-" "__dash__" | replace "." "__dot__"}} = ExternalHttpSensor(
+    endpoint={{$httpDependency.URL | quote}},
+    method={{$httpDependency.Method | quote}},
+    headers={{ default (dict) $httpDependency.Headers | toJson }},
+    expected_status={{$httpDependency.ExpectedStatus}},
+    expected_body={{$httpDependency.ExpectedBody | quote}},
+    poke_interval=SENSOR_DEFAULT_POKE_INTERVAL_IN_SECS,
+    timeout=SENSOR_DEFAULT_TIMEOUT_IN_SECS,
+    task_id="wait_http_{{$httpDependency.Name | trunc 200}}",
+    dag=dag
+)
+{{- end}}
 
 # arrange inter task dependencies
 ####################################
@@ -167,6 +183,9 @@ wait_{{$dependency.Job.Name | replace "-" "__dash__" | replace "." "__dot__"}} =
 {{- range $i, $t := $.Job.Dependencies }}
 wait_{{ $t.Job.Name | replace "-" "__dash__" | replace "." "__dot__" }} >> transformation_{{$baseTaskSchema.Name | replace "-" "__dash__" | replace "." "__dot__"}}
 {{- end}}
+{{- range $_, $t := $.Job.ExternalDependencies.HTTPDependencies }}
+wait_http_{{ $t.Name | replace "-" "__dash__" | replace "." "__dot__" }} >> transformation_{{$baseTaskSchema.Name | replace "-" "__dash__" | replace "." "__dot__"}}
+{{- end}}
 
 # set inter-dependencies between task and hooks
 {{- range $_, $task := .Job.Hooks }}
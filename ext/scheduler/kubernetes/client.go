@@ -0,0 +1,205 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProjectNamespaceKey is the project config holding kubernetes namespace
+	// cron jobs of the project are applied to
+	ProjectNamespaceKey = "KUBERNETES_NAMESPACE"
+	defaultNamespace    = "default"
+
+	resourceCronJobs = "cronjobs"
+	resourceJobs     = "jobs"
+	listPageSize     = 500
+
+	contentTypeJSON       = "application/json"
+	contentTypeApplyPatch = "application/apply-patch+yaml"
+	contentTypeMergePatch = "application/merge-patch+json"
+)
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// apiError is a failed response of kubernetes api
+type apiError struct {
+	method     string
+	url        string
+	statusCode int
+	message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("kubernetes api %s %s failed with %d: %s", e.method, e.url, e.statusCode, e.message)
+}
+
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.statusCode == http.StatusNotFound
+}
+
+// client calls kubernetes api of the cluster jobs of a project run on, the
+// cluster is reached at scheduler host of the project using its scheduler
+// auth secret as bearer token
+type client struct {
+	httpClient HttpClient
+	host       string
+	token      string
+	namespace  string
+}
+
+func newClient(httpClient HttpClient, proj models.ProjectSpec) (*client, error) {
+	host, ok := proj.SchedulerHost()
+	if !ok || strings.TrimSpace(host) == "" {
+		return nil, errors.Errorf("scheduler host not set for %s", proj.Name)
+	}
+	token, ok := proj.Secret.GetByName(proj.SchedulerAuthSecret())
+	if !ok {
+		return nil, errors.Errorf("%s secret not configured for project %s", proj.SchedulerAuthSecret(), proj.Name)
+	}
+	namespace := strings.TrimSpace(proj.Config[ProjectNamespaceKey])
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return &client{
+		httpClient: httpClient,
+		host:       strings.TrimRight(host, "/"),
+		token:      strings.TrimSpace(token),
+		namespace:  namespace,
+	}, nil
+}
+
+// resourceURL of a namespaced batch/v1 resource, whole collection is
+// addressed if name is empty
+func (c *client) resourceURL(resource, name string, query url.Values) string {
+	resourceURL := fmt.Sprintf("%s/apis/batch/v1/namespaces/%s/%s", c.host, url.PathEscape(c.namespace), resource)
+	if name != "" {
+		resourceURL = fmt.Sprintf("%s/%s", resourceURL, url.PathEscape(name))
+	}
+	if len(query) > 0 {
+		resourceURL = fmt.Sprintf("%s?%s", resourceURL, query.Encode())
+	}
+	return resourceURL
+}
+
+// podURL of a pod in namespace of the client, all pods are addressed if
+// name is empty
+func (c *client) podURL(name, subresource string, query url.Values) string {
+	podURL := fmt.Sprintf("%s/api/v1/namespaces/%s/pods", c.host, url.PathEscape(c.namespace))
+	if name != "" {
+		podURL = fmt.Sprintf("%s/%s", podURL, url.PathEscape(name))
+	}
+	if subresource != "" {
+		podURL = fmt.Sprintf("%s/%s", podURL, subresource)
+	}
+	if len(query) > 0 {
+		podURL = fmt.Sprintf("%s?%s", podURL, query.Encode())
+	}
+	return podURL
+}
+
+// stream calls the api and returns body of a successful response, caller
+// should close it
+func (c *client) stream(ctx context.Context, requestURL string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to build http request for %s", requestURL)
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to call kubernetes api %s %s", http.MethodGet, requestURL)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		message, _ := ioutil.ReadAll(resp.Body)
+		return nil, &apiError{method: http.MethodGet, url: requestURL, statusCode: resp.StatusCode, message: string(message)}
+	}
+	return resp.Body, nil
+}
+
+// do calls the api and decodes response in out if it is set
+func (c *client) do(ctx context.Context, method, requestURL, contentType string, body []byte, out interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", requestURL)
+	}
+	request.Header.Set("Accept", contentTypeJSON)
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call kubernetes api %s %s", method, requestURL)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read kubernetes api response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// failures are reported as a Status object
+		var status struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(respBody, &status); err != nil || status.Message == "" {
+			status.Message = string(respBody)
+		}
+		return &apiError{method: method, url: requestURL, statusCode: resp.StatusCode, message: status.Message}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return errors.Wrapf(err, "json error: %s", string(respBody))
+	}
+	return nil
+}
+
+// list calls each with every object of resource matching label selector,
+// objects are fetched pageSize at a time
+func (c *client) list(ctx context.Context, resource, labelSelector string, pageSize int,
+	each func(json.RawMessage) error) error {
+	query := url.Values{
+		"labelSelector": []string{labelSelector},
+		"limit":         []string{strconv.Itoa(pageSize)},
+	}
+	for {
+		var page struct {
+			Items    []json.RawMessage `json:"items"`
+			Metadata struct {
+				Continue string `json:"continue"`
+			} `json:"metadata"`
+		}
+		if err := c.do(ctx, http.MethodGet, c.resourceURL(resource, "", query), "", nil, &page); err != nil {
+			return err
+		}
+		for _, item := range page.Items {
+			if err := each(item); err != nil {
+				return err
+			}
+		}
+		if page.Metadata.Continue == "" {
+			return nil
+		}
+		query.Set("continue", page.Metadata.Continue)
+	}
+}
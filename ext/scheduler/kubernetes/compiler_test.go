@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"context"
+	_ "embed"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+)
+
+//go:embed resources/expected_compiled_template.yaml
+var CompiledTemplate []byte
+
+func TestCompiler(t *testing.T) {
+	ctx := context.Background()
+	execUnit := new(mock.TaskPlugin)
+	execUnit.On("GetTaskSchema", ctx, models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+		Name:       "bq",
+		Image:      "example.io/namespace/image:latest",
+		SecretPath: "/opt/optimus/secrets/auth.json",
+	}, nil)
+
+	hookUnit := new(mock.HookPlugin)
+	hookUnit.On("GetHookSchema", ctx, models.GetHookSchemaRequest{}).Return(models.GetHookSchemaResponse{
+		Name:       "transporter",
+		Type:       models.HookTypePre,
+		Image:      "example.io/namespace/hook-image:latest",
+		SecretPath: "/opt/optimus/secrets/auth.json",
+	}, nil)
+
+	hookUnit2 := new(mock.HookPlugin)
+	hookUnit2.On("GetHookSchema", ctx, models.GetHookSchemaRequest{}).Return(models.GetHookSchemaResponse{
+		Name:  "predator",
+		Type:  models.HookTypePost,
+		Image: "example.io/namespace/predator-image:latest",
+	}, nil)
+
+	namespaceSpec := models.NamespaceSpec{
+		ID:   uuid.MustParse("0b1ff6a8-4f5b-4cbf-a9cd-4f0a3ec1e6a4"),
+		Name: "bar-namespace",
+		ProjectSpec: models.ProjectSpec{
+			Name: "foo-project",
+		},
+	}
+	endDate := time.Date(2021, 11, 11, 0, 0, 0, 0, time.UTC)
+	spec := models.JobSpec{
+		Name:  "foo_daily.transform",
+		Owner: "mee@mee",
+		Behavior: models.JobSpecBehavior{
+			DependsOnPast: true,
+			Retry: models.JobSpecBehaviorRetry{
+				Count: 4,
+			},
+		},
+		Schedule: models.JobSpecSchedule{
+			StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
+			EndDate:   &endDate,
+			Interval:  "0 2 * * *",
+		},
+		Task: models.JobSpecTask{
+			Unit:     execUnit,
+			Priority: 2000,
+		},
+		Hooks: []models.JobSpecHook{{Unit: hookUnit}, {Unit: hookUnit2}},
+		Labels: map[string]string{
+			"orchestrator": "optimus",
+		},
+	}
+
+	t.Run("Compile", func(t *testing.T) {
+		t.Run("should compile cron job of job", func(t *testing.T) {
+			scheduler := NewScheduler(nil)
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://optimus.example.io",
+			)
+			compiledJob, err := com.Compile(namespaceSpec, spec)
+			assert.Nil(t, err)
+			assert.Equal(t, string(CompiledTemplate), string(compiledJob.Contents))
+
+			var manifest struct {
+				Metadata struct {
+					Name string `yaml:"name"`
+				} `yaml:"metadata"`
+			}
+			assert.Nil(t, yaml.Unmarshal(compiledJob.Contents, &manifest))
+			assert.Equal(t, cronJobName(spec.Name), manifest.Metadata.Name)
+		})
+	})
+}
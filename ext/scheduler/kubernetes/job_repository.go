@@ -0,0 +1,154 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	errEmptyJobName = errors.New("job name cannot be an empty string")
+)
+
+// JobRepository applies compiled jobs as cron jobs to the cluster of a
+// project, cron jobs are owned by optimus field manager
+type JobRepository struct {
+	client *client
+}
+
+func NewJobRepository(httpClient HttpClient, proj models.ProjectSpec) (*JobRepository, error) {
+	c, err := newClient(httpClient, proj)
+	if err != nil {
+		return nil, err
+	}
+	return &JobRepository{client: c}, nil
+}
+
+// Save creates or updates cron job of the compiled job using server side
+// apply, fields of cron job not in compiled job are left untouched
+func (repo *JobRepository) Save(ctx context.Context, j models.Job) error {
+	if strings.TrimSpace(j.Name) == "" {
+		return errEmptyJobName
+	}
+	query := url.Values{
+		"fieldManager": []string{fieldManager},
+		"force":        []string{"true"},
+	}
+	applyURL := repo.client.resourceURL(resourceCronJobs, cronJobName(j.Name), query)
+	if err := repo.client.do(ctx, http.MethodPatch, applyURL, contentTypeApplyPatch, j.Contents, nil); err != nil {
+		return errors.Wrapf(err, "failed to apply cron job of %s", j.Name)
+	}
+
+	// cron jobs can't end by themselves, the ones past end date of job are
+	// suspended instead, suspension outlives later deploys like a pause
+	var compiled struct {
+		Metadata struct {
+			Annotations map[string]string `yaml:"annotations"`
+		} `yaml:"metadata"`
+	}
+	if err := yaml.Unmarshal(j.Contents, &compiled); err != nil {
+		return errors.Wrapf(err, "failed to read compiled cron job of %s", j.Name)
+	}
+	endDate, ok := compiled.Metadata.Annotations[annotationEndDate]
+	if !ok {
+		return nil
+	}
+	end, err := time.Parse(time.RFC3339, endDate)
+	if err != nil {
+		return errors.Wrapf(err, "invalid end date of %s", j.Name)
+	}
+	if !end.Before(time.Now()) {
+		return nil
+	}
+	body := []byte(`{"spec":{"suspend":true}}`)
+	if err := repo.client.do(ctx, http.MethodPatch, repo.client.resourceURL(resourceCronJobs, cronJobName(j.Name), nil),
+		contentTypeMergePatch, body, nil); err != nil {
+		return errors.Wrapf(err, "failed to suspend cron job of %s past its end date", j.Name)
+	}
+	return nil
+}
+
+// GetByName returns cron job of job as stored in cluster
+func (repo *JobRepository) GetByName(ctx context.Context, jobName string) (models.Job, error) {
+	if strings.TrimSpace(jobName) == "" {
+		return models.Job{}, errEmptyJobName
+	}
+	var contents json.RawMessage
+	if err := repo.client.do(ctx, http.MethodGet, repo.client.resourceURL(resourceCronJobs, cronJobName(jobName), nil), "", nil, &contents); err != nil {
+		if isNotFound(err) {
+			return models.Job{}, errors.Wrap(models.ErrNoSuchJob, jobName)
+		}
+		return models.Job{}, err
+	}
+	var cj cronJob
+	if err := json.Unmarshal(contents, &cj); err != nil {
+		return models.Job{}, errors.Wrapf(err, "json error: %s", string(contents))
+	}
+	return models.Job{
+		Name:        jobName,
+		Contents:    contents,
+		NamespaceID: cj.Metadata.Annotations[annotationNamespaceID],
+	}, nil
+}
+
+func (repo *JobRepository) GetAll(ctx context.Context) ([]models.Job, error) {
+	var jobs []models.Job
+	err := repo.listCronJobs(ctx, func(cj cronJob, contents json.RawMessage) {
+		jobs = append(jobs, models.Job{
+			Name:        cj.Metadata.Annotations[annotationJobName],
+			Contents:    contents,
+			NamespaceID: cj.Metadata.Annotations[annotationNamespaceID],
+		})
+	})
+	return jobs, err
+}
+
+// ListNames returns names of jobs of namespace having a cron job
+func (repo *JobRepository) ListNames(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	var jobNames []string
+	err := repo.listCronJobs(ctx, func(cj cronJob, _ json.RawMessage) {
+		if cj.Metadata.Annotations[annotationNamespaceID] == namespace.ID.String() {
+			jobNames = append(jobNames, cj.Metadata.Annotations[annotationJobName])
+		}
+	})
+	return jobNames, err
+}
+
+// Delete removes cron job of job along with its runs
+func (repo *JobRepository) Delete(ctx context.Context, namespace models.NamespaceSpec, jobName string) error {
+	if strings.TrimSpace(jobName) == "" {
+		return errEmptyJobName
+	}
+	query := url.Values{"propagationPolicy": []string{"Background"}}
+	if err := repo.client.do(ctx, http.MethodDelete, repo.client.resourceURL(resourceCronJobs, cronJobName(jobName), query), "", nil, nil); err != nil {
+		if isNotFound(err) {
+			return errors.Wrap(models.ErrNoSuchJob, jobName)
+		}
+		return err
+	}
+	return nil
+}
+
+// listCronJobs calls each with every cron job applied by optimus
+func (repo *JobRepository) listCronJobs(ctx context.Context, each func(cronJob, json.RawMessage)) error {
+	labelSelector := fmt.Sprintf("%s=%s", labelManagedBy, managedByOptimus)
+	return repo.client.list(ctx, resourceCronJobs, labelSelector, listPageSize, func(item json.RawMessage) error {
+		var cj cronJob
+		if err := json.Unmarshal(item, &cj); err != nil {
+			return errors.Wrapf(err, "json error: %s", string(item))
+		}
+		if cj.Metadata.Annotations[annotationJobName] == "" {
+			return nil
+		}
+		each(cj, item)
+		return nil
+	})
+}
@@ -0,0 +1,359 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+
+	_ "embed"
+)
+
+//go:embed resources/cronjob.yaml
+var resCronJob []byte
+
+const (
+	SchedulerName = "kubernetes"
+
+	labelManagedBy        = "app.kubernetes.io/managed-by"
+	labelCronJob          = "optimus.odpf.io/cronjob"
+	annotationJobName     = "optimus.odpf.io/job-name"
+	annotationNamespaceID = "optimus.odpf.io/namespace-id"
+	annotationEndDate     = "optimus.odpf.io/end-date"
+
+	// set by cron job controller on jobs it creates
+	annotationScheduledAt = "batch.kubernetes.io/cronjob-scheduled-timestamp"
+
+	managedByOptimus     = "optimus"
+	fieldManager         = "optimus"
+	maxCronJobNameLength = 52
+)
+
+// cronJobName is the kubernetes object name of the cron job of a job, it
+// must match the name used in cron job template
+func cronJobName(jobName string) string {
+	name := strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(jobName))
+	if len(name) > maxCronJobNameLength {
+		name = name[:maxCronJobNameLength]
+	}
+	return strings.TrimSuffix(name, "-")
+}
+
+type objectMeta struct {
+	Name              string            `json:"name"`
+	UID               string            `json:"uid"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Annotations       map[string]string `json:"annotations,omitempty"`
+	CreationTimestamp time.Time         `json:"creationTimestamp"`
+}
+
+type cronJob struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		Suspend     bool `json:"suspend"`
+		JobTemplate struct {
+			Metadata objectMeta      `json:"metadata"`
+			Spec     json.RawMessage `json:"spec"`
+		} `json:"jobTemplate"`
+	} `json:"spec"`
+}
+
+type jobCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+type cronJobRun struct {
+	Metadata objectMeta `json:"metadata"`
+	Status   struct {
+		StartTime      *time.Time     `json:"startTime"`
+		CompletionTime *time.Time     `json:"completionTime"`
+		Conditions     []jobCondition `json:"conditions"`
+	} `json:"status"`
+}
+
+// scheduledAt of the run, creation time truncated to cron granularity is
+// used if cron job controller didn't annotate it
+func (r cronJobRun) scheduledAt() time.Time {
+	if scheduledAt, err := time.Parse(time.RFC3339, r.Metadata.Annotations[annotationScheduledAt]); err == nil {
+		return scheduledAt.UTC()
+	}
+	return r.Metadata.CreationTimestamp.UTC().Truncate(time.Minute)
+}
+
+func (r cronJobRun) finished() bool {
+	state := r.toJobStatus().State
+	return state == models.JobStatusStateSuccess || state == models.JobStatusStateFailed
+}
+
+func (r cronJobRun) toJobStatus() models.JobStatus {
+	status := models.JobStatus{
+		ScheduledAt: r.scheduledAt(),
+		State:       models.JobStatusStateRunning,
+	}
+	if r.Status.StartTime != nil {
+		status.StartedAt = *r.Status.StartTime
+	}
+	for _, condition := range r.Status.Conditions {
+		if condition.Status != "True" {
+			continue
+		}
+		switch condition.Type {
+		case "Complete":
+			status.State = models.JobStatusStateSuccess
+			status.EndedAt = condition.LastTransitionTime
+		case "Failed":
+			status.State = models.JobStatusStateFailed
+			status.EndedAt = condition.LastTransitionTime
+		}
+	}
+	if r.Status.CompletionTime != nil {
+		status.EndedAt = *r.Status.CompletionTime
+	}
+	return status
+}
+
+type scheduler struct {
+	httpClient HttpClient
+}
+
+// NewScheduler creates a scheduler running jobs as kubernetes cron jobs,
+// compiled jobs are applied to the cluster by the repository returned
+// from NewJobRepository instead of being read from an object store
+func NewScheduler(httpClient HttpClient) *scheduler {
+	return &scheduler{
+		httpClient: httpClient,
+	}
+}
+
+func (s *scheduler) GetName() string {
+	return SchedulerName
+}
+
+func (s *scheduler) GetJobsDir() string {
+	return "cronjobs"
+}
+
+func (s *scheduler) GetJobsExtension() string {
+	return ".yaml"
+}
+
+func (s *scheduler) GetTemplate() []byte {
+	return resCronJob
+}
+
+func (s *scheduler) StoresCompiledJobs() bool {
+	return true
+}
+
+// NewJobRepository returns repository applying compiled jobs of project
+// to its cluster
+func (s *scheduler) NewJobRepository(proj models.ProjectSpec) (store.JobRepository, error) {
+	return NewJobRepository(s.httpClient, proj)
+}
+
+// Bootstrap has nothing to prepare, cron jobs carry everything needed to
+// run a job
+func (s *scheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec) error {
+	return nil
+}
+
+// GetJobStatus filters runs of cron job kept by its history limits
+func (s *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	filter models.JobStatusFilter) ([]models.JobStatus, error) {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.listRuns(ctx, c, jobName, listPageSize)
+	if err != nil {
+		return nil, err
+	}
+	var jobStatus []models.JobStatus
+	for _, run := range runs {
+		jobStatus = append(jobStatus, run.toJobStatus())
+	}
+	return filter.Apply(jobStatus), nil
+}
+
+func (s *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time,
+	endDate time.Time, batchSize int) ([]models.JobStatus, error) {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.listRuns(ctx, c, jobName, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	var jobStatus []models.JobStatus
+	for _, run := range runs {
+		if scheduledAt := run.scheduledAt(); !scheduledAt.Before(startDate) && !scheduledAt.After(endDate) {
+			jobStatus = append(jobStatus, run.toJobStatus())
+		}
+	}
+	return jobStatus, nil
+}
+
+// Clear runs finished runs of job scheduled between start and end date
+// again, a run is recreated from the current job template of cron job.
+// Cron jobs don't keep runs which were never created or are cleaned up
+// by history limits, those are not run again
+func (s *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate, endDate time.Time) error {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return err
+	}
+	var cj cronJob
+	if err := c.do(ctx, http.MethodGet, c.resourceURL(resourceCronJobs, cronJobName(jobName), nil), "", nil, &cj); err != nil {
+		return errors.Wrapf(err, "failed to fetch cron job of %s", jobName)
+	}
+	runs, err := s.listRuns(ctx, c, jobName, listPageSize)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		scheduledAt := run.scheduledAt()
+		if scheduledAt.Before(startDate) || scheduledAt.After(endDate) || !run.finished() {
+			continue
+		}
+		if err := s.rerun(ctx, c, cj, run); err != nil {
+			return errors.Wrapf(err, "failed to clear run of %s scheduled at %s", jobName, scheduledAt.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// RunJob creates a run of cron job scheduled at the provided time, a
+// finished run of that time is replaced by a new one
+func (s *scheduler) RunJob(ctx context.Context, projSpec models.ProjectSpec, jobName string, scheduledAt time.Time) error {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return err
+	}
+	var cj cronJob
+	if err := c.do(ctx, http.MethodGet, c.resourceURL(resourceCronJobs, cronJobName(jobName), nil), "", nil, &cj); err != nil {
+		return errors.Wrapf(err, "failed to fetch cron job of %s", jobName)
+	}
+	runs, err := s.listRuns(ctx, c, jobName, listPageSize)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		if !run.scheduledAt().Equal(scheduledAt.UTC()) {
+			continue
+		}
+		if !run.finished() {
+			return errors.Errorf("run of %s scheduled at %s is still running", jobName, scheduledAt.UTC().Format(time.RFC3339))
+		}
+		return s.rerun(ctx, c, cj, run)
+	}
+	return s.createRun(ctx, c, cj, scheduledAt)
+}
+
+// rerun replaces run with a new one owned by cron job, scheduled time of
+// run is carried over
+func (s *scheduler) rerun(ctx context.Context, c *client, cj cronJob, run cronJobRun) error {
+	deleteQuery := url.Values{"propagationPolicy": []string{"Background"}}
+	if err := c.do(ctx, http.MethodDelete, c.resourceURL(resourceJobs, run.Metadata.Name, deleteQuery), "", nil, nil); err != nil && !isNotFound(err) {
+		return err
+	}
+	return s.createRun(ctx, c, cj, run.scheduledAt())
+}
+
+// createRun creates a run from the current job template of cron job
+// annotated with its scheduled time
+func (s *scheduler) createRun(ctx context.Context, c *client, cj cronJob, scheduledAt time.Time) error {
+	labels := map[string]string{}
+	for key, value := range cj.Spec.JobTemplate.Metadata.Labels {
+		labels[key] = value
+	}
+	labels[labelCronJob] = cj.Metadata.Name
+	newRun := map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata": map[string]interface{}{
+			"generateName": fmt.Sprintf("%s-", cj.Metadata.Name),
+			"labels":       labels,
+			"annotations": map[string]string{
+				annotationScheduledAt: scheduledAt.UTC().Format(time.RFC3339),
+			},
+			"ownerReferences": []map[string]interface{}{
+				{
+					"apiVersion":         "batch/v1",
+					"kind":               "CronJob",
+					"name":               cj.Metadata.Name,
+					"uid":                cj.Metadata.UID,
+					"controller":         true,
+					"blockOwnerDeletion": true,
+				},
+			},
+		},
+		"spec": cj.Spec.JobTemplate.Spec,
+	}
+	body, err := json.Marshal(newRun)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPost, c.resourceURL(resourceJobs, "", nil), contentTypeJSON, body, nil)
+}
+
+// listRuns returns runs of job sorted by their scheduled time
+func (s *scheduler) listRuns(ctx context.Context, c *client, jobName string, pageSize int) ([]cronJobRun, error) {
+	if pageSize <= 0 {
+		pageSize = listPageSize
+	}
+	var runs []cronJobRun
+	labelSelector := fmt.Sprintf("%s=%s", labelCronJob, cronJobName(jobName))
+	if err := c.list(ctx, resourceJobs, labelSelector, pageSize, func(item json.RawMessage) error {
+		var run cronJobRun
+		if err := json.Unmarshal(item, &run); err != nil {
+			return errors.Wrapf(err, "json error: %s", string(item))
+		}
+		runs = append(runs, run)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch runs of %s", jobName)
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].scheduledAt().Before(runs[j].scheduledAt())
+	})
+	return runs, nil
+}
+
+// IsJobPaused reports if cron job of job is suspended
+func (s *scheduler) IsJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string) (bool, error) {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return false, err
+	}
+	var cj cronJob
+	if err := c.do(ctx, http.MethodGet, c.resourceURL(resourceCronJobs, cronJobName(jobName), nil), "", nil, &cj); err != nil {
+		if isNotFound(err) {
+			// job is not deployed yet
+			return false, nil
+		}
+		return false, err
+	}
+	return cj.Spec.Suspend, nil
+}
+
+// SetJobPaused suspends or resumes cron job of job, suspension is not part
+// of the applied cron job so it is kept across deploys
+func (s *scheduler) SetJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string, paused bool) error {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return err
+	}
+	body := []byte(fmt.Sprintf(`{"spec":{"suspend":%t}}`, paused))
+	return c.do(ctx, http.MethodPatch, c.resourceURL(resourceCronJobs, cronJobName(jobName), nil), contentTypeMergePatch, body, nil)
+}
@@ -0,0 +1,503 @@
+package kubernetes_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/ext/scheduler/kubernetes"
+	"github.com/odpf/optimus/models"
+)
+
+type MockHttpClient struct {
+	DoFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *MockHttpClient) Do(req *http.Request) (*http.Response, error) {
+	if m.DoFunc != nil {
+		return m.DoFunc(req)
+	}
+	// default if none provided
+	return &http.Response{}, nil
+}
+
+func jsonResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+func TestKubernetes(t *testing.T) {
+	ctx := context.Background()
+	projSpec := models.ProjectSpec{
+		Name: "proj",
+		Config: map[string]string{
+			models.ProjectSchedulerHost:    "https://kube.example.io/",
+			kubernetes.ProjectNamespaceKey: "optimus-jobs",
+		},
+		Secret: []models.ProjectSecretItem{
+			{
+				Name:  models.ProjectSchedulerAuth,
+				Value: "service-account-token",
+			},
+		},
+	}
+	runs := `{
+		"items": [
+			{
+				"metadata": {
+					"name": "foo-daily-28000000",
+					"annotations": {"batch.kubernetes.io/cronjob-scheduled-timestamp": "2021-04-02T02:00:00Z"},
+					"creationTimestamp": "2021-04-02T02:00:01Z"
+				},
+				"status": {
+					"startTime": "2021-04-02T02:00:01Z",
+					"conditions": [{"type": "Failed", "status": "True", "lastTransitionTime": "2021-04-02T02:10:00Z"}]
+				}
+			},
+			{
+				"metadata": {"name": "foo-daily-27990000", "creationTimestamp": "2021-04-01T02:00:04Z"},
+				"status": {
+					"startTime": "2021-04-01T02:00:04Z",
+					"completionTime": "2021-04-01T02:05:00Z",
+					"conditions": [{"type": "Complete", "status": "True", "lastTransitionTime": "2021-04-01T02:05:00Z"}]
+				}
+			},
+			{
+				"metadata": {
+					"name": "foo-daily-28010000",
+					"annotations": {"batch.kubernetes.io/cronjob-scheduled-timestamp": "2021-04-03T02:00:00Z"},
+					"creationTimestamp": "2021-04-03T02:00:01Z"
+				},
+				"status": {"startTime": "2021-04-03T02:00:01Z"}
+			}
+		],
+		"metadata": {}
+	}`
+
+	t.Run("GetJobStatus", func(t *testing.T) {
+		t.Run("should return runs of cron job latest first", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodGet, req.Method)
+					assert.Equal(t, "https://kube.example.io/apis/batch/v1/namespaces/optimus-jobs/jobs?labelSelector=optimus.odpf.io%2Fcronjob%3Dfoo-daily&limit=500", req.URL.String())
+					assert.Equal(t, "Bearer service-account-token", req.Header.Get("Authorization"))
+					return jsonResponse(http.StatusOK, runs), nil
+				},
+			}
+
+			status, err := kubernetes.NewScheduler(client).GetJobStatus(ctx, projSpec, "foo_daily", models.JobStatusFilter{})
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobStatus{
+				{
+					ScheduledAt: time.Date(2021, 4, 3, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateRunning,
+					StartedAt:   time.Date(2021, 4, 3, 2, 0, 1, 0, time.UTC),
+				},
+				{
+					ScheduledAt: time.Date(2021, 4, 2, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateFailed,
+					StartedAt:   time.Date(2021, 4, 2, 2, 0, 1, 0, time.UTC),
+					EndedAt:     time.Date(2021, 4, 2, 2, 10, 0, 0, time.UTC),
+				},
+				{
+					ScheduledAt: time.Date(2021, 4, 1, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateSuccess,
+					StartedAt:   time.Date(2021, 4, 1, 2, 0, 4, 0, time.UTC),
+					EndedAt:     time.Date(2021, 4, 1, 2, 5, 0, 0, time.UTC),
+				},
+			}, status)
+		})
+		t.Run("should return runs matching filter", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(http.StatusOK, runs), nil
+				},
+			}
+
+			status, err := kubernetes.NewScheduler(client).GetJobStatus(ctx, projSpec, "foo_daily", models.JobStatusFilter{
+				EndDate: time.Date(2021, 4, 2, 2, 0, 0, 0, time.UTC),
+				Limit:   1,
+			})
+			assert.Nil(t, err)
+			assert.Len(t, status, 1)
+			assert.Equal(t, time.Date(2021, 4, 2, 2, 0, 0, 0, time.UTC), status[0].ScheduledAt)
+		})
+		t.Run("should fail if scheduler auth secret is not set", func(t *testing.T) {
+			_, err := kubernetes.NewScheduler(&MockHttpClient{}).GetJobStatus(ctx, models.ProjectSpec{
+				Name:   "proj",
+				Config: projSpec.Config,
+			}, "foo_daily", models.JobStatusFilter{})
+			assert.Equal(t, "SCHEDULER_AUTH secret not configured for project proj", err.Error())
+		})
+	})
+	t.Run("GetDagRunStatus", func(t *testing.T) {
+		t.Run("should return runs scheduled within dates", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "10", req.URL.Query().Get("limit"))
+					return jsonResponse(http.StatusOK, runs), nil
+				},
+			}
+
+			status, err := kubernetes.NewScheduler(client).GetDagRunStatus(ctx, projSpec, "foo_daily",
+				time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 3, 0, 0, 0, 0, time.UTC), 10)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(status))
+			assert.Equal(t, models.JobStatusStateFailed, status[0].State)
+		})
+	})
+	t.Run("Clear", func(t *testing.T) {
+		t.Run("should recreate finished runs scheduled within dates from cron job template", func(t *testing.T) {
+			var requests []string
+			var createdRun string
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req.Method+" "+req.URL.Path)
+					switch {
+					case req.Method == http.MethodGet && req.URL.Path == "/apis/batch/v1/namespaces/optimus-jobs/cronjobs/foo-daily":
+						return jsonResponse(http.StatusOK, `{
+							"metadata": {"name": "foo-daily", "uid": "cron-uid"},
+							"spec": {"jobTemplate": {
+								"metadata": {"labels": {"app.kubernetes.io/managed-by": "optimus"}},
+								"spec": {"backoffLimit": 3}
+							}}
+						}`), nil
+					case req.Method == http.MethodGet:
+						return jsonResponse(http.StatusOK, runs), nil
+					case req.Method == http.MethodPost:
+						body, _ := ioutil.ReadAll(req.Body)
+						createdRun = string(body)
+					}
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			err := kubernetes.NewScheduler(client).Clear(ctx, projSpec, "foo_daily",
+				time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 4, 0, 0, 0, 0, time.UTC))
+			assert.Nil(t, err)
+			assert.Equal(t, []string{
+				"GET /apis/batch/v1/namespaces/optimus-jobs/cronjobs/foo-daily",
+				"GET /apis/batch/v1/namespaces/optimus-jobs/jobs",
+				"DELETE /apis/batch/v1/namespaces/optimus-jobs/jobs/foo-daily-28000000",
+				"POST /apis/batch/v1/namespaces/optimus-jobs/jobs",
+			}, requests)
+			assert.JSONEq(t, `{
+				"apiVersion": "batch/v1",
+				"kind": "Job",
+				"metadata": {
+					"generateName": "foo-daily-",
+					"labels": {"app.kubernetes.io/managed-by": "optimus", "optimus.odpf.io/cronjob": "foo-daily"},
+					"annotations": {"batch.kubernetes.io/cronjob-scheduled-timestamp": "2021-04-02T02:00:00Z"},
+					"ownerReferences": [{
+						"apiVersion": "batch/v1", "kind": "CronJob", "name": "foo-daily", "uid": "cron-uid",
+						"controller": true, "blockOwnerDeletion": true
+					}]
+				},
+				"spec": {"backoffLimit": 3}
+			}`, createdRun)
+		})
+	})
+	t.Run("RunJob", func(t *testing.T) {
+		cronJobResponse := `{
+			"metadata": {"name": "foo-daily", "uid": "cron-uid"},
+			"spec": {"jobTemplate": {"spec": {"backoffLimit": 3}}}
+		}`
+		t.Run("should create run at scheduled time if cron job has none", func(t *testing.T) {
+			var requests []string
+			var createdRun string
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req.Method+" "+req.URL.Path)
+					switch {
+					case req.Method == http.MethodGet && req.URL.Path == "/apis/batch/v1/namespaces/optimus-jobs/cronjobs/foo-daily":
+						return jsonResponse(http.StatusOK, cronJobResponse), nil
+					case req.Method == http.MethodGet:
+						return jsonResponse(http.StatusOK, runs), nil
+					case req.Method == http.MethodPost:
+						body, _ := ioutil.ReadAll(req.Body)
+						createdRun = string(body)
+					}
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			err := kubernetes.NewScheduler(client).RunJob(ctx, projSpec, "foo_daily", time.Date(2021, 4, 4, 2, 0, 0, 0, time.UTC))
+			assert.Nil(t, err)
+			assert.Equal(t, []string{
+				"GET /apis/batch/v1/namespaces/optimus-jobs/cronjobs/foo-daily",
+				"GET /apis/batch/v1/namespaces/optimus-jobs/jobs",
+				"POST /apis/batch/v1/namespaces/optimus-jobs/jobs",
+			}, requests)
+			assert.Contains(t, createdRun, `"annotations":{"batch.kubernetes.io/cronjob-scheduled-timestamp":"2021-04-04T02:00:00Z"}`)
+		})
+		t.Run("should fail if run at scheduled time is still running", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path == "/apis/batch/v1/namespaces/optimus-jobs/cronjobs/foo-daily" {
+						return jsonResponse(http.StatusOK, cronJobResponse), nil
+					}
+					assert.Equal(t, http.MethodGet, req.Method)
+					return jsonResponse(http.StatusOK, runs), nil
+				},
+			}
+
+			err := kubernetes.NewScheduler(client).RunJob(ctx, projSpec, "foo_daily", time.Date(2021, 4, 3, 2, 0, 0, 0, time.UTC))
+			assert.Equal(t, "run of foo_daily scheduled at 2021-04-03T02:00:00Z is still running", err.Error())
+		})
+	})
+	t.Run("IsJobPaused", func(t *testing.T) {
+		t.Run("should return suspension of cron job", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "/apis/batch/v1/namespaces/optimus-jobs/cronjobs/foo-daily", req.URL.Path)
+					return jsonResponse(http.StatusOK, `{"metadata": {"name": "foo-daily"}, "spec": {"suspend": true}}`), nil
+				},
+			}
+			paused, err := kubernetes.NewScheduler(client).IsJobPaused(ctx, projSpec, "foo_daily")
+			assert.Nil(t, err)
+			assert.True(t, paused)
+		})
+		t.Run("should return not paused for jobs without cron job", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(http.StatusNotFound, `{"kind": "Status", "message": "cronjobs.batch \"foo-daily\" not found"}`), nil
+				},
+			}
+			paused, err := kubernetes.NewScheduler(client).IsJobPaused(ctx, projSpec, "foo_daily")
+			assert.Nil(t, err)
+			assert.False(t, paused)
+		})
+	})
+	t.Run("SetJobPaused", func(t *testing.T) {
+		t.Run("should suspend cron job", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodPatch, req.Method)
+					assert.Equal(t, "application/merge-patch+json", req.Header.Get("Content-Type"))
+					body, _ := ioutil.ReadAll(req.Body)
+					assert.Equal(t, `{"spec":{"suspend":true}}`, string(body))
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+			assert.Nil(t, kubernetes.NewScheduler(client).SetJobPaused(ctx, projSpec, "foo_daily", true))
+		})
+		t.Run("should return message of failed api call", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(http.StatusForbidden, `{"kind": "Status", "message": "cronjobs.batch is forbidden"}`), nil
+				},
+			}
+			err := kubernetes.NewScheduler(client).SetJobPaused(ctx, projSpec, "foo_daily", true)
+			assert.Contains(t, err.Error(), "failed with 403: cronjobs.batch is forbidden")
+		})
+	})
+	t.Run("MissingRequiredConfigs", func(t *testing.T) {
+		t.Run("should not require storage path for projects on kubernetes", func(t *testing.T) {
+			_ = models.SchedulerRegistry.Add(kubernetes.NewScheduler(nil))
+			spec := models.ProjectSpec{
+				Config:    map[string]string{models.ProjectSchedulerHost: "https://kube.example.io"},
+				Scheduler: models.ProjectSchedulerConfig{Type: kubernetes.SchedulerName},
+			}
+			assert.Empty(t, spec.MissingRequiredConfigs())
+		})
+	})
+	t.Run("GetJobRunLogs", func(t *testing.T) {
+		pods := `{
+			"items": [
+				{
+					"metadata": {"name": "foo-daily-28000000-b7x2q", "creationTimestamp": "2021-04-02T02:05:00Z"},
+					"spec": {"initContainers": [{"name": "hook-notify"}], "containers": [{"name": "task-bq2bq"}]},
+					"status": {
+						"initContainerStatuses": [{"name": "hook-notify", "state": {"terminated": {}}}],
+						"containerStatuses": [{"name": "task-bq2bq", "state": {"waiting": {}}}]
+					}
+				},
+				{
+					"metadata": {"name": "foo-daily-28000000-k2f9z", "creationTimestamp": "2021-04-02T02:00:01Z"},
+					"spec": {"initContainers": [{"name": "hook-notify"}], "containers": [{"name": "task-bq2bq"}]},
+					"status": {
+						"initContainerStatuses": [{"name": "hook-notify", "state": {"terminated": {}}}],
+						"containerStatuses": [{"name": "task-bq2bq", "state": {"terminated": {}}}]
+					}
+				}
+			]
+		}`
+		t.Run("should send logs of started containers of every pod of run", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					switch req.URL.Path {
+					case "/apis/batch/v1/namespaces/optimus-jobs/jobs":
+						return jsonResponse(http.StatusOK, runs), nil
+					case "/api/v1/namespaces/optimus-jobs/pods":
+						assert.Equal(t, "job-name=foo-daily-28000000", req.URL.Query().Get("labelSelector"))
+						return jsonResponse(http.StatusOK, pods), nil
+					}
+					assert.Equal(t, "log", req.URL.Path[len(req.URL.Path)-3:])
+					return jsonResponse(http.StatusOK, req.URL.Query().Get("container")+" logs of "+req.URL.Path), nil
+				},
+			}
+
+			var logs []models.JobRunLog
+			err := kubernetes.NewScheduler(client).GetJobRunLogs(ctx, projSpec, "foo_daily", models.JobRunLogRequest{
+				ScheduledAt: time.Date(2021, 4, 2, 2, 0, 0, 0, time.UTC),
+			}, func(log models.JobRunLog) error {
+				logs = append(logs, log)
+				return nil
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobRunLog{
+				{
+					TaskName:  "hook-notify",
+					TryNumber: 1,
+					Content:   []byte("hook-notify logs of /api/v1/namespaces/optimus-jobs/pods/foo-daily-28000000-k2f9z/log"),
+				},
+				{
+					TaskName:  "task-bq2bq",
+					TryNumber: 1,
+					Content:   []byte("task-bq2bq logs of /api/v1/namespaces/optimus-jobs/pods/foo-daily-28000000-k2f9z/log"),
+				},
+				{
+					TaskName:  "hook-notify",
+					TryNumber: 2,
+					Content:   []byte("hook-notify logs of /api/v1/namespaces/optimus-jobs/pods/foo-daily-28000000-b7x2q/log"),
+				},
+			}, logs)
+		})
+		t.Run("should fail if there is no run at scheduled time", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(http.StatusOK, runs), nil
+				},
+			}
+
+			err := kubernetes.NewScheduler(client).GetJobRunLogs(ctx, projSpec, "foo_daily", models.JobRunLogRequest{
+				ScheduledAt: time.Date(2021, 4, 5, 2, 0, 0, 0, time.UTC),
+			}, nil)
+			assert.True(t, errors.Is(err, models.ErrJobRunNotFound))
+		})
+	})
+}
+
+func TestJobRepository(t *testing.T) {
+	ctx := context.Background()
+	namespaceID := uuid.New()
+	projSpec := models.ProjectSpec{
+		Name: "proj",
+		Scheduler: models.ProjectSchedulerConfig{
+			Host: "https://kube.example.io",
+		},
+		Secret: []models.ProjectSecretItem{
+			{
+				Name:  models.ProjectSchedulerAuth,
+				Value: "service-account-token",
+			},
+		},
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("should apply compiled job as cron job", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodPatch, req.Method)
+					assert.Equal(t, "https://kube.example.io/apis/batch/v1/namespaces/default/cronjobs/foo-daily?fieldManager=optimus&force=true", req.URL.String())
+					assert.Equal(t, "application/apply-patch+yaml", req.Header.Get("Content-Type"))
+					body, _ := ioutil.ReadAll(req.Body)
+					assert.Equal(t, "kind: CronJob", string(body))
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+			repo, err := kubernetes.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Save(ctx, models.Job{Name: "foo_daily", Contents: []byte("kind: CronJob")}))
+		})
+		t.Run("should suspend cron job past end date of job", func(t *testing.T) {
+			contents := "kind: CronJob\nmetadata:\n  annotations:\n    optimus.odpf.io/end-date: \"2021-11-11T00:00:00Z\"\n"
+			var contentTypes []string
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					contentTypes = append(contentTypes, req.Header.Get("Content-Type"))
+					if req.Header.Get("Content-Type") == "application/merge-patch+json" {
+						assert.Equal(t, "https://kube.example.io/apis/batch/v1/namespaces/default/cronjobs/foo-daily", req.URL.String())
+						body, _ := ioutil.ReadAll(req.Body)
+						assert.Equal(t, `{"spec":{"suspend":true}}`, string(body))
+					}
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+			repo, err := kubernetes.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Save(ctx, models.Job{Name: "foo_daily", Contents: []byte(contents)}))
+			assert.Equal(t, []string{"application/apply-patch+yaml", "application/merge-patch+json"}, contentTypes)
+		})
+		t.Run("should not suspend cron job before end date of job", func(t *testing.T) {
+			contents := "kind: CronJob\nmetadata:\n  annotations:\n    optimus.odpf.io/end-date: \"2999-11-11T00:00:00Z\"\n"
+			calls := 0
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					calls++
+					assert.Equal(t, "application/apply-patch+yaml", req.Header.Get("Content-Type"))
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+			repo, err := kubernetes.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Save(ctx, models.Job{Name: "foo_daily", Contents: []byte(contents)}))
+			assert.Equal(t, 1, calls)
+		})
+	})
+	t.Run("ListNames", func(t *testing.T) {
+		t.Run("should return jobs of namespace across pages", func(t *testing.T) {
+			pages := []string{
+				`{"items": [
+					{"metadata": {"name": "foo-daily", "annotations": {"optimus.odpf.io/job-name": "foo_daily", "optimus.odpf.io/namespace-id": "` + namespaceID.String() + `"}}},
+					{"metadata": {"name": "bar", "annotations": {"optimus.odpf.io/job-name": "bar", "optimus.odpf.io/namespace-id": "other"}}}
+				], "metadata": {"continue": "next"}}`,
+				`{"items": [
+					{"metadata": {"name": "baz", "annotations": {"optimus.odpf.io/job-name": "baz", "optimus.odpf.io/namespace-id": "` + namespaceID.String() + `"}}}
+				], "metadata": {}}`,
+			}
+			var continueTokens []string
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "app.kubernetes.io/managed-by=optimus", req.URL.Query().Get("labelSelector"))
+					continueTokens = append(continueTokens, req.URL.Query().Get("continue"))
+					page := pages[0]
+					pages = pages[1:]
+					return jsonResponse(http.StatusOK, page), nil
+				},
+			}
+			repo, err := kubernetes.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+
+			jobNames, err := repo.ListNames(ctx, models.NamespaceSpec{ID: namespaceID})
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"foo_daily", "baz"}, jobNames)
+			assert.Equal(t, []string{"", "next"}, continueTokens)
+		})
+	})
+	t.Run("Delete", func(t *testing.T) {
+		t.Run("should return no such job if cron job doesn't exist", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodDelete, req.Method)
+					assert.Equal(t, "Background", req.URL.Query().Get("propagationPolicy"))
+					return jsonResponse(http.StatusNotFound, `{"kind": "Status", "message": "not found"}`), nil
+				},
+			}
+			repo, err := kubernetes.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+
+			err = repo.Delete(ctx, models.NamespaceSpec{ID: namespaceID}, "foo_daily")
+			assert.True(t, errors.Is(err, models.ErrNoSuchJob))
+		})
+	})
+}
@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+// set by job controller on pods it creates for a job
+const labelJobName = "job-name"
+
+type podContainer struct {
+	Name string `json:"name"`
+}
+
+type podContainerStatus struct {
+	Name  string `json:"name"`
+	State struct {
+		Waiting *struct{} `json:"waiting"`
+	} `json:"state"`
+}
+
+type pod struct {
+	Metadata objectMeta `json:"metadata"`
+	Spec     struct {
+		InitContainers []podContainer `json:"initContainers"`
+		Containers     []podContainer `json:"containers"`
+	} `json:"spec"`
+	Status struct {
+		InitContainerStatuses []podContainerStatus `json:"initContainerStatuses"`
+		ContainerStatuses     []podContainerStatus `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// startedContainers of pod in the order they run, hooks running as init
+// containers come before the task
+func (p pod) startedContainers() []string {
+	started := map[string]bool{}
+	for _, status := range append(p.Status.InitContainerStatuses, p.Status.ContainerStatuses...) {
+		// logs of a container are only available once it started
+		started[status.Name] = status.State.Waiting == nil
+	}
+	var names []string
+	for _, container := range append(p.Spec.InitContainers, p.Spec.Containers...) {
+		if started[container.Name] {
+			names = append(names, container.Name)
+		}
+	}
+	return names
+}
+
+// GetJobRunLogs sends logs of containers of the run scheduled at the
+// requested time, every pod created for the run is an attempt and every
+// container of a pod is a task. Logs are only available till pods are
+// cleaned up with the run
+func (s *scheduler) GetJobRunLogs(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	request models.JobRunLogRequest, send func(models.JobRunLog) error) error {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return err
+	}
+	runs, err := s.listRuns(ctx, c, jobName, listPageSize)
+	if err != nil {
+		return err
+	}
+	var run *cronJobRun
+	for i := range runs {
+		if runs[i].scheduledAt().Equal(request.ScheduledAt.UTC()) {
+			run = &runs[i]
+			break
+		}
+	}
+	if run == nil {
+		return errors.Wrapf(models.ErrJobRunNotFound, "%s scheduled at %s", jobName, request.ScheduledAt.UTC().Format(time.RFC3339))
+	}
+
+	var pods struct {
+		Items []pod `json:"items"`
+	}
+	query := url.Values{"labelSelector": []string{fmt.Sprintf("%s=%s", labelJobName, run.Metadata.Name)}}
+	if err := c.do(ctx, http.MethodGet, c.podURL("", "", query), "", nil, &pods); err != nil {
+		return errors.Wrapf(err, "failed to fetch pods of run %s", run.Metadata.Name)
+	}
+	sort.SliceStable(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Metadata.CreationTimestamp.Before(pods.Items[j].Metadata.CreationTimestamp)
+	})
+
+	for i, p := range pods.Items {
+		tryNumber := i + 1
+		for _, container := range p.startedContainers() {
+			if !request.Matches(container, tryNumber) {
+				continue
+			}
+			logs, err := c.stream(ctx, c.podURL(p.Metadata.Name, "log", url.Values{"container": []string{container}}))
+			if err != nil {
+				return errors.Wrapf(err, "failed to fetch logs of pod %s", p.Metadata.Name)
+			}
+			err = models.SendJobRunLog(logs, container, tryNumber, send)
+			logs.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
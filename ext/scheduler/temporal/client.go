@@ -0,0 +1,171 @@
+package temporal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProjectNamespaceKey is the project config holding temporal namespace
+	// schedules of the project are registered in
+	ProjectNamespaceKey = "TEMPORAL_NAMESPACE"
+	defaultNamespace    = "default"
+
+	// identity reported to temporal for changes made by optimus
+	identity     = "optimus"
+	listPageSize = 500
+)
+
+type HttpClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// apiError is a failed response of temporal frontend http api
+type apiError struct {
+	method     string
+	url        string
+	statusCode int
+	message    string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("temporal api %s %s failed with %d: %s", e.method, e.url, e.statusCode, e.message)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.statusCode == statusCode
+}
+
+func isNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// payload is a temporal payload holding json encoded data
+type payload struct {
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Data     string            `json:"data"`
+}
+
+// decode unmarshals data of payload in out
+func (p payload) decode(out interface{}) error {
+	data, err := base64.StdEncoding.DecodeString(p.Data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// String returns data of payload holding a json string, empty if it holds
+// anything else
+func (p payload) String() string {
+	var s string
+	if err := p.decode(&s); err != nil {
+		return ""
+	}
+	return s
+}
+
+// client calls http api of the temporal frontend jobs of a project are
+// scheduled on, the frontend is reached at scheduler host of project using
+// its scheduler auth secret as bearer token
+type client struct {
+	httpClient HttpClient
+	host       string
+	token      string
+	namespace  string
+}
+
+func newClient(httpClient HttpClient, proj models.ProjectSpec) (*client, error) {
+	host, ok := proj.SchedulerHost()
+	if !ok || strings.TrimSpace(host) == "" {
+		return nil, errors.Errorf("scheduler host not set for %s", proj.Name)
+	}
+	token, ok := proj.Secret.GetByName(proj.SchedulerAuthSecret())
+	if !ok {
+		return nil, errors.Errorf("%s secret not configured for project %s", proj.SchedulerAuthSecret(), proj.Name)
+	}
+	namespace := strings.TrimSpace(proj.Config[ProjectNamespaceKey])
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return &client{
+		httpClient: httpClient,
+		host:       strings.TrimRight(host, "/"),
+		token:      strings.TrimSpace(token),
+		namespace:  namespace,
+	}, nil
+}
+
+// namespaceURL of a path under temporal namespace of project, path is
+// joined after escaping each of its segments
+func (c *client) namespaceURL(query url.Values, segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	namespaceURL := fmt.Sprintf("%s/api/v1/namespaces/%s/%s", c.host, url.PathEscape(c.namespace), strings.Join(escaped, "/"))
+	if len(query) > 0 {
+		namespaceURL = fmt.Sprintf("%s?%s", namespaceURL, query.Encode())
+	}
+	return namespaceURL
+}
+
+// do calls the api with body encoded as json, response is decoded in out
+// if it is set
+func (c *client) do(ctx context.Context, method, requestURL string, body interface{}, out interface{}) error {
+	var requestBody []byte
+	if body != nil {
+		var err error
+		if requestBody, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build http request for %s", requestURL)
+	}
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call temporal api %s %s", method, requestURL)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read temporal api response")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// failures are reported as a grpc status
+		var status struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(respBody, &status); err != nil || status.Message == "" {
+			status.Message = string(respBody)
+		}
+		return &apiError{method: method, url: requestURL, statusCode: resp.StatusCode, message: status.Message}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return errors.Wrapf(err, "json error: %s", string(respBody))
+	}
+	return nil
+}
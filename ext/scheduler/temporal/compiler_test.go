@@ -0,0 +1,108 @@
+package temporal
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+)
+
+//go:embed resources/expected_compiled_template.json
+var CompiledTemplate []byte
+
+func TestCompiler(t *testing.T) {
+	ctx := context.Background()
+	execUnit := new(mock.TaskPlugin)
+	execUnit.On("GetTaskSchema", ctx, models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+		Name:       "bq",
+		Image:      "example.io/namespace/image:latest",
+		SecretPath: "/opt/optimus/secrets/auth.json",
+	}, nil)
+
+	hookUnit := new(mock.HookPlugin)
+	hookUnit.On("GetHookSchema", ctx, models.GetHookSchemaRequest{}).Return(models.GetHookSchemaResponse{
+		Name:  "predator",
+		Type:  models.HookTypePost,
+		Image: "example.io/namespace/predator-image:latest",
+	}, nil)
+
+	namespaceSpec := models.NamespaceSpec{
+		ID:   uuid.MustParse("0b1ff6a8-4f5b-4cbf-a9cd-4f0a3ec1e6a4"),
+		Name: "bar-namespace",
+		ProjectSpec: models.ProjectSpec{
+			Name: "foo-project",
+			Config: map[string]string{
+				"TEMPORAL_TASK_QUEUE": "analytics",
+			},
+		},
+	}
+	scheduleEndDate := time.Date(2022, 11, 11, 0, 0, 0, 0, time.UTC)
+	spec := models.JobSpec{
+		Name:  "foo",
+		Owner: "mee@mee",
+		Behavior: models.JobSpecBehavior{
+			CatchUp:       true,
+			DependsOnPast: true,
+			Retry: models.JobSpecBehaviorRetry{
+				Count:              2,
+				Delay:              time.Minute,
+				ExponentialBackoff: true,
+			},
+		},
+		Schedule: models.JobSpecSchedule{
+			StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
+			EndDate:   &scheduleEndDate,
+			Interval:  "0 2 * * *",
+			Timezone:  "Asia/Jakarta",
+		},
+		Task: models.JobSpecTask{
+			Unit:     execUnit,
+			Priority: 9990,
+		},
+		Hooks: []models.JobSpecHook{{Unit: hookUnit}},
+		Labels: map[string]string{
+			"orchestrator": "optimus",
+		},
+	}
+
+	t.Run("Compile", func(t *testing.T) {
+		t.Run("should compile schedule of job", func(t *testing.T) {
+			scheduler := NewScheduler(nil)
+			com := job.NewCompiler(
+				scheduler.GetTemplate(),
+				"http://optimus.example.io",
+			)
+			compiledJob, err := com.Compile(namespaceSpec, spec)
+			assert.Nil(t, err)
+			assert.Equal(t, string(CompiledTemplate), string(compiledJob.Contents))
+
+			var compiled struct {
+				Schedule struct {
+					Action struct {
+						StartWorkflow struct {
+							WorkflowID string `json:"workflowId"`
+							Input      struct {
+								Payloads []payload `json:"payloads"`
+							} `json:"input"`
+						} `json:"startWorkflow"`
+					} `json:"action"`
+				} `json:"schedule"`
+			}
+			assert.Nil(t, json.Unmarshal(compiledJob.Contents, &compiled))
+			assert.Equal(t, scheduleID(namespaceSpec.ProjectSpec, spec.Name), compiled.Schedule.Action.StartWorkflow.WorkflowID)
+
+			var input map[string]interface{}
+			assert.Nil(t, compiled.Schedule.Action.StartWorkflow.Input.Payloads[0].decode(&input))
+			assert.Equal(t, "foo", input["job"])
+			assert.Equal(t, "example.io/namespace/image:latest", input["task"].(map[string]interface{})["image"])
+		})
+	})
+}
@@ -0,0 +1,187 @@
+package temporal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	memoJobName     = "optimusJobName"
+	memoProject     = "optimusProject"
+	memoNamespaceID = "optimusNamespaceId"
+)
+
+var (
+	errEmptyJobName = errors.New("job name cannot be an empty string")
+)
+
+type memo struct {
+	Fields map[string]payload `json:"fields"`
+}
+
+// JobRepository registers compiled jobs of a project as temporal schedules
+type JobRepository struct {
+	client  *client
+	project models.ProjectSpec
+}
+
+func NewJobRepository(httpClient HttpClient, proj models.ProjectSpec) (*JobRepository, error) {
+	c, err := newClient(httpClient, proj)
+	if err != nil {
+		return nil, err
+	}
+	return &JobRepository{client: c, project: proj}, nil
+}
+
+// Save creates schedule of the compiled job or updates it if it already
+// exists, paused state of an existing schedule is kept
+func (repo *JobRepository) Save(ctx context.Context, j models.Job) error {
+	if strings.TrimSpace(j.Name) == "" {
+		return errEmptyJobName
+	}
+	var compiled map[string]interface{}
+	if err := json.Unmarshal(j.Contents, &compiled); err != nil {
+		return errors.Wrapf(err, "invalid compiled schedule of %s", j.Name)
+	}
+	scheduleURL := repo.client.namespaceURL(nil, "schedules", scheduleID(repo.project, j.Name))
+
+	compiled["identity"] = identity
+	compiled["requestId"] = uuid.New().String()
+	err := repo.client.do(ctx, http.MethodPost, scheduleURL, compiled, nil)
+	if err == nil {
+		return nil
+	}
+	if !hasStatus(err, http.StatusConflict) {
+		return errors.Wrapf(err, "failed to create temporal schedule of %s", j.Name)
+	}
+
+	var existing struct {
+		Schedule struct {
+			State json.RawMessage `json:"state"`
+		} `json:"schedule"`
+		ConflictToken string `json:"conflictToken"`
+	}
+	if err := repo.client.do(ctx, http.MethodGet, scheduleURL, nil, &existing); err != nil {
+		return errors.Wrapf(err, "failed to fetch temporal schedule of %s", j.Name)
+	}
+	schedule, ok := compiled["schedule"].(map[string]interface{})
+	if !ok {
+		return errors.Errorf("invalid compiled schedule of %s: schedule not found", j.Name)
+	}
+	if len(existing.Schedule.State) > 0 {
+		schedule["state"] = existing.Schedule.State
+	}
+	update := map[string]interface{}{
+		"schedule":      schedule,
+		"conflictToken": existing.ConflictToken,
+		"identity":      identity,
+		"requestId":     uuid.New().String(),
+	}
+	if err := repo.client.do(ctx, http.MethodPost, repo.client.namespaceURL(nil, "schedules", scheduleID(repo.project, j.Name), "update"), update, nil); err != nil {
+		return errors.Wrapf(err, "failed to update temporal schedule of %s", j.Name)
+	}
+	return nil
+}
+
+// GetByName returns schedule of job as described by temporal
+func (repo *JobRepository) GetByName(ctx context.Context, jobName string) (models.Job, error) {
+	if strings.TrimSpace(jobName) == "" {
+		return models.Job{}, errEmptyJobName
+	}
+	var contents json.RawMessage
+	if err := repo.client.do(ctx, http.MethodGet, repo.client.namespaceURL(nil, "schedules", scheduleID(repo.project, jobName)), nil, &contents); err != nil {
+		if isNotFound(err) {
+			return models.Job{}, errors.Wrap(models.ErrNoSuchJob, jobName)
+		}
+		return models.Job{}, err
+	}
+	var description struct {
+		Memo memo `json:"memo"`
+	}
+	if err := json.Unmarshal(contents, &description); err != nil {
+		return models.Job{}, errors.Wrapf(err, "json error: %s", string(contents))
+	}
+	return models.Job{
+		Name:        jobName,
+		Contents:    contents,
+		NamespaceID: description.Memo.Fields[memoNamespaceID].String(),
+	}, nil
+}
+
+func (repo *JobRepository) GetAll(ctx context.Context) ([]models.Job, error) {
+	var jobs []models.Job
+	err := repo.listSchedules(ctx, func(m memo, contents json.RawMessage) {
+		jobs = append(jobs, models.Job{
+			Name:        m.Fields[memoJobName].String(),
+			Contents:    contents,
+			NamespaceID: m.Fields[memoNamespaceID].String(),
+		})
+	})
+	return jobs, err
+}
+
+// ListNames returns names of jobs of namespace having a schedule
+func (repo *JobRepository) ListNames(ctx context.Context, namespace models.NamespaceSpec) ([]string, error) {
+	var jobNames []string
+	err := repo.listSchedules(ctx, func(m memo, _ json.RawMessage) {
+		if m.Fields[memoNamespaceID].String() == namespace.ID.String() {
+			jobNames = append(jobNames, m.Fields[memoJobName].String())
+		}
+	})
+	return jobNames, err
+}
+
+// Delete removes schedule of job, workflows already started by it are left
+// running
+func (repo *JobRepository) Delete(ctx context.Context, namespace models.NamespaceSpec, jobName string) error {
+	if strings.TrimSpace(jobName) == "" {
+		return errEmptyJobName
+	}
+	query := url.Values{"identity": []string{identity}}
+	if err := repo.client.do(ctx, http.MethodDelete, repo.client.namespaceURL(query, "schedules", scheduleID(repo.project, jobName)), nil, nil); err != nil {
+		if isNotFound(err) {
+			return errors.Wrap(models.ErrNoSuchJob, jobName)
+		}
+		return err
+	}
+	return nil
+}
+
+// listSchedules calls each with every schedule registered by optimus for
+// the project
+func (repo *JobRepository) listSchedules(ctx context.Context, each func(memo, json.RawMessage)) error {
+	params := url.Values{"maximumPageSize": []string{strconv.Itoa(listPageSize)}}
+	for {
+		var page struct {
+			Schedules     []json.RawMessage `json:"schedules"`
+			NextPageToken string            `json:"nextPageToken"`
+		}
+		if err := repo.client.do(ctx, http.MethodGet, repo.client.namespaceURL(params, "schedules"), nil, &page); err != nil {
+			return errors.Wrap(err, "failed to list temporal schedules")
+		}
+		for _, item := range page.Schedules {
+			var entry struct {
+				Memo memo `json:"memo"`
+			}
+			if err := json.Unmarshal(item, &entry); err != nil {
+				return errors.Wrapf(err, "json error: %s", string(item))
+			}
+			if entry.Memo.Fields[memoJobName].String() == "" || entry.Memo.Fields[memoProject].String() != repo.project.Name {
+				continue
+			}
+			each(entry.Memo, item)
+		}
+		if page.NextPageToken == "" {
+			return nil
+		}
+		params.Set("nextPageToken", page.NextPageToken)
+	}
+}
@@ -0,0 +1,266 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+	"github.com/pkg/errors"
+
+	_ "embed"
+)
+
+//go:embed resources/schedule.json
+var resSchedule []byte
+
+const (
+	SchedulerName = "temporal"
+
+	// search attributes set by temporal on workflows started by a schedule
+	searchAttributeScheduledByID      = "TemporalScheduledById"
+	searchAttributeScheduledStartTime = "TemporalScheduledStartTime"
+
+	temporalTimeFormat = "2006-01-02T15:04:05Z"
+)
+
+// scheduleID of a job, schedules of all projects sharing a temporal
+// namespace are kept apart by project name
+func scheduleID(proj models.ProjectSpec, jobName string) string {
+	return fmt.Sprintf("%s.%s", proj.Name, jobName)
+}
+
+type workflowExecution struct {
+	StartTime        *time.Time `json:"startTime"`
+	CloseTime        *time.Time `json:"closeTime"`
+	Status           string     `json:"status"`
+	SearchAttributes struct {
+		IndexedFields map[string]payload `json:"indexedFields"`
+	} `json:"searchAttributes"`
+}
+
+// scheduledAt of the run, start time truncated to cron granularity is used
+// if temporal didn't set the scheduled start time
+func (w workflowExecution) scheduledAt() time.Time {
+	if field, ok := w.SearchAttributes.IndexedFields[searchAttributeScheduledStartTime]; ok {
+		if scheduledAt, err := time.Parse(time.RFC3339Nano, field.String()); err == nil {
+			return scheduledAt.UTC()
+		}
+	}
+	if w.StartTime == nil {
+		return time.Time{}
+	}
+	return w.StartTime.UTC().Truncate(time.Minute)
+}
+
+func (w workflowExecution) toJobStatus() models.JobStatus {
+	status := models.JobStatus{
+		ScheduledAt: w.scheduledAt(),
+		State:       models.JobStatusStateRunning,
+	}
+	switch w.Status {
+	case "WORKFLOW_EXECUTION_STATUS_COMPLETED":
+		status.State = models.JobStatusStateSuccess
+	case "WORKFLOW_EXECUTION_STATUS_FAILED", "WORKFLOW_EXECUTION_STATUS_CANCELED",
+		"WORKFLOW_EXECUTION_STATUS_TERMINATED", "WORKFLOW_EXECUTION_STATUS_TIMED_OUT":
+		status.State = models.JobStatusStateFailed
+	}
+	if w.StartTime != nil {
+		status.StartedAt = *w.StartTime
+	}
+	if w.CloseTime != nil {
+		status.EndedAt = *w.CloseTime
+	}
+	return status
+}
+
+type scheduler struct {
+	httpClient HttpClient
+}
+
+// NewScheduler creates a scheduler running jobs as workflows started by
+// temporal schedules, compiled jobs are registered as schedules by the
+// repository returned from NewJobRepository
+func NewScheduler(httpClient HttpClient) *scheduler {
+	return &scheduler{
+		httpClient: httpClient,
+	}
+}
+
+func (s *scheduler) GetName() string {
+	return SchedulerName
+}
+
+func (s *scheduler) GetJobsDir() string {
+	return "schedules"
+}
+
+func (s *scheduler) GetJobsExtension() string {
+	return ".json"
+}
+
+func (s *scheduler) GetTemplate() []byte {
+	return resSchedule
+}
+
+func (s *scheduler) StoresCompiledJobs() bool {
+	return true
+}
+
+// NewJobRepository returns repository registering compiled jobs of project
+// as temporal schedules
+func (s *scheduler) NewJobRepository(proj models.ProjectSpec) (store.JobRepository, error) {
+	return NewJobRepository(s.httpClient, proj)
+}
+
+// Bootstrap has nothing to prepare, workers running the scheduled
+// workflows are deployed outside optimus
+func (s *scheduler) Bootstrap(ctx context.Context, proj models.ProjectSpec) error {
+	return nil
+}
+
+// GetJobStatus narrows down workflows by scheduled time in visibility
+// query, rest of the filter is applied on fetched workflows
+func (s *scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	filter models.JobStatusFilter) ([]models.JobStatus, error) {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("%s = '%s'", searchAttributeScheduledByID, scheduleID(projSpec, jobName))
+	if !filter.StartDate.IsZero() {
+		query += fmt.Sprintf(" AND %s >= '%s'", searchAttributeScheduledStartTime, filter.StartDate.UTC().Format(temporalTimeFormat))
+	}
+	if !filter.EndDate.IsZero() {
+		query += fmt.Sprintf(" AND %s <= '%s'", searchAttributeScheduledStartTime, filter.EndDate.UTC().Format(temporalTimeFormat))
+	}
+	jobStatus, err := s.listRuns(ctx, c, query, listPageSize)
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(jobStatus), nil
+}
+
+// GetJobRunLogs is not supported, workflows log to the workers running
+// them instead of temporal
+func (s *scheduler) GetJobRunLogs(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	request models.JobRunLogRequest, send func(models.JobRunLog) error) error {
+	return errors.Wrap(models.ErrJobRunLogsNotSupported, s.GetName())
+}
+
+func (s *scheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time,
+	endDate time.Time, batchSize int) ([]models.JobStatus, error) {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("%s = '%s' AND %s BETWEEN '%s' AND '%s'", searchAttributeScheduledByID, scheduleID(projSpec, jobName),
+		searchAttributeScheduledStartTime, startDate.UTC().Format(temporalTimeFormat), endDate.UTC().Format(temporalTimeFormat))
+	return s.listRuns(ctx, c, query, batchSize)
+}
+
+// listRuns returns workflows matching visibility query, workflows are
+// fetched pageSize at a time
+func (s *scheduler) listRuns(ctx context.Context, c *client, query string, pageSize int) ([]models.JobStatus, error) {
+	if pageSize <= 0 {
+		pageSize = listPageSize
+	}
+	params := url.Values{
+		"query":    []string{query},
+		"pageSize": []string{strconv.Itoa(pageSize)},
+	}
+	var jobStatus []models.JobStatus
+	for {
+		var page struct {
+			Executions    []workflowExecution `json:"executions"`
+			NextPageToken string              `json:"nextPageToken"`
+		}
+		if err := c.do(ctx, http.MethodGet, c.namespaceURL(params, "workflows"), nil, &page); err != nil {
+			return nil, errors.Wrap(err, "failed to fetch temporal workflows")
+		}
+		for _, execution := range page.Executions {
+			jobStatus = append(jobStatus, execution.toJobStatus())
+		}
+		if page.NextPageToken == "" {
+			return jobStatus, nil
+		}
+		params.Set("nextPageToken", page.NextPageToken)
+	}
+}
+
+// Clear runs job again for every scheduled time between start and end date
+// by backfilling its schedule, runs are started even if earlier runs of
+// those times are still running
+func (s *scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate, endDate time.Time) error {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return err
+	}
+	// backfill range excludes its start time
+	backfillRequest := map[string]interface{}{
+		"startTime":     startDate.Add(-time.Second).UTC().Format(temporalTimeFormat),
+		"endTime":       endDate.UTC().Format(temporalTimeFormat),
+		"overlapPolicy": "SCHEDULE_OVERLAP_POLICY_ALLOW_ALL",
+	}
+	return s.patch(ctx, c, projSpec, jobName, map[string]interface{}{
+		"backfillRequest": []interface{}{backfillRequest},
+	})
+}
+
+// RunJob starts a workflow for the scheduled time by backfilling schedule
+// of job at that time only
+func (s *scheduler) RunJob(ctx context.Context, projSpec models.ProjectSpec, jobName string, scheduledAt time.Time) error {
+	return s.Clear(ctx, projSpec, jobName, scheduledAt, scheduledAt)
+}
+
+// IsJobPaused reports if schedule of job is paused
+func (s *scheduler) IsJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string) (bool, error) {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return false, err
+	}
+	var description struct {
+		Schedule struct {
+			State struct {
+				Paused bool `json:"paused"`
+			} `json:"state"`
+		} `json:"schedule"`
+	}
+	if err := c.do(ctx, http.MethodGet, c.namespaceURL(nil, "schedules", scheduleID(projSpec, jobName)), nil, &description); err != nil {
+		if isNotFound(err) {
+			// job is not deployed yet
+			return false, nil
+		}
+		return false, err
+	}
+	return description.Schedule.State.Paused, nil
+}
+
+// SetJobPaused pauses or resumes schedule of job
+func (s *scheduler) SetJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string, paused bool) error {
+	c, err := newClient(s.httpClient, projSpec)
+	if err != nil {
+		return err
+	}
+	if paused {
+		return s.patch(ctx, c, projSpec, jobName, map[string]interface{}{"pause": "paused through optimus"})
+	}
+	return s.patch(ctx, c, projSpec, jobName, map[string]interface{}{"unpause": "resumed through optimus"})
+}
+
+func (s *scheduler) patch(ctx context.Context, c *client, projSpec models.ProjectSpec, jobName string, patch map[string]interface{}) error {
+	body := map[string]interface{}{
+		"patch":     patch,
+		"identity":  identity,
+		"requestId": uuid.New().String(),
+	}
+	if err := c.do(ctx, http.MethodPost, c.namespaceURL(nil, "schedules", scheduleID(projSpec, jobName), "patch"), body, nil); err != nil {
+		return errors.Wrapf(err, "failed to patch temporal schedule of %s", jobName)
+	}
+	return nil
+}
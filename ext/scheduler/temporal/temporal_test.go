@@ -0,0 +1,358 @@
+package temporal_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/ext/scheduler/temporal"
+	"github.com/odpf/optimus/models"
+)
+
+type MockHttpClient struct {
+	DoFunc func(req *http.Request) (*http.Response, error)
+}
+
+func (m *MockHttpClient) Do(req *http.Request) (*http.Response, error) {
+	if m.DoFunc != nil {
+		return m.DoFunc(req)
+	}
+	// default if none provided
+	return &http.Response{}, nil
+}
+
+func jsonResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+// jsonPayload is a temporal payload of value encoded as json
+func jsonPayload(value string) string {
+	return fmt.Sprintf(`{"metadata": {"encoding": "anNvbi9wbGFpbg=="}, "data": %q}`,
+		base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%q", value))))
+}
+
+func TestTemporal(t *testing.T) {
+	ctx := context.Background()
+	projSpec := models.ProjectSpec{
+		Name: "proj",
+		Config: map[string]string{
+			models.ProjectSchedulerHost:  "https://temporal.example.io/",
+			temporal.ProjectNamespaceKey: "optimus",
+		},
+		Secret: []models.ProjectSecretItem{
+			{
+				Name:  models.ProjectSchedulerAuth,
+				Value: "temporal-token",
+			},
+		},
+	}
+	workflows := `{
+		"executions": [
+			{
+				"startTime": "2021-04-01T02:00:04Z",
+				"closeTime": "2021-04-01T02:05:00Z",
+				"status": "WORKFLOW_EXECUTION_STATUS_COMPLETED",
+				"searchAttributes": {"indexedFields": {"TemporalScheduledStartTime": ` + jsonPayload("2021-04-01T02:00:00Z") + `}}
+			},
+			{
+				"startTime": "2021-04-02T02:00:01Z",
+				"closeTime": "2021-04-02T02:10:00Z",
+				"status": "WORKFLOW_EXECUTION_STATUS_FAILED"
+			},
+			{
+				"startTime": "2021-04-03T02:00:01Z",
+				"status": "WORKFLOW_EXECUTION_STATUS_RUNNING"
+			}
+		]
+	}`
+
+	t.Run("GetJobStatus", func(t *testing.T) {
+		t.Run("should return workflows started by schedule of job", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodGet, req.Method)
+					assert.Equal(t, "/api/v1/namespaces/optimus/workflows", req.URL.Path)
+					assert.Equal(t, "TemporalScheduledById = 'proj.foo_daily'", req.URL.Query().Get("query"))
+					assert.Equal(t, "500", req.URL.Query().Get("pageSize"))
+					assert.Equal(t, "Bearer temporal-token", req.Header.Get("Authorization"))
+					return jsonResponse(http.StatusOK, workflows), nil
+				},
+			}
+
+			status, err := temporal.NewScheduler(client).GetJobStatus(ctx, projSpec, "foo_daily", models.JobStatusFilter{})
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobStatus{
+				{
+					ScheduledAt: time.Date(2021, 4, 3, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateRunning,
+					StartedAt:   time.Date(2021, 4, 3, 2, 0, 1, 0, time.UTC),
+				},
+				{
+					ScheduledAt: time.Date(2021, 4, 2, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateFailed,
+					StartedAt:   time.Date(2021, 4, 2, 2, 0, 1, 0, time.UTC),
+					EndedAt:     time.Date(2021, 4, 2, 2, 10, 0, 0, time.UTC),
+				},
+				{
+					ScheduledAt: time.Date(2021, 4, 1, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateSuccess,
+					StartedAt:   time.Date(2021, 4, 1, 2, 0, 4, 0, time.UTC),
+					EndedAt:     time.Date(2021, 4, 1, 2, 5, 0, 0, time.UTC),
+				},
+			}, status)
+		})
+		t.Run("should query workflows scheduled within filter dates", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "TemporalScheduledById = 'proj.foo_daily' AND TemporalScheduledStartTime >= '2021-04-02T00:00:00Z'",
+						req.URL.Query().Get("query"))
+					return jsonResponse(http.StatusOK, workflows), nil
+				},
+			}
+
+			status, err := temporal.NewScheduler(client).GetJobStatus(ctx, projSpec, "foo_daily", models.JobStatusFilter{
+				StartDate: time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC),
+				States:    []models.JobStatusState{models.JobStatusStateFailed},
+			})
+			assert.Nil(t, err)
+			assert.Len(t, status, 1)
+			assert.Equal(t, models.JobStatusStateFailed, status[0].State)
+		})
+		t.Run("should fail if scheduler auth secret is not set", func(t *testing.T) {
+			_, err := temporal.NewScheduler(&MockHttpClient{}).GetJobStatus(ctx, models.ProjectSpec{
+				Name:   "proj",
+				Config: projSpec.Config,
+			}, "foo_daily", models.JobStatusFilter{})
+			assert.Equal(t, "SCHEDULER_AUTH secret not configured for project proj", err.Error())
+		})
+	})
+	t.Run("GetDagRunStatus", func(t *testing.T) {
+		t.Run("should query workflows scheduled within dates across pages", func(t *testing.T) {
+			var pageTokens []string
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "TemporalScheduledById = 'proj.foo_daily' AND TemporalScheduledStartTime BETWEEN '2021-04-02T00:00:00Z' AND '2021-04-03T00:00:00Z'",
+						req.URL.Query().Get("query"))
+					assert.Equal(t, "10", req.URL.Query().Get("pageSize"))
+					pageTokens = append(pageTokens, req.URL.Query().Get("nextPageToken"))
+					if len(pageTokens) == 1 {
+						return jsonResponse(http.StatusOK, `{"executions": [{"startTime": "2021-04-02T02:00:01Z", "status": "WORKFLOW_EXECUTION_STATUS_TIMED_OUT"}], "nextPageToken": "next"}`), nil
+					}
+					return jsonResponse(http.StatusOK, `{"executions": []}`), nil
+				},
+			}
+
+			status, err := temporal.NewScheduler(client).GetDagRunStatus(ctx, projSpec, "foo_daily",
+				time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 3, 0, 0, 0, 0, time.UTC), 10)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(status))
+			assert.Equal(t, models.JobStatusStateFailed, status[0].State)
+			assert.Equal(t, []string{"", "next"}, pageTokens)
+		})
+	})
+	t.Run("Clear", func(t *testing.T) {
+		t.Run("should backfill schedule of job between dates", func(t *testing.T) {
+			var patch map[string]interface{}
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodPost, req.Method)
+					assert.Equal(t, "/api/v1/namespaces/optimus/schedules/proj.foo_daily/patch", req.URL.Path)
+					body, _ := ioutil.ReadAll(req.Body)
+					assert.Nil(t, json.Unmarshal(body, &patch))
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+
+			err := temporal.NewScheduler(client).Clear(ctx, projSpec, "foo_daily",
+				time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 4, 0, 0, 0, 0, time.UTC))
+			assert.Nil(t, err)
+			assert.Equal(t, "optimus", patch["identity"])
+			assert.Equal(t, map[string]interface{}{
+				"backfillRequest": []interface{}{
+					map[string]interface{}{
+						"startTime":     "2021-04-01T23:59:59Z",
+						"endTime":       "2021-04-04T00:00:00Z",
+						"overlapPolicy": "SCHEDULE_OVERLAP_POLICY_ALLOW_ALL",
+					},
+				},
+			}, patch["patch"])
+		})
+	})
+	t.Run("IsJobPaused", func(t *testing.T) {
+		t.Run("should return paused state of schedule", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "/api/v1/namespaces/optimus/schedules/proj.foo_daily", req.URL.Path)
+					return jsonResponse(http.StatusOK, `{"schedule": {"state": {"paused": true}}}`), nil
+				},
+			}
+			paused, err := temporal.NewScheduler(client).IsJobPaused(ctx, projSpec, "foo_daily")
+			assert.Nil(t, err)
+			assert.True(t, paused)
+		})
+		t.Run("should return not paused for jobs without schedule", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(http.StatusNotFound, `{"code": 5, "message": "schedule not found"}`), nil
+				},
+			}
+			paused, err := temporal.NewScheduler(client).IsJobPaused(ctx, projSpec, "foo_daily")
+			assert.Nil(t, err)
+			assert.False(t, paused)
+		})
+	})
+	t.Run("SetJobPaused", func(t *testing.T) {
+		t.Run("should unpause schedule", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					body, _ := ioutil.ReadAll(req.Body)
+					var request struct {
+						Patch map[string]string `json:"patch"`
+					}
+					assert.Nil(t, json.Unmarshal(body, &request))
+					assert.Equal(t, map[string]string{"unpause": "resumed through optimus"}, request.Patch)
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+			assert.Nil(t, temporal.NewScheduler(client).SetJobPaused(ctx, projSpec, "foo_daily", false))
+		})
+		t.Run("should return message of failed api call", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(http.StatusForbidden, `{"code": 7, "message": "request unauthorized"}`), nil
+				},
+			}
+			err := temporal.NewScheduler(client).SetJobPaused(ctx, projSpec, "foo_daily", true)
+			assert.Contains(t, err.Error(), "failed with 403: request unauthorized")
+		})
+	})
+}
+
+func TestJobRepository(t *testing.T) {
+	ctx := context.Background()
+	namespaceID := uuid.New()
+	projSpec := models.ProjectSpec{
+		Name: "proj",
+		Scheduler: models.ProjectSchedulerConfig{
+			Host: "https://temporal.example.io",
+		},
+		Secret: []models.ProjectSecretItem{
+			{
+				Name:  models.ProjectSchedulerAuth,
+				Value: "temporal-token",
+			},
+		},
+	}
+
+	t.Run("Save", func(t *testing.T) {
+		t.Run("should create schedule of compiled job", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodPost, req.Method)
+					assert.Equal(t, "https://temporal.example.io/api/v1/namespaces/default/schedules/proj.foo_daily", req.URL.String())
+					body, _ := ioutil.ReadAll(req.Body)
+					var request map[string]interface{}
+					assert.Nil(t, json.Unmarshal(body, &request))
+					assert.Equal(t, "optimus", request["identity"])
+					assert.NotEmpty(t, request["requestId"])
+					assert.Equal(t, map[string]interface{}{"spec": map[string]interface{}{}}, request["schedule"])
+					return jsonResponse(http.StatusOK, `{}`), nil
+				},
+			}
+			repo, err := temporal.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Save(ctx, models.Job{Name: "foo_daily", Contents: []byte(`{"schedule": {"spec": {}}}`)}))
+		})
+		t.Run("should update existing schedule keeping its state", func(t *testing.T) {
+			var requests []string
+			var update map[string]interface{}
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req.Method+" "+req.URL.Path)
+					switch {
+					case req.Method == http.MethodGet:
+						return jsonResponse(http.StatusOK, `{"schedule": {"state": {"paused": true}}, "conflictToken": "token"}`), nil
+					case req.URL.Path == "/api/v1/namespaces/default/schedules/proj.foo_daily/update":
+						body, _ := ioutil.ReadAll(req.Body)
+						assert.Nil(t, json.Unmarshal(body, &update))
+						return jsonResponse(http.StatusOK, `{}`), nil
+					}
+					return jsonResponse(http.StatusConflict, `{"code": 6, "message": "schedule already registered"}`), nil
+				},
+			}
+			repo, err := temporal.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+			assert.Nil(t, repo.Save(ctx, models.Job{Name: "foo_daily", Contents: []byte(`{"schedule": {"spec": {}}}`)}))
+			assert.Equal(t, []string{
+				"POST /api/v1/namespaces/default/schedules/proj.foo_daily",
+				"GET /api/v1/namespaces/default/schedules/proj.foo_daily",
+				"POST /api/v1/namespaces/default/schedules/proj.foo_daily/update",
+			}, requests)
+			assert.Equal(t, "token", update["conflictToken"])
+			assert.Equal(t, map[string]interface{}{
+				"spec":  map[string]interface{}{},
+				"state": map[string]interface{}{"paused": true},
+			}, update["schedule"])
+		})
+	})
+	t.Run("ListNames", func(t *testing.T) {
+		t.Run("should return jobs of namespace registered for project across pages", func(t *testing.T) {
+			pages := []string{
+				`{"schedules": [
+					{"scheduleId": "proj.foo_daily", "memo": {"fields": {"optimusJobName": ` + jsonPayload("foo_daily") + `, "optimusProject": ` + jsonPayload("proj") + `, "optimusNamespaceId": ` + jsonPayload(namespaceID.String()) + `}}},
+					{"scheduleId": "other.bar", "memo": {"fields": {"optimusJobName": ` + jsonPayload("bar") + `, "optimusProject": ` + jsonPayload("other") + `, "optimusNamespaceId": ` + jsonPayload(namespaceID.String()) + `}}},
+					{"scheduleId": "unmanaged"}
+				], "nextPageToken": "next"}`,
+				`{"schedules": [
+					{"scheduleId": "proj.baz", "memo": {"fields": {"optimusJobName": ` + jsonPayload("baz") + `, "optimusProject": ` + jsonPayload("proj") + `, "optimusNamespaceId": ` + jsonPayload(namespaceID.String()) + `}}}
+				]}`,
+			}
+			var pageTokens []string
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, "/api/v1/namespaces/default/schedules", req.URL.Path)
+					pageTokens = append(pageTokens, req.URL.Query().Get("nextPageToken"))
+					page := pages[0]
+					pages = pages[1:]
+					return jsonResponse(http.StatusOK, page), nil
+				},
+			}
+			repo, err := temporal.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+
+			jobNames, err := repo.ListNames(ctx, models.NamespaceSpec{ID: namespaceID})
+			assert.Nil(t, err)
+			assert.Equal(t, []string{"foo_daily", "baz"}, jobNames)
+			assert.Equal(t, []string{"", "next"}, pageTokens)
+		})
+	})
+	t.Run("Delete", func(t *testing.T) {
+		t.Run("should return no such job if schedule doesn't exist", func(t *testing.T) {
+			client := &MockHttpClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					assert.Equal(t, http.MethodDelete, req.Method)
+					assert.Equal(t, "optimus", req.URL.Query().Get("identity"))
+					return jsonResponse(http.StatusNotFound, `{"code": 5, "message": "schedule not found"}`), nil
+				},
+			}
+			repo, err := temporal.NewJobRepository(client, projSpec)
+			assert.Nil(t, err)
+
+			err = repo.Delete(ctx, models.NamespaceSpec{ID: namespaceID}, "foo_daily")
+			assert.True(t, errors.Is(err, models.ErrNoSuchJob))
+		})
+	})
+}
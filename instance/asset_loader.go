@@ -0,0 +1,312 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	AssetSchemeGCS   = "gs"
+	AssetSchemeHTTP  = "http"
+	AssetSchemeHTTPS = "https"
+	AssetSchemeFile  = "file"
+
+	// DefaultAssetCacheTTL is the duration a fetched remote asset is reused
+	// before fetching it again
+	DefaultAssetCacheTTL = 5 * time.Minute
+
+	// DefaultAssetMaxBytes is the size of a remote asset read at most
+	DefaultAssetMaxBytes = 1 << 20
+)
+
+var (
+	ErrUnsupportedAssetScheme = errors.New("unsupported asset scheme")
+	ErrAssetHostNotAllowed    = errors.New("asset host not allowed")
+	ErrAssetTooLarge          = errors.New("asset too large")
+)
+
+// ReadAsset reads the content of a remote asset failing with
+// ErrAssetTooLarge once more than maxBytes are read, DefaultAssetMaxBytes
+// are read at most if maxBytes is not set
+func ReadAsset(r io.Reader, maxBytes int64) ([]byte, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultAssetMaxBytes
+	}
+	content, err := ioutil.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > maxBytes {
+		return nil, errors.Wrapf(ErrAssetTooLarge, "more than %d bytes", maxBytes)
+	}
+	return content, nil
+}
+
+// RemoteAssetURI returns the uri if asset value is a reference to a remote
+// asset instead of the content itself. A reference is a single line value
+// with one of the known schemes, e.g. gs://bucket/path/query.sql
+func RemoteAssetURI(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.ContainsAny(value, " \n\t") {
+		return "", false
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", false
+	}
+	switch parsed.Scheme {
+	case AssetSchemeGCS, AssetSchemeHTTP, AssetSchemeHTTPS, AssetSchemeFile:
+		return value, true
+	}
+	return "", false
+}
+
+// ResolveAssets replaces all remote asset references with their content
+// fetched using loader, assets are returned as is if loader is nil
+func ResolveAssets(ctx context.Context, loader models.AssetLoader, assets models.JobAssets) (models.JobAssets, error) {
+	if loader == nil {
+		return assets, nil
+	}
+	assetMap := assets.ToMap()
+	if len(assetMap) == 0 {
+		return assets, nil
+	}
+	resolved := false
+	for name, value := range assetMap {
+		uri, ok := RemoteAssetURI(value)
+		if !ok {
+			continue
+		}
+		content, err := loader.Load(ctx, uri)
+		if err != nil {
+			return models.JobAssets{}, errors.Wrapf(err, "failed to fetch asset %s from %s", name, uri)
+		}
+		assetMap[name] = string(content)
+		resolved = true
+	}
+	if !resolved {
+		return assets, nil
+	}
+	return models.JobAssets{}.FromMap(assetMap), nil
+}
+
+// ResolveResourceAssets replaces remote asset references of a resource with
+// their content fetched using loader, assets are returned as is if loader is nil
+func ResolveResourceAssets(ctx context.Context, loader models.AssetLoader, assets models.ResourceAssets) (models.ResourceAssets, error) {
+	if loader == nil || len(assets) == 0 {
+		return assets, nil
+	}
+	resolved := models.ResourceAssets{}
+	for name, value := range assets {
+		resolved[name] = value
+		uri, ok := RemoteAssetURI(value)
+		if !ok {
+			continue
+		}
+		content, err := loader.Load(ctx, uri)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch asset %s from %s", name, uri)
+		}
+		resolved[name] = string(content)
+	}
+	return resolved, nil
+}
+
+type cachedAsset struct {
+	content   []byte
+	fetchedAt time.Time
+}
+
+// assetCacheKey keeps assets of projects apart as they can be fetched
+// with credentials of the project
+type assetCacheKey struct {
+	project string
+	uri     string
+}
+
+type assetCache struct {
+	mu      sync.Mutex
+	entries map[assetCacheKey]cachedAsset
+}
+
+func newAssetCache() *assetCache {
+	return &assetCache{
+		entries: map[assetCacheKey]cachedAsset{},
+	}
+}
+
+// CachedAssetLoader picks a loader based on scheme of the asset uri and
+// caches the fetched content for a while, as the same shared asset is
+// usually referenced by many jobs
+type CachedAssetLoader struct {
+	loaders map[string]models.AssetLoader
+	ttl     time.Duration
+	now     func() time.Time
+
+	project string
+	cache   *assetCache
+}
+
+func (l *CachedAssetLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	key := assetCacheKey{project: l.project, uri: uri}
+	l.cache.mu.Lock()
+	entry, ok := l.cache.entries[key]
+	l.cache.mu.Unlock()
+	if ok && l.now().Sub(entry.fetchedAt) < l.ttl {
+		return entry.content, nil
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	loader, ok := l.loaders[parsed.Scheme]
+	if !ok {
+		return nil, errors.Wrap(ErrUnsupportedAssetScheme, parsed.Scheme)
+	}
+	content, err := loader.Load(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	l.cache.mu.Lock()
+	l.cache.entries[key] = cachedAsset{content: content, fetchedAt: l.now()}
+	l.cache.mu.Unlock()
+	return content, nil
+}
+
+// NewCachedAssetLoader creates a loader using provided loaders keyed by
+// the uri scheme they support, e.g. gs, https
+func NewCachedAssetLoader(loaders map[string]models.AssetLoader, ttl time.Duration, timeFunc func() time.Time) *CachedAssetLoader {
+	if ttl <= 0 {
+		ttl = DefaultAssetCacheTTL
+	}
+	return &CachedAssetLoader{
+		loaders: loaders,
+		ttl:     ttl,
+		now:     timeFunc,
+		cache:   newAssetCache(),
+	}
+}
+
+// ProjectAssetLoaderFactory creates cached loaders of remote assets of a
+// project, loaders of a project are given by projectLoaders so assets can
+// be fetched with credentials of the project, and are cached per project
+type ProjectAssetLoaderFactory struct {
+	projectLoaders func(proj models.ProjectSpec) map[string]models.AssetLoader
+	ttl            time.Duration
+	now            func() time.Time
+	cache          *assetCache
+}
+
+func (f *ProjectAssetLoaderFactory) New(proj models.ProjectSpec) models.AssetLoader {
+	return &CachedAssetLoader{
+		loaders: f.projectLoaders(proj),
+		ttl:     f.ttl,
+		now:     f.now,
+		project: proj.Name,
+		cache:   f.cache,
+	}
+}
+
+func NewProjectAssetLoaderFactory(projectLoaders func(proj models.ProjectSpec) map[string]models.AssetLoader,
+	ttl time.Duration, timeFunc func() time.Time) *ProjectAssetLoaderFactory {
+	if ttl <= 0 {
+		ttl = DefaultAssetCacheTTL
+	}
+	return &ProjectAssetLoaderFactory{
+		projectLoaders: projectLoaders,
+		ttl:            ttl,
+		now:            timeFunc,
+		cache:          newAssetCache(),
+	}
+}
+
+// FileAssetLoader reads assets referenced as file:///path/to/asset
+type FileAssetLoader struct {
+	fs afero.Fs
+}
+
+func (l *FileAssetLoader) Load(_ context.Context, uri string) ([]byte, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != AssetSchemeFile {
+		return nil, errors.Wrap(ErrUnsupportedAssetScheme, parsed.Scheme)
+	}
+	return afero.ReadFile(l.fs, parsed.Path)
+}
+
+func NewFileAssetLoader(fs afero.Fs) *FileAssetLoader {
+	return &FileAssetLoader{
+		fs: fs,
+	}
+}
+
+// HTTPAssetLoader fetches assets served over http(s) by allowed hosts,
+// assets of any host are fetched if allowed hosts are not set
+type HTTPAssetLoader struct {
+	client       *http.Client
+	allowedHosts map[string]bool
+	maxBytes     int64
+}
+
+func (l *HTTPAssetLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.checkHost(req.URL); err != nil {
+		return nil, err
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return ReadAsset(resp.Body, l.maxBytes)
+}
+
+func (l *HTTPAssetLoader) checkHost(u *url.URL) error {
+	if len(l.allowedHosts) > 0 && !l.allowedHosts[strings.ToLower(u.Hostname())] {
+		return errors.Wrap(ErrAssetHostNotAllowed, u.Hostname())
+	}
+	return nil
+}
+
+// NewHTTPAssetLoader creates a loader fetching assets of allowedHosts with
+// client, redirects to other hosts are not followed, at most maxBytes of an
+// asset are read
+func NewHTTPAssetLoader(client *http.Client, allowedHosts []string, maxBytes int64) *HTTPAssetLoader {
+	l := &HTTPAssetLoader{
+		allowedHosts: map[string]bool{},
+		maxBytes:     maxBytes,
+	}
+	for _, host := range allowedHosts {
+		l.allowedHosts[strings.ToLower(host)] = true
+	}
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return l.checkHost(req.URL)
+	}
+	l.client = &c
+	return l
+}
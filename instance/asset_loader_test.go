@@ -0,0 +1,201 @@
+package instance_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/instance"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetLoader(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RemoteAssetURI", func(t *testing.T) {
+		cases := map[string]bool{
+			"gs://bucket/path/query.sql":       true,
+			" https://example.io/query.sql \n": true,
+			"http://example.io/query.sql":      true,
+			"file:///tmp/query.sql":            true,
+			"select * from table":              false,
+			"s3://bucket/query.sql":            false,
+			"-- gs://bucket/query.sql\nselect": false,
+			"":                                 false,
+		}
+		for value, expected := range cases {
+			_, ok := instance.RemoteAssetURI(value)
+			assert.Equal(t, expected, ok, value)
+		}
+	})
+	t.Run("ResolveAssets", func(t *testing.T) {
+		t.Run("should replace remote references with fetched content", func(t *testing.T) {
+			loader := new(mock.AssetLoader)
+			loader.On("Load", ctx, "gs://bucket/shared/query.sql").Return([]byte("select * from shared"), nil)
+			defer loader.AssertExpectations(t)
+
+			assets := models.JobAssets{}.FromMap(map[string]string{
+				"query.sql": "gs://bucket/shared/query.sql",
+				"local.sql": "select * from local",
+			})
+			resolved, err := instance.ResolveAssets(ctx, loader, assets)
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]string{
+				"query.sql": "select * from shared",
+				"local.sql": "select * from local",
+			}, resolved.ToMap())
+		})
+		t.Run("should fail naming the url if asset can't be fetched", func(t *testing.T) {
+			loader := new(mock.AssetLoader)
+			loader.On("Load", ctx, "https://example.io/query.sql").Return([]byte{}, errors.New("connection refused"))
+			defer loader.AssertExpectations(t)
+
+			assets := models.JobAssets{}.FromMap(map[string]string{
+				"query.sql": "https://example.io/query.sql",
+			})
+			_, err := instance.ResolveAssets(ctx, loader, assets)
+			assert.Equal(t, "failed to fetch asset query.sql from https://example.io/query.sql: connection refused", err.Error())
+		})
+		t.Run("should return assets as is without a loader", func(t *testing.T) {
+			assets := models.JobAssets{}.FromMap(map[string]string{
+				"query.sql": "gs://bucket/shared/query.sql",
+			})
+			resolved, err := instance.ResolveAssets(ctx, nil, assets)
+			assert.Nil(t, err)
+			assert.Equal(t, assets, resolved)
+		})
+	})
+	t.Run("ResolveResourceAssets", func(t *testing.T) {
+		t.Run("should replace remote references with fetched content", func(t *testing.T) {
+			loader := new(mock.AssetLoader)
+			loader.On("Load", ctx, "gs://bucket/views/user.sql").Return([]byte("select * from users"), nil)
+			defer loader.AssertExpectations(t)
+
+			assets := models.ResourceAssets{
+				"view.sql":  "gs://bucket/views/user.sql",
+				"local.sql": "select * from local",
+			}
+			resolved, err := instance.ResolveResourceAssets(ctx, loader, assets)
+			assert.Nil(t, err)
+			assert.Equal(t, models.ResourceAssets{
+				"view.sql":  "select * from users",
+				"local.sql": "select * from local",
+			}, resolved)
+			assert.Equal(t, "gs://bucket/views/user.sql", assets["view.sql"])
+		})
+	})
+	t.Run("CachedAssetLoader", func(t *testing.T) {
+		t.Run("should fetch an asset once within ttl", func(t *testing.T) {
+			now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			gcsLoader := new(mock.AssetLoader)
+			gcsLoader.On("Load", ctx, "gs://bucket/query.sql").Return([]byte("select 1"), nil).Twice()
+			defer gcsLoader.AssertExpectations(t)
+
+			loader := instance.NewCachedAssetLoader(map[string]models.AssetLoader{
+				instance.AssetSchemeGCS: gcsLoader,
+			}, time.Minute, func() time.Time {
+				return now
+			})
+			for i := 0; i < 3; i++ {
+				content, err := loader.Load(ctx, "gs://bucket/query.sql")
+				assert.Nil(t, err)
+				assert.Equal(t, "select 1", string(content))
+			}
+
+			// cache expires after ttl
+			now = now.Add(time.Minute)
+			_, err := loader.Load(ctx, "gs://bucket/query.sql")
+			assert.Nil(t, err)
+		})
+		t.Run("should fail for unsupported scheme", func(t *testing.T) {
+			loader := instance.NewCachedAssetLoader(map[string]models.AssetLoader{}, 0, time.Now)
+			_, err := loader.Load(ctx, "gs://bucket/query.sql")
+			assert.True(t, errors.Is(err, instance.ErrUnsupportedAssetScheme))
+		})
+	})
+	t.Run("ProjectAssetLoaderFactory", func(t *testing.T) {
+		t.Run("should fetch assets with loaders of project and cache them per project", func(t *testing.T) {
+			loaderA := new(mock.AssetLoader)
+			loaderA.On("Load", ctx, "gs://bucket/query.sql").Return([]byte("select 1"), nil).Once()
+			defer loaderA.AssertExpectations(t)
+			loaderB := new(mock.AssetLoader)
+			loaderB.On("Load", ctx, "gs://bucket/query.sql").Return([]byte("select 2"), nil).Once()
+			defer loaderB.AssertExpectations(t)
+
+			projectLoaders := map[string]models.AssetLoader{"project-a": loaderA, "project-b": loaderB}
+			factory := instance.NewProjectAssetLoaderFactory(func(proj models.ProjectSpec) map[string]models.AssetLoader {
+				return map[string]models.AssetLoader{
+					instance.AssetSchemeGCS: projectLoaders[proj.Name],
+				}
+			}, time.Minute, time.Now)
+
+			for i := 0; i < 2; i++ {
+				content, err := factory.New(models.ProjectSpec{Name: "project-a"}).Load(ctx, "gs://bucket/query.sql")
+				assert.Nil(t, err)
+				assert.Equal(t, "select 1", string(content))
+
+				content, err = factory.New(models.ProjectSpec{Name: "project-b"}).Load(ctx, "gs://bucket/query.sql")
+				assert.Nil(t, err)
+				assert.Equal(t, "select 2", string(content))
+			}
+		})
+	})
+	t.Run("FileAssetLoader", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		assert.Nil(t, afero.WriteFile(fs, "/shared/query.sql", []byte("select 1"), 0644))
+
+		loader := instance.NewFileAssetLoader(fs)
+		content, err := loader.Load(ctx, "file:///shared/query.sql")
+		assert.Nil(t, err)
+		assert.Equal(t, "select 1", string(content))
+
+		_, err = loader.Load(ctx, "file:///shared/missing.sql")
+		assert.NotNil(t, err)
+	})
+	t.Run("HTTPAssetLoader", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/query.sql" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, "select 1")
+		}))
+		defer srv.Close()
+
+		t.Run("should fetch assets of allowed hosts", func(t *testing.T) {
+			loader := instance.NewHTTPAssetLoader(srv.Client(), []string{"127.0.0.1"}, 0)
+			content, err := loader.Load(ctx, srv.URL+"/query.sql")
+			assert.Nil(t, err)
+			assert.Equal(t, "select 1", string(content))
+
+			_, err = loader.Load(ctx, srv.URL+"/missing.sql")
+			assert.NotNil(t, err)
+		})
+		t.Run("should not fetch assets of other hosts", func(t *testing.T) {
+			loader := instance.NewHTTPAssetLoader(srv.Client(), []string{"assets.example.io"}, 0)
+			_, err := loader.Load(ctx, srv.URL+"/query.sql")
+			assert.True(t, errors.Is(err, instance.ErrAssetHostNotAllowed))
+		})
+		t.Run("should not follow redirects to other hosts", func(t *testing.T) {
+			redirectSrv := httptest.NewServer(http.RedirectHandler(srv.URL+"/query.sql", http.StatusFound))
+			defer redirectSrv.Close()
+
+			loader := instance.NewHTTPAssetLoader(redirectSrv.Client(), []string{"localhost"}, 0)
+			_, err := loader.Load(ctx, strings.Replace(redirectSrv.URL, "127.0.0.1", "localhost", 1)+"/query.sql")
+			assert.True(t, errors.Is(err, instance.ErrAssetHostNotAllowed))
+		})
+		t.Run("should fail for assets larger than max bytes", func(t *testing.T) {
+			loader := instance.NewHTTPAssetLoader(srv.Client(), nil, 4)
+			_, err := loader.Load(ctx, srv.URL+"/query.sql")
+			assert.True(t, errors.Is(err, instance.ErrAssetTooLarge))
+		})
+	})
+}
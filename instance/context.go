@@ -3,6 +3,7 @@ package instance
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/odpf/optimus/models"
@@ -23,6 +24,10 @@ var (
 	// IgnoreTemplateRenderExtension used as extension on a file will skip template
 	// rendering of it
 	IgnoreTemplateRenderExtension = []string{".gtpl", ".j2", ".tmpl", ".tpl"}
+
+	// ErrMissingSecret is returned when a job refers a secret which is not
+	// available to the instance being compiled
+	ErrMissingSecret = errors.New("missing secret")
 )
 
 // ContextManager fetches all config data for a given instanceSpec and compiles all
@@ -35,6 +40,10 @@ type ContextManager struct {
 	namespace models.NamespaceSpec
 	jobSpec   models.JobSpec
 	engine    models.TemplateEngine
+
+	// GlobalConfig is shared by all projects, available as {{.global.KEY}}
+	// and as project config when neither namespace nor project has the key
+	GlobalConfig map[string]string
 }
 
 // Generate fetches and compiles all config data related to an instance and
@@ -45,16 +54,18 @@ func (fm *ContextManager) Generate(
 	runType models.InstanceType,
 	runName string,
 ) (envMap map[string]string, fileMap map[string]string, err error) {
-	// project configs will be used for templating
-	// prefix project configs to avoid conflicts with project/instance configs
-	projectConfig := map[string]string{}
-	for key, val := range fm.getProjectConfigMap() {
-		projectConfig[fmt.Sprintf("%s%s", ProjectConfigPrefix, key)] = val
+	// secrets are resolved only here, fail early if the job refers one
+	// which is not available to the instance
+	if missing := fm.missingSecrets(); len(missing) > 0 {
+		return nil, nil, errors.Wrapf(ErrMissingSecret, "secrets %s referred by job %s",
+			strings.Join(missing, ", "), fm.jobSpec.Name)
 	}
 
-	// use namespace configs for templating. also, override project config with
-	// namespace's configs when present
-	for key, val := range fm.getNamespaceConfigMap() {
+	// project configs will be used for templating, namespace configs
+	// override project configs which override global configs
+	// prefix project configs to avoid conflicts with project/instance configs
+	projectConfig := map[string]string{}
+	for key, val := range fm.namespace.ResolvedConfig(fm.GlobalConfig) {
 		projectConfig[fmt.Sprintf("%s%s", ProjectConfigPrefix, key)] = val
 	}
 
@@ -65,13 +76,13 @@ func (fm *ContextManager) Generate(
 	projectInstanceContext := MergeStringMap(instanceEnvMap, projectConfig)
 
 	// prepare configs
-	envMap, err = fm.generateEnvs(runName, runType, projectInstanceContext)
+	envMap, taskConfigs, err := fm.generateEnvs(runName, runType, projectInstanceContext)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// transformation may need instance variables as well
 	envMap = MergeStringMap(envMap, instanceEnvMap)
-	if err != nil {
-		return
-	}
 
 	// do the same for asset files
 	// check if task needs to override the compilation behaviour
@@ -86,50 +97,59 @@ func (fm *ContextManager) Generate(
 		return nil, nil, err
 	}
 
-	// append job spec assets to list of files need to write
+	// append job spec assets to list of files need to write, compiled
+	// task configs are available to assets as {{.TASK__KEY}}
 	fileMap = MergeStringMap(instanceFileMap, compiledAssetResponse.Assets.ToJobSpec().ToMap())
-	if fileMap, err = fm.engine.CompileFiles(fileMap, ConvertStringToInterfaceMap(projectInstanceContext)); err != nil {
-		return
+	assetContext := MergeStringMap(projectInstanceContext, prefixTaskConfigs(taskConfigs))
+	if fileMap, err = fm.engine.CompileFiles(fileMap, fm.templateContext(assetContext)); err != nil {
+		return nil, nil, err
 	}
 	return envMap, fileMap, nil
 }
 
+// generateEnvs returns envs of the run along with the compiled task configs
 func (fm *ContextManager) generateEnvs(runName string, runType models.InstanceType,
-	projectInstanceContext map[string]string) (map[string]string, error) {
+	projectInstanceContext map[string]string) (map[string]string, map[string]string, error) {
 	transformationConfigs, hookConfigs, err := fm.getConfigMaps(fm.jobSpec, runName, runType)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// templatize configs for transformation with project and instance
 	if transformationConfigs, err = fm.compileTemplates(transformationConfigs, projectInstanceContext); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// if this is requested for transformation, just return from here
 	if runType == models.InstanceTypeTask {
-		return transformationConfigs, nil
+		return transformationConfigs, transformationConfigs, nil
 	}
 
 	// prefix transformation configs to avoid conflicts with project/instance configs
-	prefixedTransformationConfigs := map[string]string{}
-	for key, val := range transformationConfigs {
-		prefixedTransformationConfigs[fmt.Sprintf("%s%s", TaskConfigPrefix, key)] = val
-	}
+	prefixedTransformationConfigs := prefixTaskConfigs(transformationConfigs)
 
 	// templatize configs of hook with transformation, project and instance
 	projectInstanceTransformationConfigs := MergeStringMap(projectInstanceContext, prefixedTransformationConfigs)
 	if hookConfigs, err = fm.compileTemplates(hookConfigs, projectInstanceTransformationConfigs); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// merge transformation and hook configs
-	return MergeStringMap(prefixedTransformationConfigs, hookConfigs), nil
+	return MergeStringMap(prefixedTransformationConfigs, hookConfigs), transformationConfigs, nil
+}
+
+// prefixTaskConfigs to avoid conflicts with project/instance configs
+func prefixTaskConfigs(taskConfigs map[string]string) map[string]string {
+	prefixed := map[string]string{}
+	for key, val := range taskConfigs {
+		prefixed[fmt.Sprintf("%s%s", TaskConfigPrefix, key)] = val
+	}
+	return prefixed
 }
 
 func (fm *ContextManager) compileTemplates(templateValueMap, templateContext map[string]string) (map[string]string, error) {
 	for key, val := range templateValueMap {
-		compiledValue, err := fm.engine.CompileString(val, ConvertStringToInterfaceMap(templateContext))
+		compiledValue, err := fm.engine.CompileString(val, fm.templateContext(templateContext))
 		if err != nil {
 			return nil, err
 		}
@@ -138,20 +158,26 @@ func (fm *ContextManager) compileTemplates(templateValueMap, templateContext map
 	return templateValueMap, nil
 }
 
-func (fm *ContextManager) getProjectConfigMap() map[string]string {
-	configMap := map[string]string{}
-	for key, val := range fm.namespace.ProjectSpec.Config {
-		configMap[key] = val
-	}
-	return configMap
+// templateContext prepares values available to templates, project configs
+// overridden by namespace configs are also accessible as {{.proj.KEY}},
+// global configs as {{.global.KEY}} and project secrets as {{.secret.NAME}}
+func (fm *ContextManager) templateContext(values map[string]string) map[string]interface{} {
+	templateContext := ConvertStringToInterfaceMap(values)
+	templateContext[models.ProjectConfigTemplateKey] = fm.namespace.ResolvedConfig(fm.GlobalConfig)
+	templateContext[models.GlobalConfigTemplateKey] = MergeStringMap(fm.GlobalConfig, nil)
+	templateContext[models.SecretTemplateKey] = fm.namespace.ProjectSpec.Secret.ToMap()
+	return templateContext
 }
 
-func (fm *ContextManager) getNamespaceConfigMap() map[string]string {
-	configMap := map[string]string{}
-	for key, val := range fm.namespace.Config {
-		configMap[key] = val
+func (fm *ContextManager) missingSecrets() []string {
+	secrets := fm.namespace.ProjectSpec.Secret.ToMap()
+	var missing []string
+	for _, name := range fm.jobSpec.SecretReferences() {
+		if _, ok := secrets[name]; !ok {
+			missing = append(missing, name)
+		}
 	}
-	return configMap
+	return missing
 }
 
 func (fm *ContextManager) getInstanceData(instanceSpec models.InstanceSpec) (map[string]string, map[string]string) {
@@ -231,6 +257,12 @@ func DumpAssets(jobSpec models.JobSpec, scheduledAt time.Time, engine models.Tem
 	if err != nil {
 		return nil, err
 	}
+	loc, err := jobSpec.Schedule.Location()
+	if err != nil {
+		return nil, err
+	}
+	// macros resolve in timezone of the job schedule
+	scheduledAt = scheduledAt.In(loc)
 
 	assetsToDump := jobSpec.Assets.ToMap()
 
@@ -259,12 +291,28 @@ func DumpAssets(jobSpec models.JobSpec, scheduledAt time.Time, engine models.Tem
 	}
 
 	// compile again if needed
-	templates, err := engine.CompileFiles(assetsToDump, map[string]interface{}{
+	dumpContext := map[string]interface{}{
 		ConfigKeyDstart:        jobSpec.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
 		ConfigKeyDend:          jobSpec.Task.Window.GetEnd(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
 		ConfigKeyExecutionTime: scheduledAt.Format(models.InstanceScheduledAtTimeLayout),
 		ConfigKeyDestination:   jobDestination,
-	})
+	}
+	// secrets are never resolved in a dry run
+	redactedSecrets := map[string]string{}
+	for _, name := range jobSpec.SecretReferences() {
+		redactedSecrets[name] = models.ProjectSecrets{}.String()
+	}
+	dumpContext[models.SecretTemplateKey] = redactedSecrets
+	// task configs are rendered with the same values, project configs
+	// referred by them are not available in a dry run
+	for _, config := range jobSpec.Task.Config {
+		compiledConfig, err := engine.CompileString(config.Value, dumpContext)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compile task config %s", config.Name)
+		}
+		dumpContext[TaskConfigPrefix+config.Name] = compiledConfig
+	}
+	templates, err := engine.CompileFiles(assetsToDump, dumpContext)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to compile templates")
 	}
@@ -141,6 +141,288 @@ func TestContextManager(t *testing.T) {
 				fileMap["query.sql"],
 			)
 		})
+		t.Run("should return compiled instanceSpec config referring project config", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "humara-projectSpec",
+				Config: map[string]string{
+					"STAGING_DATASET": "proj.staging",
+					"BUCKET":          "gs://some_folder",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "namespace-1",
+				Config: map[string]string{
+					"BUCKET": "gs://namespace_folder",
+				},
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit := new(mock.TaskPlugin)
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: execUnit,
+					Window: models.JobSpecTaskWindow{
+						Size:       time.Hour,
+						Offset:     0,
+						TruncateTo: "d",
+					},
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "DATASET",
+							Value: "{{.proj.STAGING_DATASET}}",
+						},
+						{
+							Name:  "BUCKET",
+							Value: "{{.proj.BUCKET}}",
+						},
+					},
+				},
+				Assets: *models.JobAssets{}.New(
+					[]models.JobSpecAsset{
+						{
+							Name:  "query.sql",
+							Value: "select * from `{{.proj.STAGING_DATASET}}.table`",
+						},
+					},
+				),
+			}
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.InstanceStateRunning,
+			}
+
+			execUnit.On("CompileTaskAssets", context.TODO(), models.CompileTaskAssetsRequest{
+				TaskWindow:       jobSpec.Task.Window,
+				Config:           models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+				Assets:           models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets),
+				InstanceSchedule: scheduledAt,
+			}).Return(models.CompileTaskAssetsResponse{Assets: models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets)}, nil)
+			defer execUnit.AssertExpectations(t)
+
+			envMap, fileMap, err := instance.NewContextManager(namespaceSpec, jobSpec,
+				instance.NewGoEngine()).Generate(instanceSpec, models.InstanceTypeTask, "bq")
+			assert.Nil(t, err)
+			assert.Equal(t, "proj.staging", envMap["DATASET"])
+			assert.Equal(t, "gs://namespace_folder", envMap["BUCKET"])
+			assert.Equal(t, "select * from `proj.staging.table`", fileMap["query.sql"])
+		})
+		t.Run("should return config compiled with global configs overridden by project configs", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "namespace-1",
+				Config: map[string]string{
+					"BUCKET": "gs://namespace_folder",
+				},
+				ProjectSpec: models.ProjectSpec{
+					ID:   uuid.Must(uuid.NewRandom()),
+					Name: "humara-projectSpec",
+					Config: map[string]string{
+						"STAGING_DATASET": "proj.staging",
+						"BUCKET":          "gs://some_folder",
+					},
+				},
+			}
+
+			execUnit := new(mock.TaskPlugin)
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: execUnit,
+					Config: models.JobSpecConfigs{
+						{Name: "DATASET", Value: "{{.proj.STAGING_DATASET}}"},
+						{Name: "BUCKET", Value: "{{.proj.BUCKET}}"},
+						{Name: "BROKERS", Value: "{{.proj.KAFKA_BROKERS}}"},
+						{Name: "GLOBAL_DATASET", Value: "{{.global.STAGING_DATASET}}"},
+					},
+				},
+				Assets: *models.JobAssets{}.New(nil),
+			}
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.InstanceStateRunning,
+			}
+
+			execUnit.On("CompileTaskAssets", context.TODO(), models.CompileTaskAssetsRequest{
+				TaskWindow:       jobSpec.Task.Window,
+				Config:           models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+				Assets:           models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets),
+				InstanceSchedule: scheduledAt,
+			}).Return(models.CompileTaskAssetsResponse{Assets: models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets)}, nil)
+			defer execUnit.AssertExpectations(t)
+
+			contextManager := instance.NewContextManager(namespaceSpec, jobSpec, instance.NewGoEngine())
+			contextManager.GlobalConfig = map[string]string{
+				"STAGING_DATASET": "global.staging",
+				"BUCKET":          "gs://global_folder",
+				"KAFKA_BROKERS":   "localhost:9092",
+			}
+			envMap, _, err := contextManager.Generate(instanceSpec, models.InstanceTypeTask, "bq")
+			assert.Nil(t, err)
+			assert.Equal(t, "proj.staging", envMap["DATASET"])
+			assert.Equal(t, "gs://namespace_folder", envMap["BUCKET"])
+			assert.Equal(t, "localhost:9092", envMap["BROKERS"])
+			assert.Equal(t, "global.staging", envMap["GLOBAL_DATASET"])
+		})
+		t.Run("should return config and assets compiled with project secrets", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "humara-projectSpec",
+				Secret: models.ProjectSecrets{
+					{Name: "BQ_SERVICE_ACCOUNT", Value: "base64-account"},
+					{Name: "API_KEY", Value: "some-key"},
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit := new(mock.TaskPlugin)
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: execUnit,
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "SERVICE_ACCOUNT",
+							Value: "{{ .secret.BQ_SERVICE_ACCOUNT }}",
+						},
+					},
+				},
+				Assets: *models.JobAssets{}.New(
+					[]models.JobSpecAsset{
+						{
+							Name:  "request.json",
+							Value: `{"key": "{{.secret.API_KEY}}"}`,
+						},
+					},
+				),
+			}
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.InstanceStateRunning,
+			}
+
+			execUnit.On("CompileTaskAssets", context.TODO(), models.CompileTaskAssetsRequest{
+				TaskWindow:       jobSpec.Task.Window,
+				Config:           models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+				Assets:           models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets),
+				InstanceSchedule: scheduledAt,
+			}).Return(models.CompileTaskAssetsResponse{Assets: models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets)}, nil)
+			defer execUnit.AssertExpectations(t)
+
+			envMap, fileMap, err := instance.NewContextManager(namespaceSpec, jobSpec,
+				instance.NewGoEngine()).Generate(instanceSpec, models.InstanceTypeTask, "bq")
+			assert.Nil(t, err)
+			assert.Equal(t, "base64-account", envMap["SERVICE_ACCOUNT"])
+			assert.Equal(t, `{"key": "some-key"}`, fileMap["request.json"])
+		})
+		t.Run("should fail if a referred secret is not available", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "namespace-1",
+				ProjectSpec: models.ProjectSpec{
+					ID:   uuid.Must(uuid.NewRandom()),
+					Name: "humara-projectSpec",
+				},
+			}
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: new(mock.TaskPlugin),
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "SERVICE_ACCOUNT",
+							Value: "{{ .secret.BQ_SERVICE_ACCOUNT }}",
+						},
+					},
+				},
+				Assets: *models.JobAssets{}.New(nil),
+			}
+
+			_, _, err := instance.NewContextManager(namespaceSpec, jobSpec,
+				instance.NewGoEngine()).Generate(models.InstanceSpec{Job: jobSpec}, models.InstanceTypeTask, "bq")
+			assert.EqualError(t, err, "secrets BQ_SERVICE_ACCOUNT referred by job foo: missing secret")
+			assert.ErrorIs(t, err, instance.ErrMissingSecret)
+		})
+		t.Run("should return assets compiled with task configs", func(t *testing.T) {
+			projectSpec := models.ProjectSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "humara-projectSpec",
+				Config: map[string]string{
+					"STAGING_DATASET": "proj.staging",
+				},
+			}
+			namespaceSpec := models.NamespaceSpec{
+				ID:          uuid.Must(uuid.NewRandom()),
+				Name:        "namespace-1",
+				ProjectSpec: projectSpec,
+			}
+
+			execUnit := new(mock.TaskPlugin)
+			jobSpec := models.JobSpec{
+				Name: "foo",
+				Task: models.JobSpecTask{
+					Unit: execUnit,
+					Window: models.JobSpecTaskWindow{
+						Size:       time.Hour,
+						Offset:     0,
+						TruncateTo: "d",
+					},
+					Config: models.JobSpecConfigs{
+						{
+							Name:  "DATASET",
+							Value: "{{.proj.STAGING_DATASET}}",
+						},
+					},
+				},
+				Assets: *models.JobAssets{}.New(
+					[]models.JobSpecAsset{
+						{
+							Name:  "query.sql",
+							Value: "select * from `{{.TASK__DATASET}}.table` where dt = '{{.DSTART}}'",
+						},
+					},
+				),
+			}
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.InstanceStateRunning,
+				Data: []models.InstanceSpecData{
+					{
+						Name:  instance.ConfigKeyDstart,
+						Value: "2020-11-10T00:00:00Z",
+						Type:  models.InstanceDataTypeEnv,
+					},
+				},
+			}
+
+			execUnit.On("CompileTaskAssets", context.TODO(), models.CompileTaskAssetsRequest{
+				TaskWindow:       jobSpec.Task.Window,
+				Config:           models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+				Assets:           models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets),
+				InstanceSchedule: scheduledAt,
+				InstanceData:     instanceSpec.Data,
+			}).Return(models.CompileTaskAssetsResponse{Assets: models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets)}, nil)
+			defer execUnit.AssertExpectations(t)
+
+			_, fileMap, err := instance.NewContextManager(namespaceSpec, jobSpec,
+				instance.NewGoEngine()).Generate(instanceSpec, models.InstanceTypeTask, "bq")
+			assert.Nil(t, err)
+			assert.Equal(t, "select * from `proj.staging.table` where dt = '2020-11-10T00:00:00Z'", fileMap["query.sql"])
+		})
 		t.Run("should return valid compiled instanceSpec config for task type hook", func(t *testing.T) {
 			projectName := "humara-projectSpec"
 			projectSpec := models.ProjectSpec{
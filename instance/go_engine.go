@@ -30,7 +30,7 @@ func (e *GoEngine) CompileFiles(files map[string]string, context map[string]inte
 	for name, content := range files {
 		root, err = root.New(name).Parse(content)
 		if err != nil {
-			return nil, err
+			return nil, models.NewTemplateError(err)
 		}
 	}
 	// render templates
@@ -43,7 +43,7 @@ func (e *GoEngine) CompileFiles(files map[string]string, context map[string]inte
 		var buf bytes.Buffer
 		err = root.ExecuteTemplate(&buf, name, context)
 		if err != nil {
-			return nil, err
+			return nil, models.NewTemplateError(err)
 		}
 		rendered[name] = buf.String()
 	}
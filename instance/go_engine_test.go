@@ -1,9 +1,11 @@
 package instance_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/odpf/optimus/instance"
+	"github.com/odpf/optimus/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -129,5 +131,17 @@ func TestGoEngine(t *testing.T) {
 				assert.Equal(t, testCase.Expected, compiledExpr)
 			}
 		})
+		t.Run("should return location of the broken line of asset in error", func(t *testing.T) {
+			comp := instance.NewGoEngine()
+			_, err := comp.CompileFiles(map[string]string{
+				"query.sql": "select *\nfrom table\nwhere date = {{ .DSTART | unknownFn }}",
+			}, map[string]interface{}{
+				"DSTART": "2021-02-10T10:00:00+00:00",
+			})
+
+			var tmplErr *models.TemplateError
+			assert.True(t, errors.As(err, &tmplErr))
+			assert.Equal(t, "query.sql:3", tmplErr.Location())
+		})
 	})
 }
@@ -2,6 +2,9 @@ package instance
 
 import (
 	"context"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -16,6 +19,15 @@ const (
 	ConfigKeyDend          = "DEND"
 	ConfigKeyExecutionTime = "EXECUTION_TIME"
 	ConfigKeyDestination   = "JOB_DESTINATION"
+
+	// ConfigKeyDependencies lists names of resolved dependencies of the job, window of
+	// each is available as DSTART__<NAME> and DEND__<NAME> where NAME is the job
+	// name uppercased with characters other than letters and digits replaced by _
+	ConfigKeyDependencies = "JOB_DEPENDENCIES"
+)
+
+var (
+	dependencyKeyReplaceRegex = regexp.MustCompile(`[^A-Z0-9]`)
 )
 
 type InstanceSpecRepoFactory interface {
@@ -26,14 +38,21 @@ type Service struct {
 	repoFac        InstanceSpecRepoFactory
 	Now            func() time.Time
 	templateEngine models.TemplateEngine
+
+	// GlobalConfig is shared by jobs of all projects
+	GlobalConfig map[string]string
 }
 
+// Compile generates the context of an instance based on its type, task
+// instances get the task configs while hook instances get configs of the
+// requested hook along with task configs prefixed by TaskConfigPrefix.
+// Both can refer the resolved destination of the task. Remote assets of
+// job are expected to be resolved already
 func (s *Service) Compile(namespace models.NamespaceSpec, jobSpec models.JobSpec, instanceSpec models.InstanceSpec,
 	runType models.InstanceType, runName string) (envMap map[string]string, fileMap map[string]string, err error) {
-	return NewContextManager(
-		namespace, jobSpec, s.templateEngine).Generate(
-		instanceSpec, runType, runName,
-	)
+	contextManager := NewContextManager(namespace, jobSpec, s.templateEngine)
+	contextManager.GlobalConfig = s.GlobalConfig
+	return contextManager.Generate(instanceSpec, runType, runName)
 }
 
 func (s *Service) Register(jobSpec models.JobSpec, scheduledAt time.Time,
@@ -77,6 +96,16 @@ func (s *Service) Register(jobSpec models.JobSpec, scheduledAt time.Time,
 	return instanceSpec, nil
 }
 
+// Get returns the instance registered earlier for the job scheduled at
+// provided time, store.ErrResourceNotFound is returned if there is none
+func (s *Service) Get(jobSpec models.JobSpec, scheduledAt time.Time) (models.InstanceSpec, error) {
+	instanceSpec, err := s.repoFac.New(jobSpec).GetByScheduledAt(scheduledAt)
+	if err != nil {
+		return models.InstanceSpec{}, errors.Wrapf(err, "failed to get instance scheduled at: %s", scheduledAt.String())
+	}
+	return instanceSpec, nil
+}
+
 func (s *Service) PrepInstance(jobSpec models.JobSpec, scheduledAt time.Time) (models.InstanceSpec, error) {
 	jobDestination, err := jobSpec.Task.Unit.GenerateTaskDestination(context.TODO(), models.GenerateTaskDestinationRequest{
 		Config: models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
@@ -85,36 +114,97 @@ func (s *Service) PrepInstance(jobSpec models.JobSpec, scheduledAt time.Time) (m
 	if err != nil {
 		return models.InstanceSpec{}, errors.Wrapf(err, "failed to generate destination for job %s", jobSpec.Name)
 	}
+	loc, err := jobSpec.Schedule.Location()
+	if err != nil {
+		return models.InstanceSpec{}, errors.Wrapf(err, "failed to prepare instance of job %s", jobSpec.Name)
+	}
+	// macros resolve in timezone of the job schedule
+	localScheduledAt := scheduledAt.In(loc)
+
+	// append optimus configs based on the values of a specific JobRun eg, jobScheduledTime
+	instanceData := []models.InstanceSpecData{
+		{
+			Name:  ConfigKeyExecutionTime,
+			Value: s.Now().Format(models.InstanceScheduledAtTimeLayout),
+			Type:  models.InstanceDataTypeEnv,
+		},
+		{
+			Name:  ConfigKeyDstart,
+			Value: jobSpec.Task.Window.GetStart(localScheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+			Type:  models.InstanceDataTypeEnv,
+		},
+		{
+			Name:  ConfigKeyDend,
+			Value: jobSpec.Task.Window.GetEnd(localScheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+			Type:  models.InstanceDataTypeEnv,
+		},
+		{
+			Name:  ConfigKeyDestination,
+			Value: jobDestination.Destination,
+			Type:  models.InstanceDataTypeEnv,
+		},
+	}
+	depWindowData, err := dependencyWindowData(jobSpec, scheduledAt)
+	if err != nil {
+		return models.InstanceSpec{}, errors.Wrapf(err, "failed to prepare instance of job %s", jobSpec.Name)
+	}
+	instanceData = append(instanceData, depWindowData...)
 
 	return models.InstanceSpec{
 		Job:         jobSpec,
 		ScheduledAt: scheduledAt,
 		State:       models.InstanceStateRunning,
+		Data:        instanceData,
+	}, nil
+}
 
-		// append optimus configs based on the values of a specific JobRun eg, jobScheduledTime
-		Data: []models.InstanceSpecData{
-			{
-				Name:  ConfigKeyExecutionTime,
-				Value: s.Now().Format(models.InstanceScheduledAtTimeLayout),
-				Type:  models.InstanceDataTypeEnv,
-			},
-			{
-				Name:  ConfigKeyDstart,
-				Value: jobSpec.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
-				Type:  models.InstanceDataTypeEnv,
-			},
-			{
-				Name:  ConfigKeyDend,
-				Value: jobSpec.Task.Window.GetEnd(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
-				Type:  models.InstanceDataTypeEnv,
-			},
-			{
-				Name:  ConfigKeyDestination,
-				Value: jobDestination.Destination,
-				Type:  models.InstanceDataTypeEnv,
-			},
+// dependencyWindowData exposes window of each resolved dependency so tasks reading
+// from several sources can template the date range of each one of them, windows
+// are aligned to timezone of the dependency
+func dependencyWindowData(jobSpec models.JobSpec, scheduledAt time.Time) ([]models.InstanceSpecData, error) {
+	var depNames []string
+	for depName, dep := range jobSpec.Dependencies {
+		if dep.Job != nil {
+			depNames = append(depNames, depName)
+		}
+	}
+	if len(depNames) == 0 {
+		return nil, nil
+	}
+	sort.Strings(depNames)
+
+	data := []models.InstanceSpecData{
+		{
+			Name:  ConfigKeyDependencies,
+			Value: strings.Join(depNames, ","),
+			Type:  models.InstanceDataTypeEnv,
 		},
-	}, nil
+	}
+	for _, depName := range depNames {
+		depJob := jobSpec.Dependencies[depName].Job
+		loc, err := depJob.Schedule.Location()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid dependency %s", depName)
+		}
+		window := depJob.Task.Window
+		depKey := DependencyConfigKey(depName)
+		data = append(data, models.InstanceSpecData{
+			Name:  ConfigKeyDstart + "__" + depKey,
+			Value: window.GetStart(scheduledAt.In(loc)).Format(models.InstanceScheduledAtTimeLayout),
+			Type:  models.InstanceDataTypeEnv,
+		}, models.InstanceSpecData{
+			Name:  ConfigKeyDend + "__" + depKey,
+			Value: window.GetEnd(scheduledAt.In(loc)).Format(models.InstanceScheduledAtTimeLayout),
+			Type:  models.InstanceDataTypeEnv,
+		})
+	}
+	return data, nil
+}
+
+// DependencyConfigKey converts name of a dependency to the suffix of its window
+// configs, e.g. DSTART__<key>
+func DependencyConfigKey(depName string) string {
+	return dependencyKeyReplaceRegex.ReplaceAllString(strings.ToUpper(depName), "_")
 }
 
 func NewService(repoFac InstanceSpecRepoFactory, timeFunc func() time.Time, te models.TemplateEngine) *Service {
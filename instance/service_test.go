@@ -248,6 +248,136 @@ func TestService(t *testing.T) {
 			assert.Equal(t, "a random error", err.Error())
 			assert.Equal(t, models.InstanceSpec{}, returnedInstanceSpec)
 		})
+		t.Run("should expose window of each resolved dependency", func(t *testing.T) {
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			hourlyDep := models.JobSpec{
+				Name: "hourly-source",
+				Task: models.JobSpecTask{
+					Window: models.JobSpecTaskWindow{Size: time.Hour, Offset: 0, TruncateTo: "h"},
+				},
+			}
+			weeklyDep := models.JobSpec{
+				Name: "weekly.source",
+				Task: models.JobSpecTask{
+					Window: models.JobSpecTaskWindow{Size: time.Hour * 24 * 7, Offset: 0, TruncateTo: "w"},
+				},
+			}
+			depJobSpec := jobSpec
+			depJobSpec.Dependencies = map[string]models.JobSpecDependency{
+				"weekly.source": {Job: &weeklyDep, Type: models.JobSpecDependencyTypeIntra},
+				"hourly-source": {Job: &hourlyDep, Type: models.JobSpecDependencyTypeIntra},
+			}
+
+			instanceSpec := models.InstanceSpec{
+				Job:         depJobSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.InstanceStateRunning,
+				Data: []models.InstanceSpecData{
+					{
+						Name:  instance.ConfigKeyExecutionTime,
+						Value: mockedTimeNow.Format(models.InstanceScheduledAtTimeLayout),
+						Type:  models.InstanceDataTypeEnv,
+					},
+					{
+						Name:  instance.ConfigKeyDstart,
+						Value: jobSpec.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+						Type:  models.InstanceDataTypeEnv,
+					},
+					{
+						Name:  instance.ConfigKeyDend,
+						Value: jobSpec.Task.Window.GetEnd(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+						Type:  models.InstanceDataTypeEnv,
+					},
+					{
+						Name:  instance.ConfigKeyDestination,
+						Value: "proj.data.tab",
+						Type:  models.InstanceDataTypeEnv,
+					},
+					{
+						Name:  instance.ConfigKeyDependencies,
+						Value: "hourly-source,weekly.source",
+						Type:  models.InstanceDataTypeEnv,
+					},
+					{
+						Name:  "DSTART__HOURLY_SOURCE",
+						Value: hourlyDep.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+						Type:  models.InstanceDataTypeEnv,
+					},
+					{
+						Name:  "DEND__HOURLY_SOURCE",
+						Value: hourlyDep.Task.Window.GetEnd(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+						Type:  models.InstanceDataTypeEnv,
+					},
+					{
+						Name:  "DSTART__WEEKLY_SOURCE",
+						Value: weeklyDep.Task.Window.GetStart(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+						Type:  models.InstanceDataTypeEnv,
+					},
+					{
+						Name:  "DEND__WEEKLY_SOURCE",
+						Value: weeklyDep.Task.Window.GetEnd(scheduledAt).Format(models.InstanceScheduledAtTimeLayout),
+						Type:  models.InstanceDataTypeEnv,
+					},
+				},
+			}
+
+			instanceSpecRepo := new(mock.InstanceSpecRepository)
+			instanceSpecRepo.On("Clear", scheduledAt).Return(nil)
+			instanceSpecRepo.On("Save", instanceSpec).Return(nil)
+			instanceSpecRepo.On("GetByScheduledAt", scheduledAt).Return(instanceSpec, nil)
+			defer instanceSpecRepo.AssertExpectations(t)
+
+			jobRunSpecRep := new(mock.InstanceSpecRepoFactory)
+			jobRunSpecRep.On("New", depJobSpec).Return(instanceSpecRepo, nil)
+			defer jobRunSpecRep.AssertExpectations(t)
+
+			instanceService := instance.NewService(jobRunSpecRep, mockedTimeFunc, nil)
+
+			returnedInstanceSpec, err := instanceService.Register(depJobSpec, scheduledAt, models.InstanceTypeTask)
+			assert.Nil(t, err)
+			assert.Equal(t, instanceSpec, returnedInstanceSpec)
+			assert.Equal(t, "2020-11-10T23:00:00Z", returnedInstanceSpec.Data[5].Value)
+			assert.Equal(t, "2020-11-11T00:00:00Z", returnedInstanceSpec.Data[6].Value)
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("should return the instance saved for schedule time", func(t *testing.T) {
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			instanceSpec := models.InstanceSpec{
+				Job:         jobSpec,
+				ScheduledAt: scheduledAt,
+				State:       models.InstanceStateRunning,
+			}
+
+			instanceSpecRepo := new(mock.InstanceSpecRepository)
+			instanceSpecRepo.On("GetByScheduledAt", scheduledAt).Return(instanceSpec, nil)
+			defer instanceSpecRepo.AssertExpectations(t)
+
+			jobRunSpecRep := new(mock.InstanceSpecRepoFactory)
+			jobRunSpecRep.On("New", jobSpec).Return(instanceSpecRepo, nil)
+			defer jobRunSpecRep.AssertExpectations(t)
+
+			instanceService := instance.NewService(jobRunSpecRep, mockedTimeFunc, nil)
+			returnedInstanceSpec, err := instanceService.Get(jobSpec, scheduledAt)
+			assert.Nil(t, err)
+			assert.Equal(t, instanceSpec, returnedInstanceSpec)
+		})
+		t.Run("should return not found error if instance was never registered", func(t *testing.T) {
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+
+			instanceSpecRepo := new(mock.InstanceSpecRepository)
+			instanceSpecRepo.On("GetByScheduledAt", scheduledAt).Return(models.InstanceSpec{}, store.ErrResourceNotFound)
+			defer instanceSpecRepo.AssertExpectations(t)
+
+			jobRunSpecRep := new(mock.InstanceSpecRepoFactory)
+			jobRunSpecRep.On("New", jobSpec).Return(instanceSpecRepo, nil)
+			defer jobRunSpecRep.AssertExpectations(t)
+
+			instanceService := instance.NewService(jobRunSpecRep, mockedTimeFunc, nil)
+			_, err := instanceService.Get(jobSpec, scheduledAt)
+			assert.True(t, errors.Is(err, store.ErrResourceNotFound))
+		})
 	})
 
 	t.Run("PrepInstance", func(t *testing.T) {
@@ -263,5 +393,109 @@ func TestService(t *testing.T) {
 			assert.Nil(t, err)
 			assert.NotEqual(t, prep1.Data, prep2.Data)
 		})
+		t.Run("should resolve window in timezone of job schedule", func(t *testing.T) {
+			jakartaJobSpec := jobSpec
+			jakartaJobSpec.Schedule.Timezone = "Asia/Jakarta"
+			jakartaJobSpec.Task.Window = models.JobSpecTaskWindow{
+				Size:       24 * time.Hour,
+				Offset:     0,
+				TruncateTo: "d",
+			}
+			// daily at 02:00 Asia/Jakarta
+			scheduledAt := time.Date(2020, 11, 10, 19, 0, 0, 0, time.UTC)
+			srv := instance.NewService(nil, mockedTimeFunc, nil)
+			prep, err := srv.PrepInstance(jakartaJobSpec, scheduledAt)
+			assert.Nil(t, err)
+			assert.Equal(t, scheduledAt, prep.ScheduledAt)
+			assert.Contains(t, prep.Data, models.InstanceSpecData{
+				Name:  instance.ConfigKeyDstart,
+				Value: "2020-11-10T00:00:00+07:00",
+				Type:  models.InstanceDataTypeEnv,
+			})
+			assert.Contains(t, prep.Data, models.InstanceSpecData{
+				Name:  instance.ConfigKeyDend,
+				Value: "2020-11-11T00:00:00+07:00",
+				Type:  models.InstanceDataTypeEnv,
+			})
+		})
+		t.Run("should fail if timezone of job schedule is invalid", func(t *testing.T) {
+			invalidJobSpec := jobSpec
+			invalidJobSpec.Schedule.Timezone = "Mars/Olympus"
+			srv := instance.NewService(nil, mockedTimeFunc, nil)
+			_, err := srv.PrepInstance(invalidJobSpec, time.Date(2020, 11, 10, 19, 0, 0, 0, time.UTC))
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("Compile", func(t *testing.T) {
+		t.Run("should generate context specific to the type of instance registered", func(t *testing.T) {
+			scheduledAt := time.Date(2020, 11, 11, 0, 0, 0, 0, time.UTC)
+			namespaceSpec := models.NamespaceSpec{
+				Name: "namespace-1",
+				ProjectSpec: models.ProjectSpec{
+					Name: "proj",
+				},
+			}
+
+			taskUnit := new(mock.TaskPlugin)
+			taskUnit.On("GenerateTaskDestination", context.TODO(), mock2.AnythingOfType("models.GenerateTaskDestinationRequest")).Return(
+				models.GenerateTaskDestinationResponse{Destination: "proj.data.tab"}, nil)
+			taskUnit.On("CompileTaskAssets", context.TODO(), mock2.AnythingOfType("models.CompileTaskAssetsRequest")).Return(
+				models.CompileTaskAssetsResponse{}, nil)
+			defer taskUnit.AssertExpectations(t)
+
+			hookUnit := new(mock.HookPlugin)
+			hookUnit.On("GetHookSchema", context.Background(), models.GetHookSchemaRequest{}).Return(models.GetHookSchemaResponse{
+				Name: "transporter",
+			}, nil)
+
+			hookJobSpec := jobSpec
+			hookJobSpec.Task = models.JobSpecTask{
+				Unit:   taskUnit,
+				Window: jobSpec.Task.Window,
+				Config: models.JobSpecConfigs{
+					{Name: "LOAD_METHOD", Value: "APPEND"},
+				},
+			}
+			hookJobSpec.Hooks = []models.JobSpecHook{
+				{
+					Config: models.JobSpecConfigs{
+						{Name: "SOURCE_TABLE", Value: "{{.JOB_DESTINATION}}"},
+					},
+					Unit: hookUnit,
+				},
+			}
+
+			jobRunSpecRep := new(mock.InstanceSpecRepoFactory)
+			instanceService := instance.NewService(jobRunSpecRep, mockedTimeFunc, instance.NewGoEngine())
+			registered, err := instanceService.PrepInstance(hookJobSpec, scheduledAt)
+			assert.Nil(t, err)
+
+			instanceSpecRepo := new(mock.InstanceSpecRepository)
+			instanceSpecRepo.On("Clear", scheduledAt).Return(nil)
+			instanceSpecRepo.On("Save", registered).Return(nil).Once()
+			instanceSpecRepo.On("GetByScheduledAt", scheduledAt).Return(registered, nil)
+			defer instanceSpecRepo.AssertExpectations(t)
+
+			jobRunSpecRep.On("New", hookJobSpec).Return(instanceSpecRepo, nil)
+			defer jobRunSpecRep.AssertExpectations(t)
+
+			taskInstance, err := instanceService.Register(hookJobSpec, scheduledAt, models.InstanceTypeTask)
+			assert.Nil(t, err)
+			taskEnvs, _, err := instanceService.Compile(namespaceSpec, hookJobSpec, taskInstance, models.InstanceTypeTask, "bq")
+			assert.Nil(t, err)
+			assert.Equal(t, "APPEND", taskEnvs["LOAD_METHOD"])
+			assert.Equal(t, "proj.data.tab", taskEnvs[instance.ConfigKeyDestination])
+			assert.NotContains(t, taskEnvs, "SOURCE_TABLE")
+			assert.NotContains(t, taskEnvs, instance.TaskConfigPrefix+"LOAD_METHOD")
+
+			hookInstance, err := instanceService.Register(hookJobSpec, scheduledAt, models.InstanceTypeHook)
+			assert.Nil(t, err)
+			hookEnvs, _, err := instanceService.Compile(namespaceSpec, hookJobSpec, hookInstance, models.InstanceTypeHook, "transporter")
+			assert.Nil(t, err)
+			assert.Equal(t, "proj.data.tab", hookEnvs["SOURCE_TABLE"])
+			assert.Equal(t, "proj.data.tab", hookEnvs[instance.ConfigKeyDestination])
+			assert.Equal(t, "APPEND", hookEnvs[instance.TaskConfigPrefix+"LOAD_METHOD"])
+			assert.NotContains(t, hookEnvs, "LOAD_METHOD")
+		})
 	})
 }
@@ -0,0 +1,250 @@
+package job
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultCompileCacheSize is the number of compiled jobs kept in memory
+	DefaultCompileCacheSize = 5000
+)
+
+// CachedCompiler wraps a compiler and memoizes the compiled jobs in a LRU
+// cache keyed by a hash of the job spec and project/namespace configs.
+// Any change in the spec or the configs that feeds compilation produces
+// a different key, hence unchanged jobs skip the template engine
+type CachedCompiler struct {
+	compiler models.JobCompiler
+
+	// seed is mixed in every key, it should change whenever the compiled
+	// output could change without a change in job spec, e.g. scheduler template
+	seed string
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front is the most recently used
+}
+
+type compileCacheEntry struct {
+	Key string
+	Job models.Job
+}
+
+// Compile returns the cached job if the spec is unchanged since last
+// compilation, otherwise compiles it using the wrapped compiler
+func (c *CachedCompiler) Compile(namespaceSpec models.NamespaceSpec, jobSpec models.JobSpec) (models.Job, error) {
+	key, err := c.key(namespaceSpec, jobSpec)
+	if err != nil {
+		return models.Job{}, errors.Wrapf(err, "failed to generate compile cache key for %s", jobSpec.Name)
+	}
+	if compiledJob, ok := c.get(key); ok {
+		return compiledJob, nil
+	}
+
+	compiledJob, err := c.compiler.Compile(namespaceSpec, jobSpec)
+	if err != nil {
+		return models.Job{}, err
+	}
+	c.put(key, compiledJob)
+	return compiledJob, nil
+}
+
+// Len returns number of compiled jobs currently cached
+func (c *CachedCompiler) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Dump writes all cached entries to w, it can be used to persist
+// the cache across restarts
+func (c *CachedCompiler) Dump(w io.Writer) error {
+	c.mu.Lock()
+	entries := make([]compileCacheEntry, 0, c.order.Len())
+	// least recently used first so a restore keeps the same order
+	for elem := c.order.Back(); elem != nil; elem = elem.Prev() {
+		entries = append(entries, elem.Value.(compileCacheEntry))
+	}
+	c.mu.Unlock()
+	return json.NewEncoder(w).Encode(entries)
+}
+
+// Restore loads entries previously written by Dump
+func (c *CachedCompiler) Restore(r io.Reader) error {
+	var entries []compileCacheEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return errors.Wrap(err, "failed to decode compile cache")
+	}
+	for _, entry := range entries {
+		c.put(entry.Key, entry.Job)
+	}
+	return nil
+}
+
+func (c *CachedCompiler) get(key string) (models.Job, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return models.Job{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(compileCacheEntry).Job, true
+}
+
+func (c *CachedCompiler) put(key string, compiledJob models.Job) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = compileCacheEntry{Key: key, Job: compiledJob}
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(compileCacheEntry{Key: key, Job: compiledJob})
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(compileCacheEntry).Key)
+	}
+}
+
+// compileFingerprint holds everything of a job spec and its namespace
+// that can be referenced while compiling, plugins are represented by
+// their schema as the plugin instances itself are not comparable
+type compileFingerprint struct {
+	Seed             string
+	ProjectName      string
+	ProjectConfig    map[string]string
+	ProjectScheduler models.ProjectSchedulerConfig
+	NamespaceName    string
+	NamespaceConfig  map[string]string
+
+	JobID        string
+	Version      int
+	Name         string
+	Description  string
+	Labels       map[string]string
+	Owner        string
+	Schedule     models.JobSpecSchedule
+	Behavior     models.JobSpecBehavior
+	TaskSchema   models.GetTaskSchemaResponse
+	TaskConfig   models.JobSpecConfigs
+	TaskWindow   models.JobSpecTaskWindow
+	TaskPriority int
+	Assets       []models.JobSpecAsset
+	Hooks        []hookFingerprint
+	Dependencies []dependencyFingerprint
+
+	ExternalDependencies models.ExternalDependency
+}
+
+type hookFingerprint struct {
+	Schema models.GetHookSchemaResponse
+	Config models.JobSpecConfigs
+}
+
+type dependencyFingerprint struct {
+	Name        string
+	Type        models.JobSpecDependencyType
+	ProjectName string
+	JobName     string
+	TaskSchema  models.GetTaskSchemaResponse
+}
+
+func (c *CachedCompiler) key(namespaceSpec models.NamespaceSpec, jobSpec models.JobSpec) (string, error) {
+	fp := compileFingerprint{
+		Seed:             c.seed,
+		ProjectName:      namespaceSpec.ProjectSpec.Name,
+		ProjectConfig:    namespaceSpec.ProjectSpec.Config,
+		ProjectScheduler: namespaceSpec.ProjectSpec.Scheduler,
+		NamespaceName:    namespaceSpec.Name,
+		NamespaceConfig:  namespaceSpec.Config,
+		JobID:            jobSpec.ID.String(),
+		Version:          jobSpec.Version,
+		Name:             jobSpec.Name,
+		Description:      jobSpec.Description,
+		Labels:           jobSpec.Labels,
+		Owner:            jobSpec.Owner,
+		Schedule:         jobSpec.Schedule,
+		Behavior:         jobSpec.Behavior,
+		TaskConfig:       jobSpec.Task.Config,
+		TaskWindow:       jobSpec.Task.Window,
+		TaskPriority:     jobSpec.Task.Priority,
+		Assets:           jobSpec.Assets.GetAll(),
+
+		ExternalDependencies: jobSpec.ExternalDependencies,
+	}
+
+	ctx := context.Background()
+	if jobSpec.Task.Unit != nil {
+		schema, err := jobSpec.Task.Unit.GetTaskSchema(ctx, models.GetTaskSchemaRequest{})
+		if err != nil {
+			return "", err
+		}
+		fp.TaskSchema = schema
+	}
+	for _, hook := range jobSpec.Hooks {
+		hookFp := hookFingerprint{Config: hook.Config}
+		if hook.Unit != nil {
+			schema, err := hook.Unit.GetHookSchema(ctx, models.GetHookSchemaRequest{})
+			if err != nil {
+				return "", err
+			}
+			hookFp.Schema = schema
+		}
+		fp.Hooks = append(fp.Hooks, hookFp)
+	}
+	for depName, dep := range jobSpec.Dependencies {
+		depFp := dependencyFingerprint{Name: depName, Type: dep.Type}
+		if dep.Project != nil {
+			depFp.ProjectName = dep.Project.Name
+		}
+		if dep.Job != nil {
+			depFp.JobName = dep.Job.Name
+			if dep.Job.Task.Unit != nil {
+				schema, err := dep.Job.Task.Unit.GetTaskSchema(ctx, models.GetTaskSchemaRequest{})
+				if err != nil {
+					return "", err
+				}
+				depFp.TaskSchema = schema
+			}
+		}
+		fp.Dependencies = append(fp.Dependencies, depFp)
+	}
+	// map iteration is random, keep the key stable
+	sort.Slice(fp.Dependencies, func(i, j int) bool {
+		return fp.Dependencies[i].Name < fp.Dependencies[j].Name
+	})
+
+	raw, err := json.Marshal(fp)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// NewCachedCompiler creates a compiler that caches up to size compiled jobs
+func NewCachedCompiler(compiler models.JobCompiler, size int, seed string) *CachedCompiler {
+	if size <= 0 {
+		size = DefaultCompileCacheSize
+	}
+	return &CachedCompiler{
+		compiler: compiler,
+		seed:     seed,
+		size:     size,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
@@ -0,0 +1,209 @@
+package job_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedCompiler(t *testing.T) {
+	execUnit := new(mock.TaskPlugin)
+	execUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+		Name:  "bq",
+		Image: "example.io/namespace/image:latest",
+	}, nil)
+
+	projSpec := models.ProjectSpec{
+		Name: "foo-project",
+		Config: map[string]string{
+			"bucket": "gs://some_folder",
+		},
+	}
+	namespaceSpec := models.NamespaceSpec{
+		Name:        "foo-namespace",
+		ProjectSpec: projSpec,
+	}
+	spec := models.JobSpec{
+		Name:  "foo",
+		Owner: "mee@mee",
+		Schedule: models.JobSpecSchedule{
+			StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
+			Interval:  "* * * * *",
+		},
+		Task: models.JobSpecTask{
+			Unit:     execUnit,
+			Priority: 2000,
+		},
+		Dependencies: map[string]models.JobSpecDependency{},
+	}
+	compiledJob := models.Job{
+		Name:     spec.Name,
+		Contents: []byte("compiled"),
+	}
+
+	t.Run("should compile only once for identical specs", func(t *testing.T) {
+		compiler := new(mock.Compiler)
+		compiler.On("Compile", namespaceSpec, spec).Return(compiledJob, nil).Once()
+		defer compiler.AssertExpectations(t)
+
+		cachedCompiler := job.NewCachedCompiler(compiler, 10, "")
+		for i := 0; i < 3; i++ {
+			got, err := cachedCompiler.Compile(namespaceSpec, spec)
+			assert.Nil(t, err)
+			assert.Equal(t, compiledJob, got)
+		}
+		assert.Equal(t, 1, cachedCompiler.Len())
+	})
+	t.Run("should recompile if project config changes", func(t *testing.T) {
+		changedNamespaceSpec := namespaceSpec
+		changedNamespaceSpec.ProjectSpec.Config = map[string]string{
+			"bucket": "gs://another_folder",
+		}
+
+		compiler := new(mock.Compiler)
+		compiler.On("Compile", namespaceSpec, spec).Return(compiledJob, nil).Once()
+		compiler.On("Compile", changedNamespaceSpec, spec).Return(compiledJob, nil).Once()
+		defer compiler.AssertExpectations(t)
+
+		cachedCompiler := job.NewCachedCompiler(compiler, 10, "")
+		_, err := cachedCompiler.Compile(namespaceSpec, spec)
+		assert.Nil(t, err)
+		_, err = cachedCompiler.Compile(changedNamespaceSpec, spec)
+		assert.Nil(t, err)
+	})
+	t.Run("should recompile if job spec changes", func(t *testing.T) {
+		changedSpec := spec
+		changedSpec.Owner = "you@you"
+
+		compiler := new(mock.Compiler)
+		compiler.On("Compile", namespaceSpec, spec).Return(compiledJob, nil).Once()
+		compiler.On("Compile", namespaceSpec, changedSpec).Return(compiledJob, nil).Once()
+		defer compiler.AssertExpectations(t)
+
+		cachedCompiler := job.NewCachedCompiler(compiler, 10, "")
+		_, err := cachedCompiler.Compile(namespaceSpec, spec)
+		assert.Nil(t, err)
+		_, err = cachedCompiler.Compile(namespaceSpec, changedSpec)
+		assert.Nil(t, err)
+	})
+	t.Run("should not cache failed compilations", func(t *testing.T) {
+		compiler := new(mock.Compiler)
+		compiler.On("Compile", namespaceSpec, spec).Return(models.Job{}, errors.New("random error")).Twice()
+		defer compiler.AssertExpectations(t)
+
+		cachedCompiler := job.NewCachedCompiler(compiler, 10, "")
+		_, err := cachedCompiler.Compile(namespaceSpec, spec)
+		assert.NotNil(t, err)
+		_, err = cachedCompiler.Compile(namespaceSpec, spec)
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, cachedCompiler.Len())
+	})
+	t.Run("should evict least recently used jobs over the size limit", func(t *testing.T) {
+		otherSpec := spec
+		otherSpec.Name = "bar"
+
+		compiler := new(mock.Compiler)
+		compiler.On("Compile", namespaceSpec, spec).Return(compiledJob, nil).Twice()
+		compiler.On("Compile", namespaceSpec, otherSpec).Return(compiledJob, nil).Once()
+		defer compiler.AssertExpectations(t)
+
+		cachedCompiler := job.NewCachedCompiler(compiler, 1, "")
+		_, err := cachedCompiler.Compile(namespaceSpec, spec)
+		assert.Nil(t, err)
+		_, err = cachedCompiler.Compile(namespaceSpec, otherSpec)
+		assert.Nil(t, err)
+		_, err = cachedCompiler.Compile(namespaceSpec, spec)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, cachedCompiler.Len())
+	})
+	t.Run("should restore dumped entries", func(t *testing.T) {
+		compiler := new(mock.Compiler)
+		compiler.On("Compile", namespaceSpec, spec).Return(compiledJob, nil).Once()
+		defer compiler.AssertExpectations(t)
+
+		cachedCompiler := job.NewCachedCompiler(compiler, 10, "seed")
+		_, err := cachedCompiler.Compile(namespaceSpec, spec)
+		assert.Nil(t, err)
+
+		var buf bytes.Buffer
+		assert.Nil(t, cachedCompiler.Dump(&buf))
+
+		restoredCompiler := job.NewCachedCompiler(compiler, 10, "seed")
+		assert.Nil(t, restoredCompiler.Restore(&buf))
+		got, err := restoredCompiler.Compile(namespaceSpec, spec)
+		assert.Nil(t, err)
+		assert.Equal(t, compiledJob, got)
+	})
+	t.Run("should not use restored entries if seed changes", func(t *testing.T) {
+		compiler := new(mock.Compiler)
+		compiler.On("Compile", namespaceSpec, spec).Return(compiledJob, nil).Twice()
+		defer compiler.AssertExpectations(t)
+
+		cachedCompiler := job.NewCachedCompiler(compiler, 10, "seed")
+		_, err := cachedCompiler.Compile(namespaceSpec, spec)
+		assert.Nil(t, err)
+
+		var buf bytes.Buffer
+		assert.Nil(t, cachedCompiler.Dump(&buf))
+
+		restoredCompiler := job.NewCachedCompiler(compiler, 10, "another-seed")
+		assert.Nil(t, restoredCompiler.Restore(&buf))
+		_, err = restoredCompiler.Compile(namespaceSpec, spec)
+		assert.Nil(t, err)
+	})
+}
+
+func BenchmarkCachedCompiler(b *testing.B) {
+	execUnit := new(mock.TaskPlugin)
+	execUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+		Name:  "bq",
+		Image: "example.io/namespace/image:latest",
+	}, nil)
+
+	namespaceSpec := models.NamespaceSpec{
+		Name: "foo-namespace",
+		ProjectSpec: models.ProjectSpec{
+			Name: "foo-project",
+		},
+	}
+	spec := models.JobSpec{
+		Name:  "foo",
+		Owner: "mee@mee",
+		Schedule: models.JobSpecSchedule{
+			StartDate: time.Date(2000, 11, 11, 0, 0, 0, 0, time.UTC),
+			Interval:  "* * * * *",
+		},
+		Task: models.JobSpecTask{
+			Unit:     execUnit,
+			Priority: 2000,
+		},
+		Dependencies: map[string]models.JobSpecDependency{},
+	}
+	template := []byte(`{{.Job.Name}} {{.Job.Owner}} {{.Job.Schedule.Interval}} {{$s := .Job.Task.Unit.GetTaskSchema .Context .TaskSchemaRequest}}{{$s.Name}} {{$s.Image}}`)
+
+	b.Run("without cache", func(b *testing.B) {
+		compiler := job.NewCompiler(template, "http://airflow.io")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := compiler.Compile(namespaceSpec, spec); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("with cache", func(b *testing.B) {
+		compiler := job.NewCachedCompiler(job.NewCompiler(template, "http://airflow.io"), 10, "")
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := compiler.Compile(namespaceSpec, spec); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -3,6 +3,7 @@ package job
 import (
 	"bytes"
 	"context"
+	"sync"
 	"text/template"
 	"time"
 
@@ -31,7 +32,7 @@ func (com *Compiler) Compile(namespaceSpec models.NamespaceSpec, jobSpec models.
 
 	tmpl, err := template.New("compiler").Funcs(sprig.TxtFuncMap()).Parse(string(com.schedulerTemplate))
 	if err != nil {
-		return models.Job{}, err
+		return models.Job{}, models.NewTemplateError(err)
 	}
 
 	var slaMissDurationInSec int64
@@ -83,7 +84,7 @@ func (com *Compiler) Compile(namespaceSpec models.NamespaceSpec, jobSpec models.
 		JobSpecDependencyTypeExtra: string(models.JobSpecDependencyTypeExtra),
 		SLAMissDurationInSec:       slaMissDurationInSec,
 	}); err != nil {
-		return models.Job{}, errors.Wrap(err, "failed to templatize job")
+		return models.Job{}, errors.Wrap(models.NewTemplateError(err), "failed to templatize job")
 	}
 
 	return models.Job{
@@ -100,3 +101,43 @@ func NewCompiler(schedulerTemplate []byte, hostname string) *Compiler {
 		hostname:          hostname,
 	}
 }
+
+// ProjectCompiler compiles jobs using template of the scheduler configured
+// for their project, projects can be on different schedulers or different
+// versions of the same scheduler
+type ProjectCompiler struct {
+	projectScheduler func(models.ProjectSpec) (models.SchedulerUnit, error)
+	hostname         string
+
+	mu        sync.Mutex
+	compilers map[string]*Compiler
+}
+
+func (com *ProjectCompiler) Compile(namespaceSpec models.NamespaceSpec, jobSpec models.JobSpec) (models.Job, error) {
+	scheduler, err := com.projectScheduler(namespaceSpec.ProjectSpec)
+	if err != nil {
+		return models.Job{}, errors.Wrapf(err, "failed to find scheduler of project %s", namespaceSpec.ProjectSpec.Name)
+	}
+	return com.compilerOf(scheduler).Compile(namespaceSpec, jobSpec)
+}
+
+func (com *ProjectCompiler) compilerOf(scheduler models.SchedulerUnit) *Compiler {
+	com.mu.Lock()
+	defer com.mu.Unlock()
+	compiler, ok := com.compilers[scheduler.GetName()]
+	if !ok {
+		compiler = NewCompiler(scheduler.GetTemplate(), com.hostname)
+		com.compilers[scheduler.GetName()] = compiler
+	}
+	return compiler
+}
+
+// NewProjectCompiler constructs a compiler picking the scheduler template
+// of a job using projectScheduler
+func NewProjectCompiler(projectScheduler func(models.ProjectSpec) (models.SchedulerUnit, error), hostname string) *ProjectCompiler {
+	return &ProjectCompiler{
+		projectScheduler: projectScheduler,
+		hostname:         hostname,
+		compilers:        map[string]*Compiler{},
+	}
+}
@@ -1,6 +1,7 @@
 package job_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -81,6 +82,16 @@ func TestCompiler(t *testing.T) {
 			assert.Equal(t, dag.Contents, []byte("content = foo"))
 			assert.Nil(t, err)
 		})
+		t.Run("should render job behavior in compiled template", func(t *testing.T) {
+			com := job.NewCompiler(
+				[]byte("retries = {{.Job.Behavior.Retry.Count}}, retry_delay = {{.Job.Behavior.Retry.Delay.Seconds}}, catchup = {{.Job.Behavior.CatchUp}}, notify = {{range .Job.Behavior.Notify}}{{.On}}{{end}}"),
+				"",
+			)
+			dag, err := com.Compile(namespaceSpec, spec)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "retries = 2, retry_delay = 2, catchup = true, notify = sla_miss", string(dag.Contents))
+		})
 		t.Run("should return error if failed to read template", func(t *testing.T) {
 			com := job.NewCompiler(
 				[]byte(""),
@@ -97,5 +108,88 @@ func TestCompiler(t *testing.T) {
 			_, err := com.Compile(namespaceSpec, spec)
 			assert.Error(t, err)
 		})
+		t.Run("should return location of the broken template line in error", func(t *testing.T) {
+			com := job.NewCompiler(
+				[]byte("name = {{.Job.Name}}\nowner = {{.Job.Owner}}\ncontent = {{.Tob.Name}}"),
+				"",
+			)
+			_, err := com.Compile(namespaceSpec, spec)
+
+			var tmplErr *models.TemplateError
+			assert.True(t, errors.As(err, &tmplErr))
+			assert.Equal(t, "compiler", tmplErr.Name)
+			assert.Equal(t, 3, tmplErr.Line)
+			assert.Contains(t, err.Error(), "compiler:3:")
+		})
+		t.Run("should return location of the template line failing to parse", func(t *testing.T) {
+			com := job.NewCompiler(
+				[]byte("name = {{.Job.Name}}\ncontent = {{.Job.Name"),
+				"",
+			)
+			_, err := com.Compile(namespaceSpec, spec)
+
+			var tmplErr *models.TemplateError
+			assert.True(t, errors.As(err, &tmplErr))
+			assert.Equal(t, "compiler:2", tmplErr.Location())
+		})
+	})
+}
+
+// templateScheduler is a scheduler with its own name and compile template
+type templateScheduler struct {
+	*mock.Scheduler
+	name     string
+	template string
+}
+
+func (s *templateScheduler) GetName() string {
+	return s.name
+}
+
+func (s *templateScheduler) GetTemplate() []byte {
+	return []byte(s.template)
+}
+
+func TestProjectCompiler(t *testing.T) {
+	spec := models.JobSpec{
+		Name: "foo",
+	}
+	schedulers := map[string]models.SchedulerUnit{
+		"airflow":  &templateScheduler{name: "airflow", template: "v1 {{.Job.Name}}"},
+		"airflow2": &templateScheduler{name: "airflow2", template: "v2 {{.Job.Name}}"},
+	}
+	projectScheduler := func(proj models.ProjectSpec) (models.SchedulerUnit, error) {
+		schd, ok := schedulers[proj.Scheduler.Type]
+		if !ok {
+			return nil, models.ErrUnsupportedScheduler
+		}
+		return schd, nil
+	}
+
+	t.Run("should compile jobs with template of the scheduler of their project", func(t *testing.T) {
+		com := job.NewProjectCompiler(projectScheduler, "")
+		for schedulerType, expected := range map[string]string{"airflow": "v1 foo", "airflow2": "v2 foo"} {
+			namespaceSpec := models.NamespaceSpec{
+				Name: "foo-namespace",
+				ProjectSpec: models.ProjectSpec{
+					Name:      "foo-project",
+					Scheduler: models.ProjectSchedulerConfig{Type: schedulerType},
+				},
+			}
+			compiledJob, err := com.Compile(namespaceSpec, spec)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, string(compiledJob.Contents))
+		}
+	})
+	t.Run("should fail if scheduler of project is not supported", func(t *testing.T) {
+		com := job.NewProjectCompiler(projectScheduler, "")
+		namespaceSpec := models.NamespaceSpec{
+			ProjectSpec: models.ProjectSpec{
+				Name:      "foo-project",
+				Scheduler: models.ProjectSchedulerConfig{Type: "cron"},
+			},
+		}
+		_, err := com.Compile(namespaceSpec, spec)
+		assert.True(t, errors.Is(err, models.ErrUnsupportedScheduler))
 	})
 }
@@ -2,6 +2,9 @@ package job
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/odpf/optimus/core/progress"
 	"github.com/odpf/optimus/models"
@@ -43,27 +46,99 @@ func (r *dependencyResolver) Resolve(projectSpec models.ProjectSpec, projectJobS
 	return jobSpec, nil
 }
 
-func (r *dependencyResolver) resolveInferredDependencies(jobSpec models.JobSpec, projectSpec models.ProjectSpec,
-	projectJobSpecRepo store.ProjectJobSpecRepository, observer progress.Observer) (models.JobSpec, error) {
+// Explain tells for every destination used by the job which jobs were
+// considered as its dependency and why they were picked or not
+func (r *dependencyResolver) Explain(projectSpec models.ProjectSpec, projectJobSpecRepo store.ProjectJobSpecRepository,
+	jobSpec models.JobSpec) ([]models.DependencyExplanation, error) {
+	jobDependenciesDestination, err := r.generateDependencyDestinations(jobSpec, projectSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var explanations []models.DependencyExplanation
+	for _, depDestination := range jobDependenciesDestination.Dependencies {
+		depURN := models.DestinationURN("", depDestination)
+		explanation := models.DependencyExplanation{Destination: depURN}
+
+		// resolve the same way as while deploying to know which candidate gets picked
+		depSpec, depProj, err := projectJobSpecRepo.GetByDestination(depURN)
+		if err != nil && err != store.ErrResourceNotFound {
+			return nil, errors.Wrap(err, "runtime dependency evaluation failed")
+		}
+		explanation.Resolved = err == nil
+
+		candidates, err := projectJobSpecRepo.GetAllByDestination(depURN)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find jobs producing %s", depURN)
+		}
+		for _, candidate := range candidates {
+			picked := explanation.Resolved && candidate.Job.Name == depSpec.Name && candidate.Project.Name == depProj.Name
+			explanation.Candidates = append(explanation.Candidates, models.DependencyCandidate{
+				Job:     candidate.Job.Name,
+				Project: candidate.Project.Name,
+				Matched: picked,
+				Reason:  r.explainCandidate(candidate, picked, len(candidates), projectSpec.Name, depURN),
+			})
+		}
+
+		// jobs storing destination without its type are never matched
+		if depURN != depDestination {
+			legacyCandidates, err := projectJobSpecRepo.GetAllByDestination(depDestination)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to find jobs producing %s", depDestination)
+			}
+			for _, candidate := range legacyCandidates {
+				explanation.Candidates = append(explanation.Candidates, models.DependencyCandidate{
+					Job:     candidate.Job.Name,
+					Project: candidate.Project.Name,
+					Reason:  fmt.Sprintf("destination urn mismatch, produces %s instead of %s", depDestination, depURN),
+				})
+			}
+		}
+		explanations = append(explanations, explanation)
+	}
+	return explanations, nil
+}
+
+func (r *dependencyResolver) explainCandidate(candidate models.ProjectJobPair, picked bool, totalCandidates int,
+	currentJobSpecProject, destination string) string {
+	if !picked {
+		return fmt.Sprintf("ambiguous, %d jobs produce %s and another one was picked", totalCandidates, destination)
+	}
+	depType := r.getJobSpecDependencyType(models.JobSpecDependency{Project: &candidate.Project}, currentJobSpecProject)
+	if totalCandidates > 1 {
+		return fmt.Sprintf("ambiguous, picked as %s dependency out of %d jobs producing %s", depType, totalCandidates, destination)
+	}
+	return fmt.Sprintf("produces %s, picked as %s dependency", destination, depType)
+}
+
+func (r *dependencyResolver) generateDependencyDestinations(jobSpec models.JobSpec, projectSpec models.ProjectSpec) (models.GenerateTaskDependenciesResponse, error) {
 	// get destinations of dependencies, assets should be
-	jobDependenciesDestination, err := jobSpec.Task.Unit.GenerateTaskDependencies(context.TODO(),
+	return jobSpec.Task.Unit.GenerateTaskDependencies(context.TODO(),
 		models.GenerateTaskDependenciesRequest{
 			Config:  models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
 			Assets:  models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets),
 			Project: projectSpec,
 		},
 	)
+}
+
+func (r *dependencyResolver) resolveInferredDependencies(jobSpec models.JobSpec, projectSpec models.ProjectSpec,
+	projectJobSpecRepo store.ProjectJobSpecRepository, observer progress.Observer) (models.JobSpec, error) {
+	jobDependenciesDestination, err := r.generateDependencyDestinations(jobSpec, projectSpec)
 	if err != nil {
 		return models.JobSpec{}, err
 	}
 
 	// get job spec of these destinations and append to current jobSpec
+	// destinations are matched using their urn
 	for _, depDestination := range jobDependenciesDestination.Dependencies {
-		depSpec, depProj, err := projectJobSpecRepo.GetByDestination(depDestination)
+		depURN := models.DestinationURN("", depDestination)
+		depSpec, depProj, err := projectJobSpecRepo.GetByDestination(depURN)
 		if err != nil {
 			if err == store.ErrResourceNotFound {
 				// should not fail for unknown dependency
-				r.notifyProgress(observer, &EventJobSpecUnknownDependencyUsed{Job: jobSpec.Name, Dependency: depDestination})
+				r.notifyProgress(observer, &EventJobSpecUnknownDependencyUsed{Job: jobSpec.Name, Dependency: depURN})
 				continue
 			}
 			return jobSpec, errors.Wrap(err, "runtime dependency evaluation failed")
@@ -105,8 +180,10 @@ func (r *dependencyResolver) resolveStaticDependencies(jobSpec models.JobSpec, p
 }
 
 // hooks can be dependent on each other inside a job spec, this will populate
-// the local array that points to its dependent hook
+// the local array that points to its dependent hook, hooks depending on
+// hooks not attached to the job run without waiting for them
 func (r *dependencyResolver) resolveHookDependencies(jobSpec models.JobSpec) (models.JobSpec, error) {
+	hookDependencies := map[string][]string{}
 	for hookIdx, jobHook := range jobSpec.Hooks {
 		jobHook.DependsOn = nil
 		schema, err := jobHook.Unit.GetHookSchema(context.Background(), models.GetHookSchemaRequest{})
@@ -117,13 +194,64 @@ func (r *dependencyResolver) resolveHookDependencies(jobSpec models.JobSpec) (mo
 			dependentHook, err := jobSpec.GetHookByName(depends)
 			if err == nil {
 				jobHook.DependsOn = append(jobHook.DependsOn, &dependentHook)
+				hookDependencies[schema.Name] = append(hookDependencies[schema.Name], depends)
 			}
 		}
 		jobSpec.Hooks[hookIdx] = jobHook
 	}
+	if cycle := findHookCycle(hookDependencies); cycle != nil {
+		return models.JobSpec{}, errors.Errorf("cyclic dependency between hooks of job %s: %s",
+			jobSpec.Name, strings.Join(cycle, " -> "))
+	}
 	return jobSpec, nil
 }
 
+// findHookCycle returns names of hooks forming a cycle through their
+// dependencies, nil if there is none
+func findHookCycle(hookDependencies map[string][]string) []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			for idx, hookName := range path {
+				if hookName == name {
+					return append(append([]string{}, path[idx:]...), name)
+				}
+			}
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, depends := range hookDependencies[name] {
+			if cycle := visit(depends); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	names := make([]string, 0, len(hookDependencies))
+	for name := range hookDependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if cycle := visit(name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
 func (r *dependencyResolver) notifyProgress(observer progress.Observer, e progress.Event) {
 	if observer == nil {
 		return
@@ -10,6 +10,7 @@ import (
 	"github.com/odpf/optimus/job"
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -91,7 +92,7 @@ func TestDependencyResolver(t *testing.T) {
 			}
 
 			jobSpecRepository := new(mock.ProjectJobSpecRepository)
-			jobSpecRepository.On("GetByDestination", "project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil)
 			defer jobSpecRepository.AssertExpectations(t)
 
 			unitData := models.GenerateTaskDependenciesRequest{
@@ -128,6 +129,53 @@ func TestDependencyResolver(t *testing.T) {
 			assert.Equal(t, map[string]models.JobSpecDependency{}, resolvedJobSpec2.Dependencies)
 			assert.Equal(t, []*models.JobSpecHook{&resolvedJobSpec1.Hooks[0]}, resolvedJobSpec1.Hooks[1].DependsOn)
 		})
+		t.Run("it should fail if hooks depend on each other", func(t *testing.T) {
+			execUnit1 := new(mock.TaskPlugin)
+			defer execUnit1.AssertExpectations(t)
+			hookUnit1 := new(mock.HookPlugin)
+			defer hookUnit1.AssertExpectations(t)
+			hookUnit2 := new(mock.HookPlugin)
+			defer hookUnit2.AssertExpectations(t)
+
+			jobSpec1 := models.JobSpec{
+				Version: 1,
+				Name:    "test1",
+				Owner:   "optimus",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  "@daily",
+				},
+				Task: models.JobSpecTask{
+					Unit: execUnit1,
+				},
+				Dependencies: make(map[string]models.JobSpecDependency),
+				Hooks: []models.JobSpecHook{
+					{Unit: hookUnit1},
+					{Unit: hookUnit2},
+				},
+			}
+
+			jobSpecRepository := new(mock.ProjectJobSpecRepository)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			execUnit1.On("GenerateTaskDependencies", context.TODO(), models.GenerateTaskDependenciesRequest{
+				Config:  models.TaskPluginConfigs{}.FromJobSpec(jobSpec1.Task.Config),
+				Assets:  models.TaskPluginAssets{}.FromJobSpec(jobSpec1.Assets),
+				Project: projectSpec,
+			}).Return(models.GenerateTaskDependenciesResponse{}, nil)
+			hookUnit1.On("GetHookSchema", context.Background(), models.GetHookSchemaRequest{}).Return(models.GetHookSchemaResponse{
+				Name:      "hook1",
+				DependsOn: []string{"hook2"},
+			}, nil)
+			hookUnit2.On("GetHookSchema", context.Background(), models.GetHookSchemaRequest{}).Return(models.GetHookSchemaResponse{
+				Name:      "hook2",
+				DependsOn: []string{"hook1"},
+			}, nil)
+
+			resolver := job.NewDependencyResolver()
+			_, err := resolver.Resolve(projectSpec, jobSpecRepository, jobSpec1, nil)
+			assert.EqualError(t, err, "cyclic dependency between hooks of job test1: hook1 -> hook2 -> hook1")
+		})
 		t.Run("it should resolve all dependencies including static unresolved dependency", func(t *testing.T) {
 			execUnit := new(mock.TaskPlugin)
 			defer execUnit.AssertExpectations(t)
@@ -191,7 +239,7 @@ func TestDependencyResolver(t *testing.T) {
 			}
 
 			jobSpecRepository := new(mock.ProjectJobSpecRepository)
-			jobSpecRepository.On("GetByDestination", "project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil)
 			defer jobSpecRepository.AssertExpectations(t)
 
 			unitData := models.GenerateTaskDependenciesRequest{
@@ -265,7 +313,7 @@ func TestDependencyResolver(t *testing.T) {
 			}
 
 			jobSpecRepository := new(mock.ProjectJobSpecRepository)
-			jobSpecRepository.On("GetByDestination", "project.dataset.table2_destination").Return(jobSpec2, projectSpec, errors.New("random error"))
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_destination").Return(jobSpec2, projectSpec, errors.New("random error"))
 			defer jobSpecRepository.AssertExpectations(t)
 
 			unitData := models.GenerateTaskDependenciesRequest{Config: models.TaskPluginConfigs{}.FromJobSpec(jobSpec1.Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(jobSpec1.Assets), Project: projectSpec}
@@ -343,7 +391,7 @@ func TestDependencyResolver(t *testing.T) {
 			}
 
 			jobSpecRepository := new(mock.ProjectJobSpecRepository)
-			jobSpecRepository.On("GetByDestination", "project.dataset.table3_destination").Return(nil, nil, errors.New("spec not found"))
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table3_destination").Return(nil, nil, errors.New("spec not found"))
 			defer jobSpecRepository.AssertExpectations(t)
 
 			unitData := models.GenerateTaskDependenciesRequest{Config: models.TaskPluginConfigs{}.FromJobSpec(jobSpec1.Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(jobSpec1.Assets), Project: projectSpec}
@@ -401,7 +449,7 @@ func TestDependencyResolver(t *testing.T) {
 			}
 
 			jobSpecRepository := new(mock.ProjectJobSpecRepository)
-			jobSpecRepository.On("GetByDestination", "project.dataset.table1_destination").Return(jobSpec1, projectSpec, nil)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table1_destination").Return(jobSpec1, projectSpec, nil)
 			jobSpecRepository.On("GetByName", "static_dep").Return(nil, errors.New("spec not found"))
 			defer jobSpecRepository.AssertExpectations(t)
 
@@ -479,7 +527,7 @@ func TestDependencyResolver(t *testing.T) {
 			}
 
 			jobSpecRepository := new(mock.ProjectJobSpecRepository)
-			jobSpecRepository.On("GetByDestination", "project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil)
 			jobSpecRepository.On("GetByName", "test3").Return(jobSpec3, namespaceSpec, nil)
 			defer jobSpecRepository.AssertExpectations(t)
 
@@ -602,8 +650,8 @@ func TestDependencyResolver(t *testing.T) {
 			}
 
 			jobSpecRepository := new(mock.ProjectJobSpecRepository)
-			jobSpecRepository.On("GetByDestination", "project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil)
-			jobSpecRepository.On("GetByDestination", "project.dataset.table2_external_destination").Return(jobSpecExternal, externalProjectSpec, nil)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_destination").Return(jobSpec2, projectSpec, nil)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_external_destination").Return(jobSpecExternal, externalProjectSpec, nil)
 			jobSpecRepository.On("GetByName", "test3").Return(jobSpec3, namespaceSpec, nil)
 			defer jobSpecRepository.AssertExpectations(t)
 
@@ -636,5 +684,238 @@ func TestDependencyResolver(t *testing.T) {
 			assert.Equal(t, models.JobSpecDependency{Job: &jobSpec3, Project: &projectSpec, Type: models.JobSpecDependencyTypeIntra}, resolvedJobSpec1.Dependencies[jobSpec3.Name])
 			assert.Equal(t, map[string]models.JobSpecDependency{}, resolvedJobSpec2.Dependencies)
 		})
+		t.Run("it should resolve dependencies on destination urn of different datastores", func(t *testing.T) {
+			bqUnit := new(mock.TaskPlugin)
+			defer bqUnit.AssertExpectations(t)
+			fileUnit := new(mock.TaskPlugin)
+			defer fileUnit.AssertExpectations(t)
+
+			bqJobSpec := models.JobSpec{
+				Version:      1,
+				Name:         "bq-job",
+				Owner:        "optimus",
+				Task:         models.JobSpecTask{Unit: bqUnit},
+				Dependencies: make(map[string]models.JobSpecDependency),
+			}
+			fileJobSpec := models.JobSpec{
+				Version:      1,
+				Name:         "file-job",
+				Owner:        "optimus",
+				Task:         models.JobSpecTask{Unit: fileUnit},
+				Dependencies: make(map[string]models.JobSpecDependency),
+			}
+			upstreamBQJobSpec := models.JobSpec{Name: "upstream-bq-job"}
+			upstreamFileJobSpec := models.JobSpec{Name: "upstream-file-job"}
+
+			jobSpecRepository := new(mock.ProjectJobSpecRepository)
+			jobSpecRepository.On("GetByDestination", "file://bucket/path/upstream").Return(upstreamFileJobSpec, projectSpec, nil)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.upstream").Return(upstreamBQJobSpec, projectSpec, nil)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			bqUnit.On("GenerateTaskDependencies", context.TODO(), models.GenerateTaskDependenciesRequest{
+				Config: models.TaskPluginConfigs{}.FromJobSpec(bqJobSpec.Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(bqJobSpec.Assets),
+				Project: projectSpec,
+			}).Return(models.GenerateTaskDependenciesResponse{Dependencies: []string{"file://bucket/path/upstream"}}, nil)
+			fileUnit.On("GenerateTaskDependencies", context.TODO(), models.GenerateTaskDependenciesRequest{
+				Config: models.TaskPluginConfigs{}.FromJobSpec(fileJobSpec.Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(fileJobSpec.Assets),
+				Project: projectSpec,
+			}).Return(models.GenerateTaskDependenciesResponse{Dependencies: []string{"bigquery://project.dataset.upstream"}}, nil)
+
+			resolver := job.NewDependencyResolver()
+			resolvedBQJobSpec, err := resolver.Resolve(projectSpec, jobSpecRepository, bqJobSpec, nil)
+			assert.Nil(t, err)
+			resolvedFileJobSpec, err := resolver.Resolve(projectSpec, jobSpecRepository, fileJobSpec, nil)
+			assert.Nil(t, err)
+
+			assert.Equal(t, map[string]models.JobSpecDependency{
+				upstreamFileJobSpec.Name: {Job: &upstreamFileJobSpec, Project: &projectSpec, Type: models.JobSpecDependencyTypeIntra},
+			}, resolvedBQJobSpec.Dependencies)
+			assert.Equal(t, map[string]models.JobSpecDependency{
+				upstreamBQJobSpec.Name: {Job: &upstreamBQJobSpec, Project: &projectSpec, Type: models.JobSpecDependencyTypeIntra},
+			}, resolvedFileJobSpec.Dependencies)
+		})
+		t.Run("it should resolve every destination of a job writing to more than one table", func(t *testing.T) {
+			producerUnit := new(mock.TaskPlugin)
+			defer producerUnit.AssertExpectations(t)
+			consumerUnit := new(mock.TaskPlugin)
+			defer consumerUnit.AssertExpectations(t)
+
+			producerJobSpec := models.JobSpec{
+				Version:      1,
+				Name:         "fan-out-job",
+				Owner:        "optimus",
+				Task:         models.JobSpecTask{Unit: producerUnit},
+				Dependencies: make(map[string]models.JobSpecDependency),
+			}
+			consumerJobSpecs := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "users-job",
+					Owner:   "optimus",
+					Task: models.JobSpecTask{
+						Unit:   consumerUnit,
+						Config: models.JobSpecConfigs{{Name: "SOURCE", Value: "project.dataset.users"}},
+					},
+					Dependencies: make(map[string]models.JobSpecDependency),
+				},
+				{
+					Version: 1,
+					Name:    "orders-job",
+					Owner:   "optimus",
+					Task: models.JobSpecTask{
+						Unit:   consumerUnit,
+						Config: models.JobSpecConfigs{{Name: "SOURCE", Value: "project.dataset.orders"}},
+					},
+					Dependencies: make(map[string]models.JobSpecDependency),
+				},
+			}
+
+			// producer is stored under every destination it writes to
+			producerDestination := models.GenerateTaskDestinationResponse{
+				Destination:            "project.dataset.users",
+				Type:                   models.DestinationTypeBigquery,
+				AdditionalDestinations: []string{"project.dataset.orders"},
+			}
+			assert.Equal(t, []string{
+				"bigquery://project.dataset.users",
+				"bigquery://project.dataset.orders",
+			}, producerDestination.URNs())
+			jobSpecRepository := new(mock.ProjectJobSpecRepository)
+			for _, urn := range producerDestination.URNs() {
+				jobSpecRepository.On("GetByDestination", urn).Return(producerJobSpec, projectSpec, nil).Once()
+			}
+			defer jobSpecRepository.AssertExpectations(t)
+
+			for _, consumerJobSpec := range consumerJobSpecs {
+				consumerUnit.On("GenerateTaskDependencies", context.TODO(), models.GenerateTaskDependenciesRequest{
+					Config: models.TaskPluginConfigs{}.FromJobSpec(consumerJobSpec.Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(consumerJobSpec.Assets),
+					Project: projectSpec,
+				}).Return(models.GenerateTaskDependenciesResponse{Dependencies: []string{consumerJobSpec.Task.Config[0].Value}}, nil)
+			}
+
+			resolver := job.NewDependencyResolver()
+			for _, consumerJobSpec := range consumerJobSpecs {
+				resolvedJobSpec, err := resolver.Resolve(projectSpec, jobSpecRepository, consumerJobSpec, nil)
+				assert.Nil(t, err)
+				assert.Equal(t, map[string]models.JobSpecDependency{
+					producerJobSpec.Name: {Job: &producerJobSpec, Project: &projectSpec, Type: models.JobSpecDependencyTypeIntra},
+				}, resolvedJobSpec.Dependencies)
+			}
+		})
+	})
+	t.Run("Explain", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			Name: "a-data-project",
+		}
+		externalProjectSpec := models.ProjectSpec{
+			Name: "an-external-data-project",
+		}
+		execUnit := new(mock.TaskPlugin)
+		jobSpec := models.JobSpec{
+			Version: 1,
+			Name:    "test1",
+			Task: models.JobSpecTask{
+				Unit: execUnit,
+				Config: models.JobSpecConfigs{
+					{
+						Name:  "foo",
+						Value: "bar",
+					},
+				},
+			},
+			Dependencies: make(map[string]models.JobSpecDependency),
+		}
+		upstreamJobSpec := models.JobSpec{Name: "test2"}
+		externalJobSpec := models.JobSpec{Name: "test3"}
+		execUnit.On("GenerateTaskDependencies", context.TODO(), models.GenerateTaskDependenciesRequest{
+			Config: models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets),
+			Project: projectSpec,
+		}).Return(models.GenerateTaskDependenciesResponse{Dependencies: []string{"project.dataset.table2_destination"}}, nil)
+
+		t.Run("should explain a destination matched by a single job", func(t *testing.T) {
+			jobSpecRepository := new(mock.ProjectJobSpecRepository)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_destination").Return(upstreamJobSpec, projectSpec, nil)
+			jobSpecRepository.On("GetAllByDestination", "bigquery://project.dataset.table2_destination").Return([]models.ProjectJobPair{
+				{Project: projectSpec, Job: upstreamJobSpec},
+			}, nil)
+			jobSpecRepository.On("GetAllByDestination", "project.dataset.table2_destination").Return([]models.ProjectJobPair{}, nil)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			explanations, err := job.NewDependencyResolver().Explain(projectSpec, jobSpecRepository, jobSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.DependencyExplanation{
+				{
+					Destination: "bigquery://project.dataset.table2_destination",
+					Resolved:    true,
+					Candidates: []models.DependencyCandidate{
+						{
+							Job:     "test2",
+							Project: projectSpec.Name,
+							Matched: true,
+							Reason:  "produces bigquery://project.dataset.table2_destination, picked as intra dependency",
+						},
+					},
+				},
+			}, explanations)
+		})
+		t.Run("should explain an unresolved destination produced by a job without urn", func(t *testing.T) {
+			jobSpecRepository := new(mock.ProjectJobSpecRepository)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_destination").Return(nil, nil, store.ErrResourceNotFound)
+			jobSpecRepository.On("GetAllByDestination", "bigquery://project.dataset.table2_destination").Return([]models.ProjectJobPair{}, nil)
+			jobSpecRepository.On("GetAllByDestination", "project.dataset.table2_destination").Return([]models.ProjectJobPair{
+				{Project: projectSpec, Job: upstreamJobSpec},
+			}, nil)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			explanations, err := job.NewDependencyResolver().Explain(projectSpec, jobSpecRepository, jobSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.DependencyExplanation{
+				{
+					Destination: "bigquery://project.dataset.table2_destination",
+					Resolved:    false,
+					Candidates: []models.DependencyCandidate{
+						{
+							Job:     "test2",
+							Project: projectSpec.Name,
+							Matched: false,
+							Reason:  "destination urn mismatch, produces project.dataset.table2_destination instead of bigquery://project.dataset.table2_destination",
+						},
+					},
+				},
+			}, explanations)
+		})
+		t.Run("should explain a destination produced by more than one job", func(t *testing.T) {
+			jobSpecRepository := new(mock.ProjectJobSpecRepository)
+			jobSpecRepository.On("GetByDestination", "bigquery://project.dataset.table2_destination").Return(externalJobSpec, externalProjectSpec, nil)
+			jobSpecRepository.On("GetAllByDestination", "bigquery://project.dataset.table2_destination").Return([]models.ProjectJobPair{
+				{Project: projectSpec, Job: upstreamJobSpec},
+				{Project: externalProjectSpec, Job: externalJobSpec},
+			}, nil)
+			jobSpecRepository.On("GetAllByDestination", "project.dataset.table2_destination").Return([]models.ProjectJobPair{}, nil)
+			defer jobSpecRepository.AssertExpectations(t)
+
+			explanations, err := job.NewDependencyResolver().Explain(projectSpec, jobSpecRepository, jobSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.DependencyExplanation{
+				{
+					Destination: "bigquery://project.dataset.table2_destination",
+					Resolved:    true,
+					Candidates: []models.DependencyCandidate{
+						{
+							Job:     "test2",
+							Project: projectSpec.Name,
+							Matched: false,
+							Reason:  "ambiguous, 2 jobs produce bigquery://project.dataset.table2_destination and another one was picked",
+						},
+						{
+							Job:     "test3",
+							Project: externalProjectSpec.Name,
+							Matched: true,
+							Reason:  "ambiguous, picked as inter dependency out of 2 jobs producing bigquery://project.dataset.table2_destination",
+						},
+					},
+				},
+			}, explanations)
+		})
 	})
 }
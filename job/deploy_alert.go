@@ -0,0 +1,91 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/models"
+)
+
+// deployAlertTimeout bounds the time taken to queue a deploy alert on
+// every channel
+const deployAlertTimeout = time.Second * 30
+
+// Alerter sends events to alert channels configured for a namespace
+type Alerter interface {
+	Alert(context.Context, models.NamespaceSpec, models.JobSpec, models.JobEvent) error
+}
+
+// DeployAlertObserver collects failures of a deploy as its events flow
+// through and raises a single models.JobEventTypeDeployFailure alert for the
+// namespace once the deploy summary is notified
+type DeployAlertObserver struct {
+	Namespace models.NamespaceSpec
+	Alerter   Alerter
+
+	mu         sync.Mutex
+	failedJobs []interface{}
+	syncErr    error
+}
+
+func (obs *DeployAlertObserver) Notify(e progress.Event) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	switch evt := e.(type) {
+	case *EventJobUpload:
+		if evt.Err != nil {
+			obs.failedJobs = append(obs.failedJobs, fmt.Sprintf("%s: %s", evt.Job.Name, evt.Err.Error()))
+		}
+	case *EventJobSyncFailed:
+		obs.syncErr = evt.Err
+	case *EventDeploySummary:
+		obs.flush()
+	}
+}
+
+// Flush raises the alert for failures collected so far, for deploys which
+// don't notify a summary
+func (obs *DeployAlertObserver) Flush() {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	obs.flush()
+}
+
+func (obs *DeployAlertObserver) flush() {
+	if len(obs.failedJobs) == 0 && obs.syncErr == nil {
+		return
+	}
+	if err := obs.alert(); err != nil {
+		logger.E(errors.Wrapf(err, "failed to alert deploy failure of namespace %s", obs.Namespace.Name))
+	}
+	obs.failedJobs = nil
+	obs.syncErr = nil
+}
+
+func (obs *DeployAlertObserver) alert() error {
+	value := map[string]interface{}{
+		"failed_jobs": obs.failedJobs,
+	}
+	if obs.syncErr != nil {
+		value["message"] = obs.syncErr.Error()
+	}
+	eventValue, err := structpb.NewStruct(value)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deployAlertTimeout)
+	defer cancel()
+	return obs.Alerter.Alert(ctx, obs.Namespace, models.JobSpec{}, models.JobEvent{
+		Type:  models.JobEventTypeDeployFailure,
+		Value: eventValue.GetFields(),
+	})
+}
@@ -0,0 +1,60 @@
+package job_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/pkg/errors"
+	testMock "github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+)
+
+func TestDeployAlertObserver(t *testing.T) {
+	logger.InitWithWriter("ERROR", ioutil.Discard)
+
+	namespaceSpec := models.NamespaceSpec{
+		Name: "game_jam",
+		ProjectSpec: models.ProjectSpec{
+			Name: "a-data-project",
+		},
+	}
+	t.Run("should alert failed jobs once deploy is summarised", func(t *testing.T) {
+		eventValue, _ := structpb.NewStruct(map[string]interface{}{
+			"failed_jobs": []interface{}{"job-b: failed to upload"},
+			"message":     "failed to sync",
+		})
+		alerter := new(mock.EventService)
+		alerter.On("Alert", testMock.Anything, namespaceSpec, models.JobSpec{}, models.JobEvent{
+			Type:  models.JobEventTypeDeployFailure,
+			Value: eventValue.GetFields(),
+		}).Return(nil)
+		defer alerter.AssertExpectations(t)
+
+		obs := &job.DeployAlertObserver{
+			Namespace: namespaceSpec,
+			Alerter:   alerter,
+		}
+		obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-a"}})
+		obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-b"}, Err: errors.New("failed to upload")})
+		obs.Notify(&job.EventJobSyncFailed{Err: errors.New("failed to sync")})
+		obs.Notify(&job.EventDeploySummary{Succeeded: 1, Failed: 1})
+	})
+	t.Run("should not alert successful deploys", func(t *testing.T) {
+		alerter := new(mock.EventService)
+		defer alerter.AssertExpectations(t)
+
+		obs := &job.DeployAlertObserver{
+			Namespace: namespaceSpec,
+			Alerter:   alerter,
+		}
+		obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-a"}})
+		obs.Notify(&job.EventDeploySummary{Succeeded: 1})
+		alerter.AssertNotCalled(t, "Alert", context.Background(), namespaceSpec, models.JobSpec{}, testMock.Anything)
+	})
+}
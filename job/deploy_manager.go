@@ -0,0 +1,319 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/pkg/errors"
+)
+
+const (
+	DeploymentStatusQueued     = "queued"
+	DeploymentStatusInProgress = "in progress"
+	DeploymentStatusSucceeded  = "succeeded"
+	DeploymentStatusFailed     = "failed"
+	DeploymentStatusCancelled  = "cancelled"
+
+	// DeploymentDefaultRetention is how long finished deployments are
+	// kept for clients to poll their status
+	DeploymentDefaultRetention = time.Hour
+)
+
+var (
+	// ErrDeploymentNotFound is returned when a deployment is unknown to
+	// manager or it was removed after retention passed
+	ErrDeploymentNotFound = errors.New("deployment not found")
+)
+
+// DeployFunc runs a queued deployment, progress is notified to obs and
+// ctx gets cancelled if the deployment is cancelled
+type DeployFunc func(ctx context.Context, obs progress.Observer) error
+
+type DeployManagerConfig struct {
+	NumWorkers int
+	// QueueSize is the number of deployments waiting for a worker, excess
+	// deployments are rejected with ErrRequestQueueFull
+	QueueSize int
+	// Retention is how long finished deployments are kept
+	Retention time.Duration
+}
+
+// Deployment is the state of a deployment accepted by DeployManager
+type Deployment struct {
+	ID        string
+	Project   string
+	Namespace string
+	Status    string
+	// Message explains why deployment failed
+	Message   string
+	Summary   EventDeploySummary
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Finished is true once deployment is not going to change anymore
+func (d Deployment) Finished() bool {
+	return d.Status == DeploymentStatusSucceeded || d.Status == DeploymentStatusFailed ||
+		d.Status == DeploymentStatusCancelled
+}
+
+// DeployManager runs deployments in background workers.
+// Offers an asynchronous interface to deploys, a deployment is identified
+// by the id returned when it is queued which can be used to poll its status
+// or follow its progress events. The queue and deployments are only kept in
+// memory of the server: queued deployments are lost when it restarts and
+// each replica has its own queue, status of a deployment can only be read
+// from the replica which accepted it
+type DeployManager struct {
+	wg sync.WaitGroup
+	mu sync.Mutex
+
+	config DeployManagerConfig
+
+	requestQ    chan *deployment
+	deployments map[string]*deployment
+
+	progressObserver progress.Observer
+	Now              func() time.Time
+}
+
+// deployment tracks a single queued deployment along with the events
+// notified while it runs
+type deployment struct {
+	mu sync.Mutex
+
+	info   Deployment
+	run    DeployFunc
+	events []progress.Event
+	// changed is closed and replaced whenever an event is recorded or
+	// status of deployment changes
+	changed   chan struct{}
+	cancel    context.CancelFunc
+	cancelled bool
+	summary   DeploySummaryObserver
+}
+
+func (d *deployment) Notify(e progress.Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = append(d.events, e)
+	d.broadcast()
+}
+
+func (d *deployment) setStatus(status, message string, at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.info.Status = status
+	d.info.Message = message
+	d.info.UpdatedAt = at
+	d.broadcast()
+}
+
+// broadcast wakes up everyone waiting for deployment to change, must be
+// called with lock held
+func (d *deployment) broadcast() {
+	close(d.changed)
+	d.changed = make(chan struct{})
+}
+
+// Deploy queues run for a namespace of project, returns a deployment id
+// that can be used to query its status
+func (m *DeployManager) Deploy(project, namespace string, run DeployFunc) (string, error) {
+	m.removeExpired()
+
+	now := m.Now()
+	d := &deployment{
+		info: Deployment{
+			ID:        uuid.New().String(),
+			Project:   project,
+			Namespace: namespace,
+			Status:    DeploymentStatusQueued,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		run:     run,
+		changed: make(chan struct{}),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// a closed manager has no queue, sending to nil channel never succeeds
+	select {
+	case m.requestQ <- d:
+		m.deployments[d.info.ID] = d
+		return d.info.ID, nil
+	default:
+		return "", ErrRequestQueueFull
+	}
+}
+
+// Get returns the current state of a deployment of project
+func (m *DeployManager) Get(project, id string) (Deployment, error) {
+	d, err := m.find(project, id)
+	if err != nil {
+		return Deployment{}, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	info := d.info
+	info.Summary = *d.summary.Summary()
+	return info, nil
+}
+
+// Events returns events of a deployment notified after the first from
+// events, finished tells if no more events will follow, otherwise changed
+// gets closed once there is something new
+func (m *DeployManager) Events(project, id string, from int) (events []progress.Event, finished bool,
+	changed <-chan struct{}, err error) {
+	d, err := m.find(project, id)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if from < len(d.events) {
+		events = append(events, d.events[from:]...)
+	}
+	return events, d.info.Finished(), d.changed, nil
+}
+
+// Cancel stops a deployment of project, queued deployments are dropped
+// before they run. Returns false if deployment is not queued or running
+func (m *DeployManager) Cancel(project, id string) bool {
+	d, err := m.find(project, id)
+	if err != nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	switch d.info.Status {
+	case DeploymentStatusQueued:
+		d.info.Status = DeploymentStatusCancelled
+		d.info.UpdatedAt = m.Now()
+		d.broadcast()
+		return true
+	case DeploymentStatusInProgress:
+		// status changes once the running deploy returns
+		d.cancelled = true
+		d.cancel()
+		return true
+	}
+	return false
+}
+
+func (m *DeployManager) find(project, id string) (*deployment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.deployments[id]
+	if !ok || d.info.Project != project {
+		return nil, ErrDeploymentNotFound
+	}
+	return d, nil
+}
+
+// removeExpired forgets deployments finished before retention period
+func (m *DeployManager) removeExpired() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, d := range m.deployments {
+		d.mu.Lock()
+		expired := d.info.Finished() && m.Now().Sub(d.info.UpdatedAt) > m.config.Retention
+		d.mu.Unlock()
+		if expired {
+			delete(m.deployments, id)
+		}
+	}
+}
+
+// process runs a deployment unless it was cancelled while it was queued
+func (m *DeployManager) process(d *deployment) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d.mu.Lock()
+	if d.info.Status == DeploymentStatusCancelled {
+		d.mu.Unlock()
+		return
+	}
+	d.info.Status = DeploymentStatusInProgress
+	d.info.UpdatedAt = m.Now()
+	d.cancel = cancel
+	d.broadcast()
+	d.mu.Unlock()
+
+	observers := new(progress.ObserverChain)
+	if m.progressObserver != nil {
+		observers.Join(m.progressObserver)
+	}
+	observers.Join(&d.summary)
+	observers.Join(d)
+
+	logger.I("worker picked up deployment ", d.info.ID)
+	err := d.run(ctx, observers)
+	observers.Notify(d.summary.Summary())
+
+	d.mu.Lock()
+	cancelled := d.cancelled
+	d.mu.Unlock()
+	switch {
+	case cancelled:
+		d.setStatus(DeploymentStatusCancelled, "", m.Now())
+	case err != nil:
+		logger.E(errors.Wrapf(err, "deployment %s failed", d.info.ID))
+		d.setStatus(DeploymentStatusFailed, err.Error(), m.Now())
+	default:
+		d.setStatus(DeploymentStatusSucceeded, "", m.Now())
+	}
+}
+
+// start a worker goroutine that runs queued deployments in background
+func (m *DeployManager) spawnServiceWorker(requestQ <-chan *deployment) {
+	defer m.wg.Done()
+
+	for d := range requestQ {
+		m.process(d)
+	}
+}
+
+// Close stops accepting deployments and waits for queued ones to finish
+func (m *DeployManager) Close() error {
+	m.mu.Lock()
+	if m.requestQ != nil {
+		// stop accepting any more deployments
+		close(m.requestQ)
+		m.requestQ = nil
+	}
+	m.mu.Unlock()
+
+	m.wg.Wait()
+	return nil
+}
+
+func (m *DeployManager) Init() {
+	logger.I("starting deploy workers")
+	for i := 0; i < m.config.NumWorkers; i++ {
+		m.wg.Add(1)
+		go m.spawnServiceWorker(m.requestQ)
+	}
+}
+
+// NewDeployManager constructs a new instance of DeployManager, progress of
+// every deployment is notified to progressObserver as well
+func NewDeployManager(config DeployManagerConfig, progressObserver progress.Observer) *DeployManager {
+	if config.Retention <= 0 {
+		config.Retention = DeploymentDefaultRetention
+	}
+	mgr := &DeployManager{
+		config:           config,
+		requestQ:         make(chan *deployment, config.QueueSize),
+		deployments:      map[string]*deployment{},
+		progressObserver: progressObserver,
+		Now:              time.Now,
+	}
+	mgr.Init()
+	return mgr
+}
@@ -0,0 +1,131 @@
+package job_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/core/progress"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitDeployment follows events of a deployment until it finishes
+func waitDeployment(t *testing.T, mgr *job.DeployManager, project, id string) []progress.Event {
+	var events []progress.Event
+	for {
+		newEvents, finished, changed, err := mgr.Events(project, id, len(events))
+		assert.Nil(t, err)
+		events = append(events, newEvents...)
+		if finished {
+			return events
+		}
+		<-changed
+	}
+}
+
+func TestDeployManager(t *testing.T) {
+	logger.InitWithWriter(logger.DEBUG, ioutil.Discard)
+
+	t.Run("Deploy", func(t *testing.T) {
+		t.Run("should run deployment in background and record its progress", func(t *testing.T) {
+			mgr := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 1, QueueSize: 1}, nil)
+			defer mgr.Close()
+
+			deployID, err := mgr.Deploy("a-data-project", "dev-team-1", func(ctx context.Context, obs progress.Observer) error {
+				obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-1"}})
+				obs.Notify(&job.EventJobUpload{Job: models.JobSpec{Name: "job-2"}, Err: errors.New("upload failed")})
+				return nil
+			})
+			assert.Nil(t, err)
+
+			events := waitDeployment(t, mgr, "a-data-project", deployID)
+			assert.Len(t, events, 3)
+			assert.Equal(t, &job.EventDeploySummary{Succeeded: 1, Failed: 1}, events[2])
+
+			deployment, err := mgr.Get("a-data-project", deployID)
+			assert.Nil(t, err)
+			assert.Equal(t, job.DeploymentStatusSucceeded, deployment.Status)
+			assert.Equal(t, "dev-team-1", deployment.Namespace)
+			assert.Equal(t, job.EventDeploySummary{Succeeded: 1, Failed: 1}, deployment.Summary)
+		})
+		t.Run("should mark deployment failed if it returns an error", func(t *testing.T) {
+			mgr := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 1, QueueSize: 1}, nil)
+			defer mgr.Close()
+
+			deployID, err := mgr.Deploy("a-data-project", "dev-team-1", func(ctx context.Context, obs progress.Observer) error {
+				return errors.New("failed to sync jobs")
+			})
+			assert.Nil(t, err)
+			waitDeployment(t, mgr, "a-data-project", deployID)
+
+			deployment, err := mgr.Get("a-data-project", deployID)
+			assert.Nil(t, err)
+			assert.Equal(t, job.DeploymentStatusFailed, deployment.Status)
+			assert.Equal(t, "failed to sync jobs", deployment.Message)
+		})
+		t.Run("should reject deployment if queue is full", func(t *testing.T) {
+			mgr := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 0, QueueSize: 1}, nil)
+			noop := func(ctx context.Context, obs progress.Observer) error { return nil }
+
+			_, err := mgr.Deploy("a-data-project", "dev-team-1", noop)
+			assert.Nil(t, err)
+			_, err = mgr.Deploy("a-data-project", "dev-team-1", noop)
+			assert.Equal(t, job.ErrRequestQueueFull, err)
+		})
+	})
+	t.Run("Get", func(t *testing.T) {
+		t.Run("should not find deployment of a different project", func(t *testing.T) {
+			mgr := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 0, QueueSize: 1}, nil)
+
+			deployID, err := mgr.Deploy("a-data-project", "dev-team-1", func(ctx context.Context, obs progress.Observer) error {
+				return nil
+			})
+			assert.Nil(t, err)
+
+			_, err = mgr.Get("b-data-project", deployID)
+			assert.Equal(t, job.ErrDeploymentNotFound, err)
+		})
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		t.Run("should drop a queued deployment before it runs", func(t *testing.T) {
+			mgr := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 0, QueueSize: 1}, nil)
+
+			deployID, err := mgr.Deploy("a-data-project", "dev-team-1", func(ctx context.Context, obs progress.Observer) error {
+				t.Error("cancelled deployment should not run")
+				return nil
+			})
+			assert.Nil(t, err)
+
+			assert.True(t, mgr.Cancel("a-data-project", deployID))
+			assert.False(t, mgr.Cancel("a-data-project", deployID))
+
+			deployment, err := mgr.Get("a-data-project", deployID)
+			assert.Nil(t, err)
+			assert.Equal(t, job.DeploymentStatusCancelled, deployment.Status)
+		})
+		t.Run("should cancel context of a running deployment", func(t *testing.T) {
+			mgr := job.NewDeployManager(job.DeployManagerConfig{NumWorkers: 1, QueueSize: 1}, nil)
+			defer mgr.Close()
+
+			started := make(chan struct{})
+			deployID, err := mgr.Deploy("a-data-project", "dev-team-1", func(ctx context.Context, obs progress.Observer) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			assert.Nil(t, err)
+
+			<-started
+			assert.True(t, mgr.Cancel("a-data-project", deployID))
+			waitDeployment(t, mgr, "a-data-project", deployID)
+
+			deployment, err := mgr.Get("a-data-project", deployID)
+			assert.Nil(t, err)
+			assert.Equal(t, job.DeploymentStatusCancelled, deployment.Status)
+		})
+	})
+}
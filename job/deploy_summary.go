@@ -0,0 +1,78 @@
+package job
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/odpf/optimus/core/progress"
+)
+
+// EventDeploySummary is the outcome of a whole deploy, counted from the
+// events of each job
+type EventDeploySummary struct {
+	Succeeded int
+	Failed    int
+	Deleted   int
+	// Unchanged are jobs left as they were, like the ones not deleted
+	// as other jobs still depend on them or not uploaded as their
+	// compiled job didn't change
+	Unchanged int
+}
+
+func (e *EventDeploySummary) String() string {
+	return fmt.Sprintf("deployed: %d ok, %d failed, %d deleted, %d unchanged", e.Succeeded, e.Failed, e.Deleted, e.Unchanged)
+}
+
+// DeploySummaryObserver counts the outcome of each job as deploy events flow
+// through it, a job deleted from both the spec repository and the remote is
+// counted once
+type DeploySummaryObserver struct {
+	mu        sync.Mutex
+	succeeded int
+	failed    int
+	deleted   map[string]bool
+	unchanged int
+}
+
+func (obs *DeploySummaryObserver) Notify(e progress.Event) {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	switch evt := e.(type) {
+	case *EventJobUpload:
+		if evt.Err != nil {
+			obs.failed++
+		} else if evt.Unchanged {
+			obs.unchanged++
+		} else {
+			obs.succeeded++
+		}
+	case *EventSavedJobDelete:
+		obs.markDeleted(evt.Name)
+	case *EventJobRemoteDelete:
+		obs.markDeleted(evt.Name)
+	case *EventSavedJobDeleteBlocked:
+		obs.unchanged++
+	}
+}
+
+func (obs *DeploySummaryObserver) markDeleted(name string) {
+	if obs.deleted == nil {
+		obs.deleted = map[string]bool{}
+	}
+	obs.deleted[name] = true
+}
+
+// Summary returns the totals counted so far, meant to be notified once
+// the deploy is over
+func (obs *DeploySummaryObserver) Summary() *EventDeploySummary {
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+
+	return &EventDeploySummary{
+		Succeeded: obs.succeeded,
+		Failed:    obs.failed,
+		Deleted:   len(obs.deleted),
+		Unchanged: obs.unchanged,
+	}
+}
@@ -11,20 +11,56 @@ import (
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 )
 
+// JobEventRepoFactory is used to persist every registered event
+type JobEventRepoFactory interface {
+	New(namespace models.NamespaceSpec) store.JobEventRepository
+}
+
 type eventService struct {
 	// scheme -> notifier
 	notifyChannels map[string]models.Notifier
+
+	// EventRepoFac persists events when set
+	EventRepoFac JobEventRepoFactory
+
+	// Webhook posts every event to the endpoints configured for the project
+	// at models.ProjectJobEventWebhooks when set
+	Webhook models.Notifier
 }
 
 func (e *eventService) Register(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec,
 	evt models.JobEvent) error {
 	var err error
+	if e.EventRepoFac != nil {
+		if currErr := e.EventRepoFac.New(namespace).Save(jobSpec, evt); currErr != nil {
+			log.E(currErr)
+			err = multierror.Append(err, errors.Wrapf(currErr, "failed to save event of job %s", jobSpec.Name))
+		}
+	}
+	if e.Webhook != nil {
+		for _, endpoint := range namespace.ProjectSpec.JobEventWebhooks() {
+			if currErr := e.Webhook.Notify(ctx, models.NotifyAttrs{
+				Namespace: namespace,
+				JobSpec:   jobSpec,
+				JobEvent:  evt,
+				Route:     endpoint,
+			}); currErr != nil {
+				log.E(currErr)
+				err = multierror.Append(err, errors.Wrapf(currErr, "webhook.Notify: %s", endpoint))
+			}
+		}
+	}
 	for _, notify := range jobSpec.Behavior.Notify {
 		if notify.On == evt.Type {
 			for _, channel := range notify.Channels {
-				chanParts := strings.Split(channel, "://")
+				chanParts := strings.SplitN(channel, "://", 2)
+				if len(chanParts) != 2 {
+					err = multierror.Append(err, errors.Errorf("invalid notify channel %s", channel))
+					continue
+				}
 				scheme := chanParts[0]
 				route := chanParts[1]
 
@@ -43,14 +79,54 @@ func (e *eventService) Register(ctx context.Context, namespace models.NamespaceS
 			}
 		}
 	}
+	if currErr := e.Alert(ctx, namespace, jobSpec, evt); currErr != nil {
+		err = multierror.Append(err, currErr)
+	}
 	return err
 }
 
+// Alert sends evt to alert channels of the namespace and its project that
+// accept severity of the event, see models.AlertChannelsConfig
+func (e *eventService) Alert(ctx context.Context, namespace models.NamespaceSpec, jobSpec models.JobSpec,
+	evt models.JobEvent) error {
+	channels, err := namespace.AlertChannels()
+	if err != nil {
+		log.E(err)
+		return errors.Wrap(err, "failed to read alert channels")
+	}
+	severity := models.JobEventSeverity(evt.Type)
+	var alertErr error
+	for _, channel := range channels {
+		if !severity.AtLeast(channel.MinSeverity) {
+			continue
+		}
+		notifyChannel, ok := e.notifyChannels[channel.Type]
+		if !ok {
+			alertErr = multierror.Append(alertErr, errors.Errorf("unknown alert channel type %s", channel.Type))
+			continue
+		}
+		log.Df("alert event for namespace %s: %v", namespace.Name, evt)
+		if currErr := notifyChannel.Notify(ctx, models.NotifyAttrs{
+			Namespace: namespace,
+			JobSpec:   jobSpec,
+			JobEvent:  evt,
+			Route:     channel.Route,
+		}); currErr != nil {
+			log.E(currErr)
+			alertErr = multierror.Append(alertErr, errors.Wrapf(currErr, "alert %s://%s", channel.Type, channel.Route))
+		}
+	}
+	return alertErr
+}
+
 func (e *eventService) Close() error {
 	var err error
 	for _, notify := range e.notifyChannels {
 		err = multierror.Append(err, notify.Close())
 	}
+	if e.Webhook != nil {
+		err = multierror.Append(err, e.Webhook.Close())
+	}
 	return err
 }
 
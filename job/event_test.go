@@ -152,4 +152,162 @@ func TestEventService(t *testing.T) {
 		err := evtService.Register(context.Background(), namespaceSpec, jobSpec, je)
 		assert.Error(t, err, "failed to notify")
 	})
+	t.Run("should save event and post it to webhooks of project", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+			Config: map[string]string{
+				models.ProjectJobEventWebhooks: "https://alerts.example.io/optimus,https://audit.example.io/events",
+			},
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "game_jam",
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+		}
+		je := models.JobEvent{
+			Type:  models.JobEventTypeStart,
+			Value: eventValues.GetFields(),
+		}
+
+		eventRepo := new(mock.JobEventRepository)
+		eventRepo.On("Save", jobSpec, je).Return(nil)
+		defer eventRepo.AssertExpectations(t)
+		eventRepoFac := new(mock.JobEventRepoFactory)
+		eventRepoFac.On("New", namespaceSpec).Return(eventRepo)
+		defer eventRepoFac.AssertExpectations(t)
+
+		webhook := new(mock.Notifier)
+		for _, endpoint := range []string{"https://alerts.example.io/optimus", "https://audit.example.io/events"} {
+			webhook.On("Notify", context.Background(), models.NotifyAttrs{
+				Namespace: namespaceSpec,
+				JobSpec:   jobSpec,
+				JobEvent:  je,
+				Route:     endpoint,
+			}).Return(nil)
+		}
+		defer webhook.AssertExpectations(t)
+
+		evtService := job.NewEventService(map[string]models.Notifier{})
+		evtService.EventRepoFac = eventRepoFac
+		evtService.Webhook = webhook
+		err := evtService.Register(context.Background(), namespaceSpec, jobSpec, je)
+		assert.Nil(t, err)
+	})
+	t.Run("should still notify if failed to save event", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "game_jam",
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+			Behavior: models.JobSpecBehavior{
+				Notify: []models.JobSpecNotifier{
+					{
+						On: models.JobEventTypeFailure,
+						Channels: []string{
+							"slacker://@devs",
+						},
+					},
+				},
+			},
+		}
+		je := models.JobEvent{
+			Type:  models.JobEventTypeFailure,
+			Value: eventValues.GetFields(),
+		}
+
+		eventRepo := new(mock.JobEventRepository)
+		eventRepo.On("Save", jobSpec, je).Return(errors.New("db is down"))
+		defer eventRepo.AssertExpectations(t)
+		eventRepoFac := new(mock.JobEventRepoFactory)
+		eventRepoFac.On("New", namespaceSpec).Return(eventRepo)
+		defer eventRepoFac.AssertExpectations(t)
+
+		notifier := new(mock.Notifier)
+		notifier.On("Notify", context.Background(), models.NotifyAttrs{
+			Namespace: namespaceSpec,
+			JobSpec:   jobSpec,
+			JobEvent:  je,
+			Route:     "@devs",
+		}).Return(nil)
+		defer notifier.AssertExpectations(t)
+
+		evtService := job.NewEventService(map[string]models.Notifier{
+			"slacker": notifier,
+		})
+		evtService.EventRepoFac = eventRepoFac
+		err := evtService.Register(context.Background(), namespaceSpec, jobSpec, je)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "db is down")
+	})
+	t.Run("should alert channels of project and namespace accepting severity of event", func(t *testing.T) {
+		projectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "a-data-project",
+			Config: map[string]string{
+				models.AlertChannelsConfig: "pager://r0ut1ngk3y?severity=critical",
+			},
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "game_jam",
+			Config: map[string]string{
+				models.AlertChannelsConfig: "slacker://#game-alerts",
+			},
+			ProjectSpec: projectSpec,
+		}
+		jobSpec := models.JobSpec{
+			Name: "transform-tables",
+		}
+		je := models.JobEvent{
+			Type:  models.JobEventTypeSLAMiss,
+			Value: eventValues.GetFields(),
+		}
+
+		// sla miss is a warning, pager only accepts critical alerts
+		pager := new(mock.Notifier)
+		defer pager.AssertExpectations(t)
+		slacker := new(mock.Notifier)
+		slacker.On("Notify", context.Background(), models.NotifyAttrs{
+			Namespace: namespaceSpec,
+			JobSpec:   jobSpec,
+			JobEvent:  je,
+			Route:     "#game-alerts",
+		}).Return(nil)
+		defer slacker.AssertExpectations(t)
+
+		evtService := job.NewEventService(map[string]models.Notifier{
+			"slacker": slacker,
+			"pager":   pager,
+		})
+		err := evtService.Register(context.Background(), namespaceSpec, jobSpec, je)
+		assert.Nil(t, err)
+	})
+	t.Run("should fail to alert channels of unknown type", func(t *testing.T) {
+		namespaceSpec := models.NamespaceSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "game_jam",
+			Config: map[string]string{
+				models.AlertChannelsConfig: "blocker://#game-alerts",
+			},
+		}
+		evtService := job.NewEventService(map[string]models.Notifier{})
+		err := evtService.Alert(context.Background(), namespaceSpec, models.JobSpec{}, models.JobEvent{
+			Type: models.JobEventTypeDeployFailure,
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unknown alert channel type blocker")
+	})
 }
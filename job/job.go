@@ -7,5 +7,12 @@ type SpecRepository interface {
 	Save(models.JobSpec) error
 	GetByName(string) (models.JobSpec, error)
 	GetAll() ([]models.JobSpec, error)
+	// GetPage returns at most limit jobs ordered by name, starting
+	// after the job named after
+	GetPage(after string, limit int) ([]models.JobSpec, error)
 	Delete(string) error
+	// GetRevisions returns the saved revisions of a job, latest first
+	GetRevisions(string) ([]models.JobSpecRevision, error)
+	// GetRevision returns a single revision of a job
+	GetRevision(string, int) (models.JobSpecRevision, error)
 }
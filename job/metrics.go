@@ -0,0 +1,55 @@
+package job
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	metricsNamespace = "optimus"
+	metricsSubsystem = "job"
+
+	syncResultSuccess   = "success"
+	syncResultFailure   = "failure"
+	syncResultCancelled = "cancelled"
+	syncResultUnchanged = "unchanged"
+)
+
+var (
+	syncDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sync_duration_seconds",
+		Help:      "Time taken to sync jobs of a namespace with the scheduler",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1200},
+	}, []string{"project", "result"})
+
+	syncsInProgress = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "syncs_in_progress",
+		Help:      "Number of job syncs currently running",
+	})
+
+	syncErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sync_errors_total",
+		Help:      "Number of job syncs and job uploads failed, by category of the failure",
+	}, []string{"project", "category"})
+
+	jobsSynced = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "synced_total",
+		Help:      "Number of jobs uploaded to the scheduler",
+	}, []string{"project", "result"})
+
+	dependencyResolveDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "dependency_resolve_duration_seconds",
+		Help:      "Time taken to resolve dependencies of all jobs in a project",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300},
+	}, []string{"project"})
+)
@@ -65,7 +65,7 @@ func prepareTree(replayRequest *models.ReplayWorkerRequest) (*tree.TreeNode, err
 	// compute runs that require replay
 	dagTree := tree.NewMultiRootTree()
 	parentNode := tree.NewTreeNode(replayJobSpec)
-	if runs, err := getRunsBetweenDates(replayRequest.Start, replayRequest.End, replayJobSpec.Schedule.Interval); err == nil {
+	if runs, err := GetReplayRuns(replayJobSpec, replayRequest.Start, replayRequest.End); err == nil {
 		for _, run := range runs {
 			parentNode.Runs.Add(run)
 		}
@@ -145,9 +145,13 @@ func populateDownstreamRuns(parentNode *tree.TreeNode) (*tree.TreeNode, error) {
 		if err != nil {
 			return nil, err
 		}
+		loc, err := childDag.Schedule.Location()
+		if err != nil {
+			return nil, err
+		}
 
 		for _, parentRunDateRaw := range parentNode.Runs.Values() { //
-			parentRunDate := parentRunDateRaw.(time.Time)
+			parentRunDate := parentRunDateRaw.(time.Time).In(loc)
 
 			// subtract 1 day to make end inclusive
 			parentEndDate := parentRunDate.Add(time.Hour * -24).Add(childDag.Task.Window.Size)
@@ -178,8 +182,22 @@ func populateDownstreamRuns(parentNode *tree.TreeNode) (*tree.TreeNode, error) {
 	return parentNode, nil
 }
 
+// GetReplayRuns returns the scheduled runs of a job which a replay between
+// start and end dates would execute, both dates are inclusive
+func GetReplayRuns(jobSpec models.JobSpec, start, end time.Time) ([]time.Time, error) {
+	loc, err := jobSpec.Schedule.Location()
+	if err != nil {
+		return nil, err
+	}
+	// replay dates are calendar dates of the job schedule timezone
+	start = time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+	end = time.Date(end.Year(), end.Month(), end.Day(), end.Hour(), end.Minute(), end.Second(), 0, loc)
+	return getRunsBetweenDates(start, end, jobSpec.Schedule.Interval)
+}
+
 // getRunsBetweenDates provides execution runs from start to end following a schedule interval
-// start and end both are inclusive
+// start and end both are inclusive, schedule is evaluated in location of start and runs are
+// returned in UTC
 func getRunsBetweenDates(start time.Time, end time.Time, schedule string) ([]time.Time, error) {
 	var runs []time.Time
 
@@ -196,7 +214,7 @@ func getRunsBetweenDates(start time.Time, end time.Time, schedule string) ([]tim
 
 	// loop until start date reaches end date
 	for run := schd.Next(start.Add(time.Second * -1)); run.Before(replayRunEnd); run = schd.Next(run) {
-		runs = append(runs, run)
+		runs = append(runs, run.UTC())
 	}
 
 	return runs, nil
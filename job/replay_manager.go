@@ -63,6 +63,10 @@ type Manager struct {
 
 	replaySpecRepoFac ReplaySpecRepoFactory
 	scheduler         models.SchedulerUnit
+
+	// ProjectScheduler returns the scheduler runs of a project are checked
+	// in, scheduler of the manager is used for every project when not set
+	ProjectScheduler func(models.ProjectSpec) (models.SchedulerUnit, error)
 }
 
 // Replay a request asynchronously, returns a replay id that can
@@ -158,9 +162,13 @@ func cancelConflictedReplays(replaySpecRepo store.ReplaySpecRepository, reqInput
 
 func (m *Manager) validateRunningInstance(ctx context.Context, reqReplayNodes []*tree.TreeNode, reqInput *models.ReplayWorkerRequest) error {
 	requestBatchSize := 100
+	scheduler, err := projectSchedulerOrDefault(m.ProjectScheduler, m.scheduler, reqInput.Project)
+	if err != nil {
+		return err
+	}
 	for _, reqReplayNode := range reqReplayNodes {
 		batchEndDate := reqInput.End.AddDate(0, 0, 1)
-		jobStatusAllRuns, err := m.scheduler.GetDagRunStatus(ctx, reqInput.Project, reqInput.Job.Name, reqInput.Start, batchEndDate, requestBatchSize)
+		jobStatusAllRuns, err := scheduler.GetDagRunStatus(ctx, reqInput.Project, reqInput.Job.Name, reqInput.Start, batchEndDate, requestBatchSize)
 		if err != nil {
 			return err
 		}
@@ -39,7 +39,7 @@ func getRuns(root *tree.TreeNode, countMap map[string][]time.Time) {
 func TestReplay(t *testing.T) {
 	ctx := context.TODO()
 	noDependency := map[string]models.JobSpecDependency{}
-	dumpAssets := func(jobSpec models.JobSpec, scheduledAt time.Time) (models.JobAssets, error) {
+	dumpAssets := func(_ models.ProjectSpec, jobSpec models.JobSpec, scheduledAt time.Time) (models.JobAssets, error) {
 		return jobSpec.Assets, nil
 	}
 	var (
@@ -410,3 +410,26 @@ func TestReplay(t *testing.T) {
 		})
 	})
 }
+
+func TestGetReplayRuns(t *testing.T) {
+	t.Run("should return runs between dates in utc", func(t *testing.T) {
+		runs, err := job.GetReplayRuns(models.JobSpec{
+			Schedule: models.JobSpecSchedule{Interval: "0 2 * * *"},
+		}, time.Date(2020, 8, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 8, 6, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2020, 8, 5, 2, 0, 0, 0, time.UTC),
+			time.Date(2020, 8, 6, 2, 0, 0, 0, time.UTC),
+		}, runs)
+	})
+	t.Run("should evaluate interval on dates of schedule timezone", func(t *testing.T) {
+		runs, err := job.GetReplayRuns(models.JobSpec{
+			Schedule: models.JobSpecSchedule{Interval: "0 2 * * *", Timezone: "Asia/Jakarta"},
+		}, time.Date(2020, 8, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 8, 6, 0, 0, 0, 0, time.UTC))
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2020, 8, 4, 19, 0, 0, 0, time.UTC),
+			time.Date(2020, 8, 5, 19, 0, 0, 0, time.UTC),
+		}, runs)
+	})
+}
@@ -22,6 +22,10 @@ type ReplayWorker interface {
 type replayWorker struct {
 	replaySpecRepoFac ReplaySpecRepoFactory
 	scheduler         models.SchedulerUnit
+
+	// ProjectScheduler returns the scheduler runs of a project are cleared
+	// in, scheduler of the worker is used for every project when not set
+	ProjectScheduler func(models.ProjectSpec) (models.SchedulerUnit, error)
 }
 
 func (w *replayWorker) Process(ctx context.Context, input *models.ReplayWorkerRequest) (err error) {
@@ -35,13 +39,18 @@ func (w *replayWorker) Process(ctx context.Context, input *models.ReplayWorkerRe
 	if err != nil {
 		return err
 	}
+	scheduler, err := projectSchedulerOrDefault(w.ProjectScheduler, w.scheduler, input.Project)
+	if err != nil {
+		return err
+	}
 
-	replayDagsMap := replayTree.GetAllNodes()
+	// clear upstream runs first so jobs are re-run after the jobs they depend on
+	replayDagsMap := replayTree.GetAllNodesInTopologicalOrder()
 	for _, treeNode := range replayDagsMap {
 		runTimes := treeNode.Runs.Values()
 		startTime := runTimes[0].(time.Time)
 		endTime := runTimes[treeNode.Runs.Size()-1].(time.Time)
-		if err = w.scheduler.Clear(ctx, input.Project, treeNode.GetName(), startTime, endTime); err != nil {
+		if err = scheduler.Clear(ctx, input.Project, treeNode.GetName(), startTime, endTime); err != nil {
 			err = errors.Wrapf(err, "error while clearing dag runs for job %s", treeNode.GetName())
 			logger.W(fmt.Sprintf("error while running replay %s: %s", input.ID.String(), err.Error()))
 			if updateStatusErr := replaySpecRepo.UpdateStatus(input.ID, models.ReplayStatusFailed, models.ReplayMessage{
@@ -61,6 +70,16 @@ func (w *replayWorker) Process(ctx context.Context, input *models.ReplayWorkerRe
 	return nil
 }
 
+// projectSchedulerOrDefault returns scheduler of the project if projectScheduler
+// is set, defaultScheduler otherwise
+func projectSchedulerOrDefault(projectScheduler func(models.ProjectSpec) (models.SchedulerUnit, error),
+	defaultScheduler models.SchedulerUnit, project models.ProjectSpec) (models.SchedulerUnit, error) {
+	if projectScheduler == nil {
+		return defaultScheduler, nil
+	}
+	return projectScheduler(project)
+}
+
 func NewReplayWorker(replaySpecRepoFac ReplaySpecRepoFactory, scheduler models.SchedulerUnit) *replayWorker {
 	return &replayWorker{replaySpecRepoFac: replaySpecRepoFac, scheduler: scheduler}
 }
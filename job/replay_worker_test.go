@@ -152,6 +152,31 @@ func TestReplayWorker(t *testing.T) {
 			err := worker.Process(ctx, replayRequest)
 			assert.Nil(t, err)
 		})
+		t.Run("should clear runs in the scheduler of the project", func(t *testing.T) {
+			ctx := context.Background()
+			replayRepository := new(mock.ReplayRepository)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusInProgress, models.ReplayMessage{}).Return(nil)
+			replayRepository.On("UpdateStatus", currUUID, models.ReplayStatusSuccess, models.ReplayMessage{}).Return(nil)
+
+			replaySpecRepoFac := new(mock.ReplaySpecRepoFactory)
+			defer replaySpecRepoFac.AssertExpectations(t)
+			replaySpecRepoFac.On("New", replayRequest.Job).Return(replayRepository)
+
+			defaultScheduler := new(mock.Scheduler)
+			defer defaultScheduler.AssertExpectations(t)
+
+			projectScheduler := new(mock.Scheduler)
+			defer projectScheduler.AssertExpectations(t)
+			projectScheduler.On("Clear", ctx, replayRequest.Project, "job-name", dagRunStartTime, dagRunEndTime).Return(nil)
+
+			worker := job.NewReplayWorker(replaySpecRepoFac, defaultScheduler)
+			worker.ProjectScheduler = func(proj models.ProjectSpec) (models.SchedulerUnit, error) {
+				assert.Equal(t, replayRequest.Project, proj)
+				return projectScheduler, nil
+			}
+			err := worker.Process(ctx, replayRequest)
+			assert.Nil(t, err)
+		})
 		t.Run("should throw an error when prepareTree throws an error", func(t *testing.T) {
 			replayRequest.JobSpecMap = make(map[string]models.JobSpec)
 			ctx := context.Background()
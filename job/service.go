@@ -2,18 +2,24 @@ package job
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/kushsharma/parallel"
+	"github.com/odpf/optimus/core/logger"
 	"github.com/odpf/optimus/core/progress"
 	"github.com/odpf/optimus/meta"
 	"github.com/odpf/optimus/models"
 	"github.com/odpf/optimus/store"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -22,14 +28,44 @@ const (
 
 	ConcurrentTicketPerSec = 40
 	ConcurrentLimit        = 600
+
+	// jobUploadSkipped is the result of uploading a job found unchanged
+	jobUploadSkipped = "upload-skipped"
+
+	// length bounds of job name, scheduler ids are limited to 250 chars
+	JobNameMinLength = 3
+	JobNameMaxLength = 220
 )
 
-type AssetCompiler func(jobSpec models.JobSpec, scheduledAt time.Time) (models.JobAssets, error)
+var (
+	// ErrMissingProjectConfig is returned when a job refers a project
+	// config in its templates which is not set on project or namespace
+	ErrMissingProjectConfig = errors.New("missing project config")
+
+	// ErrMissingGlobalConfig is returned when a job refers a global
+	// config in its templates which is not set on server
+	ErrMissingGlobalConfig = errors.New("missing global config")
+
+	// ErrInvalidJobSpec is returned when name or owner of a job doesn't
+	// follow the rules required by schedulers
+	ErrInvalidJobSpec = errors.New("invalid job spec")
+
+	// ErrDuplicateDestination is returned when more than one job of a
+	// project writes the same destination
+	ErrDuplicateDestination = errors.New("duplicate job destination")
+
+	jobNamePattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+)
+
+// AssetCompiler compiles assets of a job of the project at scheduled time
+type AssetCompiler func(proj models.ProjectSpec, jobSpec models.JobSpec, scheduledAt time.Time) (models.JobAssets, error)
 
 // DependencyResolver compiles static and runtime dependencies
 type DependencyResolver interface {
 	Resolve(projectSpec models.ProjectSpec, projectJobSpecRepo store.ProjectJobSpecRepository,
 		jobSpec models.JobSpec, observer progress.Observer) (models.JobSpec, error)
+	Explain(projectSpec models.ProjectSpec, projectJobSpecRepo store.ProjectJobSpecRepository,
+		jobSpec models.JobSpec) ([]models.DependencyExplanation, error)
 }
 
 // SpecRepoFactory is used to manage job specs at namespace level
@@ -52,6 +88,11 @@ type JobRepoFactory interface {
 	New(context.Context, models.ProjectSpec) (store.JobRepository, error)
 }
 
+// JobChecksumRepoFactory is used to store checksums of uploaded compiled jobs
+type JobChecksumRepoFactory interface {
+	New(models.NamespaceSpec) store.JobChecksumRepository
+}
+
 // ReplaySpecRepoFactory is used to manage replay spec objects from store
 type ReplaySpecRepoFactory interface {
 	New(jobSpec models.JobSpec) store.ReplaySpecRepository
@@ -72,10 +113,56 @@ type Service struct {
 
 	Now           func() time.Time
 	assetCompiler AssetCompiler
+
+	// ProjectScheduler returns the scheduler jobs of a project are deployed
+	// to, paused jobs are kept paused across deploys only when it is set
+	ProjectScheduler func(models.ProjectSpec) (models.SchedulerUnit, error)
+
+	// AllowDuplicateDestinations only warns about jobs writing the same
+	// destination instead of failing the deploy
+	AllowDuplicateDestinations bool
+
+	// GlobalConfig is shared by jobs of all projects, jobs can refer it
+	// as {{.global.KEY}} and as a fallback of project configs
+	GlobalConfig map[string]string
+
+	// SyncConfig bounds the jobs resolved, compiled and uploaded at a time
+	// during sync
+	SyncConfig SyncConfig
+
+	// ChecksumRepoFactory enables incremental sync when set, a compiled job
+	// is not uploaded again if it is the same as the one uploaded last
+	ChecksumRepoFactory JobChecksumRepoFactory
+}
+
+// SyncConfig bounds the worker pool used to sync jobs, zero values fall
+// back to ConcurrentLimit and ConcurrentTicketPerSec
+type SyncConfig struct {
+	// Workers is the number of jobs processed concurrently
+	Workers int
+	// TicketsPerSec caps the jobs picked up per second across workers, it
+	// keeps sync under the rate limits of stores jobs are uploaded to
+	TicketsPerSec int
+}
+
+// syncRunner returns a runner processing jobs with the bounds of SyncConfig
+func (srv *Service) syncRunner() *parallel.Runner {
+	workers, ticketsPerSec := srv.SyncConfig.Workers, srv.SyncConfig.TicketsPerSec
+	if workers <= 0 {
+		workers = ConcurrentLimit
+	}
+	if ticketsPerSec <= 0 {
+		ticketsPerSec = ConcurrentTicketPerSec
+	}
+	return parallel.NewRunner(parallel.WithTicket(ticketsPerSec), parallel.WithLimit(workers))
 }
 
 // Create constructs a Job for a namespace and commits it to the store
 func (srv *Service) Create(namespace models.NamespaceSpec, spec models.JobSpec) error {
+	if err := srv.Validate(namespace, spec); err != nil {
+		return err
+	}
+
 	jobRepo := srv.jobSpecRepoFactory.New(namespace)
 	if err := jobRepo.Save(spec); err != nil {
 		return errors.Wrapf(err, "failed to save job: %s", spec.Name)
@@ -83,6 +170,55 @@ func (srv *Service) Create(namespace models.NamespaceSpec, spec models.JobSpec)
 	return nil
 }
 
+// Validate checks the job spec and the configs it refers without saving it
+func (srv *Service) Validate(namespace models.NamespaceSpec, spec models.JobSpec) error {
+	if err := validateJobSpec(spec); err != nil {
+		return err
+	}
+	return validateProjectConfigReferences(namespace, spec, srv.GlobalConfig)
+}
+
+// validateJobSpec checks job name can be used as an id in scheduler and
+// job has an owner
+func validateJobSpec(spec models.JobSpec) error {
+	if len(spec.Name) < JobNameMinLength || len(spec.Name) > JobNameMaxLength {
+		return errors.Wrapf(ErrInvalidJobSpec, "job name %s should be between %d and %d characters",
+			spec.Name, JobNameMinLength, JobNameMaxLength)
+	}
+	if !jobNamePattern.MatchString(spec.Name) {
+		return errors.Wrapf(ErrInvalidJobSpec, "job name %s should only contain lowercase letters, digits, _ and -", spec.Name)
+	}
+	if strings.TrimSpace(spec.Owner) == "" {
+		return errors.Wrapf(ErrInvalidJobSpec, "job %s should have an owner", spec.Name)
+	}
+	return nil
+}
+
+// validateProjectConfigReferences checks if project configs referred in
+// job templates are available either in namespace, project or global config
+// and global configs referred are available in global config
+func validateProjectConfigReferences(namespace models.NamespaceSpec, spec models.JobSpec, global map[string]string) error {
+	configs := namespace.ResolvedConfig(global)
+	var missing []string
+	for _, key := range spec.ProjectConfigReferences() {
+		if _, ok := configs[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Wrapf(ErrMissingProjectConfig, "job %s refers %s", spec.Name, strings.Join(missing, ", "))
+	}
+	for _, key := range spec.GlobalConfigReferences() {
+		if _, ok := global[key]; !ok {
+			missing = append(missing, fmt.Sprintf("%s.%s", models.GlobalConfigTemplateKey, key))
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Wrapf(ErrMissingGlobalConfig, "job %s refers %s", spec.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // GetByName fetches a Job by name for a specific namespace
 func (srv *Service) GetByName(name string, namespace models.NamespaceSpec) (models.JobSpec, error) {
 	jobSpec, err := srv.jobSpecRepoFactory.New(namespace).GetByName(name)
@@ -109,6 +245,16 @@ func (srv *Service) GetAll(namespace models.NamespaceSpec) ([]models.JobSpec, er
 	return jobSpecs, nil
 }
 
+// GetPage returns at most limit jobs of the namespace ordered by name,
+// starting after the job named after
+func (srv *Service) GetPage(namespace models.NamespaceSpec, after string, limit int) ([]models.JobSpec, error) {
+	jobSpecs, err := srv.jobSpecRepoFactory.New(namespace).GetPage(after, limit)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve jobs")
+	}
+	return jobSpecs, nil
+}
+
 // Dump takes a jobSpec of a project, resolves dependencies, priorities and returns the compiled Job
 func (srv *Service) Dump(namespace models.NamespaceSpec, jobSpec models.JobSpec) (models.Job, error) {
 	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(namespace.ProjectSpec)
@@ -142,11 +288,12 @@ func (srv *Service) Dump(namespace models.NamespaceSpec, jobSpec models.JobSpec)
 	return compiledJob, nil
 }
 
-// Check if job specifications are valid
+// Check validates job specifications the way a deploy would without saving
+// or uploading anything, result of each job is notified as it gets checked
 func (srv *Service) Check(namespace models.NamespaceSpec, jobSpecs []models.JobSpec, obs progress.Observer) (err error) {
 	for i, jSpec := range jobSpecs {
 		// compile assets
-		if jobSpecs[i].Assets, err = srv.assetCompiler(jSpec, srv.Now()); err != nil {
+		if jobSpecs[i].Assets, err = srv.assetCompiler(namespace.ProjectSpec, jSpec, srv.Now()); err != nil {
 			return errors.Wrap(err, "asset compilation")
 		}
 
@@ -154,10 +301,21 @@ func (srv *Service) Check(namespace models.NamespaceSpec, jobSpecs []models.JobS
 		jobSpecs[i].Dependencies = map[string]models.JobSpecDependency{}
 	}
 
+	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(namespace.ProjectSpec)
 	runner := parallel.NewRunner(parallel.WithTicket(ConcurrentTicketPerSec), parallel.WithLimit(ConcurrentLimit))
 	for _, jSpec := range jobSpecs {
 		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
 			return func() (interface{}, error) {
+				// check name, owner and project configs used
+				if err := validateJobSpec(currentSpec); err != nil {
+					srv.notifyProgress(obs, &EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("validation: %s\n", err.Error())})
+					return nil, err
+				}
+				if err := validateProjectConfigReferences(namespace, currentSpec, srv.GlobalConfig); err != nil {
+					srv.notifyProgress(obs, &EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("validation: %s\n", err.Error())})
+					return nil, err
+				}
+
 				// check dependencies
 				if _, err := currentSpec.Task.Unit.GenerateTaskDependencies(context.TODO(), models.GenerateTaskDependenciesRequest{
 					Config:  models.TaskPluginConfigs{}.FromJobSpec(currentSpec.Task.Config),
@@ -167,27 +325,68 @@ func (srv *Service) Check(namespace models.NamespaceSpec, jobSpecs []models.JobS
 						DryRun: true,
 					},
 				}); err != nil {
-					obs.Notify(&EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("dependency resolution: %s\n", err.Error())})
+					srv.notifyProgress(obs, &EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("dependency resolution: %s\n", err.Error())})
+					return nil, errors.Wrapf(err, "failed to resolve dependencies %s", currentSpec.Name)
+				}
+				resolvedSpec, err := srv.dependencyResolver.Resolve(namespace.ProjectSpec, projectJobSpecRepo, currentSpec, obs)
+				if err != nil {
+					srv.notifyProgress(obs, &EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("dependency resolution: %s\n", err.Error())})
 					return nil, errors.Wrapf(err, "failed to resolve dependencies %s", currentSpec.Name)
 				}
 
 				// check compilation
-				if _, err := srv.compiler.Compile(namespace, currentSpec); err != nil {
-					obs.Notify(&EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("compilation: %s\n", err.Error())})
+				if _, err := srv.compiler.Compile(namespace, resolvedSpec); err != nil {
+					srv.notifyProgress(obs, &EventJobCheckFailed{Name: currentSpec.Name, Reason: fmt.Sprintf("compilation: %s\n", err.Error())})
 					return nil, errors.Wrapf(err, "failed to compile %s", currentSpec.Name)
 				}
 
-				obs.Notify(&EventJobCheckSuccess{Name: currentSpec.Name})
-				return nil, nil
+				srv.notifyProgress(obs, &EventJobCheckSuccess{Name: currentSpec.Name})
+				return resolvedSpec, nil
 			}
 		}(jSpec))
 	}
+
+	var resolvedSpecs []models.JobSpec
 	for _, result := range runner.Run() {
 		if result.Err != nil {
 			err = multierror.Append(err, result.Err)
+			continue
+		}
+		resolvedSpecs = append(resolvedSpecs, result.Val.(models.JobSpec))
+	}
+	if err != nil {
+		return err
+	}
+
+	// check dependencies between the checked jobs can be ordered
+	if _, err := srv.priorityResolver.Resolve(withBatchDependencies(resolvedSpecs)); err != nil {
+		return errors.Wrap(err, "priority resolution")
+	}
+	return nil
+}
+
+// withBatchDependencies returns copies of jobSpecs where dependencies on
+// jobs outside of jobSpecs are treated like the ones on other projects,
+// those jobs are deployed already so only jobSpecs need to be ordered
+func withBatchDependencies(jobSpecs []models.JobSpec) []models.JobSpec {
+	inBatch := map[string]bool{}
+	for _, jobSpec := range jobSpecs {
+		inBatch[jobSpec.Name] = true
+	}
+
+	batchSpecs := make([]models.JobSpec, len(jobSpecs))
+	for idx, jobSpec := range jobSpecs {
+		dependencies := map[string]models.JobSpecDependency{}
+		for name, dependency := range jobSpec.Dependencies {
+			if dependency.Job != nil && !inBatch[dependency.Job.Name] && dependency.Type == models.JobSpecDependencyTypeIntra {
+				dependency.Type = models.JobSpecDependencyTypeInter
+			}
+			dependencies[name] = dependency
 		}
+		jobSpec.Dependencies = dependencies
+		batchSpecs[idx] = jobSpec
 	}
-	return err
+	return batchSpecs
 }
 
 // Delete deletes a job spec from all spec repos
@@ -212,6 +411,44 @@ func (srv *Service) Delete(ctx context.Context, namespace models.NamespaceSpec,
 // assign proper priority weights, compiles it and uploads it to the destination
 // store
 func (srv *Service) Sync(ctx context.Context, namespace models.NamespaceSpec, progressObserver progress.Observer) error {
+	return srv.sync(ctx, namespace, nil, progressObserver)
+}
+
+// SyncJob uploads only the named job of a namespace, dependencies and
+// priorities are still resolved for the whole project but no other job
+// is uploaded or deleted
+func (srv *Service) SyncJob(ctx context.Context, namespace models.NamespaceSpec, jobName string, progressObserver progress.Observer) error {
+	return srv.sync(ctx, namespace, []string{jobName}, progressObserver)
+}
+
+// sync uploads the jobs of a namespace, limited to jobNames when provided,
+// jobs missing from the namespace are deleted from the store on a full sync
+func (srv *Service) sync(ctx context.Context, namespace models.NamespaceSpec, jobNames []string,
+	progressObserver progress.Observer) (err error) {
+	// stage of the sync currently running, used to categorise failures
+	category := ErrorCategoryDependency
+	startTime := time.Now()
+	syncsInProgress.Inc()
+	defer func() {
+		syncsInProgress.Dec()
+		projectName := namespace.ProjectSpec.Name
+		log := namespaceLogger(ctx, namespace).WithField("duration", time.Since(startTime).String())
+		switch {
+		case err == nil:
+			syncDuration.WithLabelValues(projectName, syncResultSuccess).Observe(time.Since(startTime).Seconds())
+			log.Info("synced jobs")
+		case ctx.Err() != nil:
+			syncDuration.WithLabelValues(projectName, syncResultCancelled).Observe(time.Since(startTime).Seconds())
+			log.Warn("job sync cancelled")
+			srv.notifyProgress(progressObserver, &EventJobSyncCancelled{Err: ctx.Err()})
+		default:
+			syncDuration.WithLabelValues(projectName, syncResultFailure).Observe(time.Since(startTime).Seconds())
+			syncErrors.WithLabelValues(projectName, string(category)).Inc()
+			log.WithError(err).WithField("category", category).Error("failed to sync jobs")
+			srv.notifyProgress(progressObserver, &EventJobSyncFailed{Err: err, Category: category})
+		}
+	}()
+
 	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(namespace.ProjectSpec)
 	jobSpecs, err := srv.GetDependencyResolvedSpecs(namespace.ProjectSpec, projectJobSpecRepo, progressObserver)
 	if err != nil {
@@ -219,30 +456,86 @@ func (srv *Service) Sync(ctx context.Context, namespace models.NamespaceSpec, pr
 	}
 	srv.notifyProgress(progressObserver, &EventJobSpecDependencyResolve{})
 
+	// resolving dependencies takes a while, stop if deploy got cancelled meanwhile
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	jobSpecs, err = srv.priorityResolver.Resolve(jobSpecs)
 	if err != nil {
 		return err
 	}
 	srv.notifyProgress(progressObserver, &EventJobPriorityWeightAssign{})
 
+	projectJobSpecs := jobSpecs
 	jobSpecs, err = srv.filterJobSpecForNamespace(jobSpecs, namespace)
 	if err != nil {
 		return err
 	}
+	if jobNames != nil {
+		if jobSpecs, err = filterJobSpecByName(jobSpecs, jobNames, namespace); err != nil {
+			return err
+		}
+	}
 
+	category = ErrorCategoryValidation
+	if err = srv.checkDuplicateDestinations(ctx, namespace.ProjectSpec, projectJobSpecs, jobSpecs, progressObserver); err != nil {
+		return err
+	}
+
+	// jobs past their end date are still deployed but will not be
+	// scheduled anymore, warn so they can be cleaned up
+	for _, jobSpec := range jobSpecs {
+		if jobSpec.Schedule.EndDate != nil && jobSpec.Schedule.EndDate.Before(srv.Now()) {
+			srv.notifyProgress(progressObserver, &EventJobSpecEndDatePassed{Name: jobSpec.Name, EndDate: *jobSpec.Schedule.EndDate})
+		}
+	}
+
+	category = ErrorCategoryUpload
 	jobRepo, err := srv.jobRepoFactory.New(ctx, namespace.ProjectSpec)
 	if err != nil {
 		return err
 	}
 
-	if err = srv.uploadSpecs(ctx, jobSpecs, jobRepo, namespace, progressObserver); err != nil {
+	// uploading a job could reset its paused state in scheduler depending
+	// on how the compiled job is written, note paused jobs to pause again
+	var scheduler models.SchedulerUnit
+	var pausedJobNames []string
+	if srv.ProjectScheduler != nil {
+		if scheduler, err = srv.ProjectScheduler(namespace.ProjectSpec); err != nil {
+			return err
+		}
+		pausedJobNames = srv.pausedJobs(ctx, scheduler, namespace.ProjectSpec, jobSpecs)
+	}
+
+	var checksumRepo store.JobChecksumRepository
+	uploadedChecksums := map[string]string{}
+	if srv.ChecksumRepoFactory != nil {
+		checksumRepo = srv.ChecksumRepoFactory.New(namespace)
+		if uploadedChecksums, err = srv.uploadedChecksums(ctx, checksumRepo, jobRepo, namespace); err != nil {
+			return err
+		}
+	}
+
+	if err = srv.uploadSpecs(ctx, jobSpecs, jobRepo, checksumRepo, uploadedChecksums, namespace, progressObserver); err != nil {
 		return err
 	}
 
+	for _, jobName := range pausedJobNames {
+		if err = scheduler.SetJobPaused(ctx, namespace.ProjectSpec, jobName, true); err != nil {
+			return errors.Wrapf(err, "failed to keep job %s paused", jobName)
+		}
+	}
+
 	if err = srv.publishMetadata(namespace, jobSpecs, progressObserver); err != nil {
 		return err
 	}
 
+	// jobs not being synced are left untouched in store
+	if jobNames != nil {
+		return nil
+	}
+
 	// get all the stored job names
 	destJobNames, err := jobRepo.ListNames(ctx, namespace)
 	if err != nil {
@@ -261,13 +554,47 @@ func (srv *Service) Sync(ctx context.Context, namespace models.NamespaceSpec, pr
 		if err := jobRepo.Delete(ctx, namespace, dagName); err != nil {
 			return err
 		}
+		if checksumRepo != nil {
+			if err := checksumRepo.Delete(ctx, dagName); err != nil {
+				namespaceLogger(ctx, namespace).WithField(logger.FieldJob, dagName).WithError(err).
+					Warn("failed to delete checksum of job")
+			}
+		}
 		srv.notifyProgress(progressObserver, &EventJobRemoteDelete{dagName})
 	}
 	return nil
 }
 
-// KeepOnly only keeps the provided jobSpecs in argument and deletes rest from spec repository
-func (srv *Service) KeepOnly(namespace models.NamespaceSpec, specsToKeep []models.JobSpec, progressObserver progress.Observer) error {
+// GetHistory returns all saved revisions of a job, latest first
+func (srv *Service) GetHistory(namespace models.NamespaceSpec, jobName string) ([]models.JobSpecRevision, error) {
+	revisions, err := srv.jobSpecRepoFactory.New(namespace).GetRevisions(jobName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch revisions of %s", jobName)
+	}
+	return revisions, nil
+}
+
+// Rollback saves the spec of an earlier revision of a job as its latest
+// revision and deploys only that job
+func (srv *Service) Rollback(ctx context.Context, namespace models.NamespaceSpec, jobName string, revision int,
+	progressObserver progress.Observer) (models.JobSpec, error) {
+	jobRevision, err := srv.jobSpecRepoFactory.New(namespace).GetRevision(jobName, revision)
+	if err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "failed to fetch revision %d of %s", revision, jobName)
+	}
+
+	if err := srv.Create(namespace, jobRevision.Spec); err != nil {
+		return models.JobSpec{}, err
+	}
+	if err := srv.SyncJob(ctx, namespace, jobName, progressObserver); err != nil {
+		return models.JobSpec{}, err
+	}
+	return jobRevision.Spec, nil
+}
+
+// KeepOnly only keeps the provided jobSpecs in argument and deletes rest from spec repository,
+// jobs other jobs of the project still depend on are kept unless force is set
+func (srv *Service) KeepOnly(namespace models.NamespaceSpec, specsToKeep []models.JobSpec, force bool, progressObserver progress.Observer) error {
 	jobSpecRepo := srv.jobSpecRepoFactory.New(namespace)
 	jobSpecs, err := jobSpecRepo.GetAll()
 	if err != nil {
@@ -287,6 +614,25 @@ func (srv *Service) KeepOnly(namespace models.NamespaceSpec, specsToKeep []model
 	jobsToDelete := setSubstract(specsPresentNames, specsToKeepNames)
 	jobsToDelete = jobDeletionFilter(jobsToDelete)
 
+	if !force && len(jobsToDelete) > 0 {
+		dependents, err := srv.getDependents(namespace.ProjectSpec, jobsToDelete)
+		if err != nil {
+			return errors.Wrap(err, "failed to find dependents of jobs being deleted")
+		}
+		var deletable []string
+		for _, jobName := range jobsToDelete {
+			if len(dependents[jobName]) > 0 {
+				srv.notifyProgress(progressObserver, &EventSavedJobDeleteBlocked{
+					Name:       jobName,
+					Dependents: dependents[jobName],
+				})
+				continue
+			}
+			deletable = append(deletable, jobName)
+		}
+		jobsToDelete = deletable
+	}
+
 	for _, jobName := range jobsToDelete {
 		// delete raw spec
 		if err := jobSpecRepo.Delete(jobName); err != nil {
@@ -318,8 +664,31 @@ func (srv *Service) filterJobSpecForNamespace(jobSpecs []models.JobSpec, namespa
 	return filteredJobSpecs, nil
 }
 
+// filterJobSpecByName returns the named job specs, all of them are expected
+// to be present
+func filterJobSpecByName(jobSpecs []models.JobSpec, jobNames []string, namespace models.NamespaceSpec) ([]models.JobSpec, error) {
+	byName := map[string]models.JobSpec{}
+	for _, jobSpec := range jobSpecs {
+		byName[jobSpec.Name] = jobSpec
+	}
+
+	var filteredJobSpecs []models.JobSpec
+	for _, jobName := range jobNames {
+		jobSpec, ok := byName[jobName]
+		if !ok {
+			return nil, errors.Errorf("job %s not found in namespace %s", jobName, namespace.Name)
+		}
+		filteredJobSpecs = append(filteredJobSpecs, jobSpec)
+	}
+	return filteredJobSpecs, nil
+}
+
 func (srv *Service) GetDependencyResolvedSpecs(proj models.ProjectSpec, projectJobSpecRepo store.ProjectJobSpecRepository,
 	progressObserver progress.Observer) (resolvedSpecs []models.JobSpec, resolvedErrors error) {
+	defer func(startTime time.Time) {
+		dependencyResolveDuration.WithLabelValues(proj.Name).Observe(time.Since(startTime).Seconds())
+	}(time.Now())
+
 	// fetch all jobs since dependency resolution happens for all jobs in a project, not just for a namespace
 	jobSpecs, err := projectJobSpecRepo.GetAll()
 	if err != nil {
@@ -327,18 +696,17 @@ func (srv *Service) GetDependencyResolvedSpecs(proj models.ProjectSpec, projectJ
 	}
 	srv.notifyProgress(progressObserver, &EventJobSpecFetch{})
 
-	// compile assets first
-	for i, jSpec := range jobSpecs {
-		if jobSpecs[i].Assets, err = srv.assetCompiler(jSpec, srv.Now()); err != nil {
-			return nil, errors.Wrap(err, "asset compilation")
-		}
-	}
-
-	// resolve specs in parallel
-	runner := parallel.NewRunner(parallel.WithTicket(ConcurrentTicketPerSec), parallel.WithLimit(ConcurrentLimit))
+	// compile assets and resolve specs in parallel, failures of every job
+	// are collected instead of stopping at the first one
+	now := srv.Now()
+	runner := srv.syncRunner()
 	for _, jobSpec := range jobSpecs {
 		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
 			return func() (interface{}, error) {
+				var err error
+				if currentSpec.Assets, err = srv.assetCompiler(proj, currentSpec, now); err != nil {
+					return nil, errors.Wrapf(err, "asset compilation of %s", currentSpec.Name)
+				}
 				resolvedSpec, err := srv.dependencyResolver.Resolve(proj, projectJobSpecRepo, currentSpec, progressObserver)
 				if err != nil {
 					return nil, errors.Wrapf(err, "failed to resolve dependency for %s", currentSpec.Name)
@@ -359,23 +727,155 @@ func (srv *Service) GetDependencyResolvedSpecs(proj models.ProjectSpec, projectJ
 	return resolvedSpecs, resolvedErrors
 }
 
+// checkDuplicateDestinations looks for destinations written by more than one
+// job of the project, only conflicts involving jobs being deployed are reported
+func (srv *Service) checkDuplicateDestinations(ctx context.Context, proj models.ProjectSpec, projectJobSpecs,
+	jobSpecs []models.JobSpec, progressObserver progress.Observer) error {
+	deployed := map[string]bool{}
+	for _, jobSpec := range jobSpecs {
+		deployed[jobSpec.Name] = true
+	}
+
+	writers := map[string][]string{}
+	for _, jobSpec := range projectJobSpecs {
+		if jobSpec.Task.Unit == nil {
+			continue
+		}
+		destination, err := jobSpec.Task.Unit.GenerateTaskDestination(ctx, models.GenerateTaskDestinationRequest{
+			Config:  models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+			Assets:  models.TaskPluginAssets{}.FromJobSpec(jobSpec.Assets),
+			Project: proj,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate destination for %s", jobSpec.Name)
+		}
+		for _, urn := range destination.URNs() {
+			writers[urn] = append(writers[urn], jobSpec.Name)
+		}
+	}
+
+	var duplicates []string
+	for urn, jobNames := range writers {
+		if len(jobNames) < 2 {
+			continue
+		}
+		for _, jobName := range jobNames {
+			if deployed[jobName] {
+				duplicates = append(duplicates, urn)
+				break
+			}
+		}
+	}
+	sort.Strings(duplicates)
+
+	var conflicts []string
+	for _, urn := range duplicates {
+		jobNames := writers[urn]
+		sort.Strings(jobNames)
+		srv.notifyProgress(progressObserver, &EventJobSpecDuplicateDestination{Destination: urn, Jobs: jobNames})
+		conflicts = append(conflicts, fmt.Sprintf("%s by %s", urn, strings.Join(jobNames, ", ")))
+	}
+	if len(conflicts) == 0 || srv.AllowDuplicateDestinations {
+		return nil
+	}
+	return errors.Wrapf(ErrDuplicateDestination, "written more than once: %s", strings.Join(conflicts, "; "))
+}
+
+// pausedJobs returns the jobs currently paused in scheduler, keeping the
+// state is best effort so jobs whose state can't be read are left out
+func (srv *Service) pausedJobs(ctx context.Context, scheduler models.SchedulerUnit, proj models.ProjectSpec,
+	jobSpecs []models.JobSpec) []string {
+	runner := parallel.NewRunner(parallel.WithTicket(ConcurrentTicketPerSec), parallel.WithLimit(ConcurrentLimit))
+	for _, jobSpec := range jobSpecs {
+		runner.Add(func(jobName string) func() (interface{}, error) {
+			return func() (interface{}, error) {
+				return scheduler.IsJobPaused(ctx, proj, jobName)
+			}
+		}(jobSpec.Name))
+	}
+
+	var paused []string
+	for runIdx, state := range runner.Run() {
+		if isPaused, _ := state.Val.(bool); state.Err == nil && isPaused {
+			paused = append(paused, jobSpecs[runIdx].Name)
+		}
+	}
+	return paused
+}
+
 // uploadSpecs compiles a Job and uploads it to the destination store
+// uploadedChecksums returns checksums of compiled jobs last uploaded for the
+// namespace, jobs missing in the remote repository are left out so they are
+// uploaded again even if unchanged
+func (srv *Service) uploadedChecksums(ctx context.Context, checksumRepo store.JobChecksumRepository,
+	jobRepo store.JobRepository, namespace models.NamespaceSpec) (map[string]string, error) {
+	checksums, err := checksumRepo.GetAll(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read checksums of uploaded jobs")
+	}
+	remoteJobNames, err := jobRepo.ListNames(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	uploadedChecksums := map[string]string{}
+	for _, jobName := range remoteJobNames {
+		if checksum, ok := checksums[jobName]; ok {
+			uploadedChecksums[jobName] = checksum
+		}
+	}
+	return uploadedChecksums, nil
+}
+
+// compiledJobChecksum is a hex encoded sha256 of everything uploaded for a job
+func compiledJobChecksum(job models.Job) string {
+	hash := sha256.New()
+	hash.Write([]byte(job.Name))
+	hash.Write([]byte{0})
+	hash.Write([]byte(job.NamespaceID))
+	hash.Write([]byte{0})
+	hash.Write(job.Contents)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// uploadSpecs compiles and uploads jobs, when checksumRepo is set a job whose
+// checksum matches the one in uploadedChecksums is not uploaded again
 func (srv *Service) uploadSpecs(ctx context.Context, jobSpecs []models.JobSpec, jobRepo store.JobRepository,
+	checksumRepo store.JobChecksumRepository, uploadedChecksums map[string]string,
 	namespace models.NamespaceSpec, progressObserver progress.Observer) error {
-	runner := parallel.NewRunner(parallel.WithTicket(ConcurrentTicketPerSec))
+	runner := srv.syncRunner()
 	for _, jobSpec := range jobSpecs {
 		runner.Add(func(currentSpec models.JobSpec) func() (interface{}, error) {
 			return func() (interface{}, error) {
+				// deploy might have been aborted while waiting for its turn
+				if err := ctx.Err(); err != nil {
+					return ErrorCategoryCancelled, err
+				}
 				compiledJob, err := srv.compiler.Compile(namespace, currentSpec)
 				if err != nil {
-					return nil, err
+					return ErrorCategoryCompile, err
 				}
 				srv.notifyProgress(progressObserver, &EventJobSpecCompile{
 					Name: currentSpec.Name,
 				})
 
+				if checksumRepo == nil {
+					if err = jobRepo.Save(ctx, compiledJob); err != nil {
+						return ErrorCategoryUpload, err
+					}
+					return nil, nil
+				}
+
+				checksum := compiledJobChecksum(compiledJob)
+				if uploadedChecksums[currentSpec.Name] == checksum {
+					return jobUploadSkipped, nil
+				}
 				if err = jobRepo.Save(ctx, compiledJob); err != nil {
-					return nil, err
+					return ErrorCategoryUpload, err
+				}
+				// a missing checksum only costs an upload on next sync
+				if err = checksumRepo.Save(ctx, currentSpec.Name, checksum); err != nil {
+					namespaceLogger(ctx, namespace).WithField(logger.FieldJob, currentSpec.Name).WithError(err).
+						Warn("failed to save checksum of job")
 				}
 				return nil, nil
 			}
@@ -383,12 +883,35 @@ func (srv *Service) uploadSpecs(ctx context.Context, jobSpecs []models.JobSpec,
 	}
 
 	for runIdx, state := range runner.Run() {
+		category, _ := state.Val.(ErrorCategory)
+		unchanged := state.Val == jobUploadSkipped
+		switch {
+		case state.Err != nil:
+			jobsSynced.WithLabelValues(namespace.ProjectSpec.Name, syncResultFailure).Inc()
+			syncErrors.WithLabelValues(namespace.ProjectSpec.Name, string(category)).Inc()
+			namespaceLogger(ctx, namespace).WithField(logger.FieldJob, jobSpecs[runIdx].Name).WithError(state.Err).
+				WithField("category", category).Warn("failed to upload job")
+		case unchanged:
+			jobsSynced.WithLabelValues(namespace.ProjectSpec.Name, syncResultUnchanged).Inc()
+		default:
+			jobsSynced.WithLabelValues(namespace.ProjectSpec.Name, syncResultSuccess).Inc()
+		}
 		srv.notifyProgress(progressObserver, &EventJobUpload{
-			Job: jobSpecs[runIdx],
-			Err: state.Err,
+			Job:       jobSpecs[runIdx],
+			Err:       state.Err,
+			Category:  category,
+			Unchanged: unchanged,
 		})
 	}
-	return nil
+	return ctx.Err()
+}
+
+// namespaceLogger is the logger of ctx including fields of the namespace
+func namespaceLogger(ctx context.Context, namespace models.NamespaceSpec) logrus.FieldLogger {
+	return logger.FromContext(ctx).WithFields(logrus.Fields{
+		logger.FieldProject:   namespace.ProjectSpec.Name,
+		logger.FieldNamespace: namespace.Name,
+	})
 }
 
 func (srv *Service) publishMetadata(namespace models.NamespaceSpec, jobSpecs []models.JobSpec,
@@ -424,6 +947,273 @@ func (srv *Service) isJobDeletable(projectSpec models.ProjectSpec, jobSpec model
 	return nil
 }
 
+// ExplainDependency tells which jobs were considered for every destination
+// used by the job and why they were picked as its dependency or not
+func (srv *Service) ExplainDependency(projectSpec models.ProjectSpec, jobSpec models.JobSpec) ([]models.DependencyExplanation, error) {
+	// dependencies are generated from compiled assets, same as while deploying
+	var err error
+	if jobSpec.Assets, err = srv.assetCompiler(projectSpec, jobSpec, srv.Now()); err != nil {
+		return nil, errors.Wrap(err, "asset compilation")
+	}
+	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(projectSpec)
+	return srv.dependencyResolver.Explain(projectSpec, projectJobSpecRepo, jobSpec)
+}
+
+// UnknownDependencies returns destinations used by the jobs, by job name,
+// which neither a saved job of the project nor one of the jobs produces
+func (srv *Service) UnknownDependencies(projectSpec models.ProjectSpec, jobSpecs []models.JobSpec) (map[string][]string, error) {
+	produced := map[string]bool{}
+	for _, jobSpec := range jobSpecs {
+		assets, err := srv.assetCompiler(projectSpec, jobSpec, srv.Now())
+		if err != nil {
+			return nil, errors.Wrapf(err, "asset compilation of %s", jobSpec.Name)
+		}
+		destination, err := jobSpec.Task.Unit.GenerateTaskDestination(context.TODO(), models.GenerateTaskDestinationRequest{
+			Config:  models.TaskPluginConfigs{}.FromJobSpec(jobSpec.Task.Config),
+			Assets:  models.TaskPluginAssets{}.FromJobSpec(assets),
+			Project: projectSpec,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate destination for %s", jobSpec.Name)
+		}
+		for _, urn := range destination.URNs() {
+			produced[urn] = true
+		}
+	}
+
+	unknown := map[string][]string{}
+	for _, jobSpec := range jobSpecs {
+		explanations, err := srv.ExplainDependency(projectSpec, jobSpec)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve dependencies of %s", jobSpec.Name)
+		}
+		for _, explanation := range explanations {
+			if !explanation.Resolved && !produced[explanation.Destination] {
+				unknown[jobSpec.Name] = append(unknown[jobSpec.Name], explanation.Destination)
+			}
+		}
+	}
+	return unknown, nil
+}
+
+// ResolveDependencies returns the job with its dependencies resolved to the jobs
+// producing its sources, assets of the returned job are left uncompiled
+func (srv *Service) ResolveDependencies(projectSpec models.ProjectSpec, jobSpec models.JobSpec) (models.JobSpec, error) {
+	compiledSpec := jobSpec
+	var err error
+	if compiledSpec.Assets, err = srv.assetCompiler(projectSpec, jobSpec, srv.Now()); err != nil {
+		return models.JobSpec{}, errors.Wrap(err, "asset compilation")
+	}
+	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(projectSpec)
+	resolvedSpec, err := srv.dependencyResolver.Resolve(projectSpec, projectJobSpecRepo, compiledSpec, nil)
+	if err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "failed to resolve dependency for %s", jobSpec.Name)
+	}
+	jobSpec.Dependencies = resolvedSpec.Dependencies
+	return jobSpec, nil
+}
+
+// GetDownstream resolves dependencies of all jobs in a project and returns the
+// ones depending on the provided job, sorted by name
+func (srv *Service) GetDownstream(projectSpec models.ProjectSpec, jobName string, transitive bool) ([]models.JobSpec, error) {
+	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(projectSpec)
+	depsResolvedJobSpecs, err := srv.GetDependencyResolvedSpecs(projectSpec, projectJobSpecRepo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// build reverse edges, dependency name -> jobs depending on it
+	dependents := map[string][]models.JobSpec{}
+	for _, resolvedJobSpec := range depsResolvedJobSpecs {
+		for depJobSpecName, dep := range resolvedJobSpec.Dependencies {
+			if dep.Project != nil && dep.Project.Name != projectSpec.Name {
+				continue
+			}
+			dependents[depJobSpecName] = append(dependents[depJobSpecName], resolvedJobSpec)
+		}
+	}
+
+	visited := map[string]bool{jobName: true}
+	downstream := []models.JobSpec{}
+	queue := []string{jobName}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[current] {
+			if visited[dependent.Name] {
+				continue
+			}
+			visited[dependent.Name] = true
+			downstream = append(downstream, dependent)
+			if transitive {
+				queue = append(queue, dependent.Name)
+			}
+		}
+	}
+
+	sort.Slice(downstream, func(i, j int) bool {
+		return downstream[i].Name < downstream[j].Name
+	})
+	return downstream, nil
+}
+
+func (srv *Service) GetDependencyGraph(projectSpec models.ProjectSpec, jobName string) (models.JobDependencyGraph, error) {
+	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(projectSpec)
+	jobSpecs, err := projectJobSpecRepo.GetAll()
+	if err != nil {
+		return models.JobDependencyGraph{}, errors.Wrap(err, "failed to retrieve jobs")
+	}
+	// dependencies defined in specs, rest of them are inferred while resolving
+	staticDependencies := map[string]map[string]bool{}
+	for _, jobSpec := range jobSpecs {
+		staticDependencies[jobSpec.Name] = map[string]bool{}
+		for depName := range jobSpec.Dependencies {
+			staticDependencies[jobSpec.Name][depName] = true
+		}
+	}
+	if jobName != "" {
+		if _, ok := staticDependencies[jobName]; !ok {
+			return models.JobDependencyGraph{}, errors.Wrapf(store.ErrResourceNotFound, "job %s", jobName)
+		}
+	}
+
+	depsResolvedJobSpecs, err := srv.GetDependencyResolvedSpecs(projectSpec, projectJobSpecRepo, nil)
+	if err != nil {
+		return models.JobDependencyGraph{}, err
+	}
+
+	var edges []models.JobDependencyGraphEdge
+	for _, resolvedJobSpec := range depsResolvedJobSpecs {
+		for depName, dep := range resolvedJobSpec.Dependencies {
+			if dep.Job == nil {
+				continue
+			}
+			upstream := models.JobDependencyGraphNode{Name: dep.Job.Name, Project: projectSpec.Name}
+			if dep.Project != nil {
+				upstream.Project = dep.Project.Name
+			}
+			edges = append(edges, models.JobDependencyGraphEdge{
+				Upstream:   upstream,
+				Downstream: models.JobDependencyGraphNode{Name: resolvedJobSpec.Name, Project: projectSpec.Name},
+				Type:       dep.Type,
+				Inferred:   !staticDependencies[resolvedJobSpec.Name][depName],
+			})
+		}
+	}
+	if jobName != "" {
+		edges = lineageEdges(edges, models.JobDependencyGraphNode{Name: jobName, Project: projectSpec.Name})
+	}
+
+	graph := models.JobDependencyGraph{Edges: edges}
+	seen := map[models.JobDependencyGraphNode]bool{}
+	addNode := func(node models.JobDependencyGraphNode) {
+		if !seen[node] {
+			seen[node] = true
+			graph.Jobs = append(graph.Jobs, node)
+		}
+	}
+	if jobName != "" {
+		addNode(models.JobDependencyGraphNode{Name: jobName, Project: projectSpec.Name})
+	} else {
+		for _, jobSpec := range depsResolvedJobSpecs {
+			addNode(models.JobDependencyGraphNode{Name: jobSpec.Name, Project: projectSpec.Name})
+		}
+	}
+	for _, edge := range edges {
+		addNode(edge.Upstream)
+		addNode(edge.Downstream)
+	}
+
+	sort.Slice(graph.Jobs, func(i, j int) bool {
+		if graph.Jobs[i].Project != graph.Jobs[j].Project {
+			return graph.Jobs[i].Project < graph.Jobs[j].Project
+		}
+		return graph.Jobs[i].Name < graph.Jobs[j].Name
+	})
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].Downstream != graph.Edges[j].Downstream {
+			return graph.Edges[i].Downstream.Name < graph.Edges[j].Downstream.Name
+		}
+		if graph.Edges[i].Upstream.Project != graph.Edges[j].Upstream.Project {
+			return graph.Edges[i].Upstream.Project < graph.Edges[j].Upstream.Project
+		}
+		return graph.Edges[i].Upstream.Name < graph.Edges[j].Upstream.Name
+	})
+	return graph, nil
+}
+
+// lineageEdges keeps the edges reachable from node walking towards its
+// upstream and towards its downstream, edges between siblings are dropped
+func lineageEdges(edges []models.JobDependencyGraphEdge, node models.JobDependencyGraphNode) []models.JobDependencyGraphEdge {
+	byDownstream := map[models.JobDependencyGraphNode][]int{}
+	byUpstream := map[models.JobDependencyGraphNode][]int{}
+	for idx, edge := range edges {
+		byDownstream[edge.Downstream] = append(byDownstream[edge.Downstream], idx)
+		byUpstream[edge.Upstream] = append(byUpstream[edge.Upstream], idx)
+	}
+
+	kept := map[int]bool{}
+	walk := func(adjacent map[models.JobDependencyGraphNode][]int, next func(models.JobDependencyGraphEdge) models.JobDependencyGraphNode) {
+		visited := map[models.JobDependencyGraphNode]bool{node: true}
+		queue := []models.JobDependencyGraphNode{node}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, idx := range adjacent[current] {
+				kept[idx] = true
+				if nextNode := next(edges[idx]); !visited[nextNode] {
+					visited[nextNode] = true
+					queue = append(queue, nextNode)
+				}
+			}
+		}
+	}
+	walk(byDownstream, func(edge models.JobDependencyGraphEdge) models.JobDependencyGraphNode { return edge.Upstream })
+	walk(byUpstream, func(edge models.JobDependencyGraphEdge) models.JobDependencyGraphNode { return edge.Downstream })
+
+	var lineage []models.JobDependencyGraphEdge
+	for idx, edge := range edges {
+		if kept[idx] {
+			lineage = append(lineage, edge)
+		}
+	}
+	return lineage
+}
+
+// getDependents returns sorted names of the jobs of project depending on each
+// of the provided jobs, jobs of the provided set depending on each other are
+// not considered as they go away together
+func (srv *Service) getDependents(projectSpec models.ProjectSpec, jobNames []string) (map[string][]string, error) {
+	projectJobSpecRepo := srv.projectJobSpecRepoFactory.New(projectSpec)
+	depsResolvedJobSpecs, err := srv.GetDependencyResolvedSpecs(projectSpec, projectJobSpecRepo, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := map[string]bool{}
+	for _, jobName := range jobNames {
+		requested[jobName] = true
+	}
+	dependents := map[string][]string{}
+	for _, resolvedJobSpec := range depsResolvedJobSpecs {
+		if requested[resolvedJobSpec.Name] {
+			continue
+		}
+		for depJobSpecName, dep := range resolvedJobSpec.Dependencies {
+			if dep.Project != nil && dep.Project.Name != projectSpec.Name {
+				continue
+			}
+			if requested[depJobSpecName] {
+				dependents[depJobSpecName] = append(dependents[depJobSpecName], resolvedJobSpec.Name)
+			}
+		}
+	}
+	for _, names := range dependents {
+		sort.Strings(names)
+	}
+	return dependents, nil
+}
+
 func (srv *Service) notifyProgress(po progress.Observer, event progress.Event) {
 	if po == nil {
 		return
@@ -494,6 +1284,24 @@ func NewService(jobSpecRepoFactory SpecRepoFactory, jobRepoFact JobRepoFactory,
 	}
 }
 
+// ErrorCategory tells at which stage a deploy failed, letting
+// clients decide if a retry could help
+type ErrorCategory string
+
+const (
+	// ErrorCategoryValidation is a job spec rejected before deploying it
+	ErrorCategoryValidation ErrorCategory = "validation"
+	// ErrorCategoryDependency is a failure while resolving dependencies
+	// or priorities between jobs
+	ErrorCategoryDependency ErrorCategory = "dependency"
+	// ErrorCategoryCompile is a job that failed to compile
+	ErrorCategoryCompile ErrorCategory = "compile"
+	// ErrorCategoryUpload is a compiled job that failed to be stored
+	ErrorCategoryUpload ErrorCategory = "upload"
+	// ErrorCategoryCancelled is a deploy stopped by its client
+	ErrorCategoryCancelled ErrorCategory = "cancelled"
+)
+
 type (
 	// EventJobSpecFetch represents a specification being
 	// read from the storage
@@ -510,6 +1318,20 @@ type (
 		Dependency string
 	}
 
+	// EventJobSpecEndDatePassed represents a job spec whose
+	// end date is already in the past
+	EventJobSpecEndDatePassed struct {
+		Name    string
+		EndDate time.Time
+	}
+
+	// EventJobSpecDuplicateDestination represents a destination
+	// written by more than one job
+	EventJobSpecDuplicateDestination struct {
+		Destination string
+		Jobs        []string
+	}
+
 	// EventJobSpecCompile represents a specification
 	// being compiled to a Job
 	EventJobSpecCompile struct{ Name string }
@@ -517,8 +1339,12 @@ type (
 	// EventJobUpload represents the compiled Job
 	// being uploaded
 	EventJobUpload struct {
-		Job models.JobSpec
-		Err error
+		Job      models.JobSpec
+		Err      error
+		Category ErrorCategory
+		// Unchanged is set when the compiled job was not uploaded as it
+		// is the same as the one uploaded last
+		Unchanged bool
 	}
 
 	// EventJobRemoteDelete signifies that a
@@ -529,10 +1355,28 @@ type (
 	// job from a repository is being deleted
 	EventSavedJobDelete struct{ Name string }
 
+	// EventSavedJobDeleteBlocked signifies that a raw job was
+	// not deleted as other jobs still depend on it
+	EventSavedJobDeleteBlocked struct {
+		Name       string
+		Dependents []string
+	}
+
 	// EventJobPriorityWeightAssign signifies that a
 	// job is being assigned a priority weight
 	EventJobPriorityWeightAssign struct{}
 
+	// EventJobSyncCancelled signifies that sync was stopped
+	// before completion as the deploy got cancelled
+	EventJobSyncCancelled struct{ Err error }
+
+	// EventJobSyncFailed signifies that sync was stopped
+	// before completion because one of its stages failed
+	EventJobSyncFailed struct {
+		Err      error
+		Category ErrorCategory
+	}
+
 	// job check events
 	EventJobCheckFailed struct {
 		Name   string
@@ -553,7 +1397,10 @@ func (e *EventJobSpecCompile) String() string {
 
 func (e *EventJobUpload) String() string {
 	if e.Err != nil {
-		return fmt.Sprintf("uploading: %s, failed with error): %s", e.Job.Name, e.Err.Error())
+		return fmt.Sprintf("uploading: %s, failed with error: %s", e.Job.Name, e.Err.Error())
+	}
+	if e.Unchanged {
+		return fmt.Sprintf("unchanged: %s", e.Job.Name)
 	}
 	return fmt.Sprintf("uploaded: %s", e.Job.Name)
 }
@@ -566,10 +1413,22 @@ func (e *EventSavedJobDelete) String() string {
 	return fmt.Sprintf("deleting: %s", e.Name)
 }
 
+func (e *EventSavedJobDeleteBlocked) String() string {
+	return fmt.Sprintf("skipped deleting: %s, still a dependency of %s", e.Name, strings.Join(e.Dependents, ", "))
+}
+
 func (e *EventJobPriorityWeightAssign) String() string {
 	return fmt.Sprintf("assigned priority weights")
 }
 
+func (e *EventJobSyncCancelled) String() string {
+	return fmt.Sprintf("deploy cancelled: %s", e.Err.Error())
+}
+
+func (e *EventJobSyncFailed) String() string {
+	return fmt.Sprintf("deploy failed during %s: %s", e.Category, e.Err.Error())
+}
+
 func (e *EventJobSpecDependencyResolve) String() string {
 	return fmt.Sprintf("dependencies resolved")
 }
@@ -578,6 +1437,14 @@ func (e *EventJobSpecUnknownDependencyUsed) String() string {
 	return fmt.Sprintf("could not find registered destination '%s' during compiling dependencies for the provided job %s", e.Dependency, e.Job)
 }
 
+func (e *EventJobSpecEndDatePassed) String() string {
+	return fmt.Sprintf("end date %s of job %s is in the past, it will not be scheduled anymore", e.EndDate.Format(models.JobDatetimeLayout), e.Name)
+}
+
+func (e *EventJobSpecDuplicateDestination) String() string {
+	return fmt.Sprintf("destination %s is written by more than one job: %s", e.Destination, strings.Join(e.Jobs, ", "))
+}
+
 func (e *EventJobCheckFailed) String() string {
 	return fmt.Sprintf("check for job failed: %s, reason: %s", e.Name, e.Reason)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/odpf/optimus/job"
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/stretchr/testify/assert"
 	testMock "github.com/stretchr/testify/mock"
 )
@@ -18,7 +19,7 @@ import (
 func TestService(t *testing.T) {
 	ctx := context.Background()
 
-	dumpAssets := func(jobSpec models.JobSpec, _ time.Time) (models.JobAssets, error) {
+	dumpAssets := func(_ models.ProjectSpec, jobSpec models.JobSpec, _ time.Time) (models.JobAssets, error) {
 		return jobSpec.Assets, nil
 	}
 
@@ -89,24 +90,677 @@ func TestService(t *testing.T) {
 			err := svc.Create(namespaceSpec, jobSpec)
 			assert.NotNil(t, err)
 		})
+		t.Run("should reject job spec violating name or owner rules", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-team-1",
+				ProjectSpec: models.ProjectSpec{
+					Name: "proj",
+				},
+			}
+			cases := []struct {
+				name          string
+				jobName       string
+				owner         string
+				expectedError string
+			}{
+				{
+					name:          "uppercase and spaces in name",
+					jobName:       "My Job",
+					owner:         "optimus",
+					expectedError: "job name My Job should only contain lowercase letters, digits, _ and -: invalid job spec",
+				},
+				{
+					name:          "name too short",
+					jobName:       "ab",
+					owner:         "optimus",
+					expectedError: "job name ab should be between 3 and 220 characters: invalid job spec",
+				},
+				{
+					name:          "empty owner",
+					jobName:       "test",
+					owner:         "  ",
+					expectedError: "job test should have an owner: invalid job spec",
+				},
+			}
+			for _, tt := range cases {
+				t.Run(tt.name, func(t *testing.T) {
+					repoFac := new(mock.JobSpecRepoFactory)
+					defer repoFac.AssertExpectations(t)
+
+					svc := job.NewService(repoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+					err := svc.Create(namespaceSpec, models.JobSpec{
+						Version: 1,
+						Name:    tt.jobName,
+						Owner:   tt.owner,
+					})
+					assert.True(t, errors.Is(err, job.ErrInvalidJobSpec))
+					assert.Equal(t, tt.expectedError, err.Error())
+				})
+			}
+		})
+		t.Run("should fail if job refers a project config which is not set", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-team-1",
+				Config: map[string]string{
+					"BUCKET": "gs://some_folder",
+				},
+				ProjectSpec: models.ProjectSpec{
+					Name: "proj",
+					Config: map[string]string{
+						"STAGING_DATASET": "proj.staging",
+					},
+				},
+			}
+			jobSpec := models.JobSpec{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+				Task: models.JobSpecTask{
+					Config: models.JobSpecConfigs{
+						{Name: "DATASET", Value: "{{.proj.STAGING_DATASET}}"},
+						{Name: "BUCKET", Value: "{{ .proj.BUCKET }}"},
+						{Name: "TABLE", Value: "{{.proj.STAGING_TABLE}}"},
+					},
+				},
+			}
+
+			repoFac := new(mock.JobSpecRepoFactory)
+			defer repoFac.AssertExpectations(t)
+
+			svc := job.NewService(repoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			err := svc.Create(namespaceSpec, jobSpec)
+			assert.True(t, errors.Is(err, job.ErrMissingProjectConfig))
+			assert.Equal(t, "job test refers STAGING_TABLE: missing project config", err.Error())
+		})
+		t.Run("should fall back to global configs and fail if a referred global config is not set", func(t *testing.T) {
+			namespaceSpec := models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-team-1",
+				ProjectSpec: models.ProjectSpec{
+					Name: "proj",
+				},
+			}
+			jobSpec := models.JobSpec{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+				Task: models.JobSpecTask{
+					Config: models.JobSpecConfigs{
+						{Name: "DATASET", Value: "{{.proj.STAGING_DATASET}}"},
+						{Name: "BROKERS", Value: "{{.global.KAFKA_BROKERS}}"},
+						{Name: "TOPIC", Value: "{{.global.KAFKA_TOPIC}}"},
+					},
+				},
+			}
+
+			repoFac := new(mock.JobSpecRepoFactory)
+			defer repoFac.AssertExpectations(t)
+
+			svc := job.NewService(repoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			svc.GlobalConfig = map[string]string{
+				"STAGING_DATASET": "global.staging",
+				"KAFKA_BROKERS":   "localhost:9092",
+			}
+			err := svc.Create(namespaceSpec, jobSpec)
+			assert.True(t, errors.Is(err, job.ErrMissingGlobalConfig))
+			assert.Equal(t, "job test refers global.KAFKA_TOPIC: missing global config", err.Error())
+		})
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		namespaceSpec := models.NamespaceSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "dev-team-1",
+			ProjectSpec: models.ProjectSpec{
+				Name: "proj",
+				Config: map[string]string{
+					"STAGING_DATASET": "proj.staging",
+				},
+			},
+		}
+		t.Run("should accept a valid job without saving it", func(t *testing.T) {
+			jobSpec := models.JobSpec{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+				Task: models.JobSpecTask{
+					Config: models.JobSpecConfigs{
+						{Name: "DATASET", Value: "{{.proj.STAGING_DATASET}}"},
+					},
+				},
+			}
+
+			repoFac := new(mock.JobSpecRepoFactory)
+			defer repoFac.AssertExpectations(t)
+
+			svc := job.NewService(repoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			assert.Nil(t, svc.Validate(namespaceSpec, jobSpec))
+		})
+		t.Run("should reject a job the same way create does", func(t *testing.T) {
+			jobSpec := models.JobSpec{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+				Task: models.JobSpecTask{
+					Config: models.JobSpecConfigs{
+						{Name: "TABLE", Value: "{{.proj.STAGING_TABLE}}"},
+					},
+				},
+			}
+
+			repoFac := new(mock.JobSpecRepoFactory)
+			defer repoFac.AssertExpectations(t)
+
+			svc := job.NewService(repoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			err := svc.Validate(namespaceSpec, jobSpec)
+			assert.True(t, errors.Is(err, job.ErrMissingProjectConfig))
+			assert.Equal(t, svc.Create(namespaceSpec, jobSpec).Error(), err.Error())
+		})
 	})
+	t.Run("Sync", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: projSpec,
+		}
+
+		t.Run("should successfully store job specs for the requested project", func(t *testing.T) {
+			jobSpecsBase := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{},
+				},
+			}
+			jobSpecsAfterDepenResolve := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{},
+				},
+			}
+			jobSpecsAfterPriorityResolve := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{
+						Priority: 10000,
+					},
+				},
+			}
+
+			jobs := []models.Job{
+				{
+					Name:        "test",
+					Contents:    []byte(`come string`),
+					NamespaceID: namespaceSpec.Name,
+				},
+			}
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			// used to store compiled job specs
+			jobRepo := new(mock.JobRepository)
+			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mock.JobRepoFactory)
+			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			// resolve dependencies
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
+			defer depenResolver.AssertExpectations(t)
+
+			// resolve priority
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			defer compiler.AssertExpectations(t)
+
+			// compile to dag and save
+			for idx, compiledJob := range jobs {
+				compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[idx]).Return(compiledJob, nil)
+				jobRepo.On("Save", ctx, compiledJob).Return(nil)
+			}
+
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			err := svc.Sync(ctx, namespaceSpec, nil)
+			assert.Nil(t, err)
+		})
+		t.Run("should keep a paused job paused after deploying it", func(t *testing.T) {
+			jobSpecsBase := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "paused-job",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{},
+				},
+				{
+					Version: 1,
+					Name:    "running-job",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{},
+				},
+			}
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			jobRepo := new(mock.JobRepository)
+			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"paused-job", "running-job"}, nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mock.JobRepoFactory)
+			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			for _, jobSpec := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, nil).Return(jobSpec, nil)
+			}
+			defer depenResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", testMock.Anything).Return(jobSpecsBase, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			defer compiler.AssertExpectations(t)
+			for _, jobSpec := range jobSpecsBase {
+				compiledJob := models.Job{Name: jobSpec.Name, NamespaceID: namespaceSpec.Name}
+				compiler.On("Compile", namespaceSpec, jobSpec).Return(compiledJob, nil)
+				jobRepo.On("Save", ctx, compiledJob).Return(nil)
+			}
+
+			scheduler := new(mock.Scheduler)
+			scheduler.On("IsJobPaused", ctx, projSpec, "paused-job").Return(true, nil)
+			scheduler.On("IsJobPaused", ctx, projSpec, "running-job").Return(false, nil)
+			scheduler.On("SetJobPaused", ctx, projSpec, "paused-job", true).Return(nil).Once()
+			defer scheduler.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			svc.ProjectScheduler = func(proj models.ProjectSpec) (models.SchedulerUnit, error) {
+				return scheduler, nil
+			}
+			err := svc.Sync(ctx, namespaceSpec, nil)
+			assert.Nil(t, err)
+			scheduler.AssertNotCalled(t, "SetJobPaused", ctx, projSpec, "running-job", testMock.Anything)
+		})
+		t.Run("should skip recompilation of unchanged job specs on subsequent sync", func(t *testing.T) {
+			jobSpecsBase := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{},
+				},
+			}
+			jobSpecsAfterPriorityResolve := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{
+						Priority: 10000,
+					},
+				},
+			}
+			compiledJob := models.Job{
+				Name:        "test",
+				Contents:    []byte(`come string`),
+				NamespaceID: namespaceSpec.Name,
+			}
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			jobRepo := new(mock.JobRepository)
+			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			jobRepo.On("Save", ctx, compiledJob).Return(nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mock.JobRepoFactory)
+			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsBase[0], nil)
+			defer depenResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", jobSpecsBase).Return(jobSpecsAfterPriorityResolve, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[0]).Return(compiledJob, nil).Once()
+			defer compiler.AssertExpectations(t)
+
+			cachedCompiler := job.NewCachedCompiler(compiler, 10, "")
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, cachedCompiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			err := svc.Sync(ctx, namespaceSpec, nil)
+			assert.Nil(t, err)
+			err = svc.Sync(ctx, namespaceSpec, nil)
+			assert.Nil(t, err)
+
+			compiler.AssertNumberOfCalls(t, "Compile", 1)
+			jobRepo.AssertNumberOfCalls(t, "Save", 2)
+		})
+
+		t.Run("should not upload compiled jobs unchanged since last sync", func(t *testing.T) {
+			jobSpecsBase := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+				},
+			}
+			compiledJob := models.Job{
+				Name:        "test",
+				Contents:    []byte(`come string`),
+				NamespaceID: namespaceSpec.Name,
+			}
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			jobRepo := new(mock.JobRepository)
+			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test", "stale"}, nil)
+			jobRepo.On("Save", ctx, compiledJob).Return(nil).Once()
+			jobRepo.On("Delete", ctx, namespaceSpec, "stale").Return(nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mock.JobRepoFactory)
+			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], testMock.Anything).Return(jobSpecsBase[0], nil)
+			defer depenResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", jobSpecsBase).Return(jobSpecsBase, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, jobSpecsBase[0]).Return(compiledJob, nil)
+			defer compiler.AssertExpectations(t)
+
+			// checksum saved by the first sync is read back by the second one
+			uploadedChecksums := map[string]string{}
+			checksumRepo := new(mock.JobChecksumRepository)
+			checksumRepo.On("GetAll", ctx).Return(uploadedChecksums, nil)
+			checksumRepo.On("Save", ctx, "test", testMock.AnythingOfType("string")).Return(nil).Once().
+				Run(func(args testMock.Arguments) {
+					uploadedChecksums["test"] = args.String(2)
+				})
+			checksumRepo.On("Delete", ctx, "stale").Return(nil)
+			defer checksumRepo.AssertExpectations(t)
+
+			checksumRepoFac := new(mock.JobChecksumRepoFactory)
+			checksumRepoFac.On("New", namespaceSpec).Return(checksumRepo)
+			defer checksumRepoFac.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			svc.ChecksumRepoFactory = checksumRepoFac
+
+			firstSummary := &job.DeploySummaryObserver{}
+			assert.Nil(t, svc.Sync(ctx, namespaceSpec, firstSummary))
+			assert.Equal(t, 1, firstSummary.Summary().Succeeded)
+
+			secondSummary := &job.DeploySummaryObserver{}
+			assert.Nil(t, svc.Sync(ctx, namespaceSpec, secondSummary))
+			assert.Equal(t, 0, secondSummary.Summary().Succeeded)
+			assert.Equal(t, 1, secondSummary.Summary().Unchanged)
+		})
+
+		t.Run("should notify a warning for job specs with end date in the past", func(t *testing.T) {
+			endDate := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			jobSpecsBase := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						EndDate:   &endDate,
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{},
+				},
+			}
+
+			jobs := []models.Job{
+				{
+					Name:        "test",
+					Contents:    []byte(`come string`),
+					NamespaceID: namespaceSpec.Name,
+				},
+			}
+
+			observer := new(mock.PipelineLogObserver)
+			observer.On("Notify", &job.EventJobSpecEndDatePassed{Name: "test", EndDate: endDate}).Return()
+			observer.On("Notify", testMock.Anything).Return()
+			defer observer.AssertExpectations(t)
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			jobRepo := new(mock.JobRepository)
+			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mock.JobRepoFactory)
+			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], observer).Return(jobSpecsBase[0], nil)
+			defer depenResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", jobSpecsBase).Return(jobSpecsBase, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, jobSpecsBase[0]).Return(jobs[0], nil)
+			defer compiler.AssertExpectations(t)
+			jobRepo.On("Save", ctx, jobs[0]).Return(nil)
+
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			svc.Now = func() time.Time {
+				return time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+			}
+			err := svc.Sync(ctx, namespaceSpec, observer)
+			assert.Nil(t, err)
+		})
+
+		t.Run("should fail deploy when two jobs write the same destination", func(t *testing.T) {
+			execUnit := new(mock.TaskPlugin)
+			execUnit.On("GenerateTaskDestination", ctx, testMock.Anything).Return(models.GenerateTaskDestinationResponse{
+				Destination: "proj.dataset.table",
+				Type:        models.DestinationTypeBigquery,
+			}, nil)
+			defer execUnit.AssertExpectations(t)
+
+			jobSpecsBase := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test-2",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{Unit: execUnit},
+				},
+				{
+					Version: 1,
+					Name:    "test-1",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{Unit: execUnit},
+				},
+			}
+
+			observer := new(mock.PipelineLogObserver)
+			observer.On("Notify", &job.EventJobSpecDuplicateDestination{
+				Destination: "bigquery://proj.dataset.table",
+				Jobs:        []string{"test-1", "test-2"},
+			}).Return()
+			observer.On("Notify", testMock.Anything).Return()
+			defer observer.AssertExpectations(t)
+
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			for _, jobSpec := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, observer).Return(jobSpec, nil)
+			}
+			defer depenResolver.AssertExpectations(t)
 
-	t.Run("Sync", func(t *testing.T) {
-		projSpec := models.ProjectSpec{
-			Name: "proj",
-		}
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", testMock.Anything).Return(jobSpecsBase, nil)
+			defer priorityResolver.AssertExpectations(t)
 
-		namespaceSpec := models.NamespaceSpec{
-			ID:          uuid.Must(uuid.NewRandom()),
-			Name:        "dev-team-1",
-			ProjectSpec: projSpec,
-		}
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			err := svc.Sync(ctx, namespaceSpec, observer)
+			assert.True(t, errors.Is(err, job.ErrDuplicateDestination))
+			assert.Contains(t, err.Error(), "bigquery://proj.dataset.table by test-1, test-2")
+		})
 
-		t.Run("should successfully store job specs for the requested project", func(t *testing.T) {
+		t.Run("should notify failed uploads with the stage they failed in", func(t *testing.T) {
 			jobSpecsBase := []models.JobSpec{
 				{
 					Version: 1,
-					Name:    "test",
+					Name:    "test-1",
 					Owner:   "optimus",
 					Schedule: models.JobSpecSchedule{
 						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
@@ -114,11 +768,9 @@ func TestService(t *testing.T) {
 					},
 					Task: models.JobSpecTask{},
 				},
-			}
-			jobSpecsAfterDepenResolve := []models.JobSpec{
 				{
 					Version: 1,
-					Name:    "test",
+					Name:    "test-2",
 					Owner:   "optimus",
 					Schedule: models.JobSpecSchedule{
 						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
@@ -127,28 +779,19 @@ func TestService(t *testing.T) {
 					Task: models.JobSpecTask{},
 				},
 			}
-			jobSpecsAfterPriorityResolve := []models.JobSpec{
-				{
-					Version: 1,
-					Name:    "test",
-					Owner:   "optimus",
-					Schedule: models.JobSpecSchedule{
-						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
-						Interval:  "@daily",
-					},
-					Task: models.JobSpecTask{
-						Priority: 10000,
-					},
-				},
+			compiledJob := models.Job{
+				Name:        "test-2",
+				Contents:    []byte(`come string`),
+				NamespaceID: namespaceSpec.Name,
 			}
+			compileErr := errors.New("template: unexpected EOF")
+			saveErr := errors.New("bucket not found")
 
-			jobs := []models.Job{
-				{
-					Name:        "test",
-					Contents:    []byte(`come string`),
-					NamespaceID: namespaceSpec.Name,
-				},
-			}
+			observer := new(mock.PipelineLogObserver)
+			observer.On("Notify", &job.EventJobUpload{Job: jobSpecsBase[0], Err: compileErr, Category: job.ErrorCategoryCompile}).Return()
+			observer.On("Notify", &job.EventJobUpload{Job: jobSpecsBase[1], Err: saveErr, Category: job.ErrorCategoryUpload}).Return()
+			observer.On("Notify", testMock.Anything).Return()
+			defer observer.AssertExpectations(t)
 
 			jobSpecRepo := new(mock.JobSpecRepository)
 			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
@@ -166,39 +809,75 @@ func TestService(t *testing.T) {
 			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// used to store compiled job specs
 			jobRepo := new(mock.JobRepository)
-			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			jobRepo.On("Save", ctx, compiledJob).Return(saveErr)
+			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{}, nil)
 			defer jobRepo.AssertExpectations(t)
 
 			jobRepoFac := new(mock.JobRepoFactory)
 			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
 			defer jobRepoFac.AssertExpectations(t)
 
-			// resolve dependencies
 			depenResolver := new(mock.DependencyResolver)
-			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], observer).Return(jobSpecsBase[0], nil)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[1], observer).Return(jobSpecsBase[1], nil)
 			defer depenResolver.AssertExpectations(t)
 
-			// resolve priority
 			priorityResolver := new(mock.PriorityResolver)
-			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
+			priorityResolver.On("Resolve", testMock.Anything).Return(jobSpecsBase, nil)
 			defer priorityResolver.AssertExpectations(t)
 
 			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, jobSpecsBase[0]).Return(models.Job{}, compileErr)
+			compiler.On("Compile", namespaceSpec, jobSpecsBase[1]).Return(compiledJob, nil)
 			defer compiler.AssertExpectations(t)
 
-			// compile to dag and save
-			for idx, compiledJob := range jobs {
-				compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[idx]).Return(compiledJob, nil)
-				jobRepo.On("Save", ctx, compiledJob).Return(nil)
-			}
-
 			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
-			err := svc.Sync(ctx, namespaceSpec, nil)
+			err := svc.Sync(ctx, namespaceSpec, observer)
 			assert.Nil(t, err)
 		})
 
+		t.Run("should notify a dependency failure if priorities can not be resolved", func(t *testing.T) {
+			jobSpecsBase := []models.JobSpec{
+				{
+					Version: 1,
+					Name:    "test",
+					Owner:   "optimus",
+					Schedule: models.JobSpecSchedule{
+						StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+						Interval:  "@daily",
+					},
+					Task: models.JobSpecTask{},
+				},
+			}
+			cycleErr := errors.New("a cycle dependency encountered in the tree")
+
+			observer := new(mock.PipelineLogObserver)
+			observer.On("Notify", &job.EventJobSyncFailed{Err: cycleErr, Category: job.ErrorCategoryDependency}).Return()
+			observer.On("Notify", testMock.Anything).Return()
+			defer observer.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], observer).Return(jobSpecsBase[0], nil)
+			defer depenResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", jobSpecsBase).Return([]models.JobSpec{}, cycleErr)
+			defer priorityResolver.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			err := svc.Sync(ctx, namespaceSpec, observer)
+			assert.Equal(t, cycleErr, err)
+		})
+
 		t.Run("should delete job specs from target store if there are existing specs that are no longer present in job specs", func(t *testing.T) {
 			jobSpecsBase := []models.JobSpec{
 				{
@@ -430,45 +1109,413 @@ func TestService(t *testing.T) {
 			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
-			// used to store compiled job specs
+			// used to store compiled job specs
+			jobRepo := new(mock.JobRepository)
+			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mock.JobRepoFactory)
+			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			// resolve dependencies
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
+			defer depenResolver.AssertExpectations(t)
+
+			// resolve priority
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			defer compiler.AssertExpectations(t)
+
+			metaSvc := new(mock.MetaService)
+			metaSvc.On("Publish", namespaceSpec, jobSpecsAfterPriorityResolve, nil).Return(nil)
+			defer metaSvc.AssertExpectations(t)
+
+			metaSvcFact := new(mock.MetaSvcFactory)
+			metaSvcFact.On("New").Return(metaSvc)
+			defer metaSvcFact.AssertExpectations(t)
+
+			// compile to dag and save
+			for idx, compiledJob := range jobs {
+				compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[idx]).Return(compiledJob, nil)
+				jobRepo.On("Save", ctx, compiledJob).Return(nil)
+			}
+
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, metaSvcFact, projJobSpecRepoFac, nil)
+			err := svc.Sync(ctx, namespaceSpec, nil)
+			assert.Nil(t, err)
+		})
+		t.Run("should stop and notify if deploy gets cancelled", func(t *testing.T) {
+			cancelledCtx, cancel := context.WithCancel(ctx)
+			cancel()
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return([]models.JobSpec{}, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			// nothing should be compiled or uploaded
+			compiler := new(mock.Compiler)
+			defer compiler.AssertExpectations(t)
+			jobRepoFac := new(mock.JobRepoFactory)
+			defer jobRepoFac.AssertExpectations(t)
+
+			obs := new(mock.PipelineLogObserver)
+			obs.On("Notify", &job.EventJobSyncCancelled{Err: context.Canceled}).Return().Once()
+			obs.On("Notify", testMock.Anything).Return()
+			defer obs.AssertExpectations(t)
+
+			svc := job.NewService(nil, jobRepoFac, compiler, dumpAssets, nil, nil, nil, projJobSpecRepoFac, nil)
+			err := svc.Sync(cancelledCtx, namespaceSpec, obs)
+			assert.Equal(t, context.Canceled, err)
+		})
+	})
+
+	t.Run("SyncJob", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: projSpec,
+		}
+
+		jobSpecsBase := []models.JobSpec{
+			{
+				Version: 1,
+				Name:    "test",
+				Owner:   "optimus",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  "@daily",
+				},
+				Task: models.JobSpecTask{},
+			},
+			{
+				Version: 1,
+				Name:    "other-test",
+				Owner:   "optimus",
+				Schedule: models.JobSpecSchedule{
+					StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+					Interval:  "@daily",
+				},
+				Task: models.JobSpecTask{},
+			},
+		}
+
+		t.Run("should upload only the requested job without deleting others", func(t *testing.T) {
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			compiledJob := models.Job{Name: "test", NamespaceID: namespaceSpec.Name}
+			jobRepo := new(mock.JobRepository)
+			jobRepo.On("Save", ctx, compiledJob).Return(nil)
+			defer jobRepo.AssertExpectations(t)
+
+			jobRepoFac := new(mock.JobRepoFactory)
+			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
+			defer jobRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			for _, jobSpec := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, nil).Return(jobSpec, nil)
+			}
+			defer depenResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", testMock.Anything).Return(jobSpecsBase, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, jobSpecsBase[0]).Return(compiledJob, nil)
+			defer compiler.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			err := svc.SyncJob(ctx, namespaceSpec, "test", nil)
+			assert.Nil(t, err)
+			compiler.AssertNotCalled(t, "Compile", namespaceSpec, jobSpecsBase[1])
+			jobRepo.AssertNotCalled(t, "ListNames", testMock.Anything, testMock.Anything)
+			jobRepo.AssertNotCalled(t, "Delete", testMock.Anything, testMock.Anything, testMock.Anything)
+		})
+		t.Run("should fail if the job is not present in namespace", func(t *testing.T) {
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			for _, jobSpec := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, nil).Return(jobSpec, nil)
+			}
+			defer depenResolver.AssertExpectations(t)
+
+			priorityResolver := new(mock.PriorityResolver)
+			priorityResolver.On("Resolve", testMock.Anything).Return(jobSpecsBase, nil)
+			defer priorityResolver.AssertExpectations(t)
+
+			jobRepoFac := new(mock.JobRepoFactory)
+			defer jobRepoFac.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, nil, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			err := svc.SyncJob(ctx, namespaceSpec, "missing-job", nil)
+			assert.Equal(t, "job missing-job not found in namespace dev-team-1", err.Error())
+		})
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: projSpec,
+		}
+
+		previousSpec := models.JobSpec{
+			Version: 1,
+			Name:    "test",
+			Owner:   "optimus",
+			Schedule: models.JobSpecSchedule{
+				StartDate: time.Date(2020, 12, 02, 0, 0, 0, 0, time.UTC),
+				Interval:  "@daily",
+			},
+			Task: models.JobSpecTask{},
+		}
+
+		t.Run("should save spec of the revision and deploy only that job", func(t *testing.T) {
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetRevision", "test", 1).Return(models.JobSpecRevision{
+				Revision: 1,
+				Spec:     previousSpec,
+			}, nil)
+			jobSpecRepo.On("Save", previousSpec).Return(nil)
+			jobSpecRepo.On("GetAll").Return([]models.JobSpec{previousSpec}, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return([]models.JobSpec{previousSpec}, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			compiledJob := models.Job{Name: "test", NamespaceID: namespaceSpec.Name}
 			jobRepo := new(mock.JobRepository)
-			jobRepo.On("ListNames", ctx, namespaceSpec).Return([]string{"test"}, nil)
+			jobRepo.On("Save", ctx, compiledJob).Return(nil)
 			defer jobRepo.AssertExpectations(t)
 
 			jobRepoFac := new(mock.JobRepoFactory)
 			jobRepoFac.On("New", context.Background(), projSpec).Return(jobRepo, nil)
 			defer jobRepoFac.AssertExpectations(t)
 
-			// resolve dependencies
 			depenResolver := new(mock.DependencyResolver)
-			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsAfterDepenResolve[0], nil)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, previousSpec, nil).Return(previousSpec, nil)
 			defer depenResolver.AssertExpectations(t)
 
-			// resolve priority
 			priorityResolver := new(mock.PriorityResolver)
-			priorityResolver.On("Resolve", jobSpecsAfterDepenResolve).Return(jobSpecsAfterPriorityResolve, nil)
+			priorityResolver.On("Resolve", []models.JobSpec{previousSpec}).Return([]models.JobSpec{previousSpec}, nil)
 			defer priorityResolver.AssertExpectations(t)
 
 			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, previousSpec).Return(compiledJob, nil)
 			defer compiler.AssertExpectations(t)
 
-			metaSvc := new(mock.MetaService)
-			metaSvc.On("Publish", namespaceSpec, jobSpecsAfterPriorityResolve, nil).Return(nil)
-			defer metaSvc.AssertExpectations(t)
+			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, nil, projJobSpecRepoFac, nil)
+			restoredSpec, err := svc.Rollback(ctx, namespaceSpec, "test", 1, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, previousSpec, restoredSpec)
+			jobRepo.AssertNotCalled(t, "ListNames", testMock.Anything, testMock.Anything)
+		})
+		t.Run("should fail if the revision does not exist", func(t *testing.T) {
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetRevision", "test", 3).Return(models.JobSpecRevision{}, store.ErrResourceNotFound)
+			defer jobSpecRepo.AssertExpectations(t)
 
-			metaSvcFact := new(mock.MetaSvcFactory)
-			metaSvcFact.On("New").Return(metaSvc)
-			defer metaSvcFact.AssertExpectations(t)
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
 
-			// compile to dag and save
-			for idx, compiledJob := range jobs {
-				compiler.On("Compile", namespaceSpec, jobSpecsAfterPriorityResolve[idx]).Return(compiledJob, nil)
-				jobRepo.On("Save", ctx, compiledJob).Return(nil)
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, nil, nil, nil, nil, nil)
+			_, err := svc.Rollback(ctx, namespaceSpec, "test", 3, nil)
+			assert.True(t, errors.Is(err, store.ErrResourceNotFound))
+			jobSpecRepo.AssertNotCalled(t, "Save", testMock.Anything)
+		})
+	})
+
+	t.Run("Check", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+
+		namespaceSpec := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-1",
+			ProjectSpec: projSpec,
+		}
+
+		t.Run("should resolve dependencies, priorities and compile jobs without saving them", func(t *testing.T) {
+			execUnit := new(mock.TaskPlugin)
+			execUnit.On("GenerateTaskDependencies", context.TODO(), testMock.Anything).Return(models.GenerateTaskDependenciesResponse{}, nil)
+			defer execUnit.AssertExpectations(t)
+
+			upstreamSpec := models.JobSpec{
+				Name:         "upstream",
+				Owner:        "optimus",
+				Task:         models.JobSpecTask{Unit: execUnit},
+				Dependencies: map[string]models.JobSpecDependency{},
 			}
+			downstreamSpec := models.JobSpec{
+				Name:         "downstream",
+				Owner:        "optimus",
+				Task:         models.JobSpecTask{Unit: execUnit},
+				Dependencies: map[string]models.JobSpecDependency{},
+			}
+			savedSpec := models.JobSpec{Name: "saved-job"}
 
-			svc := job.NewService(jobSpecRepoFac, jobRepoFac, compiler, dumpAssets, depenResolver, priorityResolver, metaSvcFact, projJobSpecRepoFac, nil)
-			err := svc.Sync(ctx, namespaceSpec, nil)
+			resolvedUpstream := upstreamSpec
+			resolvedDownstream := downstreamSpec
+			resolvedDownstream.Dependencies = map[string]models.JobSpecDependency{
+				"upstream":  {Job: &upstreamSpec, Type: models.JobSpecDependencyTypeIntra},
+				"saved-job": {Job: &savedSpec, Type: models.JobSpecDependencyTypeIntra},
+			}
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			obs := new(mock.PipelineLogObserver)
+			obs.On("Notify", &job.EventJobCheckSuccess{Name: "upstream"}).Return().Once()
+			obs.On("Notify", &job.EventJobCheckSuccess{Name: "downstream"}).Return().Once()
+			defer obs.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, resolvedUpstream, obs).Return(resolvedUpstream, nil)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, downstreamSpec, obs).Return(resolvedDownstream, nil)
+			defer depenResolver.AssertExpectations(t)
+
+			compiler := new(mock.Compiler)
+			compiler.On("Compile", namespaceSpec, resolvedUpstream).Return(models.Job{Name: "upstream"}, nil)
+			compiler.On("Compile", namespaceSpec, resolvedDownstream).Return(models.Job{Name: "downstream"}, nil)
+			defer compiler.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, compiler, dumpAssets, depenResolver, job.NewPriorityResolver(), nil, projJobSpecRepoFac, nil)
+			err := svc.Check(namespaceSpec, []models.JobSpec{upstreamSpec, downstreamSpec}, obs)
+			assert.Nil(t, err)
+		})
+		t.Run("should fail jobs not following name or owner rules before resolving them", func(t *testing.T) {
+			execUnit := new(mock.TaskPlugin)
+			defer execUnit.AssertExpectations(t)
+
+			jobSpec := models.JobSpec{
+				Name: "no-owner",
+				Task: models.JobSpecTask{Unit: execUnit},
+			}
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(new(mock.ProjectJobSpecRepository))
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			obs := new(mock.PipelineLogObserver)
+			obs.On("Notify", &job.EventJobCheckFailed{
+				Name:   "no-owner",
+				Reason: "validation: job no-owner should have an owner: invalid job spec\n",
+			}).Return().Once()
+			defer obs.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, nil, nil, nil, projJobSpecRepoFac, nil)
+			err := svc.Check(namespaceSpec, []models.JobSpec{jobSpec}, obs)
+			assert.True(t, errors.Is(err, job.ErrInvalidJobSpec))
+			execUnit.AssertNotCalled(t, "GenerateTaskDependencies", testMock.Anything, testMock.Anything)
+		})
+	})
+
+	t.Run("UnknownDependencies", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+
+		t.Run("should return destinations produced neither by saved jobs nor by the given jobs", func(t *testing.T) {
+			upstreamUnit := new(mock.TaskPlugin)
+			upstreamUnit.On("GenerateTaskDestination", context.TODO(), testMock.Anything).Return(models.GenerateTaskDestinationResponse{
+				Destination: "proj:dataset.upstream",
+			}, nil)
+			defer upstreamUnit.AssertExpectations(t)
+			downstreamUnit := new(mock.TaskPlugin)
+			downstreamUnit.On("GenerateTaskDestination", context.TODO(), testMock.Anything).Return(models.GenerateTaskDestinationResponse{
+				Destination: "proj:dataset.downstream",
+			}, nil)
+			defer downstreamUnit.AssertExpectations(t)
+
+			upstreamSpec := models.JobSpec{
+				Name: "upstream",
+				Task: models.JobSpecTask{Unit: upstreamUnit},
+			}
+			downstreamSpec := models.JobSpec{
+				Name: "downstream",
+				Task: models.JobSpecTask{Unit: downstreamUnit},
+			}
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Explain", projSpec, projectJobSpecRepo, upstreamSpec).Return([]models.DependencyExplanation{}, nil)
+			depenResolver.On("Explain", projSpec, projectJobSpecRepo, downstreamSpec).Return([]models.DependencyExplanation{
+				{Destination: "bigquery://proj:dataset.upstream"},
+				{Destination: "bigquery://proj:dataset.saved", Resolved: true},
+				{Destination: "bigquery://proj:dataset.missing"},
+			}, nil)
+			defer depenResolver.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			unknown, err := svc.UnknownDependencies(projSpec, []models.JobSpec{upstreamSpec, downstreamSpec})
 			assert.Nil(t, err)
+			assert.Equal(t, map[string][]string{
+				"downstream": {"bigquery://proj:dataset.missing"},
+			}, unknown)
 		})
 	})
 
@@ -530,18 +1577,100 @@ func TestService(t *testing.T) {
 			defer jobSpecRepoFac.AssertExpectations(t)
 
 			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
 			defer projectJobSpecRepo.AssertExpectations(t)
 
 			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
 			defer projJobSpecRepoFac.AssertExpectations(t)
 
+			// no job depends on the deleted one
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[0], nil).Return(jobSpecsBase[0], nil)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[1], nil).Return(jobSpecsBase[1], nil)
+			defer depenResolver.AssertExpectations(t)
+
 			// fetch currently stored
 			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
 			// delete unwanted
 			jobSpecRepo.On("Delete", jobSpecsBase[0].Name).Return(nil)
 
-			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, nil, nil, nil, projJobSpecRepoFac, nil)
-			err := svc.KeepOnly(namespaceSpec, toKeep, nil)
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			err := svc.KeepOnly(namespaceSpec, toKeep, false, nil)
+			assert.Nil(t, err)
+		})
+
+		// job-b depends on job-a, job-c depends on job-b
+		jobSpecsBase := []models.JobSpec{
+			{Name: "job-a"},
+			{Name: "job-b"},
+			{Name: "job-c"},
+		}
+		jobSpecsAfterDepenResolve := []models.JobSpec{
+			{Name: "job-a"},
+			{Name: "job-b", Dependencies: map[string]models.JobSpecDependency{
+				"job-a": {Job: &jobSpecsBase[0], Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
+			}},
+			{Name: "job-c", Dependencies: map[string]models.JobSpecDependency{
+				"job-b": {Job: &jobSpecsBase[1], Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
+			}},
+		}
+		t.Run("should not delete jobs other jobs depend on and notify their dependents", func(t *testing.T) {
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			for idx := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[idx], nil).Return(jobSpecsAfterDepenResolve[idx], nil)
+			}
+			defer depenResolver.AssertExpectations(t)
+
+			// job-b goes away along with job-a, only job-c blocks deletion of job-b
+			jobSpecRepo.On("Delete", "job-a").Return(nil)
+
+			obs := new(mock.PipelineLogObserver)
+			obs.On("Notify", &job.EventSavedJobDeleteBlocked{Name: "job-b", Dependents: []string{"job-c"}}).Return()
+			obs.On("Notify", &job.EventSavedJobDelete{Name: "job-a"}).Return()
+			defer obs.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			err := svc.KeepOnly(namespaceSpec, jobSpecsBase[2:], false, obs)
+			assert.Nil(t, err)
+			jobSpecRepo.AssertNotCalled(t, "Delete", "job-b")
+		})
+		t.Run("should delete jobs other jobs depend on if forced", func(t *testing.T) {
+			jobSpecRepo := new(mock.JobSpecRepository)
+			jobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			jobSpecRepo.On("Delete", "job-a").Return(nil)
+			jobSpecRepo.On("Delete", "job-b").Return(nil)
+			defer jobSpecRepo.AssertExpectations(t)
+
+			jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+			jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+			defer jobSpecRepoFac.AssertExpectations(t)
+
+			// dependencies are not resolved when forced
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			defer depenResolver.AssertExpectations(t)
+
+			svc := job.NewService(jobSpecRepoFac, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			err := svc.KeepOnly(namespaceSpec, jobSpecsBase[2:], true, nil)
 			assert.Nil(t, err)
 		})
 	})
@@ -852,4 +1981,267 @@ func TestService(t *testing.T) {
 			assert.Equal(t, "cannot delete job test since it's dependency of job downstream-test", err.Error())
 		})
 	})
+
+	t.Run("GetDownstream", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+		jobSpecsBase := []models.JobSpec{
+			{Name: "job-a"},
+			{Name: "job-b"},
+			{Name: "job-c"},
+			{Name: "job-d"},
+		}
+		// job-b depends on job-a, job-c depends on job-b
+		jobSpecsAfterDepenResolve := []models.JobSpec{
+			{Name: "job-a"},
+			{Name: "job-b", Dependencies: map[string]models.JobSpecDependency{
+				"job-a": {Job: &jobSpecsBase[0], Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
+			}},
+			{Name: "job-c", Dependencies: map[string]models.JobSpecDependency{
+				"job-b": {Job: &jobSpecsBase[1], Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
+			}},
+			{Name: "job-d"},
+		}
+
+		setup := func(t *testing.T) (*job.Service, func()) {
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+
+			depenResolver := new(mock.DependencyResolver)
+			for idx := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[idx], nil).Return(jobSpecsAfterDepenResolve[idx], nil)
+			}
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			return svc, func() {
+				projectJobSpecRepo.AssertExpectations(t)
+				projJobSpecRepoFac.AssertExpectations(t)
+				depenResolver.AssertExpectations(t)
+			}
+		}
+
+		t.Run("should return only direct dependents of a job", func(t *testing.T) {
+			svc, assertAll := setup(t)
+			defer assertAll()
+
+			downstream, err := svc.GetDownstream(projSpec, "job-a", false)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobSpec{jobSpecsAfterDepenResolve[1]}, downstream)
+		})
+		t.Run("should return transitive dependents of a job sorted by name", func(t *testing.T) {
+			svc, assertAll := setup(t)
+			defer assertAll()
+
+			downstream, err := svc.GetDownstream(projSpec, "job-a", true)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobSpec{jobSpecsAfterDepenResolve[1], jobSpecsAfterDepenResolve[2]}, downstream)
+		})
+		t.Run("should return empty list if no job depends on it", func(t *testing.T) {
+			svc, assertAll := setup(t)
+			defer assertAll()
+
+			downstream, err := svc.GetDownstream(projSpec, "job-d", true)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.JobSpec{}, downstream)
+		})
+		t.Run("should fail if dependencies can't be resolved", func(t *testing.T) {
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return([]models.JobSpec{}, errors.New("a random error"))
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, nil, nil, nil, projJobSpecRepoFac, nil)
+			_, err := svc.GetDownstream(projSpec, "job-a", false)
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("GetDependencyGraph", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+		otherProjSpec := models.ProjectSpec{
+			Name: "other",
+		}
+		externalJobSpec := models.JobSpec{Name: "ext-job"}
+		jobSpecsBase := []models.JobSpec{
+			{Name: "job-a"},
+			{Name: "job-b", Dependencies: map[string]models.JobSpecDependency{
+				"job-a": {},
+			}},
+			{Name: "job-c"},
+			{Name: "job-d"},
+		}
+		// job-b depends on job-a as defined in its spec, job-c depends on
+		// job-b and ext-job of other project as inferred from its task
+		jobSpecsAfterDepenResolve := []models.JobSpec{
+			{Name: "job-a"},
+			{Name: "job-b", Dependencies: map[string]models.JobSpecDependency{
+				"job-a": {Job: &jobSpecsBase[0], Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
+			}},
+			{Name: "job-c", Dependencies: map[string]models.JobSpecDependency{
+				"job-b":   {Job: &jobSpecsBase[1], Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
+				"ext-job": {Job: &externalJobSpec, Project: &otherProjSpec, Type: models.JobSpecDependencyTypeInter},
+			}},
+			{Name: "job-d"},
+		}
+
+		setup := func(t *testing.T) (*job.Service, func()) {
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+
+			depenResolver := new(mock.DependencyResolver)
+			for idx := range jobSpecsBase {
+				depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecsBase[idx], nil).Return(jobSpecsAfterDepenResolve[idx], nil)
+			}
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			return svc, func() {
+				projectJobSpecRepo.AssertExpectations(t)
+				projJobSpecRepoFac.AssertExpectations(t)
+				depenResolver.AssertExpectations(t)
+			}
+		}
+		node := func(project, name string) models.JobDependencyGraphNode {
+			return models.JobDependencyGraphNode{Name: name, Project: project}
+		}
+
+		t.Run("should return dependencies between all jobs of project", func(t *testing.T) {
+			svc, assertAll := setup(t)
+			defer assertAll()
+
+			graph, err := svc.GetDependencyGraph(projSpec, "")
+			assert.Nil(t, err)
+			assert.Equal(t, models.JobDependencyGraph{
+				Jobs: []models.JobDependencyGraphNode{
+					node("other", "ext-job"), node("proj", "job-a"), node("proj", "job-b"),
+					node("proj", "job-c"), node("proj", "job-d"),
+				},
+				Edges: []models.JobDependencyGraphEdge{
+					{Upstream: node("proj", "job-a"), Downstream: node("proj", "job-b"), Type: models.JobSpecDependencyTypeIntra},
+					{Upstream: node("other", "ext-job"), Downstream: node("proj", "job-c"), Type: models.JobSpecDependencyTypeInter, Inferred: true},
+					{Upstream: node("proj", "job-b"), Downstream: node("proj", "job-c"), Type: models.JobSpecDependencyTypeIntra, Inferred: true},
+				},
+			}, graph)
+		})
+		t.Run("should return only upstream and downstream of a job", func(t *testing.T) {
+			svc, assertAll := setup(t)
+			defer assertAll()
+
+			graph, err := svc.GetDependencyGraph(projSpec, "job-b")
+			assert.Nil(t, err)
+			assert.Equal(t, models.JobDependencyGraph{
+				Jobs: []models.JobDependencyGraphNode{
+					node("proj", "job-a"), node("proj", "job-b"), node("proj", "job-c"),
+				},
+				Edges: []models.JobDependencyGraphEdge{
+					{Upstream: node("proj", "job-a"), Downstream: node("proj", "job-b"), Type: models.JobSpecDependencyTypeIntra},
+					{Upstream: node("proj", "job-b"), Downstream: node("proj", "job-c"), Type: models.JobSpecDependencyTypeIntra, Inferred: true},
+				},
+			}, graph)
+		})
+		t.Run("should fail if job is not found in project", func(t *testing.T) {
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecsBase, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, nil, nil, nil, projJobSpecRepoFac, nil)
+			_, err := svc.GetDependencyGraph(projSpec, "job-x")
+			assert.True(t, errors.Is(err, store.ErrResourceNotFound))
+		})
+	})
+	t.Run("ResolveDependencies", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+		depSpec := models.JobSpec{Name: "job-a"}
+		jobSpec := models.JobSpec{
+			Name:         "job-b",
+			Dependencies: map[string]models.JobSpecDependency{},
+		}
+
+		t.Run("should return job with its resolved dependencies", func(t *testing.T) {
+			resolvedSpec := models.JobSpec{
+				Name: "job-b",
+				Dependencies: map[string]models.JobSpecDependency{
+					"job-a": {Job: &depSpec, Project: &projSpec, Type: models.JobSpecDependencyTypeIntra},
+				},
+			}
+
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, nil).Return(resolvedSpec, nil)
+			defer depenResolver.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			resolved, err := svc.ResolveDependencies(projSpec, jobSpec)
+			assert.Nil(t, err)
+			assert.Equal(t, resolvedSpec, resolved)
+		})
+		t.Run("should fail if dependencies can't be resolved", func(t *testing.T) {
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projJobSpecRepoFac := new(mock.ProjectJobSpecRepoFactory)
+			projJobSpecRepoFac.On("New", projSpec).Return(projectJobSpecRepo)
+			defer projJobSpecRepoFac.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpec, nil).Return(models.JobSpec{}, errors.New("unknown dependency"))
+			defer depenResolver.AssertExpectations(t)
+
+			svc := job.NewService(nil, nil, nil, dumpAssets, depenResolver, nil, nil, projJobSpecRepoFac, nil)
+			_, err := svc.ResolveDependencies(projSpec, jobSpec)
+			assert.Contains(t, err.Error(), "failed to resolve dependency for job-b: unknown dependency")
+		})
+	})
+	t.Run("GetDependencyResolvedSpecs", func(t *testing.T) {
+		projSpec := models.ProjectSpec{
+			Name: "proj",
+		}
+		jobSpecs := []models.JobSpec{{Name: "job-a"}, {Name: "job-b"}, {Name: "job-c"}}
+
+		t.Run("should compile and resolve every job collecting failures of each", func(t *testing.T) {
+			projectJobSpecRepo := new(mock.ProjectJobSpecRepository)
+			projectJobSpecRepo.On("GetAll").Return(jobSpecs, nil)
+			defer projectJobSpecRepo.AssertExpectations(t)
+
+			depenResolver := new(mock.DependencyResolver)
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecs[1], nil).Return(models.JobSpec{}, errors.New("unknown dependency"))
+			depenResolver.On("Resolve", projSpec, projectJobSpecRepo, jobSpecs[2], nil).Return(jobSpecs[2], nil)
+			defer depenResolver.AssertExpectations(t)
+
+			failingAssets := func(_ models.ProjectSpec, jobSpec models.JobSpec, _ time.Time) (models.JobAssets, error) {
+				if jobSpec.Name == "job-a" {
+					return models.JobAssets{}, errors.New("bad template")
+				}
+				return jobSpec.Assets, nil
+			}
+			svc := job.NewService(nil, nil, nil, failingAssets, depenResolver, nil, nil, nil, nil)
+			svc.SyncConfig = job.SyncConfig{Workers: 2, TicketsPerSec: 100}
+
+			resolved, err := svc.GetDependencyResolvedSpecs(projSpec, projectJobSpecRepo, nil)
+			assert.Equal(t, []models.JobSpec{jobSpecs[2]}, resolved)
+			assert.Contains(t, err.Error(), "2 errors occurred")
+			assert.Contains(t, err.Error(), "asset compilation of job-a: bad template")
+			assert.Contains(t, err.Error(), "failed to resolve dependency for job-b: unknown dependency")
+		})
+	})
 }
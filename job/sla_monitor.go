@@ -0,0 +1,238 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/core/cron"
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+const (
+	// SLAMonitorDefaultInterval is how often run status of jobs is checked
+	// against their sla
+	SLAMonitorDefaultInterval = time.Minute * 5
+
+	slaMonitorBatchSize = 100
+)
+
+// ProjectRepoFactory is used to read registered projects
+type ProjectRepoFactory interface {
+	New() store.ProjectRepository
+}
+
+// EventRegistrar registers events of a job, breaches are reported through it
+type EventRegistrar interface {
+	Register(context.Context, models.NamespaceSpec, models.JobSpec, models.JobEvent) error
+}
+
+type SLAMonitorConfig struct {
+	// Interval between checks, each check looks at runs whose sla
+	// deadline passed since the previous one
+	Interval time.Duration
+}
+
+// SLAMonitor periodically compares runs of jobs having an sla with the
+// status reported by scheduler and registers a models.JobEventTypeSLAMiss
+// event for jobs with runs not finished within sla of being scheduled
+type SLAMonitor struct {
+	config SLAMonitorConfig
+
+	projectRepoFac   ProjectRepoFactory
+	namespaceRepoFac NamespaceRepoFactory
+	jobSpecRepoFac   SpecRepoFactory
+	eventService     EventRegistrar
+
+	// ProjectScheduler returns the scheduler jobs of a project are deployed
+	// to, models.Scheduler is used if not set
+	ProjectScheduler func(models.ProjectSpec) (models.SchedulerUnit, error)
+	Now              func() time.Time
+
+	// lastCheck is when the previous check looked up runs till
+	lastCheck time.Time
+
+	wg   sync.WaitGroup
+	done chan struct{}
+}
+
+// Check registers breaches of runs whose deadline passed since the previous
+// check, a job failing to be checked doesn't stop rest of the jobs
+func (m *SLAMonitor) Check(ctx context.Context) error {
+	now := m.Now().UTC()
+	from := m.lastCheck
+	if from.IsZero() {
+		from = now.Add(-m.config.Interval)
+	}
+
+	projects, err := m.projectRepoFac.New().GetAll()
+	if err != nil {
+		return errors.Wrap(err, "failed to read projects")
+	}
+	var checkErr error
+	for _, proj := range projects {
+		if err := m.checkProject(ctx, proj, from, now); err != nil {
+			checkErr = multierror.Append(checkErr, errors.Wrapf(err, "project %s", proj.Name))
+		}
+	}
+	m.lastCheck = now
+	return checkErr
+}
+
+func (m *SLAMonitor) checkProject(ctx context.Context, proj models.ProjectSpec, from, to time.Time) error {
+	scheduler := models.Scheduler
+	if m.ProjectScheduler != nil {
+		var err error
+		if scheduler, err = m.ProjectScheduler(proj); err != nil {
+			return err
+		}
+	}
+
+	namespaces, err := m.namespaceRepoFac.New(proj).GetAll()
+	if err != nil {
+		return errors.Wrap(err, "failed to read namespaces")
+	}
+	var checkErr error
+	for _, namespace := range namespaces {
+		jobSpecs, err := m.jobSpecRepoFac.New(namespace).GetAll()
+		if err != nil {
+			checkErr = multierror.Append(checkErr, errors.Wrapf(err, "failed to read jobs of namespace %s", namespace.Name))
+			continue
+		}
+		for _, jobSpec := range jobSpecs {
+			if jobSpec.Behavior.SLA.Duration <= 0 {
+				continue
+			}
+			if err := m.checkJob(ctx, scheduler, namespace, jobSpec, from, to); err != nil {
+				checkErr = multierror.Append(checkErr, errors.Wrapf(err, "job %s", jobSpec.Name))
+			}
+		}
+	}
+	return checkErr
+}
+
+// checkJob registers a single event listing every run of job which had its
+// deadline in (from, to] and didn't finish before it
+func (m *SLAMonitor) checkJob(ctx context.Context, scheduler models.SchedulerUnit, namespace models.NamespaceSpec,
+	jobSpec models.JobSpec, from, to time.Time) error {
+	schd, err := cron.ParseCronSchedule(jobSpec.Schedule.Interval)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse schedule %s", jobSpec.Schedule.Interval)
+	}
+	// schedule is evaluated in the timezone of job
+	loc, err := jobSpec.Schedule.Location()
+	if err != nil {
+		return err
+	}
+	sla := jobSpec.Behavior.SLA.Duration
+
+	// a run is triggered at the end of the interval it is scheduled for, so
+	// runs are looked up from a couple of intervals before the deadlines
+	// and filtered by their actual deadline
+	windowStart := from.Add(-sla)
+	nextRun := schd.Next(windowStart.In(loc))
+	lookupStart := windowStart.Add(-2 * schd.Next(nextRun).Sub(nextRun))
+	runs, err := scheduler.GetDagRunStatus(ctx, namespace.ProjectSpec, jobSpec.Name, lookupStart, to.Add(-sla),
+		slaMonitorBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to fetch run status")
+	}
+
+	var breaches []interface{}
+	for _, run := range runs {
+		deadline := schd.Next(run.ScheduledAt.In(loc)).Add(sla)
+		if !deadline.After(from) || deadline.After(to) {
+			continue
+		}
+		if !breachedSLA(run, deadline) {
+			continue
+		}
+		breaches = append(breaches, map[string]interface{}{
+			"task_id":      jobSpec.Name,
+			"scheduled_at": run.ScheduledAt.Format(time.RFC3339),
+			"deadline":     deadline.UTC().Format(time.RFC3339),
+			"state":        run.State.String(),
+		})
+	}
+	if len(breaches) == 0 {
+		return nil
+	}
+
+	value, err := structpb.NewStruct(map[string]interface{}{
+		"sla":  sla.String(),
+		"slas": breaches,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to build sla miss event")
+	}
+	return m.eventService.Register(ctx, namespace, jobSpec, models.JobEvent{
+		Type:  models.JobEventTypeSLAMiss,
+		Value: value.GetFields(),
+	})
+}
+
+// breachedSLA is true if run finished after deadline, or is still not
+// finished. Runs which succeeded without reporting end time are assumed
+// to be within sla
+func breachedSLA(run models.JobStatus, deadline time.Time) bool {
+	if run.EndedAt.IsZero() {
+		return run.State != models.JobStatusStateSuccess
+	}
+	return run.EndedAt.After(deadline)
+}
+
+func (m *SLAMonitor) run() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), m.config.Interval)
+			if err := m.Check(ctx); err != nil {
+				logger.E(errors.Wrap(err, "sla check failed"))
+			}
+			cancel()
+		}
+	}
+}
+
+// Init starts checking sla of jobs in background
+func (m *SLAMonitor) Init() {
+	logger.I("starting sla monitor")
+	m.wg.Add(1)
+	go m.run()
+}
+
+// Close stops the monitor and waits for a running check to finish
+func (m *SLAMonitor) Close() error {
+	close(m.done)
+	m.wg.Wait()
+	return nil
+}
+
+// NewSLAMonitor creates a monitor, checks don't start until Init is called
+func NewSLAMonitor(config SLAMonitorConfig, projectRepoFac ProjectRepoFactory, namespaceRepoFac NamespaceRepoFactory,
+	jobSpecRepoFac SpecRepoFactory, eventService EventRegistrar) *SLAMonitor {
+	if config.Interval <= 0 {
+		config.Interval = SLAMonitorDefaultInterval
+	}
+	return &SLAMonitor{
+		config:           config,
+		projectRepoFac:   projectRepoFac,
+		namespaceRepoFac: namespaceRepoFac,
+		jobSpecRepoFac:   jobSpecRepoFac,
+		eventService:     eventService,
+		Now:              time.Now,
+		done:             make(chan struct{}),
+	}
+}
@@ -0,0 +1,198 @@
+package job_test
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	testMock "github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/core/logger"
+	"github.com/odpf/optimus/job"
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+)
+
+func TestSLAMonitor(t *testing.T) {
+	logger.InitWithWriter("ERROR", ioutil.Discard)
+
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "a-data-project",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "game_jam",
+		ProjectSpec: projectSpec,
+	}
+	newJob := func(name string, sla time.Duration) models.JobSpec {
+		return models.JobSpec{
+			Name: name,
+			Schedule: models.JobSpecSchedule{
+				Interval: "0 2 * * *",
+			},
+			Behavior: models.JobSpecBehavior{
+				SLA: models.JobSpecSLA{
+					Duration: sla,
+				},
+			},
+		}
+	}
+	// checks deadlines passed in (2021-03-02T03:00, 2021-03-02T05:00]
+	now := time.Date(2021, 3, 2, 5, 0, 0, 0, time.UTC)
+	newMonitor := func(jobSpecs []models.JobSpec, scheduler models.SchedulerUnit,
+		eventService job.EventRegistrar) *job.SLAMonitor {
+		projectRepo := new(mock.ProjectRepository)
+		projectRepo.On("GetAll").Return([]models.ProjectSpec{projectSpec}, nil)
+		projectRepoFac := new(mock.ProjectRepoFactory)
+		projectRepoFac.On("New").Return(projectRepo)
+
+		namespaceRepo := new(mock.NamespaceRepository)
+		namespaceRepo.On("GetAll").Return([]models.NamespaceSpec{namespaceSpec}, nil)
+		namespaceRepoFac := new(mock.NamespaceRepoFactory)
+		namespaceRepoFac.On("New", projectSpec).Return(namespaceRepo)
+
+		jobSpecRepo := new(mock.JobSpecRepository)
+		jobSpecRepo.On("GetAll").Return(jobSpecs, nil)
+		jobSpecRepoFac := new(mock.JobSpecRepoFactory)
+		jobSpecRepoFac.On("New", namespaceSpec).Return(jobSpecRepo)
+
+		monitor := job.NewSLAMonitor(job.SLAMonitorConfig{Interval: time.Hour * 2}, projectRepoFac,
+			namespaceRepoFac, jobSpecRepoFac, eventService)
+		monitor.ProjectScheduler = func(models.ProjectSpec) (models.SchedulerUnit, error) {
+			return scheduler, nil
+		}
+		monitor.Now = func() time.Time {
+			return now
+		}
+		return monitor
+	}
+
+	t.Run("should register sla miss for runs not finished within sla", func(t *testing.T) {
+		lateJob := newJob("late-job", time.Hour*2)
+		onTimeJob := newJob("on-time-job", time.Hour*3)
+		noSLAJob := newJob("no-sla-job", 0)
+
+		scheduler := new(mock.Scheduler)
+		defer scheduler.AssertExpectations(t)
+		scheduler.On("GetDagRunStatus", context.Background(), projectSpec, lateJob.Name,
+			time.Date(2021, 2, 28, 1, 0, 0, 0, time.UTC), time.Date(2021, 3, 2, 3, 0, 0, 0, time.UTC), 100).
+			Return([]models.JobStatus{
+				{
+					// deadline passed before the check window
+					ScheduledAt: time.Date(2021, 2, 28, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateRunning,
+				},
+				{
+					ScheduledAt: time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateRunning,
+					StartedAt:   time.Date(2021, 3, 2, 2, 0, 0, 0, time.UTC),
+				},
+			}, nil)
+		scheduler.On("GetDagRunStatus", context.Background(), projectSpec, onTimeJob.Name,
+			time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2021, 3, 2, 2, 0, 0, 0, time.UTC), 100).
+			Return([]models.JobStatus{
+				{
+					ScheduledAt: time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateSuccess,
+					StartedAt:   time.Date(2021, 3, 2, 2, 0, 0, 0, time.UTC),
+					EndedAt:     time.Date(2021, 3, 2, 4, 30, 0, 0, time.UTC),
+				},
+			}, nil)
+
+		eventValue, _ := structpb.NewStruct(map[string]interface{}{
+			"sla": "2h0m0s",
+			"slas": []interface{}{
+				map[string]interface{}{
+					"task_id":      "late-job",
+					"scheduled_at": "2021-03-01T02:00:00Z",
+					"deadline":     "2021-03-02T04:00:00Z",
+					"state":        "running",
+				},
+			},
+		})
+		eventService := new(mock.EventService)
+		defer eventService.AssertExpectations(t)
+		eventService.On("Register", context.Background(), namespaceSpec, lateJob, models.JobEvent{
+			Type:  models.JobEventTypeSLAMiss,
+			Value: eventValue.GetFields(),
+		}).Return(nil)
+
+		monitor := newMonitor([]models.JobSpec{lateJob, onTimeJob, noSLAJob}, scheduler, eventService)
+		assert.Nil(t, monitor.Check(context.Background()))
+	})
+	t.Run("should evaluate schedule of job in its timezone", func(t *testing.T) {
+		// runs at 2021-03-01T02:00Z in UTC, next one at 2021-03-02T02:00Z
+		jakartaJob := newJob("jakarta-job", time.Hour*2)
+		jakartaJob.Schedule.Interval = "0 9 * * *"
+		jakartaJob.Schedule.Timezone = "Asia/Jakarta"
+
+		scheduler := new(mock.Scheduler)
+		defer scheduler.AssertExpectations(t)
+		scheduler.On("GetDagRunStatus", context.Background(), projectSpec, jakartaJob.Name,
+			time.Date(2021, 2, 28, 1, 0, 0, 0, time.UTC), time.Date(2021, 3, 2, 3, 0, 0, 0, time.UTC), 100).
+			Return([]models.JobStatus{
+				{
+					ScheduledAt: time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateRunning,
+					StartedAt:   time.Date(2021, 3, 2, 2, 0, 0, 0, time.UTC),
+				},
+			}, nil)
+
+		eventValue, _ := structpb.NewStruct(map[string]interface{}{
+			"sla": "2h0m0s",
+			"slas": []interface{}{
+				map[string]interface{}{
+					"task_id":      "jakarta-job",
+					"scheduled_at": "2021-03-01T02:00:00Z",
+					"deadline":     "2021-03-02T04:00:00Z",
+					"state":        "running",
+				},
+			},
+		})
+		eventService := new(mock.EventService)
+		defer eventService.AssertExpectations(t)
+		eventService.On("Register", context.Background(), namespaceSpec, jakartaJob, models.JobEvent{
+			Type:  models.JobEventTypeSLAMiss,
+			Value: eventValue.GetFields(),
+		}).Return(nil)
+
+		monitor := newMonitor([]models.JobSpec{jakartaJob}, scheduler, eventService)
+		assert.Nil(t, monitor.Check(context.Background()))
+	})
+	t.Run("should keep checking rest of the jobs if fetching status fails", func(t *testing.T) {
+		failingJob := newJob("failing-job", time.Hour*2)
+		lateJob := newJob("late-job", time.Hour*2)
+
+		scheduler := new(mock.Scheduler)
+		defer scheduler.AssertExpectations(t)
+		scheduler.On("GetDagRunStatus", context.Background(), projectSpec, failingJob.Name,
+			time.Date(2021, 2, 28, 1, 0, 0, 0, time.UTC), time.Date(2021, 3, 2, 3, 0, 0, 0, time.UTC), 100).
+			Return([]models.JobStatus{}, errors.New("scheduler unreachable"))
+		scheduler.On("GetDagRunStatus", context.Background(), projectSpec, lateJob.Name,
+			time.Date(2021, 2, 28, 1, 0, 0, 0, time.UTC), time.Date(2021, 3, 2, 3, 0, 0, 0, time.UTC), 100).
+			Return([]models.JobStatus{
+				{
+					ScheduledAt: time.Date(2021, 3, 1, 2, 0, 0, 0, time.UTC),
+					State:       models.JobStatusStateFailed,
+					StartedAt:   time.Date(2021, 3, 2, 2, 0, 0, 0, time.UTC),
+					EndedAt:     time.Date(2021, 3, 2, 4, 10, 0, 0, time.UTC),
+				},
+			}, nil)
+
+		eventService := new(mock.EventService)
+		defer eventService.AssertExpectations(t)
+		eventService.On("Register", context.Background(), namespaceSpec, lateJob, testMock.AnythingOfType("models.JobEvent")).
+			Return(nil)
+
+		monitor := newMonitor([]models.JobSpec{failingJob, lateJob}, scheduler, eventService)
+		err := monitor.Check(context.Background())
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "scheduler unreachable")
+	})
+}
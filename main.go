@@ -38,7 +38,7 @@ func main() {
 
 	pluginLogLevel := hclog.Info
 	if configuration.GetLog().Level != "" {
-		lg.Init(configuration.GetLog().Level)
+		lg.InitWithFormat(configuration.GetLog().Level, configuration.GetLog().Format)
 		if strings.ToLower(configuration.GetLog().Level) == "debug" {
 			pluginLogLevel = hclog.Debug
 		}
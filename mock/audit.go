@@ -0,0 +1,22 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/odpf/optimus/models"
+)
+
+type AuditEventRepository struct {
+	mock.Mock
+}
+
+func (repo *AuditEventRepository) Save(ctx context.Context, event models.AuditEvent) error {
+	return repo.Called(ctx, event).Error(0)
+}
+
+func (repo *AuditEventRepository) List(ctx context.Context, filter models.AuditEventFilter) ([]models.AuditEvent, error) {
+	args := repo.Called(ctx, filter)
+	return args.Get(0).([]models.AuditEvent), args.Error(1)
+}
@@ -2,6 +2,9 @@ package mock
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/odpf/optimus/store"
 
@@ -37,6 +40,19 @@ func (d *Datastorer) ReadResource(ctx context.Context, inp models.ReadResourceRe
 func (d *Datastorer) DeleteResource(ctx context.Context, inp models.DeleteResourceRequest) error {
 	return d.Called(ctx, inp).Error(0)
 }
+func (d *Datastorer) RenameResource(ctx context.Context, inp models.RenameResourceRequest) error {
+	return d.Called(ctx, inp).Error(0)
+}
+func (d *Datastorer) BackupResource(ctx context.Context, inp models.BackupResourceRequest) (models.BackupResourceResponse, error) {
+	args := d.Called(ctx, inp)
+	return args.Get(0).(models.BackupResourceResponse), args.Error(1)
+}
+func (d *Datastorer) RestoreResource(ctx context.Context, inp models.RestoreResourceRequest) error {
+	return d.Called(ctx, inp).Error(0)
+}
+func (d *Datastorer) CheckDatastore(ctx context.Context, inp models.CheckDatastoreRequest) error {
+	return d.Called(ctx, inp).Error(0)
+}
 
 type DatastoreTypeController struct {
 	mock.Mock
@@ -50,6 +66,10 @@ func (d *DatastoreTypeController) Validator() models.DatastoreSpecValidator {
 	return d.Called().Get(0).(models.DatastoreSpecValidator)
 }
 
+func (d *DatastoreTypeController) NameFormat() string {
+	return d.Called().String(0)
+}
+
 func (d *DatastoreTypeController) DefaultAssets() map[string]string {
 	return d.Called().Get(0).(map[string]string)
 }
@@ -87,12 +107,21 @@ func (d *DatastoreService) GetAll(spec models.NamespaceSpec, datastoreName strin
 	return args.Get(0).([]models.ResourceSpec), args.Error(1)
 }
 
+func (d *DatastoreService) GetPage(spec models.NamespaceSpec, datastoreName, after string, limit int) ([]models.ResourceSpec, error) {
+	args := d.Called(spec, datastoreName, after, limit)
+	return args.Get(0).([]models.ResourceSpec), args.Error(1)
+}
+
 func (d *DatastoreService) CreateResource(ctx context.Context, namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec, obs progress.Observer) error {
 	return d.Called(ctx, namespace, resourceSpecs, obs).Error(0)
 }
 
-func (d *DatastoreService) UpdateResource(ctx context.Context, namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec, obs progress.Observer) error {
-	return d.Called(ctx, namespace, resourceSpecs, obs).Error(0)
+func (d *DatastoreService) UpdateResource(ctx context.Context, namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec, force bool, obs progress.Observer) error {
+	return d.Called(ctx, namespace, resourceSpecs, force, obs).Error(0)
+}
+
+func (d *DatastoreService) SaveResource(namespace models.NamespaceSpec, resourceSpecs []models.ResourceSpec) error {
+	return d.Called(namespace, resourceSpecs).Error(0)
 }
 
 func (d *DatastoreService) ReadResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string) (models.ResourceSpec, error) {
@@ -100,8 +129,35 @@ func (d *DatastoreService) ReadResource(ctx context.Context, namespace models.Na
 	return args.Get(0).(models.ResourceSpec), args.Error(1)
 }
 
-func (d *DatastoreService) DeleteResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string) error {
-	return d.Called(ctx, namespace, datastoreName, name).Error(1)
+func (d *DatastoreService) DeleteResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string, deleteFromDatastore bool) error {
+	return d.Called(ctx, namespace, datastoreName, name, deleteFromDatastore).Error(0)
+}
+
+func (d *DatastoreService) RenameResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, oldName, newName string, allowCopy bool) error {
+	return d.Called(ctx, namespace, datastoreName, oldName, newName, allowCopy).Error(0)
+}
+
+func (d *DatastoreService) KeepOnly(ctx context.Context, namespace models.NamespaceSpec, datastoreName string, resourceSpecs []models.ResourceSpec, obs progress.Observer) error {
+	return d.Called(ctx, namespace, datastoreName, resourceSpecs, obs).Error(0)
+}
+
+func (d *DatastoreService) BackupResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName, name string, ttl time.Duration, description string) (models.BackupSpec, error) {
+	args := d.Called(ctx, namespace, datastoreName, name, ttl, description)
+	return args.Get(0).(models.BackupSpec), args.Error(1)
+}
+
+func (d *DatastoreService) RestoreResource(ctx context.Context, namespace models.NamespaceSpec, datastoreName string, backupID uuid.UUID) (models.ResourceSpec, error) {
+	args := d.Called(ctx, namespace, datastoreName, backupID)
+	return args.Get(0).(models.ResourceSpec), args.Error(1)
+}
+
+func (d *DatastoreService) GetBackups(namespace models.NamespaceSpec, datastoreName string) ([]models.BackupSpec, error) {
+	args := d.Called(namespace, datastoreName)
+	return args.Get(0).([]models.BackupSpec), args.Error(1)
+}
+
+func (d *DatastoreService) CheckDatastore(ctx context.Context, project models.ProjectSpec, datastoreName string) error {
+	return d.Called(ctx, project, datastoreName).Error(0)
 }
 
 type SupportedDatastoreRepo struct {
@@ -148,10 +204,41 @@ func (r *ResourceSpecRepository) GetAll() ([]models.ResourceSpec, error) {
 	return args.Get(0).([]models.ResourceSpec), args.Error(1)
 }
 
+func (r *ResourceSpecRepository) GetPage(after string, limit int) ([]models.ResourceSpec, error) {
+	args := r.Called(after, limit)
+	return args.Get(0).([]models.ResourceSpec), args.Error(1)
+}
+
 func (r *ResourceSpecRepository) Delete(s string) error {
 	return r.Called(s).Error(0)
 }
 
+type BackupRepoFactory struct {
+	mock.Mock
+}
+
+func (r *BackupRepoFactory) New(spec models.NamespaceSpec, storer models.Datastorer) store.BackupRepository {
+	return r.Called(spec, storer).Get(0).(store.BackupRepository)
+}
+
+type BackupRepository struct {
+	mock.Mock
+}
+
+func (r *BackupRepository) Save(spec models.BackupSpec) error {
+	return r.Called(spec).Error(0)
+}
+
+func (r *BackupRepository) GetByID(id uuid.UUID) (models.BackupSpec, error) {
+	args := r.Called(id)
+	return args.Get(0).(models.BackupSpec), args.Error(1)
+}
+
+func (r *BackupRepository) GetAll() ([]models.BackupSpec, error) {
+	args := r.Called()
+	return args.Get(0).([]models.BackupSpec), args.Error(1)
+}
+
 type ProjectResourceSpecRepoFactory struct {
 	mock.Mock
 }
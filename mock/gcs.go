@@ -33,3 +33,17 @@ func (wc *WriteCloser) Write(p []byte) (n int, err error) {
 func (wc *WriteCloser) Close() error {
 	return wc.Called().Error(0)
 }
+
+type ObjectReader struct {
+	mock.Mock
+}
+
+func (m *ObjectReader) NewReader(bucket, path string) (io.ReadCloser, error) {
+	args := m.Called(bucket, path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *ObjectReader) NewReaderContext(ctx context.Context, bucket, path string) (io.ReadCloser, error) {
+	args := m.Called(ctx, bucket, path)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
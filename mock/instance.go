@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"time"
 
 	"github.com/odpf/optimus/models"
@@ -52,3 +53,25 @@ func (s *InstanceService) Register(jobSpec models.JobSpec, scheduledAt time.Time
 	args := s.Called(jobSpec, scheduledAt, taskType)
 	return args.Get(0).(models.InstanceSpec), args.Error(1)
 }
+
+func (s *InstanceService) Get(jobSpec models.JobSpec, scheduledAt time.Time) (models.InstanceSpec, error) {
+	args := s.Called(jobSpec, scheduledAt)
+	return args.Get(0).(models.InstanceSpec), args.Error(1)
+}
+
+type AssetLoader struct {
+	mock.Mock
+}
+
+func (l *AssetLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	args := l.Called(ctx, uri)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+type AssetLoaderFactory struct {
+	mock.Mock
+}
+
+func (f *AssetLoaderFactory) New(proj models.ProjectSpec) models.AssetLoader {
+	return f.Called(proj).Get(0).(models.AssetLoader)
+}
@@ -52,6 +52,11 @@ func (repo *ProjectJobSpecRepository) GetByDestination(dest string) (models.JobS
 	return models.JobSpec{}, models.ProjectSpec{}, args.Error(2)
 }
 
+func (repo *ProjectJobSpecRepository) GetAllByDestination(dest string) ([]models.ProjectJobPair, error) {
+	args := repo.Called(dest)
+	return args.Get(0).([]models.ProjectJobPair), args.Error(1)
+}
+
 // JobSpecRepoFactory to store raw specs at namespace level
 type JobSpecRepoFactory struct {
 	mock.Mock
@@ -90,6 +95,24 @@ func (repo *JobSpecRepository) GetAll() ([]models.JobSpec, error) {
 	return []models.JobSpec{}, args.Error(1)
 }
 
+func (repo *JobSpecRepository) GetPage(after string, limit int) ([]models.JobSpec, error) {
+	args := repo.Called(after, limit)
+	return args.Get(0).([]models.JobSpec), args.Error(1)
+}
+
+func (repo *JobSpecRepository) GetRevisions(name string) ([]models.JobSpecRevision, error) {
+	args := repo.Called(name)
+	if args.Get(0) != nil {
+		return args.Get(0).([]models.JobSpecRevision), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (repo *JobSpecRepository) GetRevision(name string, revision int) (models.JobSpecRevision, error) {
+	args := repo.Called(name, revision)
+	return args.Get(0).(models.JobSpecRevision), args.Error(1)
+}
+
 func (repo *JobSpecRepository) GetByDestination(dest string) (models.JobSpec, models.ProjectSpec, error) {
 	args := repo.Called(dest)
 	if args.Get(0) != nil {
@@ -156,6 +179,10 @@ func (srv *JobService) Create(spec2 models.NamespaceSpec, spec models.JobSpec) e
 	return args.Error(0)
 }
 
+func (srv *JobService) Validate(namespace models.NamespaceSpec, spec models.JobSpec) error {
+	return srv.Called(namespace, spec).Error(0)
+}
+
 func (srv *JobService) GetByName(s string, spec models.NamespaceSpec) (models.JobSpec, error) {
 	args := srv.Called(s, spec)
 	return args.Get(0).(models.JobSpec), args.Error(1)
@@ -166,8 +193,8 @@ func (srv *JobService) Dump(spec2 models.NamespaceSpec, spec3 models.JobSpec) (m
 	return args.Get(0).(models.Job), args.Error(1)
 }
 
-func (srv *JobService) KeepOnly(spec models.NamespaceSpec, specs []models.JobSpec, observer progress.Observer) error {
-	args := srv.Called(spec, specs)
+func (srv *JobService) KeepOnly(spec models.NamespaceSpec, specs []models.JobSpec, force bool, observer progress.Observer) error {
+	args := srv.Called(spec, specs, force)
 	return args.Error(0)
 }
 
@@ -176,6 +203,11 @@ func (srv *JobService) GetAll(spec models.NamespaceSpec) ([]models.JobSpec, erro
 	return args.Get(0).([]models.JobSpec), args.Error(1)
 }
 
+func (srv *JobService) GetPage(spec models.NamespaceSpec, after string, limit int) ([]models.JobSpec, error) {
+	args := srv.Called(spec, after, limit)
+	return args.Get(0).([]models.JobSpec), args.Error(1)
+}
+
 func (srv *JobService) GetByNameForProject(s string, spec models.ProjectSpec) (models.JobSpec, models.NamespaceSpec, error) {
 	args := srv.Called(s, spec)
 	return args.Get(0).(models.JobSpec), args.Get(1).(models.NamespaceSpec), args.Error(2)
@@ -186,11 +218,51 @@ func (srv *JobService) Sync(ctx context.Context, spec models.NamespaceSpec, obse
 	return args.Error(0)
 }
 
+func (srv *JobService) SyncJob(ctx context.Context, spec models.NamespaceSpec, jobName string, observer progress.Observer) error {
+	args := srv.Called(ctx, spec, jobName, observer)
+	return args.Error(0)
+}
+
+func (srv *JobService) GetHistory(namespace models.NamespaceSpec, jobName string) ([]models.JobSpecRevision, error) {
+	args := srv.Called(namespace, jobName)
+	return args.Get(0).([]models.JobSpecRevision), args.Error(1)
+}
+
+func (srv *JobService) Rollback(ctx context.Context, namespace models.NamespaceSpec, jobName string, revision int, observer progress.Observer) (models.JobSpec, error) {
+	args := srv.Called(ctx, namespace, jobName, revision, observer)
+	return args.Get(0).(models.JobSpec), args.Error(1)
+}
+
 func (j *JobService) Check(namespaceSpec models.NamespaceSpec, specs []models.JobSpec, observer progress.Observer) error {
 	args := j.Called(namespaceSpec, specs, observer)
 	return args.Error(0)
 }
 
+func (j *JobService) GetDownstream(projectSpec models.ProjectSpec, jobName string, transitive bool) ([]models.JobSpec, error) {
+	args := j.Called(projectSpec, jobName, transitive)
+	return args.Get(0).([]models.JobSpec), args.Error(1)
+}
+
+func (j *JobService) ExplainDependency(projectSpec models.ProjectSpec, jobSpec models.JobSpec) ([]models.DependencyExplanation, error) {
+	args := j.Called(projectSpec, jobSpec)
+	return args.Get(0).([]models.DependencyExplanation), args.Error(1)
+}
+
+func (j *JobService) UnknownDependencies(projectSpec models.ProjectSpec, jobSpecs []models.JobSpec) (map[string][]string, error) {
+	args := j.Called(projectSpec, jobSpecs)
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (j *JobService) GetDependencyGraph(projectSpec models.ProjectSpec, jobName string) (models.JobDependencyGraph, error) {
+	args := j.Called(projectSpec, jobName)
+	return args.Get(0).(models.JobDependencyGraph), args.Error(1)
+}
+
+func (j *JobService) ResolveDependencies(projectSpec models.ProjectSpec, jobSpec models.JobSpec) (models.JobSpec, error) {
+	args := j.Called(projectSpec, jobSpec)
+	return args.Get(0).(models.JobSpec), args.Error(1)
+}
+
 func (j *JobService) Delete(ctx context.Context, c models.NamespaceSpec, job models.JobSpec) error {
 	args := j.Called(ctx, c, job)
 	return args.Error(0)
@@ -225,6 +297,12 @@ func (srv *DependencyResolver) Resolve(projectSpec models.ProjectSpec, projectJo
 	return args.Get(0).(models.JobSpec), args.Error(1)
 }
 
+func (srv *DependencyResolver) Explain(projectSpec models.ProjectSpec, projectJobSpecRepo store.ProjectJobSpecRepository,
+	jobSpec models.JobSpec) ([]models.DependencyExplanation, error) {
+	args := srv.Called(projectSpec, projectJobSpecRepo, jobSpec)
+	return args.Get(0).([]models.DependencyExplanation), args.Error(1)
+}
+
 type PriorityResolver struct {
 	mock.Mock
 }
@@ -242,6 +320,10 @@ func (e *EventService) Register(ctx context.Context, spec models.NamespaceSpec,
 	return e.Called(ctx, spec, spec2, event).Error(0)
 }
 
+func (e *EventService) Alert(ctx context.Context, spec models.NamespaceSpec, spec2 models.JobSpec, event models.JobEvent) error {
+	return e.Called(ctx, spec, spec2, event).Error(0)
+}
+
 type Notifier struct {
 	mock.Mock
 }
@@ -253,3 +335,49 @@ func (n *Notifier) Close() error {
 func (n *Notifier) Notify(ctx context.Context, attr models.NotifyAttrs) error {
 	return n.Called(ctx, attr).Error(0)
 }
+
+type JobEventRepository struct {
+	mock.Mock
+}
+
+func (repo *JobEventRepository) Save(jobSpec models.JobSpec, event models.JobEvent) error {
+	return repo.Called(jobSpec, event).Error(0)
+}
+
+func (repo *JobEventRepository) GetByJobName(name string) ([]models.JobEvent, error) {
+	args := repo.Called(name)
+	return args.Get(0).([]models.JobEvent), args.Error(1)
+}
+
+type JobEventRepoFactory struct {
+	mock.Mock
+}
+
+func (fac *JobEventRepoFactory) New(namespace models.NamespaceSpec) store.JobEventRepository {
+	return fac.Called(namespace).Get(0).(store.JobEventRepository)
+}
+
+type JobChecksumRepository struct {
+	mock.Mock
+}
+
+func (repo *JobChecksumRepository) GetAll(ctx context.Context) (map[string]string, error) {
+	args := repo.Called(ctx)
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (repo *JobChecksumRepository) Save(ctx context.Context, jobName, checksum string) error {
+	return repo.Called(ctx, jobName, checksum).Error(0)
+}
+
+func (repo *JobChecksumRepository) Delete(ctx context.Context, jobName string) error {
+	return repo.Called(ctx, jobName).Error(0)
+}
+
+type JobChecksumRepoFactory struct {
+	mock.Mock
+}
+
+func (fac *JobChecksumRepoFactory) New(namespace models.NamespaceSpec) store.JobChecksumRepository {
+	return fac.Called(namespace).Get(0).(store.JobChecksumRepository)
+}
@@ -25,6 +25,10 @@ func (pr *ProjectRepository) GetAll() ([]models.ProjectSpec, error) {
 	return args.Get(0).([]models.ProjectSpec), args.Error(1)
 }
 
+func (pr *ProjectRepository) Delete(name string) error {
+	return pr.Called(name).Error(0)
+}
+
 type ProjectRepoFactory struct {
 	mock.Mock
 }
@@ -61,6 +65,15 @@ func (pr *ProjectSecretRepository) GetAll() ([]models.ProjectSecretItem, error)
 	return args.Get(0).([]models.ProjectSecretItem), args.Error(1)
 }
 
+func (pr *ProjectSecretRepository) Delete(name string) error {
+	return pr.Called(name).Error(0)
+}
+
+func (pr *ProjectSecretRepository) RotateKey() (int, error) {
+	args := pr.Called()
+	return args.Int(0), args.Error(1)
+}
+
 type PipelineLogObserver struct {
 	mock.Mock
 }
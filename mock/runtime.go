@@ -41,3 +41,207 @@ func (r *RuntimeService_DeployJobSpecificationServer) SendMsg(m interface{}) err
 func (r *RuntimeService_DeployJobSpecificationServer) RecvMsg(m interface{}) error {
 	panic("implement me")
 }
+
+type RuntimeService_DeployResourceSpecificationServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_DeployResourceSpecificationServer) Send(response *pb.DeployResourceSpecificationResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_DeployResourceSpecificationServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_DeployResourceSpecificationServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_DeployResourceSpecificationServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_DeployResourceSpecificationServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_DeployResourceSpecificationServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_DeployResourceSpecificationServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
+
+type RuntimeService_ReadResourcesServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_ReadResourcesServer) Send(response *pb.ReadResourcesResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_ReadResourcesServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ReadResourcesServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ReadResourcesServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ReadResourcesServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_ReadResourcesServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ReadResourcesServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
+
+type RuntimeService_ValidateResourceSpecificationServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_ValidateResourceSpecificationServer) Send(response *pb.ValidateResourceSpecificationResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_ValidateResourceSpecificationServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ValidateResourceSpecificationServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ValidateResourceSpecificationServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ValidateResourceSpecificationServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_ValidateResourceSpecificationServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ValidateResourceSpecificationServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
+
+type RuntimeService_ExportProjectServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_ExportProjectServer) Send(response *pb.ExportProjectResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_ExportProjectServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ExportProjectServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ExportProjectServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ExportProjectServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_ExportProjectServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ExportProjectServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
+
+type RuntimeService_ImportProjectServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_ImportProjectServer) Send(response *pb.ImportProjectResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_ImportProjectServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ImportProjectServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ImportProjectServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ImportProjectServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_ImportProjectServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_ImportProjectServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
+
+type RuntimeService_GetJobRunLogsServer struct {
+	mock.Mock
+}
+
+func (r *RuntimeService_GetJobRunLogsServer) Send(response *pb.GetJobRunLogsResponse) error {
+	args := r.Called(response)
+	return args.Error(0)
+}
+
+func (r *RuntimeService_GetJobRunLogsServer) SetHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_GetJobRunLogsServer) SendHeader(md metadata.MD) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_GetJobRunLogsServer) SetTrailer(md metadata.MD) {
+	panic("implement me")
+}
+
+func (r *RuntimeService_GetJobRunLogsServer) Context() context.Context {
+	args := r.Called()
+	return args.Get(0).(context.Context)
+}
+
+func (r *RuntimeService_GetJobRunLogsServer) SendMsg(m interface{}) error {
+	panic("implement me")
+}
+
+func (r *RuntimeService_GetJobRunLogsServer) RecvMsg(m interface{}) error {
+	panic("implement me")
+}
@@ -32,8 +32,9 @@ func (ms *Scheduler) Bootstrap(ctx context.Context, projectSpec models.ProjectSp
 	return ms.Called(ctx, projectSpec).Error(0)
 }
 
-func (ms *Scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string) ([]models.JobStatus, error) {
-	args := ms.Called(ctx, projSpec, jobName)
+func (ms *Scheduler) GetJobStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string,
+	filter models.JobStatusFilter) ([]models.JobStatus, error) {
+	args := ms.Called(ctx, projSpec, jobName, filter)
 	return args.Get(0).([]models.JobStatus), args.Error(1)
 }
 
@@ -42,8 +43,34 @@ func (ms *Scheduler) Clear(ctx context.Context, projSpec models.ProjectSpec, job
 	return args.Error(0)
 }
 
+func (ms *Scheduler) RunJob(ctx context.Context, projSpec models.ProjectSpec, jobName string, scheduledAt time.Time) error {
+	return ms.Called(ctx, projSpec, jobName, scheduledAt).Error(0)
+}
+
+func (ms *Scheduler) GetJobRunLogs(ctx context.Context, projSpec models.ProjectSpec, jobName string, request models.JobRunLogRequest,
+	send func(models.JobRunLog) error) error {
+	args := ms.Called(ctx, projSpec, jobName, request, send)
+	if logs, ok := args.Get(0).([]models.JobRunLog); ok {
+		for _, log := range logs {
+			if err := send(log); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (ms *Scheduler) GetDagRunStatus(ctx context.Context, projSpec models.ProjectSpec, jobName string, startDate time.Time,
 	endDate time.Time, batchSize int) ([]models.JobStatus, error) {
 	args := ms.Called(ctx, projSpec, jobName, startDate, endDate, batchSize)
 	return args.Get(0).([]models.JobStatus), args.Error(1)
 }
+
+func (ms *Scheduler) IsJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string) (bool, error) {
+	args := ms.Called(ctx, projSpec, jobName)
+	return args.Bool(0), args.Error(1)
+}
+
+func (ms *Scheduler) SetJobPaused(ctx context.Context, projSpec models.ProjectSpec, jobName string, paused bool) error {
+	return ms.Called(ctx, projSpec, jobName, paused).Error(0)
+}
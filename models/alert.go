@@ -0,0 +1,108 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AlertChannelsConfig is the project or namespace config listing comma
+	// separated channels alerts are sent to, each channel is a notifier
+	// scheme and route optionally followed by the minimum severity it
+	// accepts, e.g. slack://#data-alerts,pagerduty://<routing key>?severity=critical
+	AlertChannelsConfig = "ALERT_CHANNELS"
+
+	AlertSeverityInfo     AlertSeverity = "info"
+	AlertSeverityWarning  AlertSeverity = "warning"
+	AlertSeverityCritical AlertSeverity = "critical"
+
+	// DefaultAlertSeverity is the minimum severity of channels not
+	// declaring one
+	DefaultAlertSeverity = AlertSeverityWarning
+)
+
+var alertSeverityRank = map[AlertSeverity]int{
+	AlertSeverityInfo:     0,
+	AlertSeverityWarning:  1,
+	AlertSeverityCritical: 2,
+}
+
+// AlertSeverity tells how urgently an alert needs attention
+type AlertSeverity string
+
+// AtLeast is true if s is as severe as other or more
+func (s AlertSeverity) AtLeast(other AlertSeverity) bool {
+	return alertSeverityRank[s] >= alertSeverityRank[other]
+}
+
+// JobEventSeverity is the severity alerts of an event are raised with
+func JobEventSeverity(eventType JobEventType) AlertSeverity {
+	switch eventType {
+	case JobEventTypeFailure, JobEventTypeDeployFailure:
+		return AlertSeverityCritical
+	case JobEventTypeSLAMiss:
+		return AlertSeverityWarning
+	}
+	return AlertSeverityInfo
+}
+
+// AlertChannel is a destination alerts of a namespace are sent to
+type AlertChannel struct {
+	// Type is the scheme of notifier handling the channel, e.g. slack
+	Type string
+	// Route is passed to notifier as it is, e.g. a slack channel or a
+	// pagerduty routing key
+	Route string
+	// MinSeverity of alerts sent to the channel
+	MinSeverity AlertSeverity
+}
+
+// ParseAlertChannels reads channels in the format of AlertChannelsConfig
+func ParseAlertChannels(conf string) ([]AlertChannel, error) {
+	var channels []AlertChannel
+	for _, raw := range strings.Split(conf, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		parts := strings.SplitN(raw, "://", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("invalid alert channel %s, expected <type>://<route>", raw)
+		}
+		channel := AlertChannel{
+			Type:        parts[0],
+			Route:       parts[1],
+			MinSeverity: DefaultAlertSeverity,
+		}
+		if idx := strings.LastIndex(parts[1], "?"); idx >= 0 {
+			query, err := url.ParseQuery(parts[1][idx+1:])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid alert channel %s", raw)
+			}
+			channel.Route = parts[1][:idx]
+			if severity := query.Get("severity"); severity != "" {
+				channel.MinSeverity = AlertSeverity(strings.ToLower(severity))
+				if _, ok := alertSeverityRank[channel.MinSeverity]; !ok {
+					return nil, errors.Errorf("invalid severity %s of alert channel %s", severity, raw)
+				}
+			}
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+// AlertChannels returns channels configured at AlertChannelsConfig of the
+// project followed by the ones of namespace
+func (n NamespaceSpec) AlertChannels() ([]AlertChannel, error) {
+	projectChannels, err := ParseAlertChannels(n.ProjectSpec.Config[AlertChannelsConfig])
+	if err != nil {
+		return nil, errors.Wrapf(err, "project %s", n.ProjectSpec.Name)
+	}
+	namespaceChannels, err := ParseAlertChannels(n.Config[AlertChannelsConfig])
+	if err != nil {
+		return nil, errors.Wrapf(err, "namespace %s", n.Name)
+	}
+	return append(projectChannels, namespaceChannels...), nil
+}
@@ -0,0 +1,63 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestAlertChannels(t *testing.T) {
+	t.Run("ParseAlertChannels", func(t *testing.T) {
+		t.Run("should parse channels with default and declared severities", func(t *testing.T) {
+			channels, err := models.ParseAlertChannels("slack://#data-alerts, ,pagerduty://r0ut1ngk3y?severity=Critical")
+			assert.Nil(t, err)
+			assert.Equal(t, []models.AlertChannel{
+				{
+					Type:        "slack",
+					Route:       "#data-alerts",
+					MinSeverity: models.AlertSeverityWarning,
+				},
+				{
+					Type:        "pagerduty",
+					Route:       "r0ut1ngk3y",
+					MinSeverity: models.AlertSeverityCritical,
+				},
+			}, channels)
+		})
+		t.Run("should fail for channels without type or unknown severity", func(t *testing.T) {
+			_, err := models.ParseAlertChannels("#data-alerts")
+			assert.Equal(t, "invalid alert channel #data-alerts, expected <type>://<route>", err.Error())
+
+			_, err = models.ParseAlertChannels("slack://#data-alerts?severity=urgent")
+			assert.Equal(t, "invalid severity urgent of alert channel slack://#data-alerts?severity=urgent", err.Error())
+		})
+	})
+	t.Run("should combine channels of project and namespace", func(t *testing.T) {
+		namespace := models.NamespaceSpec{
+			Name: "game_jam",
+			Config: map[string]string{
+				models.AlertChannelsConfig: "slack://@game-devs?severity=info",
+			},
+			ProjectSpec: models.ProjectSpec{
+				Name: "a-data-project",
+				Config: map[string]string{
+					models.AlertChannelsConfig: "pagerduty://r0ut1ngk3y",
+				},
+			},
+		}
+		channels, err := namespace.AlertChannels()
+		assert.Nil(t, err)
+		assert.Equal(t, []models.AlertChannel{
+			{Type: "pagerduty", Route: "r0ut1ngk3y", MinSeverity: models.AlertSeverityWarning},
+			{Type: "slack", Route: "@game-devs", MinSeverity: models.AlertSeverityInfo},
+		}, channels)
+	})
+	t.Run("should rank event severities", func(t *testing.T) {
+		assert.True(t, models.JobEventSeverity(models.JobEventTypeFailure).AtLeast(models.AlertSeverityWarning))
+		assert.True(t, models.JobEventSeverity(models.JobEventTypeSLAMiss).AtLeast(models.AlertSeverityWarning))
+		assert.False(t, models.JobEventSeverity(models.JobEventTypeSLAMiss).AtLeast(models.AlertSeverityCritical))
+		assert.False(t, models.JobEventSeverity(models.JobEventTypeStart).AtLeast(models.AlertSeverityWarning))
+	})
+}
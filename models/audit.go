@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent records a request made to optimus which changes its state
+type AuditEvent struct {
+	ID uuid.UUID
+
+	// Method is the full rpc method requested, e.g.
+	// /odpf.optimus.RuntimeService/RegisterProject
+	Method string
+	// Identity is the caller as authenticated by the proxy in front of
+	// optimus, empty when the server isn't configured to trust one
+	Identity string
+	// UserAgent is the client which made the request as it reports itself,
+	// it can be set to anything by the client. Peer is the address the
+	// request came from
+	UserAgent string
+	Peer      string
+	RequestID string
+
+	ProjectName string
+	Namespace   string
+
+	// RequestDigest is sha256 of the serialized request, requests are not
+	// stored as they may carry secrets
+	RequestDigest string
+	// Code is the status code of the response, OK if the request succeeded
+	// and Message is the error sent otherwise
+	Code    string
+	Message string
+
+	CreatedAt time.Time
+}
+
+// AuditEventFilter narrows down audit events listed, empty fields match all
+type AuditEventFilter struct {
+	ProjectName string
+	Method      string
+	Identity    string
+	UserAgent   string
+
+	// events created within the dates, both inclusive
+	StartTime time.Time
+	EndTime   time.Time
+
+	// Offset number of events from the latest one are skipped and at most
+	// Limit events are returned
+	Offset int
+	Limit  int
+}
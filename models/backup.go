@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultBackupTTL is how long a backup is kept in datastore when no
+	// ttl is requested
+	DefaultBackupTTL = 30 * 24 * time.Hour
+)
+
+// BackupSpec is a snapshot taken of a resource, it points to the copy made
+// in datastore along with the spec the resource had when it was taken
+type BackupSpec struct {
+	ID       uuid.UUID
+	Resource ResourceSpec
+	// URN locates the copy in datastore, e.g. project.dataset.table
+	URN         string
+	Description string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+type BackupResourceRequest struct {
+	Resource ResourceSpec
+	Project  ProjectSpec
+	// ID is used by datastore to name the copy uniquely
+	ID  uuid.UUID
+	TTL time.Duration
+}
+
+type BackupResourceResponse struct {
+	URN string
+}
+
+type RestoreResourceRequest struct {
+	Resource ResourceSpec
+	Project  ProjectSpec
+	// URN of the copy made during backup
+	URN string
+}
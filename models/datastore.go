@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/odpf/optimus/core/progress"
 
@@ -11,9 +12,14 @@ import (
 )
 
 const (
-	ResourceTypeTable   ResourceType = "table"
-	ResourceTypeDataset ResourceType = "dataset"
-	ResourceTypeView    ResourceType = "view"
+	ResourceTypeTable         ResourceType = "table"
+	ResourceTypeDataset       ResourceType = "dataset"
+	ResourceTypeView          ResourceType = "view"
+	ResourceTypeExternalTable ResourceType = "external_table"
+	ResourceTypeSchema        ResourceType = "schema"
+	ResourceTypeTopic         ResourceType = "topic"
+	ResourceTypeBucket        ResourceType = "bucket"
+	ResourceTypePrefix        ResourceType = "prefix"
 )
 
 type ResourceType string
@@ -32,6 +38,10 @@ type ResourceSpec struct {
 	Spec   interface{}
 	Assets ResourceAssets
 	Labels map[string]string
+
+	// DependsOn are names of resources of the same datastore which should
+	// be applied before this resource
+	DependsOn []string
 }
 
 type ResourceAssets map[string]string
@@ -61,12 +71,34 @@ type Datastorer interface {
 
 	// DeleteResource will delete the requested resource if exists
 	DeleteResource(context.Context, DeleteResourceRequest) error
+
+	// RenameResource will move the requested resource to its new name, if the
+	// datastore can't rename in place, resource is copied and the old one deleted
+	// only when copying is allowed in request
+	RenameResource(context.Context, RenameResourceRequest) error
+
+	// BackupResource copies the requested resource along with its data, the
+	// copy expires after the requested ttl
+	BackupResource(context.Context, BackupResourceRequest) (BackupResourceResponse, error)
+
+	// RestoreResource overwrites the requested resource with a copy made
+	// during backup
+	RestoreResource(context.Context, RestoreResourceRequest) error
+
+	// CheckDatastore performs a lightweight authenticated call to verify
+	// datastore is reachable with the project credentials, failures should
+	// be returned as DatastoreError if they can be categorized
+	CheckDatastore(context.Context, CheckDatastoreRequest) error
 }
 
 type DatastoreTypeController interface {
 	Adapter() DatastoreSpecAdapter
 	Validator() DatastoreSpecValidator
 
+	// NameFormat tells how names of resources are written, used as a hint
+	// for clients, e.g. project_name.dataset_name
+	NameFormat() string
+
 	// assets that will be created as templates when the resource is created
 	// for the first time
 	DefaultAssets() map[string]string
@@ -94,6 +126,9 @@ type CreateResourceRequest struct {
 type UpdateResourceRequest struct {
 	Resource ResourceSpec
 	Project  ProjectSpec
+	// Force applies compatible changes of resource even when some of its
+	// changes are destructive, destructive changes are left unapplied
+	Force bool
 }
 
 type ResourceExistsRequest struct {
@@ -115,11 +150,60 @@ type DeleteResourceRequest struct {
 	Project  ProjectSpec
 }
 
+type RenameResourceRequest struct {
+	Resource    ResourceSpec
+	NewResource ResourceSpec
+	Project     ProjectSpec
+
+	// AllowCopy confirms resource can be copied to its new name and deleted
+	// afterwards, copying moves all the data of resource
+	AllowCopy bool
+}
+
+type CheckDatastoreRequest struct {
+	Project ProjectSpec
+}
+
+const (
+	DatastoreErrorCategoryAuth       DatastoreErrorCategory = "auth"
+	DatastoreErrorCategoryPermission DatastoreErrorCategory = "permission"
+	DatastoreErrorCategoryNetwork    DatastoreErrorCategory = "network"
+	DatastoreErrorCategoryUnknown    DatastoreErrorCategory = "unknown"
+)
+
+type DatastoreErrorCategory string
+
+// DatastoreError is a datastore failure with a known cause
+type DatastoreError struct {
+	Category DatastoreErrorCategory
+	Err      error
+}
+
+func (e *DatastoreError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Category, e.Err.Error())
+}
+
+func (e *DatastoreError) Unwrap() error {
+	return e.Err
+}
+
 var (
 	DatastoreRegistry = &supportedDatastore{
 		data: map[string]Datastorer{},
 	}
 	ErrUnsupportedDatastore = errors.New("unsupported datastore requested")
+
+	// ErrResourceExists is returned when a resource is being moved to a name
+	// already in use
+	ErrResourceExists = errors.New("resource already exists")
+
+	// ErrRenameRequiresCopy is returned when a resource can only be renamed
+	// by copying it and copying was not allowed
+	ErrRenameRequiresCopy = errors.New("resource can only be renamed by copying, copy needs to be allowed")
+
+	// ErrDestructiveChange is returned when an update of resource would drop
+	// or rewrite existing data and it was not forced
+	ErrDestructiveChange = errors.New("resource update has destructive changes, update needs to be forced")
 )
 
 type DatastoreRepo interface {
@@ -164,9 +248,30 @@ func (s *supportedDatastore) Add(newUnit Datastorer) error {
 type DatastoreService interface {
 	// does not really fetch resource metadata, just the user provided spec
 	GetAll(namespace NamespaceSpec, datastoreName string) ([]ResourceSpec, error)
+	// GetPage reads at most limit resource specs ordered by name, starting
+	// after the resource named after
+	GetPage(namespace NamespaceSpec, datastoreName, after string, limit int) ([]ResourceSpec, error)
 
 	CreateResource(ctx context.Context, namespace NamespaceSpec, resourceSpecs []ResourceSpec, obs progress.Observer) error
-	UpdateResource(ctx context.Context, namespace NamespaceSpec, resourceSpecs []ResourceSpec, obs progress.Observer) error
+	// UpdateResource creates or updates resources, force applies compatible changes
+	// of resources that also have destructive ones
+	UpdateResource(ctx context.Context, namespace NamespaceSpec, resourceSpecs []ResourceSpec, force bool, obs progress.Observer) error
+	// SaveResource stores specs of resources without applying them to the datastore,
+	// they are applied when the resources are next deployed
+	SaveResource(namespace NamespaceSpec, resourceSpecs []ResourceSpec) error
 	ReadResource(ctx context.Context, namespace NamespaceSpec, datastoreName, name string) (ResourceSpec, error)
-	DeleteResource(ctx context.Context, namespace NamespaceSpec, datastoreName, name string) error
+	// DeleteResource removes stored spec of resource, resource itself is deleted
+	// from datastore only if deleteFromDatastore is set
+	DeleteResource(ctx context.Context, namespace NamespaceSpec, datastoreName, name string, deleteFromDatastore bool) error
+	// RenameResource moves resource to a new name in datastore as well as its stored spec
+	RenameResource(ctx context.Context, namespace NamespaceSpec, datastoreName, oldName, newName string, allowCopy bool) error
+	// BackupResource snapshots resource in datastore and records the backup
+	BackupResource(ctx context.Context, namespace NamespaceSpec, datastoreName, name string, ttl time.Duration, description string) (BackupSpec, error)
+	// RestoreResource brings resource back to the state of a backup along with its stored spec
+	RestoreResource(ctx context.Context, namespace NamespaceSpec, datastoreName string, backupID uuid.UUID) (ResourceSpec, error)
+	// GetBackups lists backups of a datastore in namespace
+	GetBackups(namespace NamespaceSpec, datastoreName string) ([]BackupSpec, error)
+	// KeepOnly deletes all resources of a datastore in namespace except the ones provided
+	KeepOnly(ctx context.Context, namespace NamespaceSpec, datastoreName string, resourceSpecs []ResourceSpec, obs progress.Observer) error
+	CheckDatastore(ctx context.Context, project ProjectSpec, datastoreName string) error
 }
@@ -233,6 +233,14 @@ func (s *supportedHooks) Add(newUnit HookPlugin) error {
 		return errors.New("hook image cannot be empty")
 	}
 
+	// type decides where hook is placed around the task
+	switch schema.Type {
+	case HookTypePre, HookTypePost, HookTypeFail:
+	default:
+		return errors.Errorf("hook %s has invalid type %s, expected one of %s, %s, %s", schema.Name, schema.Type,
+			HookTypePre, HookTypePost, HookTypeFail)
+	}
+
 	s.data[schema.Name] = newUnit
 	return nil
 }
@@ -0,0 +1,37 @@
+package models_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+)
+
+func TestHookRegistry(t *testing.T) {
+	t.Run("should add hooks of known types", func(t *testing.T) {
+		hookUnit := new(mock.HookPlugin)
+		hookUnit.On("GetHookSchema", context.TODO(), models.GetHookSchemaRequest{}).Return(models.GetHookSchemaResponse{
+			Name:  "test-registry-transporter",
+			Image: "example.io/transporter",
+			Type:  models.HookTypePost,
+		}, nil)
+		assert.Nil(t, models.HookRegistry.Add(hookUnit))
+
+		registered, err := models.HookRegistry.GetByName("test-registry-transporter")
+		assert.Nil(t, err)
+		assert.Equal(t, hookUnit, registered)
+	})
+	t.Run("should fail to add hooks of unknown types", func(t *testing.T) {
+		hookUnit := new(mock.HookPlugin)
+		hookUnit.On("GetHookSchema", context.TODO(), models.GetHookSchemaRequest{}).Return(models.GetHookSchemaResponse{
+			Name:  "test-registry-predator",
+			Image: "example.io/predator",
+			Type:  "during",
+		}, nil)
+		assert.EqualError(t, models.HookRegistry.Add(hookUnit),
+			"hook test-registry-predator has invalid type during, expected one of pre, post, fail")
+	})
+}
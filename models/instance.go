@@ -1,7 +1,11 @@
 package models
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -76,6 +80,7 @@ func (j *InstanceSpec) DataToJSON() ([]byte, error) {
 
 type InstanceService interface {
 	Register(jobSpec JobSpec, scheduledAt time.Time, taskType InstanceType) (InstanceSpec, error)
+	Get(jobSpec JobSpec, scheduledAt time.Time) (InstanceSpec, error)
 	Compile(namespaceSpec NamespaceSpec, jobSpec JobSpec, instanceSpec InstanceSpec,
 		runType InstanceType, runName string) (envMap map[string]string, fileMap map[string]string, err error)
 }
@@ -85,3 +90,61 @@ type TemplateEngine interface {
 	CompileFiles(files map[string]string, context map[string]interface{}) (map[string]string, error)
 	CompileString(input string, context map[string]interface{}) (string, error)
 }
+
+// AssetLoader fetches content of a job asset stored outside of the job
+// specification, e.g. a shared sql file in a bucket
+type AssetLoader interface {
+	Load(ctx context.Context, uri string) ([]byte, error)
+}
+
+// AssetLoaderFactory gives the loader of remote assets of a project, which
+// fetches them with credentials of the project
+type AssetLoaderFactory interface {
+	New(proj ProjectSpec) AssetLoader
+}
+
+var templateErrLocation = regexp.MustCompile(`(?s)^template: ([^:]+):(\d+):(?:(\d+):)? (.*)$`)
+
+// TemplateError points to the location in a template which failed to compile
+type TemplateError struct {
+	Name   string
+	Line   int
+	Column int
+	Err    error
+}
+
+// Location of error in form of name:line[:column]
+func (e *TemplateError) Location() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d", e.Name, e.Line, e.Column)
+	}
+	return fmt.Sprintf("%s:%d", e.Name, e.Line)
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Location(), e.Err.Error())
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// NewTemplateError extracts the failure location from text/template errors,
+// errors without a location are returned as it is
+func NewTemplateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	match := templateErrLocation.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	line, _ := strconv.Atoi(match[2])
+	column, _ := strconv.Atoi(match[3])
+	return &TemplateError{
+		Name:   match[1],
+		Line:   line,
+		Column: column,
+		Err:    errors.New(match[4]),
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -38,9 +42,36 @@ const (
 	JobSpecDependencyTypeInter JobSpecDependencyType = "inter"
 	// outside optimus
 	JobSpecDependencyTypeExtra JobSpecDependencyType = "extra"
+	// an http endpoint outside optimus, see HTTPDependency
+	JobSpecDependencyTypeHTTP JobSpecDependencyType = "http"
 
 	JobEventTypeSLAMiss JobEventType = "sla_miss"
 	JobEventTypeFailure JobEventType = "failure"
+	JobEventTypeSuccess JobEventType = "success"
+	JobEventTypeStart   JobEventType = "start"
+	// JobEventTypeDeployFailure is raised by optimus itself when deploying
+	// jobs of a namespace fails, it is only sent to alert channels
+	JobEventTypeDeployFailure JobEventType = "deploy_failure"
+
+	// ProjectConfigTemplateKey is used in task and hook config templates to
+	// refer project configs, e.g. {{.proj.STAGING_DATASET}}
+	ProjectConfigTemplateKey = "proj"
+
+	// GlobalConfigTemplateKey is used in task and hook config templates to
+	// refer configs shared by all projects, e.g. {{.global.KAFKA_BROKERS}}
+	GlobalConfigTemplateKey = "global"
+
+	// SecretTemplateKey is used in task and hook config templates and assets
+	// to refer project secrets, e.g. {{.secret.BQ_SERVICE_ACCOUNT}}. Secrets
+	// are resolved only when an instance of the job is registered
+	SecretTemplateKey = "secret"
+)
+
+var (
+	templateActionRegex   = regexp.MustCompile(`\{\{.*?\}\}`)
+	projectConfigRefRegex = regexp.MustCompile(`\.` + ProjectConfigTemplateKey + `\.(\w+)`)
+	globalConfigRefRegex  = regexp.MustCompile(`\.` + GlobalConfigTemplateKey + `\.(\w+)`)
+	secretRefRegex        = regexp.MustCompile(`\.` + SecretTemplateKey + `\.(\w+)`)
 )
 
 // JobSpec represents a job
@@ -58,6 +89,10 @@ type JobSpec struct {
 	Dependencies map[string]JobSpecDependency // job name to dependency
 	Assets       JobAssets
 	Hooks        []JobSpecHook
+
+	// ExternalDependencies are waited for before running the task along
+	// with Dependencies
+	ExternalDependencies ExternalDependency
 }
 
 func (js JobSpec) GetName() string {
@@ -77,6 +112,64 @@ func (js JobSpec) GetHookByName(name string) (JobSpecHook, error) {
 	return JobSpecHook{}, ErrNoSuchHook
 }
 
+// ProjectConfigReferences returns the sorted project config keys referred
+// in task and hook config templates of the job
+func (js JobSpec) ProjectConfigReferences() []string {
+	return ProjectConfigReferencesIn(js.configValues()...)
+}
+
+// GlobalConfigReferences returns the sorted global config keys referred in
+// task and hook config templates of the job
+func (js JobSpec) GlobalConfigReferences() []string {
+	return templateReferencesIn(globalConfigRefRegex, js.configValues())
+}
+
+// SecretReferences returns the sorted project secret names referred in
+// task and hook config templates and assets of the job
+func (js JobSpec) SecretReferences() []string {
+	values := js.configValues()
+	for _, asset := range js.Assets.GetAll() {
+		values = append(values, asset.Value)
+	}
+	return templateReferencesIn(secretRefRegex, values)
+}
+
+func (js JobSpec) configValues() []string {
+	var values []string
+	for _, config := range js.Task.Config {
+		values = append(values, config.Value)
+	}
+	for _, hook := range js.Hooks {
+		for _, config := range hook.Config {
+			values = append(values, config.Value)
+		}
+	}
+	return values
+}
+
+// ProjectConfigReferencesIn returns the sorted project config keys referred
+// in templates of the values, e.g. ENV for "{{.proj.ENV}}"
+func ProjectConfigReferencesIn(values ...string) []string {
+	return templateReferencesIn(projectConfigRefRegex, values)
+}
+
+func templateReferencesIn(refRegex *regexp.Regexp, values []string) []string {
+	keys := map[string]bool{}
+	for _, value := range values {
+		for _, action := range templateActionRegex.FindAllString(value, -1) {
+			for _, match := range refRegex.FindAllStringSubmatch(action, -1) {
+				keys[match[1]] = true
+			}
+		}
+	}
+	refs := []string{}
+	for key := range keys {
+		refs = append(refs, key)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
 func (js JobSpec) GetLabelsAsString() string {
 	labels := ""
 	for k, v := range js.Labels {
@@ -89,6 +182,22 @@ type JobSpecSchedule struct {
 	StartDate time.Time
 	EndDate   *time.Time
 	Interval  string
+
+	// Timezone is the IANA name of the zone interval is evaluated in and
+	// windows of runs are aligned to, UTC if empty
+	Timezone string
+}
+
+// Location of the schedule timezone
+func (s JobSpecSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule timezone %s: %w", s.Timezone, err)
+	}
+	return loc, nil
 }
 
 type JobSpecBehavior struct {
@@ -96,6 +205,7 @@ type JobSpecBehavior struct {
 	CatchUp       bool
 	Retry         JobSpecBehaviorRetry
 	Notify        []JobSpecNotifier
+	SLA           JobSpecSLA
 }
 
 type JobSpecBehaviorRetry struct {
@@ -104,12 +214,57 @@ type JobSpecBehaviorRetry struct {
 	ExponentialBackoff bool
 }
 
+// Validate checks retry count and delay are not negative
+func (r JobSpecBehaviorRetry) Validate() error {
+	if r.Count < 0 {
+		return fmt.Errorf("retry count cannot be negative: %d", r.Count)
+	}
+	if r.Delay < 0 {
+		return fmt.Errorf("retry delay cannot be negative: %s", r.Delay)
+	}
+	return nil
+}
+
+// JobSpecSLA is the time within which a run of job is expected to finish
+// once it is scheduled, zero duration means job has no sla
+type JobSpecSLA struct {
+	Duration time.Duration
+}
+
+// Validate checks sla duration is not negative
+func (s JobSpecSLA) Validate() error {
+	if s.Duration < 0 {
+		return fmt.Errorf("sla duration cannot be negative: %s", s.Duration)
+	}
+	return nil
+}
+
 type JobSpecNotifier struct {
 	On       JobEventType
 	Config   map[string]string
 	Channels []string
 }
 
+// Validate checks channels of notifier are in the format <type>://<route>
+// and sla miss duration, when set in config, is a valid duration
+func (n JobSpecNotifier) Validate() error {
+	if n.On == "" {
+		return fmt.Errorf("notifier event type cannot be empty")
+	}
+	for _, channel := range n.Channels {
+		parts := strings.SplitN(channel, "://", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid notify channel %s on %s, expected <type>://<route>", channel, n.On)
+		}
+	}
+	if duration, ok := n.Config["duration"]; ok && n.On == JobEventTypeSLAMiss {
+		if d, err := time.ParseDuration(duration); err != nil || d < 0 {
+			return fmt.Errorf("invalid sla_miss duration %s", duration)
+		}
+	}
+	return nil
+}
+
 type JobSpecTask struct {
 	Unit     TaskPlugin
 	Config   JobSpecConfigs
@@ -140,6 +295,9 @@ type JobSpecTaskWindow struct {
 	TruncateTo string
 }
 
+// GetStart of the window of a run, truncation is done in the location of
+// scheduledAt, i.e. day windows of a run scheduled in Asia/Jakarta start at
+// midnight of Asia/Jakarta
 func (w *JobSpecTaskWindow) GetStart(scheduledAt time.Time) time.Time {
 	s, _ := w.getWindowDate(scheduledAt, w.Size, w.Offset, w.TruncateTo)
 	return s
@@ -152,19 +310,19 @@ func (w *JobSpecTaskWindow) GetEnd(scheduledAt time.Time) time.Time {
 
 func (w *JobSpecTaskWindow) getWindowDate(today time.Time, windowSize, windowOffset time.Duration, windowTruncateTo string) (time.Time, time.Time) {
 	floatingEnd := today
+	loc := today.Location()
 
 	// apply truncation to end
 	if windowTruncateTo == "h" {
 		// remove time upto hours
-		floatingEnd = floatingEnd.Truncate(time.Hour)
+		floatingEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), floatingEnd.Day(), floatingEnd.Hour(), 0, 0, 0, loc)
 	} else if windowTruncateTo == "d" {
 		// remove time upto day
-		floatingEnd = floatingEnd.Truncate(24 * time.Hour)
+		floatingEnd = truncateToDay(floatingEnd)
 	} else if windowTruncateTo == "w" {
 		// shift current window to nearest Sunday
-		nearestSunday := time.Duration(time.Saturday-floatingEnd.Weekday()+1) * 24 * time.Hour
-		floatingEnd = floatingEnd.Add(nearestSunday)
-		floatingEnd = floatingEnd.Truncate(24 * time.Hour)
+		nearestSunday := int(time.Saturday - floatingEnd.Weekday() + 1)
+		floatingEnd = truncateToDay(floatingEnd.AddDate(0, 0, nearestSunday))
 	}
 
 	windowEnd := floatingEnd.Add(windowOffset)
@@ -176,7 +334,7 @@ func (w *JobSpecTaskWindow) getWindowDate(today time.Time, windowSize, windowOff
 		// shift current window to nearest month start and end
 
 		// truncate the date
-		floatingEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), 1, 0, 0, 0, 0, time.UTC)
+		floatingEnd = time.Date(floatingEnd.Year(), floatingEnd.Month(), 1, 0, 0, 0, 0, loc)
 
 		// then add the month offset
 		// for handling offset, treat 30 days as 1 month
@@ -187,10 +345,10 @@ func (w *JobSpecTaskWindow) getWindowDate(today time.Time, windowSize, windowOff
 		floatingEnd = floatingEnd.AddDate(0, 1, -1)
 
 		// final end is computed
-		windowEnd = floatingEnd.Truncate(time.Hour * 24)
+		windowEnd = truncateToDay(floatingEnd)
 
 		// truncate days/hours from window start as well
-		floatingStart := time.Date(floatingEnd.Year(), floatingEnd.Month(), 1, 0, 0, 0, 0, time.UTC)
+		floatingStart := time.Date(floatingEnd.Year(), floatingEnd.Month(), 1, 0, 0, 0, 0, loc)
 		// for handling size, treat 30 days as 1 month, and as we have already truncated current month
 		// subtract 1 from this
 		sizeMonths := (windowSize / HoursInMonth) - 1
@@ -205,6 +363,11 @@ func (w *JobSpecTaskWindow) getWindowDate(today time.Time, windowSize, windowOff
 	return windowStart, windowEnd
 }
 
+// truncateToDay removes time of the day in location of t
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
 type JobSpecHook struct {
 	Config    JobSpecConfigs
 	Unit      HookPlugin
@@ -297,18 +460,138 @@ type JobSpecDependency struct {
 	Type    JobSpecDependencyType
 }
 
+// ExternalDependency are dependencies of a job outside optimus
+type ExternalDependency struct {
+	HTTPDependencies []HTTPDependency
+}
+
+// HTTPDependency is met once a request to URL responds with
+// ExpectedStatus and a body containing ExpectedBody
+type HTTPDependency struct {
+	Name    string
+	URL     string
+	Method  string
+	Headers map[string]string
+
+	ExpectedStatus int
+	ExpectedBody   string
+}
+
+// WithDefaults fills method and expected status if they are not set
+func (d HTTPDependency) WithDefaults() HTTPDependency {
+	if d.Method == "" {
+		d.Method = http.MethodGet
+	}
+	d.Method = strings.ToUpper(d.Method)
+	if d.ExpectedStatus == 0 {
+		d.ExpectedStatus = http.StatusOK
+	}
+	return d
+}
+
+// Validate checks the dependency is named and polls a valid http url
+func (d HTTPDependency) Validate() error {
+	if d.Name == "" {
+		return errors.New("http dependency name cannot be empty")
+	}
+	parsed, err := url.Parse(d.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("http dependency %s has an invalid url: %s", d.Name, d.URL)
+	}
+	if d.ExpectedStatus < 100 || d.ExpectedStatus > 599 {
+		return fmt.Errorf("http dependency %s has an invalid expected status: %d", d.Name, d.ExpectedStatus)
+	}
+	return nil
+}
+
+// Validate checks every dependency is valid and uniquely named
+func (d ExternalDependency) Validate() error {
+	names := map[string]bool{}
+	for _, dep := range d.HTTPDependencies {
+		if err := dep.Validate(); err != nil {
+			return err
+		}
+		if names[dep.Name] {
+			return fmt.Errorf("http dependency %s is defined more than once", dep.Name)
+		}
+		names[dep.Name] = true
+	}
+	return nil
+}
+
+// ProjectJobPair is a job spec along with the project it belongs to
+type ProjectJobPair struct {
+	Project ProjectSpec
+	Job     JobSpec
+}
+
+// DependencyExplanation tells how a destination used by a job got
+// resolved to the job producing it
+type DependencyExplanation struct {
+	Destination string
+	// Resolved is false if none of the candidates was picked as dependency
+	Resolved   bool
+	Candidates []DependencyCandidate
+}
+
+// DependencyCandidate is a job considered while resolving a destination
+// and the reason why it was picked or not
+type DependencyCandidate struct {
+	Job     string
+	Project string
+	Matched bool
+	Reason  string
+}
+
+// JobDependencyGraph is the resolved dependency graph between jobs
+type JobDependencyGraph struct {
+	Jobs  []JobDependencyGraphNode
+	Edges []JobDependencyGraphEdge
+}
+
+// JobDependencyGraphNode is a job of the graph, jobs of other projects
+// appear in the graph if a job depends on them
+type JobDependencyGraphNode struct {
+	Name    string
+	Project string
+}
+
+// JobDependencyGraphEdge connects a job to one of the jobs it depends on
+type JobDependencyGraphEdge struct {
+	Upstream   JobDependencyGraphNode
+	Downstream JobDependencyGraphNode
+	Type       JobSpecDependencyType
+	// Inferred is true if the dependency is generated from destinations
+	// used by the task instead of being defined in the job spec
+	Inferred bool
+}
+
+// JobSpecRevision is a saved version of a job spec, a revision is added
+// every time the content of a saved spec changes
+type JobSpecRevision struct {
+	Revision  int
+	CreatedAt time.Time
+	Spec      JobSpec
+}
+
 // JobService provides a high-level operations on DAGs
 type JobService interface {
 	// Create constructs a Job and commits it to a storage
 	Create(NamespaceSpec, JobSpec) error
+	// Validate checks a Job the way Create does without storing it
+	Validate(NamespaceSpec, JobSpec) error
 	// GetByName fetches a Job by name for a specific namespace
 	GetByName(string, NamespaceSpec) (JobSpec, error)
 	// Dump returns the compiled Job
 	Dump(NamespaceSpec, JobSpec) (Job, error)
-	// KeepOnly deletes all jobs except the ones provided for a namespace
-	KeepOnly(NamespaceSpec, []JobSpec, progress.Observer) error
+	// KeepOnly deletes all jobs except the ones provided for a namespace, jobs
+	// still depended upon are only deleted when forced
+	KeepOnly(namespace NamespaceSpec, specsToKeep []JobSpec, force bool, obs progress.Observer) error
 	// GetAll reads all job specifications of the given namespace
 	GetAll(NamespaceSpec) ([]JobSpec, error)
+	// GetPage reads at most limit job specifications of the namespace
+	// ordered by name, starting after the job named after
+	GetPage(namespace NamespaceSpec, after string, limit int) ([]JobSpec, error)
 	// Delete deletes a job spec from all repos
 	Delete(context.Context, NamespaceSpec, JobSpec) error
 
@@ -316,7 +599,29 @@ type JobService interface {
 	// GetByNameForProject fetches a Job by name for a specific project
 	GetByNameForProject(string, ProjectSpec) (JobSpec, NamespaceSpec, error)
 	Sync(context.Context, NamespaceSpec, progress.Observer) error
+	// SyncJob deploys a single job of the namespace without touching the
+	// other jobs
+	SyncJob(context.Context, NamespaceSpec, string, progress.Observer) error
 	Check(NamespaceSpec, []JobSpec, progress.Observer) error
+	// GetDownstream returns jobs of a project that depend on the provided job,
+	// including indirect dependents if transitive is true
+	GetDownstream(ProjectSpec, string, bool) ([]JobSpec, error)
+	// ExplainDependency tells how destinations used by a job were resolved
+	// to the jobs producing them
+	ExplainDependency(ProjectSpec, JobSpec) ([]DependencyExplanation, error)
+	// UnknownDependencies returns destinations used by the jobs, by job name,
+	// which neither a saved job of the project nor one of the jobs produces
+	UnknownDependencies(ProjectSpec, []JobSpec) (map[string][]string, error)
+	// GetDependencyGraph returns the resolved dependency graph of a project,
+	// limited to the upstream and downstream of jobName if it is not empty
+	GetDependencyGraph(projectSpec ProjectSpec, jobName string) (JobDependencyGraph, error)
+	// GetHistory returns all saved revisions of a job, latest first
+	GetHistory(namespace NamespaceSpec, jobName string) ([]JobSpecRevision, error)
+	// Rollback saves the spec of an earlier revision of a job as its latest
+	// revision and deploys it
+	Rollback(ctx context.Context, namespace NamespaceSpec, jobName string, revision int, obs progress.Observer) (JobSpec, error)
+	// ResolveDependencies returns the job with its dependencies resolved
+	ResolveDependencies(ProjectSpec, JobSpec) (JobSpec, error)
 	// ReplayDryRun returns the execution tree of jobSpec and its dependencies between start and endDate
 	ReplayDryRun(*ReplayWorkerRequest) (*tree.TreeNode, error)
 	// Replay replays the jobSpec and its dependencies between start and endDate
@@ -344,6 +649,10 @@ type JobEventType string
 type JobEvent struct {
 	Type  JobEventType
 	Value map[string]*structpb.Value
+
+	// CreatedAt is when the event was registered, only set for events read
+	// back from store
+	CreatedAt time.Time
 }
 
 type NotifyAttrs struct {
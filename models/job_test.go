@@ -145,5 +145,88 @@ func TestJob(t *testing.T) {
 				assert.Equal(t, tcase.ExpectedEnd, windowEnd)
 			}
 		})
+		t.Run("should truncate window in location of scheduled time", func(t *testing.T) {
+			jakarta, err := time.LoadLocation("Asia/Jakarta")
+			assert.Nil(t, err)
+			win := &models.JobSpecTaskWindow{
+				Size:       24 * time.Hour,
+				Offset:     0,
+				TruncateTo: "d",
+			}
+			// daily at 02:00 Asia/Jakarta is 19:00 UTC of previous day
+			scheduledAt := time.Date(2021, 2, 24, 19, 0, 0, 0, time.UTC).In(jakarta)
+			assert.True(t, win.GetStart(scheduledAt).Equal(time.Date(2021, 2, 24, 0, 0, 0, 0, jakarta)))
+			assert.True(t, win.GetEnd(scheduledAt).Equal(time.Date(2021, 2, 25, 0, 0, 0, 0, jakarta)))
+
+			win.TruncateTo = "M"
+			win.Size = 24 * 30 * time.Hour
+			scheduledAt = time.Date(2021, 2, 28, 19, 0, 0, 0, time.UTC).In(jakarta)
+			assert.True(t, win.GetStart(scheduledAt).Equal(time.Date(2021, 3, 1, 0, 0, 0, 0, jakarta)))
+			assert.True(t, win.GetEnd(scheduledAt).Equal(time.Date(2021, 3, 31, 0, 0, 0, 0, jakarta)))
+		})
+	})
+	t.Run("JobSpecSchedule", func(t *testing.T) {
+		t.Run("should return location of timezone and utc if not set", func(t *testing.T) {
+			loc, err := models.JobSpecSchedule{}.Location()
+			assert.Nil(t, err)
+			assert.Equal(t, time.UTC, loc)
+
+			loc, err = models.JobSpecSchedule{Timezone: "Asia/Jakarta"}.Location()
+			assert.Nil(t, err)
+			assert.Equal(t, "Asia/Jakarta", loc.String())
+		})
+		t.Run("should fail for unknown timezone", func(t *testing.T) {
+			_, err := models.JobSpecSchedule{Timezone: "Mars/Olympus"}.Location()
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("JobSpecNotifier", func(t *testing.T) {
+		t.Run("should accept channels with type and route", func(t *testing.T) {
+			notifier := models.JobSpecNotifier{
+				On:       models.JobEventTypeSLAMiss,
+				Config:   map[string]string{"duration": "2h"},
+				Channels: []string{"slack://#data-alerts", "pagerduty://r0ut1ngk3y"},
+			}
+			assert.Nil(t, notifier.Validate())
+		})
+		t.Run("should fail for channels without type or invalid sla miss duration", func(t *testing.T) {
+			err := models.JobSpecNotifier{
+				On:       models.JobEventTypeFailure,
+				Channels: []string{"slack://"},
+			}.Validate()
+			assert.Equal(t, "invalid notify channel slack:// on failure, expected <type>://<route>", err.Error())
+
+			err = models.JobSpecNotifier{
+				On:     models.JobEventTypeSLAMiss,
+				Config: map[string]string{"duration": "two hours"},
+			}.Validate()
+			assert.Equal(t, "invalid sla_miss duration two hours", err.Error())
+		})
+	})
+	t.Run("ExternalDependency", func(t *testing.T) {
+		t.Run("should fill default method and expected status of http dependency", func(t *testing.T) {
+			dep := models.HTTPDependency{Name: "api", URL: "https://api.example.io", Method: "post"}.WithDefaults()
+			assert.Equal(t, "POST", dep.Method)
+			assert.Equal(t, 200, dep.ExpectedStatus)
+			assert.Nil(t, dep.Validate())
+		})
+		t.Run("should fail if http dependency is invalid", func(t *testing.T) {
+			cases := map[string]models.HTTPDependency{
+				"http dependency name cannot be empty": {URL: "https://api.example.io", ExpectedStatus: 200},
+				"http dependency api has an invalid url: api.example.io": {Name: "api", URL: "api.example.io",
+					ExpectedStatus: 200},
+				"http dependency api has an invalid expected status: 42": {Name: "api", URL: "https://api.example.io",
+					ExpectedStatus: 42},
+			}
+			for expected, dep := range cases {
+				err := models.ExternalDependency{HTTPDependencies: []models.HTTPDependency{dep}}.Validate()
+				assert.EqualError(t, err, expected)
+			}
+		})
+		t.Run("should fail if http dependencies are not uniquely named", func(t *testing.T) {
+			dep := models.HTTPDependency{Name: "api", URL: "https://api.example.io"}.WithDefaults()
+			err := models.ExternalDependency{HTTPDependencies: []models.HTTPDependency{dep, dep}}.Validate()
+			assert.EqualError(t, err, "http dependency api is defined more than once")
+		})
 	})
 }
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LabelRequirement is a single condition of a LabelSelector
+type LabelRequirement struct {
+	Key   string
+	Value string
+	// Exists only checks the label is set, value is ignored
+	Exists bool
+	// NotEqual matches labels with a different value, or not set at all
+	NotEqual bool
+}
+
+func (r LabelRequirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch {
+	case r.Exists:
+		return ok
+	case r.NotEqual:
+		return !ok || value != r.Value
+	default:
+		return ok && value == r.Value
+	}
+}
+
+// LabelSelector picks jobs having labels matching all of its requirements
+type LabelSelector []LabelRequirement
+
+// ParseLabelSelector reads a comma separated selector where each requirement
+// is either key=value, key!=value or just key, e.g. team=growth,tier!=low
+func ParseLabelSelector(selector string) (LabelSelector, error) {
+	var requirements LabelSelector
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var requirement LabelRequirement
+		if idx := strings.Index(part, "!="); idx >= 0 {
+			requirement = LabelRequirement{Key: part[:idx], Value: part[idx+2:], NotEqual: true}
+		} else if idx := strings.Index(part, "="); idx >= 0 {
+			requirement = LabelRequirement{Key: part[:idx], Value: part[idx+1:]}
+		} else {
+			requirement = LabelRequirement{Key: part, Exists: true}
+		}
+		requirement.Key = strings.TrimSpace(requirement.Key)
+		requirement.Value = strings.TrimSpace(requirement.Value)
+		if requirement.Key == "" {
+			return nil, fmt.Errorf("invalid label selector %q: label key cannot be empty", part)
+		}
+		if strings.ContainsAny(requirement.Value, "=!") {
+			return nil, fmt.Errorf("invalid label selector %q: value cannot contain = or !", part)
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// Matches is true if labels satisfy every requirement, an empty selector
+// matches everything
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		if !requirement.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestLabelSelector(t *testing.T) {
+	t.Run("ParseLabelSelector", func(t *testing.T) {
+		t.Run("should parse equality, inequality and existence requirements", func(t *testing.T) {
+			selector, err := models.ParseLabelSelector("team=growth, tier!=low,critical")
+			assert.Nil(t, err)
+			assert.Equal(t, models.LabelSelector{
+				{Key: "team", Value: "growth"},
+				{Key: "tier", Value: "low", NotEqual: true},
+				{Key: "critical", Exists: true},
+			}, selector)
+		})
+		t.Run("should return an empty selector for an empty string", func(t *testing.T) {
+			selector, err := models.ParseLabelSelector("")
+			assert.Nil(t, err)
+			assert.Empty(t, selector)
+		})
+		t.Run("should fail for requirements without a key", func(t *testing.T) {
+			_, err := models.ParseLabelSelector("team=growth,=critical")
+			assert.NotNil(t, err)
+		})
+		t.Run("should fail for malformed values", func(t *testing.T) {
+			_, err := models.ParseLabelSelector("team==growth")
+			assert.NotNil(t, err)
+		})
+	})
+	t.Run("Matches", func(t *testing.T) {
+		labels := map[string]string{"team": "growth", "tier": "critical"}
+		cases := map[string]bool{
+			"":                          true,
+			"team=growth":               true,
+			"team=growth,tier=critical": true,
+			"team=growth,tier=low":      false,
+			"tier!=low":                 true,
+			"owner!=someone":            true,
+			"team":                      true,
+			"owner":                     false,
+		}
+		for selectorString, expected := range cases {
+			selector, err := models.ParseLabelSelector(selectorString)
+			assert.Nil(t, err)
+			assert.Equal(t, expected, selector.Matches(labels), selectorString)
+		}
+	})
+}
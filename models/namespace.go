@@ -14,3 +14,16 @@ type NamespaceSpec struct {
 	// ProjectSpec is the project that this namespace belongs to
 	ProjectSpec ProjectSpec
 }
+
+// ResolvedConfig returns configs available to jobs of the namespace, configs
+// of the namespace take precedence over configs of its project which take
+// precedence over the global configs
+func (n NamespaceSpec) ResolvedConfig(global map[string]string) map[string]string {
+	configs := map[string]string{}
+	for _, source := range []map[string]string{global, n.ProjectSpec.Config, n.Config} {
+		for key, val := range source {
+			configs[key] = val
+		}
+	}
+	return configs
+}
@@ -15,12 +15,25 @@ const (
 	ProjectStoragePathKey = "STORAGE_PATH"
 	ProjectSchedulerHost  = "SCHEDULER_HOST"
 
+	// Template used to place compiled jobs inside ProjectStoragePathKey, can
+	// refer {{.project}}, {{.namespace}} and {{.job}}
+	// e.g. {{.project}}/dags/{{.namespace}}/{{.job}}.py
+	ProjectStoragePathTemplateKey = "STORAGE_PATH_TEMPLATE"
+
 	// Secret used for uploading prepared scheduler specifications to cloud
 	// e.g. for gcs it will be base64 encoded service account for the bucket
 	ProjectSecretStorageKey = "STORAGE"
 
 	// Secret used to authenticate with scheduler provided at ProjectSchedulerHost
 	ProjectSchedulerAuth = "SCHEDULER_AUTH"
+
+	// Comma separated endpoints every job event of the project is posted to,
+	// e.g. https://alerts.example.io/optimus,https://audit.example.io/events
+	ProjectJobEventWebhooks = "JOB_EVENT_WEBHOOKS"
+
+	// Secret executors of the project authenticate with while registering
+	// instances, secrets referred by jobs are only resolved for them
+	ProjectSecretExecutorTokenKey = "EXECUTOR_TOKEN"
 )
 
 var (
@@ -41,8 +54,14 @@ type ProjectSpec struct {
 	// suggested are gcs/s3 or similar object store
 	// - ProjectSchedulerHost: host url to connect with the scheduler used by
 	// the tenant
+	// - ProjectStoragePathTemplateKey: optional layout of compiled jobs
+	// inside the specification store
 	Config map[string]string
 
+	// Scheduler used to run jobs of this project, if type is not provided
+	// server default scheduler is used
+	Scheduler ProjectSchedulerConfig
+
 	// Secret contains key value pair for project level credentials and gets
 	// shared with plugins(task/hook) for execution.
 	// Few credentials are mandatory to be defined like ProjectSecretStorageKey
@@ -57,6 +76,75 @@ func (s ProjectSpec) String() string {
 	return fmt.Sprintf("%s, %v", s.Name, s.Config)
 }
 
+// SchedulerHost returns host of the scheduler used by the project, typed
+// scheduler config takes precedence over ProjectSchedulerHost in config
+func (s ProjectSpec) SchedulerHost() (string, bool) {
+	if s.Scheduler.Host != "" {
+		return s.Scheduler.Host, true
+	}
+	host, ok := s.Config[ProjectSchedulerHost]
+	return host, ok
+}
+
+// MissingRequiredConfigs returns the configs required to deploy jobs of the
+// project but are not set, scheduler host can be set in typed scheduler config
+// and storage path is not needed by schedulers storing compiled jobs
+func (s ProjectSpec) MissingRequiredConfigs() []string {
+	var missing []string
+	if strings.TrimSpace(s.Config[ProjectStoragePathKey]) == "" && !s.schedulerStoresJobs() {
+		missing = append(missing, ProjectStoragePathKey)
+	}
+	if host, _ := s.SchedulerHost(); strings.TrimSpace(host) == "" {
+		missing = append(missing, ProjectSchedulerHost)
+	}
+	return missing
+}
+
+// schedulerStoresJobs is true if the scheduler of project is a
+// JobStoringScheduler storing compiled jobs
+func (s ProjectSpec) schedulerStoresJobs() bool {
+	scheduler := Scheduler
+	if s.Scheduler.Type != "" {
+		scheduler, _ = SchedulerRegistry.GetByName(s.Scheduler.Type)
+	}
+	storing, ok := scheduler.(JobStoringScheduler)
+	return ok && storing.StoresCompiledJobs()
+}
+
+// SchedulerAuthSecret returns name of the project secret used to
+// authenticate with the scheduler
+func (s ProjectSpec) SchedulerAuthSecret() string {
+	if s.Scheduler.AuthSecret != "" {
+		return s.Scheduler.AuthSecret
+	}
+	return ProjectSchedulerAuth
+}
+
+// JobEventWebhooks returns the endpoints configured at
+// ProjectJobEventWebhooks
+func (s ProjectSpec) JobEventWebhooks() []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(s.Config[ProjectJobEventWebhooks], ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
+// ProjectSchedulerConfig is the scheduler a project is targeting
+type ProjectSchedulerConfig struct {
+	// Type of scheduler registered in SchedulerRegistry, e.g. airflow2
+	Type string
+
+	// Host url to connect with the scheduler
+	Host string
+
+	// AuthSecret is the name of project secret used to authenticate
+	// with the scheduler
+	AuthSecret string
+}
+
 type ProjectSecrets []ProjectSecretItem
 
 func (s ProjectSecrets) String() string {
@@ -72,6 +160,15 @@ func (s ProjectSecrets) GetByName(name string) (string, bool) {
 	return "", false
 }
 
+// ToMap of secret name to its value
+func (s ProjectSecrets) ToMap() map[string]string {
+	secrets := map[string]string{}
+	for _, v := range s {
+		secrets[v.Name] = v.Value
+	}
+	return secrets
+}
+
 type ProjectSecretItem struct {
 	ID uuid.UUID
 
@@ -81,19 +178,46 @@ type ProjectSecretItem struct {
 
 type ApplicationKey struct {
 	key *[32]byte
+
+	// previous keys are only used to read secrets encrypted before
+	// the key was rotated
+	previous []*[32]byte
 }
 
-func NewApplicationSecret(k string) (ApplicationKey, error) {
+// NewApplicationSecret creates the key used to encrypt secrets, previous
+// keys are accepted while secrets are being rotated to the new key
+func NewApplicationSecret(k string, previous ...string) (ApplicationKey, error) {
+	key, err := readApplicationKey(k)
+	if err != nil {
+		return ApplicationKey{key: key}, err
+	}
 	secret := ApplicationKey{
-		key: &[32]byte{},
+		key: key,
+	}
+	for _, prev := range previous {
+		prevKey, err := readApplicationKey(prev)
+		if err != nil {
+			return secret, errors.Wrap(err, "previous key")
+		}
+		secret.previous = append(secret.previous, prevKey)
 	}
+	return secret, nil
+}
+
+func readApplicationKey(k string) (*[32]byte, error) {
+	key := &[32]byte{}
 	if len(k) < 32 {
-		return secret, errors.New("random hash should be 32 chars in length")
+		return key, errors.New("random hash should be 32 chars in length")
 	}
-	_, err := io.ReadFull(bytes.NewBufferString(k), secret.key[:])
-	return secret, err
+	_, err := io.ReadFull(bytes.NewBufferString(k), key[:])
+	return key, err
 }
 
 func (s *ApplicationKey) GetKey() *[32]byte {
 	return s.key
 }
+
+// GetPreviousKeys returns keys that were used before the current one
+func (s *ApplicationKey) GetPreviousKeys() []*[32]byte {
+	return s.previous
+}
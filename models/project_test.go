@@ -76,6 +76,27 @@ func TestProjectSpec(t *testing.T) {
 			_, err := models.NewApplicationSecret("test-hashtestzs")
 			assert.NotNil(t, err)
 		})
+		t.Run("should keep previous hashes to decrypt text encrypted before rotation", func(t *testing.T) {
+			enc, err := models.NewApplicationSecret("test-hashtest-hashtest-hashzzzzz")
+			assert.Nil(t, err)
+
+			// encrypt secret
+			cipher, err := cryptopasta.Encrypt([]byte(rawSecret), enc.GetKey())
+			assert.Nil(t, err)
+
+			dec, err := models.NewApplicationSecret("zest-hashtest-hashtest-hashzzzzz", "test-hashtest-hashtest-hashzzzzz")
+			assert.Nil(t, err)
+			assert.Len(t, dec.GetPreviousKeys(), 1)
+
+			// decrypt secret
+			value, err := cryptopasta.Decrypt(cipher, dec.GetPreviousKeys()[0])
+			assert.Nil(t, err)
+			assert.Equal(t, rawSecret, string(value))
+		})
+		t.Run("should fail if a previous hash size is not atleast 32", func(t *testing.T) {
+			_, err := models.NewApplicationSecret("test-hashtest-hashtest-hashzzzzz", "test-hashtestzs")
+			assert.NotNil(t, err)
+		})
 		t.Run("should encrypt text correctly with hash and return as base64", func(t *testing.T) {
 			enc, err := models.NewApplicationSecret("test-hashtest-hashtest-hashzzzzz")
 			assert.Nil(t, err)
@@ -102,4 +123,40 @@ func TestProjectSpec(t *testing.T) {
 			assert.Equal(t, rawSecret, string(value))
 		})
 	})
+	t.Run("MissingRequiredConfigs", func(t *testing.T) {
+		t.Run("should return nothing for a complete project", func(t *testing.T) {
+			spec := models.ProjectSpec{
+				Name: "test",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: "gs://bucket/folder",
+				},
+				Scheduler: models.ProjectSchedulerConfig{
+					Host: "http://airflow.example.io",
+				},
+			}
+			assert.Empty(t, spec.MissingRequiredConfigs())
+		})
+		t.Run("should return keys which are not set", func(t *testing.T) {
+			spec := models.ProjectSpec{
+				Name: "test",
+				Config: map[string]string{
+					models.ProjectStoragePathKey: " ",
+					models.ProjectSchedulerHost:  "http://airflow.example.io",
+				},
+			}
+			assert.Equal(t, []string{models.ProjectStoragePathKey}, spec.MissingRequiredConfigs())
+			assert.Equal(t, []string{models.ProjectStoragePathKey, models.ProjectSchedulerHost}, models.ProjectSpec{}.MissingRequiredConfigs())
+		})
+	})
+	t.Run("JobEventWebhooks", func(t *testing.T) {
+		t.Run("should split configured endpoints ignoring blanks", func(t *testing.T) {
+			spec := models.ProjectSpec{
+				Config: map[string]string{
+					models.ProjectJobEventWebhooks: "https://alerts.example.io/optimus, ,https://audit.example.io/events ",
+				},
+			}
+			assert.Equal(t, []string{"https://alerts.example.io/optimus", "https://audit.example.io/events"}, spec.JobEventWebhooks())
+			assert.Nil(t, models.ProjectSpec{}.JobEventWebhooks())
+		})
+	})
 }
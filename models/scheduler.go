@@ -2,7 +2,12 @@ package models
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"sort"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 var (
@@ -11,6 +16,19 @@ var (
 	// to support target scheduling engine
 	Scheduler SchedulerUnit
 
+	// SchedulerRegistry holds all the schedulers a project can choose from
+	SchedulerRegistry = &supportedSchedulers{
+		data: map[string]SchedulerUnit{},
+	}
+	ErrUnsupportedScheduler = errors.New("unsupported scheduler requested")
+
+	// ErrJobRunNotFound is returned when scheduler has no run of a job at
+	// the requested scheduled time
+	ErrJobRunNotFound = errors.New("job run not found")
+	// ErrJobRunLogsNotSupported is returned by schedulers which can't
+	// serve logs of job runs
+	ErrJobRunLogsNotSupported = errors.New("job run logs are not supported by scheduler")
+
 	JobStatusStateSuccess JobStatusState = "success"
 	JobStatusStateFailed  JobStatusState = "failed"
 	JobStatusStateRunning JobStatusState = "running"
@@ -37,14 +55,79 @@ type SchedulerUnit interface {
 	Bootstrap(context.Context, ProjectSpec) error
 
 	// GetJobStatus should return the current and previous status of job
-	GetJobStatus(ctx context.Context, projSpec ProjectSpec, jobName string) ([]JobStatus, error)
+	// matching filter, latest run first
+	GetJobStatus(ctx context.Context, projSpec ProjectSpec, jobName string, filter JobStatusFilter) ([]JobStatus, error)
 
 	// Clear clears state of job between provided start and end dates
 	Clear(ctx context.Context, projSpec ProjectSpec, jobName string, startDate, endDate time.Time) error
 
+	// RunJob triggers a run of job for the provided scheduled time, if a run
+	// already exists for that time it is cleared to run again
+	RunJob(ctx context.Context, projSpec ProjectSpec, jobName string, scheduledAt time.Time) error
+
+	// GetJobRunLogs sends logs of task attempts of a job run matching
+	// request to send, in the order tasks ran, logs of an attempt are sent
+	// in chunks of at most JobRunLogChunkSize
+	GetJobRunLogs(ctx context.Context, projSpec ProjectSpec, jobName string, request JobRunLogRequest,
+		send func(JobRunLog) error) error
+
 	// GetDagRunStatus should return batch of runs of a job
 	GetDagRunStatus(ctx context.Context, projSpec ProjectSpec, jobName string, startDate time.Time, endDate time.Time,
 		batchSize int) ([]JobStatus, error)
+
+	// IsJobPaused returns if scheduling of job is paused, a job not yet
+	// known to scheduler should be reported as not paused
+	IsJobPaused(ctx context.Context, projSpec ProjectSpec, jobName string) (bool, error)
+
+	// SetJobPaused pauses or resumes scheduling of job
+	SetJobPaused(ctx context.Context, projSpec ProjectSpec, jobName string, paused bool) error
+}
+
+// JobStoringScheduler is implemented by schedulers compiled jobs are
+// deployed to directly, e.g. by applying them to a cluster, instead of
+// being read by scheduler from ProjectStoragePathKey
+type JobStoringScheduler interface {
+	SchedulerUnit
+	StoresCompiledJobs() bool
+}
+
+type SchedulerRepo interface {
+	GetByName(string) (SchedulerUnit, error)
+	GetAll() []SchedulerUnit
+	Add(SchedulerUnit) error
+}
+
+type supportedSchedulers struct {
+	data map[string]SchedulerUnit
+}
+
+func (s *supportedSchedulers) GetByName(name string) (SchedulerUnit, error) {
+	if unit, ok := s.data[name]; ok {
+		return unit, nil
+	}
+	return nil, errors.Wrap(ErrUnsupportedScheduler, name)
+}
+
+func (s *supportedSchedulers) GetAll() []SchedulerUnit {
+	list := []SchedulerUnit{}
+	for _, unit := range s.data {
+		list = append(list, unit)
+	}
+	return list
+}
+
+func (s *supportedSchedulers) Add(newUnit SchedulerUnit) error {
+	if newUnit.GetName() == "" {
+		return fmt.Errorf("scheduler name cannot be empty")
+	}
+
+	// check if name is already used
+	if _, ok := s.data[newUnit.GetName()]; ok {
+		return fmt.Errorf("scheduler name already in use %s", newUnit.GetName())
+	}
+
+	s.data[newUnit.GetName()] = newUnit
+	return nil
 }
 
 type JobStatusState string
@@ -56,4 +139,125 @@ func (j JobStatusState) String() string {
 type JobStatus struct {
 	ScheduledAt time.Time
 	State       JobStatusState
+
+	// StartedAt and EndedAt are zero if the scheduler didn't report them
+	// or the run has not started/finished yet
+	StartedAt time.Time
+	EndedAt   time.Time
+
+	// TryNumber is the attempt of the run, zero if not reported
+	TryNumber int
+}
+
+// Duration of a finished run, zero if run is not finished yet
+func (j JobStatus) Duration() time.Duration {
+	if j.StartedAt.IsZero() || j.EndedAt.IsZero() || j.EndedAt.Before(j.StartedAt) {
+		return 0
+	}
+	return j.EndedAt.Sub(j.StartedAt)
+}
+
+// JobStatusFilter narrows down runs of a job, zero value of a field
+// doesn't filter on it
+type JobStatusFilter struct {
+	// StartDate and EndDate bound scheduled time of runs, inclusive
+	StartDate time.Time
+	EndDate   time.Time
+
+	// States of runs to keep
+	States []JobStatusState
+
+	// Offset runs are skipped from the latest one and at most Limit
+	// runs are kept after that
+	Offset int
+	Limit  int
+}
+
+// Apply returns runs matching filter sorted latest first, schedulers
+// which can't filter at source use it on all runs of a job
+func (f JobStatusFilter) Apply(runs []JobStatus) []JobStatus {
+	filtered := []JobStatus{}
+	for _, run := range runs {
+		if f.matches(run) {
+			filtered = append(filtered, run)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].ScheduledAt.After(filtered[j].ScheduledAt)
+	})
+	if f.Offset >= len(filtered) {
+		return []JobStatus{}
+	}
+	filtered = filtered[f.Offset:]
+	if f.Limit > 0 && f.Limit < len(filtered) {
+		filtered = filtered[:f.Limit]
+	}
+	return filtered
+}
+
+func (f JobStatusFilter) matches(run JobStatus) bool {
+	if !f.StartDate.IsZero() && run.ScheduledAt.Before(f.StartDate) {
+		return false
+	}
+	if !f.EndDate.IsZero() && run.ScheduledAt.After(f.EndDate) {
+		return false
+	}
+	if len(f.States) == 0 {
+		return true
+	}
+	for _, state := range f.States {
+		if run.State == state {
+			return true
+		}
+	}
+	return false
+}
+
+// JobRunLogChunkSize is the max size of logs sent at once
+const JobRunLogChunkSize = 64 * 1024
+
+// JobRunLogRequest identifies logs of a job run
+type JobRunLogRequest struct {
+	ScheduledAt time.Time
+
+	// TaskName and TryNumber narrow down logs to a task or an attempt,
+	// logs of all tasks and attempts are sent if zero
+	TaskName  string
+	TryNumber int
+}
+
+// Matches reports if attempt of a task is requested
+func (r JobRunLogRequest) Matches(taskName string, tryNumber int) bool {
+	return (r.TaskName == "" || r.TaskName == taskName) && (r.TryNumber == 0 || r.TryNumber == tryNumber)
+}
+
+// JobRunLog is a chunk of logs of a task attempt
+type JobRunLog struct {
+	TaskName  string
+	TryNumber int
+	Content   []byte
+}
+
+// SendJobRunLog reads logs of a task attempt and sends them in chunks of
+// JobRunLogChunkSize
+func SendJobRunLog(r io.Reader, taskName string, tryNumber int, send func(JobRunLog) error) error {
+	buf := make([]byte, JobRunLogChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if sendErr := send(JobRunLog{
+				TaskName:  taskName,
+				TryNumber: tryNumber,
+				Content:   append([]byte(nil), buf[:n]...),
+			}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read logs of %s attempt %d", taskName, tryNumber)
+		}
+	}
 }
@@ -0,0 +1,72 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestJobStatusFilter(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2021, 3, d, 2, 0, 0, 0, time.UTC)
+	}
+	runs := []models.JobStatus{
+		{ScheduledAt: day(1), State: models.JobStatusStateSuccess},
+		{ScheduledAt: day(2), State: models.JobStatusStateFailed},
+		{ScheduledAt: day(3), State: models.JobStatusStateSuccess},
+		{ScheduledAt: day(4), State: models.JobStatusStateFailed},
+		{ScheduledAt: day(5), State: models.JobStatusStateRunning},
+	}
+	t.Run("should return all runs latest first with an empty filter", func(t *testing.T) {
+		filtered := models.JobStatusFilter{}.Apply(runs)
+		assert.Len(t, filtered, 5)
+		assert.Equal(t, day(5), filtered[0].ScheduledAt)
+		assert.Equal(t, day(1), filtered[4].ScheduledAt)
+	})
+	t.Run("should keep runs within dates and of requested states", func(t *testing.T) {
+		filtered := models.JobStatusFilter{
+			StartDate: day(2),
+			EndDate:   day(4),
+			States:    []models.JobStatusState{models.JobStatusStateFailed},
+		}.Apply(runs)
+		assert.Equal(t, []models.JobStatus{
+			{ScheduledAt: day(4), State: models.JobStatusStateFailed},
+			{ScheduledAt: day(2), State: models.JobStatusStateFailed},
+		}, filtered)
+	})
+	t.Run("should paginate filtered runs", func(t *testing.T) {
+		filtered := models.JobStatusFilter{Offset: 1, Limit: 2}.Apply(runs)
+		assert.Equal(t, []models.JobStatus{
+			{ScheduledAt: day(4), State: models.JobStatusStateFailed},
+			{ScheduledAt: day(3), State: models.JobStatusStateSuccess},
+		}, filtered)
+
+		assert.Empty(t, models.JobStatusFilter{Offset: 5}.Apply(runs))
+	})
+}
+
+func TestSendJobRunLog(t *testing.T) {
+	t.Run("should send logs in chunks", func(t *testing.T) {
+		content := strings.Repeat("a", models.JobRunLogChunkSize+10)
+		var logs []models.JobRunLog
+		err := models.SendJobRunLog(strings.NewReader(content), "bq2bq", 2, func(log models.JobRunLog) error {
+			logs = append(logs, log)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Len(t, logs, 2)
+		assert.Len(t, logs[0].Content, models.JobRunLogChunkSize)
+		assert.Equal(t, models.JobRunLog{TaskName: "bq2bq", TryNumber: 2, Content: []byte(strings.Repeat("a", 10))}, logs[1])
+	})
+	t.Run("should not send empty logs", func(t *testing.T) {
+		err := models.SendJobRunLog(strings.NewReader(""), "bq2bq", 1, func(log models.JobRunLog) error {
+			t.Fatal("unexpected send")
+			return nil
+		})
+		assert.Nil(t, err)
+	})
+}
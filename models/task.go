@@ -33,7 +33,8 @@ type TaskPlugin interface {
 	GenerateTaskDestination(context.Context, GenerateTaskDestinationRequest) (GenerateTaskDestinationResponse, error)
 
 	// GetDependencies returns names of job destination on which this unit
-	// is dependent on
+	// is dependent on, each dependency can be a destination urn in form of
+	// scheme://path, dependencies without a scheme are considered bigquery
 	GenerateTaskDependencies(context.Context, GenerateTaskDependenciesRequest) (GenerateTaskDependenciesResponse, error)
 }
 
@@ -255,6 +256,35 @@ type GenerateTaskDestinationRequest struct {
 
 type GenerateTaskDestinationResponse struct {
 	Destination string
+
+	// Type of datastore destination belongs to, defaults to bigquery
+	Type DestinationType
+
+	// AdditionalDestinations are other destinations of the same Type
+	// written by the task, e.g. a task fanning out to more than one table
+	AdditionalDestinations []string
+}
+
+// URN returns destination in form of scheme://path, it is used to
+// uniquely identify a destination across datastores
+func (r GenerateTaskDestinationResponse) URN() string {
+	return DestinationURN(r.Type, r.Destination)
+}
+
+// URNs returns urn of every destination written by the task, starting
+// with the main destination
+func (r GenerateTaskDestinationResponse) URNs() []string {
+	urns := []string{}
+	seen := map[string]bool{}
+	for _, destination := range append([]string{r.Destination}, r.AdditionalDestinations...) {
+		urn := DestinationURN(r.Type, destination)
+		if urn == "" || seen[urn] {
+			continue
+		}
+		seen[urn] = true
+		urns = append(urns, urn)
+	}
+	return urns
 }
 
 type GenerateTaskDependenciesRequest struct {
@@ -274,6 +304,26 @@ type GenerateTaskDependenciesResponse struct {
 	Dependencies []string
 }
 
+type DestinationType string
+
+const (
+	DestinationTypeBigquery DestinationType = "bigquery"
+
+	destinationURNSeparator = "://"
+)
+
+// DestinationURN builds the urn of a destination, a destination which is
+// already an urn is returned as it is
+func DestinationURN(destinationType DestinationType, destination string) string {
+	if destination == "" || strings.Contains(destination, destinationURNSeparator) {
+		return destination
+	}
+	if destinationType == "" {
+		destinationType = DestinationTypeBigquery
+	}
+	return string(destinationType) + destinationURNSeparator + destination
+}
+
 var (
 	// TaskRegistry is a list of tasks that are supported as base task in a job
 	TaskRegistry       TaskPluginRepository = NewTaskPluginRepository()
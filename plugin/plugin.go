@@ -32,6 +32,10 @@ const (
 	// should always remain constant
 	MagicCookieKey   = "OP_PLUGIN_MAGIC_COOKIE"
 	MagicCookieValue = "ksxR4BqCT81whVF2dVEUpYZXwM3pazSkP4IbVc6f2Kns57ypp2c0z0GzQNMdHSUk"
+
+	// PluginsDirEnv is the directory plugins are discovered in before
+	// any of the default directories
+	PluginsDirEnv = "OPTIMUS_PLUGINS_DIR"
 )
 
 var (
@@ -110,7 +114,7 @@ func Initialize(pluginLogger hclog.Logger) error {
 				hookClient := raw.(models.HookPlugin)
 				hookSchema, err := hookClient.GetHookSchema(context.Background(), models.GetHookSchemaRequest{})
 				if err != nil {
-					return errors.Wrapf(err, "hookClient.GetTaskSchema: %s", pluginPath)
+					return errors.Wrapf(err, "hookClient.GetHookSchema: %s", pluginPath)
 				}
 				pluginLogger.Debug("tested plugin communication for hook ", hookSchema.Name)
 
@@ -118,7 +122,7 @@ func Initialize(pluginLogger hclog.Logger) error {
 					return errors.Wrapf(err, "models.HookRegistry.Add: %s", pluginPath)
 				}
 			default:
-				return errors.Wrapf(err, "unsupported plugin type: %s", pluginType)
+				return errors.Errorf("unsupported plugin type: %s", pluginType)
 			}
 		}
 	}
@@ -128,6 +132,7 @@ func Initialize(pluginLogger hclog.Logger) error {
 
 // DiscoverPlugins look for plugin binaries in following folders
 // order to search is top to down
+// $OPTIMUS_PLUGINS_DIR
 // ./
 // <exec>/
 // <exec>/.optimus/plugins
@@ -142,30 +147,14 @@ func DiscoverPlugins(pluginLogger hclog.Logger) (map[string][]string, error) {
 		prefix            = "optimus-"
 		suffix            = fmt.Sprintf("_%s_%s", runtime.GOOS, runtime.GOARCH)
 		discoveredPlugins = map[string][]string{}
-	)
-
-	dirs := []string{}
-	// current working directory
-	if p, err := os.Getwd(); err == nil {
-		dirs = append(dirs, p)
-	}
-	{
-		// look in the same directory as the executable
-		if exePath, err := os.Executable(); err != nil {
-			pluginLogger.Debug(fmt.Sprintf("Error discovering exe directory: %s", err))
-		} else {
-			dirs = append(dirs, filepath.Dir(exePath))
+		// discovered names of plugins per type
+		discoveredNames = map[string]map[string]bool{
+			TaskPluginName: {},
+			HookPluginName: {},
 		}
-	}
-	{
-		// add user home directory
-		if currentHomeDir, err := os.UserHomeDir(); err == nil {
-			dirs = append(dirs, filepath.Join(currentHomeDir, ".optimus", "plugins"))
-		}
-	}
-	dirs = append(dirs, []string{"/usr/bin", "/usr/local/bin"}...)
+	)
 
-	for _, dirPath := range dirs {
+	for _, dirPath := range pluginDirs(pluginLogger) {
 		fileInfos, err := ioutil.ReadDir(dirPath)
 		if err != nil {
 			continue
@@ -195,42 +184,55 @@ func DiscoverPlugins(pluginLogger hclog.Logger) (map[string][]string, error) {
 			}
 
 			// get plugin type
-			nameParts := strings.Split(fullName, "-")
+			nameParts := strings.SplitN(fullName, "-", 3)
 			if len(nameParts) < 3 {
 				continue
 			}
+			pluginType := nameParts[1]
+			if _, ok := discoveredNames[pluginType]; !ok {
+				// skip
+				continue
+			}
 
+			// check for duplicate binaries, could be different versions
+			// if we have already discovered one, ignore rest
 			pluginName := strings.Split(nameParts[2], "_")[0]
-			absPath = filepath.Clean(absPath)
-			switch nameParts[1] {
-			case TaskPluginName:
-				// check for duplicate binaries, could be different versions
-				// if we have already discovered one, ignore rest
-				isAlreadyFound := false
-				for _, storedName := range discoveredPlugins[TaskPluginName] {
-					if strings.Contains(storedName, pluginName) {
-						isAlreadyFound = true
-					}
-				}
-
-				if !isAlreadyFound {
-					discoveredPlugins[TaskPluginName] = append(discoveredPlugins[TaskPluginName], absPath)
-				}
-			case HookPluginName:
-				isAlreadyFound := false
-				for _, storedName := range discoveredPlugins[HookPluginName] {
-					if strings.Contains(storedName, pluginName) {
-						isAlreadyFound = true
-					}
-				}
-
-				if !isAlreadyFound {
-					discoveredPlugins[HookPluginName] = append(discoveredPlugins[HookPluginName], absPath)
-				}
-			default:
-				// skip
+			if discoveredNames[pluginType][pluginName] {
+				pluginLogger.Debug(fmt.Sprintf("skipping %s, %s plugin %s is already discovered", absPath, pluginType, pluginName))
+				continue
 			}
+			discoveredNames[pluginType][pluginName] = true
+			discoveredPlugins[pluginType] = append(discoveredPlugins[pluginType], filepath.Clean(absPath))
 		}
 	}
 	return discoveredPlugins, nil
 }
+
+// pluginDirs are the directories searched for plugin binaries in the order
+// of their precedence
+func pluginDirs(pluginLogger hclog.Logger) []string {
+	dirs := []string{}
+	// explicitly configured directory
+	if p := os.Getenv(PluginsDirEnv); p != "" {
+		dirs = append(dirs, p)
+	}
+	// current working directory
+	if p, err := os.Getwd(); err == nil {
+		dirs = append(dirs, p)
+	}
+	{
+		// look in the same directory as the executable
+		if exePath, err := os.Executable(); err != nil {
+			pluginLogger.Debug(fmt.Sprintf("Error discovering exe directory: %s", err))
+		} else {
+			dirs = append(dirs, filepath.Dir(exePath), filepath.Join(filepath.Dir(exePath), ".optimus", "plugins"))
+		}
+	}
+	{
+		// add user home directory
+		if currentHomeDir, err := os.UserHomeDir(); err == nil {
+			dirs = append(dirs, filepath.Join(currentHomeDir, ".optimus", "plugins"))
+		}
+	}
+	return append(dirs, []string{"/usr/bin", "/usr/local/bin"}...)
+}
@@ -0,0 +1,48 @@
+package plugin_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/plugin"
+)
+
+func TestDiscoverPlugins(t *testing.T) {
+	t.Run("should discover first binary of every plugin in plugins dir", func(t *testing.T) {
+		pluginsDir, err := ioutil.TempDir("", "optimus-plugins")
+		assert.Nil(t, err)
+		defer os.RemoveAll(pluginsDir)
+
+		suffix := fmt.Sprintf("%s_%s", runtime.GOOS, runtime.GOARCH)
+		for _, name := range []string{
+			"optimus-task-bq2bq_0.1_" + suffix,
+			"optimus-task-bq_0.1_" + suffix,
+			"optimus-hook-predator-lite_0.1_" + suffix,
+			"optimus-hook-predator-lite_0.2_" + suffix,
+			"optimus-exporter-bq_0.1_" + suffix,
+			"optimus-task-bq2bq_0.1_plan9_mips",
+		} {
+			assert.Nil(t, ioutil.WriteFile(filepath.Join(pluginsDir, name), []byte{}, 0755))
+		}
+
+		os.Setenv(plugin.PluginsDirEnv, pluginsDir)
+		defer os.Unsetenv(plugin.PluginsDirEnv)
+
+		discovered, err := plugin.DiscoverPlugins(hclog.NewNullLogger())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(pluginsDir, "optimus-task-bq2bq_0.1_"+suffix),
+			filepath.Join(pluginsDir, "optimus-task-bq_0.1_"+suffix),
+		}, discovered[plugin.TaskPluginName])
+		assert.Equal(t, []string{
+			filepath.Join(pluginsDir, "optimus-hook-predator-lite_0.1_"+suffix),
+		}, discovered[plugin.HookPluginName])
+	})
+}
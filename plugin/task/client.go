@@ -155,7 +155,9 @@ func (m *GRPCClient) GenerateTaskDestination(ctx context.Context, request models
 		return models.GenerateTaskDestinationResponse{}, err
 	}
 	return models.GenerateTaskDestinationResponse{
-		Destination: resp.Destination,
+		Destination:            resp.Destination,
+		Type:                   models.DestinationType(resp.Type),
+		AdditionalDestinations: resp.AdditionalDestinations,
 	}, nil
 }
 
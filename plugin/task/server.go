@@ -142,7 +142,11 @@ func (s *GRPCServer) GenerateTaskDestination(ctx context.Context, req *pb.Genera
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GenerateTaskDestination_Response{Destination: resp.Destination}, nil
+	return &pb.GenerateTaskDestination_Response{
+		Destination:            resp.Destination,
+		Type:                   string(resp.Type),
+		AdditionalDestinations: resp.AdditionalDestinations,
+	}, nil
 }
 
 func (s *GRPCServer) GenerateTaskDependencies(ctx context.Context, req *pb.GenerateTaskDependencies_Request) (*pb.GenerateTaskDependencies_Response, error) {
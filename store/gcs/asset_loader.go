@@ -0,0 +1,49 @@
+package gcs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/odpf/optimus/instance"
+)
+
+// ObjectContextReader opens objects for reading till ctx is done
+type ObjectContextReader interface {
+	NewReaderContext(ctx context.Context, bucket, path string) (io.ReadCloser, error)
+}
+
+// AssetLoader reads job assets referenced as gs://bucket/path/to/asset,
+// at most MaxBytes of an asset are read
+type AssetLoader struct {
+	ObjectReader ObjectContextReader
+	MaxBytes     int64
+}
+
+func (l *AssetLoader) Load(ctx context.Context, uri string) ([]byte, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	objectPath := strings.TrimPrefix(parsed.Path, "/")
+	if parsed.Scheme != "gs" || parsed.Host == "" || objectPath == "" {
+		return nil, fmt.Errorf("invalid gcs asset path %s, expected gs://bucket/path", uri)
+	}
+
+	reader, err := l.ObjectReader.NewReaderContext(ctx, parsed.Host, objectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return instance.ReadAsset(reader, l.MaxBytes)
+}
+
+func NewAssetLoader(client *storage.Client, maxBytes int64) *AssetLoader {
+	return &AssetLoader{
+		ObjectReader: &gcsObjectReader{c: client},
+		MaxBytes:     maxBytes,
+	}
+}
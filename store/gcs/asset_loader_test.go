@@ -0,0 +1,51 @@
+package gcs_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/odpf/optimus/instance"
+	mocked "github.com/odpf/optimus/mock"
+	gcsStore "github.com/odpf/optimus/store/gcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetLoader(t *testing.T) {
+	ctx := context.Background()
+	t.Run("should read asset from referenced bucket object", func(t *testing.T) {
+		or := new(mocked.ObjectReader)
+		or.On("NewReaderContext", ctx, "shared-assets", "sql/query.sql").Return(ioutil.NopCloser(strings.NewReader("select 1")), nil)
+		defer or.AssertExpectations(t)
+
+		loader := &gcsStore.AssetLoader{ObjectReader: or}
+		content, err := loader.Load(ctx, "gs://shared-assets/sql/query.sql")
+		assert.Nil(t, err)
+		assert.Equal(t, "select 1", string(content))
+	})
+	t.Run("should fail if object can't be read", func(t *testing.T) {
+		or := new(mocked.ObjectReader)
+		or.On("NewReaderContext", ctx, "shared-assets", "sql/query.sql").Return(ioutil.NopCloser(strings.NewReader("")), errors.New("object doesn't exist"))
+		defer or.AssertExpectations(t)
+
+		loader := &gcsStore.AssetLoader{ObjectReader: or}
+		_, err := loader.Load(ctx, "gs://shared-assets/sql/query.sql")
+		assert.NotNil(t, err)
+	})
+	t.Run("should fail if object is larger than max bytes", func(t *testing.T) {
+		or := new(mocked.ObjectReader)
+		or.On("NewReaderContext", ctx, "shared-assets", "sql/query.sql").Return(ioutil.NopCloser(strings.NewReader("select 1")), nil)
+		defer or.AssertExpectations(t)
+
+		loader := &gcsStore.AssetLoader{ObjectReader: or, MaxBytes: 4}
+		_, err := loader.Load(ctx, "gs://shared-assets/sql/query.sql")
+		assert.True(t, errors.Is(err, instance.ErrAssetTooLarge))
+	})
+	t.Run("should fail for invalid gcs path", func(t *testing.T) {
+		loader := &gcsStore.AssetLoader{}
+		_, err := loader.Load(ctx, "gs://shared-assets")
+		assert.NotNil(t, err)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"cloud.google.com/go/storage"
+	"github.com/odpf/optimus/store"
 )
 
 type GcsObjectWriter struct {
@@ -19,17 +20,27 @@ func (gcs *GcsObjectWriter) NewWriter(ctx context.Context, bucket, path string)
 	return b.Object(path).NewWriter(ctx), nil
 }
 
+// NewObjectReader returns reader of objects stored in gcs
+func NewObjectReader(client *storage.Client) store.ObjectReader {
+	return &gcsObjectReader{c: client}
+}
+
 type gcsObjectReader struct {
 	c *storage.Client
 }
 
 func (gcs *gcsObjectReader) NewReader(bucket, path string) (io.ReadCloser, error) {
+	return gcs.NewReaderContext(context.Background(), bucket, path)
+}
+
+// NewReaderContext opens the object for reading till ctx is done
+func (gcs *gcsObjectReader) NewReaderContext(ctx context.Context, bucket, path string) (io.ReadCloser, error) {
 	b := gcs.c.Bucket(bucket)
-	if _, err := b.Attrs(context.Background()); err != nil {
+	if _, err := b.Attrs(ctx); err != nil {
 		return nil, err
 	}
 
-	reader, err := b.Object(path).NewReader(context.Background())
+	reader, err := b.Object(path).NewReader(ctx)
 	if err != nil {
 		return nil, err
 	}
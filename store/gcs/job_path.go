@@ -0,0 +1,185 @@
+package gcs
+
+import (
+	"bytes"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	jobPathNamespaceMarker = "\x00namespace\x00"
+	jobPathJobMarker       = "\x00job\x00"
+)
+
+// JobPathTemplate places compiled jobs in the storage bucket, template can
+// refer {{.project}}, {{.namespace}} and {{.job}} of the compiled job where
+// namespace is the namespace id, e.g. {{.project}}/dags/{{.namespace}}/{{.job}}.py
+type JobPathTemplate struct {
+	text    string
+	tmpl    *template.Template
+	project string
+	suffix  string
+
+	// pattern matches object paths rendered by the template and captures
+	// the namespace and job of the object
+	pattern      *regexp.Regexp
+	namespaceIdx int
+	jobIdx       int
+}
+
+// NewJobPathTemplate parses the template and validates each job of the
+// project gets a unique path, suffix is added to rendered paths missing it
+func NewJobPathTemplate(text, project, suffix string) (*JobPathTemplate, error) {
+	tmpl, err := template.New("job_path").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse job path template %s", text)
+	}
+	t := &JobPathTemplate{
+		text:    text,
+		tmpl:    tmpl,
+		project: project,
+		suffix:  suffix,
+	}
+
+	jobA, err := t.render("namespace", "job-a")
+	if err != nil {
+		return nil, err
+	}
+	jobB, err := t.render("namespace", "job-b")
+	if err != nil {
+		return nil, err
+	}
+	if jobA == jobB {
+		return nil, errors.Errorf("job path template %s must refer {{.job}}", text)
+	}
+
+	// same job name can be used across namespaces of a project
+	rendered := map[string]string{}
+	for _, namespace := range []string{"namespace-a", "namespace-b"} {
+		for _, job := range []string{"job-a", "job-b"} {
+			p, err := t.render(namespace, job)
+			if err != nil {
+				return nil, err
+			}
+			if other, ok := rendered[p]; ok {
+				return nil, errors.Errorf("job path template %s renders same path %s for %s and %s/%s", text, p, other, namespace, job)
+			}
+			rendered[p] = namespace + "/" + job
+		}
+	}
+
+	if err := t.compilePattern(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Path returns the object path of a job
+func (t *JobPathTemplate) Path(namespaceID, jobName string) (string, error) {
+	return t.render(namespaceID, jobName)
+}
+
+// NamespacePrefix returns the common prefix of all jobs of a namespace
+func (t *JobPathTemplate) NamespacePrefix(namespaceID string) (string, error) {
+	p, err := t.render(namespaceID, jobPathJobMarker)
+	if err != nil {
+		return "", err
+	}
+	return p[:strings.Index(p, jobPathJobMarker)], nil
+}
+
+// Prefix returns the common prefix of all jobs of the project
+func (t *JobPathTemplate) Prefix() (string, error) {
+	p, err := t.render(jobPathNamespaceMarker, jobPathJobMarker)
+	if err != nil {
+		return "", err
+	}
+	end := strings.Index(p, jobPathJobMarker)
+	if idx := strings.Index(p, jobPathNamespaceMarker); idx != -1 && idx < end {
+		end = idx
+	}
+	return p[:end], nil
+}
+
+// Match parses namespace id and job name from an object path, returns false
+// if the object was not placed by the template
+func (t *JobPathTemplate) Match(objectPath string) (namespaceID, jobName string, ok bool) {
+	matches := t.pattern.FindStringSubmatch(objectPath)
+	if matches == nil {
+		return "", "", false
+	}
+	if t.namespaceIdx > 0 {
+		namespaceID = matches[t.namespaceIdx]
+	}
+	return namespaceID, matches[t.jobIdx], true
+}
+
+func (t *JobPathTemplate) render(namespaceID, jobName string) (string, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, map[string]string{
+		"project":   t.project,
+		"namespace": namespaceID,
+		"job":       jobName,
+	}); err != nil {
+		return "", errors.Wrap(err, "failed to render job path template")
+	}
+	p := strings.TrimPrefix(path.Clean(buf.String()), "/")
+	if !strings.HasSuffix(p, t.suffix) {
+		p += t.suffix
+	}
+	return p, nil
+}
+
+// compilePattern builds a regex out of the rendered template where the first
+// occurrence of namespace and job is captured
+func (t *JobPathTemplate) compilePattern() error {
+	p, err := t.render(jobPathNamespaceMarker, jobPathJobMarker)
+	if err != nil {
+		return err
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	group := 0
+	for len(p) > 0 {
+		nsIdx := strings.Index(p, jobPathNamespaceMarker)
+		jobIdx := strings.Index(p, jobPathJobMarker)
+		next, marker := jobIdx, jobPathJobMarker
+		if nsIdx != -1 && (jobIdx == -1 || nsIdx < jobIdx) {
+			next, marker = nsIdx, jobPathNamespaceMarker
+		}
+		if next == -1 {
+			expr.WriteString(regexp.QuoteMeta(p))
+			break
+		}
+		expr.WriteString(regexp.QuoteMeta(p[:next]))
+		switch {
+		case marker == jobPathJobMarker && t.jobIdx == 0:
+			group++
+			t.jobIdx = group
+			expr.WriteString("([^/]+)")
+		case marker == jobPathNamespaceMarker && t.namespaceIdx == 0:
+			group++
+			t.namespaceIdx = group
+			expr.WriteString("([^/]+)")
+		default:
+			expr.WriteString("[^/]+")
+		}
+		p = p[next+len(marker):]
+	}
+	expr.WriteString("$")
+	if t.jobIdx == 0 {
+		return errors.Errorf("job path template %s must refer {{.job}} without modifying it", t.text)
+	}
+
+	pattern, err := regexp.Compile(expr.String())
+	if err != nil {
+		return errors.Wrapf(err, "failed to compile job path template %s", t.text)
+	}
+	t.pattern = pattern
+	return nil
+}
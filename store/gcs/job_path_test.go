@@ -0,0 +1,60 @@
+package gcs_test
+
+import (
+	"testing"
+
+	gcsStore "github.com/odpf/optimus/store/gcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobPathTemplate(t *testing.T) {
+	t.Run("should render job path using project, namespace and job", func(t *testing.T) {
+		tmpl, err := gcsStore.NewJobPathTemplate("{{.project}}/dags/{{.namespace}}/{{.job}}.py", "proj", ".py")
+		assert.Nil(t, err)
+
+		p, err := tmpl.Path("ns-id", "foo")
+		assert.Nil(t, err)
+		assert.Equal(t, "proj/dags/ns-id/foo.py", p)
+
+		prefix, err := tmpl.NamespacePrefix("ns-id")
+		assert.Nil(t, err)
+		assert.Equal(t, "proj/dags/ns-id/", prefix)
+
+		prefix, err = tmpl.Prefix()
+		assert.Nil(t, err)
+		assert.Equal(t, "proj/dags/", prefix)
+
+		namespaceID, jobName, ok := tmpl.Match("proj/dags/ns-id/foo.py")
+		assert.True(t, ok)
+		assert.Equal(t, "ns-id", namespaceID)
+		assert.Equal(t, "foo", jobName)
+
+		_, _, ok = tmpl.Match("other/dags/ns-id/foo.py")
+		assert.False(t, ok)
+	})
+	t.Run("should add suffix to rendered path if missing", func(t *testing.T) {
+		tmpl, err := gcsStore.NewJobPathTemplate("/teams/{{.project}}/{{.namespace}}/{{.job}}/dag", "proj", ".py")
+		assert.Nil(t, err)
+
+		p, err := tmpl.Path("ns-id", "foo")
+		assert.Nil(t, err)
+		assert.Equal(t, "teams/proj/ns-id/foo/dag.py", p)
+
+		namespaceID, jobName, ok := tmpl.Match("teams/proj/ns-id/foo/dag.py")
+		assert.True(t, ok)
+		assert.Equal(t, "ns-id", namespaceID)
+		assert.Equal(t, "foo", jobName)
+	})
+	t.Run("should reject template missing job placeholder", func(t *testing.T) {
+		_, err := gcsStore.NewJobPathTemplate("{{.project}}/{{.namespace}}/dag.py", "proj", ".py")
+		assert.Contains(t, err.Error(), "must refer {{.job}}")
+	})
+	t.Run("should reject template rendering same path for jobs of different namespaces", func(t *testing.T) {
+		_, err := gcsStore.NewJobPathTemplate("{{.project}}/dags/{{.job}}.py", "proj", ".py")
+		assert.Contains(t, err.Error(), "renders same path proj/dags/job-a.py")
+	})
+	t.Run("should reject template referring unknown keys", func(t *testing.T) {
+		_, err := gcsStore.NewJobPathTemplate("{{.team}}/{{.namespace}}/{{.job}}.py", "proj", ".py")
+		assert.NotNil(t, err)
+	})
+}
@@ -27,10 +27,18 @@ type JobRepository struct {
 	Bucket       string
 	Prefix       string
 	Suffix       string
+
+	// PathTemplate if set places jobs relative to Prefix instead of
+	// the default <prefix>/<namespace id>/<job><suffix> layout
+	PathTemplate *JobPathTemplate
 }
 
 func (repo *JobRepository) Save(ctx context.Context, j models.Job) (err error) {
-	dst, err := repo.ObjectWriter.NewWriter(ctx, repo.Bucket, repo.pathFor(j))
+	filePath, err := repo.pathFor(j.NamespaceID, j.Name)
+	if err != nil {
+		return err
+	}
+	dst, err := repo.ObjectWriter.NewWriter(ctx, repo.Bucket, filePath)
 	if err != nil {
 		return err
 	}
@@ -59,7 +67,10 @@ func (repo *JobRepository) Delete(ctx context.Context, namespace models.Namespac
 		return err
 	}
 
-	filePath := fmt.Sprintf("%s%s", path.Join(repo.Prefix, namespace.ID.String(), jobName), repo.Suffix)
+	filePath, err := repo.pathFor(namespace.ID.String(), jobName)
+	if err != nil {
+		return err
+	}
 	objectHandle := bucket.Object(filePath)
 	_, err = objectHandle.Attrs(ctx)
 	if err != nil {
@@ -84,8 +95,12 @@ func (repo *JobRepository) GetAll(ctx context.Context) ([]models.Job, error) {
 		return nil, err
 	}
 
+	prefix, err := repo.jobsPrefix()
+	if err != nil {
+		return nil, err
+	}
 	query := storage.Query{
-		Prefix: repo.Prefix,
+		Prefix: prefix,
 	}
 	it := bucket.Objects(ctx, &query)
 
@@ -99,7 +114,7 @@ func (repo *JobRepository) GetAll(ctx context.Context) ([]models.Job, error) {
 			break
 		}
 
-		if strings.HasSuffix(objAttr.Name, repo.Suffix) {
+		if _, _, ok := repo.matchPath(objAttr.Name); ok {
 			objAttrs = append(objAttrs, objAttr)
 		}
 	}
@@ -118,8 +133,9 @@ func (repo *JobRepository) GetAll(ctx context.Context) ([]models.Job, error) {
 			return nil, err
 		}
 
+		_, jobName, _ := repo.matchPath(objAttr.Name)
 		jobs = append(jobs, models.Job{
-			Name:     repo.jobNameFromPath(objAttr.Name),
+			Name:     jobName,
 			Contents: b.Bytes(),
 		})
 	}
@@ -134,8 +150,16 @@ func (repo *JobRepository) ListNames(ctx context.Context, namespace models.Names
 		return nil, err
 	}
 
+	prefix := path.Join(repo.Prefix, namespace.ID.String())
+	if repo.PathTemplate != nil {
+		namespacePrefix, err := repo.PathTemplate.NamespacePrefix(namespace.ID.String())
+		if err != nil {
+			return nil, err
+		}
+		prefix = path.Join(repo.Prefix, namespacePrefix)
+	}
 	query := storage.Query{
-		Prefix: path.Join(repo.Prefix, namespace.ID.String()),
+		Prefix: prefix,
 	}
 	it := bucket.Objects(ctx, &query)
 
@@ -149,8 +173,9 @@ func (repo *JobRepository) ListNames(ctx context.Context, namespace models.Names
 			break
 		}
 
-		if strings.HasSuffix(objAttr.Name, repo.Suffix) {
-			jobNames = append(jobNames, repo.jobNameFromPath(objAttr.Name))
+		namespaceID, jobName, ok := repo.matchPath(objAttr.Name)
+		if ok && (repo.PathTemplate == nil || namespaceID == namespace.ID.String()) {
+			jobNames = append(jobNames, jobName)
 		}
 	}
 	return jobNames, nil
@@ -168,6 +193,11 @@ func (repo *JobRepository) GetByName(ctx context.Context, jobName string) (model
 	}
 
 	filePath := fmt.Sprintf("%s%s", path.Join(repo.Prefix, jobName), repo.Suffix)
+	if repo.PathTemplate != nil {
+		if filePath, err = repo.findPath(ctx, bucket, jobName); err != nil {
+			return models.Job{}, err
+		}
+	}
 
 	objHandle := bucket.Object(filePath)
 	_, err = objHandle.Attrs(ctx)
@@ -196,11 +226,67 @@ func (repo *JobRepository) GetByName(ctx context.Context, jobName string) (model
 	}, nil
 }
 
-func (repo *JobRepository) pathFor(j models.Job) string {
+func (repo *JobRepository) pathFor(namespaceID, jobName string) (string, error) {
 	if len(repo.Prefix) > 0 && repo.Prefix[0] == '/' {
 		repo.Prefix = repo.Prefix[1:]
 	}
-	return fmt.Sprintf("%s%s", path.Join(repo.Prefix, j.NamespaceID, j.Name), repo.Suffix)
+	if repo.PathTemplate != nil {
+		filePath, err := repo.PathTemplate.Path(namespaceID, jobName)
+		if err != nil {
+			return "", err
+		}
+		return path.Join(repo.Prefix, filePath), nil
+	}
+	return fmt.Sprintf("%s%s", path.Join(repo.Prefix, namespaceID, jobName), repo.Suffix), nil
+}
+
+// jobsPrefix is the common prefix of all the jobs stored in repository
+func (repo *JobRepository) jobsPrefix() (string, error) {
+	if repo.PathTemplate == nil {
+		return repo.Prefix, nil
+	}
+	prefix, err := repo.PathTemplate.Prefix()
+	if err != nil {
+		return "", err
+	}
+	return path.Join(repo.Prefix, prefix), nil
+}
+
+// matchPath returns the namespace id and job name of a stored job, namespace
+// is only known if jobs are placed using a template
+func (repo *JobRepository) matchPath(filePath string) (namespaceID, jobName string, ok bool) {
+	if repo.PathTemplate == nil {
+		if !strings.HasSuffix(filePath, repo.Suffix) {
+			return "", "", false
+		}
+		return "", repo.jobNameFromPath(filePath), true
+	}
+	relPath := strings.TrimPrefix(strings.TrimPrefix(filePath, strings.TrimPrefix(repo.Prefix, "/")), "/")
+	return repo.PathTemplate.Match(relPath)
+}
+
+// findPath looks up the stored path of a job when the namespace of job
+// is not known
+func (repo *JobRepository) findPath(ctx context.Context, bucket stiface.BucketHandle, jobName string) (string, error) {
+	prefix, err := repo.jobsPrefix()
+	if err != nil {
+		return "", err
+	}
+	it := bucket.Objects(ctx, &storage.Query{
+		Prefix: prefix,
+	})
+	for {
+		objAttr, err := it.Next()
+		if err == iterator.Done {
+			return "", errors.Wrap(models.ErrNoSuchJob, jobName)
+		}
+		if err != nil {
+			return "", err
+		}
+		if _, name, ok := repo.matchPath(objAttr.Name); ok && name == jobName {
+			return objAttr.Name, nil
+		}
+	}
 }
 
 func (repo *JobRepository) jobNameFromPath(filePath string) string {
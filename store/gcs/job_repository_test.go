@@ -77,6 +77,40 @@ func TestJobRepository(t *testing.T) {
 			assert.Nil(t, err)
 			assert.Equal(t, string(testJob.Contents), out.String())
 		})
+		t.Run("should write job contents to path rendered by template", func(t *testing.T) {
+			bucket := "scheduled-tasks"
+			prefix := "resources"
+
+			var out bytes.Buffer
+			wc := new(mocked.WriteCloser)
+			defer wc.AssertExpectations(t)
+			wc.On("Write").Return(&out, nil)
+			wc.On("Close").Return(nil)
+
+			ow := new(mocked.ObjectWriter)
+			defer ow.AssertExpectations(t)
+
+			objectPath := fmt.Sprintf("%s/proj/dags/ns-id/%s.py", prefix, testJob.Name)
+			ow.On("NewWriter", ctx, bucket, objectPath).Return(wc, nil)
+
+			pathTemplate, err := gcsStore.NewJobPathTemplate("{{.project}}/dags/{{.namespace}}/{{.job}}.py", "proj", ".py")
+			assert.Nil(t, err)
+			repo := &gcsStore.JobRepository{
+				ObjectWriter: ow,
+				Bucket:       bucket,
+				Prefix:       prefix,
+				Suffix:       ".py",
+				PathTemplate: pathTemplate,
+			}
+
+			err = repo.Save(ctx, models.Job{
+				Name:        testJob.Name,
+				NamespaceID: "ns-id",
+				Contents:    testJob.Contents,
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, string(testJob.Contents), out.String())
+		})
 		t.Run("should return error if writing to object fails", func(t *testing.T) {
 			writeError := errors.New("write error")
 			bucket := "foo"
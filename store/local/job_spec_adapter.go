@@ -49,6 +49,29 @@ type JobSchedule struct {
 	StartDate string `yaml:"start_date" json:"start_date" validate:"regexp=^\\d{4}-\\d{2}-\\d{2}$"`
 	EndDate   string `yaml:"end_date,omitempty" json:"end_date"`
 	Interval  string `yaml:"interval" validate:"isCron"`
+	Timezone  string `yaml:"timezone,omitempty" json:"timezone,omitempty"`
+
+	// CatchUp and DependsOnPast take precedence over flags of the same name
+	// in behavior when set, an explicit false opts a job out of the value
+	// inherited from parent this.yaml
+	CatchUp       *bool `yaml:"catch_up,omitempty" json:"catch_up,omitempty"`
+	DependsOnPast *bool `yaml:"depends_on_past,omitempty" json:"depends_on_past,omitempty"`
+}
+
+// catchUp of a job resolved from schedule and behavior
+func (conf *Job) catchUp() bool {
+	if conf.Schedule.CatchUp != nil {
+		return *conf.Schedule.CatchUp
+	}
+	return conf.Behavior.Catchup
+}
+
+// dependsOnPast of a job resolved from schedule and behavior
+func (conf *Job) dependsOnPast() bool {
+	if conf.Schedule.DependsOnPast != nil {
+		return *conf.Schedule.DependsOnPast
+	}
+	return conf.Behavior.DependsOnPast
 }
 
 type JobBehavior struct {
@@ -56,6 +79,11 @@ type JobBehavior struct {
 	Catchup       bool             `yaml:"catch_up" json:"catch_up"`
 	Retry         JobBehaviorRetry `yaml:"retry,omitempty" json:"retry"`
 	Notify        []JobNotifier    `yaml:"notify,omitempty" json:"notify"`
+	SLA           JobBehaviorSLA   `yaml:"sla,omitempty" json:"sla"`
+}
+
+type JobBehaviorSLA struct {
+	Duration string `yaml:"duration,omitempty" json:"duration,omitempty"`
 }
 
 type JobBehaviorRetry struct {
@@ -129,6 +157,17 @@ func (conf *Job) MergeFrom(parent Job) {
 	if conf.Schedule.EndDate == "" {
 		conf.Schedule.EndDate = parent.Schedule.EndDate
 	}
+	if conf.Schedule.Timezone == "" {
+		conf.Schedule.Timezone = parent.Schedule.Timezone
+	}
+	// a child enabling the flag in behavior should not be overridden by
+	// the parent schedule
+	if conf.Schedule.CatchUp == nil && !conf.Behavior.Catchup {
+		conf.Schedule.CatchUp = parent.Schedule.CatchUp
+	}
+	if conf.Schedule.DependsOnPast == nil && !conf.Behavior.DependsOnPast {
+		conf.Schedule.DependsOnPast = parent.Schedule.DependsOnPast
+	}
 
 	if conf.Behavior.Retry.ExponentialBackoff == false {
 		conf.Behavior.Retry.ExponentialBackoff = parent.Behavior.Retry.ExponentialBackoff
@@ -142,6 +181,9 @@ func (conf *Job) MergeFrom(parent Job) {
 	if conf.Behavior.DependsOnPast == false {
 		conf.Behavior.DependsOnPast = parent.Behavior.DependsOnPast
 	}
+	if conf.Behavior.SLA.Duration == "" {
+		conf.Behavior.SLA.Duration = parent.Behavior.SLA.Duration
+	}
 	if conf.Behavior.Catchup == false {
 		conf.Behavior.Catchup = parent.Behavior.Catchup
 	}
@@ -214,7 +256,7 @@ func (conf *Job) MergeFrom(parent Job) {
 	for _, dep := range parent.Dependencies {
 		alreadyExists := false
 		for _, cd := range conf.Dependencies {
-			if dep.JobName == cd.JobName && dep.Type == cd.Type {
+			if dep.sameAs(cd) {
 				alreadyExists = true
 				break
 			}
@@ -331,8 +373,27 @@ func (conf *Job) prepareWindow() (models.JobSpecTaskWindow, error) {
 }
 
 type JobDependency struct {
-	JobName string `yaml:"job"`
-	Type    string `yaml:"type,omitempty"`
+	JobName string             `yaml:"job,omitempty"`
+	Type    string             `yaml:"type,omitempty"`
+	HTTP    *JobHTTPDependency `yaml:"http,omitempty"`
+}
+
+// JobHTTPDependency is an http endpoint polled before running the job
+type JobHTTPDependency struct {
+	Name           string            `yaml:"name"`
+	URL            string            `yaml:"url"`
+	Method         string            `yaml:"method,omitempty"`
+	Headers        map[string]string `yaml:"headers,omitempty"`
+	ExpectedStatus int               `yaml:"expected_status,omitempty"`
+	ExpectedBody   string            `yaml:"expected_body,omitempty"`
+}
+
+// sameAs tells if both refer the same dependency
+func (dep JobDependency) sameAs(other JobDependency) bool {
+	if dep.HTTP != nil || other.HTTP != nil {
+		return dep.HTTP != nil && other.HTTP != nil && dep.HTTP.Name == other.HTTP.Name
+	}
+	return dep.JobName == other.JobName && dep.Type == other.Type
 }
 
 type JobSpecAdapter struct {
@@ -356,10 +417,29 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 		}
 		endDate = &end
 	}
+	if _, err := (models.JobSpecSchedule{Timezone: conf.Schedule.Timezone}).Location(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "invalid schedule of job %s", conf.Name)
+	}
 
 	// prep dirty dependencies
 	dependencies := map[string]models.JobSpecDependency{}
+	externalDependencies := models.ExternalDependency{}
 	for _, dep := range conf.Dependencies {
+		if dep.HTTP != nil || dep.Type == string(models.JobSpecDependencyTypeHTTP) {
+			if dep.HTTP == nil {
+				return models.JobSpec{}, errors.Errorf("spec reading error, http dependency of %s is not defined", conf.Name)
+			}
+			externalDependencies.HTTPDependencies = append(externalDependencies.HTTPDependencies, models.HTTPDependency{
+				Name:           dep.HTTP.Name,
+				URL:            dep.HTTP.URL,
+				Method:         dep.HTTP.Method,
+				Headers:        dep.HTTP.Headers,
+				ExpectedStatus: dep.HTTP.ExpectedStatus,
+				ExpectedBody:   dep.HTTP.ExpectedBody,
+			}.WithDefaults())
+			continue
+		}
+
 		depType := models.JobSpecDependencyTypeIntra
 		switch dep.Type {
 		case string(models.JobSpecDependencyTypeIntra):
@@ -374,9 +454,19 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 		}
 	}
 
+	if err := externalDependencies.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "spec reading error, invalid dependencies of %s", conf.Name)
+	}
+
 	// prep hooks
 	var hooks []models.JobSpecHook
+	hookNames := map[string]bool{}
 	for _, hook := range conf.Hooks {
+		if hookNames[hook.Name] {
+			return models.JobSpec{}, errors.Errorf("spec reading error, hook %s is attached more than once to %s", hook.Name, conf.Name)
+		}
+		hookNames[hook.Name] = true
+
 		adaptHook, err := hook.ToSpec(adapt.supportedHookRepo)
 		if err != nil {
 			return models.JobSpec{}, err
@@ -415,14 +505,37 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 			return models.JobSpec{}, err
 		}
 	}
+	retry := models.JobSpecBehaviorRetry{
+		Count:              conf.Behavior.Retry.Count,
+		Delay:              retryDelayDuration,
+		ExponentialBackoff: conf.Behavior.Retry.ExponentialBackoff,
+	}
+	if err := retry.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "spec reading error, invalid behavior of %s", conf.Name)
+	}
+
+	sla := models.JobSpecSLA{}
+	if conf.Behavior.SLA.Duration != "" {
+		sla.Duration, err = time.ParseDuration(conf.Behavior.SLA.Duration)
+		if err != nil {
+			return models.JobSpec{}, errors.Wrapf(err, "spec reading error, invalid sla of %s", conf.Name)
+		}
+	}
+	if err := sla.Validate(); err != nil {
+		return models.JobSpec{}, errors.Wrapf(err, "spec reading error, invalid behavior of %s", conf.Name)
+	}
 
 	var jobNotifiers []models.JobSpecNotifier
 	for _, notify := range conf.Behavior.Notify {
-		jobNotifiers = append(jobNotifiers, models.JobSpecNotifier{
+		notifier := models.JobSpecNotifier{
 			On:       models.JobEventType(notify.On),
 			Config:   notify.Config,
 			Channels: notify.Channels,
-		})
+		}
+		if err := notifier.Validate(); err != nil {
+			return models.JobSpec{}, errors.Wrapf(err, "spec reading error, invalid behavior of %s", conf.Name)
+		}
+		jobNotifiers = append(jobNotifiers, notifier)
 	}
 
 	job := models.JobSpec{
@@ -435,25 +548,24 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 			StartDate: startDate,
 			EndDate:   endDate,
 			Interval:  conf.Schedule.Interval,
+			Timezone:  conf.Schedule.Timezone,
 		},
 		Behavior: models.JobSpecBehavior{
-			CatchUp:       conf.Behavior.Catchup,
-			DependsOnPast: conf.Behavior.DependsOnPast,
-			Retry: models.JobSpecBehaviorRetry{
-				Count:              conf.Behavior.Retry.Count,
-				Delay:              retryDelayDuration,
-				ExponentialBackoff: conf.Behavior.Retry.ExponentialBackoff,
-			},
-			Notify: jobNotifiers,
+			CatchUp:       conf.catchUp(),
+			DependsOnPast: conf.dependsOnPast(),
+			Retry:         retry,
+			Notify:        jobNotifiers,
+			SLA:           sla,
 		},
 		Task: models.JobSpecTask{
 			Unit:   execUnit,
 			Config: taskConf,
 			Window: window,
 		},
-		Assets:       models.JobAssets{}.FromMap(conf.Asset),
-		Dependencies: dependencies,
-		Hooks:        hooks,
+		Assets:               models.JobAssets{}.FromMap(conf.Asset),
+		Dependencies:         dependencies,
+		Hooks:                hooks,
+		ExternalDependencies: externalDependencies,
 	}
 	return job, nil
 }
@@ -480,6 +592,10 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 	if spec.Behavior.Retry.Delay.Nanoseconds() > 0 {
 		retryDelayDuration = spec.Behavior.Retry.Delay.String()
 	}
+	slaDuration := ""
+	if spec.Behavior.SLA.Duration > 0 {
+		slaDuration = spec.Behavior.SLA.Duration.String()
+	}
 
 	taskSchema, err := spec.Task.Unit.GetTaskSchema(context.Background(), models.GetTaskSchemaRequest{})
 	if err != nil {
@@ -504,6 +620,7 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 		Schedule: JobSchedule{
 			Interval:  spec.Schedule.Interval,
 			StartDate: spec.Schedule.StartDate.Format(models.JobDatetimeLayout),
+			Timezone:  spec.Schedule.Timezone,
 		},
 		Behavior: JobBehavior{
 			DependsOnPast: spec.Behavior.DependsOnPast,
@@ -514,6 +631,9 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 				ExponentialBackoff: spec.Behavior.Retry.ExponentialBackoff,
 			},
 			Notify: notifiers,
+			SLA: JobBehaviorSLA{
+				Duration: slaDuration,
+			},
 		},
 		Task: JobTask{
 			Name:   taskSchema.Name,
@@ -538,6 +658,19 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 			Type:    dep.Type.String(),
 		})
 	}
+	for _, dep := range spec.ExternalDependencies.HTTPDependencies {
+		parsed.Dependencies = append(parsed.Dependencies, JobDependency{
+			Type: models.JobSpecDependencyTypeHTTP.String(),
+			HTTP: &JobHTTPDependency{
+				Name:           dep.Name,
+				URL:            dep.URL,
+				Method:         dep.Method,
+				Headers:        dep.Headers,
+				ExpectedStatus: dep.ExpectedStatus,
+				ExpectedBody:   dep.ExpectedBody,
+			},
+		})
+	}
 
 	// prep hooks
 	for _, hook := range spec.Hooks {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/odpf/optimus/models"
 
@@ -30,6 +31,8 @@ behavior:
     - on: test
       channel:
         - test://hello
+  sla:
+    duration: 2h0m0s
 task:
   name: bq2bq
   config:
@@ -62,15 +65,137 @@ hooks: []
 		adapter := local.NewJobSpecAdapter(allTasksRepo, nil)
 		modelJob, err := adapter.ToSpec(localJobParsed)
 		assert.Nil(t, err)
+		assert.Equal(t, time.Hour*2, modelJob.Behavior.SLA.Duration)
 
 		localJobBack, err := adapter.FromSpec(modelJob)
 		assert.Nil(t, err)
 
 		assert.Equal(t, localJobParsed, localJobBack)
 	})
+	t.Run("should convert job with http dependencies from yaml to optimus model & back successfully", func(t *testing.T) {
+		yamlSpec := `
+version: 1
+name: test_job
+owner: test@example.com
+schedule:
+  start_date: "2021-02-03"
+  interval: 0 2 * * *
+task:
+  name: bq2bq
+  window:
+    size: 24h
+    offset: 0
+    truncate_to: d
+dependencies:
+- type: http
+  http:
+    name: inventory-api
+    url: https://inventory.example.io/ready
+    method: POST
+    headers:
+      Authorization: Bearer token
+    expected_status: 204
+    expected_body: ready
+hooks: []
+`
+		var localJobParsed local.Job
+		err := yaml.Unmarshal([]byte(yamlSpec), &localJobParsed)
+		assert.Nil(t, err)
+
+		bq2bqTransformer := new(mock.TaskPlugin)
+		bq2bqTransformer.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+			Name: "bq2bq",
+		}, nil)
+
+		allTasksRepo := new(mock.SupportedTaskRepo)
+		allTasksRepo.On("GetByName", "bq2bq").Return(bq2bqTransformer, nil)
+
+		adapter := local.NewJobSpecAdapter(allTasksRepo, nil)
+		modelJob, err := adapter.ToSpec(localJobParsed)
+		assert.Nil(t, err)
+		assert.Empty(t, modelJob.Dependencies)
+		assert.Equal(t, models.ExternalDependency{
+			HTTPDependencies: []models.HTTPDependency{
+				{
+					Name:           "inventory-api",
+					URL:            "https://inventory.example.io/ready",
+					Method:         "POST",
+					Headers:        map[string]string{"Authorization": "Bearer token"},
+					ExpectedStatus: 204,
+					ExpectedBody:   "ready",
+				},
+			},
+		}, modelJob.ExternalDependencies)
+
+		localJobBack, err := adapter.FromSpec(modelJob)
+		assert.Nil(t, err)
+		assert.Equal(t, localJobParsed.Dependencies, localJobBack.Dependencies)
+	})
+	t.Run("should fail to convert job with invalid http dependency", func(t *testing.T) {
+		allTasksRepo := new(mock.SupportedTaskRepo)
+		adapter := local.NewJobSpecAdapter(allTasksRepo, nil)
+		_, err := adapter.ToSpec(local.Job{
+			Name: "test_job",
+			Schedule: local.JobSchedule{
+				StartDate: "2021-02-03",
+			},
+			Dependencies: []local.JobDependency{
+				{Type: "http", HTTP: &local.JobHTTPDependency{Name: "inventory-api", URL: "inventory.example.io"}},
+			},
+		})
+		assert.EqualError(t, err, "spec reading error, invalid dependencies of test_job: "+
+			"http dependency inventory-api has an invalid url: inventory.example.io")
+	})
+	t.Run("should fail to convert job with a hook attached more than once", func(t *testing.T) {
+		hookUnit := new(mock.HookPlugin)
+		allHooksRepo := new(mock.SupportedHookRepo)
+		allHooksRepo.On("GetByName", "transporter").Return(hookUnit, nil)
+		adapter := local.NewJobSpecAdapter(new(mock.SupportedTaskRepo), allHooksRepo)
+		_, err := adapter.ToSpec(local.Job{
+			Name: "test_job",
+			Schedule: local.JobSchedule{
+				StartDate: "2021-02-03",
+			},
+			Hooks: []local.JobHook{{Name: "transporter"}, {Name: "transporter"}},
+		})
+		assert.EqualError(t, err, "spec reading error, hook transporter is attached more than once to test_job")
+	})
+	t.Run("should prefer catch_up and depends_on_past of schedule over behavior", func(t *testing.T) {
+		yamlSpec := `
+version: 1
+name: test_job
+schedule:
+  start_date: "2021-02-03"
+  interval: 0 2 * * *
+  catch_up: false
+  depends_on_past: true
+behavior:
+  catch_up: true
+task:
+  name: bq2bq
+  window:
+    size: 24h
+    offset: 0
+    truncate_to: d
+`
+		var localJobParsed local.Job
+		err := yaml.Unmarshal([]byte(yamlSpec), &localJobParsed)
+		assert.Nil(t, err)
+
+		bq2bqTransformer := new(mock.TaskPlugin)
+		allTasksRepo := new(mock.SupportedTaskRepo)
+		allTasksRepo.On("GetByName", "bq2bq").Return(bq2bqTransformer, nil)
+
+		adapter := local.NewJobSpecAdapter(allTasksRepo, nil)
+		modelJob, err := adapter.ToSpec(localJobParsed)
+		assert.Nil(t, err)
+		assert.False(t, modelJob.Behavior.CatchUp)
+		assert.True(t, modelJob.Behavior.DependsOnPast)
+	})
 }
 
 func TestJob_MergeFrom(t *testing.T) {
+	trueValue, falseValue := true, false
 	type fields struct {
 		child    local.Job
 		expected local.Job
@@ -117,11 +242,15 @@ func TestJob_MergeFrom(t *testing.T) {
 							Delay:              "2m",
 							ExponentialBackoff: false,
 						},
+						SLA: local.JobBehaviorSLA{
+							Duration: "2h",
+						},
 					},
 					Schedule: local.JobSchedule{
 						StartDate: "2020",
 						EndDate:   "2021",
 						Interval:  "@daily",
+						Timezone:  "Asia/Jakarta",
 					},
 				},
 			},
@@ -140,11 +269,39 @@ func TestJob_MergeFrom(t *testing.T) {
 							Delay:              "2m",
 							ExponentialBackoff: false,
 						},
+						SLA: local.JobBehaviorSLA{
+							Duration: "2h",
+						},
 					},
 					Schedule: local.JobSchedule{
 						StartDate: "2020",
 						EndDate:   "2021",
 						Interval:  "@daily",
+						Timezone:  "Asia/Jakarta",
+					},
+				},
+			},
+		},
+		{
+			name: "should not inherit catch_up of parent schedule if child opts out",
+			fields: fields{
+				child: local.Job{
+					Schedule: local.JobSchedule{
+						CatchUp: &falseValue,
+					},
+				},
+				expected: local.Job{
+					Schedule: local.JobSchedule{
+						CatchUp:       &falseValue,
+						DependsOnPast: &trueValue,
+					},
+				},
+			},
+			args: args{
+				parent: local.Job{
+					Schedule: local.JobSchedule{
+						CatchUp:       &trueValue,
+						DependsOnPast: &trueValue,
 					},
 				},
 			},
@@ -363,11 +520,15 @@ func TestJob_MergeFrom(t *testing.T) {
 			assert.Equal(t, tt.fields.expected.Schedule.Interval, tt.fields.child.Schedule.Interval)
 			assert.Equal(t, tt.fields.expected.Schedule.StartDate, tt.fields.child.Schedule.StartDate)
 			assert.Equal(t, tt.fields.expected.Schedule.EndDate, tt.fields.child.Schedule.EndDate)
+			assert.Equal(t, tt.fields.expected.Schedule.Timezone, tt.fields.child.Schedule.Timezone)
+			assert.Equal(t, tt.fields.expected.Schedule.CatchUp, tt.fields.child.Schedule.CatchUp)
+			assert.Equal(t, tt.fields.expected.Schedule.DependsOnPast, tt.fields.child.Schedule.DependsOnPast)
 			assert.Equal(t, tt.fields.expected.Behavior.DependsOnPast, tt.fields.child.Behavior.DependsOnPast)
 			assert.Equal(t, tt.fields.expected.Behavior.Catchup, tt.fields.child.Behavior.Catchup)
 			assert.Equal(t, tt.fields.expected.Behavior.Retry.Count, tt.fields.child.Behavior.Retry.Count)
 			assert.Equal(t, tt.fields.expected.Behavior.Retry.Delay, tt.fields.child.Behavior.Retry.Delay)
 			assert.Equal(t, tt.fields.expected.Behavior.Retry.ExponentialBackoff, tt.fields.child.Behavior.Retry.ExponentialBackoff)
+			assert.Equal(t, tt.fields.expected.Behavior.SLA.Duration, tt.fields.child.Behavior.SLA.Duration)
 			assert.ElementsMatch(t, tt.fields.expected.Dependencies, tt.fields.child.Dependencies)
 			assert.Equal(t, len(tt.fields.expected.Labels), len(tt.fields.child.Labels))
 			assert.Equal(t, tt.fields.expected.Task.Name, tt.fields.child.Task.Name)
@@ -148,6 +148,10 @@ func (repo *jobRepository) GetByDestination(jobName string) (models.JobSpec, mod
 	panic("GetByDestination() should not be invoked with local.JobSpecRepo")
 }
 
+func (repo *jobRepository) GetAllByDestination(destination string) ([]models.ProjectJobPair, error) {
+	panic("GetAllByDestination() should not be invoked with local.JobSpecRepo")
+}
+
 // Delete deletes a requested job by name
 func (repo *jobRepository) Delete(jobName string) error {
 	panic("unimplemented")
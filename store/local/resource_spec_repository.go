@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/spf13/afero"
@@ -113,6 +114,29 @@ func (repo *resourceRepository) GetAll() ([]models.ResourceSpec, error) {
 	return resourceSpecs, nil
 }
 
+// GetPage returns at most limit resources ordered by name, starting after
+// the resource named after
+func (repo *resourceRepository) GetPage(after string, limit int) ([]models.ResourceSpec, error) {
+	resourceSpecs, err := repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(resourceSpecs, func(i, j int) bool {
+		return resourceSpecs[i].Name < resourceSpecs[j].Name
+	})
+
+	var page []models.ResourceSpec
+	for _, resourceSpec := range resourceSpecs {
+		if len(page) == limit {
+			break
+		}
+		if resourceSpec.Name > after {
+			page = append(page, resourceSpec)
+		}
+	}
+	return page, nil
+}
+
 // GetByName returns a job requested by the name
 func (repo *resourceRepository) GetByName(jobName string) (models.ResourceSpec, error) {
 	if strings.TrimSpace(jobName) == "" {
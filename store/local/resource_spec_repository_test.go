@@ -220,6 +220,22 @@ spec:
 			sort.Slice(result, func(i, j int) bool { return result[i].Name > result[j].Name })
 			assert.Equal(t, resSpecs, result)
 		})
+		t.Run("should read a page of resources ordered by name", func(t *testing.T) {
+			appFS := afero.NewMemMapFs()
+			for idx, resSpec := range resSpecs {
+				appFS.MkdirAll(resSpec.Name, 0755)
+				afero.WriteFile(appFS, filepath.Join(resSpec.Name, local.ResourceSpecFileName), []byte(content[idx]), 0644)
+			}
+
+			repo := local.NewResourceSpecRepository(appFS, datastorer)
+			result, err := repo.GetPage("", 1)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.ResourceSpec{resSpecs[1]}, result)
+
+			result, err = repo.GetPage("proj.datas", 5)
+			assert.Nil(t, err)
+			assert.Equal(t, []models.ResourceSpec{resSpecs[0]}, result)
+		})
 		t.Run("should return ErrNoResources if the root directory does not exist", func(t *testing.T) {
 			repo := local.NewResourceSpecRepository(afero.NewMemMapFs(), datastorer)
 			_, err := repo.GetAll()
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+
+	"github.com/odpf/optimus/models"
+)
+
+// AuditEvent is a request which changed state of optimus, project is kept
+// by name so events of failed registrations and deleted projects are kept
+type AuditEvent struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	Method    string `gorm:"not null"`
+	Identity  string
+	UserAgent string
+	Peer      string
+	RequestID string
+
+	ProjectName string
+	Namespace   string
+
+	RequestDigest string `gorm:"not null"`
+	Code          string `gorm:"not null"`
+	Message       string
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (e AuditEvent) FromSpec(event models.AuditEvent) AuditEvent {
+	id := event.ID
+	if id == uuid.Nil {
+		id = uuid.New()
+	}
+	return AuditEvent{
+		ID:            id,
+		Method:        event.Method,
+		Identity:      event.Identity,
+		UserAgent:     event.UserAgent,
+		Peer:          event.Peer,
+		RequestID:     event.RequestID,
+		ProjectName:   event.ProjectName,
+		Namespace:     event.Namespace,
+		RequestDigest: event.RequestDigest,
+		Code:          event.Code,
+		Message:       event.Message,
+		CreatedAt:     event.CreatedAt,
+	}
+}
+
+func (e AuditEvent) ToSpec() models.AuditEvent {
+	return models.AuditEvent{
+		ID:            e.ID,
+		Method:        e.Method,
+		Identity:      e.Identity,
+		UserAgent:     e.UserAgent,
+		Peer:          e.Peer,
+		RequestID:     e.RequestID,
+		ProjectName:   e.ProjectName,
+		Namespace:     e.Namespace,
+		RequestDigest: e.RequestDigest,
+		Code:          e.Code,
+		Message:       e.Message,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+type auditEventRepository struct {
+	db *gorm.DB
+}
+
+func (repo *auditEventRepository) Save(ctx context.Context, event models.AuditEvent) error {
+	auditEvent := AuditEvent{}.FromSpec(event)
+	return repo.db.Create(&auditEvent).Error
+}
+
+func (repo *auditEventRepository) List(ctx context.Context, filter models.AuditEventFilter) ([]models.AuditEvent, error) {
+	query := repo.db
+	if filter.ProjectName != "" {
+		query = query.Where("project_name = ?", filter.ProjectName)
+	}
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+	if filter.Identity != "" {
+		query = query.Where("identity = ?", filter.Identity)
+	}
+	if filter.UserAgent != "" {
+		query = query.Where("user_agent = ?", filter.UserAgent)
+	}
+	if !filter.StartTime.IsZero() {
+		query = query.Where("created_at >= ?", filter.StartTime)
+	}
+	if !filter.EndTime.IsZero() {
+		query = query.Where("created_at <= ?", filter.EndTime)
+	}
+	query = query.Order("created_at DESC").Offset(filter.Offset)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []AuditEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, errors.Wrap(err, "failed to list audit events")
+	}
+	specs := []models.AuditEvent{}
+	for _, e := range events {
+		specs = append(specs, e.ToSpec())
+	}
+	return specs, nil
+}
+
+func NewAuditEventRepository(db *gorm.DB) *auditEventRepository {
+	return &auditEventRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,84 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestAuditEventRepository(t *testing.T) {
+	ctx := context.Background()
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+		return dbConn
+	}
+	at := func(hour int) time.Time {
+		return time.Date(2021, 11, 1, hour, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("should save events and list them latest first as per filter", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		repo := NewAuditEventRepository(db)
+		events := []models.AuditEvent{
+			{Method: "/odpf.optimus.RuntimeService/RegisterProject", UserAgent: "optimus/cli", ProjectName: "proj-a",
+				RequestDigest: "d1", Code: "OK", CreatedAt: at(1)},
+			{Method: "/odpf.optimus.RuntimeService/RegisterSecret", UserAgent: "optimus/cli", ProjectName: "proj-a",
+				RequestDigest: "d2", Code: "Internal", Message: "failed", CreatedAt: at(2)},
+			{Method: "/odpf.optimus.RuntimeService/RegisterSecret", Identity: "jane@example.com", UserAgent: "curl", ProjectName: "proj-b",
+				RequestDigest: "d3", Code: "OK", CreatedAt: at(3)},
+		}
+		for _, event := range events {
+			assert.Nil(t, repo.Save(ctx, event))
+		}
+
+		all, err := repo.List(ctx, models.AuditEventFilter{})
+		assert.Nil(t, err)
+		assert.Len(t, all, 3)
+		assert.Equal(t, "d3", all[0].RequestDigest)
+
+		filtered, err := repo.List(ctx, models.AuditEventFilter{
+			ProjectName: "proj-a",
+			Method:      "/odpf.optimus.RuntimeService/RegisterSecret",
+		})
+		assert.Nil(t, err)
+		assert.Len(t, filtered, 1)
+		assert.Equal(t, "failed", filtered[0].Message)
+
+		byIdentity, err := repo.List(ctx, models.AuditEventFilter{Identity: "jane@example.com"})
+		assert.Nil(t, err)
+		assert.Len(t, byIdentity, 1)
+		assert.Equal(t, "d3", byIdentity[0].RequestDigest)
+
+		paged, err := repo.List(ctx, models.AuditEventFilter{StartTime: at(2), Offset: 1, Limit: 5})
+		assert.Nil(t, err)
+		assert.Len(t, paged, 1)
+		assert.Equal(t, "d2", paged[0].RequestDigest)
+	})
+}
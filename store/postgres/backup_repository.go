@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"gorm.io/datatypes"
+
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+// Backup keeps the spec of resource as it was when backup was taken, so
+// the resource can be restored even after its spec is changed or deleted
+type Backup struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	NamespaceID uuid.UUID `gorm:"not null"`
+	Datastore   string    `gorm:"not null"`
+
+	ResourceID      uuid.UUID
+	ResourceName    string `gorm:"not null"`
+	ResourceType    string `gorm:"not null"`
+	ResourceVersion int
+	Spec            []byte
+	Assets          datatypes.JSON
+	Labels          datatypes.JSON
+
+	URN         string `gorm:"not null"`
+	Description string
+	ExpiresAt   time.Time `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null" json:"updated_at"`
+}
+
+func (b Backup) FromSpec(spec models.BackupSpec, namespace models.NamespaceSpec) (Backup, error) {
+	resource, err := Resource{}.FromSpec(spec.Resource)
+	if err != nil {
+		return Backup{}, err
+	}
+	return Backup{
+		ID:              spec.ID,
+		NamespaceID:     namespace.ID,
+		Datastore:       resource.Datastore,
+		ResourceID:      resource.ID,
+		ResourceName:    resource.Name,
+		ResourceType:    resource.Type,
+		ResourceVersion: resource.Version,
+		Spec:            resource.Spec,
+		Assets:          resource.Assets,
+		Labels:          resource.Labels,
+		URN:             spec.URN,
+		Description:     spec.Description,
+		ExpiresAt:       spec.ExpiresAt.UTC(),
+	}, nil
+}
+
+func (b Backup) ToSpec(ds models.Datastorer) (models.BackupSpec, error) {
+	resourceSpec, err := Resource{
+		ID:      b.ResourceID,
+		Version: b.ResourceVersion,
+		Name:    b.ResourceName,
+		Type:    b.ResourceType,
+		Spec:    b.Spec,
+		Assets:  b.Assets,
+		Labels:  b.Labels,
+	}.ToSpec(ds)
+	if err != nil {
+		return models.BackupSpec{}, err
+	}
+	return models.BackupSpec{
+		ID:          b.ID,
+		Resource:    resourceSpec,
+		URN:         b.URN,
+		Description: b.Description,
+		ExpiresAt:   b.ExpiresAt,
+		CreatedAt:   b.CreatedAt,
+	}, nil
+}
+
+type backupRepository struct {
+	db        *gorm.DB
+	namespace models.NamespaceSpec
+	datastore models.Datastorer
+}
+
+func (repo *backupRepository) Save(spec models.BackupSpec) error {
+	backup, err := Backup{}.FromSpec(spec, repo.namespace)
+	if err != nil {
+		return err
+	}
+	return repo.db.Create(&backup).Error
+}
+
+func (repo *backupRepository) GetByID(id uuid.UUID) (models.BackupSpec, error) {
+	var b Backup
+	if err := repo.db.Where("namespace_id = ? AND datastore = ? AND id = ?", repo.namespace.ID, repo.datastore.Name(), id).Find(&b).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.BackupSpec{}, store.ErrResourceNotFound
+		}
+		return models.BackupSpec{}, err
+	}
+	return b.ToSpec(repo.datastore)
+}
+
+func (repo *backupRepository) GetAll() ([]models.BackupSpec, error) {
+	specs := []models.BackupSpec{}
+	backups := []Backup{}
+	if err := repo.db.Where("namespace_id = ? AND datastore = ?", repo.namespace.ID, repo.datastore.Name()).
+		Order("created_at DESC").Find(&backups).Error; err != nil {
+		return specs, err
+	}
+	for _, b := range backups {
+		adapted, err := b.ToSpec(repo.datastore)
+		if err != nil {
+			return specs, errors.Wrap(err, "failed to adapt backup")
+		}
+		specs = append(specs, adapted)
+	}
+	return specs, nil
+}
+
+func NewBackupRepository(db *gorm.DB, namespace models.NamespaceSpec, ds models.Datastorer) *backupRepository {
+	return &backupRepository{
+		db:        db,
+		namespace: namespace,
+		datastore: ds,
+	}
+}
@@ -0,0 +1,124 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/mock"
+	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
+)
+
+func TestBackupRepository(t *testing.T) {
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "t-optimus-project",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+
+	dsTypeTableAdapter := new(mock.DatastoreTypeAdapter)
+	dsTypeTableController := new(mock.DatastoreTypeController)
+	dsTypeTableController.On("Adapter").Return(dsTypeTableAdapter)
+	datastorer := new(mock.Datastorer)
+	datastorer.On("Types").Return(map[models.ResourceType]models.DatastoreTypeController{
+		models.ResourceTypeTable: dsTypeTableController,
+	})
+	datastorer.On("Name").Return("DS")
+
+	resourceSpec := models.ResourceSpec{
+		ID:        uuid.Must(uuid.NewRandom()),
+		Version:   1,
+		Name:      "proj.datas.test",
+		Type:      models.ResourceTypeTable,
+		Datastore: datastorer,
+		Labels:    map[string]string{"owner": "optimus"},
+	}
+	dsTypeTableAdapter.On("ToYaml", resourceSpec).Return([]byte("some binary data"), nil)
+	dsTypeTableAdapter.On("FromYaml", []byte("some binary data")).Return(resourceSpec, nil)
+
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		assert.Nil(t, NewProjectRepository(dbConn, hash).Save(projectSpec))
+		assert.Nil(t, NewNamespaceRepository(dbConn, projectSpec, hash).Save(namespaceSpec))
+		return dbConn
+	}
+
+	t.Run("should save backup and read it back with spec of resource", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		backupSpec := models.BackupSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Resource:    resourceSpec,
+			URN:         "proj.optimus_backup.datas_test_1",
+			Description: "before update",
+			ExpiresAt:   time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		}
+
+		repo := NewBackupRepository(db, namespaceSpec, datastorer)
+		assert.Nil(t, repo.Save(backupSpec))
+
+		checkModel, err := repo.GetByID(backupSpec.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, backupSpec.URN, checkModel.URN)
+		assert.Equal(t, backupSpec.Description, checkModel.Description)
+		assert.True(t, backupSpec.ExpiresAt.Equal(checkModel.ExpiresAt))
+		assert.Equal(t, resourceSpec.Name, checkModel.Resource.Name)
+		assert.Equal(t, resourceSpec.ID, checkModel.Resource.ID)
+		assert.Equal(t, resourceSpec.Labels, checkModel.Resource.Labels)
+
+		all, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Len(t, all, 1)
+	})
+	t.Run("should not find backups of other namespaces", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		backupSpec := models.BackupSpec{
+			ID:        uuid.Must(uuid.NewRandom()),
+			Resource:  resourceSpec,
+			URN:       "proj.optimus_backup.datas_test_1",
+			ExpiresAt: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		}
+		assert.Nil(t, NewBackupRepository(db, namespaceSpec, datastorer).Save(backupSpec))
+
+		otherNamespace := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-2",
+			ProjectSpec: projectSpec,
+		}
+		_, err := NewBackupRepository(db, otherNamespace, datastorer).GetByID(backupSpec.ID)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+
+	"github.com/odpf/optimus/models"
+)
+
+// JobChecksum is checksum of the compiled job last uploaded for a job
+type JobChecksum struct {
+	NamespaceID uuid.UUID `gorm:"primary_key;type:uuid"`
+	JobName     string    `gorm:"primary_key"`
+	Checksum    string    `gorm:"not null"`
+
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+type jobChecksumRepository struct {
+	db        *gorm.DB
+	namespace models.NamespaceSpec
+}
+
+func (repo *jobChecksumRepository) GetAll(ctx context.Context) (map[string]string, error) {
+	var checksums []JobChecksum
+	if err := repo.db.Where("namespace_id = ?", repo.namespace.ID).Find(&checksums).Error; err != nil {
+		return nil, err
+	}
+	checksumsByName := map[string]string{}
+	for _, c := range checksums {
+		checksumsByName[c.JobName] = c.Checksum
+	}
+	return checksumsByName, nil
+}
+
+func (repo *jobChecksumRepository) Save(ctx context.Context, jobName, checksum string) error {
+	// save updates the row with same primary key, inserting it if missing
+	return repo.db.Save(&JobChecksum{
+		NamespaceID: repo.namespace.ID,
+		JobName:     jobName,
+		Checksum:    checksum,
+	}).Error
+}
+
+func (repo *jobChecksumRepository) Delete(ctx context.Context, jobName string) error {
+	return repo.db.Where("namespace_id = ? AND job_name = ?", repo.namespace.ID, jobName).
+		Delete(&JobChecksum{}).Error
+}
+
+func NewJobChecksumRepository(db *gorm.DB, namespace models.NamespaceSpec) *jobChecksumRepository {
+	return &jobChecksumRepository{
+		db:        db,
+		namespace: namespace,
+	}
+}
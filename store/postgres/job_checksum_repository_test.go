@@ -0,0 +1,88 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestJobChecksumRepository(t *testing.T) {
+	ctx := context.Background()
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "t-optimus-project",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		assert.Nil(t, NewProjectRepository(dbConn, hash).Save(projectSpec))
+		assert.Nil(t, NewNamespaceRepository(dbConn, projectSpec, hash).Save(namespaceSpec))
+		return dbConn
+	}
+
+	t.Run("should save, overwrite and delete checksums", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		repo := NewJobChecksumRepository(db, namespaceSpec)
+		assert.Nil(t, repo.Save(ctx, "job-1", "abc"))
+		assert.Nil(t, repo.Save(ctx, "job-2", "def"))
+		assert.Nil(t, repo.Save(ctx, "job-1", "xyz"))
+
+		checksums, err := repo.GetAll(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"job-1": "xyz", "job-2": "def"}, checksums)
+
+		assert.Nil(t, repo.Delete(ctx, "job-2"))
+		checksums, err = repo.GetAll(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{"job-1": "xyz"}, checksums)
+	})
+	t.Run("should not return checksums of other namespaces", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		assert.Nil(t, NewJobChecksumRepository(db, namespaceSpec).Save(ctx, "job-1", "abc"))
+
+		otherNamespace := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-2",
+			ProjectSpec: projectSpec,
+		}
+		checksums, err := NewJobChecksumRepository(db, otherNamespace).GetAll(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, checksums, 0)
+	})
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/datatypes"
+
+	"github.com/odpf/optimus/models"
+)
+
+// JobEvent is an event posted by scheduler about a run of job, name of the
+// job is kept so events can still be read after the job is deleted
+type JobEvent struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	NamespaceID uuid.UUID `gorm:"not null"`
+	JobID       uuid.UUID
+	JobName     string `gorm:"not null"`
+
+	Type  string `gorm:"not null"`
+	Value datatypes.JSON
+
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+}
+
+func (e JobEvent) FromSpec(jobSpec models.JobSpec, event models.JobEvent, namespace models.NamespaceSpec) (JobEvent, error) {
+	value, err := json.Marshal((&structpb.Struct{Fields: event.Value}).AsMap())
+	if err != nil {
+		return JobEvent{}, err
+	}
+	return JobEvent{
+		ID:          uuid.New(),
+		NamespaceID: namespace.ID,
+		JobID:       jobSpec.ID,
+		JobName:     jobSpec.Name,
+		Type:        string(event.Type),
+		Value:       value,
+	}, nil
+}
+
+func (e JobEvent) ToSpec() (models.JobEvent, error) {
+	var rawValue map[string]interface{}
+	if len(e.Value) > 0 {
+		if err := json.Unmarshal(e.Value, &rawValue); err != nil {
+			return models.JobEvent{}, err
+		}
+	}
+	value, err := structpb.NewStruct(rawValue)
+	if err != nil {
+		return models.JobEvent{}, err
+	}
+	return models.JobEvent{
+		Type:      models.JobEventType(e.Type),
+		Value:     value.GetFields(),
+		CreatedAt: e.CreatedAt,
+	}, nil
+}
+
+type jobEventRepository struct {
+	db        *gorm.DB
+	namespace models.NamespaceSpec
+}
+
+func (repo *jobEventRepository) Save(jobSpec models.JobSpec, event models.JobEvent) error {
+	jobEvent, err := JobEvent{}.FromSpec(jobSpec, event, repo.namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to adapt job event")
+	}
+	return repo.db.Create(&jobEvent).Error
+}
+
+func (repo *jobEventRepository) GetByJobName(name string) ([]models.JobEvent, error) {
+	specs := []models.JobEvent{}
+	events := []JobEvent{}
+	if err := repo.db.Where("namespace_id = ? AND job_name = ?", repo.namespace.ID, name).
+		Order("created_at DESC").Find(&events).Error; err != nil {
+		return specs, err
+	}
+	for _, e := range events {
+		adapted, err := e.ToSpec()
+		if err != nil {
+			return specs, errors.Wrap(err, "failed to adapt job event")
+		}
+		specs = append(specs, adapted)
+	}
+	return specs, nil
+}
+
+func NewJobEventRepository(db *gorm.DB, namespace models.NamespaceSpec) *jobEventRepository {
+	return &jobEventRepository{
+		db:        db,
+		namespace: namespace,
+	}
+}
@@ -0,0 +1,101 @@
+// +build !unit_test
+
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/odpf/optimus/models"
+)
+
+func TestJobEventRepository(t *testing.T) {
+	projectSpec := models.ProjectSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "t-optimus-project",
+	}
+	namespaceSpec := models.NamespaceSpec{
+		ID:          uuid.Must(uuid.NewRandom()),
+		Name:        "dev-team-1",
+		ProjectSpec: projectSpec,
+	}
+	jobSpec := models.JobSpec{
+		ID:   uuid.Must(uuid.NewRandom()),
+		Name: "transform-tables",
+	}
+	hash, _ := models.NewApplicationSecret("32charshtesthashtesthashtesthash")
+
+	DBSetup := func() *gorm.DB {
+		dbURL, ok := os.LookupEnv("TEST_OPTIMUS_DB_URL")
+		if !ok {
+			panic("unable to find TEST_OPTIMUS_DB_URL env var")
+		}
+		dbConn, err := Connect(dbURL, 1, 1)
+		if err != nil {
+			panic(err)
+		}
+		m, err := NewHTTPFSMigrator(dbURL)
+		if err != nil {
+			panic(err)
+		}
+		if err := m.Drop(); err != nil {
+			panic(err)
+		}
+		if err := Migrate(dbURL); err != nil {
+			panic(err)
+		}
+
+		assert.Nil(t, NewProjectRepository(dbConn, hash).Save(projectSpec))
+		assert.Nil(t, NewNamespaceRepository(dbConn, projectSpec, hash).Save(namespaceSpec))
+		return dbConn
+	}
+
+	t.Run("should save events and read them back latest first", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		eventValues, _ := structpb.NewStruct(map[string]interface{}{
+			"run_id":       "scheduled__2021-03-01T00:00:00+00:00",
+			"scheduled_at": "2021-03-01T00:00:00Z",
+		})
+		repo := NewJobEventRepository(db, namespaceSpec)
+		assert.Nil(t, repo.Save(jobSpec, models.JobEvent{
+			Type:  models.JobEventTypeStart,
+			Value: eventValues.GetFields(),
+		}))
+		assert.Nil(t, repo.Save(jobSpec, models.JobEvent{
+			Type:  models.JobEventTypeSuccess,
+			Value: eventValues.GetFields(),
+		}))
+
+		events, err := repo.GetByJobName(jobSpec.Name)
+		assert.Nil(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, models.JobEventTypeSuccess, events[0].Type)
+		assert.Equal(t, models.JobEventTypeStart, events[1].Type)
+		assert.Equal(t, "2021-03-01T00:00:00Z", events[0].Value["scheduled_at"].GetStringValue())
+		assert.False(t, events[0].CreatedAt.IsZero())
+	})
+	t.Run("should not return events of other namespaces", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		assert.Nil(t, NewJobEventRepository(db, namespaceSpec).Save(jobSpec, models.JobEvent{
+			Type: models.JobEventTypeFailure,
+		}))
+
+		otherNamespace := models.NamespaceSpec{
+			ID:          uuid.Must(uuid.NewRandom()),
+			Name:        "dev-team-2",
+			ProjectSpec: projectSpec,
+		}
+		events, err := NewJobEventRepository(db, otherNamespace).GetByJobName(jobSpec.Name)
+		assert.Nil(t, err)
+		assert.Len(t, events, 0)
+	})
+}
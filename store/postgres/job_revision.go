@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/odpf/optimus/models"
+	"gorm.io/datatypes"
+)
+
+// JobRevision is a saved version of a job spec
+type JobRevision struct {
+	ID uuid.UUID `gorm:"primary_key;type:uuid"`
+
+	ProjectID   uuid.UUID `gorm:"not null"`
+	NamespaceID uuid.UUID `gorm:"not null"`
+
+	Name     string `gorm:"not null"`
+	Revision int    `gorm:"not null"`
+	// Checksum of the spec, a revision is only added when it changes
+	Checksum string         `gorm:"not null"`
+	Spec     datatypes.JSON `gorm:"not null"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// jobRevisionSpec is the content of a job kept in a revision, ids,
+// timestamps and related rows of the job are left out
+type jobRevisionSpec struct {
+	Version          int
+	Name             string
+	Owner            string
+	Description      string
+	Labels           datatypes.JSON
+	StartDate        time.Time
+	EndDate          *time.Time
+	Interval         string
+	Destination      string
+	Destinations     datatypes.JSON
+	Dependencies     datatypes.JSON
+	Behavior         datatypes.JSON
+	TaskName         string
+	TaskConfig       datatypes.JSON
+	WindowSize       *int64
+	WindowOffset     *int64
+	WindowTruncateTo *string
+	Assets           datatypes.JSON
+	Hooks            datatypes.JSON
+
+	// left out when empty to keep checksums of older revisions
+	ExternalDependencies datatypes.JSON `json:",omitempty"`
+}
+
+// revisionContent serializes the spec of a job along with its checksum
+func revisionContent(job Job) ([]byte, string, error) {
+	content, err := json.Marshal(jobRevisionSpec{
+		Version:          job.Version,
+		Name:             job.Name,
+		Owner:            job.Owner,
+		Description:      job.Description,
+		Labels:           job.Labels,
+		StartDate:        job.StartDate.UTC(),
+		EndDate:          job.EndDate,
+		Interval:         job.Interval,
+		Destination:      job.Destination,
+		Destinations:     job.Destinations,
+		Dependencies:     job.Dependencies,
+		Behavior:         job.Behavior,
+		TaskName:         job.TaskName,
+		TaskConfig:       job.TaskConfig,
+		WindowSize:       job.WindowSize,
+		WindowOffset:     job.WindowOffset,
+		WindowTruncateTo: job.WindowTruncateTo,
+		Assets:           job.Assets,
+		Hooks:            job.Hooks,
+
+		ExternalDependencies: job.ExternalDependencies,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return content, fmt.Sprintf("%x", sha256.Sum256(content)), nil
+}
+
+func (r JobRevision) ToSpec(adapter *JobSpecAdapter) (models.JobSpecRevision, error) {
+	var content jobRevisionSpec
+	if err := json.Unmarshal(r.Spec, &content); err != nil {
+		return models.JobSpecRevision{}, err
+	}
+
+	jobSpec, err := adapter.ToSpec(Job{
+		Version:          content.Version,
+		Name:             content.Name,
+		Owner:            content.Owner,
+		Description:      content.Description,
+		Labels:           content.Labels,
+		StartDate:        content.StartDate,
+		EndDate:          content.EndDate,
+		Interval:         content.Interval,
+		Destination:      content.Destination,
+		Destinations:     content.Destinations,
+		Dependencies:     content.Dependencies,
+		Behavior:         content.Behavior,
+		TaskName:         content.TaskName,
+		TaskConfig:       content.TaskConfig,
+		WindowSize:       content.WindowSize,
+		WindowOffset:     content.WindowOffset,
+		WindowTruncateTo: content.WindowTruncateTo,
+		Assets:           content.Assets,
+		Hooks:            content.Hooks,
+
+		ExternalDependencies: content.ExternalDependencies,
+	})
+	if err != nil {
+		return models.JobSpecRevision{}, err
+	}
+
+	return models.JobSpecRevision{
+		Revision:  r.Revision,
+		CreatedAt: r.CreatedAt,
+		Spec:      jobSpec,
+	}, nil
+}
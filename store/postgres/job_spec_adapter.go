@@ -23,10 +23,14 @@ type Job struct {
 	StartDate    time.Time
 	EndDate      *time.Time
 	Interval     string
+	Timezone     string
 	Destination  string
+	Destinations datatypes.JSON
 	Dependencies datatypes.JSON
 	Behavior     datatypes.JSON
 
+	ExternalDependencies datatypes.JSON
+
 	ProjectID uuid.UUID
 	Project   Project `gorm:"foreignKey:ProjectID"`
 
@@ -52,6 +56,11 @@ type JobBehavior struct {
 	CatchUp       bool
 	Retry         JobBehaviorRetry
 	Notify        []JobBehaviorNotifier
+	SLA           JobBehaviorSLA
+}
+
+type JobBehaviorSLA struct {
+	Duration int64
 }
 
 type JobBehaviorRetry struct {
@@ -157,6 +166,13 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 		return models.JobSpec{}, err
 	}
 
+	externalDependencies := models.ExternalDependency{}
+	if conf.ExternalDependencies != nil {
+		if err := json.Unmarshal(conf.ExternalDependencies, &externalDependencies); err != nil {
+			return models.JobSpec{}, err
+		}
+	}
+
 	// prep task conf
 	taskConf := models.JobSpecConfigs{}
 	if err := json.Unmarshal(conf.TaskConfig, &taskConf); err != nil {
@@ -212,6 +228,7 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 			StartDate: conf.StartDate,
 			EndDate:   conf.EndDate,
 			Interval:  conf.Interval,
+			Timezone:  conf.Timezone,
 		},
 		Behavior: models.JobSpecBehavior{
 			DependsOnPast: behavior.DependsOnPast,
@@ -222,6 +239,9 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 				ExponentialBackoff: behavior.Retry.ExponentialBackoff,
 			},
 			Notify: notifiers,
+			SLA: models.JobSpecSLA{
+				Duration: time.Duration(behavior.SLA.Duration),
+			},
 		},
 		Task: models.JobSpecTask{
 			Unit:   execUnit,
@@ -232,9 +252,10 @@ func (adapt JobSpecAdapter) ToSpec(conf Job) (models.JobSpec, error) {
 				TruncateTo: *conf.WindowTruncateTo,
 			},
 		},
-		Assets:       *(models.JobAssets{}).New(jobAssets),
-		Dependencies: dependencies,
-		Hooks:        jobHooks,
+		Assets:               *(models.JobAssets{}).New(jobAssets),
+		Dependencies:         dependencies,
+		Hooks:                jobHooks,
+		ExternalDependencies: externalDependencies,
 	}
 	return job, nil
 }
@@ -268,6 +289,9 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 			ExponentialBackoff: spec.Behavior.Retry.ExponentialBackoff,
 		},
 		Notify: notifiers,
+		SLA: JobBehaviorSLA{
+			Duration: spec.Behavior.SLA.Duration.Nanoseconds(),
+		},
 	})
 	if err != nil {
 		return Job{}, err
@@ -284,6 +308,15 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 		return Job{}, err
 	}
 
+	// jobs without external dependencies leave the column empty
+	var externalDependenciesJSON datatypes.JSON
+	if len(spec.ExternalDependencies.HTTPDependencies) > 0 {
+		externalDependenciesJSON, err = json.Marshal(spec.ExternalDependencies)
+		if err != nil {
+			return Job{}, err
+		}
+	}
+
 	// prep task config
 	taskConfigJSON, err := json.Marshal(spec.Task.Config)
 	if err != nil {
@@ -328,6 +361,10 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 	if err != nil {
 		return Job{}, err
 	}
+	destinationsJSON, err := json.Marshal(jobDestination.URNs())
+	if err != nil {
+		return Job{}, err
+	}
 
 	return Job{
 		ID:               spec.ID,
@@ -339,8 +376,10 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 		StartDate:        spec.Schedule.StartDate,
 		EndDate:          spec.Schedule.EndDate,
 		Interval:         spec.Schedule.Interval,
+		Timezone:         spec.Schedule.Timezone,
 		Behavior:         behaviorJSON,
-		Destination:      jobDestination.Destination,
+		Destination:      jobDestination.URN(),
+		Destinations:     destinationsJSON,
 		Dependencies:     dependenciesJSON,
 		TaskName:         taskSchema.Name,
 		TaskConfig:       taskConfigJSON,
@@ -349,6 +388,8 @@ func (adapt JobSpecAdapter) FromSpec(spec models.JobSpec) (Job, error) {
 		WindowTruncateTo: &spec.Task.Window.TruncateTo,
 		Assets:           assetsJSON,
 		Hooks:            hooksJSON,
+
+		ExternalDependencies: externalDependenciesJSON,
 	}, nil
 }
 
@@ -1,7 +1,7 @@
 package postgres
 
 import (
-	"fmt"
+	"encoding/json"
 
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
@@ -65,7 +65,7 @@ func (repo *ProjectJobSpecRepository) GetAll() ([]models.JobSpec, error) {
 
 func (repo *ProjectJobSpecRepository) GetByDestination(destination string) (models.JobSpec, models.ProjectSpec, error) {
 	var r Job
-	if err := repo.db.Preload("Project").Where("destination = ?", destination).Find(&r).Error; err != nil {
+	if err := repo.db.Preload("Project").Where("destinations @> ?::jsonb", destinationFilter(destination)).Find(&r).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return models.JobSpec{}, models.ProjectSpec{}, store.ErrResourceNotFound
 		}
@@ -85,6 +85,37 @@ func (repo *ProjectJobSpecRepository) GetByDestination(destination string) (mode
 	return jSpec, pSpec, err
 }
 
+func (repo *ProjectJobSpecRepository) GetAllByDestination(destination string) ([]models.ProjectJobPair, error) {
+	var jobs []Job
+	if err := repo.db.Preload("Project").Where("destinations @> ?::jsonb", destinationFilter(destination)).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	var pairs []models.ProjectJobPair
+	for _, job := range jobs {
+		jSpec, err := repo.adapter.ToSpec(job)
+		if err != nil {
+			return nil, err
+		}
+		pSpec, err := job.Project.ToSpec()
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, models.ProjectJobPair{
+			Project: pSpec,
+			Job:     jSpec,
+		})
+	}
+	return pairs, nil
+}
+
+// destinationFilter matches jobs having the destination among
+// all the destinations they write to
+func destinationFilter(destination string) string {
+	filter, _ := json.Marshal([]string{destination})
+	return string(filter)
+}
+
 type JobSpecRepository struct {
 	db                 *gorm.DB
 	namespace          models.NamespaceSpec
@@ -100,11 +131,16 @@ func (repo *JobSpecRepository) Insert(spec models.JobSpec) error {
 	if len(resource.Name) == 0 {
 		return errors.New("name cannot be empty")
 	}
-	// if soft deleted earlier
-	if err := repo.HardDelete(spec.Name); err != nil {
-		return err
-	}
-	return repo.db.Create(&resource).Error
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		// if soft deleted earlier
+		if err := repo.hardDelete(tx, spec.Name); err != nil {
+			return err
+		}
+		if err := tx.Create(&resource).Error; err != nil {
+			return err
+		}
+		return repo.saveRevision(tx, resource)
+	})
 }
 
 func (repo *JobSpecRepository) Save(spec models.JobSpec) error {
@@ -117,7 +153,7 @@ func (repo *JobSpecRepository) Save(spec models.JobSpec) error {
 	}
 
 	if namespaceSpec.ID != repo.namespace.ID {
-		return errors.New(fmt.Sprintf("job %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name))
+		return errors.Wrapf(store.ErrResourceAlreadyExists, "job %s in another namespace of the project %s", spec.Name, repo.namespace.ProjectSpec.Name)
 	}
 
 	resource, err := repo.adapter.FromSpec(spec)
@@ -126,7 +162,84 @@ func (repo *JobSpecRepository) Save(spec models.JobSpec) error {
 	}
 	resource.ID = existingJobSpec.ID
 
-	return repo.db.Model(resource).Updates(resource).Error
+	// updated row stays locked till the revision is added, concurrent
+	// saves of the job can't pick the same revision number
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(resource).Updates(resource).Error; err != nil {
+			return err
+		}
+		// Updates skips empty fields, clear external dependencies removed from spec
+		if resource.ExternalDependencies == nil {
+			if err := tx.Model(resource).Update("external_dependencies", nil).Error; err != nil {
+				return err
+			}
+		}
+		return repo.saveRevision(tx, resource)
+	})
+}
+
+// saveRevision records the job as its latest revision unless the spec
+// is the same as the one of current latest revision. Revisions are kept
+// per job name of the project, it should run in the transaction saving
+// the job
+func (repo *JobSpecRepository) saveRevision(tx *gorm.DB, resource Job) error {
+	content, checksum, err := revisionContent(resource)
+	if err != nil {
+		return errors.Wrapf(err, "failed to serialize revision of %s", resource.Name)
+	}
+
+	var latest JobRevision
+	err = tx.Where("project_id = ? AND name = ?", repo.namespace.ProjectSpec.ID, resource.Name).
+		Order("revision desc").First(&latest).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.Wrapf(err, "failed to fetch latest revision of %s", resource.Name)
+	}
+	if err == nil && latest.Checksum == checksum {
+		return nil
+	}
+
+	return tx.Create(&JobRevision{
+		ID:          uuid.Must(uuid.NewRandom()),
+		ProjectID:   repo.namespace.ProjectSpec.ID,
+		NamespaceID: repo.namespace.ID,
+		Name:        resource.Name,
+		Revision:    latest.Revision + 1,
+		Checksum:    checksum,
+		Spec:        content,
+	}).Error
+}
+
+func (repo *JobSpecRepository) GetRevisions(name string) ([]models.JobSpecRevision, error) {
+	var revisions []JobRevision
+	if err := repo.db.Where("project_id = ? AND name = ?", repo.namespace.ProjectSpec.ID, name).
+		Order("revision desc").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, store.ErrResourceNotFound
+	}
+
+	var specs []models.JobSpecRevision
+	for _, revision := range revisions {
+		adapt, err := revision.ToSpec(repo.adapter)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, adapt)
+	}
+	return specs, nil
+}
+
+func (repo *JobSpecRepository) GetRevision(name string, revision int) (models.JobSpecRevision, error) {
+	var r JobRevision
+	if err := repo.db.Where("project_id = ? AND name = ? AND revision = ?", repo.namespace.ProjectSpec.ID, name, revision).
+		Find(&r).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.JobSpecRevision{}, store.ErrResourceNotFound
+		}
+		return models.JobSpecRevision{}, err
+	}
+	return r.ToSpec(repo.adapter)
 }
 
 func (repo *JobSpecRepository) GetByID(id uuid.UUID) (models.JobSpec, error) {
@@ -158,19 +271,23 @@ func (repo *JobSpecRepository) Delete(name string) error {
 }
 
 func (repo *JobSpecRepository) HardDelete(name string) error {
+	return repo.hardDelete(repo.db, name)
+}
+
+func (repo *JobSpecRepository) hardDelete(db *gorm.DB, name string) error {
 	//find the base job
 	var r Job
-	if err := repo.db.Unscoped().Where("project_id = ? AND name = ?", repo.namespace.ProjectSpec.ID, name).Find(&r).Error; err == gorm.ErrRecordNotFound {
+	if err := db.Unscoped().Where("project_id = ? AND name = ?", repo.namespace.ProjectSpec.ID, name).Find(&r).Error; err == gorm.ErrRecordNotFound {
 		// no job exists, inserting for the first time
 		return nil
 	} else if err != nil {
 		return errors.Wrap(err, "failed to fetch soft deleted resource")
 	}
 	// cascade delete instances
-	if err := repo.db.Unscoped().Where("job_id = ?", r.ID).Delete(&Instance{}).Error; err != nil {
+	if err := db.Unscoped().Where("job_id = ?", r.ID).Delete(&Instance{}).Error; err != nil {
 		return errors.Wrap(err, "failed to cascade delete instances for the job")
 	}
-	return repo.db.Unscoped().Where("id = ?", r.ID).Delete(&Job{}).Error
+	return db.Unscoped().Where("id = ?", r.ID).Delete(&Job{}).Error
 }
 
 func (repo *JobSpecRepository) GetAll() ([]models.JobSpec, error) {
@@ -190,6 +307,24 @@ func (repo *JobSpecRepository) GetAll() ([]models.JobSpec, error) {
 	return specs, nil
 }
 
+func (repo *JobSpecRepository) GetPage(after string, limit int) ([]models.JobSpec, error) {
+	specs := []models.JobSpec{}
+	jobs := []Job{}
+	if err := repo.db.Where("namespace_id = ? AND name > ?", repo.namespace.ID, after).
+		Order("name").Limit(limit).Find(&jobs).Error; err != nil {
+		return specs, err
+	}
+
+	for _, job := range jobs {
+		adapt, err := repo.adapter.ToSpec(job)
+		if err != nil {
+			return specs, err
+		}
+		specs = append(specs, adapt)
+	}
+	return specs, nil
+}
+
 func NewJobSpecRepository(db *gorm.DB, namespace models.NamespaceSpec, projectJobSpecRepo store.ProjectJobSpecRepository, adapter *JobSpecAdapter) *JobSpecRepository {
 	return &JobSpecRepository{
 		db:                 db,
@@ -12,6 +12,7 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/odpf/optimus/mock"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -462,7 +463,8 @@ func TestJobRepository(t *testing.T) {
 			// try to create same job with second namespace and it should fail.
 			err = jobRepoNamespace2.Save(testModelA)
 			assert.NotNil(t, err)
-			assert.Equal(t, "job g-optimus-id already exists for the project t-optimus-id", err.Error())
+			assert.Equal(t, "job g-optimus-id in another namespace of the project t-optimus-id: resource already exists", err.Error())
+			assert.ErrorIs(t, err, store.ErrResourceAlreadyExists)
 		})
 		t.Run("should properly insert spec behavior, reading and writing", func(t *testing.T) {
 			db := DBSetup()
@@ -500,6 +502,128 @@ func TestJobRepository(t *testing.T) {
 			assert.Equal(t, false, checkModel.Behavior.CatchUp)
 			assert.Equal(t, true, checkModel.Behavior.DependsOnPast)
 		})
+		t.Run("should save external dependencies and clear them once removed", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			testModelA := testConfigs[0]
+			testModelA.ExternalDependencies = models.ExternalDependency{
+				HTTPDependencies: []models.HTTPDependency{
+					{
+						Name:           "inventory-api",
+						URL:            "https://inventory.example.io/ready",
+						Method:         "GET",
+						Headers:        map[string]string{"Authorization": "Bearer token"},
+						ExpectedStatus: 200,
+					},
+				},
+			}
+
+			unitData1 := models.GenerateTaskDestinationRequest{Config: models.TaskPluginConfigs{}.FromJobSpec(testConfigs[0].Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(testConfigs[0].Assets)}
+			execUnit1.On("GenerateTaskDestination", context.TODO(), unitData1).Return(models.GenerateTaskDestinationResponse{Destination: destination}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+			repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+			err := repo.Save(testModelA)
+			assert.Nil(t, err)
+
+			checkModel, err := repo.GetByID(testModelA.ID)
+			assert.Nil(t, err)
+			assert.Equal(t, testModelA.ExternalDependencies, checkModel.ExternalDependencies)
+
+			testModelA.ExternalDependencies = models.ExternalDependency{}
+			err = repo.Save(testModelA)
+			assert.Nil(t, err)
+
+			checkModel, err = repo.GetByID(testModelA.ID)
+			assert.Nil(t, err)
+			assert.Empty(t, checkModel.ExternalDependencies.HTTPDependencies)
+		})
+	})
+
+	t.Run("GetRevisions", func(t *testing.T) {
+		t.Run("should add a revision only when saved spec changes", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			testModelA := testConfigs[0]
+			testModelA.Owner = "optimus"
+
+			unitData1 := models.GenerateTaskDestinationRequest{Config: models.TaskPluginConfigs{}.FromJobSpec(testConfigs[0].Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(testConfigs[0].Assets)}
+			execUnit1.On("GenerateTaskDestination", context.TODO(), unitData1).Return(models.GenerateTaskDestinationResponse{Destination: destination}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+			repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+			err := repo.Save(testModelA)
+			assert.Nil(t, err)
+			// saving the same spec again is not a new revision
+			err = repo.Save(testModelA)
+			assert.Nil(t, err)
+
+			testModelA.Owner = "optimus-2"
+			testModelA.Task.Window.Offset = time.Hour * 2
+			err = repo.Save(testModelA)
+			assert.Nil(t, err)
+
+			revisions, err := repo.GetRevisions(testModelA.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(revisions))
+			assert.Equal(t, 2, revisions[0].Revision)
+			assert.Equal(t, "optimus-2", revisions[0].Spec.Owner)
+			assert.Equal(t, time.Hour*2, revisions[0].Spec.Task.Window.Offset)
+			assert.Equal(t, 1, revisions[1].Revision)
+			assert.Equal(t, "optimus", revisions[1].Spec.Owner)
+			assert.Equal(t, time.Duration(0), revisions[1].Spec.Task.Window.Offset)
+			assert.Equal(t, "this", revisions[1].Spec.Task.Config[0].Value)
+
+			revision, err := repo.GetRevision(testModelA.Name, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, revisions[1].Spec.Owner, revision.Spec.Owner)
+			assert.Equal(t, "query.sql", revision.Spec.Assets.GetAll()[0].Name)
+		})
+		t.Run("should keep numbering revisions of a job moved to another namespace", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			testModelA := testConfigs[0]
+
+			unitData1 := models.GenerateTaskDestinationRequest{Config: models.TaskPluginConfigs{}.FromJobSpec(testConfigs[0].Task.Config), Assets: models.TaskPluginAssets{}.FromJobSpec(testConfigs[0].Assets)}
+			execUnit1.On("GenerateTaskDestination", context.TODO(), unitData1).Return(models.GenerateTaskDestinationResponse{Destination: destination}, nil)
+			defer execUnit1.AssertExpectations(t)
+
+			projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+			jobRepoNamespace1 := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+			jobRepoNamespace2 := NewJobSpecRepository(db, namespaceSpec2, projectJobSpecRepo, adapter)
+
+			err := jobRepoNamespace1.Save(testModelA)
+			assert.Nil(t, err)
+			err = jobRepoNamespace1.Delete(testModelA.Name)
+			assert.Nil(t, err)
+
+			testModelA.Task.Window.Offset = time.Hour * 2
+			err = jobRepoNamespace2.Save(testModelA)
+			assert.Nil(t, err)
+
+			revisions, err := jobRepoNamespace2.GetRevisions(testModelA.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, 2, len(revisions))
+			assert.Equal(t, 2, revisions[0].Revision)
+			assert.Equal(t, time.Hour*2, revisions[0].Spec.Task.Window.Offset)
+		})
+		t.Run("should return not found for a job without revisions", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+
+			projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+			repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+
+			_, err := repo.GetRevisions("unknown-job")
+			assert.Equal(t, store.ErrResourceNotFound, err)
+
+			_, err = repo.GetRevision("unknown-job", 1)
+			assert.Equal(t, store.ErrResourceNotFound, err)
+		})
 	})
 
 	t.Run("GetByName", func(t *testing.T) {
@@ -538,6 +662,29 @@ func TestJobRepository(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, 2, len(checkModels))
 	})
+	t.Run("GetPage", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+		repo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+		assert.Nil(t, repo.Insert(testConfigs[2]))
+		assert.Nil(t, repo.Insert(testConfigs[0]))
+
+		firstPage, err := repo.GetPage("", 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(firstPage))
+		assert.Equal(t, "g-optimus-id", firstPage[0].Name)
+
+		secondPage, err := repo.GetPage(firstPage[0].Name, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(secondPage))
+		assert.Equal(t, "t-optimus-id", secondPage[0].Name)
+
+		lastPage, err := repo.GetPage(secondPage[0].Name, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(lastPage))
+	})
 }
 
 func TestProjectJobRepository(t *testing.T) {
@@ -727,7 +874,7 @@ func TestProjectJobRepository(t *testing.T) {
 			Assets: models.TaskPluginAssets{}.FromJobSpec(testConfigs[0].Assets),
 		}
 		execUnit1.On("GenerateTaskDestination", context.TODO(), unitData1).Return(
-			models.GenerateTaskDestinationResponse{Destination: destination}, nil)
+			models.GenerateTaskDestinationResponse{Destination: destination, Type: models.DestinationTypeBigquery}, nil)
 		defer execUnit1.AssertExpectations(t)
 		defer execUnit2.AssertExpectations(t)
 
@@ -739,9 +886,54 @@ func TestProjectJobRepository(t *testing.T) {
 		err := jobRepo.Insert(testModels[0])
 		assert.Nil(t, err)
 
-		j, p, err := projectJobSpecRepo.GetByDestination(destination)
+		j, p, err := projectJobSpecRepo.GetByDestination("bigquery://" + destination)
 		assert.Nil(t, err)
 		assert.Equal(t, testConfigs[0].Name, j.Name)
 		assert.Equal(t, projectSpec.Name, p.Name)
 	})
+
+	t.Run("GetByDestination should match any of the destinations written by a job", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		fTask := "f-task"
+		fanOutUnit := new(mock.TaskPlugin)
+		fanOutUnit.On("GetTaskSchema", context.Background(), models.GetTaskSchemaRequest{}).Return(models.GetTaskSchemaResponse{
+			Name: fTask,
+		}, nil)
+		allTasksRepo.On("GetByName", fTask).Return(fanOutUnit, nil)
+
+		fanOutJobSpec := models.JobSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "fan-out-optimus-id",
+			Task: models.JobSpecTask{
+				Unit: fanOutUnit,
+			},
+		}
+		fanOutUnit.On("GenerateTaskDestination", context.TODO(), models.GenerateTaskDestinationRequest{
+			Config: models.TaskPluginConfigs{}.FromJobSpec(fanOutJobSpec.Task.Config),
+			Assets: models.TaskPluginAssets{}.FromJobSpec(fanOutJobSpec.Assets),
+		}).Return(models.GenerateTaskDestinationResponse{
+			Destination:            "p.d.first",
+			AdditionalDestinations: []string{"p.d.second"},
+		}, nil)
+		defer fanOutUnit.AssertExpectations(t)
+
+		projectJobSpecRepo := NewProjectJobSpecRepository(db, projectSpec, adapter)
+		jobRepo := NewJobSpecRepository(db, namespaceSpec, projectJobSpecRepo, adapter)
+		err := jobRepo.Insert(fanOutJobSpec)
+		assert.Nil(t, err)
+
+		for _, urn := range []string{"bigquery://p.d.first", "bigquery://p.d.second"} {
+			j, p, err := projectJobSpecRepo.GetByDestination(urn)
+			assert.Nil(t, err)
+			assert.Equal(t, fanOutJobSpec.Name, j.Name)
+			assert.Equal(t, projectSpec.Name, p.Name)
+
+			pairs, err := projectJobSpecRepo.GetAllByDestination(urn)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, len(pairs))
+			assert.Equal(t, fanOutJobSpec.Name, pairs[0].Job.Name)
+		}
+	})
 }
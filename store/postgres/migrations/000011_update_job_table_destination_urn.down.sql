@@ -0,0 +1 @@
+UPDATE job SET destination = substring(destination from 12) WHERE destination LIKE 'bigquery://%';
@@ -0,0 +1 @@
+UPDATE job SET destination = 'bigquery://' || destination WHERE destination IS NOT NULL AND destination != '' AND destination NOT LIKE '%://%';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS job_destinations_idx;
+ALTER TABLE job DROP IF EXISTS destinations;
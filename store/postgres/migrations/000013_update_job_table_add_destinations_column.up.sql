@@ -0,0 +1,3 @@
+ALTER TABLE job ADD IF NOT EXISTS destinations JSONB;
+UPDATE job SET destinations = jsonb_build_array(destination) WHERE destination IS NOT NULL AND destination != '';
+CREATE INDEX IF NOT EXISTS job_destinations_idx ON job USING GIN (destinations);
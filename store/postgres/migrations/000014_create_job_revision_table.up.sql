@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS job_revision (
+   id UUID PRIMARY KEY NOT NULL,
+   project_id UUID NOT NULL REFERENCES project (id),
+   namespace_id UUID NOT NULL REFERENCES namespace (id),
+
+   name VARCHAR(250) NOT NULL,
+   revision INTEGER NOT NULL,
+   checksum VARCHAR(64) NOT NULL,
+   spec JSONB NOT NULL,
+
+   created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+   UNIQUE (project_id, name, revision)
+);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS backup (
+   id UUID PRIMARY KEY NOT NULL,
+   namespace_id UUID NOT NULL REFERENCES namespace (id),
+   datastore VARCHAR(100) NOT NULL,
+
+   resource_id UUID,
+   resource_name VARCHAR(250) NOT NULL,
+   resource_type VARCHAR(100) NOT NULL,
+   resource_version INTEGER,
+   spec BYTEA,
+   assets JSONB,
+   labels JSONB,
+
+   urn VARCHAR(1024) NOT NULL,
+   description TEXT,
+   expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+   created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+   updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS backup_namespace_id_idx ON backup (namespace_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS job_event (
+   id UUID PRIMARY KEY NOT NULL,
+   namespace_id UUID NOT NULL REFERENCES namespace (id),
+
+   job_id UUID,
+   job_name VARCHAR(220) NOT NULL,
+   type VARCHAR(100) NOT NULL,
+   value JSONB,
+
+   created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS job_event_namespace_id_job_name_idx ON job_event (namespace_id, job_name);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS job_checksum (
+   namespace_id UUID NOT NULL REFERENCES namespace (id),
+   job_name VARCHAR(220) NOT NULL,
+   checksum VARCHAR(64) NOT NULL,
+
+   updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+   PRIMARY KEY (namespace_id, job_name)
+);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS audit_event (
+   id UUID PRIMARY KEY NOT NULL,
+
+   method VARCHAR(220) NOT NULL,
+   identity VARCHAR(220),
+   user_agent VARCHAR(220),
+   peer VARCHAR(220),
+   request_id VARCHAR(100),
+
+   project_name VARCHAR(100),
+   namespace VARCHAR(100),
+
+   request_digest VARCHAR(64) NOT NULL,
+   code VARCHAR(40) NOT NULL,
+   message TEXT,
+
+   created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE INDEX IF NOT EXISTS audit_event_project_name_created_at_idx ON audit_event (project_name, created_at);
+CREATE INDEX IF NOT EXISTS audit_event_created_at_idx ON audit_event (created_at);
@@ -18,6 +18,9 @@ type Project struct {
 	Name   string    `gorm:"not null;unique"`
 	Config datatypes.JSON
 
+	// Scheduler is the typed scheduler configuration of the project
+	Scheduler datatypes.JSON
+
 	// Secrets are read only and will not be saved by updating it here
 	Secrets []Secret
 
@@ -26,15 +29,44 @@ type Project struct {
 	DeletedAt *time.Time
 }
 
+type ProjectScheduler struct {
+	Type       string
+	Host       string
+	AuthSecret string
+}
+
 func (p Project) FromSpec(spec models.ProjectSpec) (Project, error) {
 	jsonBytes, err := json.Marshal(spec.Config)
 	if err != nil {
 		return Project{}, nil
 	}
+	schedulerBytes, err := json.Marshal(ProjectScheduler{
+		Type:       spec.Scheduler.Type,
+		Host:       spec.Scheduler.Host,
+		AuthSecret: spec.Scheduler.AuthSecret,
+	})
+	if err != nil {
+		return Project{}, err
+	}
 	return Project{
-		ID:     spec.ID,
-		Name:   spec.Name,
-		Config: jsonBytes,
+		ID:        spec.ID,
+		Name:      spec.Name,
+		Config:    jsonBytes,
+		Scheduler: schedulerBytes,
+	}, nil
+}
+
+func (p Project) schedulerSpec() (models.ProjectSchedulerConfig, error) {
+	schd := ProjectScheduler{}
+	if p.Scheduler != nil {
+		if err := json.Unmarshal(p.Scheduler, &schd); err != nil {
+			return models.ProjectSchedulerConfig{}, err
+		}
+	}
+	return models.ProjectSchedulerConfig{
+		Type:       schd.Type,
+		Host:       schd.Host,
+		AuthSecret: schd.AuthSecret,
 	}, nil
 }
 
@@ -43,10 +75,15 @@ func (p Project) ToSpec() (models.ProjectSpec, error) {
 	if err := json.Unmarshal(p.Config, &conf); err != nil {
 		return models.ProjectSpec{}, nil
 	}
+	schd, err := p.schedulerSpec()
+	if err != nil {
+		return models.ProjectSpec{}, err
+	}
 	return models.ProjectSpec{
-		ID:     p.ID,
-		Name:   p.Name,
-		Config: conf,
+		ID:        p.ID,
+		Name:      p.Name,
+		Config:    conf,
+		Scheduler: schd,
 	}, nil
 }
 
@@ -55,6 +92,10 @@ func (p Project) ToSpecWithSecrets(h models.ApplicationKey) (models.ProjectSpec,
 	if err := json.Unmarshal(p.Config, &conf); err != nil {
 		return models.ProjectSpec{}, nil
 	}
+	schd, err := p.schedulerSpec()
+	if err != nil {
+		return models.ProjectSpec{}, err
+	}
 	specSecrets := models.ProjectSecrets{}
 	for _, sec := range p.Secrets {
 		specSecret, err := sec.ToSpec(h)
@@ -64,10 +105,11 @@ func (p Project) ToSpecWithSecrets(h models.ApplicationKey) (models.ProjectSpec,
 		specSecrets = append(specSecrets, specSecret)
 	}
 	return models.ProjectSpec{
-		ID:     p.ID,
-		Name:   p.Name,
-		Config: conf,
-		Secret: specSecrets,
+		ID:        p.ID,
+		Name:      p.Name,
+		Config:    conf,
+		Scheduler: schd,
+		Secret:    specSecrets,
 	}, nil
 }
 
@@ -140,6 +182,45 @@ func (repo *ProjectRepository) GetAll() ([]models.ProjectSpec, error) {
 	return specs, nil
 }
 
+// Delete hard deletes the project and every row stored under it in a
+// single transaction, nothing of the project is left to be restored
+func (repo *ProjectRepository) Delete(name string) error {
+	var p Project
+	if err := repo.db.Where("name = ?", name).Find(&p).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return store.ErrResourceNotFound
+		}
+		return err
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		jobIDs := tx.Unscoped().Model(&Job{}).Select("id").Where("project_id = ?", p.ID).QueryExpr()
+		namespaceIDs := tx.Unscoped().Model(&Namespace{}).Select("id").Where("project_id = ?", p.ID).QueryExpr()
+		// children go first, rows are referred by foreign keys
+		for _, rows := range []struct {
+			model interface{}
+			query string
+			arg   interface{}
+		}{
+			{&Instance{}, "job_id IN (?)", jobIDs},
+			{&Replay{}, "job_id IN (?)", jobIDs},
+			{&JobRevision{}, "project_id = ?", p.ID},
+			{&JobEvent{}, "namespace_id IN (?)", namespaceIDs},
+			{&JobChecksum{}, "namespace_id IN (?)", namespaceIDs},
+			{&Backup{}, "namespace_id IN (?)", namespaceIDs},
+			{&Job{}, "project_id = ?", p.ID},
+			{&Resource{}, "project_id = ?", p.ID},
+			{&Secret{}, "project_id = ?", p.ID},
+			{&Namespace{}, "project_id = ?", p.ID},
+			{&Project{}, "id = ?", p.ID},
+		} {
+			if err := tx.Unscoped().Where(rows.query, rows.arg).Delete(rows.model).Error; err != nil {
+				return errors.Wrapf(err, "failed to delete %s of project", tx.NewScope(rows.model).TableName())
+			}
+		}
+		return nil
+	})
+}
+
 func NewProjectRepository(db *gorm.DB, hash models.ApplicationKey) *ProjectRepository {
 	return &ProjectRepository{
 		db:   db,
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,6 +64,11 @@ func TestProjectRepository(t *testing.T) {
 				"bucket":                  "gs://some_folder-2",
 				transporterKafkaBrokerKey: "10.12.12.12:6668,10.12.12.13:6668",
 			},
+			Scheduler: models.ProjectSchedulerConfig{
+				Type:       "airflow2",
+				Host:       "http://airflow.example.io",
+				AuthSecret: "AIRFLOW_AUTH",
+			},
 		},
 	}
 
@@ -151,6 +157,20 @@ func TestProjectRepository(t *testing.T) {
 			assert.Nil(t, err)
 			assert.Equal(t, "g-optimus", checkModel.Name)
 		})
+		t.Run("should save typed scheduler config of the project", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			testModelA := testConfigs[3]
+
+			repo := NewProjectRepository(db, hash)
+
+			err := repo.Save(testModelA)
+			assert.Nil(t, err)
+
+			checkModel, err := repo.GetByName(testModelA.Name)
+			assert.Nil(t, err)
+			assert.Equal(t, testModelA.Scheduler, checkModel.Scheduler)
+		})
 	})
 	t.Run("GetByName", func(t *testing.T) {
 		db := DBSetup()
@@ -212,4 +232,47 @@ func TestProjectRepository(t *testing.T) {
 		sec, _ = checkModels[1].Secret.GetByName("t2")
 		assert.Equal(t, "v2", sec)
 	})
+	t.Run("Delete", func(t *testing.T) {
+		t.Run("should remove project along with its secrets and namespaces", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+			testModels := []models.ProjectSpec{}
+			testModels = append(testModels, testConfigs...)
+
+			repo := NewProjectRepository(db, hash)
+			assert.Nil(t, repo.Insert(testModels[0]))
+			assert.Nil(t, repo.Insert(testModels[2]))
+
+			err := NewSecretRepository(db, testModels[0], hash).Save(models.ProjectSecretItem{
+				Name:  "t1",
+				Value: "v1",
+			})
+			assert.Nil(t, err)
+			err = NewNamespaceRepository(db, testModels[0], hash).Save(models.NamespaceSpec{
+				ID:   uuid.Must(uuid.NewRandom()),
+				Name: "dev-team-1",
+			})
+			assert.Nil(t, err)
+
+			assert.Nil(t, repo.Delete(testModels[0].Name))
+
+			_, err = repo.GetByName(testModels[0].Name)
+			assert.Equal(t, store.ErrResourceNotFound, err)
+			_, err = repo.GetByName(testModels[2].Name)
+			assert.Nil(t, err)
+
+			// nothing is left behind to block registering the name again
+			assert.Nil(t, repo.Insert(testModels[0]))
+			namespaces, err := NewNamespaceRepository(db, testModels[0], hash).GetAll()
+			assert.Nil(t, err)
+			assert.Empty(t, namespaces)
+		})
+		t.Run("should return not found for unknown project", func(t *testing.T) {
+			db := DBSetup()
+			defer db.Close()
+
+			repo := NewProjectRepository(db, hash)
+			assert.Equal(t, store.ErrResourceNotFound, repo.Delete("unknown"))
+		})
+	})
 }
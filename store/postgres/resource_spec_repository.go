@@ -214,7 +214,7 @@ func (repo *resourceSpecRepository) Save(spec models.ResourceSpec) error {
 	}
 
 	if namespaceSpec.ID != repo.namespace.ID {
-		return errors.New(fmt.Sprintf("resource %s already exists for the project %s", spec.Name, repo.namespace.ProjectSpec.Name))
+		return errors.Wrapf(store.ErrResourceAlreadyExists, "resource %s in another namespace of the project %s", spec.Name, repo.namespace.ProjectSpec.Name)
 	}
 
 	resource, err := Resource{}.FromSpec(spec)
@@ -264,6 +264,23 @@ func (repo *resourceSpecRepository) GetAll() ([]models.ResourceSpec, error) {
 	return specs, nil
 }
 
+func (repo *resourceSpecRepository) GetPage(after string, limit int) ([]models.ResourceSpec, error) {
+	specs := []models.ResourceSpec{}
+	resources := []Resource{}
+	if err := repo.db.Where("namespace_id = ? AND datastore = ? AND name > ?", repo.namespace.ID, repo.datastore.Name(), after).
+		Order("name").Limit(limit).Find(&resources).Error; err != nil {
+		return specs, err
+	}
+	for _, r := range resources {
+		adapted, err := r.ToSpec(repo.datastore)
+		if err != nil {
+			return specs, errors.Wrap(err, "failed to adapt resource")
+		}
+		specs = append(specs, adapted)
+	}
+	return specs, nil
+}
+
 func (repo *resourceSpecRepository) Delete(name string) error {
 	return repo.db.Where("namespace_id = ? AND datastore = ? AND name = ? ", repo.namespace.ID, repo.datastore.Name(), name).Delete(&Resource{}).Error
 }
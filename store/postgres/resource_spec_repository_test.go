@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -243,7 +244,8 @@ func TestResourceSpecRepository(t *testing.T) {
 			// try to create same resource with second client and it should fail.
 			err = resourceSpecNamespace2.Save(testModelA)
 			assert.NotNil(t, err)
-			assert.Equal(t, "resource proj.ttt.test2 already exists for the project t-optimus-project", err.Error())
+			assert.Equal(t, "resource proj.ttt.test2 in another namespace of the project t-optimus-project: resource already exists", err.Error())
+			assert.ErrorIs(t, err, store.ErrResourceAlreadyExists)
 		})
 	})
 
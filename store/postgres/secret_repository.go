@@ -52,7 +52,7 @@ func (p Secret) ToSpec(hash models.ApplicationKey) (models.ProjectSecretItem, er
 	}
 
 	// decrypt secret
-	cleartext, err := cryptopasta.Decrypt(encrypted, hash.GetKey())
+	cleartext, _, err := decryptSecret(encrypted, hash)
 	if err != nil {
 		return models.ProjectSecretItem{}, err
 	}
@@ -64,6 +64,21 @@ func (p Secret) ToSpec(hash models.ApplicationKey) (models.ProjectSecretItem, er
 	}, nil
 }
 
+// decryptSecret decrypts with the current application key and falls back to
+// the previous ones, rotated is true if the current key wasn't the one used
+func decryptSecret(encrypted []byte, hash models.ApplicationKey) (cleartext []byte, rotated bool, err error) {
+	cleartext, err = cryptopasta.Decrypt(encrypted, hash.GetKey())
+	if err == nil {
+		return cleartext, false, nil
+	}
+	for _, prevKey := range hash.GetPreviousKeys() {
+		if prevCleartext, prevErr := cryptopasta.Decrypt(encrypted, prevKey); prevErr == nil {
+			return prevCleartext, true, nil
+		}
+	}
+	return nil, false, err
+}
+
 type secretRepository struct {
 	db      *gorm.DB
 	project models.ProjectSpec
@@ -124,7 +139,7 @@ func (repo *secretRepository) GetByID(id uuid.UUID) (models.ProjectSecretItem, e
 func (repo *secretRepository) GetAll() ([]models.ProjectSecretItem, error) {
 	specs := []models.ProjectSecretItem{}
 	resources := []Secret{}
-	if err := repo.db.Find(&resources).Error; err != nil {
+	if err := repo.db.Where("project_id = ?", repo.project.ID).Find(&resources).Error; err != nil {
 		return specs, err
 	}
 	for _, res := range resources {
@@ -137,6 +152,57 @@ func (repo *secretRepository) GetAll() ([]models.ProjectSecretItem, error) {
 	return specs, nil
 }
 
+// Delete removes the secret permanently so the name can be registered again
+func (repo *secretRepository) Delete(name string) error {
+	res := repo.db.Unscoped().Where("name = ? AND project_id = ?", name, repo.project.ID).Delete(&Secret{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return store.ErrResourceNotFound
+	}
+	return nil
+}
+
+// RotateKey re-encrypts secrets of the project still encrypted with one of
+// the previous application keys using the current key, returns the number
+// of secrets re-encrypted
+func (repo *secretRepository) RotateKey() (int, error) {
+	resources := []Secret{}
+	if err := repo.db.Where("project_id = ?", repo.project.ID).Find(&resources).Error; err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for _, res := range resources {
+		encrypted, err := base64.StdEncoding.DecodeString(res.Value)
+		if err != nil {
+			return rotated, errors.Wrapf(err, "failed to decode secret %s", res.Name)
+		}
+		cleartext, needsRotation, err := decryptSecret(encrypted, repo.hash)
+		if err != nil {
+			return rotated, errors.Wrapf(err, "failed to decrypt secret %s", res.Name)
+		}
+		if !needsRotation {
+			continue
+		}
+
+		updated, err := Secret{}.FromSpec(models.ProjectSecretItem{
+			ID:    res.ID,
+			Name:  res.Name,
+			Value: string(cleartext),
+		}, repo.project, repo.hash)
+		if err != nil {
+			return rotated, errors.Wrapf(err, "failed to encrypt secret %s", res.Name)
+		}
+		if err := repo.db.Model(&res).Update("value", updated.Value).Error; err != nil {
+			return rotated, errors.Wrapf(err, "failed to save secret %s", res.Name)
+		}
+		rotated++
+	}
+	return rotated, nil
+}
+
 func NewSecretRepository(db *gorm.DB, project models.ProjectSpec, hash models.ApplicationKey) *secretRepository {
 	return &secretRepository{
 		db:      db,
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/odpf/optimus/models"
+	"github.com/odpf/optimus/store"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -164,4 +165,74 @@ func TestSecretRepository(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, "g-optimus", checkModel.Name)
 	})
+	t.Run("GetAll", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		otherProjectSpec := models.ProjectSpec{
+			ID:   uuid.Must(uuid.NewRandom()),
+			Name: "t-optimus-other-project",
+		}
+		assert.Nil(t, NewProjectRepository(db, hash).Save(otherProjectSpec))
+
+		repo := NewSecretRepository(db, projectSpec, hash)
+		assert.Nil(t, repo.Insert(testConfigs[0]))
+		otherRepo := NewSecretRepository(db, otherProjectSpec, hash)
+		assert.Nil(t, otherRepo.Insert(testConfigs[2]))
+
+		checkModels, err := repo.GetAll()
+		assert.Nil(t, err)
+		assert.Len(t, checkModels, 1)
+		assert.Equal(t, "g-optimus", checkModels[0].Name)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		repo := NewSecretRepository(db, projectSpec, hash)
+		assert.Nil(t, repo.Insert(testConfigs[0]))
+
+		assert.Nil(t, repo.Delete(testConfigs[0].Name))
+		_, err := repo.GetByName(testConfigs[0].Name)
+		assert.Equal(t, store.ErrResourceNotFound, err)
+
+		// deleted name can be registered again
+		assert.Nil(t, repo.Save(models.ProjectSecretItem{Name: testConfigs[0].Name, Value: "new-secret"}))
+
+		err = repo.Delete("non-existing")
+		assert.Equal(t, store.ErrResourceNotFound, err)
+	})
+	t.Run("RotateKey", func(t *testing.T) {
+		db := DBSetup()
+		defer db.Close()
+
+		oldRepo := NewSecretRepository(db, projectSpec, hash)
+		assert.Nil(t, oldRepo.Insert(testConfigs[0]))
+		assert.Nil(t, oldRepo.Insert(testConfigs[2]))
+
+		rotatedHash, err := models.NewApplicationSecret("32charsnewhashnewhashnewhashnewh", "32charshtesthashtesthashtesthash")
+		assert.Nil(t, err)
+		repo := NewSecretRepository(db, projectSpec, rotatedHash)
+
+		// secrets encrypted with the previous key are still readable
+		checkModel, err := repo.GetByName(testConfigs[0].Name)
+		assert.Nil(t, err)
+		assert.Equal(t, "secret", checkModel.Value)
+
+		rotated, err := repo.RotateKey()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, rotated)
+
+		rotated, err = repo.RotateKey()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, rotated)
+
+		newHash, _ := models.NewApplicationSecret("32charsnewhashnewhashnewhashnewh")
+		checkModel, err = NewSecretRepository(db, projectSpec, newHash).GetByName(testConfigs[2].Name)
+		assert.Nil(t, err)
+		assert.Equal(t, "super-secret", checkModel.Value)
+
+		_, err = oldRepo.GetByName(testConfigs[2].Name)
+		assert.NotNil(t, err)
+	})
 }
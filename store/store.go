@@ -13,6 +13,10 @@ import (
 
 var (
 	ErrResourceNotFound = errors.New("resource not found")
+
+	// ErrResourceAlreadyExists is returned when a spec can't be saved as
+	// its name is already taken by another namespace of the project
+	ErrResourceAlreadyExists = errors.New("resource already exists")
 )
 
 // ProjectJobSpecRepository represents a storage interface for Job specifications at a project level
@@ -20,6 +24,8 @@ type ProjectJobSpecRepository interface {
 	GetByName(string) (models.JobSpec, models.NamespaceSpec, error)
 	GetAll() ([]models.JobSpec, error)
 	GetByDestination(string) (models.JobSpec, models.ProjectSpec, error)
+	// GetAllByDestination returns every job producing the destination across projects
+	GetAllByDestination(string) ([]models.ProjectJobPair, error)
 }
 
 // ProjectRepository represents a storage interface for registered projects
@@ -27,6 +33,9 @@ type ProjectRepository interface {
 	Save(models.ProjectSpec) error
 	GetByName(string) (models.ProjectSpec, error)
 	GetAll() ([]models.ProjectSpec, error)
+	// Delete removes the project along with its secrets, namespaces and
+	// everything stored under them
+	Delete(name string) error
 }
 
 // ProjectSecretRepository stores secrets attached to projects
@@ -34,6 +43,11 @@ type ProjectSecretRepository interface {
 	Save(item models.ProjectSecretItem) error
 	GetByName(string) (models.ProjectSecretItem, error)
 	GetAll() ([]models.ProjectSecretItem, error)
+	Delete(name string) error
+
+	// RotateKey re-encrypts secrets encrypted with a previous application
+	// key, returns the number of secrets re-encrypted
+	RotateKey() (int, error)
 }
 
 // NamespaceRepository represents a storage interface for registered namespaces
@@ -74,9 +88,45 @@ type ResourceSpecRepository interface {
 	Save(models.ResourceSpec) error
 	GetByName(string) (models.ResourceSpec, error)
 	GetAll() ([]models.ResourceSpec, error)
+	// GetPage returns at most limit resources ordered by name, starting
+	// after the resource named after
+	GetPage(after string, limit int) ([]models.ResourceSpec, error)
 	Delete(string) error
 }
 
+// BackupRepository represents a storage interface for backups taken of resources
+// at namespace level
+type BackupRepository interface {
+	Save(models.BackupSpec) error
+	GetByID(uuid.UUID) (models.BackupSpec, error)
+	GetAll() ([]models.BackupSpec, error)
+}
+
+// JobEventRepository represents a storage interface for events posted by
+// scheduler about runs of jobs at namespace level
+type JobEventRepository interface {
+	Save(models.JobSpec, models.JobEvent) error
+	// GetByJobName returns events of the job, latest first
+	GetByJobName(string) ([]models.JobEvent, error)
+}
+
+// JobChecksumRepository keeps checksum of the last compiled job uploaded
+// for every job of a namespace
+type JobChecksumRepository interface {
+	// GetAll returns checksums keyed by job name
+	GetAll(context.Context) (map[string]string, error)
+	Save(ctx context.Context, jobName, checksum string) error
+	Delete(ctx context.Context, jobName string) error
+}
+
+// AuditEventRepository keeps audit events of requests changing state of
+// optimus across projects
+type AuditEventRepository interface {
+	Save(context.Context, models.AuditEvent) error
+	// List returns events matching the filter, latest first
+	List(context.Context, models.AuditEventFilter) ([]models.AuditEvent, error)
+}
+
 // ObjectWriter can be used to write in s3 compatible storage interfaces like
 // aws s3, gcs, digitalocean buckets, etc.
 type ObjectWriter interface {
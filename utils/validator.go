@@ -7,8 +7,8 @@ import (
 
 	"github.com/AlecAivazis/survey/v2"
 
+	"github.com/odpf/optimus/core/cron"
 	"github.com/pkg/errors"
-	"github.com/robfig/cron/v3"
 )
 
 // CronIntervalValidator return a nil value when a valid cron string is passed
@@ -18,7 +18,7 @@ func CronIntervalValidator(val interface{}, param string) error {
 	if !ok {
 		return fmt.Errorf("invalid crontab entry, not a valid string")
 	}
-	if _, err := cron.ParseStandard(value); err != nil {
+	if _, err := cron.ParseCronSchedule(value); err != nil {
 		return errors.Wrap(err, "invalid crontab entry")
 	}
 	return nil